@@ -42,6 +42,8 @@ type IntrospectionConfig struct {
 	Clock              clock.Clock
 	LocalHub           introspection.SimpleHub
 	CentralHub         introspection.StructuredHub
+	SlowQueries        introspection.SlowQueryReporter
+	LeaseReporter      introspection.LeaseReporter
 
 	NewSocketName func(names.Tag) string
 	WorkerFunc    func(config introspection.Config) (worker.Worker, error)
@@ -71,7 +73,8 @@ func StartIntrospection(cfg IntrospectionConfig) error {
 		Clock:              cfg.Clock,
 		LocalHub:           cfg.LocalHub,
 		CentralHub:         cfg.CentralHub,
-		// TODO(leases) - add lease introspection
+		SlowQueries:        cfg.SlowQueries,
+		Leases:             cfg.LeaseReporter,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -153,6 +153,7 @@ type MigrationSummary struct {
 	Status    string
 	StartTime *time.Time
 	EndTime   *time.Time
+	Suspended bool
 }
 
 // SLASummary holds information about SLA.
@@ -18,8 +18,9 @@ import (
 type baseSuite struct {
 	testing.IsolationSuite
 
-	facade    *mocks.MockFacadeCaller
-	apiCaller *mocks.MockAPICallCloser
+	facade       *mocks.MockFacadeCaller
+	apiCaller    *mocks.MockAPICallCloser
+	clientFacade *mocks.MockClientFacade
 }
 
 func (s *baseSuite) setupMocks(c *gc.C) *gomock.Controller {
@@ -27,14 +28,16 @@ func (s *baseSuite) setupMocks(c *gc.C) *gomock.Controller {
 
 	s.facade = mocks.NewMockFacadeCaller(ctrl)
 	s.apiCaller = mocks.NewMockAPICallCloser(ctrl)
+	s.clientFacade = mocks.NewMockClientFacade(ctrl)
 
 	return ctrl
 }
 
 func (s *baseSuite) newClient() *Client {
 	return &Client{
-		facade: s.facade,
-		st:     s.apiCaller,
+		ClientFacade: s.clientFacade,
+		facade:       s.facade,
+		st:           s.apiCaller,
 	}
 }
 
@@ -0,0 +1,39 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List returns the metadata of the backups stored on the controller.
+func (c *Client) List() ([]params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("listing backups on this version of juju")
+	}
+	var result params.BackupsListResult
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.List, nil
+}
+
+// Remove removes the backups with the given file names from the
+// controller.
+func (c *Client) Remove(filenames ...string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("removing backups on this version of juju")
+	}
+	args := params.BackupsRemoveArgs{IDs: filenames}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("Remove", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(filenames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(filenames), len(result.Results))
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type listSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) TestList(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	meta := backupstesting.NewMetadata()
+	result := params.BackupsListResult{
+		List: []params.BackupsMetadataResult{apiserverbackups.CreateResult(meta, "test-filename")},
+	}
+	s.clientFacade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("List", nil, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.HasLen, 1)
+	c.Check(got[0].Filename, gc.Equals, "test-filename")
+	s.checkMetadataResult(c, &got[0], meta)
+}
+
+func (s *listSuite) TestListNotSupported(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.clientFacade.EXPECT().BestAPIVersion().Return(3)
+
+	client := s.newClient()
+	_, err := client.List()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *listSuite) TestRemove(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	args := params.BackupsRemoveArgs{IDs: []string{"one", "two"}}
+	result := params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "boom"}},
+	}}
+	s.clientFacade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Remove", args, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.Remove("one", "two")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got, jc.DeepEquals, result.Results)
+}
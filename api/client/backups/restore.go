@@ -0,0 +1,50 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// Upload sends a backup archive to the controller, returning the file
+// name that it is stored as, ready to be restored.
+func (c *Client) Upload(archive io.Reader) (string, error) {
+	if c.BestAPIVersion() < 4 {
+		return "", errors.NotSupportedf("uploading backups on this version of juju")
+	}
+	req, err := http.NewRequest("PUT", "/backups", archive)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", params.ContentTypeRaw)
+
+	httpClient, err := c.st.HTTPClient()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var result params.BackupsUploadResult
+	if err := httpClient.Do(c.st.Context(), req, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.ID, nil
+}
+
+// Restore loads the backup with the given file name onto the
+// controller, returning the metadata of the backup.
+func (c *Client) Restore(filename string) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("restoring backups on this version of juju")
+	}
+	args := params.BackupsRestoreArgs{ID: filename}
+	var result params.BackupsMetadataResult
+	if err := c.facade.FacadeCall("Restore", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/httprequest.v1"
+
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type restoreSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) TestUpload(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "PUT")
+		c.Check(r.URL.String(), gc.Equals, "/backups")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, params.ContentTypeRaw)
+		body, err := io.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(string(body), gc.Equals, "<archive>")
+		w.Header().Set("Content-Type", params.ContentTypeJSON)
+		_, err = w.Write([]byte(`{"id":"/tmp/juju-backup-upload-1.tar.gz"}`))
+		c.Check(err, jc.ErrorIsNil)
+	}))
+	defer srv.Close()
+	httpClient := &httprequest.Client{BaseURL: srv.URL}
+
+	s.clientFacade.EXPECT().BestAPIVersion().Return(4)
+	s.apiCaller.EXPECT().HTTPClient().Return(httpClient, nil)
+	s.apiCaller.EXPECT().Context().Return(context.TODO())
+
+	client := s.newClient()
+	id, err := client.Upload(strings.NewReader("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "/tmp/juju-backup-upload-1.tar.gz")
+}
+
+func (s *restoreSuite) TestRestore(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	meta := backupstesting.NewMetadata()
+	result := apiserverbackups.CreateResult(meta, "test-filename")
+	s.clientFacade.EXPECT().BestAPIVersion().Return(4)
+	s.facade.EXPECT().FacadeCall("Restore", params.BackupsRestoreArgs{ID: "test-filename"}, gomock.Any()).SetArg(2, result)
+
+	client := s.newClient()
+	got, err := client.Restore("test-filename")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkMetadataResult(c, got, meta)
+}
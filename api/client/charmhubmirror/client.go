@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubmirror
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// UploadResult describes the outcome of uploading an export.
+type UploadResult struct {
+	// Entities is the number of charms and bundles in the export.
+	Entities int
+
+	// BlobsAdded is the number of archives and resources that the mirror
+	// did not already hold.
+	BlobsAdded int
+}
+
+// Client uploads offline charmhub exports to the controller's charmhub
+// mirror.
+type Client struct {
+	st base.APICallCloser
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	return &Client{st: st}
+}
+
+// Upload uploads an export archive, as written by mirror.ArchiveDir, to
+// the controller's charmhub mirror.
+func (c *Client) Upload(r io.ReadSeeker) (UploadResult, error) {
+	req, err := http.NewRequest("POST", "/charmhub-mirror", r)
+	if err != nil {
+		return UploadResult{}, errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", "application/x-tar-gz")
+
+	// The mirror belongs to the controller, not to a model.
+	httpClient, err := c.st.RootHTTPClient()
+	if err != nil {
+		return UploadResult{}, errors.Trace(err)
+	}
+
+	var resp params.CharmhubMirrorImportResult
+	if err := httpClient.Do(c.st.Context(), req, &resp); err != nil {
+		return UploadResult{}, errors.Trace(err)
+	}
+	if resp.Error != nil {
+		return UploadResult{}, apiservererrors.RestoreError(resp.Error)
+	}
+	return UploadResult{
+		Entities:   resp.Entities,
+		BlobsAdded: resp.BlobsAdded,
+	}, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhubmirror_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/httprequest.v1"
+
+	"github.com/juju/juju/api/client/charmhubmirror"
+	"github.com/juju/juju/api/client/charmhubmirror/mocks"
+)
+
+type ClientSuite struct{}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) expectUpload(c *gc.C, ctrl *gomock.Controller, body string) *mocks.MockAPICallCloser {
+	apiCaller := mocks.NewMockAPICallCloser(ctrl)
+	doer := mocks.NewMockDoer(ctrl)
+	ctx := mocks.NewMockContext(ctrl)
+
+	req, err := http.NewRequest("POST", "/charmhub-mirror", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Content-Type", "application/x-tar-gz")
+	req = req.WithContext(ctx)
+
+	resp := &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	resp.Header.Set("Content-Type", "application/json")
+
+	gomock.InOrder(
+		apiCaller.EXPECT().RootHTTPClient().Return(&httprequest.Client{Doer: doer}, nil),
+		apiCaller.EXPECT().Context().Return(ctx),
+		doer.EXPECT().Do(req).Return(resp, nil),
+	)
+	return apiCaller
+}
+
+func (s *ClientSuite) TestUpload(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	apiCaller := s.expectUpload(c, ctrl, `{"entities": 3, "blobs-added": 5}`)
+
+	client := charmhubmirror.NewClient(apiCaller)
+	result, err := client.Upload(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, charmhubmirror.UploadResult{
+		Entities:   3,
+		BlobsAdded: 5,
+	})
+}
+
+func (s *ClientSuite) TestUploadError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	apiCaller := s.expectUpload(c, ctrl, `{"error": {"message": "boom", "code": "not found"}}`)
+
+	client := charmhubmirror.NewClient(apiCaller)
+	_, err := client.Upload(nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
@@ -125,17 +125,9 @@ func (c *Client) statusV6(patterns []string, includeStorage bool) (*params.FullS
 // for <name> unit
 func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
 	var results params.StatusHistoryResults
-	args := params.StatusHistoryRequest{
-		Kind: string(kind),
-		Filter: params.StatusHistoryFilter{
-			Size:    filter.Size,
-			Date:    filter.FromDate,
-			Delta:   filter.Delta,
-			Exclude: filter.Exclude.Values(),
-		},
-		Tag: tag.String(),
-	}
-	bulkArgs := params.StatusHistoryRequests{Requests: []params.StatusHistoryRequest{args}}
+	bulkArgs := params.StatusHistoryRequests{Requests: []params.StatusHistoryRequest{
+		statusHistoryRequest(kind, tag, filter),
+	}}
 	err := c.facade.FacadeCall("StatusHistory", bulkArgs, &results)
 	if err != nil {
 		return status.History{}, errors.Trace(err)
@@ -146,11 +138,74 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 	if results.Results[0].Error != nil {
 		return status.History{}, errors.Annotatef(results.Results[0].Error, "while processing the request")
 	}
-	history := make(status.History, len(results.Results[0].History.Statuses))
 	if results.Results[0].History.Error != nil {
 		return status.History{}, results.Results[0].History.Error
 	}
-	for i, h := range results.Results[0].History.Statuses {
+	return c.statusHistoryFromParams(results.Results[0].History.Statuses), nil
+}
+
+// StatusHistoryRequest identifies the status history of a single
+// entity, for use with StatusHistories.
+type StatusHistoryRequest struct {
+	Kind   status.HistoryKind
+	Tag    names.Tag
+	Filter status.StatusHistoryFilter
+}
+
+// StatusHistoryResult holds the status history of a single entity, or
+// the error encountered fetching it.
+type StatusHistoryResult struct {
+	History status.History
+	Error   error
+}
+
+// StatusHistories retrieves the status history of several entities in
+// a single call. The results are returned in the same order as the
+// requests.
+func (c *Client) StatusHistories(requests ...StatusHistoryRequest) ([]StatusHistoryResult, error) {
+	args := params.StatusHistoryRequests{
+		Requests: make([]params.StatusHistoryRequest, len(requests)),
+	}
+	for i, request := range requests {
+		args.Requests[i] = statusHistoryRequest(request.Kind, request.Tag, request.Filter)
+	}
+	var results params.StatusHistoryResults
+	if err := c.facade.FacadeCall("StatusHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(requests) {
+		return nil, errors.Errorf("expected %d results got %d", len(requests), len(results.Results))
+	}
+	histories := make([]StatusHistoryResult, len(requests))
+	for i, result := range results.Results {
+		switch {
+		case result.Error != nil:
+			histories[i].Error = result.Error
+		case result.History.Error != nil:
+			histories[i].Error = result.History.Error
+		default:
+			histories[i].History = c.statusHistoryFromParams(result.History.Statuses)
+		}
+	}
+	return histories, nil
+}
+
+func statusHistoryRequest(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) params.StatusHistoryRequest {
+	return params.StatusHistoryRequest{
+		Kind: string(kind),
+		Filter: params.StatusHistoryFilter{
+			Size:    filter.Size,
+			Date:    filter.FromDate,
+			Delta:   filter.Delta,
+			Exclude: filter.Exclude.Values(),
+		},
+		Tag: tag.String(),
+	}
+}
+
+func (c *Client) statusHistoryFromParams(statuses []params.DetailedStatus) status.History {
+	history := make(status.History, len(statuses))
+	for i, h := range statuses {
 		history[i] = status.DetailedStatus{
 			Status: status.Status(h.Status),
 			Info:   h.Info,
@@ -163,7 +218,7 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 			c.logger.Errorf("history returned an unknown status kind %q", h.Kind)
 		}
 	}
-	return history, nil
+	return history
 }
 
 // WatchAll returns an AllWatcher, from which you can request the Next
@@ -234,5 +289,10 @@ func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, r
 // WatchDebugLog returns a channel of structured Log Messages. Only log entries
 // that match the filtering specified in the DebugLogParams are returned.
 func (c *Client) WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMessage, error) {
+	if serverVersion, ok := c.conn.ServerVersion(); ok {
+		if err := args.CheckServerVersion(serverVersion); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return common.StreamDebugLog(context.TODO(), c.conn, args)
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -22,6 +23,7 @@ import (
 	basemocks "github.com/juju/juju/api/base/mocks"
 	"github.com/juju/juju/api/client/client"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/rpc/params"
 )
 
@@ -46,6 +48,51 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestStatusHistories(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	args := params.StatusHistoryRequests{Requests: []params.StatusHistoryRequest{{
+		Kind:   "workload",
+		Tag:    "unit-mysql-0",
+		Filter: params.StatusHistoryFilter{Size: 5, Exclude: []string{}},
+	}, {
+		Kind:   "juju-unit",
+		Tag:    "unit-mysql-1",
+		Filter: params.StatusHistoryFilter{Size: 5, Exclude: []string{}},
+	}}}
+	results := params.StatusHistoryResults{Results: []params.StatusHistoryResult{{
+		History: params.History{Statuses: []params.DetailedStatus{{
+			Status: "active",
+			Info:   "ready",
+			Since:  &since,
+			Kind:   "workload",
+		}}},
+	}, {
+		Error: &params.Error{Message: "boom"},
+	}}}
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().FacadeCall("StatusHistory", args, gomock.Any()).SetArg(2, results).Return(nil)
+	cl := client.NewClientFromFacadeCaller(mockFacadeCaller)
+
+	filter := status.StatusHistoryFilter{Size: 5}
+	histories, err := cl.StatusHistories(
+		client.StatusHistoryRequest{Kind: status.KindWorkload, Tag: names.NewUnitTag("mysql/0"), Filter: filter},
+		client.StatusHistoryRequest{Kind: status.KindUnitAgent, Tag: names.NewUnitTag("mysql/1"), Filter: filter},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(histories, gc.HasLen, 2)
+	c.Check(histories[0].Error, jc.ErrorIsNil)
+	c.Check(histories[0].History, jc.DeepEquals, status.History{{
+		Status: status.Active,
+		Info:   "ready",
+		Since:  &since,
+		Kind:   status.KindWorkload,
+	}})
+	c.Check(histories[1].Error, gc.ErrorMatches, "boom")
+}
+
 func (s *clientSuite) TestWebsocketDialWithErrorsJSON(c *gc.C) {
 	errorResult := params.ErrorResult{
 		Error: apiservererrors.ServerError(errors.New("kablooie")),
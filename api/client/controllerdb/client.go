@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Client is the api client for the ControllerDB facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a controller database api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ControllerDB")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// QueryResult holds the columns and rows returned by a query.
+type QueryResult struct {
+	Columns   []string
+	Rows      [][]interface{}
+	Truncated bool
+}
+
+// Query runs a read-only query against the controller database. A zero
+// maxRows or timeout means the controller default is used.
+func (api *Client) Query(query string, maxRows int, timeout time.Duration) (QueryResult, error) {
+	if api.BestAPIVersion() < 1 {
+		return QueryResult{}, errors.NotSupportedf("querying the controller database on this juju version")
+	}
+
+	args := params.DBQueryArgs{
+		Query:   query,
+		MaxRows: maxRows,
+		Timeout: timeout,
+	}
+	var response params.DBQueryResult
+	if err := api.facade.FacadeCall("Query", args, &response); err != nil {
+		return QueryResult{}, errors.Trace(err)
+	}
+	return QueryResult{
+		Columns:   response.Columns,
+		Rows:      response.Rows,
+		Truncated: response.Truncated,
+	}, nil
+}
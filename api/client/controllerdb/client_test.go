@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/client/controllerdb"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&ControllerDBSuite{})
+
+type ControllerDBSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *ControllerDBSuite) TestQuery(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "ControllerDB")
+			c.Check(version, gc.Equals, 1)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(arg, jc.DeepEquals, params.DBQueryArgs{
+				Query:   "SELECT uuid, name FROM cloud",
+				MaxRows: 10,
+				Timeout: time.Minute,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.DBQueryResult{})
+			*(result.(*params.DBQueryResult)) = params.DBQueryResult{
+				Columns:   []string{"uuid", "name"},
+				Rows:      [][]interface{}{{"deadbeef", "lxd"}},
+				Truncated: true,
+			}
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := controllerdb.NewClient(apiCaller)
+	result, err := client.Query("SELECT uuid, name FROM cloud", 10, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, controllerdb.QueryResult{
+		Columns:   []string{"uuid", "name"},
+		Rows:      [][]interface{}{{"deadbeef", "lxd"}},
+		Truncated: true,
+	})
+}
+
+func (s *ControllerDBSuite) TestQueryError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			return errors.New("boom")
+		}),
+		BestVersion: 1,
+	}
+	client := controllerdb.NewClient(apiCaller)
+	_, err := client.Query("SELECT 1", 0, 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,6 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerdb provides the api client
+// for the controllerdb facade.
+package controllerdb
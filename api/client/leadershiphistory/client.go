@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/base"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Client is the api client for the LeadershipHistory facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a leadership history api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "LeadershipHistory")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Entry is a single recorded change to an application's leadership.
+type Entry struct {
+	// Action is one of claim, extend, expire, revoke, pin or unpin.
+	Action string
+
+	// Entity is the leader, or for pins, the entity requiring the pin.
+	Entity string
+
+	// Expiry is when the lease was due to expire following the change.
+	// It is zero when unknown.
+	Expiry time.Time
+
+	// Time is when the change was recorded.
+	Time time.Time
+}
+
+// LeadershipHistory returns the recorded changes to the leadership of the
+// input application, oldest first. If limit is greater than zero, only
+// the most recent limit changes are returned.
+func (api *Client) LeadershipHistory(application string, limit int) ([]Entry, error) {
+	if api.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("leadership history on this juju version")
+	}
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+
+	args := params.LeadershipHistoryArgs{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+		Limit:    limit,
+	}
+	var response params.LeadershipHistoryResults
+	if err := api.facade.FacadeCall("LeadershipHistory", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(response.Results))
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(apiservererrors.RestoreError(result.Error))
+	}
+
+	entries := make([]Entry, len(result.History))
+	for i, h := range result.History {
+		entries[i] = Entry{
+			Action: h.Action,
+			Entity: h.Entity,
+			Time:   h.Time,
+		}
+		if h.Expiry != nil {
+			entries[i].Expiry = *h.Expiry
+		}
+	}
+	return entries, nil
+}
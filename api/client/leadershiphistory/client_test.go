@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/client/leadershiphistory"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&LeadershipHistorySuite{})
+
+type LeadershipHistorySuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistory(c *gc.C) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiry := now.Add(time.Minute)
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "LeadershipHistory")
+			c.Check(version, gc.Equals, 1)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "LeadershipHistory")
+			c.Check(arg, jc.DeepEquals, params.LeadershipHistoryArgs{
+				Entities: []params.Entity{{Tag: "application-redis"}},
+				Limit:    5,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.LeadershipHistoryResults{})
+			*(result.(*params.LeadershipHistoryResults)) = params.LeadershipHistoryResults{
+				Results: []params.LeadershipHistoryResult{{
+					History: []params.LeadershipHistoryEntry{
+						{Action: "claim", Entity: "redis/0", Expiry: &expiry, Time: now},
+						{Action: "pin", Entity: "machine-0", Time: now.Add(time.Second)},
+					},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := leadershiphistory.NewClient(apiCaller)
+	entries, err := client.LeadershipHistory("redis", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []leadershiphistory.Entry{
+		{Action: "claim", Entity: "redis/0", Expiry: expiry, Time: now},
+		{Action: "pin", Entity: "machine-0", Time: now.Add(time.Second)},
+	})
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryResultError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.LeadershipHistoryResults)) = params.LeadershipHistoryResults{
+				Results: []params.LeadershipHistoryResult{{
+					Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 1,
+	}
+	client := leadershiphistory.NewClient(apiCaller)
+	_, err := client.LeadershipHistory("redis", 0)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			return errors.New("boom")
+		}),
+		BestVersion: 1,
+	}
+	client := leadershiphistory.NewClient(apiCaller)
+	_, err := client.LeadershipHistory("redis", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryInvalidApplication(c *gc.C) {
+	client := leadershiphistory.NewClient(testing.BestVersionCaller{BestVersion: 1})
+	_, err := client.LeadershipHistory("redis/0", 0)
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
@@ -270,6 +270,7 @@ func (c *Client) ListModelSummaries(user string, all bool) ([]base.UserModelSumm
 				Status:    summary.Migration.Status,
 				StartTime: summary.Migration.Start,
 				EndTime:   summary.Migration.End,
+				Suspended: summary.Migration.Suspended,
 			}
 		}
 		if summary.SLA != nil {
@@ -337,6 +338,32 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 	return asMap, nil
 }
 
+// ExportModel exports the model, along with the details of the charms,
+// agent binaries and resources it uses, so that it can be archived and
+// later imported into another controller. The result is left in its wire
+// representation, which is suitable for recording in the archive.
+func (c *Client) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	if c.facade.BestAPIVersion() < 11 {
+		return params.SerializedModel{}, errors.NotSupportedf("exporting models on this version of juju")
+	}
+	var results params.SerializedModelResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	err := c.facade.FacadeCall("ExportModels", args, &results)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return params.SerializedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.SerializedModel{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // DumpModelDB returns all relevant mongo documents for the model.
 func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, error) {
 	var results params.MapResults
@@ -666,3 +666,64 @@ func (s *dumpModelSuite) TestDumpModelDBError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fake error")
 	c.Assert(out, gc.IsNil)
 }
+
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	serialized := params.SerializedModel{
+		Bytes:  []byte("model-uuid: some-uuid\n"),
+		Charms: []string{"ch:amd64/mysql-3"},
+		Tools: []params.SerializedModelTools{{
+			Version: "3.4.0-ubuntu-amd64",
+			URI:     "/tools/3.4.0-ubuntu-amd64",
+		}},
+	}
+	args := params.Entities{[]params.Entity{{coretesting.ModelTag.String()}}}
+
+	res := new(params.SerializedModelResults)
+	ress := params.SerializedModelResults{Results: []params.SerializedModelResult{{
+		Result: serialized,
+	}}}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(11)
+	mockFacadeCaller.EXPECT().FacadeCall("ExportModels", args, res).SetArg(2, ress).Return(nil)
+	client := modelmanager.NewClientFromCaller(mockFacadeCaller)
+
+	out, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, serialized)
+}
+
+func (s *dumpModelSuite) TestExportModelError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	args := params.Entities{[]params.Entity{{coretesting.ModelTag.String()}}}
+
+	res := new(params.SerializedModelResults)
+	ress := params.SerializedModelResults{Results: []params.SerializedModelResult{{
+		Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+	}}}
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(11)
+	mockFacadeCaller.EXPECT().FacadeCall("ExportModels", args, res).SetArg(2, ress).Return(nil)
+	client := modelmanager.NewClientFromCaller(mockFacadeCaller)
+
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *dumpModelSuite) TestExportModelNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockFacadeCaller := basemocks.NewMockFacadeCaller(ctrl)
+	mockFacadeCaller.EXPECT().BestAPIVersion().Return(10)
+	client := modelmanager.NewClientFromCaller(mockFacadeCaller)
+
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/rpc/params"
+)
+
+// ChangeLogParams holds parameters for StreamChangeLog that control which
+// change log events are sent. If the structure is zero initialized, every
+// event is sent from the start of the change log, and the stream waits for
+// new events until the caller closes the connection.
+type ChangeLogParams struct {
+	// Namespaces lists the change log namespaces to include. If none are
+	// set, then all namespaces are included.
+	Namespaces []string
+	// From is the cursor to resume the stream from; only events with an
+	// ID greater than it are sent. Pass the ID of the last event received
+	// to pick up where a previous stream left off.
+	From int64
+	// Limit defines the maximum number of events to return. Once this many
+	// have been sent, the socket is closed.
+	Limit uint
+	// NoTail tells the server to only return the events it has now, and not
+	// to wait for new events to arrive.
+	NoTail bool
+}
+
+// URLQuery returns the URL query values for the change log endpoint.
+func (args ChangeLogParams) URLQuery() url.Values {
+	attrs := url.Values{
+		"namespace": args.Namespaces,
+	}
+	if args.From > 0 {
+		attrs.Set("from", fmt.Sprint(args.From))
+	}
+	if args.Limit > 0 {
+		attrs.Set("maxEvents", fmt.Sprint(args.Limit))
+	}
+	if args.NoTail {
+		attrs.Set("noTail", fmt.Sprint(args.NoTail))
+	}
+	return attrs
+}
+
+// ChangeLogEvent is a single change to the controller database.
+type ChangeLogEvent struct {
+	ID          int64
+	Namespace   string
+	EditType    string
+	ChangedUUID string
+	CreatedAt   time.Time
+}
+
+// StreamChangeLog requests the controller change log from the server and
+// returns a channel of the events that come back. The channel is closed
+// when the stream ends.
+func StreamChangeLog(ctx context.Context, source base.ControllerStreamConnector, args ChangeLogParams) (<-chan ChangeLogEvent, error) {
+	connection, err := source.ConnectControllerStream("/changelog", args.URLQuery(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	events := make(chan ChangeLogEvent)
+	go func() {
+		defer close(events)
+		defer connection.Close()
+
+		for {
+			var event params.ChangeLogEvent
+			if err := connection.ReadJSON(&event); err != nil {
+				return
+			}
+			select {
+			case events <- ChangeLogEvent{
+				ID:          event.ID,
+				Namespace:   event.Namespace,
+				EditType:    event.EditType,
+				ChangedUUID: event.ChangedUUID,
+				CreatedAt:   event.CreatedAt,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
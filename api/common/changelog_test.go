@@ -0,0 +1,150 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type changeLogSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&changeLogSuite{})
+
+func (s *changeLogSuite) TestURLQuery(c *gc.C) {
+	args := common.ChangeLogParams{
+		Namespaces: []string{"external_controller", "cloud"},
+		From:       42,
+		Limit:      10,
+		NoTail:     true,
+	}
+	c.Assert(args.URLQuery(), jc.DeepEquals, url.Values{
+		"namespace": {"external_controller", "cloud"},
+		"from":      {"42"},
+		"maxEvents": {"10"},
+		"noTail":    {"true"},
+	})
+}
+
+func (s *changeLogSuite) TestURLQueryZero(c *gc.C) {
+	c.Assert(common.ChangeLogParams{}.URLQuery(), jc.DeepEquals, url.Values{
+		"namespace": nil,
+	})
+}
+
+func (s *changeLogSuite) TestStreamChangeLog(c *gc.C) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stream := &fakeChangeLogStream{
+		events: []params.ChangeLogEvent{{
+			ID:          7,
+			Namespace:   "external_controller",
+			EditType:    "create",
+			ChangedUUID: "deadbeef",
+			CreatedAt:   created,
+		}, {
+			ID:          9,
+			Namespace:   "external_controller",
+			EditType:    "delete",
+			ChangedUUID: "deadbeef",
+			CreatedAt:   created,
+		}},
+	}
+	connector := &fakeControllerStreamConnector{stream: stream}
+
+	events, err := common.StreamChangeLog(context.Background(), connector, common.ChangeLogParams{
+		Namespaces: []string{"external_controller"},
+		From:       6,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(connector.path, gc.Equals, "/changelog")
+	c.Check(connector.attrs.Get("from"), gc.Equals, "6")
+	c.Check(connector.attrs["namespace"], jc.DeepEquals, []string{"external_controller"})
+
+	var received []common.ChangeLogEvent
+	timeout := time.After(coretesting.LongWait)
+	for done := false; !done; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				done = true
+				break
+			}
+			received = append(received, event)
+		case <-timeout:
+			c.Fatalf("timed out waiting for change log events")
+		}
+	}
+	c.Assert(received, jc.DeepEquals, []common.ChangeLogEvent{{
+		ID:          7,
+		Namespace:   "external_controller",
+		EditType:    "create",
+		ChangedUUID: "deadbeef",
+		CreatedAt:   created,
+	}, {
+		ID:          9,
+		Namespace:   "external_controller",
+		EditType:    "delete",
+		ChangedUUID: "deadbeef",
+		CreatedAt:   created,
+	}})
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *changeLogSuite) TestStreamChangeLogConnectError(c *gc.C) {
+	connector := &fakeControllerStreamConnector{err: errors.New("boom")}
+	_, err := common.StreamChangeLog(context.Background(), connector, common.ChangeLogParams{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeControllerStreamConnector struct {
+	path   string
+	attrs  url.Values
+	stream base.Stream
+	err    error
+}
+
+func (f *fakeControllerStreamConnector) ConnectControllerStream(path string, attrs url.Values, _ http.Header) (base.Stream, error) {
+	f.path = path
+	f.attrs = attrs
+	return f.stream, f.err
+}
+
+type fakeChangeLogStream struct {
+	base.Stream
+	events []params.ChangeLogEvent
+	closed bool
+}
+
+func (f *fakeChangeLogStream) ReadJSON(v interface{}) error {
+	if len(f.events) == 0 {
+		return io.EOF
+	}
+	data, err := json.Marshal(f.events[0])
+	if err != nil {
+		return err
+	}
+	f.events = f.events[1:]
+	return json.Unmarshal(data, v)
+}
+
+func (f *fakeChangeLogStream) Close() error {
+	f.closed = true
+	return nil
+}
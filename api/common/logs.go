@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/rpc/params"
@@ -40,6 +42,14 @@ type DebugLogParams struct {
 	ExcludeModule []string
 	// ExcludeLabel lists logging labels to exclude from the response.
 	ExcludeLabel []string
+	// IncludeMessage lists regular expressions matched against log
+	// messages. If any are set, only messages matching at least one of
+	// them are included in the response.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against log
+	// messages. Messages matching any of them are excluded from the
+	// response.
+	ExcludeMessage []string
 
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
@@ -51,6 +61,9 @@ type DebugLogParams struct {
 	Backlog uint
 	// Level specifies the minimum logging level to be sent back in the response.
 	Level loggo.Level
+	// MaxLevel specifies the maximum logging level to be sent back in the
+	// response. If unspecified, there is no maximum.
+	MaxLevel loggo.Level
 	// Replay tells the server to start at the start of the log file rather
 	// than the end. If replay is true, backlog is ignored.
 	Replay bool
@@ -60,6 +73,37 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned.
+	EndTime time.Time
+}
+
+// filtersMinServerVersion is the first controller version to filter
+// log messages by content, maximum level and end time. Older
+// controllers ignore those filters.
+var filtersMinServerVersion = version.MustParse("3.6-beta2")
+
+// CheckServerVersion returns an error if the controller with the input
+// version would ignore any of the filters set in args.
+func (args DebugLogParams) CheckServerVersion(serverVersion version.Number) error {
+	if serverVersion.ToPatch().Compare(filtersMinServerVersion) >= 0 {
+		return nil
+	}
+	var unsupported []string
+	if len(args.IncludeMessage) > 0 || len(args.ExcludeMessage) > 0 {
+		unsupported = append(unsupported, "message")
+	}
+	if args.MaxLevel != loggo.UNSPECIFIED {
+		unsupported = append(unsupported, "maximum level")
+	}
+	if !args.EndTime.IsZero() {
+		unsupported = append(unsupported, "end time")
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return errors.NotSupportedf("filtering debug log by %s with controller version %s",
+		strings.Join(unsupported, ", "), serverVersion)
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -86,9 +130,21 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
+	if args.MaxLevel != loggo.UNSPECIFIED {
+		attrs.Set("maxLevel", fmt.Sprint(args.MaxLevel))
+	}
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if len(args.IncludeMessage) > 0 {
+		attrs["includeMessage"] = args.IncludeMessage
+	}
+	if len(args.ExcludeMessage) > 0 {
+		attrs["excludeMessage"] = args.ExcludeMessage
+	}
 	return attrs
 }
 
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/common"
+)
+
+type debugLogParamsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&debugLogParamsSuite{})
+
+func (s *debugLogParamsSuite) TestURLQuery(c *gc.C) {
+	args := common.DebugLogParams{
+		IncludeEntity:  []string{"unit-mysql-*"},
+		IncludeMessage: []string{"^hook", "failed"},
+		ExcludeMessage: []string{"config-changed"},
+		Level:          loggo.INFO,
+		MaxLevel:       loggo.WARNING,
+		StartTime:      time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:        time.Date(2024, 3, 1, 11, 30, 0, 500, time.UTC),
+		NoTail:         true,
+	}
+	c.Assert(args.URLQuery(), jc.DeepEquals, url.Values{
+		"includeEntity":  {"unit-mysql-*"},
+		"includeModule":  nil,
+		"includeLabel":   nil,
+		"excludeEntity":  nil,
+		"excludeModule":  nil,
+		"excludeLabel":   nil,
+		"includeMessage": {"^hook", "failed"},
+		"excludeMessage": {"config-changed"},
+		"level":          {"INFO"},
+		"maxLevel":       {"WARNING"},
+		"startTime":      {"2024-03-01T10:00:00Z"},
+		"endTime":        {"2024-03-01T11:30:00.0000005Z"},
+		"noTail":         {"true"},
+	})
+}
+
+func (s *debugLogParamsSuite) TestCheckServerVersion(c *gc.C) {
+	args := common.DebugLogParams{
+		IncludeEntity: []string{"unit-mysql-*"},
+		Level:         loggo.INFO,
+		StartTime:     time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+	c.Check(args.CheckServerVersion(version.MustParse("3.5.1")), jc.ErrorIsNil)
+
+	args.IncludeMessage = []string{"failed"}
+	args.MaxLevel = loggo.WARNING
+	args.EndTime = time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC)
+	c.Check(args.CheckServerVersion(version.MustParse("3.6.0")), jc.ErrorIsNil)
+	c.Check(args.CheckServerVersion(version.MustParse("3.6-beta2")), jc.ErrorIsNil)
+
+	err := args.CheckServerVersion(version.MustParse("3.5.1"))
+	c.Check(err, jc.ErrorIs, errors.NotSupported)
+	c.Check(err, gc.ErrorMatches, `filtering debug log by message, maximum level, end time with controller version 3.5.1 not supported`)
+}
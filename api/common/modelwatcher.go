@@ -12,6 +12,8 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/logfwd/otlp"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/rpc/params"
 )
@@ -73,6 +75,28 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return cfg, ok, nil
 }
 
+// LogForwardJSONConfig returns the current log forward JSON-over-TCP
+// configuration.
+func (e *ModelWatcher) LogForwardJSONConfig() (*jsonstream.RawConfig, bool, error) {
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdJSON()
+	return cfg, ok, nil
+}
+
+// LogForwardOTLPConfig returns the current log forward OTLP/HTTP
+// configuration.
+func (e *ModelWatcher) LogForwardOTLPConfig() (*otlp.RawConfig, bool, error) {
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdOTLP()
+	return cfg, ok, nil
+}
+
 // UpdateStatusHookInterval returns the current update status hook interval.
 func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
@@ -0,0 +1,23 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/rpc/params"
+)
+
+// PrecheckIssuesFromParams converts the issues found by the migration
+// prechecks from their wire representation.
+func PrecheckIssuesFromParams(issues []params.MigrationPrecheckIssue) coremigration.PrecheckIssues {
+	out := make(coremigration.PrecheckIssues, len(issues))
+	for i, issue := range issues {
+		out[i] = coremigration.PrecheckIssue{
+			Severity:   coremigration.PrecheckSeverity(issue.Severity),
+			Controller: issue.Controller,
+			Message:    issue.Message,
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	charmresource "github.com/juju/charm/v12/resource"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/rpc/params"
+)
+
+// SerializedModelFromParams converts a serialized model, along with the
+// details of the charms, agent binaries and resources it uses, from its
+// wire representation.
+func SerializedModelFromParams(serialized params.SerializedModel) (migration.SerializedModel, error) {
+	var empty migration.SerializedModel
+
+	// Convert tools info to output map.
+	tools := make(map[version.Binary]string)
+	for _, toolsInfo := range serialized.Tools {
+		v, err := version.ParseBinary(toolsInfo.Version)
+		if err != nil {
+			return empty, errors.Annotate(err, "error parsing agent binary version")
+		}
+		tools[v] = toolsInfo.URI
+	}
+
+	modelResources, err := convertResources(serialized.Resources)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	return migration.SerializedModel{
+		Bytes:     serialized.Bytes,
+		Charms:    serialized.Charms,
+		Tools:     tools,
+		Resources: modelResources,
+	}, nil
+}
+
+func convertResources(in []params.SerializedModelResource) ([]migration.SerializedModelResource, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make([]migration.SerializedModelResource, 0, len(in))
+	for _, resource := range in {
+		outResource, err := convertAppResource(resource)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out = append(out, outResource)
+	}
+	return out, nil
+}
+
+func convertAppResource(in params.SerializedModelResource) (migration.SerializedModelResource, error) {
+	var empty migration.SerializedModelResource
+	appRev, err := convertResourceRevision(in.Application, in.Name, in.ApplicationRevision)
+	if err != nil {
+		return empty, errors.Annotate(err, "application revision")
+	}
+	csRev, err := convertResourceRevision(in.Application, in.Name, in.CharmStoreRevision)
+	if err != nil {
+		return empty, errors.Annotate(err, "charmstore revision")
+	}
+	unitRevs := make(map[string]resources.Resource)
+	for unitName, inUnitRev := range in.UnitRevisions {
+		unitRev, err := convertResourceRevision(in.Application, in.Name, inUnitRev)
+		if err != nil {
+			return empty, errors.Annotate(err, "unit revision")
+		}
+		unitRevs[unitName] = unitRev
+	}
+	return migration.SerializedModelResource{
+		ApplicationRevision: appRev,
+		CharmStoreRevision:  csRev,
+		UnitRevisions:       unitRevs,
+	}, nil
+}
+
+func convertResourceRevision(app, name string, rev params.SerializedModelResourceRevision) (resources.Resource, error) {
+	var empty resources.Resource
+	type_, err := charmresource.ParseType(rev.Type)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex); err != nil {
+			return empty, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resources.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path,
+				Description: rev.Description,
+			},
+			Origin:      origin,
+			Revision:    rev.Revision,
+			Size:        rev.Size,
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username,
+		Timestamp:     rev.Timestamp,
+	}, nil
+}
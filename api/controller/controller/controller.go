@@ -15,8 +15,11 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/proxy"
 	"github.com/juju/juju/rpc/params"
@@ -327,16 +330,94 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// DryRunMigration runs the checks made when a migration is initiated,
+// along with checks that the model can be exported and imported,
+// without starting the migration. It returns every issue found, on
+// either controller, which would prevent the migration or affect it.
+func (c *Client) DryRunMigration(spec MigrationSpec) (coremigration.PrecheckIssues, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotSupportedf("migration dry runs on this controller")
+	}
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("DryRunMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return common.PrecheckIssuesFromParams(result.Issues), nil
+}
+
+// ResumeMigration resumes the suspended migration of the model with
+// the given UUID from the phase it was suspended in.
+func (c *Client) ResumeMigration(modelUUID string) error {
+	return errors.Trace(c.resumeMigration(modelUUID, false))
+}
+
+// AbortMigration aborts the suspended migration of the model with the
+// given UUID, removing the partially imported model from the target
+// controller.
+func (c *Client) AbortMigration(modelUUID string) error {
+	return errors.Trace(c.resumeMigration(modelUUID, true))
+}
+
+func (c *Client) resumeMigration(modelUUID string, abort bool) error {
+	if c.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("resuming migrations on this controller")
+	}
+	if !names.IsValidModel(modelUUID) {
+		return errors.NotValidf("model UUID %q", modelUUID)
+	}
+	args := params.ResumeMigrationArgs{
+		Specs: []params.ResumeMigrationSpec{{
+			ModelTag: names.NewModelTag(modelUUID).String(),
+			Abort:    abort,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResumeMigration", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -349,19 +430,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -391,6 +460,24 @@ func (c *Client) ControllerVersion() (ControllerVersion, error) {
 	return out, err
 }
 
+// BackupStatus returns the status of the controller's scheduled backups.
+// A NotFound error is returned if no scheduled backup has been taken.
+func (c *Client) BackupStatus() (status.StatusInfo, error) {
+	if c.BestAPIVersion() < 14 {
+		return status.StatusInfo{}, errors.NotSupportedf("backup status on this controller")
+	}
+	var result params.BackupStatusResult
+	if err := c.facade.FacadeCall("BackupStatus", nil, &result); err != nil {
+		return status.StatusInfo{}, errors.Trace(apiservererrors.RestoreError(err))
+	}
+	return status.StatusInfo{
+		Status:  status.Status(result.Status),
+		Message: result.Info,
+		Data:    result.Data,
+		Since:   result.Since,
+	}, nil
+}
+
 // DashboardConnectionInfo
 type DashboardConnectionInfo struct {
 	Proxier   proxy.Proxier
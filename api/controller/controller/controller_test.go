@@ -20,6 +20,8 @@ import (
 	"github.com/juju/juju/api/controller/controller"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/core/life"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/status"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	proxyfactory "github.com/juju/juju/proxy/factory"
 	"github.com/juju/juju/rpc/params"
@@ -171,6 +173,185 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestDryRunMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Issues: []params.MigrationPrecheckIssue{{
+						Severity:   "blocker",
+						Controller: "source",
+						Message:    "machine 0 is dying",
+					}},
+				}},
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	issues, err := client.DryRunMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, coremigration.PrecheckIssues{{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckSource,
+		Message:    "machine 0 is dying",
+	}})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DryRunMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestDryRunMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: apiservererrors.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestDryRunMigrationNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.DryRunMigration(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *Suite) TestResumeMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	modelUUID := randomUUID()
+	c.Assert(client.ResumeMigration(modelUUID), jc.ErrorIsNil)
+	c.Assert(client.AbortMigration(modelUUID), jc.ErrorIsNil)
+	modelTag := names.NewModelTag(modelUUID).String()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ResumeMigration", []interface{}{params.ResumeMigrationArgs{
+			Specs: []params.ResumeMigrationSpec{{ModelTag: modelTag}},
+		}}},
+		{"Controller.ResumeMigration", []interface{}{params.ResumeMigrationArgs{
+			Specs: []params.ResumeMigrationSpec{{ModelTag: modelTag, Abort: true}},
+		}}},
+	})
+}
+
+func (s *Suite) TestResumeMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{
+				Error: apiservererrors.ServerError(errors.New("migration is not suspended")),
+			}}}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ResumeMigration(randomUUID())
+	c.Check(err, gc.ErrorMatches, "migration is not suspended")
+}
+
+func (s *Suite) TestResumeMigrationNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.ResumeMigration(randomUUID())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.BackupStatusResult)
+			*out = params.BackupStatusResult{
+				Status: "active",
+				Info:   "backup created",
+				Data:   map[string]interface{}{"filename": "juju-backup.tar.gz"},
+				Since:  &since,
+			}
+			return stub.NextErr()
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	sInfo, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sInfo, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Active,
+		Message: "backup created",
+		Data:    map[string]interface{}{"filename": "juju-backup.tar.gz"},
+		Since:   &since,
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"Controller.BackupStatus", []interface{}{nil}}})
+}
+
+func (s *Suite) TestBackupStatusNotFound(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			return apiservererrors.ServerError(errors.NotFoundf("backup status"))
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestBackupStatusNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
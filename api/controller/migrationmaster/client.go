@@ -11,18 +11,15 @@ import (
 	"net/http"
 	"time"
 
-	charmresource "github.com/juju/charm/v12/resource"
 	"github.com/juju/description/v5"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
-	"github.com/juju/version/v2"
 	"gopkg.in/httprequest.v1"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/core/migration"
-	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/rpc/params"
 )
@@ -112,6 +109,7 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		Suspended: status.Suspended,
 	}, nil
 }
 
@@ -132,6 +130,16 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// Suspend stops the active migration at its current phase after a
+// failure, recording the message as its status, so that it can be
+// resumed later rather than aborted.
+func (c *Client) Suspend(message string) error {
+	args := params.SetMigrationStatusMessageArgs{
+		Message: message,
+	}
+	return c.caller.FacadeCall("Suspend", args, nil)
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
@@ -193,34 +201,12 @@ func (c *Client) Prechecks() error {
 // with the API connection. The charms used by the model are also
 // returned.
 func (c *Client) Export() (migration.SerializedModel, error) {
-	var empty migration.SerializedModel
 	var serialized params.SerializedModel
 	err := c.caller.FacadeCall("Export", nil, &serialized)
 	if err != nil {
-		return empty, errors.Trace(err)
+		return migration.SerializedModel{}, errors.Trace(err)
 	}
-
-	// Convert tools info to output map.
-	tools := make(map[version.Binary]string)
-	for _, toolsInfo := range serialized.Tools {
-		v, err := version.ParseBinary(toolsInfo.Version)
-		if err != nil {
-			return migration.SerializedModel{}, errors.Annotate(err, "error parsing agent binary version")
-		}
-		tools[v] = toolsInfo.URI
-	}
-
-	resources, err := convertResources(serialized.Resources)
-	if err != nil {
-		return empty, errors.Trace(err)
-	}
-
-	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
-	}, nil
+	return common.SerializedModelFromParams(serialized)
 }
 
 // ProcessRelations runs a series of processes to ensure that the relations
@@ -366,78 +352,3 @@ func groupTagIds(tagStrs []string) ([]string, []string, []string, error) {
 	}
 	return machines, units, applications, nil
 }
-
-func convertResources(in []params.SerializedModelResource) ([]migration.SerializedModelResource, error) {
-	if len(in) == 0 {
-		return nil, nil
-	}
-	out := make([]migration.SerializedModelResource, 0, len(in))
-	for _, resource := range in {
-		outResource, err := convertAppResource(resource)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		out = append(out, outResource)
-	}
-	return out, nil
-}
-
-func convertAppResource(in params.SerializedModelResource) (migration.SerializedModelResource, error) {
-	var empty migration.SerializedModelResource
-	appRev, err := convertResourceRevision(in.Application, in.Name, in.ApplicationRevision)
-	if err != nil {
-		return empty, errors.Annotate(err, "application revision")
-	}
-	csRev, err := convertResourceRevision(in.Application, in.Name, in.CharmStoreRevision)
-	if err != nil {
-		return empty, errors.Annotate(err, "charmstore revision")
-	}
-	unitRevs := make(map[string]resources.Resource)
-	for unitName, inUnitRev := range in.UnitRevisions {
-		unitRev, err := convertResourceRevision(in.Application, in.Name, inUnitRev)
-		if err != nil {
-			return empty, errors.Annotate(err, "unit revision")
-		}
-		unitRevs[unitName] = unitRev
-	}
-	return migration.SerializedModelResource{
-		ApplicationRevision: appRev,
-		CharmStoreRevision:  csRev,
-		UnitRevisions:       unitRevs,
-	}, nil
-}
-
-func convertResourceRevision(app, name string, rev params.SerializedModelResourceRevision) (resources.Resource, error) {
-	var empty resources.Resource
-	type_, err := charmresource.ParseType(rev.Type)
-	if err != nil {
-		return empty, errors.Trace(err)
-	}
-	origin, err := charmresource.ParseOrigin(rev.Origin)
-	if err != nil {
-		return empty, errors.Trace(err)
-	}
-	var fp charmresource.Fingerprint
-	if rev.FingerprintHex != "" {
-		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex); err != nil {
-			return empty, errors.Annotate(err, "invalid fingerprint")
-		}
-	}
-	return resources.Resource{
-		Resource: charmresource.Resource{
-			Meta: charmresource.Meta{
-				Name:        name,
-				Type:        type_,
-				Path:        rev.Path,
-				Description: rev.Description,
-			},
-			Origin:      origin,
-			Revision:    rev.Revision,
-			Size:        rev.Size,
-			Fingerprint: fp,
-		},
-		ApplicationID: app,
-		Username:      rev.Username,
-		Timestamp:     rev.Timestamp,
-	}, nil
-}
@@ -100,6 +100,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			MigrationId:      "id",
 			Phase:            "IMPORT",
 			PhaseChangedTime: timestamp,
+			Suspended:        true,
 		}
 		return nil
 	})
@@ -116,6 +117,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 		ModelUUID:        modelUUID,
 		Phase:            migration.IMPORT,
 		PhaseChangedTime: timestamp,
+		Suspended:        true,
 		TargetInfo: migration.TargetInfo{
 			ControllerTag: controllerTag,
 			Addrs:         []string{"2.2.2.2:2"},
@@ -165,6 +167,21 @@ func (s *ClientSuite) TestSetStatusMessage(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestSuspend(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.Suspend("upload failed")
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationStatusMessageArgs{Message: "upload failed"}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "MigrationMaster.Suspend", Args: []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/rpc/params"
@@ -50,12 +51,42 @@ func (c *Client) BestFacadeVersion() int {
 // Prechecks checks that the target controller is able to accept the
 // model being migrated.
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
+	args, err := makeMigrationModelInfo(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+}
+
+// PrecheckReport runs the checks made by Prechecks, along with a check
+// that the model can be imported, without leaving anything changed on
+// the target controller. It returns every issue found rather than
+// failing on the first one.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo) (coremigration.PrecheckIssues, error) {
+	if c.caller.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("precheck reports on this controller")
+	}
+	args, err := makeMigrationModelInfo(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.MigrationPrecheckResult
+	if err := c.caller.FacadeCall("PrecheckReport", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return common.PrecheckIssuesFromParams(result.Issues), nil
+}
+
+func makeMigrationModelInfo(model coremigration.ModelInfo) (params.MigrationModelInfo, error) {
 	// The model description is marshalled into YAML (description package does
 	// not support JSON) to prevent potential issues with
 	// marshalling/unmarshalling on the target API controller.
 	serialised, err := description.Serialize(model.ModelDescription)
 	if err != nil {
-		return errors.Annotate(err, "failed to marshal model description")
+		return params.MigrationModelInfo{}, errors.Annotate(err, "failed to marshal model description")
 	}
 
 	// Pass all the known facade versions to the controller so that it
@@ -68,7 +99,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		versions[name] = version
 	}
 
-	args := params.MigrationModelInfo{
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
@@ -76,8 +107,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		ControllerAgentVersion: model.ControllerAgentVersion,
 		FacadeVersions:         versions,
 		ModelDescription:       serialised,
-	}
-	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+	}, nil
 }
 
 // Import takes a serialized model and imports it into the target
@@ -253,6 +283,38 @@ func (c *Client) LatestLogTime(modelUUID string) (time.Time, error) {
 	return result, nil
 }
 
+// ImportedBinaries returns the charms, agent binaries and resources
+// which the target controller already holds for the importing model,
+// along with hashes of the agent binary and resource content.
+func (c *Client) ImportedBinaries(modelUUID string) (coremigration.ImportedBinaries, error) {
+	if c.caller.BestAPIVersion() < 5 {
+		return coremigration.ImportedBinaries{}, errors.NotSupportedf("reporting imported binaries on this controller")
+	}
+	var result params.MigrationImportedBinaries
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	if err := c.caller.FacadeCall("ImportedBinaries", args, &result); err != nil {
+		return coremigration.ImportedBinaries{}, errors.Trace(err)
+	}
+	imported := coremigration.ImportedBinaries{
+		Charms:    make(map[string]string),
+		Tools:     make(map[version.Binary]string),
+		Resources: make(map[string]map[string]string),
+	}
+	for _, ch := range result.Charms {
+		imported.Charms[ch.URL] = ch.SHA256
+	}
+	for _, t := range result.Tools {
+		imported.Tools[t.Version] = t.SHA256
+	}
+	for _, res := range result.Resources {
+		if imported.Resources[res.Application] == nil {
+			imported.Resources[res.Application] = make(map[string]string)
+		}
+		imported.Resources[res.Application][res.Name] = res.Fingerprint
+	}
+	return imported, nil
+}
+
 // AdoptResources asks the cloud provider to update the controller
 // tags for a model's resources. This prevents the resources from
 // being destroyed if the source controller is destroyed after the
@@ -90,6 +90,59 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	c.Assert(arg, mc, expectedArg)
 }
 
+func (s *ClientSuite) TestPrecheckReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MigrationPrecheckResult)
+		*out = params.MigrationPrecheckResult{
+			ModelTag: names.NewModelTag("uuid").String(),
+			Issues: []params.MigrationPrecheckIssue{{
+				Severity:   "blocker",
+				Controller: "target",
+				Message:    "upgrade in progress",
+			}, {
+				Severity:   "warning",
+				Controller: "target",
+				Message:    "source controller has higher patch version than target controller (1.2.5 > 1.2.4)",
+			}},
+		}
+		return nil
+	}), BestVersion: 4}
+	client := migrationtarget.NewClient(apiCaller)
+
+	issues, err := client.PrecheckReport(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  names.NewUserTag("owner"),
+		Name:                   "name",
+		AgentVersion:           version.MustParse("1.2.3"),
+		ControllerAgentVersion: version.MustParse("1.2.5"),
+		ModelDescription:       description.NewModel(description.ModelArgs{}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, coremigration.PrecheckIssues{{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "upgrade in progress",
+	}, {
+		Severity:   coremigration.PrecheckWarning,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "source controller has higher patch version than target controller (1.2.5 > 1.2.4)",
+	}})
+	stub.CheckCallNames(c, "MigrationTarget.PrecheckReport")
+	arg := stub.Calls()[0].Args[1].(params.MigrationModelInfo)
+	c.Check(arg.UUID, gc.Equals, "uuid")
+	c.Check(arg.OwnerTag, gc.Equals, "user-owner")
+	c.Check(arg.FacadeVersions, gc.Not(gc.HasLen), 0)
+}
+
+func (s *ClientSuite) TestPrecheckReportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckReport(coremigration.ModelInfo{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -175,6 +228,51 @@ func (s *ClientSuite) TestLatestLogTimeError(c *gc.C) {
 	s.AssertModelCall(c, stub, names.NewModelTag("fake"), "LatestLogTime", err, true)
 }
 
+func (s *ClientSuite) TestImportedBinaries(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{APICallerFunc: apitesting.APICallerFunc(func(objType string, _ int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		out := result.(*params.MigrationImportedBinaries)
+		*out = params.MigrationImportedBinaries{
+			Charms: []params.MigrationImportedCharm{{
+				URL:    "ch:foo-1",
+				SHA256: "abcdef",
+			}},
+			Tools: []params.MigrationImportedTools{{
+				Version: version.MustParseBinary("2.9.0-ubuntu-amd64"),
+				SHA256:  "123456",
+			}},
+			Resources: []params.MigrationImportedResource{{
+				Application: "foo",
+				Name:        "bar",
+				Fingerprint: "fedcba",
+			}},
+		}
+		return nil
+	}), BestVersion: 5}
+	client := migrationtarget.NewClient(apiCaller)
+
+	imported, err := client.ImportedBinaries("fake")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported, jc.DeepEquals, coremigration.ImportedBinaries{
+		Charms: map[string]string{"ch:foo-1": "abcdef"},
+		Tools: map[version.Binary]string{
+			version.MustParseBinary("2.9.0-ubuntu-amd64"): "123456",
+		},
+		Resources: map[string]map[string]string{
+			"foo": {"bar": "fedcba"},
+		},
+	})
+	s.AssertModelCall(c, &stub, names.NewModelTag("fake"), "ImportedBinaries", err, false)
+}
+
+func (s *ClientSuite) TestImportedBinariesNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.ImportedBinaries("fake")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAdoptResources(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 	err := client.AdoptResources("the-model")
@@ -27,12 +27,12 @@ var facadeVersions = facades.FacadeVersions{
 	"AgentLifeFlag":                {1},
 	"AgentTools":                   {1},
 	"AllModelWatcher":              {4},
-	"AllWatcher":                   {3},
+	"AllWatcher":                   {3, 4},
 	"Annotations":                  {2},
 	"Application":                  {15, 16, 17, 18, 19, 20},
 	"ApplicationOffers":            {4, 5},
 	"ApplicationScaler":            {1},
-	"Backups":                      {3},
+	"Backups":                      {3, 4},
 	"Block":                        {2},
 	"Bundle":                       {6},
 	"CAASAgent":                    {2},
@@ -53,7 +53,8 @@ var facadeVersions = facades.FacadeVersions{
 	"Cleaner":                      {2},
 	"Client":                       {6, 7},
 	"Cloud":                        {7},
-	"Controller":                   {11},
+	"Controller":                   {11, 12, 13, 14},
+	"ControllerDB":                 {1},
 	"CredentialManager":            {1},
 	"CredentialValidator":          {2},
 	"CrossController":              {1},
@@ -75,6 +76,7 @@ var facadeVersions = facades.FacadeVersions{
 	"InstancePoller":               {4},
 	"KeyManager":                   {1},
 	"KeyUpdater":                   {1},
+	"LeadershipHistory":            {1},
 	"LeadershipService":            {2},
 	"LifeFlag":                     {1},
 	"LogForwarding":                {1},
@@ -88,13 +90,13 @@ var facadeVersions = facades.FacadeVersions{
 	"MetricsDebug":                 {2},
 	"MetricsManager":               {1},
 	"MigrationFlag":                {1},
-	"MigrationMaster":              {3},
+	"MigrationMaster":              {3, 4},
 	"MigrationMinion":              {1},
 	"MigrationStatusWatcher":       {1},
-	"MigrationTarget":              {1, 2, 3},
+	"MigrationTarget":              {1, 2, 3, 4, 5},
 	"ModelConfig":                  {3},
 	"ModelGeneration":              {4},
-	"ModelManager":                 {9, 10},
+	"ModelManager":                 {9, 10, 11},
 	"ModelSummaryWatcher":          {1},
 	"ModelUpgrader":                {1},
 	"NotifyWatcher":                {1},
@@ -53,10 +53,12 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/controllerdb"
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
-	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/keymanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/leadershiphistory"
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
@@ -215,6 +217,7 @@ func AllFacades() *facade.Registry {
 	caasunitprovisioner.Register(registry)
 
 	controller.Register(registry)
+	controllerdb.Register(registry)
 	crossmodelrelations.Register(registry)
 	crossmodelsecrets.Register(registry)
 	crosscontroller.Register(registry)
@@ -235,6 +238,7 @@ func AllFacades() *facade.Registry {
 	keymanager.Register(registry)
 	keyupdater.Register(registry)
 	leadership.Register(registry)
+	leadershiphistory.Register(registry)
 	lifeflag.Register(registry)
 	loggerapi.Register(registry)
 	logfwd.Register(registry)
@@ -319,12 +323,13 @@ func registerWatchers(registry *facade.Registry) {
 		return NewPinger(ctx)
 	}, reflect.TypeOf((*Pinger)(nil)).Elem())
 
-	registry.MustRegister("AllWatcher", 3, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 3, NewAllWatcherV3, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
 	// but they are get under separate names as it possible the may
 	// diverge in the future (especially in terms of authorisation
 	// checks).
-	registry.MustRegister("AllModelWatcher", 4, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
+	registry.MustRegister("AllModelWatcher", 4, NewAllWatcherV3, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	registry.MustRegister("NotifyWatcher", 1, newNotifyWatcher, reflect.TypeOf((*srvNotifyWatcher)(nil)))
 	registry.MustRegister("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
 	registry.MustRegister("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
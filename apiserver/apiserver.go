@@ -42,6 +42,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/stateauthenticator"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/charmhub/mirror"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/core/cache"
@@ -56,6 +57,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/worker/syslogger"
 )
 
@@ -848,8 +850,16 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 		ctxt:          httpCtxt,
 		stateAuthFunc: httpCtxt.stateForMigrationImporting,
 	}
+	changeLogHandler := newChangeLogHandler(
+		httpCtxt,
+		httpAuthenticator,
+		controllerAdminAuthorizer,
+	)
 	backupHandler := &backupHandler{ctxt: httpCtxt}
 	registerHandler := &registerUserHandler{ctxt: httpCtxt}
+	charmhubMirror := mirror.New(storage.NewStorage(controllerModelUUID, systemState.MongoSession()), systemState)
+	charmhubMirrorHandler := mirror.NewHandler(charmhubMirror, charmhubMirrorPrefix)
+	charmhubMirrorUploadHandler := &charmhubMirrorUploadHandler{mirror: charmhubMirror}
 
 	// HTTP handler for application offer macaroon authentication.
 	addOfferAuthHandlers(srv.offerAuthCtxt, srv.mux)
@@ -938,6 +948,43 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 		pattern:    "/migrate/resources",
 		handler:    resourcesMigrationUploadHandler,
 		authorizer: controllerAdminAuthorizer,
+	}, {
+		pattern:    "/charmhub-mirror",
+		methods:    []string{"POST"},
+		handler:    charmhubMirrorUploadHandler,
+		authorizer: controllerAdminAuthorizer,
+	}, {
+		// The charmhub mirror is unauthenticated, like charmhub itself,
+		// so that models can use it as their charmhub-url.
+		pattern:         charmhubMirrorPrefix + "/v2/charms/info/:name",
+		methods:         []string{"GET"},
+		handler:         charmhubMirrorHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         charmhubMirrorPrefix + "/v2/charms/find",
+		methods:         []string{"GET"},
+		handler:         charmhubMirrorHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         charmhubMirrorPrefix + "/v2/charms/refresh",
+		methods:         []string{"POST"},
+		handler:         charmhubMirrorHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         charmhubMirrorPrefix + "/v2/charms/resources/:charm/:resource/revisions",
+		methods:         []string{"GET"},
+		handler:         charmhubMirrorHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
+	}, {
+		pattern:         charmhubMirrorPrefix + "/download/:hash",
+		methods:         []string{"GET"},
+		handler:         charmhubMirrorHandler,
+		unauthenticated: true,
+		noModelUUID:     true,
 	}, {
 		pattern:    "/migrate/logtransfer",
 		handler:    logTransferHandler,
@@ -988,6 +1035,13 @@ func (srv *Server) endpoints() ([]apihttp.Endpoint, error) {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern: "/changelog",
+		handler: changeLogHandler,
+		tracked: true,
+		// The authentication is handled within the changeLogHandler in
+		// order for discharge required errors to be handled correctly.
+		unauthenticated: true,
 	}, {
 		// GET /charms has no authorizer
 		pattern: "/charms",
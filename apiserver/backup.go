@@ -37,29 +37,38 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	model, err := st.Model()
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
+	backupDir := backups.BackupDirToUse(modelConfig.BackupDir())
+	paths := &backups.Paths{
+		BackupDir: backupDir,
+	}
+
 	switch req.Method {
 	case "GET":
 		logger.Infof("handling backups download request")
-		model, err := st.Model()
-		if err != nil {
-			h.sendError(resp, err)
-			return
-		}
-		modelConfig, err := model.ModelConfig()
+		id, err := h.download(newBackups(paths), resp, req)
 		if err != nil {
 			h.sendError(resp, err)
 			return
 		}
-		backupDir := backups.BackupDirToUse(modelConfig.BackupDir())
-		paths := &backups.Paths{
-			BackupDir: backupDir,
-		}
-		id, err := h.download(newBackups(paths), resp, req)
+		logger.Infof("backups download request successful for %q", id)
+	case "PUT":
+		logger.Infof("handling backups upload request")
+		id, err := h.upload(newBackups(paths), resp, req)
 		if err != nil {
 			h.sendError(resp, err)
 			return
 		}
-		logger.Infof("backups download request successful for %q", id)
+		logger.Infof("backups upload request successful for %q", id)
 	default:
 		h.sendError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
 	}
@@ -82,6 +91,21 @@ func (h *backupHandler) download(backups backups.Backups, resp http.ResponseWrit
 	return args.ID, err
 }
 
+func (h *backupHandler) upload(backups backups.Backups, resp http.ResponseWriter, req *http.Request) (string, error) {
+	defer req.Body.Close()
+
+	ctype := req.Header.Get("Content-Type")
+	if ctype != params.ContentTypeRaw {
+		return "", errors.Errorf("expected Content-Type %q, got %q", params.ContentTypeRaw, ctype)
+	}
+
+	id, err := backups.Add(req.Body)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, errors.Trace(sendStatusAndJSON(resp, http.StatusOK, &params.BackupsUploadResult{ID: id}))
+}
+
 func (h *backupHandler) read(req *http.Request, expectedType string) ([]byte, error) {
 	defer req.Body.Close()
 
@@ -0,0 +1,336 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/websocket"
+	coredatabase "github.com/juju/juju/core/database"
+	"github.com/juju/juju/rpc/params"
+)
+
+const (
+	// changeLogPollInterval is the amount of time to wait between polling
+	// the change log for new events, once the client has caught up.
+	changeLogPollInterval = time.Second
+
+	// changeLogBatchSize is the maximum number of change log rows read
+	// from the database in one go.
+	changeLogBatchSize = 1000
+
+	// changeLogTimeFormat is the format that sqlite uses for the
+	// created_at column of the change log, when the driver doesn't
+	// convert it to a time itself.
+	changeLogTimeFormat = "2006-01-02 15:04:05.999999999"
+)
+
+// changeLogHandler takes requests to stream the controller change log.
+type changeLogHandler struct {
+	ctxt          httpContext
+	authenticator authentication.HTTPAuthenticator
+	authorizer    authentication.Authorizer
+}
+
+func newChangeLogHandler(
+	ctxt httpContext,
+	authenticator authentication.HTTPAuthenticator,
+	authorizer authentication.Authorizer,
+) *changeLogHandler {
+	return &changeLogHandler{
+		ctxt:          ctxt,
+		authenticator: authenticator,
+		authorizer:    authorizer,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the change log
+// API. As with debug-log, authentication and authorization are done after
+// the connection has been upgraded to a websocket, so that discharge
+// required errors make it back to the client.
+//
+// Args for the HTTP request are as follows:
+//
+//	namespace -> []string - lists the change log namespaces to include
+//	   - if none are set, then all namespaces are included
+//	from -> int - only events with an ID greater than this are sent
+//	   - this is the cursor for resuming a stream; pass the ID of the
+//	     last event received
+//	maxEvents -> uint - send *at most* this many events
+//	noTail -> string - one of [true, false], if true, existing events are
+//	   sent back, but the request does not wait for new ones.
+func (h *changeLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		socket := &changeLogSocketImpl{conn}
+		defer conn.Close()
+
+		authInfo, err := h.authenticator.Authenticate(req)
+		if err != nil {
+			socket.sendError(errors.Annotate(err, "authentication failed"))
+			return
+		}
+		if err := h.authorizer.Authorize(authInfo); err != nil {
+			socket.sendError(errors.Annotate(err, "authorization failed"))
+			return
+		}
+
+		reqParams, err := readChangeLogParams(req.URL.Query())
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+
+		db, err := h.ctxt.srv.shared.dbGetter.GetDB(coredatabase.ControllerNS)
+		if err != nil {
+			socket.sendError(errors.Annotate(err, "getting controller database"))
+			return
+		}
+
+		clock := h.ctxt.srv.clock
+		maxDuration := h.ctxt.srv.shared.maxDebugLogDuration()
+		source := dbChangeLogSource{db: db}
+
+		if err := handleChangeLogRequest(clock, maxDuration, source, reqParams, socket, h.ctxt.stop()); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("change log handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("change log handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// changeLogParams contains the parsed change log API request parameters.
+type changeLogParams struct {
+	namespaces []string
+	from       int64
+	maxEvents  uint
+	noTail     bool
+}
+
+func readChangeLogParams(queryMap url.Values) (changeLogParams, error) {
+	var params changeLogParams
+
+	if value := queryMap.Get("from"); value != "" {
+		num, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || num < 0 {
+			return params, errors.Errorf("from value %q is not a valid change ID", value)
+		}
+		params.from = num
+	}
+
+	if value := queryMap.Get("maxEvents"); value != "" {
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return params, errors.Errorf("maxEvents value %q is not a valid unsigned number", value)
+		}
+		params.maxEvents = uint(num)
+	}
+
+	if value := queryMap.Get("noTail"); value != "" {
+		noTail, err := strconv.ParseBool(value)
+		if err != nil {
+			return params, errors.Errorf("noTail value %q is not a valid boolean", value)
+		}
+		params.noTail = noTail
+	}
+
+	params.namespaces = queryMap["namespace"]
+
+	return params, nil
+}
+
+// changeLogSocket describes the functionality required for the change log
+// handler to send events to the client.
+type changeLogSocket interface {
+	// sendOk sends a nil error response, indicating there were no errors.
+	sendOk()
+
+	// sendError sends a JSON-encoded error response.
+	sendError(err error)
+
+	// sendEvent sends the change log event JSON encoded.
+	sendEvent(event params.ChangeLogEvent) error
+}
+
+// changeLogSocketImpl implements the changeLogSocket interface on top of a
+// websocket.Conn.
+type changeLogSocketImpl struct {
+	conn *websocket.Conn
+}
+
+// sendOk implements changeLogSocket.
+func (s *changeLogSocketImpl) sendOk() {
+	s.sendError(nil)
+}
+
+// sendError implements changeLogSocket.
+func (s *changeLogSocketImpl) sendError(err error) {
+	if sendErr := s.conn.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		s.conn.Close()
+		return
+	}
+}
+
+// sendEvent implements changeLogSocket.
+func (s *changeLogSocketImpl) sendEvent(event params.ChangeLogEvent) error {
+	return s.conn.WriteJSON(event)
+}
+
+// changeLogSource reads change log events from the backing store.
+type changeLogSource interface {
+	// ReadChanges returns at most limit events, in ascending ID order, with
+	// an ID greater than the supplied cursor. If namespaces is not empty,
+	// only events in those namespaces are returned.
+	ReadChanges(ctx context.Context, cursor int64, namespaces []string, limit int) ([]params.ChangeLogEvent, error)
+}
+
+func handleChangeLogRequest(
+	clock clock.Clock,
+	maxDuration time.Duration,
+	source changeLogSource,
+	reqParams changeLogParams,
+	socket changeLogSocket,
+	stop <-chan struct{},
+) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Indicate that all is well.
+	socket.sendOk()
+
+	timeout := clock.After(maxDuration)
+
+	var (
+		cursor     = reqParams.from
+		eventCount uint
+	)
+	for {
+		events, err := source.ReadChanges(ctx, cursor, reqParams.namespaces, changeLogBatchSize)
+		if ctx.Err() != nil {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "reading change log")
+		}
+
+		for _, event := range events {
+			if err := socket.sendEvent(event); err != nil {
+				return errors.Annotate(err, "sending failed")
+			}
+			cursor = event.ID
+
+			eventCount++
+			if reqParams.maxEvents > 0 && eventCount == reqParams.maxEvents {
+				return nil
+			}
+		}
+
+		// Keep reading without waiting whilst there is a backlog to
+		// catch up on.
+		if len(events) == changeLogBatchSize {
+			continue
+		}
+		if reqParams.noTail {
+			return nil
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-timeout:
+			return nil
+		case <-clock.After(changeLogPollInterval):
+		}
+	}
+}
+
+const selectChangeLogQuery = `
+SELECT c.id, n.namespace, t.edit_type, c.changed_uuid, c.created_at
+	FROM change_log c
+		JOIN change_log_edit_type t ON c.edit_type_id = t.id
+		JOIN change_log_namespace n ON c.namespace_id = n.id
+	WHERE c.id > ?%s
+	ORDER BY c.id ASC
+	LIMIT ?;
+`
+
+// dbChangeLogSource reads the change log directly from the controller
+// database. Unlike the change stream workers, events are not coalesced,
+// so that every row can be replayed by the client.
+type dbChangeLogSource struct {
+	db coredatabase.TrackedDB
+}
+
+// ReadChanges implements changeLogSource.
+func (s dbChangeLogSource) ReadChanges(ctx context.Context, cursor int64, namespaces []string, limit int) ([]params.ChangeLogEvent, error) {
+	var (
+		filter string
+		args   = []any{cursor}
+	)
+	if len(namespaces) > 0 {
+		filter = " AND n.namespace IN (?" + strings.Repeat(", ?", len(namespaces)-1) + ")"
+		for _, namespace := range namespaces {
+			args = append(args, namespace)
+		}
+	}
+	args = append(args, limit)
+	q := strings.Replace(selectChangeLogQuery, "%s", filter, 1)
+
+	var events []params.ChangeLogEvent
+	err := s.db.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		events = nil
+
+		rows, err := tx.QueryContext(ctx, q, args...)
+		if err != nil {
+			return errors.Annotate(err, "querying change log")
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				event     params.ChangeLogEvent
+				createdAt string
+			)
+			if err := rows.Scan(&event.ID, &event.Namespace, &event.EditType, &event.ChangedUUID, &createdAt); err != nil {
+				return errors.Annotate(err, "scanning change log")
+			}
+			if event.CreatedAt, err = parseChangeLogTime(createdAt); err != nil {
+				return errors.Trace(err)
+			}
+			events = append(events, event)
+		}
+		return errors.Trace(rows.Err())
+	})
+	return events, errors.Trace(err)
+}
+
+func parseChangeLogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(changeLogTimeFormat, value)
+	if err != nil {
+		return time.Time{}, errors.Annotatef(err, "parsing created at %q", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type changeLogIntSuite struct {
+	coretesting.BaseSuite
+	sock    *fakeChangeLogSocket
+	clock   *testclock.Clock
+	timeout time.Duration
+}
+
+var _ = gc.Suite(&changeLogIntSuite{})
+
+func (s *changeLogIntSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.sock = newFakeChangeLogSocket()
+	s.clock = testclock.NewClock(time.Now())
+	s.timeout = time.Minute
+}
+
+func (s *changeLogIntSuite) TestReadParams(c *gc.C) {
+	reqParams, err := readChangeLogParams(url.Values{
+		"namespace": {"external_controller", "cloud"},
+		"from":      {"42"},
+		"maxEvents": {"10"},
+		"noTail":    {"true"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reqParams, jc.DeepEquals, changeLogParams{
+		namespaces: []string{"external_controller", "cloud"},
+		from:       42,
+		maxEvents:  10,
+		noTail:     true,
+	})
+}
+
+func (s *changeLogIntSuite) TestReadParamsInvalid(c *gc.C) {
+	_, err := readChangeLogParams(url.Values{"from": {"-1"}})
+	c.Assert(err, gc.ErrorMatches, `from value "-1" is not a valid change ID`)
+
+	_, err = readChangeLogParams(url.Values{"maxEvents": {"many"}})
+	c.Assert(err, gc.ErrorMatches, `maxEvents value "many" is not a valid unsigned number`)
+
+	_, err = readChangeLogParams(url.Values{"noTail": {"maybe"}})
+	c.Assert(err, gc.ErrorMatches, `noTail value "maybe" is not a valid boolean`)
+}
+
+func (s *changeLogIntSuite) TestNoTail(c *gc.C) {
+	source := newFakeChangeLogSource(1, 2, 3)
+
+	err := handleChangeLogRequest(s.clock, s.timeout, source, changeLogParams{
+		from:       1,
+		namespaces: []string{"external_controller"},
+		noTail:     true,
+	}, s.sock, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertOutput(c, []int64{2, 3})
+	c.Assert(source.namespaces, jc.DeepEquals, []string{"external_controller"})
+}
+
+func (s *changeLogIntSuite) TestMaxEvents(c *gc.C) {
+	source := newFakeChangeLogSource(1, 2, 3)
+
+	err := handleChangeLogRequest(s.clock, s.timeout, source, changeLogParams{
+		maxEvents: 2,
+	}, s.sock, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertOutput(c, []int64{1, 2})
+}
+
+func (s *changeLogIntSuite) TestTailResumesFromCursor(c *gc.C) {
+	source := newFakeChangeLogSource(1, 2)
+
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- handleChangeLogRequest(s.clock, s.timeout, source, changeLogParams{}, s.sock, stop)
+	}()
+	s.assertOutput(c, []int64{1, 2})
+
+	source.add(3)
+	c.Assert(s.clock.WaitAdvance(changeLogPollInterval, coretesting.LongWait, 2), jc.ErrorIsNil)
+	s.assertOutput(c, []int64{3})
+
+	close(stop)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request handler to stop")
+	}
+}
+
+func (s *changeLogIntSuite) TestSourceError(c *gc.C) {
+	source := newFakeChangeLogSource()
+	source.err = errors.New("boom")
+
+	err := handleChangeLogRequest(s.clock, s.timeout, source, changeLogParams{}, s.sock, nil)
+	c.Assert(err, gc.ErrorMatches, "reading change log: boom")
+}
+
+func (s *changeLogIntSuite) TestParseChangeLogTime(c *gc.C) {
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.UTC)
+
+	t, err := parseChangeLogTime("2024-01-02 03:04:05.600")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, expected)
+
+	t, err = parseChangeLogTime("2024-01-02T03:04:05.6Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t, gc.Equals, expected)
+
+	_, err = parseChangeLogTime("yesterday")
+	c.Assert(err, gc.ErrorMatches, `parsing created at "yesterday": .*`)
+}
+
+func (s *changeLogIntSuite) assertOutput(c *gc.C, expected []int64) {
+	timeout := time.After(coretesting.LongWait)
+	for i, id := range expected {
+		select {
+		case event := <-s.sock.events:
+			c.Assert(event.ID, gc.Equals, id)
+		case <-timeout:
+			c.Fatalf("timed out waiting for change log event (received %d)", i)
+		}
+	}
+}
+
+type fakeChangeLogSocket struct {
+	events chan params.ChangeLogEvent
+}
+
+func newFakeChangeLogSocket() *fakeChangeLogSocket {
+	return &fakeChangeLogSocket{
+		events: make(chan params.ChangeLogEvent, 10),
+	}
+}
+
+func (s *fakeChangeLogSocket) sendOk() {}
+
+func (s *fakeChangeLogSocket) sendError(error) {}
+
+func (s *fakeChangeLogSocket) sendEvent(event params.ChangeLogEvent) error {
+	s.events <- event
+	return nil
+}
+
+type fakeChangeLogSource struct {
+	mu         sync.Mutex
+	events     []params.ChangeLogEvent
+	namespaces []string
+	err        error
+}
+
+func newFakeChangeLogSource(ids ...int64) *fakeChangeLogSource {
+	source := &fakeChangeLogSource{}
+	source.add(ids...)
+	return source
+}
+
+func (s *fakeChangeLogSource) add(ids ...int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		s.events = append(s.events, params.ChangeLogEvent{
+			ID:        id,
+			Namespace: "external_controller",
+			EditType:  "create",
+		})
+	}
+}
+
+func (s *fakeChangeLogSource) ReadChanges(_ context.Context, cursor int64, namespaces []string, limit int) ([]params.ChangeLogEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.namespaces = namespaces
+	if s.err != nil {
+		return nil, s.err
+	}
+	var result []params.ChangeLogEvent
+	for _, event := range s.events {
+		if event.ID > cursor && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/rpc/params"
+)
+
+// charmhubMirrorPrefix is the path under which the controller serves the
+// charmhub API from its mirror.
+const charmhubMirrorPrefix = mirror.PathPrefix
+
+// charmhubMirrorUploadHandler handles uploads of offline charmhub exports
+// into the controller's charmhub mirror.
+type charmhubMirrorUploadHandler struct {
+	mirror *mirror.Mirror
+}
+
+// ServeHTTP implements http.Handler.
+func (h *charmhubMirrorUploadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "POST":
+		result, err := h.mirror.Import(r.Body)
+		if err != nil {
+			if err := sendError(w, errors.Annotate(err, "importing charmhub export")); err != nil {
+				logger.Errorf("%v", err)
+			}
+			return
+		}
+		if err := sendStatusAndJSON(w, http.StatusOK, &params.CharmhubMirrorImportResult{
+			Entities:   result.Entities,
+			BlobsAdded: result.BlobsAdded,
+		}); err != nil {
+			logger.Errorf("%v", err)
+		}
+	default:
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", r.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/rpc/params"
+)
+
+type charmhubMirrorSuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&charmhubMirrorSuite{})
+
+func (s *charmhubMirrorSuite) exportArchive(c *gc.C, content []byte) (*bytes.Buffer, string) {
+	dir := c.MkDir()
+	sha384 := fmt.Sprintf("%x", sha512.Sum384(content))
+	blob := mirror.Blob{
+		Path:   mirror.BlobPath(sha384),
+		Size:   int64(len(content)),
+		SHA256: fmt.Sprintf("%x", sha256.Sum256(content)),
+		SHA384: sha384,
+	}
+	err := os.MkdirAll(filepath.Join(dir, mirror.BlobsDir), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(dir, filepath.FromSlash(blob.Path)), content, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	base := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	f, err := os.Create(filepath.Join(dir, mirror.ManifestFile))
+	c.Assert(err, jc.ErrorIsNil)
+	err = mirror.WriteManifest(f, mirror.Manifest{
+		Version: mirror.ManifestVersion,
+		Entities: []mirror.Entity{{
+			Type: transport.CharmType,
+			ID:   "foo-id",
+			Name: "foo",
+			Revisions: []mirror.Revision{{
+				Revision: 1,
+				Bases:    []transport.Base{base},
+				Archive:  blob,
+			}},
+			Channels: []mirror.Channel{{
+				Track: "latest", Risk: "stable", Base: base, Revision: 1,
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	c.Assert(mirror.ArchiveDir(&buf, dir), jc.ErrorIsNil)
+	return &buf, sha384
+}
+
+func (s *charmhubMirrorSuite) TestUploadRequiresAuth(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "POST",
+		URL:    s.URL("/charmhub-mirror", nil).String(),
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusUnauthorized, "text/plain; charset=utf-8")
+	c.Check(string(body), gc.Equals, "authentication failed: no credentials provided\n")
+}
+
+func (s *charmhubMirrorSuite) TestUploadAndServe(c *gc.C) {
+	archive, sha384 := s.exportArchive(c, []byte("foo charm"))
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.URL("/charmhub-mirror", nil).String(),
+		ContentType: "application/x-tar-gz",
+		Body:        archive,
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var result params.CharmhubMirrorImportResult
+	c.Assert(json.Unmarshal(body, &result), jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.CharmhubMirrorImportResult{Entities: 1, BlobsAdded: 1})
+
+	// The mirror itself is served without authentication.
+	resp = apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.URL("/charmhub/v2/charms/info/foo", nil).String(),
+	})
+	body = apitesting.AssertResponse(c, resp, http.StatusOK, "application/json")
+	var info transport.InfoResponse
+	c.Assert(json.Unmarshal(body, &info), jc.ErrorIsNil)
+	c.Check(info.Name, gc.Equals, "foo")
+	c.Check(info.DefaultRelease.Revision.Download.URL, gc.Equals, s.URL("/charmhub/download/"+sha384, nil).String())
+
+	resp = apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.URL("/charmhub/download/"+sha384, nil).String(),
+	})
+	body = apitesting.AssertResponse(c, resp, http.StatusOK, "application/octet-stream")
+	c.Check(string(body), gc.Equals, "foo charm")
+}
+
+func (s *charmhubMirrorSuite) TestUploadInvalidArchive(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method:      "POST",
+		URL:         s.URL("/charmhub-mirror", nil).String(),
+		ContentType: "application/x-tar-gz",
+		Body:        bytes.NewBufferString("not an archive"),
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusInternalServerError, params.ContentTypeJSON)
+	var result params.CharmhubMirrorImportResult
+	c.Assert(json.Unmarshal(body, &result), jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Message, gc.Matches, "importing charmhub export: reading export archive: .*")
+}
@@ -0,0 +1,23 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/rpc/params"
+)
+
+// PrecheckIssuesToParams converts the issues found by the migration
+// prechecks to their wire representation.
+func PrecheckIssuesToParams(issues coremigration.PrecheckIssues) []params.MigrationPrecheckIssue {
+	out := make([]params.MigrationPrecheckIssue, len(issues))
+	for i, issue := range issues {
+		out[i] = params.MigrationPrecheckIssue{
+			Severity:   string(issue.Severity),
+			Controller: issue.Controller,
+			Message:    issue.Message,
+		}
+	}
+	return out
+}
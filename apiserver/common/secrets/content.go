@@ -0,0 +1,128 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+)
+
+// IsControllerOnlyBackendType returns true if only controllers can access
+// backends of the given type. Agents are given the content of revisions
+// held in such backends, rather than a reference to it.
+func IsControllerOnlyBackendType(backendType string) bool {
+	p, err := GetProvider(backendType)
+	if err != nil {
+		return false
+	}
+	return provider.HasControllerOnlyAccess(p)
+}
+
+// GetControllerOnlyContent returns the content of the revision referenced
+// by ref, which is held in a backend which only controllers can access.
+func GetControllerOnlyContent(adminConfigGetter BackendAdminConfigGetter, ref *coresecrets.ValueRef) (coresecrets.SecretValue, error) {
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, ok, err := controllerOnlyBackend(cfgInfo, ref.BackendID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		return nil, errors.NotValidf("secret backend %q accessible to agents", ref.BackendID)
+	}
+	val, err := backend.GetContent(context.TODO(), ref.RevisionID)
+	return val, errors.Trace(err)
+}
+
+// SaveControllerOnlyContent saves content sent by an agent to the model's
+// active backend if only controllers can access it, and returns a reference
+// to the saved content. Otherwise it returns nil, and the content is to be
+// saved in the controller database.
+func SaveControllerOnlyContent(
+	adminConfigGetter BackendAdminConfigGetter, uri *coresecrets.URI, revision int, data coresecrets.SecretData,
+) (*coresecrets.ValueRef, error) {
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return saveControllerOnlyContent(cfgInfo, uri, revision, data)
+}
+
+func saveControllerOnlyContent(
+	cfgInfo *provider.ModelBackendConfigInfo, uri *coresecrets.URI, revision int, data coresecrets.SecretData,
+) (*coresecrets.ValueRef, error) {
+	backend, ok, err := controllerOnlyBackend(cfgInfo, cfgInfo.ActiveID)
+	if err != nil || !ok {
+		return nil, errors.Trace(err)
+	}
+	revisionID, err := backend.SaveContent(context.TODO(), uri, revision, coresecrets.NewSecretValue(data))
+	if err != nil {
+		return nil, errors.Annotatef(err, "saving content for secret %q", uri.ID)
+	}
+	return &coresecrets.ValueRef{
+		BackendID:  cfgInfo.ActiveID,
+		RevisionID: revisionID,
+	}, nil
+}
+
+// DeleteControllerOnlyContent deletes the content referenced by ref if it
+// is held in a backend which only controllers can access. Agents delete
+// content held in other backends themselves.
+func DeleteControllerOnlyContent(adminConfigGetter BackendAdminConfigGetter, ref *coresecrets.ValueRef) error {
+	cfgInfo, err := adminConfigGetter()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return deleteControllerOnlyContent(cfgInfo, ref)
+}
+
+func deleteControllerOnlyContent(cfgInfo *provider.ModelBackendConfigInfo, ref *coresecrets.ValueRef) error {
+	backend, ok, err := controllerOnlyBackend(cfgInfo, ref.BackendID)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	err = backend.DeleteContent(context.TODO(), ref.RevisionID)
+	if errors.Is(err, errors.NotFound) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// hasControllerOnlyBackend returns true if any of the backends can only
+// be accessed by controllers.
+func hasControllerOnlyBackend(cfgInfo *provider.ModelBackendConfigInfo) bool {
+	for _, cfg := range cfgInfo.Configs {
+		if IsControllerOnlyBackendType(cfg.BackendType) {
+			return true
+		}
+	}
+	return false
+}
+
+// controllerOnlyBackend returns the backend with the given id, and true if
+// only controllers can access it.
+func controllerOnlyBackend(cfgInfo *provider.ModelBackendConfigInfo, backendID string) (provider.SecretsBackend, bool, error) {
+	cfg, ok := cfgInfo.Configs[backendID]
+	if !ok {
+		return nil, false, errors.NotFoundf("secret backend %q", backendID)
+	}
+	p, err := GetProvider(cfg.BackendType)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !provider.HasControllerOnlyAccess(p) {
+		return nil, false, nil
+	}
+	backend, err := p.NewBackend(&cfg)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	return backend, true, nil
+}
@@ -23,9 +23,10 @@ type SecretsDrainAPI struct {
 	resources         facade.Resources
 	leadershipChecker leadership.Checker
 
-	model           Model
-	secretsState    SecretsMetaState
-	secretsConsumer SecretsConsumer
+	model             Model
+	secretsState      SecretsMetaState
+	secretsConsumer   SecretsConsumer
+	adminConfigGetter BackendAdminConfigGetter
 }
 
 // NewSecretsDrainAPI returns a new SecretsDrainAPI.
@@ -37,6 +38,7 @@ func NewSecretsDrainAPI(
 	model Model,
 	secretsState SecretsMetaState,
 	secretsConsumer SecretsConsumer,
+	adminConfigGetter BackendAdminConfigGetter,
 ) (*SecretsDrainAPI, error) {
 	if !authorizer.AuthUnitAgent() && !authorizer.AuthApplicationAgent() && !authorizer.AuthController() {
 		return nil, apiservererrors.ErrPerm
@@ -48,6 +50,7 @@ func NewSecretsDrainAPI(
 		model:             model,
 		secretsState:      secretsState,
 		secretsConsumer:   secretsConsumer,
+		adminConfigGetter: adminConfigGetter,
 	}, nil
 }
 
@@ -107,7 +110,61 @@ func (s *SecretsDrainAPI) changeSecretBackendForOne(arg params.ChangeSecretBacke
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return s.secretsState.ChangeSecretBackend(toChangeSecretBackendParams(token, uri, arg))
+	p := toChangeSecretBackendParams(token, uri, arg)
+
+	// Agents can't access backends which only controllers can access, so
+	// the content they drain to or from such backends is saved and deleted
+	// here.
+	cfgInfo, err := s.adminConfigGetter()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !hasControllerOnlyBackend(cfgInfo) {
+		return s.secretsState.ChangeSecretBackend(p)
+	}
+	oldRef, err := s.revisionValueRef(uri, arg.Revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var savedRef *coresecrets.ValueRef
+	if p.ValueRef == nil {
+		if savedRef, err = saveControllerOnlyContent(cfgInfo, uri, arg.Revision, p.Data); err != nil {
+			return errors.Trace(err)
+		}
+		if savedRef != nil {
+			p.ValueRef = savedRef
+			p.Data = nil
+		}
+	}
+	if err := s.secretsState.ChangeSecretBackend(p); err != nil {
+		// The revision still refers to its old content, so nothing
+		// refers to the content saved above.
+		if savedRef != nil {
+			if err2 := deleteControllerOnlyContent(cfgInfo, savedRef); err2 != nil {
+				logger.Warningf("cleaning up drained content for secret %q: %v", uri, err2)
+			}
+		}
+		return errors.Trace(err)
+	}
+	if oldRef == nil || (p.ValueRef != nil && *p.ValueRef == *oldRef) {
+		return nil
+	}
+	return errors.Annotatef(deleteControllerOnlyContent(cfgInfo, oldRef), "removing drained content for secret %q", uri.ID)
+}
+
+// revisionValueRef returns the reference to the content of the secret
+// revision, or nil if it is held in the controller database.
+func (s *SecretsDrainAPI) revisionValueRef(uri *coresecrets.URI, revision int) (*coresecrets.ValueRef, error) {
+	revs, err := s.secretsState.ListSecretRevisions(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rev := range revs {
+		if rev.Revision == revision {
+			return rev.ValueRef, nil
+		}
+	}
+	return nil, errors.NotFoundf("secret %q revision %d", uri.ID, revision)
 }
 
 func toChangeSecretBackendParams(token leadership.Token, uri *coresecrets.URI, arg params.ChangeSecretBackendArg) state.ChangeSecretBackendParams {
@@ -77,6 +77,14 @@ func (s *secretsDrainSuite) setup(c *gc.C) *gomock.Controller {
 		s.model,
 		s.secretsMetaState,
 		s.secretsConsumer,
+		func() (*provider.ModelBackendConfigInfo, error) {
+			return &provider.ModelBackendConfigInfo{
+				ActiveID: "backend-id",
+				Configs: map[string]provider.ModelBackendConfig{
+					"backend-id": {BackendConfig: provider.BackendConfig{BackendType: "some-backend"}},
+				},
+			}, nil
+		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	return ctrl
@@ -329,6 +337,95 @@ func (s *secretsDrainSuite) TestChangeSecretBackend(c *gc.C) {
 	})
 }
 
+func (s *secretsDrainSuite) TestChangeSecretBackendControllerOnly(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	backend := mocks.NewMockSecretsBackend(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return controllerOnlyProvider{s.provider}, nil
+	})
+	s.provider.EXPECT().NewBackend(gomock.Any()).Return(backend, nil).Times(2)
+
+	s.expectSecretAccessQuery(2)
+	uri := coresecrets.NewURI()
+	s.leadership.EXPECT().LeadershipCheck("mariadb", "mariadb/0").Return(s.token)
+	s.token.EXPECT().Check().Return(nil)
+	s.secretsMetaState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 666,
+		ValueRef: &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "old-rev-666"},
+	}}, nil)
+	// The agent can't access the backend, so the controller saves
+	// the content and removes the old content.
+	backend.EXPECT().SaveContent(gomock.Any(), uri, 666, coresecrets.NewSecretValue(map[string]string{"foo": "bar"})).
+		Return("rev-666", nil)
+	s.secretsMetaState.EXPECT().ChangeSecretBackend(
+		state.ChangeSecretBackendParams{
+			Token:    s.token,
+			URI:      uri,
+			Revision: 666,
+			ValueRef: &coresecrets.ValueRef{
+				BackendID:  "backend-id",
+				RevisionID: "rev-666",
+			},
+		},
+	).Return(nil)
+	backend.EXPECT().DeleteContent(gomock.Any(), "old-rev-666").Return(nil)
+
+	result, err := s.facade.ChangeSecretBackend(params.ChangeSecretBackendArgs{
+		Args: []params.ChangeSecretBackendArg{{
+			URI:      uri.String(),
+			Revision: 666,
+			Content: params.SecretContentParams{
+				Data: map[string]string{"foo": "bar"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	})
+}
+
+func (s *secretsDrainSuite) TestChangeSecretBackendControllerOnlyFails(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	backend := mocks.NewMockSecretsBackend(ctrl)
+	s.PatchValue(&secrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return controllerOnlyProvider{s.provider}, nil
+	})
+	s.provider.EXPECT().NewBackend(gomock.Any()).Return(backend, nil).Times(2)
+
+	s.expectSecretAccessQuery(2)
+	uri := coresecrets.NewURI()
+	s.leadership.EXPECT().LeadershipCheck("mariadb", "mariadb/0").Return(s.token)
+	s.token.EXPECT().Check().Return(nil)
+	s.secretsMetaState.EXPECT().ListSecretRevisions(uri).Return([]*coresecrets.SecretRevisionMetadata{{
+		Revision: 666,
+		ValueRef: &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "old-rev-666"},
+	}}, nil)
+	backend.EXPECT().SaveContent(gomock.Any(), uri, 666, coresecrets.NewSecretValue(map[string]string{"foo": "bar"})).
+		Return("rev-666", nil)
+	s.secretsMetaState.EXPECT().ChangeSecretBackend(gomock.Any()).Return(errors.New("boom"))
+	// The revision keeps its old content, so the newly saved content
+	// is removed instead.
+	backend.EXPECT().DeleteContent(gomock.Any(), "rev-666").Return(nil)
+
+	result, err := s.facade.ChangeSecretBackend(params.ChangeSecretBackendArgs{
+		Args: []params.ChangeSecretBackendArg{{
+			URI:      uri.String(),
+			Revision: 666,
+			Content: params.SecretContentParams{
+				Data: map[string]string{"foo": "bar"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
 func (s *secretsDrainSuite) TestWatchSecretBackendChanged(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -367,3 +464,9 @@ func (s *secretsDrainSuite) TestWatchSecretBackendChanged(c *gc.C) {
 		c.Fatalf("timed out waiting for 2nd loop")
 	}
 }
+
+type controllerOnlyProvider struct {
+	provider.SecretBackendProvider
+}
+
+func (controllerOnlyProvider) ControllerOnlyAccess() {}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/websocket"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/state"
 )
@@ -78,11 +80,19 @@ func newDebugLogHandler(
 //	excludeEntity -> []string - lists entity tags to exclude from the response
 //	   - as with include, it may finish with a '*'
 //	excludeModule -> []string - lists logging modules to exclude from the response
+//	includeMessage -> []string - lists regular expressions matched against messages
+//	   - if any are set, only messages matching at least one are included
+//	excludeMessage -> []string - lists regular expressions matched against messages
+//	   - messages matching any of them are excluded from the response
 //	limit -> uint - show *at most* this many lines
 //	backlog -> uint
 //	   - go back this many lines from the end before starting to filter
 //	   - has no meaning if 'replay' is true
 //	level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
+//	maxLevel -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
+//	   - the most severe level to include in the response
+//	startTime -> string - RFC3339 time, only show lines logged on or after it
+//	endTime -> string - RFC3339 time, only show lines logged on or before it
 //	replay -> string - one of [true, false], if true, start the file from the start
 //	noTail -> string - one of [true, false], if true, existing logs are sent back,
 //	   - but the command does not wait for new ones.
@@ -181,18 +191,22 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
-	includeLabel  []string
-	excludeLabel  []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	maxLevel       loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeLabel   []string
+	excludeLabel   []string
+	includeMessage []*regexp.Regexp
+	excludeMessage []*regexp.Regexp
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -231,15 +245,21 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 	}
 
 	if value := queryMap.Get("level"); value != "" {
-		var ok bool
-		level, ok := loggo.ParseLevel(value)
-		if !ok || level < loggo.TRACE || level > loggo.ERROR {
-			return params, errors.Errorf("level value %q is not one of %q, %q, %q, %q, %q",
-				value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		level, err := parseDebugLogLevel("level", value)
+		if err != nil {
+			return params, errors.Trace(err)
 		}
 		params.filterLevel = level
 	}
 
+	if value := queryMap.Get("maxLevel"); value != "" {
+		level, err := parseDebugLogLevel("maxLevel", value)
+		if err != nil {
+			return params, errors.Trace(err)
+		}
+		params.maxLevel = level
+	}
+
 	if value := queryMap.Get("startTime"); value != "" {
 		startTime, err := time.Parse(time.RFC3339Nano, value)
 		if err != nil {
@@ -248,6 +268,29 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		params.endTime = endTime
+	}
+
+	for _, value := range queryMap["includeMessage"] {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return params, errors.Errorf("includeMessage value %q is not a valid regular expression", value)
+		}
+		params.includeMessage = append(params.includeMessage, re)
+	}
+	for _, value := range queryMap["excludeMessage"] {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return params, errors.Errorf("excludeMessage value %q is not a valid regular expression", value)
+		}
+		params.excludeMessage = append(params.excludeMessage, re)
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -262,3 +305,35 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 
 	return params, nil
 }
+
+func parseDebugLogLevel(name, value string) (loggo.Level, error) {
+	level, ok := loggo.ParseLevel(value)
+	if !ok || level < loggo.TRACE || level > loggo.ERROR {
+		return level, errors.Errorf("%s value %q is not one of %q, %q, %q, %q, %q",
+			name, value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+	}
+	return level, nil
+}
+
+// matches reports whether the record passes the filters that are not
+// applied by the log tailer: the message expressions and the maximum
+// level. The end time is handled separately, since it ends the request.
+func (p debugLogParams) matches(rec *corelogger.LogRecord) bool {
+	if p.maxLevel != loggo.UNSPECIFIED && rec.Level > p.maxLevel {
+		return false
+	}
+	for _, re := range p.excludeMessage {
+		if re.MatchString(rec.Message) {
+			return false
+		}
+	}
+	if len(p.includeMessage) == 0 {
+		return true
+	}
+	for _, re := range p.includeMessage {
+		if re.MatchString(rec.Message) {
+			return true
+		}
+	}
+	return false
+}
@@ -56,6 +56,16 @@ func handleDebugLogDBRequest(
 				return errors.Annotate(tailer.Err(), "tailer stopped")
 			}
 
+			// Records from different agents can arrive out of order,
+			// so a record logged after the end time doesn't mean that
+			// all those logged before it have been seen.
+			if !reqParams.endTime.IsZero() && rec.Time.After(reqParams.endTime) {
+				continue
+			}
+			if !reqParams.matches(rec) {
+				continue
+			}
+
 			if err := socket.sendLogRecord(formatLogRecord(rec)); err != nil {
 				return errors.Annotate(err, "sending failed")
 			}
@@ -5,6 +5,8 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -222,6 +224,124 @@ func (s *debugLogDBIntSuite) TestMaxLines(c *gc.C) {
 	s.assertStops(c, done, tailer)
 }
 
+func (s *debugLogDBIntSuite) TestMessageAndLevelFilters(c *gc.C) {
+	tailer := newFakeLogTailer()
+	for _, rec := range []struct {
+		level   loggo.Level
+		message string
+	}{
+		{loggo.INFO, "hook install started"},
+		{loggo.INFO, "hook install completed"},
+		{loggo.ERROR, "hook install failed"},
+		{loggo.DEBUG, "connecting to api"},
+		{loggo.INFO, "hook config-changed started"},
+	} {
+		tailer.logsCh <- &corelogger.LogRecord{
+			Time:     time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC),
+			Entity:   "unit-foo-0",
+			Module:   "some.where",
+			Location: "code.go:42",
+			Level:    rec.level,
+			Message:  rec.message,
+		}
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
+		return tailer, nil
+	})
+
+	done := s.runRequest(debugLogParams{
+		maxLines:       2,
+		maxLevel:       loggo.WARNING,
+		includeMessage: []*regexp.Regexp{regexp.MustCompile("^hook"), regexp.MustCompile("api")},
+		excludeMessage: []*regexp.Regexp{regexp.MustCompile("completed$")},
+	}, nil)
+
+	s.assertOutput(c, []string{
+		"ok",
+		"unit-foo-0: 2015-06-19 15:34:37 INFO some.where code.go:42 hook install started\n",
+		"unit-foo-0: 2015-06-19 15:34:37 DEBUG some.where code.go:42 connecting to api\n",
+	})
+
+	// Only records that were sent count towards the line limit.
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestEndTime(c *gc.C) {
+	tailer := newFakeLogTailer()
+	start := time.Date(2015, 6, 19, 15, 34, 37, 0, time.UTC)
+	// The record logged after the end time arrives before one logged
+	// within the window.
+	for _, i := range []int{0, 2, 1} {
+		tailer.logsCh <- &corelogger.LogRecord{
+			Time:     start.Add(time.Duration(i) * time.Minute),
+			Entity:   "machine-99",
+			Module:   "some.where",
+			Location: "code.go:42",
+			Level:    loggo.INFO,
+			Message:  fmt.Sprintf("message %d", i),
+		}
+	}
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params corelogger.LogTailerParams) (corelogger.LogTailer, error) {
+		return tailer, nil
+	})
+
+	stop := make(chan struct{})
+	done := s.runRequest(debugLogParams{endTime: start.Add(time.Minute)}, stop)
+
+	s.assertOutput(c, []string{
+		"ok",
+		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 message 0\n",
+		"machine-99: 2015-06-19 15:35:37 INFO some.where code.go:42 message 1\n",
+	})
+
+	// Records logged after the end time are skipped.
+	s.assertRunning(c, done, tailer)
+	c.Assert(s.sock.writes, gc.HasLen, 0)
+
+	close(stop)
+	s.assertStops(c, done, tailer)
+}
+
+func (s *debugLogDBIntSuite) TestReadParams(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"maxLevel":       {"WARNING"},
+		"endTime":        {"2016-11-30T11:48:00.0000001Z"},
+		"includeMessage": {"^hook", "api"},
+		"excludeMessage": {"completed$"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(params.maxLevel, gc.Equals, loggo.WARNING)
+	c.Check(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC))
+	c.Assert(params.includeMessage, gc.HasLen, 2)
+	c.Check(params.includeMessage[0].String(), gc.Equals, "^hook")
+	c.Check(params.includeMessage[1].String(), gc.Equals, "api")
+	c.Assert(params.excludeMessage, gc.HasLen, 1)
+	c.Check(params.excludeMessage[0].String(), gc.Equals, "completed$")
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsErrors(c *gc.C) {
+	for i, test := range []struct {
+		query    url.Values
+		errMatch string
+	}{{
+		query:    url.Values{"maxLevel": {"BOGUS"}},
+		errMatch: `maxLevel value "BOGUS" is not one of "TRACE", "DEBUG", "INFO", "WARNING", "ERROR"`,
+	}, {
+		query:    url.Values{"endTime": {"yesterday"}},
+		errMatch: `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query:    url.Values{"includeMessage": {"("}},
+		errMatch: `includeMessage value "\(" is not a valid regular expression`,
+	}, {
+		query:    url.Values{"excludeMessage": {"[a"}},
+		errMatch: `excludeMessage value "\[a" is not a valid regular expression`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
 func (s *debugLogDBIntSuite) runRequest(params debugLogParams, stop chan struct{}) chan error {
 	done := make(chan error)
 	go func() {
@@ -79,6 +79,7 @@ var allowedEmbeddedCommands = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-leadership-history",
 	"show-machine",
 	"show-model",
 	"show-offer",
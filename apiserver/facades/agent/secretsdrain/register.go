@@ -11,6 +11,7 @@ import (
 	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/secrets/provider"
 	"github.com/juju/juju/state"
 )
 
@@ -43,5 +44,8 @@ func newSecretsDrainAPI(context facade.Context) (*commonsecrets.SecretsDrainAPI,
 		commonsecrets.SecretsModel(model),
 		state.NewSecrets(context.State()),
 		context.State(),
+		func() (*provider.ModelBackendConfigInfo, error) {
+			return commonsecrets.AdminBackendConfigInfo(commonsecrets.SecretsModel(model))
+		},
 	)
 }
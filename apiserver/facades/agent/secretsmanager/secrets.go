@@ -265,12 +265,17 @@ func (s *SecretsManagerAPI) createSecret(arg params.CreateSecretArg) (string, er
 	if arg.RotatePolicy.WillRotate() {
 		nextRotateTime = arg.RotatePolicy.NextRotateTime(s.clock.Now())
 	}
+	valueRef, err := s.saveControllerOnlyContent(uri, 1, &arg.UpsertSecretArg)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
 	md, err := s.secretsState.CreateSecret(uri, state.CreateSecretParams{
 		Version:            secrets.Version,
 		Owner:              secretOwner,
 		UpdateSecretParams: fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime),
 	})
 	if err != nil {
+		s.deleteControllerOnlyContent(uri, valueRef)
 		return "", errors.Trace(err)
 	}
 	err = s.secretsConsumer.GrantSecretAccess(uri, state.SecretAccessParams{
@@ -347,10 +352,49 @@ func (s *SecretsManagerAPI) updateSecret(arg params.UpdateSecretArg) error {
 	if !md.RotatePolicy.WillRotate() && arg.RotatePolicy.WillRotate() {
 		nextRotateTime = arg.RotatePolicy.NextRotateTime(s.clock.Now())
 	}
+	valueRef, err := s.saveControllerOnlyContent(uri, md.LatestRevision+1, &arg.UpsertSecretArg)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	_, err = s.secretsState.UpdateSecret(uri, fromUpsertParams(arg.UpsertSecretArg, token, nextRotateTime))
+	if err != nil {
+		s.deleteControllerOnlyContent(uri, valueRef)
+	}
 	return errors.Trace(err)
 }
 
+// saveControllerOnlyContent saves the content sent by the agent to the
+// active backend if only controllers can access it, and replaces the
+// content in arg with a reference to the saved content.
+func (s *SecretsManagerAPI) saveControllerOnlyContent(
+	uri *coresecrets.URI, revision int, arg *params.UpsertSecretArg,
+) (*coresecrets.ValueRef, error) {
+	if len(arg.Content.Data) == 0 || arg.Content.ValueRef != nil {
+		return nil, nil
+	}
+	valueRef, err := commonsecrets.SaveControllerOnlyContent(s.adminConfigGetter, uri, revision, arg.Content.Data)
+	if err != nil || valueRef == nil {
+		return nil, errors.Trace(err)
+	}
+	arg.Content.Data = nil
+	arg.Content.ValueRef = &params.SecretValueRef{
+		BackendID:  valueRef.BackendID,
+		RevisionID: valueRef.RevisionID,
+	}
+	return valueRef, nil
+}
+
+// deleteControllerOnlyContent cleans up content saved by
+// saveControllerOnlyContent for a secret which could not be written.
+func (s *SecretsManagerAPI) deleteControllerOnlyContent(uri *coresecrets.URI, valueRef *coresecrets.ValueRef) {
+	if valueRef == nil {
+		return
+	}
+	if err := commonsecrets.DeleteControllerOnlyContent(s.adminConfigGetter, valueRef); err != nil {
+		logger.Warningf("cleaning up content for secret %q: %v", uri, err)
+	}
+}
+
 // RemoveSecrets removes the specified secrets.
 func (s *SecretsManagerAPI) RemoveSecrets(args params.DeleteSecretArgs) (params.ErrorResults, error) {
 	return commonsecrets.RemoveSecretsForAgent(
@@ -563,15 +607,30 @@ func (s *SecretsManagerAPI) GetSecretRevisionContentInfo(arg params.SecretRevisi
 		}
 		contentParams := params.SecretContentParams{}
 		if valueRef != nil {
-			contentParams.ValueRef = &params.SecretValueRef{
-				BackendID:  valueRef.BackendID,
-				RevisionID: valueRef.RevisionID,
-			}
 			backend, draining, err := s.getBackend(valueRef.BackendID)
 			if err != nil {
 				result.Results[i].Error = apiservererrors.ServerError(err)
 				continue
 			}
+			if commonsecrets.IsControllerOnlyBackendType(backend.BackendType) {
+				// The agent can't access the backend, so it's given the
+				// content rather than a reference to it. The content is
+				// deleted by the controller when the revision is removed.
+				if !arg.PendingDelete {
+					val, err := commonsecrets.GetControllerOnlyContent(s.adminConfigGetter, valueRef)
+					if err != nil {
+						result.Results[i].Error = apiservererrors.ServerError(err)
+						continue
+					}
+					contentParams.Data = val.EncodedValues()
+				}
+				result.Results[i].Content = contentParams
+				continue
+			}
+			contentParams.ValueRef = &params.SecretValueRef{
+				BackendID:  valueRef.BackendID,
+				RevisionID: valueRef.RevisionID,
+			}
 			result.Results[i].BackendConfig = &params.SecretBackendConfigResult{
 				ControllerUUID: backend.ControllerUUID,
 				ModelUUID:      backend.ModelUUID,
@@ -752,7 +811,15 @@ func (s *SecretsManagerAPI) getSecretContent(arg params.GetSecretContentArg) (
 		return content, nil, false, errors.Trace(err)
 	}
 	backend, draining, err := s.getBackend(content.ValueRef.BackendID)
-	return content, backend, draining, errors.Trace(err)
+	if err != nil {
+		return content, nil, false, errors.Trace(err)
+	}
+	if commonsecrets.IsControllerOnlyBackendType(backend.BackendType) {
+		// The agent can't access the backend, so it's given the content.
+		val, err := commonsecrets.GetControllerOnlyContent(s.adminConfigGetter, content.ValueRef)
+		return &secrets.ContentParams{SecretValue: val}, nil, false, errors.Trace(err)
+	}
+	return content, backend, draining, nil
 }
 
 // UpdateTrackedRevisions updates the consumer info to track the latest
@@ -315,6 +315,69 @@ func (s *SecretsManagerSuite) TestCreateSecrets(c *gc.C) {
 	})
 }
 
+func (s *SecretsManagerSuite) TestCreateSecretsControllerOnlyBackend(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	backend := s.expectControllerOnlyBackend(ctrl)
+
+	s.leadership.EXPECT().LeadershipCheck("mariadb", "mariadb/0").Return(s.token)
+	s.token.EXPECT().Check().Return(nil)
+	// The agent sends the content, which the controller saves to the backend.
+	backend.EXPECT().SaveContent(gomock.Any(), gomock.Any(), 1, coresecrets.NewSecretValue(map[string]string{"foo": "bar"})).
+		Return("rev-id", nil)
+	s.secretsState.EXPECT().CreateSecret(gomock.Any(), state.CreateSecretParams{
+		Version: secrets.Version,
+		Owner:   names.NewApplicationTag("mariadb"),
+		UpdateSecretParams: state.UpdateSecretParams{
+			LeaderToken: s.token,
+			ValueRef:    &coresecrets.ValueRef{BackendID: "backend-id", RevisionID: "rev-id"},
+		},
+	}).DoAndReturn(func(uri *coresecrets.URI, p state.CreateSecretParams) (*coresecrets.SecretMetadata, error) {
+		ownerTag := names.NewApplicationTag("mariadb")
+		s.secretsConsumer.EXPECT().GrantSecretAccess(uri, state.SecretAccessParams{
+			LeaderToken: s.token,
+			Scope:       ownerTag,
+			Subject:     ownerTag,
+			Role:        coresecrets.RoleManage,
+		}).Return(nil)
+		return &coresecrets.SecretMetadata{URI: uri, LatestRevision: 1}, nil
+	})
+
+	results, err := s.facade.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			OwnerTag: "application-mariadb",
+			UpsertSecretArg: params.UpsertSecretArg{
+				Content: params.SecretContentParams{Data: map[string]string{"foo": "bar"}},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *SecretsManagerSuite) expectControllerOnlyBackend(ctrl *gomock.Controller) *mocks.MockSecretsBackend {
+	s.PatchValue(&commonsecrets.GetProvider, func(string) (provider.SecretBackendProvider, error) {
+		return controllerOnlyProvider{s.provider}, nil
+	})
+	backend := mocks.NewMockSecretsBackend(ctrl)
+	s.provider.EXPECT().NewBackend(gomock.Any()).DoAndReturn(func(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+		// The controller uses the admin config.
+		if cfg.Config["foo"] != "admin" {
+			return nil, errors.Unauthorizedf("not admin")
+		}
+		return backend, nil
+	})
+	return backend
+}
+
+type controllerOnlyProvider struct {
+	provider.SecretBackendProvider
+}
+
+func (controllerOnlyProvider) ControllerOnlyAccess() {}
+
 func (s *SecretsManagerSuite) TestCreateSecretDuplicateLabel(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -1657,6 +1720,37 @@ func (s *SecretsManagerSuite) TestGetSecretRevisionContentInfo(c *gc.C) {
 	})
 }
 
+func (s *SecretsManagerSuite) TestGetSecretRevisionContentInfoControllerOnlyBackend(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	backend := s.expectControllerOnlyBackend(ctrl)
+
+	uri := coresecrets.NewURI()
+	s.secretsConsumer.EXPECT().SecretAccess(uri, s.authTag).Return(coresecrets.RoleManage, nil)
+	s.secretsState.EXPECT().GetSecretValue(uri, 666).Return(
+		nil, &coresecrets.ValueRef{
+			BackendID:  "backend-id",
+			RevisionID: "rev-id",
+		}, nil,
+	)
+	backend.EXPECT().GetContent(gomock.Any(), "rev-id").Return(coresecrets.NewSecretValue(map[string]string{"foo": "bar"}), nil)
+
+	results, err := s.facade.GetSecretRevisionContentInfo(params.SecretRevisionArg{
+		URI:       uri.String(),
+		Revisions: []int{666},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The agent is given the content, and no backend config.
+	c.Assert(results, jc.DeepEquals, params.SecretContentResults{
+		Results: []params.SecretContentResult{{
+			Content: params.SecretContentParams{
+				Data: map[string]string{"foo": "bar"},
+			},
+		}},
+	})
+}
+
 func (s *SecretsManagerSuite) TestWatchObsolete(c *gc.C) {
 	defer s.setup(c).Finish()
 
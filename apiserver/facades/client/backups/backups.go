@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/controller"
 	corebase "github.com/juju/juju/core/base"
+	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/rpc/params"
@@ -40,13 +41,27 @@ type Backend interface {
 type API struct {
 	backend Backend
 	paths   *backups.Paths
+	getDB   func() (coredatabase.TrackedDB, error)
+	hub     facade.Hub
 
 	// machineID is the ID of the machine where the API server is running.
 	machineID string
 }
 
+// APIV3 provides the Backups API facade v3, which predates listing,
+// removing and restoring backups.
+type APIV3 struct {
+	*API
+}
+
 // NewAPI creates a new instance of the Backups API facade.
-func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+func NewAPI(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	getDB func() (coredatabase.TrackedDB, error),
+	hub facade.Hub,
+) (*API, error) {
 	err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
 	if err != nil &&
 		!errors.Is(err, authentication.ErrorEntityMissingPermission) &&
@@ -97,6 +112,8 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 	b := API{
 		backend:   backend,
 		paths:     &paths,
+		getDB:     getDB,
+		hub:       hub,
 		machineID: machineID,
 	}
 	return &b, nil
@@ -14,6 +14,7 @@ import (
 	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
+	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
@@ -27,6 +28,11 @@ type backupsSuite struct {
 	api        *backupsAPI.API
 	meta       *backups.Metadata
 	machineTag names.MachineTag
+	hub        *fakeHub
+}
+
+func (s *backupsSuite) getDB() (coredatabase.TrackedDB, error) {
+	return nil, nil
 }
 
 var _ = gc.Suite(&backupsSuite{})
@@ -53,6 +59,7 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 	err = agentConfig.Write()
 	c.Assert(err, jc.ErrorIsNil)
 
+	s.hub = &fakeHub{}
 	tag := names.NewLocalUserTag("admin")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
 	shim := &stateShim{
@@ -61,7 +68,7 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 		controllerNodesF: func() ([]state.ControllerNode, error) { return nil, nil },
 		machineF:         func(id string) (backupsAPI.Machine, error) { return &testMachine{}, nil },
 	}
-	s.api, err = backupsAPI.NewAPI(shim, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPI(shim, s.resources, s.authorizer, s.getDB, s.hub)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -86,13 +93,13 @@ func (s *backupsSuite) setBackups(c *gc.C, meta *backups.Metadata, err string) *
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
-	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer, s.getDB, s.hub)
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPINotAuthorized(c *gc.C) {
 	s.authorizer.Tag = names.NewApplicationTag("eggs")
-	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer, s.getDB, s.hub)
 	c.Check(errors.Cause(err), gc.Equals, apiservererrors.ErrPerm)
 }
 
@@ -101,7 +108,7 @@ func (s *backupsSuite) TestNewAPIHostedEnvironmentFails(c *gc.C) {
 	defer otherState.Close()
 	otherModel, err := otherState.Model()
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel}, s.resources, s.authorizer)
+	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel}, s.resources, s.authorizer, s.getDB, s.hub)
 	c.Check(err, gc.ErrorMatches, "backups are only supported from the controller model\nUse juju switch to select the controller model")
 }
 
@@ -112,6 +119,6 @@ func (s *backupsSuite) TestBackupsCAASFails(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	isController := true
-	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel, isController: &isController}, s.resources, s.authorizer)
+	_, err = backupsAPI.NewAPI(&stateShim{State: otherState, Model: otherModel, isController: &isController}, s.resources, s.authorizer, s.getDB, s.hub)
 	c.Assert(err, gc.ErrorMatches, "backups on kubernetes controllers not supported")
 }
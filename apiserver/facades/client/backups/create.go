@@ -39,6 +39,9 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	if err != nil {
 		return result, errors.Trace(err)
 	}
+	if dbInfo.ControllerDB, err = a.getDB(); err != nil {
+		return result, errors.Annotatef(err, "getting controller database")
+	}
 	mBase, err := a.backend.MachineBase(a.machineID)
 	if err != nil {
 		return result, errors.Trace(err)
@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/rpc/params"
+)
+
+// List is the API method that returns the backups stored on the
+// controller machine.
+func (a *API) List() (params.BackupsListResult, error) {
+	backupsMethods := newBackups(a.paths)
+
+	stored, err := backupsMethods.List()
+	if err != nil {
+		return params.BackupsListResult{}, errors.Trace(err)
+	}
+
+	result := params.BackupsListResult{
+		List: make([]params.BackupsMetadataResult, len(stored)),
+	}
+	for i, backup := range stored {
+		result.List[i] = CreateResult(backup.Metadata, backup.Filename)
+	}
+	return result, nil
+}
+
+// List isn't on the v3 API.
+func (*APIV3) List(_, _ struct{}) {}
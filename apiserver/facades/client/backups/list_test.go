@@ -0,0 +1,55 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/rpc/params"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	fake.Stored = []statebackups.StoredBackup{{
+		Filename: "/tmp/juju-backup-1.tar.gz",
+		Metadata: s.meta,
+	}}
+
+	result, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupsListResult{
+		List: []params.BackupsMetadataResult{
+			backups.CreateResult(s.meta, "/tmp/juju-backup-1.tar.gz"),
+		},
+	})
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+}
+
+func (s *backupsSuite) TestListError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.List()
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
+
+func (s *backupsSuite) TestRemove(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+
+	result, err := s.api.Remove(params.BackupsRemoveArgs{IDs: []string{"/tmp/juju-backup-1.tar.gz"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Remove"})
+	c.Check(fake.IDArg, gc.Equals, "/tmp/juju-backup-1.tar.gz")
+}
+
+func (s *backupsSuite) TestRemoveError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+
+	result, err := s.api.Remove(params.BackupsRemoveArgs{IDs: []string{"/tmp/juju-backup-1.tar.gz"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "failed!")
+}
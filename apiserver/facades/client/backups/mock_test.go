@@ -50,6 +50,17 @@ func (s stateShim) Machine(id string) (backups.Machine, error) {
 	return s.machineF(id)
 }
 
+type fakeHub struct {
+	topics    []string
+	published []interface{}
+}
+
+func (h *fakeHub) Publish(topic string, data interface{}) (func(), error) {
+	h.topics = append(h.topics, topic)
+	h.published = append(h.published, data)
+	return func() {}, nil
+}
+
 type testMachine struct {
 	*state.Machine
 }
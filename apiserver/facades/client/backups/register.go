@@ -14,10 +14,22 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Backups", 3, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV3(ctx)
+	}, reflect.TypeOf((*APIV3)(nil)))
+	registry.MustRegister("Backups", 4, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacade(ctx)
 	}, reflect.TypeOf((*API)(nil)))
 }
 
+// newFacadeV3 provides the required signature for facade v3 registration.
+func newFacadeV3(ctx facade.Context) (*APIV3, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{API: api}, nil
+}
+
 // newFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*API, error) {
 	st := ctx.State()
@@ -25,5 +37,5 @@ func newFacade(ctx facade.Context) (*API, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewAPI(&stateShim{st, model}, ctx.Resources(), ctx.Auth())
+	return NewAPI(&stateShim{st, model}, ctx.Resources(), ctx.Auth(), ctx.ControllerDB, ctx.Hub())
 }
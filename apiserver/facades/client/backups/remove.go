@@ -0,0 +1,26 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/rpc/params"
+)
+
+// Remove is the API method that removes backups stored on the
+// controller machine.
+func (a *API) Remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
+	backupsMethods := newBackups(a.paths)
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.IDs)),
+	}
+	for i, id := range args.IDs {
+		results.Results[i].Error = apiservererrors.ServerError(backupsMethods.Remove(id))
+	}
+	return results, nil
+}
+
+// Remove isn't on the v3 API.
+func (*APIV3) Remove(_, _ struct{}) {}
@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	controllermsg "github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state/backups"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// Restore is the API method that loads the databases in a backup stored
+// on the controller machine onto the controller. The backup must have
+// been taken from the same machine of the same controller, running the
+// same juju version, and the controller must not be in HA. The controller
+// agent restarts once the databases have been restored.
+func (a *API) Restore(args params.BackupsRestoreArgs) (params.BackupsMetadataResult, error) {
+	backupsMethods := newBackups(a.paths)
+
+	var result params.BackupsMetadataResult
+	nodes, err := a.backend.ControllerNodes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	m, err := a.backend.Machine(a.machineID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	target := backups.RestoreTarget{
+		ControllerUUID:  a.backend.ControllerTag().Id(),
+		Version:         jujuversion.Current,
+		ControllerNodes: len(nodes),
+		MachineID:       a.machineID,
+		InstanceID:      string(instanceID),
+	}
+
+	mgoInfo, err := mongoInfo(a.paths.DataDir, a.machineID)
+	if err != nil {
+		return result, errors.Annotatef(err, "getting mongo info")
+	}
+	session := a.backend.MongoSession().Copy()
+	defer session.Close()
+	dbInfo, err := backups.NewDBInfo(mgoInfo, sessionShim{session})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if dbInfo.ControllerDB, err = a.getDB(); err != nil {
+		return result, errors.Annotatef(err, "getting controller database")
+	}
+
+	meta, err := backupsMethods.Restore(args.ID, backups.RestoreArgs{
+		DBInfo: dbInfo,
+		Target: target,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	// Workers in the controller agent hold state read from the databases
+	// which have been replaced, so the agent is restarted.
+	if _, err := a.hub.Publish(controllermsg.Restored, controllermsg.RestoredMessage{BackupID: args.ID}); err != nil {
+		return result, errors.Annotate(err, "restarting controller agent")
+	}
+	return CreateResult(meta, args.ID), nil
+}
+
+// Restore isn't on the v3 API.
+func (*APIV3) Restore(_, _ struct{}) {}
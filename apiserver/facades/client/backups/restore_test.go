@@ -0,0 +1,41 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/backups"
+	controllermsg "github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/rpc/params"
+	jujuversion "github.com/juju/juju/version"
+)
+
+func (s *backupsSuite) TestRestore(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+
+	result, err := s.api.Restore(params.BackupsRestoreArgs{ID: "/tmp/juju-backup-1.tar.gz"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, backups.CreateResult(s.meta, "/tmp/juju-backup-1.tar.gz"))
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Restore"})
+	c.Check(fake.IDArg, gc.Equals, "/tmp/juju-backup-1.tar.gz")
+
+	target := fake.RestoreArgs.Target
+	c.Check(target.ControllerUUID, gc.Equals, s.State.ControllerUUID())
+	c.Check(target.Version, gc.Equals, jujuversion.Current)
+	c.Check(target.ControllerNodes, gc.Equals, 0)
+	c.Check(target.MachineID, gc.Equals, "0")
+	c.Check(target.InstanceID, gc.Equals, "inst-0")
+	c.Check(fake.RestoreArgs.DBInfo, gc.NotNil)
+	c.Check(s.hub.topics, jc.DeepEquals, []string{controllermsg.Restored})
+	c.Check(s.hub.published, jc.DeepEquals, []interface{}{controllermsg.RestoredMessage{BackupID: "/tmp/juju-backup-1.tar.gz"}})
+}
+
+func (s *backupsSuite) TestRestoreError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Restore(params.BackupsRestoreArgs{ID: "/tmp/juju-backup-1.tar.gz"})
+	c.Check(err, gc.ErrorMatches, "failed!")
+	c.Check(s.hub.published, gc.HasLen, 0)
+}
@@ -9,6 +9,7 @@ import (
 
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -32,6 +33,7 @@ type Backend interface {
 	Model() (*state.Model, error)
 	Application(name string) (Application, error)
 	MongoVersion() (string, error)
+	BackupStatus() (status.StatusInfo, error)
 	ControllerModelUUID() string
 	AllModelUUIDs() ([]string, error)
 	AllBlocksForController() ([]state.Block, error)
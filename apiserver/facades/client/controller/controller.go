@@ -64,9 +64,29 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv13 provides the v13 Controller API. The only difference
+// between this and v14 is that v13 doesn't have the BackupStatus method.
+type ControllerAPIv13 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv12 provides the v12 Controller API. The only difference
+// between this and v13 is that v12 doesn't have the ResumeMigration
+// method.
+type ControllerAPIv12 struct {
+	*ControllerAPIv13
+}
+
+// ControllerAPIv11 provides the v11 Controller API. The only difference
+// between this and v12 is that v11 doesn't have the DryRunMigration
+// method.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = newControllerAPIv11
+var LatestAPI = newControllerAPIv14
 
 // TestingAPI is an escape hatch for requesting a controller API that won't
 // allow auth to correctly happen for ModelStatus. I'm not convicned this
@@ -181,6 +201,25 @@ func (c *ControllerAPI) MongoVersion() (params.StringResult, error) {
 	return result, nil
 }
 
+// BackupStatus returns the status of the controller's scheduled backups.
+// A NotFound error is returned if no scheduled backup has been taken.
+func (c *ControllerAPI) BackupStatus() (params.BackupStatusResult, error) {
+	var result params.BackupStatusResult
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	sInfo, err := c.state.BackupStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return params.BackupStatusResult{
+		Status: sInfo.Status.String(),
+		Info:   sInfo.Message,
+		Data:   sInfo.Data,
+		Since:  sInfo.Since,
+	}, nil
+}
+
 // dashboardConnectionInforForCAAS returns a dashboard connection for a Juju
 // dashboard deployed on CAAS.
 func (c *ControllerAPI) dashboardConnectionInfoForCAAS(
@@ -621,41 +660,172 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.prepareMigration(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	leaders, err := c.leadership.Leaders()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	if err := runMigrationPreChecks(
+		hostedState.State, systemState,
+		&targetInfo, c.presence,
+		leaders,
+	); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// ResumeMigration resumes, or aborts, the suspended migrations of one
+// or more models.
+func (c *ControllerAPI) ResumeMigration(args params.ResumeMigrationArgs) (params.ErrorResults, error) {
+	out := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range args.Specs {
+		err := c.resumeOneMigration(spec)
+		out.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) resumeOneMigration(spec params.ResumeMigrationSpec) error {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return errors.Annotate(err, "model tag")
+	}
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer hostedState.Release()
 
+	mig, err := hostedState.LatestMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !mig.IsSuspended() {
+		return errors.Errorf("migration is not suspended")
+	}
+	if spec.Abort {
+		return errors.Annotate(mig.SetPhase(coremigration.ABORT), "aborting migration")
+	}
+	return errors.Annotate(mig.Resume(), "resuming migration")
+}
+
+// DryRunMigration runs the checks made when initiating the migration
+// of one or more models, along with checks that the models can be
+// exported and imported, without starting the migrations. Rather than
+// failing on the first issue found, every issue which would prevent a
+// migration or affect it is reported.
+func (c *ControllerAPI) DryRunMigration(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		issues, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Issues = common.PrecheckIssuesToParams(issues)
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) (coremigration.PrecheckIssues, error) {
+	hostedState, targetInfo, err := c.prepareMigration(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	systemState, err := c.statePool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	leaders, err := c.leadership.Leaders()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	issues, err := runMigrationPrecheckReport(
+		hostedState.State, systemState,
+		&targetInfo, c.presence,
+		leaders,
+	)
+	return issues, errors.Trace(err)
+}
+
+// prepareMigration ensures that the model to be migrated exists, and
+// returns its state along with the details of the target controller.
+// The caller is responsible for releasing the state.
+func (c *ControllerAPI) prepareMigration(spec params.MigrationSpec) (
+	*state.PooledState, coremigration.TargetInfo, error,
+) {
+	var targetInfo coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, targetInfo, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, targetInfo, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, targetInfo, errors.NotFoundf("model")
+	}
+
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, targetInfo, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, targetInfo, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, targetInfo, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	targetInfo = coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -665,35 +835,21 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:       macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	systemState, err := c.statePool.SystemState()
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, targetInfo, errors.Trace(err)
 	}
+	return hostedState, targetInfo, nil
+}
 
-	leaders, err := c.leadership.Leaders()
-	if err != nil {
-		return "", errors.Trace(err)
-	}
+// DryRunMigration isn't on the v11 API.
+func (*ControllerAPIv11) DryRunMigration(_, _ struct{}) {}
 
-	if err := runMigrationPreChecks(
-		hostedState.State, systemState,
-		&targetInfo, c.presence,
-		leaders,
-	); err != nil {
-		return "", errors.Trace(err)
-	}
+// ResumeMigration isn't on the v12 API.
+func (*ControllerAPIv12) ResumeMigration(_, _ struct{}) {}
 
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
-}
+// BackupStatus isn't on the v13 API.
+func (*ControllerAPIv13) BackupStatus(_, _ struct{}) {}
 
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
@@ -859,6 +1015,89 @@ var runMigrationPreChecks = func(
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationPrecheckReport runs the same checks as
+// runMigrationPreChecks, along with a check that the model can be
+// exported, without changing anything. Every issue found is reported
+// rather than just the first.
+var runMigrationPrecheckReport = func(
+	st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo,
+	presence facade.Presence, leaders map[string]string,
+) (coremigration.PrecheckIssues, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+
+	issues, err := migration.SourcePrecheckReport(
+		backend,
+		modelPresence, controllerPresence,
+		cloudspec.MakeCloudSpecGetterForModel(st),
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "source prechecks failed")
+	}
+
+	// The model is validated as it is exported, so any failure here
+	// would also fail the migration. The target controller can't be
+	// checked without the exported model.
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt, leaders)
+	if err != nil {
+		issues = append(issues, precheckBlocker(coremigration.PrecheckSource,
+			fmt.Sprintf("model cannot be exported: %v", err)))
+		return issues, nil
+	}
+
+	// Check target controller.
+	targetConn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		issues = append(issues, precheckBlocker(coremigration.PrecheckTarget,
+			fmt.Sprintf("cannot connect to target controller: %v", err)))
+		return issues, nil
+	}
+	defer targetConn.Close()
+	dstUserList, err := getTargetControllerUsers(targetConn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		issues = append(issues, precheckBlocker(coremigration.PrecheckTarget, err.Error()))
+	}
+	client := migrationtarget.NewClient(targetConn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return nil, errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			issues = append(issues, precheckBlocker(coremigration.PrecheckTarget, "controller API version is too old"))
+			return issues, nil
+		}
+	}
+	if client.BestFacadeVersion() < 4 {
+		// Older controllers can only report the first issue found.
+		if err := client.Prechecks(modelInfo); err != nil {
+			issues = append(issues, precheckBlocker(coremigration.PrecheckTarget, err.Error()))
+		}
+		return issues, nil
+	}
+	targetIssues, err := client.PrecheckReport(modelInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "target prechecks failed")
+	}
+	return append(issues, targetIssues...), nil
+}
+
+func precheckBlocker(controller, message string) coremigration.PrecheckIssue {
+	return coremigration.PrecheckIssue{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: controller,
+		Message:    message,
+	}
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
@@ -32,8 +32,10 @@ import (
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
+	coremigration "github.com/juju/juju/core/migration"
 	coremultiwatcher "github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -319,7 +321,7 @@ func (s *controllerSuite) TestModelConfigFromNonController(c *gc.C) {
 		Tag:      s.Owner,
 		AdminTag: s.Owner,
 	}
-	controller, err := controller.NewControllerAPIv11(
+	controller, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     st,
 			StatePool_: s.StatePool,
@@ -349,7 +351,7 @@ func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	defer st.Close()
 
 	authorizer := &apiservertesting.FakeAuthorizer{Tag: s.Owner}
-	controller, err := controller.NewControllerAPIv11(
+	controller, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     st,
 			StatePool_: s.StatePool,
@@ -681,6 +683,184 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestDryRunMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetPrecheckReportResult(s, coremigration.PrecheckIssues{{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckSource,
+		Message:    "machine 0 is dying",
+	}, {
+		Severity:   coremigration.PrecheckWarning,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "source controller has higher patch version than target controller (3.5.1 > 3.5.0)",
+	}}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.DryRunMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationPrecheckResult{
+		ModelTag: m.ModelTag().String(),
+		Issues: []params.MigrationPrecheckIssue{{
+			Severity:   "blocker",
+			Controller: "source",
+			Message:    "machine 0 is dying",
+		}, {
+			Severity:   "warning",
+			Controller: "target",
+			Message:    "source controller has higher patch version than target controller (3.5.1 > 3.5.0)",
+		}},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// Nothing is changed by a dry run.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestDryRunMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetPrecheckReportResult(s, nil, errors.New("boom"))
+
+	out, err := s.controller.DryRunMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				AuthTag:       names.NewUserTag("admin1").String(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) suspendedMigration(c *gc.C) (*state.State, string) {
+	st := s.Factory.MakeModel(c, nil)
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.SetPreCheckResult(s, nil)
+	out, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.Suspend("suspended, boom"), jc.ErrorIsNil)
+	return st, m.ModelTag().String()
+}
+
+func (s *controllerSuite) TestResumeMigration(c *gc.C) {
+	st, modelTag := s.suspendedMigration(c)
+	defer st.Close()
+
+	out, err := s.controller.ResumeMigration(params.ResumeMigrationArgs{
+		Specs: []params.ResumeMigrationSpec{{ModelTag: modelTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Combine(), jc.ErrorIsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.IMPORT)
+}
+
+func (s *controllerSuite) TestResumeMigrationAbort(c *gc.C) {
+	st, modelTag := s.suspendedMigration(c)
+	defer st.Close()
+
+	out, err := s.controller.ResumeMigration(params.ResumeMigrationArgs{
+		Specs: []params.ResumeMigrationSpec{{ModelTag: modelTag, Abort: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Combine(), jc.ErrorIsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.ABORT)
+}
+
+func (s *controllerSuite) TestResumeMigrationNotSuspended(c *gc.C) {
+	st, modelTag := s.suspendedMigration(c)
+	defer st.Close()
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.Resume(), jc.ErrorIsNil)
+
+	out, err := s.controller.ResumeMigration(params.ResumeMigrationArgs{
+		Specs: []params.ResumeMigrationSpec{{ModelTag: modelTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "migration is not suspended")
+}
+
+func (s *controllerSuite) TestResumeMigrationRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ResumeMigration(params.ResumeMigrationArgs{
+		Specs: []params.ResumeMigrationSpec{{ModelTag: randomModelTag()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -900,7 +1080,7 @@ func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv11(
+	endpoint, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -987,7 +1167,7 @@ func (s *controllerSuite) TestConfigSetRequiresSuperUser(c *gc.C) {
 	anAuthoriser := apiservertesting.FakeAuthorizer{
 		Tag: user.Tag(),
 	}
-	endpoint, err := controller.NewControllerAPIv11(
+	endpoint, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -1085,6 +1265,48 @@ func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	c.Assert(result.Result, gc.Matches, "^([0-9]{1,}).([0-9]{1,}).([0-9]{1,})$")
 }
 
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	_, err := s.controller.BackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	err = s.State.SetBackupStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "backup created",
+		Data:    map[string]interface{}{"filename": "juju-backup.tar.gz"},
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Status, gc.Equals, "active")
+	c.Check(result.Info, gc.Equals, "backup created")
+	c.Check(result.Data, jc.DeepEquals, map[string]interface{}{"filename": "juju-backup.tar.gz"})
+	c.Assert(result.Since, gc.NotNil)
+	c.Check(result.Since.Equal(since), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestBackupStatusRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestIdentityProviderURL(c *gc.C) {
 	// Preserve default controller config as we will be mutating it just
 	// for this test
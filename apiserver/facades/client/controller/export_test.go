@@ -19,10 +19,16 @@ func SetPreCheckResult(p patcher, err error) {
 	})
 }
 
+func SetPrecheckReportResult(p patcher, issues migration.PrecheckIssues, err error) {
+	p.PatchValue(&runMigrationPrecheckReport, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence, map[string]string) (migration.PrecheckIssues, error) {
+		return issues, err
+	})
+}
+
 func NewControllerAPIForTest(backend Backend) *ControllerAPI {
 	return &ControllerAPI{state: backend}
 }
 
 var (
-	NewControllerAPIv11 = newControllerAPIv11
+	NewControllerAPIv14 = newControllerAPIv14
 )
@@ -15,11 +15,47 @@ import (
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("Controller", 11, func(ctx facade.Context) (facade.Facade, error) {
 		return newControllerAPIv11(ctx)
+	}, reflect.TypeOf((*ControllerAPIv11)(nil)))
+	registry.MustRegister("Controller", 12, func(ctx facade.Context) (facade.Facade, error) {
+		return newControllerAPIv12(ctx)
+	}, reflect.TypeOf((*ControllerAPIv12)(nil)))
+	registry.MustRegister("Controller", 13, func(ctx facade.Context) (facade.Facade, error) {
+		return newControllerAPIv13(ctx)
+	}, reflect.TypeOf((*ControllerAPIv13)(nil)))
+	registry.MustRegister("Controller", 14, func(ctx facade.Context) (facade.Facade, error) {
+		return newControllerAPIv14(ctx)
 	}, reflect.TypeOf((*ControllerAPI)(nil)))
 }
 
 // newControllerAPIv11 creates a new ControllerAPIv11
-func newControllerAPIv11(ctx facade.Context) (*ControllerAPI, error) {
+func newControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	api, err := newControllerAPIv12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv11{ControllerAPIv12: api}, nil
+}
+
+// newControllerAPIv12 creates a new ControllerAPIv12
+func newControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	api, err := newControllerAPIv13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv12{ControllerAPIv13: api}, nil
+}
+
+// newControllerAPIv13 creates a new ControllerAPIv13
+func newControllerAPIv13(ctx facade.Context) (*ControllerAPIv13, error) {
+	api, err := newControllerAPIv14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv13{ControllerAPI: api}, nil
+}
+
+// newControllerAPIv14 creates a new ControllerAPIv14
+func newControllerAPIv14(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -0,0 +1,240 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/apiserver/facade"
+	coredatabase "github.com/juju/juju/core/database"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.controllerdb")
+
+const (
+	// DefaultMaxRows is the number of rows returned when the
+	// caller doesn't supply a row limit.
+	DefaultMaxRows = 1000
+
+	// MaxRowsLimit is the most rows that can be requested.
+	MaxRowsLimit = 10000
+
+	// DefaultTimeout is how long a query may run for when the
+	// caller doesn't supply a timeout.
+	DefaultTimeout = 30 * time.Second
+
+	// MaxTimeout is the longest timeout that can be requested.
+	MaxTimeout = 5 * time.Minute
+)
+
+// errQueryDone is returned from the query transaction so that it is
+// always rolled back, regardless of what the statement did.
+const errQueryDone = errors.ConstError("query done")
+
+// ControllerDBAPI is the server implementation for the ControllerDB facade.
+type ControllerDBAPI struct {
+	authorizer    facade.Authorizer
+	controllerTag names.ControllerTag
+	getDB         func() (coredatabase.TrackedDB, error)
+}
+
+// Query runs a single read-only SQL statement against the controller
+// database, returning at most the requested number of rows. The query
+// is run with the connection in query-only mode, inside a transaction
+// that is always rolled back.
+func (api *ControllerDBAPI) Query(args params.DBQueryArgs) (params.DBQueryResult, error) {
+	var result params.DBQueryResult
+	if err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	query, err := readOnlyStatement(args.Query)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	maxRows := args.MaxRows
+	switch {
+	case maxRows < 0:
+		return result, errors.NotValidf("negative max rows %d", maxRows)
+	case maxRows == 0:
+		maxRows = DefaultMaxRows
+	case maxRows > MaxRowsLimit:
+		return result, errors.NotValidf("max rows %d greater than %d", maxRows, MaxRowsLimit)
+	}
+
+	timeout := args.Timeout
+	switch {
+	case timeout < 0:
+		return result, errors.NotValidf("negative timeout %v", timeout)
+	case timeout == 0:
+		timeout = DefaultTimeout
+	case timeout > MaxTimeout:
+		return result, errors.NotValidf("timeout %v greater than %v", timeout, MaxTimeout)
+	}
+
+	db, err := api.getDB()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = db.TxnNoRetry(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		// The keyword check can't see what a statement behind a WITH
+		// clause does, so have the database refuse any write. The
+		// connection goes back to the pool afterwards, so it's reset
+		// even if the query timed out.
+		if _, err := tx.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+			return errors.Trace(err)
+		}
+		defer func() {
+			if _, err := tx.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+				logger.Errorf("resetting query only mode: %v", err)
+			}
+		}()
+
+		rows, err := tx.QueryContext(ctx, query)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		if result.Columns, err = rows.Columns(); err != nil {
+			return errors.Trace(err)
+		}
+		result.Rows = [][]interface{}{}
+		for rows.Next() {
+			if len(result.Rows) == maxRows {
+				result.Truncated = true
+				break
+			}
+			row := make([]interface{}, len(result.Columns))
+			dest := make([]interface{}, len(row))
+			for i := range row {
+				dest[i] = &row[i]
+			}
+			if err := rows.Scan(dest...); err != nil {
+				return errors.Trace(err)
+			}
+			for i, v := range row {
+				// Text columns may be returned as bytes,
+				// which would otherwise be base64 encoded.
+				if b, ok := v.([]byte); ok {
+					row[i] = string(b)
+				}
+			}
+			result.Rows = append(result.Rows, row)
+		}
+		if err := rows.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		return errQueryDone
+	})
+	if errors.Is(err, errQueryDone) {
+		return result, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return params.DBQueryResult{}, errors.Timeoutf("query exceeded %v", timeout)
+	}
+	return params.DBQueryResult{}, errors.Trace(err)
+}
+
+// readOnlyKeywords are the statement types that can be run.
+// A WITH clause can precede a data modifying statement, but
+// the query is run in query-only mode, so this is a first
+// line of defence rather than the only one.
+var readOnlyKeywords = map[string]bool{
+	"SELECT":  true,
+	"WITH":    true,
+	"EXPLAIN": true,
+	"VALUES":  true,
+}
+
+// readOnlyStatement returns the single statement in the query, without
+// comments. An error is returned if the query isn't a single statement
+// beginning with a read-only keyword.
+func readOnlyStatement(query string) (string, error) {
+	statements := splitStatements(query)
+	switch len(statements) {
+	case 0:
+		return "", errors.NotValidf("empty query")
+	case 1:
+	default:
+		return "", errors.NotValidf("multiple statements")
+	}
+
+	words := strings.FieldsFunc(statements[0], func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+	})
+	if len(words) == 0 {
+		return "", errors.NotValidf("query %q", statements[0])
+	}
+	if keyword := strings.ToUpper(words[0]); !readOnlyKeywords[keyword] {
+		return "", errors.NotValidf("%s statement", keyword)
+	}
+	return statements[0], nil
+}
+
+// splitStatements splits the query on semi-colons that are not within
+// quotes or comments. Comments are removed, and empty statements are
+// discarded.
+func splitStatements(query string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(current.String()); s != "" {
+			statements = append(statements, s)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(query); i++ {
+		switch ch := query[i]; {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := strings.IndexByte(query[i+1:], ch)
+			if end < 0 {
+				current.WriteString(query[i:])
+				i = len(query)
+				break
+			}
+			current.WriteString(query[i : i+end+2])
+			i += end + 1
+		case ch == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				i = len(query)
+				break
+			}
+			current.WriteByte(' ')
+			i += end
+		case ch == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+				break
+			}
+			current.WriteByte(' ')
+			i += end + 3
+		case ch == ';':
+			flush()
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	flush()
+	return statements
+}
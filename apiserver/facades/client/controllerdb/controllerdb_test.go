@@ -0,0 +1,180 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/controllerdb"
+	"github.com/juju/juju/core/permission"
+	databasetesting "github.com/juju/juju/database/testing"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ControllerDBSuite struct {
+	databasetesting.ControllerSuite
+
+	authorizer *facademocks.MockAuthorizer
+}
+
+var _ = gc.Suite(&ControllerDBSuite{})
+
+func (s *ControllerDBSuite) SetUpTest(c *gc.C) {
+	s.ControllerSuite.SetUpTest(c)
+
+	_, err := s.DB().Exec(`
+INSERT INTO external_controller (uuid, alias, ca_cert_uuid) VALUES
+    ('ctrl-1', 'one', 'cert-1'),
+    ('ctrl-2', 'two', 'cert-2'),
+    ('ctrl-3', NULL, 'cert-3');`)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerDBSuite) setup(c *gc.C) (*gomock.Controller, *controllerdb.ControllerDBAPI) {
+	ctrl := gomock.NewController(c)
+	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
+	return ctrl, controllerdb.NewTestAPI(s.authorizer, coretesting.ControllerTag, s.TrackedDB())
+}
+
+func (s *ControllerDBSuite) expectSuperuser() {
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil)
+}
+
+func (s *ControllerDBSuite) TestQuery(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.expectSuperuser()
+
+	result, err := api.Query(params.DBQueryArgs{
+		Query: "SELECT uuid, alias FROM external_controller ORDER BY uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DBQueryResult{
+		Columns: []string{"uuid", "alias"},
+		Rows: [][]interface{}{
+			{"ctrl-1", "one"},
+			{"ctrl-2", "two"},
+			{"ctrl-3", nil},
+		},
+	})
+}
+
+func (s *ControllerDBSuite) TestQueryMaxRows(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.expectSuperuser()
+
+	result, err := api.Query(params.DBQueryArgs{
+		Query:   "SELECT uuid FROM external_controller ORDER BY uuid",
+		MaxRows: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.DBQueryResult{
+		Columns:   []string{"uuid"},
+		Rows:      [][]interface{}{{"ctrl-1"}, {"ctrl-2"}},
+		Truncated: true,
+	})
+}
+
+func (s *ControllerDBSuite) TestQueryNotSuperuser(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(apiservererrors.ErrPerm)
+
+	_, err := api.Query(params.DBQueryArgs{Query: "SELECT 1"})
+	c.Assert(err, jc.ErrorIs, apiservererrors.ErrPerm)
+}
+
+func (s *ControllerDBSuite) TestQueryInvalidArgs(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.SuperuserAccess, coretesting.ControllerTag).Return(nil).AnyTimes()
+
+	for _, test := range []struct {
+		args params.DBQueryArgs
+		err  string
+	}{{
+		args: params.DBQueryArgs{Query: "  -- nothing\n ; "},
+		err:  "empty query not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "DELETE FROM external_controller"},
+		err:  "DELETE statement not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "SELECT 1; DROP TABLE external_controller"},
+		err:  "multiple statements not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "/* SELECT */ PRAGMA writable_schema = 1"},
+		err:  "PRAGMA statement not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "SELECT 1", MaxRows: -1},
+		err:  "negative max rows -1 not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "SELECT 1", MaxRows: controllerdb.MaxRowsLimit + 1},
+		err:  "max rows 10001 greater than 10000 not valid",
+	}, {
+		args: params.DBQueryArgs{Query: "SELECT 1", Timeout: time.Hour},
+		err:  "timeout 1h0m0s greater than 5m0s not valid",
+	}} {
+		_, err := api.Query(test.args)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("query %q", test.args.Query))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ControllerDBSuite) TestQuerySemicolonInString(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.expectSuperuser()
+
+	result, err := api.Query(params.DBQueryArgs{Query: "SELECT 'a;b' AS v; -- trailing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Rows, jc.DeepEquals, [][]interface{}{{"a;b"}})
+}
+
+func (s *ControllerDBSuite) TestQueryWriteBehindWith(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.expectSuperuser()
+
+	// Share a single connection, to check that it isn't left in
+	// query-only mode.
+	s.DB().SetMaxOpenConns(1)
+
+	_, err := s.DB().Exec(`
+INSERT INTO lease (uuid, lease_type_id, model_uuid, name, holder, start, expiry)
+VALUES ('lease-1', 0, 'model-1', 'app', 'app/0', datetime('now'), datetime('now', '+1 minute'))`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A data modifying statement behind a WITH clause gets past the
+	// keyword check, but is refused by the database.
+	_, err = api.Query(params.DBQueryArgs{
+		Query: "WITH x AS (SELECT 1) DELETE FROM lease",
+	})
+	c.Assert(err, gc.ErrorMatches, ".*attempt to write a readonly database")
+
+	var count int
+	err = s.DB().QueryRow("SELECT COUNT(*) FROM lease").Scan(&count)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	_, err = s.DB().Exec("DELETE FROM lease")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerDBSuite) TestQueryError(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.expectSuperuser()
+
+	_, err := api.Query(params.DBQueryArgs{Query: "SELECT * FROM nowhere"})
+	c.Assert(err, gc.ErrorMatches, ".*no such table: nowhere")
+}
@@ -0,0 +1,7 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerdb provides the server implementation for the
+// ControllerDB facade, which allows a controller superuser to run
+// read-only queries against the controller database.
+package controllerdb
@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb
+
+import (
+	"testing"
+
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+	coredatabase "github.com/juju/juju/core/database"
+)
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
+
+func NewTestAPI(
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+	db coredatabase.TrackedDB,
+) *ControllerDBAPI {
+	return &ControllerDBAPI{
+		authorizer:    authorizer,
+		controllerTag: controllerTag,
+		getDB: func() (coredatabase.TrackedDB, error) {
+			return db, nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerdb
+
+import (
+	"reflect"
+
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// Register is called to expose a package of facades onto a given registry.
+func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("ControllerDB", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newControllerDBAPI(ctx)
+	}, reflect.TypeOf((*ControllerDBAPI)(nil)))
+}
+
+// newControllerDBAPI creates a ControllerDBAPI.
+func newControllerDBAPI(ctx facade.Context) (*ControllerDBAPI, error) {
+	if !ctx.Auth().AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &ControllerDBAPI{
+		authorizer:    ctx.Auth(),
+		controllerTag: names.NewControllerTag(ctx.State().ControllerUUID()),
+		getDB:         ctx.ControllerDB,
+	}, nil
+}
@@ -0,0 +1,7 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leadershiphistory provides the server implementation for the
+// LeadershipHistory facade, which reports the recorded changes to the
+// leadership of a model's applications.
+package leadershiphistory
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+)
+
+// HistoryReader reads the recorded changes to a lease.
+type HistoryReader interface {
+	// LeaseHistory returns the changes to the lease indicated by the
+	// input key, oldest first, limited to the most recent limit changes
+	// if limit is greater than zero.
+	LeaseHistory(ctx context.Context, key lease.Key, limit int) ([]lease.HistoryEntry, error)
+}
+
+// LeadershipHistoryAPI is the server implementation for the
+// LeadershipHistory facade.
+type LeadershipHistoryAPI struct {
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+	getHistory func() (HistoryReader, error)
+}
+
+// LeadershipHistory returns the recorded changes to the leadership of
+// each of the input applications, oldest first. Changes older than the
+// controller's lease history retention period have been pruned.
+func (api *LeadershipHistoryAPI) LeadershipHistory(args params.LeadershipHistoryArgs) (params.LeadershipHistoryResults, error) {
+	var result params.LeadershipHistoryResults
+	if err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag); err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Limit < 0 {
+		return result, errors.NotValidf("negative limit %d", args.Limit)
+	}
+
+	reader, err := api.getHistory()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.LeadershipHistoryResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		history, err := api.applicationHistory(reader, entity.Tag, args.Limit)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].History = history
+	}
+	return result, nil
+}
+
+func (api *LeadershipHistoryAPI) applicationHistory(reader HistoryReader, tag string, limit int) ([]params.LeadershipHistoryEntry, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entries, err := reader.LeaseHistory(context.Background(), lease.Key{
+		Namespace: lease.ApplicationLeadershipNamespace,
+		ModelUUID: api.modelTag.Id(),
+		Lease:     appTag.Id(),
+	}, limit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	history := make([]params.LeadershipHistoryEntry, len(entries))
+	for i, entry := range entries {
+		history[i] = params.LeadershipHistoryEntry{
+			Action: string(entry.Action),
+			Entity: entry.Entity,
+			Time:   entry.Time,
+		}
+		if !entry.Expiry.IsZero() {
+			expiry := entry.Expiry
+			history[i].Expiry = &expiry
+		}
+	}
+	return history, nil
+}
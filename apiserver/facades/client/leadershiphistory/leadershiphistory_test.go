@@ -0,0 +1,126 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	facademocks "github.com/juju/juju/apiserver/facade/mocks"
+	"github.com/juju/juju/apiserver/facades/client/leadershiphistory"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LeadershipHistorySuite struct {
+	testing.IsolationSuite
+
+	authorizer *facademocks.MockAuthorizer
+	reader     *stubHistoryReader
+}
+
+var _ = gc.Suite(&LeadershipHistorySuite{})
+
+func (s *LeadershipHistorySuite) setup(c *gc.C) (*gomock.Controller, *leadershiphistory.LeadershipHistoryAPI) {
+	ctrl := gomock.NewController(c)
+	s.authorizer = facademocks.NewMockAuthorizer(ctrl)
+	s.reader = &stubHistoryReader{}
+	return ctrl, leadershiphistory.NewTestAPI(s.authorizer, coretesting.ModelTag, s.reader)
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistory(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.ReadAccess, coretesting.ModelTag).Return(nil)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.reader.entries = []lease.HistoryEntry{
+		{Action: lease.HistoryClaim, Entity: "redis/0", Expiry: now.Add(time.Minute), Time: now},
+		{Action: lease.HistoryPin, Entity: "machine-0", Time: now.Add(time.Second)},
+	}
+
+	result, err := api.LeadershipHistory(params.LeadershipHistoryArgs{
+		Entities: []params.Entity{{Tag: "application-redis"}, {Tag: "unit-redis-0"}},
+		Limit:    10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+
+	expiry := now.Add(time.Minute)
+	c.Check(result.Results[0], jc.DeepEquals, params.LeadershipHistoryResult{
+		History: []params.LeadershipHistoryEntry{
+			{Action: "claim", Entity: "redis/0", Expiry: &expiry, Time: now},
+			{Action: "pin", Entity: "machine-0", Time: now.Add(time.Second)},
+		},
+	})
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"unit-redis-0" is not a valid application tag`)
+
+	c.Check(s.reader.keys, jc.DeepEquals, []lease.Key{{
+		Namespace: lease.ApplicationLeadershipNamespace,
+		ModelUUID: coretesting.ModelTag.Id(),
+		Lease:     "redis",
+	}})
+	c.Check(s.reader.limit, gc.Equals, 10)
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryReaderError(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.ReadAccess, coretesting.ModelTag).Return(nil)
+	s.reader.err = errors.New("boom")
+
+	result, err := api.LeadershipHistory(params.LeadershipHistoryArgs{
+		Entities: []params.Entity{{Tag: "application-redis"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryNegativeLimit(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.ReadAccess, coretesting.ModelTag).Return(nil)
+
+	_, err := api.LeadershipHistory(params.LeadershipHistoryArgs{
+		Entities: []params.Entity{{Tag: "application-redis"}},
+		Limit:    -1,
+	})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *LeadershipHistorySuite) TestLeadershipHistoryPermissionDenied(c *gc.C) {
+	ctrl, api := s.setup(c)
+	defer ctrl.Finish()
+	s.authorizer.EXPECT().HasPermission(permission.ReadAccess, coretesting.ModelTag).Return(apiservererrors.ErrPerm)
+
+	_, err := api.LeadershipHistory(params.LeadershipHistoryArgs{
+		Entities: []params.Entity{{Tag: "application-redis"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Check(s.reader.keys, gc.HasLen, 0)
+}
+
+type stubHistoryReader struct {
+	entries []lease.HistoryEntry
+	err     error
+
+	keys  []lease.Key
+	limit int
+}
+
+func (r *stubHistoryReader) LeaseHistory(_ context.Context, key lease.Key, limit int) ([]lease.HistoryEntry, error) {
+	r.keys = append(r.keys, key)
+	r.limit = limit
+	return r.entries, r.err
+}
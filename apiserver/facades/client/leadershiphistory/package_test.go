@@ -0,0 +1,31 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory
+
+import (
+	"testing"
+
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade"
+)
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
+
+func NewTestAPI(
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+	reader HistoryReader,
+) *LeadershipHistoryAPI {
+	return &LeadershipHistoryAPI{
+		authorizer: authorizer,
+		modelTag:   modelTag,
+		getHistory: func() (HistoryReader, error) {
+			return reader, nil
+		},
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershiphistory
+
+import (
+	"reflect"
+
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/worker/lease"
+)
+
+// Register is called to expose a package of facades onto a given registry.
+func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("LeadershipHistory", 1, func(ctx facade.Context) (facade.Facade, error) {
+		return newLeadershipHistoryAPI(ctx)
+	}, reflect.TypeOf((*LeadershipHistoryAPI)(nil)))
+}
+
+// newLeadershipHistoryAPI creates a LeadershipHistoryAPI.
+func newLeadershipHistoryAPI(ctx facade.Context) (*LeadershipHistoryAPI, error) {
+	if !ctx.Auth().AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &LeadershipHistoryAPI{
+		authorizer: ctx.Auth(),
+		modelTag:   names.NewModelTag(ctx.State().ModelUUID()),
+		getHistory: func() (HistoryReader, error) {
+			db, err := ctx.ControllerDB()
+			if err != nil {
+				return nil, err
+			}
+			return lease.NewStore(lease.StoreConfig{
+				TrackedDB: db,
+				Logger:    loggo.GetLogger("juju.apiserver.leadershiphistory"),
+			}), nil
+		},
+	}, nil
+}
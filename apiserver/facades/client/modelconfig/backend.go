@@ -18,6 +18,7 @@ import (
 type Backend interface {
 	common.BlockGetter
 	ControllerTag() names.ControllerTag
+	ControllerAPIAddresses() ([]string, error)
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
@@ -14,6 +14,7 @@ import (
 	commonsecrets "github.com/juju/juju/apiserver/common/secrets"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/charmhub/mirror"
 	corebase "github.com/juju/juju/core/base"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs/config"
@@ -334,8 +335,18 @@ func (c *ModelConfigAPI) checkCharmhubURL() state.ValidateConfigFunc {
 	return func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
 		if v, found := updateAttrs["charmhub-url"]; found {
 			oldURL, _ := oldConfig.CharmHubURL()
-			if v != oldURL {
-				return errors.New("charmhub-url cannot be changed")
+			newURL, _ := v.(string)
+			if newURL == oldURL {
+				return nil
+			}
+			// Models may be moved to or from the charmhub mirror hosted
+			// by their controller, but not between other stores.
+			apiAddresses, err := c.backend.ControllerAPIAddresses()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !mirror.IsMirrorURL(oldURL, apiAddresses) && !mirror.IsMirrorURL(newURL, apiAddresses) {
+				return errors.New("charmhub-url can only be changed to or from a controller's charmhub mirror")
 			}
 		}
 		return nil
@@ -52,6 +52,7 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 			"authorized-keys": {Value: coretesting.FakeAuthKeys, Source: "model"},
 			"charmhub-url":    {Value: "http://meshuggah.rocks", Source: "model"},
 		},
+		apiAddresses: []string{"10.0.0.1:17070"},
 		secretBackend: &coresecrets.SecretBackend{
 			ID:          "backend-1",
 			Name:        "backend-1",
@@ -174,7 +175,7 @@ func (s *modelconfigSuite) TestModelSetCannotChangeCharmHubURL(c *gc.C) {
 		Config: map[string]interface{}{"charmhub-url": "http://another-url.com"},
 	}
 	err = s.api.ModelSet(args)
-	c.Assert(err, gc.ErrorMatches, "charmhub-url cannot be changed")
+	c.Assert(err, gc.ErrorMatches, "charmhub-url can only be changed to or from a controller's charmhub mirror")
 
 	// It's okay to pass config back with the same charmhub-url.
 	result, err := s.api.ModelGet()
@@ -185,6 +186,43 @@ func (s *modelconfigSuite) TestModelSetCannotChangeCharmHubURL(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelSetCharmHubURLToAndFromMirror(c *gc.C) {
+	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(coretesting.Attrs{
+		"charmhub-url": "https://api.charmhub.io",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+	args := params.ModelSet{
+		Config: map[string]interface{}{"charmhub-url": "https://10.0.0.1:17070/charmhub"},
+	}
+	err = s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	old, err = config.New(config.UseDefaults, dummy.SampleConfig().Merge(coretesting.Attrs{
+		"charmhub-url": "https://10.0.0.1:17070/charmhub",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+	args = params.ModelSet{
+		Config: map[string]interface{}{"charmhub-url": "https://api.charmhub.io"},
+	}
+	err = s.api.ModelSet(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelconfigSuite) TestModelSetCannotChangeCharmHubURLToOtherMirror(c *gc.C) {
+	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(coretesting.Attrs{
+		"charmhub-url": "https://api.charmhub.io",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.old = old
+	args := params.ModelSet{
+		Config: map[string]interface{}{"charmhub-url": "https://evil.example/charmhub"},
+	}
+	err = s.api.ModelSet(args)
+	c.Assert(err, gc.ErrorMatches, "charmhub-url can only be changed to or from a controller's charmhub mirror")
+}
+
 func (s *modelconfigSuite) TestModelSetCannotChangeBothDefaultSeriesAndDefaultBaseWithSeries(c *gc.C) {
 	old, err := config.New(config.UseDefaults, dummy.SampleConfig().Merge(coretesting.Attrs{
 		"default-series": "jammy",
@@ -459,6 +497,7 @@ type mockBackend struct {
 	msg           string
 	cons          constraints.Value
 	secretBackend *coresecrets.SecretBackend
+	apiAddresses  []string
 }
 
 func (m *mockBackend) SetModelConstraints(value constraints.Value) error {
@@ -510,6 +549,10 @@ func (m *mockBackend) ControllerTag() names.ControllerTag {
 	return names.NewControllerTag("deadbeef-babe-4fd2-967d-db9663db7bea")
 }
 
+func (m *mockBackend) ControllerAPIAddresses() ([]string, error) {
+	return m.apiAddresses, nil
+}
+
 func (m *mockBackend) SetSLA(level, owner string, credentials []byte) error {
 	return nil
 }
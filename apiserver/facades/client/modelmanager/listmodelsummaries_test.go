@@ -62,7 +62,7 @@ func (s *ListModelsWithInfoSuite) SetUpTest(c *gc.C) {
 	s.callContext = context.NewEmptyCloudCallContext()
 	api, err := modelmanager.NewModelManagerAPI(
 		s.st, &mockState{}, nil, nil,
-		common.NewBlockChecker(s.st), s.authoriser, s.st.model, s.callContext,
+		common.NewBlockChecker(s.st), s.authoriser, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
@@ -84,7 +84,7 @@ func (s *ListModelsWithInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authoriser.Tag = user
 	modelmanager, err := modelmanager.NewModelManagerAPI(
 		s.st, &mockState{}, nil, nil,
-		common.NewBlockChecker(s.st), s.authoriser, s.st.model, s.callContext,
+		common.NewBlockChecker(s.st), s.authoriser, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = modelmanager
@@ -179,7 +179,7 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 	var err error
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(
 		s.st, s.ctlrSt, nil, nil, common.NewBlockChecker(s.st),
-		&s.authorizer, s.st.model, s.callContext,
+		&s.authorizer, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -197,7 +197,7 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	var err error
 	s.modelmanager, err = modelmanager.NewModelManagerAPI(
 		s.st, s.ctlrSt, nil, nil,
-		common.NewBlockChecker(s.st), s.authorizer, s.st.model, s.callContext,
+		common.NewBlockChecker(s.st), s.authorizer, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 }
@@ -458,6 +458,25 @@ func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	c.Assert(*migrationResult.End, gc.Equals, end)
 }
 
+func (s *modelInfoSuite) TestSuspendedMigration(c *gc.C) {
+	start := time.Now().Add(-20 * time.Minute)
+	s.st.migration = &mockMigration{
+		status:    "suspended, model data transfer failed",
+		start:     start,
+		suspended: true,
+	}
+
+	results, err := s.modelmanager.ModelInfo(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+
+	c.Assert(err, jc.ErrorIsNil)
+	migrationResult := results.Results[0].Result.Migration
+	c.Assert(migrationResult.Status, gc.Equals, "suspended, model data transfer failed")
+	c.Assert(migrationResult.Suspended, jc.IsTrue)
+	c.Assert(migrationResult.End, gc.IsNil)
+}
+
 func (s *modelInfoSuite) TestNoMigration(c *gc.C) {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
@@ -695,6 +714,18 @@ type fakeModelDescription struct {
 	UUID string `yaml:"model-uuid"`
 }
 
+func (*fakeModelDescription) Type() string {
+	return "iaas"
+}
+
+func (*fakeModelDescription) Applications() []description.Application {
+	return nil
+}
+
+func (*fakeModelDescription) Machines() []description.Machine {
+	return nil
+}
+
 func (st *mockState) ModelUUID() string {
 	st.MethodCall(st, "ModelUUID")
 	return st.model.UUID()
@@ -1352,9 +1383,10 @@ type mockModelUser struct {
 type mockMigration struct {
 	state.ModelMigration
 
-	status string
-	start  time.Time
-	end    time.Time
+	status    string
+	start     time.Time
+	end       time.Time
+	suspended bool
 }
 
 func (m *mockMigration) StatusMessage() string {
@@ -1368,3 +1400,7 @@ func (m *mockMigration) StartTime() time.Time {
 func (m *mockMigration) EndTime() time.Time {
 	return m.end
 }
+
+func (m *mockMigration) IsSuspended() bool {
+	return m.suspended
+}
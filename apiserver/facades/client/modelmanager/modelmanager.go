@@ -25,6 +25,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
@@ -48,22 +49,34 @@ var (
 
 type newCaasBrokerFunc func(_ stdcontext.Context, args environs.OpenParams) (caas.Broker, error)
 
+// LeadershipReaderFunc returns a leadership reader for the model with
+// the input UUID.
+type LeadershipReaderFunc func(modelUUID string) (leadership.Reader, error)
+
 // ModelManagerAPI implements the model manager interface and is
 // the concrete implementation of the api end point.
 // V10 of the facade does not return default-series or default-base
 // in model info
+// V11 adds ExportModels.
 type ModelManagerAPI struct {
 	*common.ModelStatusAPI
-	state       common.ModelManagerBackend
-	ctlrState   common.ModelManagerBackend
-	check       common.BlockCheckerInterface
-	authorizer  facade.Authorizer
-	toolsFinder common.ToolsFinder
-	apiUser     names.UserTag
-	isAdmin     bool
-	model       common.Model
-	getBroker   newCaasBrokerFunc
-	callContext context.ProviderCallContext
+	state               common.ModelManagerBackend
+	ctlrState           common.ModelManagerBackend
+	check               common.BlockCheckerInterface
+	authorizer          facade.Authorizer
+	toolsFinder         common.ToolsFinder
+	apiUser             names.UserTag
+	isAdmin             bool
+	model               common.Model
+	getBroker           newCaasBrokerFunc
+	callContext         context.ProviderCallContext
+	getLeadershipReader LeadershipReaderFunc
+}
+
+// ModelManagerAPIV10 implements the model manager interface and is
+// the concrete implementation of the api end point.
+type ModelManagerAPIV10 struct {
+	*ModelManagerAPI
 }
 
 // ModelManagerAPI implements the model manager interface and is
@@ -83,6 +96,7 @@ func NewModelManagerAPI(
 	authorizer facade.Authorizer,
 	m common.Model,
 	callCtx context.ProviderCallContext,
+	getLeadershipReader LeadershipReaderFunc,
 ) (*ModelManagerAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, apiservererrors.ErrPerm
@@ -99,17 +113,18 @@ func NewModelManagerAPI(
 	isAdmin := err == nil
 
 	return &ModelManagerAPI{
-		ModelStatusAPI: common.NewModelStatusAPI(st, authorizer, apiUser),
-		state:          st,
-		ctlrState:      ctlrSt,
-		getBroker:      getBroker,
-		check:          blockChecker,
-		authorizer:     authorizer,
-		toolsFinder:    toolsFinder,
-		apiUser:        apiUser,
-		isAdmin:        isAdmin,
-		model:          m,
-		callContext:    callCtx,
+		ModelStatusAPI:      common.NewModelStatusAPI(st, authorizer, apiUser),
+		state:               st,
+		ctlrState:           ctlrSt,
+		getBroker:           getBroker,
+		check:               blockChecker,
+		authorizer:          authorizer,
+		toolsFinder:         toolsFinder,
+		apiUser:             apiUser,
+		isAdmin:             isAdmin,
+		model:               m,
+		callContext:         callCtx,
+		getLeadershipReader: getLeadershipReader,
 	}, nil
 }
 
@@ -622,6 +637,66 @@ func (m *ModelManagerAPI) DumpModels(args params.DumpModelRequest) params.String
 	return results
 }
 
+// ExportModels exports the specified models, along with the charms,
+// agent binaries and resources they use, so that they can be archived
+// and later imported into another controller. Only controller superusers
+// may export models, as exports include the models' cloud credentials
+// and secrets.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		serialized, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = serialized
+	}
+	return results
+}
+
+// ExportModels isn't on the v10 API.
+func (*ModelManagerAPIV10) ExportModels(_, _ struct{}) {}
+
+// ExportModels isn't on the v9 API.
+func (*ModelManagerAPIV9) ExportModels(_, _ struct{}) {}
+
+func (m *ModelManagerAPI) exportModel(args params.Entity) (params.SerializedModel, error) {
+	var serialized params.SerializedModel
+	modelTag, err := names.ParseModelTag(args.Tag)
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
+	if !m.isAdmin {
+		return serialized, apiservererrors.ErrPerm
+	}
+
+	st, release, err := m.state.GetBackend(modelTag.Id())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return serialized, errors.Trace(apiservererrors.ErrBadId)
+		}
+		return serialized, errors.Trace(err)
+	}
+	defer release()
+
+	reader, err := m.getLeadershipReader(modelTag.Id())
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
+	leaders, err := reader.Leaders()
+	if err != nil {
+		return serialized, errors.Annotate(err, "getting application leaders")
+	}
+	model, err := st.Export(leaders)
+	if err != nil {
+		return serialized, errors.Trace(err)
+	}
+	return common.SerializeModel(model)
+}
+
 // DumpModelsDB will gather all documents from all model collections
 // for the specified model. The map result contains a map of collection
 // names to lists of documents represented as maps.
@@ -741,9 +816,10 @@ func (m *ModelManagerAPI) makeModelSummary(mi state.ModelSummary) *params.ModelS
 		}
 
 		summary.Migration = &params.ModelMigrationStatus{
-			Status: migration.StatusMessage(),
-			Start:  &startTime,
-			End:    endTime,
+			Status:    migration.StatusMessage(),
+			Start:     &startTime,
+			End:       endTime,
+			Suspended: migration.IsSuspended(),
 		}
 	}
 	return summary
@@ -1049,9 +1125,10 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag, withSecrets bool, wit
 			endTime = nil
 		}
 		info.Migration = &params.ModelMigrationStatus{
-			Status: migration.StatusMessage(),
-			Start:  &startTime,
-			End:    endTime,
+			Status:    migration.StatusMessage(),
+			Start:     &startTime,
+			End:       endTime,
+			Suspended: migration.IsSuspended(),
 		}
 	}
 
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/assumes"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
@@ -215,13 +216,13 @@ func (s *modelManagerSuite) SetUpTest(c *gc.C) {
 
 	api, err := modelmanager.NewModelManagerAPI(
 		s.st, s.ctlrSt, nil, newBroker, common.NewBlockChecker(s.st),
-		s.authoriser, s.st.model, s.callContext,
+		s.authoriser, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 	caasApi, err := modelmanager.NewModelManagerAPI(
 		s.caasSt, s.ctlrSt, nil, newBroker, common.NewBlockChecker(s.caasSt),
-		s.authoriser, s.st.model, s.callContext,
+		s.authoriser, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.caasApi = caasApi
@@ -242,7 +243,7 @@ func (s *modelManagerSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	}
 	mm, err := modelmanager.NewModelManagerAPI(
 		s.st, s.ctlrSt, nil, newBroker, common.NewBlockChecker(s.st),
-		s.authoriser, s.st.model, s.callContext,
+		s.authoriser, s.st.model, s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = mm
@@ -752,6 +753,56 @@ func (s *modelManagerSuite) TestDumpModelUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModels(c *gc.C) {
+	var readerModelUUID string
+	api, err := modelmanager.NewModelManagerAPI(
+		s.st, s.ctlrSt, nil, nil, common.NewBlockChecker(s.st),
+		s.authoriser, s.st.model, s.callContext,
+		func(modelUUID string) (leadership.Reader, error) {
+			readerModelUUID = modelUUID
+			return fakeLeadershipReader{"mysql": "mysql/0"}, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := api.ExportModels(params.Entities{Entities: []params.Entity{{
+		Tag: "application-foo",
+	}, {
+		Tag: s.st.ModelTag().String(),
+	}}})
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	notModel, good := results.Results[0], results.Results[1]
+	c.Check(notModel.Error.Message, gc.Equals, `"application-foo" is not a valid model tag`)
+
+	c.Assert(good.Error, gc.IsNil)
+	c.Check(string(good.Result.Bytes), gc.Equals, "model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d\n")
+	c.Check(readerModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	var leaders interface{}
+	for _, call := range s.st.Calls() {
+		if call.FuncName == "Export" {
+			leaders = call.Args[0]
+		}
+	}
+	c.Check(leaders, jc.DeepEquals, map[string]string{"mysql": "mysql/0"})
+}
+
+func (s *modelManagerSuite) TestExportModelsNotSuperuser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("otheruser"))
+	results := s.api.ExportModels(params.Entities{Entities: []params.Entity{{
+		Tag: s.st.ModelTag().String(),
+	}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.NotNil)
+	c.Check(results.Results[0].Error.Message, gc.Equals, `permission denied`)
+}
+
+type fakeLeadershipReader map[string]string
+
+func (r fakeLeadershipReader) Leaders() (map[string]string, error) {
+	return r, nil
+}
+
 func (s *modelManagerSuite) TestDumpModelsDB(c *gc.C) {
 	results := s.api.DumpModelsDB(params.Entities{[]params.Entity{{
 		Tag: "bad-tag",
@@ -870,7 +921,7 @@ func (s *modelManagerStateSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		common.NewBlockChecker(st),
 		s.authoriser,
 		s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.modelmanager = modelmanager
@@ -885,7 +936,7 @@ func (s *modelManagerStateSuite) TestNewAPIAcceptsClient(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(st), anAuthoriser,
 		s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(endPoint, gc.NotNil)
@@ -899,7 +950,7 @@ func (s *modelManagerStateSuite) TestNewAPIRefusesNonClient(c *gc.C) {
 		st,
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(st), anAuthoriser, s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(endPoint, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -1103,7 +1154,7 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(backend), s.authoriser,
 		s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1151,7 +1202,7 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(backend), s.authoriser,
 		s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1187,7 +1238,7 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 		backend,
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(backend), s.authoriser, s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1619,7 +1670,7 @@ func (s *modelManagerStateSuite) TestModelInfoForMigratedModel(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, common.NewBlockChecker(st), anAuthoriser,
 		s.Model,
-		s.callContext,
+		s.callContext, nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(endPoint, gc.NotNil)
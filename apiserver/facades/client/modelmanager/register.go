@@ -19,9 +19,12 @@ import (
 
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
+	registry.MustRegister("ModelManager", 11, func(ctx facade.Context) (facade.Facade, error) {
+		return newFacadeV11(ctx)
+	}, reflect.TypeOf((*ModelManagerAPI)(nil)))
 	registry.MustRegister("ModelManager", 10, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacadeV10(ctx)
-	}, reflect.TypeOf((*ModelManagerAPI)(nil)))
+	}, reflect.TypeOf((*ModelManagerAPIV10)(nil)))
 	registry.MustRegister("ModelManager", 9, func(ctx facade.Context) (facade.Facade, error) {
 		return newFacadeV9(ctx)
 	}, reflect.TypeOf((*ModelManagerAPIV9)(nil)))
@@ -29,7 +32,7 @@ func Register(registry facade.FacadeRegistry) {
 
 // newFacadeV9 is used for API registration.
 func newFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
-	api, err := newFacadeV10(ctx)
+	api, err := newFacadeV11(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -37,7 +40,16 @@ func newFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
 }
 
 // newFacadeV10 is used for API registration.
-func newFacadeV10(ctx facade.Context) (*ModelManagerAPI, error) {
+func newFacadeV10(ctx facade.Context) (*ModelManagerAPIV10, error) {
+	api, err := newFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelManagerAPIV10{api}, nil
+}
+
+// newFacadeV11 is used for API registration.
+func newFacadeV11(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt, err := pool.SystemState()
@@ -83,5 +95,6 @@ func newFacadeV10(ctx facade.Context) (*ModelManagerAPI, error) {
 		auth,
 		model,
 		context.CallContext(st),
+		ctx.LeadershipReader,
 	)
 }
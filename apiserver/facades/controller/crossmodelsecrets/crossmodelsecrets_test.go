@@ -133,6 +133,9 @@ func (s *CrossModelSecretsSuite) setup(c *gc.C) *gomock.Controller {
 		coretesting.ModelTag.Id(),
 		secretsStateGetter,
 		backendConfigGetter,
+		func(modelUUID string) (*provider.ModelBackendConfigInfo, error) {
+			return nil, errors.NotImplementedf("admin config")
+		},
 		s.crossModelState,
 		s.stateBackend,
 	)
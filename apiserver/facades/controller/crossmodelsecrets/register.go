@@ -42,6 +42,14 @@ func newStateCrossModelSecretsAPI(ctx facade.Context) (*CrossModelSecretsAPI, er
 		defer closer.Release()
 		return secrets.BackendConfigInfo(secrets.SecretsModel(model), sameController, []string{backendID}, false, consumer, leadershipChecker)
 	}
+	secretBackendAdminConfigGetter := func(modelUUID string) (*provider.ModelBackendConfigInfo, error) {
+		model, closer, err := ctx.StatePool().GetModel(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer closer.Release()
+		return secrets.AdminBackendConfigInfo(secrets.SecretsModel(model))
+	}
 	secretInfoGetter := func(modelUUID string) (SecretsState, SecretsConsumer, func() bool, error) {
 		st, err := ctx.StatePool().Get(modelUUID)
 		if err != nil {
@@ -58,6 +66,7 @@ func newStateCrossModelSecretsAPI(ctx facade.Context) (*CrossModelSecretsAPI, er
 		st.ModelUUID(),
 		secretInfoGetter,
 		secretBackendConfigGetter,
+		secretBackendAdminConfigGetter,
 		&crossModelShim{st.RemoteEntities()},
 		&stateBackendShim{st},
 	)
@@ -6,19 +6,16 @@ package migrationmaster
 import (
 	"encoding/json"
 
-	"github.com/juju/collections/set"
 	"github.com/juju/description/v5"
 	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	"github.com/juju/naturalsort"
-	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/leadership"
 	coremigration "github.com/juju/juju/core/migration"
-	coremodel "github.com/juju/juju/core/model"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/rpc/params"
@@ -39,6 +36,11 @@ type API struct {
 	leadership              leadership.Reader
 }
 
+// APIV3 implements the V3 version of the API facade.
+type APIV3 struct {
+	*API
+}
+
 // NewAPI creates a new API server endpoint for the model migration
 // master worker.
 func NewAPI(
@@ -119,6 +121,7 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
+		Suspended:        mig.IsSuspended(),
 	}, nil
 }
 
@@ -255,6 +258,21 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// Suspend stops the migration at its current phase after a failure,
+// rather than aborting it. The message is recorded as the migration's
+// status and should explain why the migration was suspended.
+func (api *API) Suspend(args params.SetMigrationStatusMessageArgs) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	err = mig.Suspend(args.Message)
+	return errors.Annotate(err, "failed to suspend migration")
+}
+
+// Suspend isn't on the V3 API.
+func (*APIV3) Suspend(_, _ struct{}) {}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -269,17 +287,7 @@ func (api *API) Export() (params.SerializedModel, error) {
 		return serialized, err
 	}
 
-	bytes, err := description.Serialize(model)
-	if err != nil {
-		return serialized, err
-	}
-	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
-	serialized.Resources = getUsedResources(model)
-	if model.Type() == string(coremodel.IAAS) {
-		serialized.Tools = getUsedTools(model)
-	}
-	return serialized, nil
+	return common.SerializeModel(model)
 }
 
 // ProcessRelations processes any relations that need updating after an export.
@@ -386,97 +394,3 @@ func (api *API) MinionReportTimeout() (params.StringResult, error) {
 	}
 	return params.StringResult{Result: cfg.MigrationMinionWaitMax().String()}, nil
 }
-
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
-func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
-		out = append(out, params.SerializedModelTools{
-			Version: v.String(),
-			URI:     common.ToolsURL("", v),
-		})
-	}
-	return out
-}
-
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
-
-func getUsedResources(model description.Model) []params.SerializedModelResource {
-	var out []params.SerializedModelResource
-	for _, app := range model.Applications() {
-		for _, resource := range app.Resources() {
-			outRes := resourceToSerialized(app.Name(), resource)
-
-			// Hunt through the application's units and look for
-			// revisions of this resource. This is particularly
-			// efficient or clever but will be fine even with 1000's
-			// of units and 10's of resources.
-			outRes.UnitRevisions = make(map[string]params.SerializedModelResourceRevision)
-			for _, unit := range app.Units() {
-				for _, unitResource := range unit.Resources() {
-					if unitResource.Name() == resource.Name() {
-						outRes.UnitRevisions[unit.Name()] = revisionToSerialized(unitResource.Revision())
-					}
-				}
-			}
-
-			out = append(out, outRes)
-		}
-
-	}
-	return out
-}
-
-func resourceToSerialized(app string, desc description.Resource) params.SerializedModelResource {
-	return params.SerializedModelResource{
-		Application:         app,
-		Name:                desc.Name(),
-		ApplicationRevision: revisionToSerialized(desc.ApplicationRevision()),
-		CharmStoreRevision:  revisionToSerialized(desc.CharmStoreRevision()),
-	}
-}
-
-func revisionToSerialized(rr description.ResourceRevision) params.SerializedModelResourceRevision {
-	if rr == nil {
-		return params.SerializedModelResourceRevision{}
-	}
-	return params.SerializedModelResourceRevision{
-		Revision:       rr.Revision(),
-		Type:           rr.Type(),
-		Path:           rr.Path(),
-		Description:    rr.Description(),
-		Origin:         rr.Origin(),
-		FingerprintHex: rr.FingerprintHex(),
-		Size:           rr.Size(),
-		Timestamp:      rr.Timestamp(),
-		Username:       rr.Username(),
-	}
-}
@@ -134,6 +134,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	exp.Id().Return("ID")
 	now := time.Now()
 	exp.PhaseChangedTime().Return(now)
+	exp.IsSuspended().Return(true)
 
 	s.backend.EXPECT().LatestMigration().Return(mig, nil)
 
@@ -156,6 +157,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 		MigrationId:      "ID",
 		Phase:            "IMPORT",
 		PhaseChangedTime: now,
+		Suspended:        true,
 	})
 }
 
@@ -290,6 +292,32 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSuspend(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().Suspend("upload failed").Return(nil)
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).Suspend(params.SetMigrationStatusMessageArgs{Message: "upload failed"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestSuspendError(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().Suspend("upload failed").Return(errors.New("blam"))
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).Suspend(params.SetMigrationStatusMessageArgs{Message: "upload failed"})
+	c.Assert(err, gc.ErrorMatches, "failed to suspend migration: blam")
+}
+
 func (s *Suite) TestPrechecksModelError(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
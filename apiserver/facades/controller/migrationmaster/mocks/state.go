@@ -99,6 +99,20 @@ func (mr *MockModelMigrationMockRecorder) InitiatedBy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiatedBy", reflect.TypeOf((*MockModelMigration)(nil).InitiatedBy))
 }
 
+// IsSuspended mocks base method.
+func (m *MockModelMigration) IsSuspended() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSuspended")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSuspended indicates an expected call of IsSuspended.
+func (mr *MockModelMigrationMockRecorder) IsSuspended() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSuspended", reflect.TypeOf((*MockModelMigration)(nil).IsSuspended))
+}
+
 // MinionReports mocks base method.
 func (m *MockModelMigration) MinionReports() (*state.MinionReports, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +199,20 @@ func (mr *MockModelMigrationMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockModelMigration)(nil).Refresh))
 }
 
+// Resume mocks base method.
+func (m *MockModelMigration) Resume() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockModelMigrationMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockModelMigration)(nil).Resume))
+}
+
 // SetPhase mocks base method.
 func (m *MockModelMigration) SetPhase(arg0 migration.Phase) error {
 	m.ctrl.T.Helper()
@@ -269,6 +297,20 @@ func (mr *MockModelMigrationMockRecorder) SuccessTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuccessTime", reflect.TypeOf((*MockModelMigration)(nil).SuccessTime))
 }
 
+// Suspend mocks base method.
+func (m *MockModelMigration) Suspend(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockModelMigrationMockRecorder) Suspend(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockModelMigration)(nil).Suspend), arg0)
+}
+
 // TargetInfo mocks base method.
 func (m *MockModelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	m.ctrl.T.Helper()
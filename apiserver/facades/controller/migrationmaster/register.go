@@ -16,10 +16,22 @@ import (
 // Register is called to expose a package of facades onto a given registry.
 func Register(registry facade.FacadeRegistry) {
 	registry.MustRegister("MigrationMaster", 3, func(ctx facade.Context) (facade.Facade, error) {
-		return newMigrationMasterFacade(ctx) // Adds MinionReportTimeout.
+		return newMigrationMasterFacadeV3(ctx) // Adds MinionReportTimeout.
+	}, reflect.TypeOf((*APIV3)(nil)))
+	registry.MustRegister("MigrationMaster", 4, func(ctx facade.Context) (facade.Facade, error) {
+		return newMigrationMasterFacade(ctx) // Adds Suspend.
 	}, reflect.TypeOf((*API)(nil)))
 }
 
+// newMigrationMasterFacadeV3 creates a V3 migration master facade.
+func newMigrationMasterFacadeV3(ctx facade.Context) (*APIV3, error) {
+	api, err := newMigrationMasterFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{api}, nil
+}
+
 // newMigrationMasterFacade exists to provide the required signature for API
 // registration, converting st to backend.
 func newMigrationMasterFacade(ctx facade.Context) (*API, error) {
@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/juju/description/v5"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/credentialcommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
@@ -26,6 +30,8 @@ import (
 	"github.com/juju/juju/state/stateenvirons"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.migrationtarget")
+
 // API implements the API required for the model migration
 // master worker when communicating with the target controller.
 type API struct {
@@ -50,6 +56,16 @@ type APIV2 struct {
 	*APIV1
 }
 
+// APIV3 implements the V3 version of the API facade.
+type APIV3 struct {
+	*APIV4
+}
+
+// APIV4 implements the V4 version of the API facade.
+type APIV4 struct {
+	*API
+}
+
 // NewAPI returns a new APIV1. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(
@@ -87,43 +103,188 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	// If there are no required migration facade versions, then we
-	// don't need to check anything.
-	if len(api.requiredMigrationFacadeVersions) > 0 {
-		// Ensure that when attempting to migrate a model, the source
-		// controller has the required facades for the migration.
-		sourceFacadeVersions := facades.FacadeVersions{}
-		for name, versions := range model.FacadeVersions {
-			sourceFacadeVersions[name] = versions
+	if err := api.checkSourceFacadeVersions(model); err != nil {
+		return err
+	}
+	backend, modelInfo, presence, err := api.precheckArgs(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		presence,
+	)
+}
+
+// PrecheckReport runs the same checks as Prechecks, along with a check
+// that the model can be imported, without leaving anything changed: the
+// model is imported and then removed again, as it is when a migration
+// is aborted. Rather than failing on the first issue found, it reports
+// every issue which would prevent the migration or affect it.
+func (api *API) PrecheckReport(model params.MigrationModelInfo) (params.MigrationPrecheckResult, error) {
+	result := params.MigrationPrecheckResult{
+		ModelTag: names.NewModelTag(model.UUID).String(),
+	}
+	var issues coremigration.PrecheckIssues
+	if err := api.checkSourceFacadeVersions(model); err != nil {
+		issues = append(issues, targetBlocker(err.Error()))
+	}
+	backend, modelInfo, presence, err := api.precheckArgs(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	targetIssues, err := migration.TargetPrecheckReport(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		presence,
+	)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	issues = append(issues, targetIssues...)
+	if len(model.ModelDescription) > 0 {
+		if err := migration.ValidateModel(model.ModelDescription); err != nil {
+			issues = append(issues, targetBlocker(fmt.Sprintf("model description is not valid: %v", err)))
+		} else if err := api.dryRunImport(model.ModelDescription); err != nil {
+			issues = append(issues, targetBlocker(fmt.Sprintf("model cannot be imported: %v", err)))
 		}
-		if !facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
-			majorMinor := fmt.Sprintf("%d.%d",
-				model.ControllerAgentVersion.Major,
-				model.ControllerAgentVersion.Minor,
-			)
-
-			// If the patch is zero, then we don't need to mention it.
-			var patchMessage string
-			if model.ControllerAgentVersion.Patch > 0 {
-				patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
+	}
+	result.Issues = common.PrecheckIssuesToParams(issues)
+	return result, nil
+}
+
+// dryRunImport imports the serialized model as Import does, without
+// claiming leadership, and then removes the imported model along with
+// any cloud credential added for it. Nothing is imported if the model
+// already exists, as the prechecks report that.
+func (api *API) dryRunImport(bytes []byte) (err error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelUUID := model.Tag().Id()
+	if exists, err := api.state.ModelExists(modelUUID); err != nil {
+		return errors.Trace(err)
+	} else if exists {
+		return nil
+	}
+	var credTag names.CloudCredentialTag
+	var credExisted bool
+	if creds := model.CloudCredential(); creds != nil {
+		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+		if names.IsValidCloudCredential(credID) {
+			credTag = names.NewCloudCredentialTag(credID)
+			_, err := api.state.CloudCredential(credTag)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
 			}
+			credExisted = err == nil
+		}
+	}
+
+	defer func() {
+		cleanupErr := api.removeDryRunModel(modelUUID, credTag, credExisted)
+		if cleanupErr == nil {
+			return
+		}
+		if err == nil {
+			err = errors.Annotate(cleanupErr, "removing imported model")
+		} else {
+			logger.Errorf("removing model %s imported by migration dry run: %v", modelUUID, cleanupErr)
+		}
+	}()
+	_, st, err := state.NewController(api.pool).Import(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st.Close()
+	return nil
+}
 
-			return errors.Errorf(`
+// removeDryRunModel removes a model imported by dryRunImport, if it was
+// created, and the cloud credential it was imported with unless that
+// credential existed before the import.
+func (api *API) removeDryRunModel(modelUUID string, credTag names.CloudCredentialTag, credExisted bool) error {
+	exists, err := api.state.ModelExists(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		st, err := api.pool.Get(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer st.Release()
+		if err := st.RemoveImportingModelDocs(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if credTag.Id() != "" && !credExisted {
+		return errors.Trace(api.state.RemoveCloudCredential(credTag))
+	}
+	return nil
+}
+
+// PrecheckReport isn't on the V3 API.
+func (*APIV3) PrecheckReport(_, _ struct{}) {}
+
+func targetBlocker(message string) coremigration.PrecheckIssue {
+	return coremigration.PrecheckIssue{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckTarget,
+		Message:    message,
+	}
+}
+
+// checkSourceFacadeVersions ensures that the source controller has the
+// facades required for the migration.
+func (api *API) checkSourceFacadeVersions(model params.MigrationModelInfo) error {
+	// If there are no required migration facade versions, then we
+	// don't need to check anything.
+	if len(api.requiredMigrationFacadeVersions) == 0 {
+		return nil
+	}
+	sourceFacadeVersions := facades.FacadeVersions{}
+	for name, versions := range model.FacadeVersions {
+		sourceFacadeVersions[name] = versions
+	}
+	if facades.CompleteIntersection(api.requiredMigrationFacadeVersions, sourceFacadeVersions) {
+		return nil
+	}
+	majorMinor := fmt.Sprintf("%d.%d",
+		model.ControllerAgentVersion.Major,
+		model.ControllerAgentVersion.Minor,
+	)
+
+	// If the patch is zero, then we don't need to mention it.
+	var patchMessage string
+	if model.ControllerAgentVersion.Patch > 0 {
+		patchMessage = fmt.Sprintf(", that is greater than %s.%d", majorMinor, model.ControllerAgentVersion.Patch)
+	}
+
+	return errors.Errorf(`
 Source controller does not support required facades for performing migration.
 Upgrade the controller to a newer version of %s%s or migrate to a controller
 with an earlier version of the target controller and try again.
 
 `[1:], majorMinor, patchMessage)
-		}
-	}
+}
 
+// precheckArgs returns the arguments needed to run the target
+// prechecks for the model.
+func (api *API) precheckArgs(model params.MigrationModelInfo) (
+	migration.PrecheckBackend, coremigration.ModelInfo, migration.ModelPresence, error,
+) {
 	ownerTag, err := names.ParseUserTag(model.OwnerTag)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, nil, errors.Trace(err)
 	}
 	controllerState, err := api.pool.SystemState()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, coremigration.ModelInfo{}, nil, errors.Trace(err)
 	}
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -131,20 +292,16 @@ with an earlier version of the target controller and try again.
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, coremigration.ModelInfo{}, nil, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
-	)
+	modelInfo := coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}
+	return backend, modelInfo, api.presence.ModelPresence(controllerState.ModelUUID()), nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -281,6 +438,82 @@ func (api *API) Activate(args params.ActivateModelArgs) error {
 	return model.SetMigrationMode(state.MigrationModeNone)
 }
 
+// ImportedBinaries reports the charms, agent binaries and resources
+// already held for a model being imported, along with hashes of the
+// agent binary and resource content. A resumed migration uses these
+// to avoid transferring binaries which were uploaded by an earlier
+// attempt. It is an error to request the binaries of a model that has
+// a migration mode other than importing.
+func (api *API) ImportedBinaries(args params.ModelArgs) (params.MigrationImportedBinaries, error) {
+	var result params.MigrationImportedBinaries
+	model, release, err := api.getImportingModel(args.ModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer release()
+	st := model.State()
+
+	charms, err := st.AllCharms()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving charms")
+	}
+	for _, ch := range charms {
+		if ch.IsPlaceholder() || !ch.IsUploaded() || ch.SourceSha256() == "" {
+			continue
+		}
+		result.Charms = append(result.Charms, params.MigrationImportedCharm{
+			URL:    ch.URL(),
+			SHA256: ch.SourceSha256(),
+		})
+	}
+
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer func() { _ = storage.Close() }()
+	metadata, err := storage.AllMetadata()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving agent binaries")
+	}
+	for _, m := range metadata {
+		v, err := version.ParseBinary(m.Version)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Tools = append(result.Tools, params.MigrationImportedTools{
+			Version: v,
+			SHA256:  m.SHA256,
+		})
+	}
+
+	apps, err := st.AllApplications()
+	if err != nil {
+		return result, errors.Annotate(err, "retrieving applications")
+	}
+	resources := st.Resources()
+	for _, app := range apps {
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return result, errors.Annotatef(err, "retrieving resources for %q", app.Name())
+		}
+		for _, res := range appResources.Resources {
+			if res.IsPlaceholder() {
+				continue
+			}
+			result.Resources = append(result.Resources, params.MigrationImportedResource{
+				Application: app.Name(),
+				Name:        res.Name,
+				Fingerprint: res.Fingerprint.Hex(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// ImportedBinaries isn't on the V4 API.
+func (*APIV4) ImportedBinaries(_, _ struct{}) {}
+
 // LatestLogTime returns the time of the most recent log record
 // received by the logtransfer endpoint. This can be used as the start
 // point for streaming logs from the source if the transfer was
@@ -5,6 +5,7 @@ package migrationtarget_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/description/v5"
@@ -81,7 +82,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 5)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := aFactory(&facadetest.Context{
@@ -93,6 +94,32 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV4(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV4))
+}
+
+func (s *Suite) TestFacadeRegisteredV3(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV3))
+}
+
 func (s *Suite) TestFacadeRegisteredV2(c *gc.C) {
 	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
@@ -192,6 +219,92 @@ with an earlier version of the target controller and try again.
 `[1:])
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	args := params.MigrationModelInfo{
+		UUID:                   uuid,
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		ModelDescription:       bytes,
+	}
+	result, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.MigrationPrecheckResult{
+		ModelTag: names.NewModelTag(uuid).String(),
+		Issues:   []params.MigrationPrecheckIssue{},
+	})
+
+	// The model was imported to check it, and then removed.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestPrecheckReportImportFails(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	bytes = []byte(strings.Replace(string(bytes), "\ncloud: dummy\n", "\ncloud: nowhere\n", 1))
+	args := params.MigrationModelInfo{
+		UUID:                   uuid,
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+		ModelDescription:       bytes,
+	}
+	result, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Issues, gc.HasLen, 1)
+	c.Check(result.Issues[0].Severity, gc.Equals, "blocker")
+	c.Check(result.Issues[0].Controller, gc.Equals, "target")
+	c.Check(result.Issues[0].Message, gc.Matches, `model cannot be imported: .*cloud "nowhere" not found`)
+
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestPrecheckReportIssues(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPIWithFacadeVersions(c, facades.FacadeVersions{
+		"MigrationTarget": []int{1},
+	})
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           modelVersion,
+		ControllerAgentVersion: controllerVersion,
+		ModelDescription:       []byte("not a model"),
+	}
+	result, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelTag, gc.Equals, names.NewModelTag("uuid").String())
+	c.Assert(result.Issues, gc.HasLen, 3)
+	for _, issue := range result.Issues {
+		c.Check(issue.Severity, gc.Equals, "blocker")
+		c.Check(issue.Controller, gc.Equals, "target")
+	}
+	c.Check(result.Issues[0].Message, gc.Matches, "Source controller does not support required facades (.|\n)*")
+	c.Check(result.Issues[1].Message, gc.Equals, fmt.Sprintf(
+		"model has higher version than target controller (%s > %s)", modelVersion, controllerVersion))
+	c.Check(result.Issues[2].Message, gc.Matches, "model description is not valid: yaml: unmarshal errors:\n.*")
+}
+
+func (s *Suite) TestPrecheckReportBadOwner(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.PrecheckReport(params.MigrationModelInfo{OwnerTag: "bad"})
+	c.Assert(err, gc.ErrorMatches, `"bad" is not a valid tag`)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -262,6 +375,34 @@ func (s *Suite) TestAbortNotImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) TestImportedBinaries(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "wordpress",
+		}),
+	})
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	// Charms are only added to an importing model as their archives
+	// are uploaded, so none are reported straight after the import.
+	result, err := api.ImportedBinaries(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Charms, gc.HasLen, 0)
+	c.Check(result.Resources, gc.HasLen, 0)
+}
+
+func (s *Suite) TestImportedBinariesNotImportingModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	_, err = api.ImportedBinaries(params.ModelArgs{ModelTag: model.ModelTag().String()})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
 func (s *Suite) TestActivate(c *gc.C) {
 	sourceModel := "deadbeef-0bad-400d-8000-4b1d0d06f666"
 	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
@@ -25,6 +25,12 @@ func Register(requiredMigrationFacadeVersions facades.FacadeVersions) func(regis
 			return newFacadeV2(ctx)
 		}, reflect.TypeOf((*APIV2)(nil)))
 		registry.MustRegister("MigrationTarget", 3, func(ctx facade.Context) (facade.Facade, error) {
+			return newFacadeV3(ctx, requiredMigrationFacadeVersions)
+		}, reflect.TypeOf((*APIV3)(nil)))
+		registry.MustRegister("MigrationTarget", 4, func(ctx facade.Context) (facade.Facade, error) {
+			return newFacadeV4(ctx, requiredMigrationFacadeVersions)
+		}, reflect.TypeOf((*APIV4)(nil)))
+		registry.MustRegister("MigrationTarget", 5, func(ctx facade.Context) (facade.Facade, error) {
 			return newFacade(ctx, requiredMigrationFacadeVersions)
 		}, reflect.TypeOf((*API)(nil)))
 	}
@@ -58,6 +64,24 @@ func newFacadeV2(ctx facade.Context) (*APIV2, error) {
 	return &APIV2{APIV1: &APIV1{API: api}}, nil
 }
 
+// newFacadeV3 is used for APIV3 registration.
+func newFacadeV3(ctx facade.Context, facadeVersions facades.FacadeVersions) (*APIV3, error) {
+	api, err := newFacade(ctx, facadeVersions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{APIV4: &APIV4{API: api}}, nil
+}
+
+// newFacadeV4 is used for APIV4 registration.
+func newFacadeV4(ctx facade.Context, facadeVersions facades.FacadeVersions) (*APIV4, error) {
+	api, err := newFacade(ctx, facadeVersions)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV4{API: api}, nil
+}
+
 // newFacade is used for API registration.
 func newFacade(ctx facade.Context, facadeVersions facades.FacadeVersions) (*API, error) {
 	return NewAPI(
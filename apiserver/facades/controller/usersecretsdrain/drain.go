@@ -25,6 +25,7 @@ type SecretsDrainAPI struct {
 
 	drainConfigGetter   commonsecrets.BackendDrainConfigGetter
 	backendConfigGetter commonsecrets.BackendConfigGetter
+	adminConfigGetter   commonsecrets.BackendAdminConfigGetter
 }
 
 // GetSecretBackendConfigs gets the config needed to create a client to secret backends for the drain worker.
@@ -129,7 +130,15 @@ func (s *SecretsDrainAPI) getSecretContent(arg params.GetSecretContentArg) (
 	}
 	// Get backend config for external secret.
 	backend, draining, err := s.getBackend(content.ValueRef.BackendID)
-	return content, backend, draining, errors.Trace(err)
+	if err != nil {
+		return nil, nil, false, errors.Trace(err)
+	}
+	if commonsecrets.IsControllerOnlyBackendType(backend.BackendType) {
+		// The drain worker can't access the backend, so it's given the content.
+		val, err := commonsecrets.GetControllerOnlyContent(s.adminConfigGetter, content.ValueRef)
+		return &secrets.ContentParams{SecretValue: val}, nil, false, errors.Trace(err)
+	}
+	return content, backend, draining, nil
 }
 
 func (s *SecretsDrainAPI) getBackend(backendID string) (*provider.ModelBackendConfig, bool, error) {
@@ -170,15 +179,29 @@ func (s *SecretsDrainAPI) GetSecretRevisionContentInfo(arg params.SecretRevision
 		}
 		contentParams := params.SecretContentParams{}
 		if valueRef != nil {
-			contentParams.ValueRef = &params.SecretValueRef{
-				BackendID:  valueRef.BackendID,
-				RevisionID: valueRef.RevisionID,
-			}
 			backend, draining, err := s.getBackend(valueRef.BackendID)
 			if err != nil {
 				result.Results[i].Error = apiservererrors.ServerError(err)
 				continue
 			}
+			if commonsecrets.IsControllerOnlyBackendType(backend.BackendType) {
+				// The drain worker can't access the backend, so it's given
+				// the content rather than a reference to it.
+				if !arg.PendingDelete {
+					val, err := commonsecrets.GetControllerOnlyContent(s.adminConfigGetter, valueRef)
+					if err != nil {
+						result.Results[i].Error = apiservererrors.ServerError(err)
+						continue
+					}
+					contentParams.Data = val.EncodedValues()
+				}
+				result.Results[i].Content = contentParams
+				continue
+			}
+			contentParams.ValueRef = &params.SecretValueRef{
+				BackendID:  valueRef.BackendID,
+				RevisionID: valueRef.RevisionID,
+			}
 			result.Results[i].BackendConfig = &params.SecretBackendConfigResult{
 				ControllerUUID: backend.ControllerUUID,
 				ModelUUID:      backend.ModelUUID,
@@ -74,8 +74,12 @@ func (s *drainSuite) setup(c *gc.C) *gomock.Controller {
 		}, nil
 	}
 
+	adminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return drainConfigGetter("")
+	}
+
 	var err error
-	s.facade, err = usersecretsdrain.NewTestAPI(s.authorizer, s.secretsState, backendConfigGetter, drainConfigGetter)
+	s.facade, err = usersecretsdrain.NewTestAPI(s.authorizer, s.secretsState, backendConfigGetter, drainConfigGetter, adminConfigGetter)
 	c.Assert(err, jc.ErrorIsNil)
 
 	return ctrl
@@ -26,6 +26,7 @@ func NewTestAPI(
 	secretsState SecretsState,
 	backendConfigGetter commonsecrets.BackendConfigGetter,
 	drainConfigGetter commonsecrets.BackendDrainConfigGetter,
+	adminConfigGetter commonsecrets.BackendAdminConfigGetter,
 ) (*SecretsDrainAPI, error) {
 	if !authorizer.AuthController() {
 		return nil, apiservererrors.ErrPerm
@@ -34,5 +35,6 @@ func NewTestAPI(
 		secretsState:        secretsState,
 		backendConfigGetter: backendConfigGetter,
 		drainConfigGetter:   drainConfigGetter,
+		adminConfigGetter:   adminConfigGetter,
 	}, nil
 }
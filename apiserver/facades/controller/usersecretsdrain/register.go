@@ -36,6 +36,9 @@ func newUserSecretsDrainAPI(context facade.Context) (*SecretsDrainAPI, error) {
 		return nil, errors.Trace(err)
 	}
 	authTag := model.ModelTag()
+	secretBackendAdminConfigGetter := func() (*provider.ModelBackendConfigInfo, error) {
+		return commonsecrets.AdminBackendConfigInfo(commonsecrets.SecretsModel(model))
+	}
 	commonDrainAPI, err := commonsecrets.NewSecretsDrainAPI(
 		authTag,
 		context.Auth(),
@@ -44,6 +47,7 @@ func newUserSecretsDrainAPI(context facade.Context) (*SecretsDrainAPI, error) {
 		commonsecrets.SecretsModel(model),
 		state.NewSecrets(context.State()),
 		context.State(),
+		secretBackendAdminConfigGetter,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -60,6 +64,7 @@ func newUserSecretsDrainAPI(context facade.Context) (*SecretsDrainAPI, error) {
 		SecretsDrainAPI:     commonDrainAPI,
 		drainConfigGetter:   secretBackendDrainConfigGetter,
 		backendConfigGetter: secretBackendConfigGetter,
+		adminConfigGetter:   secretBackendAdminConfigGetter,
 		secretsState:        state.NewSecrets(context.State()),
 	}, nil
 }
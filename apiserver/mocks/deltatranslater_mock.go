@@ -194,6 +194,20 @@ func (mr *MockDeltaTranslaterMockRecorder) TranslateRemoteApplication(arg0 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateRemoteApplication", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateRemoteApplication), arg0)
 }
 
+// TranslateSecret mocks base method.
+func (m *MockDeltaTranslater) TranslateSecret(arg0 multiwatcher.EntityInfo) params.EntityInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateSecret", arg0)
+	ret0, _ := ret[0].(params.EntityInfo)
+	return ret0
+}
+
+// TranslateSecret indicates an expected call of TranslateSecret.
+func (mr *MockDeltaTranslaterMockRecorder) TranslateSecret(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateSecret", reflect.TypeOf((*MockDeltaTranslater)(nil).TranslateSecret), arg0)
+}
+
 // TranslateUnit mocks base method.
 func (m *MockDeltaTranslater) TranslateUnit(arg0 multiwatcher.EntityInfo) params.EntityInfo {
 	m.ctrl.T.Helper()
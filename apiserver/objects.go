@@ -131,17 +131,18 @@ func (h *objectsCharmHandler) processPut(r *http.Request, st *state.State) (*cha
 	}
 	curl.Name = name
 
+	isImporting, err := modelIsImporting(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	schema := curl.Schema
-	if schema != "local" {
+	if schema != "local" && !isImporting {
 		// charmhub charms may only be uploaded into models
 		// which are being imported during model migrations.
 		// There's currently no other time where it makes sense
 		// to accept repository charms through this endpoint.
-		if isImporting, err := modelIsImporting(st); err != nil {
-			return nil, errors.Trace(err)
-		} else if !isImporting {
-			return nil, errors.New("non-local charms may only be uploaded during model migration import")
-		}
+		return nil, errors.New("non-local charms may only be uploaded during model migration import")
 	}
 
 	charmFileName, err := writeCharmToTempFile(r.Body)
@@ -150,14 +151,14 @@ func (h *objectsCharmHandler) processPut(r *http.Request, st *state.State) (*cha
 	}
 	defer os.Remove(charmFileName)
 
-	charmSHA, _, err := utils.ReadFileSHA256(charmFileName)
+	sourceSHA, _, err := utils.ReadFileSHA256(charmFileName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	// ReadFileSHA256 returns a full 64 char SHA256. However, charm refs
 	// only use the first 7 chars. So truncate the sha to match
-	charmSHA = charmSHA[0:7]
+	charmSHA := sourceSHA[0:7]
 	if charmSHA != shaFromQuery {
 		return nil, errors.BadRequestf("Uploaded charm sha256 (%v) does not match sha in url (%v)", charmSHA, shaFromQuery)
 	}
@@ -171,6 +172,21 @@ func (h *objectsCharmHandler) processPut(r *http.Request, st *state.State) (*cha
 		curl.Revision = archive.Revision()
 	}
 
+	if isImporting {
+		// A resumed migration may upload a charm which was already
+		// imported. The stored archive is never replaced, so only the
+		// same source archive is accepted.
+		existing, err := st.Charm(curl.String())
+		if err == nil && existing.IsUploaded() {
+			if existing.SourceSha256() != sourceSHA {
+				return nil, errors.Errorf("charm %q already imported with different content", curl)
+			}
+			return curl, nil
+		} else if !errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	switch charm.Schema(schema) {
 	case charm.Local:
 		curl, err = st.PrepareLocalCharmUpload(curl.String())
@@ -187,7 +203,15 @@ func (h *objectsCharmHandler) processPut(r *http.Request, st *state.State) (*cha
 		return nil, errors.Errorf("unsupported schema %q", schema)
 	}
 
-	return curl, errors.Trace(RepackageAndUploadCharm(st, archive, curl.String(), curl.Revision))
+	if err := RepackageAndUploadCharm(st, archive, curl.String(), curl.Revision); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if isImporting {
+		if err := st.SetCharmSourceSha256(curl.String(), sourceSHA); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return curl, nil
 }
 
 func splitNameAndSHAFromQuery(query url.Values) (string, string, error) {
@@ -191,6 +191,42 @@ func (s *putObjectsSuite) TestUploadBumpsRevision(c *gc.C) {
 	c.Assert(sch.BundleSha256(), gc.Not(gc.Equals), "")
 }
 
+func (s *putObjectsSuite) TestUploadDuringImportIsIdempotent(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	f, err := os.Open(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	hash := getCharmHash(c, f)
+	resp := s.uploadRequest(c, s.objectsCharmsURI("dummy-"+hash), "application/zip", "ch:amd64/quantal/dummy-1", f)
+	s.assertUploadResponse(c, resp, "ch:amd64/quantal/dummy-1")
+
+	sch, err := s.State.Charm("ch:amd64/quantal/dummy-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.SourceSha256(), gc.HasLen, 64)
+	c.Assert(sch.SourceSha256()[0:7], gc.Equals, hash)
+
+	// Uploading the same archive again is accepted.
+	_, err = f.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	resp = s.uploadRequest(c, s.objectsCharmsURI("dummy-"+hash), "application/zip", "ch:amd64/quantal/dummy-1", f)
+	s.assertUploadResponse(c, resp, "ch:amd64/quantal/dummy-1")
+
+	// A different archive for the same charm URL is rejected.
+	other := testcharms.Repo.CharmArchive(c.MkDir(), "logging")
+	o, err := os.Open(other.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer o.Close()
+	otherHash := getCharmHash(c, o)
+	c.Assert(otherHash, gc.Not(gc.Equals), hash)
+	resp = s.uploadRequest(c, s.objectsCharmsURI("dummy-"+otherHash), "application/zip", "ch:amd64/quantal/dummy-1", o)
+	s.assertErrorResponse(c, resp, http.StatusBadRequest, `.*charm "ch:amd64/quantal/dummy-1" already imported with different content`)
+}
+
 func getCharmHash(c *gc.C, stream io.ReadSeeker) string {
 	hash := sha256.New()
 	_, err := io.Copy(hash, stream)
@@ -95,6 +95,14 @@ func NewAllWatcher(context facade.Context) (facade.Facade, error) {
 	return newAllWatcher(context, newAllWatcherDeltaTranslater())
 }
 
+// NewAllWatcherV3 returns an AllWatcher that doesn't send secret deltas,
+// as clients of version 3 and earlier fail on unknown entity kinds.
+func NewAllWatcherV3(context facade.Context) (facade.Facade, error) {
+	return newAllWatcher(context, noSecretsDeltaTranslater{
+		DeltaTranslater: newAllWatcherDeltaTranslater(),
+	})
+}
+
 // Next will return the current state of everything on the first call
 // and subsequent calls will
 func (aw *SrvAllWatcher) Next() (params.AllWatcherNextResults, error) {
@@ -112,6 +120,15 @@ func newAllWatcherDeltaTranslater() DeltaTranslater {
 	return &allWatcherDeltaTranslater{}
 }
 
+// noSecretsDeltaTranslater drops secret deltas.
+type noSecretsDeltaTranslater struct {
+	DeltaTranslater
+}
+
+func (noSecretsDeltaTranslater) TranslateSecret(multiwatcher.EntityInfo) params.EntityInfo {
+	return nil
+}
+
 // DeltaTranslater defines methods for translating multiwatcher.EntityInfo to params.EntityInfo.
 type DeltaTranslater interface {
 	TranslateModel(multiwatcher.EntityInfo) params.EntityInfo
@@ -126,6 +143,7 @@ type DeltaTranslater interface {
 	TranslateBlock(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateAction(multiwatcher.EntityInfo) params.EntityInfo
 	TranslateApplicationOffer(multiwatcher.EntityInfo) params.EntityInfo
+	TranslateSecret(multiwatcher.EntityInfo) params.EntityInfo
 }
 
 func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
@@ -159,6 +177,8 @@ func translate(dt DeltaTranslater, deltas []multiwatcher.Delta) []params.Delta {
 			converted = dt.TranslateAction(delta.Entity)
 		case multiwatcher.ApplicationOfferKind:
 			converted = dt.TranslateApplicationOffer(delta.Entity)
+		case multiwatcher.SecretKind:
+			converted = dt.TranslateSecret(delta.Entity)
 		default:
 			// converted stays nil
 		}
@@ -451,6 +471,37 @@ func (aw allWatcherDeltaTranslater) TranslateRelation(info multiwatcher.EntityIn
 		Key:       orig.Key,
 		Id:        orig.ID,
 		Endpoints: aw.translateEndpoints(orig.Endpoints),
+		Life:      orig.Life,
+		Status:    aw.translateStatus(orig.Status),
+		UnitCount: orig.UnitCount,
+		Suspended: orig.Suspended,
+	}
+}
+
+func (aw allWatcherDeltaTranslater) TranslateSecret(info multiwatcher.EntityInfo) params.EntityInfo {
+	orig, ok := info.(*multiwatcher.SecretInfo)
+	if !ok {
+		logger.Criticalf("consistency error: %s", pretty.Sprint(info))
+		return nil
+	}
+	var consumers map[string]int
+	if orig.Consumers != nil {
+		consumers = make(map[string]int, len(orig.Consumers))
+		for k, v := range orig.Consumers {
+			consumers[k] = v
+		}
+	}
+	return &params.SecretInfo{
+		ModelUUID:      orig.ModelUUID,
+		Id:             orig.ID,
+		URI:            orig.URI,
+		Label:          orig.Label,
+		Description:    orig.Description,
+		OwnerTag:       orig.OwnerTag,
+		RotatePolicy:   orig.RotatePolicy,
+		LatestRevision: orig.LatestRevision,
+		LatestExpire:   orig.LatestExpire,
+		Consumers:      consumers,
 	}
 }
 
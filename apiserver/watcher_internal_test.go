@@ -95,6 +95,7 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		dt.EXPECT().TranslateBlock(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateAction(gomock.Any()).Return(nil),
 		dt.EXPECT().TranslateApplicationOffer(gomock.Any()).Return(nil),
+		dt.EXPECT().TranslateSecret(gomock.Any()).Return(nil),
 	)
 
 	deltas := []multiwatcher.Delta{
@@ -110,10 +111,65 @@ func (s *allWatcherSuite) TestTranslate(c *gc.C) {
 		newDelta(&multiwatcher.BlockInfo{}),
 		newDelta(&multiwatcher.ActionInfo{}),
 		newDelta(&multiwatcher.ApplicationOfferInfo{}),
+		newDelta(&multiwatcher.SecretInfo{}),
 	}
 	_ = translate(dt, deltas)
 }
 
+func (s *allWatcherSuite) TestTranslateRelation(c *gc.C) {
+	translator := newAllWatcherDeltaTranslater()
+	entityInfo := translator.TranslateRelation(&multiwatcher.RelationInfo{
+		ModelUUID: "uuid",
+		Key:       "wordpress:db mysql:server",
+		ID:        1,
+		Life:      life.Alive,
+		Status:    multiwatcher.StatusInfo{Current: status.Joined},
+		UnitCount: 3,
+	})
+	c.Assert(entityInfo, gc.DeepEquals, &params.RelationInfo{
+		ModelUUID: "uuid",
+		Key:       "wordpress:db mysql:server",
+		Id:        1,
+		Life:      life.Alive,
+		Status:    params.StatusInfo{Current: status.Joined},
+		UnitCount: 3,
+	})
+}
+
+func (s *allWatcherSuite) TestTranslateSecret(c *gc.C) {
+	translator := newAllWatcherDeltaTranslater()
+	entityInfo := translator.TranslateSecret(&multiwatcher.SecretInfo{
+		ModelUUID:      "uuid",
+		ID:             "cbd7tgkl6r0mesk3f5j0",
+		URI:            "secret:cbd7tgkl6r0mesk3f5j0",
+		Label:          "password",
+		OwnerTag:       "application-mysql",
+		LatestRevision: 2,
+		Consumers:      map[string]int{"unit-wordpress-0": 1},
+	})
+	c.Assert(entityInfo, gc.DeepEquals, &params.SecretInfo{
+		ModelUUID:      "uuid",
+		Id:             "cbd7tgkl6r0mesk3f5j0",
+		URI:            "secret:cbd7tgkl6r0mesk3f5j0",
+		Label:          "password",
+		OwnerTag:       "application-mysql",
+		LatestRevision: 2,
+		Consumers:      map[string]int{"unit-wordpress-0": 1},
+	})
+}
+
+func (s *allWatcherSuite) TestTranslateNoSecrets(c *gc.C) {
+	translator := noSecretsDeltaTranslater{
+		DeltaTranslater: newAllWatcherDeltaTranslater(),
+	}
+	deltas := translate(translator, []multiwatcher.Delta{
+		newDelta(&multiwatcher.SecretInfo{ID: "cbd7tgkl6r0mesk3f5j0"}),
+		newDelta(&multiwatcher.RelationInfo{Key: "wordpress:db mysql:server"}),
+	})
+	c.Assert(deltas, gc.HasLen, 1)
+	c.Assert(deltas[0].Entity, gc.FitsTypeOf, &params.RelationInfo{})
+}
+
 func (s *allWatcherSuite) TestTranslateModelEmpty(c *gc.C) {
 	translator := newAllWatcherDeltaTranslater()
 	entityInfo := translator.TranslateModel(&multiwatcher.ModelInfo{
@@ -74,6 +74,12 @@ type Config struct {
 	// requests. If nil, use the default HTTP client.
 	HTTPClient HTTPClient
 
+	// CACertificates holds PEM encoded CA certificates to trust, in
+	// addition to the system roots, when using the default HTTP client.
+	// This allows the client to use a charmhub mirror hosted by a
+	// controller.
+	CACertificates []string
+
 	// FileSystem represents the file system operations for downloading.
 	// If nil, use the real OS file system.
 	FileSystem FileSystem
@@ -116,7 +122,7 @@ func NewClient(config Config) (*Client, error) {
 
 	httpClient := config.HTTPClient
 	if httpClient == nil {
-		httpClient = DefaultHTTPClient(logger)
+		httpClient = DefaultHTTPClient(logger, config.CACertificates...)
 	}
 
 	fs := config.FileSystem
@@ -6,6 +6,7 @@ package charmhub
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -62,11 +63,13 @@ type HTTPClient interface {
 }
 
 // DefaultHTTPClient creates a new HTTPClient with the default configuration.
-func DefaultHTTPClient(logger Logger) HTTPClient {
+// Any CA certificates supplied are trusted in addition to the system roots,
+// allowing the client to talk to a charmhub mirror hosted by a controller.
+func DefaultHTTPClient(logger Logger, caCerts ...string) HTTPClient {
 	recorder := loggingRequestRecorder{
 		logger: logger.ChildWithLabels("transport.request-recorder", corelogger.METRICS),
 	}
-	return requestHTTPClient(recorder, defaultRetryPolicy())(logger)
+	return requestHTTPClient(recorder, defaultRetryPolicy(), caCerts...)(logger)
 }
 
 // defaultRetryPolicy returns a retry policy with sane defaults for most
@@ -99,13 +102,45 @@ func (r loggingRequestRecorder) RecordError(method string, url *url.URL, err err
 
 // requestHTTPClient returns a function that creates a new HTTPClient that
 // records the requests.
-func requestHTTPClient(recorder jujuhttp.RequestRecorder, policy jujuhttp.RetryPolicy) func(logger Logger) HTTPClient {
+func requestHTTPClient(recorder jujuhttp.RequestRecorder, policy jujuhttp.RetryPolicy, caCerts ...string) func(logger Logger) HTTPClient {
 	return func(logger Logger) HTTPClient {
-		return jujuhttp.NewClient(
+		options := []jujuhttp.Option{
 			jujuhttp.WithRequestRecorder(recorder),
 			jujuhttp.WithRequestRetrier(policy),
 			jujuhttp.WithLogger(logger.ChildWithLabels("transport", corelogger.CHARMHUB, corelogger.HTTP)),
-		)
+		}
+		if len(caCerts) > 0 {
+			// jujuhttp.WithCACertificates replaces the system roots, which
+			// would prevent talking to the public charmhub, so instead
+			// extend the default middlewares to add the certificates.
+			options = append(options, jujuhttp.WithTransportMiddlewares(
+				jujuhttp.DialContextMiddleware(jujuhttp.NewLocalDialBreaker(true)),
+				jujuhttp.FileProtocolMiddleware,
+				jujuhttp.ProxyMiddleware,
+				caCertificatesMiddleware(caCerts),
+			))
+		}
+		return jujuhttp.NewClient(options...)
+	}
+}
+
+// caCertificatesMiddleware returns a transport middleware trusting the
+// given PEM encoded CA certificates as well as the system roots.
+func caCertificatesMiddleware(caCerts []string) jujuhttp.TransportMiddleware {
+	return func(transport *http.Transport) *http.Transport {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, cert := range caCerts {
+			pool.AppendCertsFromPEM([]byte(cert))
+		}
+		tlsConfig := jujuhttp.SecureTLSConfig()
+		tlsConfig.RootCAs = pool
+		transport.TLSClientConfig = tlsConfig
+		// Setting a custom tls.Config disables HTTP/2 unless forced.
+		transport.ForceAttemptHTTP2 = true
+		return transport
 	}
 }
 
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// ArchiveDir writes the export in dir to w as a gzipped tar archive, in the
+// form expected by Mirror.Import.
+func ArchiveDir(w io.Writer, dir string) error {
+	manifestPath := filepath.Join(dir, ManifestFile)
+	f, err := os.Open(manifestPath)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manifest, err := ReadManifest(f)
+	_ = f.Close()
+	if err != nil {
+		return errors.Annotatef(err, "reading %q", manifestPath)
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	if err := addFile(tw, dir, ManifestFile); err != nil {
+		return errors.Trace(err)
+	}
+
+	blobs := manifest.Blobs()
+	hashes := make([]string, 0, len(blobs))
+	for sha384 := range blobs {
+		hashes = append(hashes, sha384)
+	}
+	sort.Strings(hashes)
+	for _, sha384 := range hashes {
+		if err := addFile(tw, dir, blobs[sha384].Path); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(zw.Close())
+}
+
+func addFile(tw *tar.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, f)
+	return errors.Trace(err)
+}
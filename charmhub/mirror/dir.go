@@ -0,0 +1,166 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3"
+)
+
+// ReadDir returns the manifest of the export in dir. An empty manifest is
+// returned if dir does not hold an export yet.
+func ReadDir(dir string) (Manifest, error) {
+	manifestPath := filepath.Join(dir, ManifestFile)
+	f, err := os.Open(manifestPath)
+	if os.IsNotExist(err) {
+		return Manifest{Version: ManifestVersion}, nil
+	} else if err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	manifest, err := ReadManifest(f)
+	if err != nil {
+		return Manifest{}, errors.Annotatef(err, "reading %q", manifestPath)
+	}
+	return manifest, nil
+}
+
+// WriteDir writes the manifest of the export in dir, along with a
+// checksums file covering every blob the manifest references.
+func WriteDir(dir string, m Manifest) error {
+	if err := m.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	var manifest bytes.Buffer
+	if err := WriteManifest(&manifest, m); err != nil {
+		return errors.Trace(err)
+	}
+
+	blobs := m.Blobs()
+	paths := make([]string, 0, len(blobs))
+	sums := make(map[string]string, len(blobs))
+	for _, blob := range blobs {
+		paths = append(paths, blob.Path)
+		sums[blob.Path] = blob.SHA256
+	}
+	sort.Strings(paths)
+	var checksums bytes.Buffer
+	for _, path := range paths {
+		_, _ = fmt.Fprintf(&checksums, "%s  %s\n", sums[path], path)
+	}
+
+	if err := utils.AtomicWriteFile(filepath.Join(dir, ChecksumsFile), checksums.Bytes(), 0644); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(filepath.Join(dir, ManifestFile), manifest.Bytes(), 0644))
+}
+
+// AddBlob stores the content read from r as a blob of the export in dir,
+// and returns the blob describing it. If check is not nil, it is called
+// with the blob before it is added, and nothing is added if it returns an
+// error.
+func AddBlob(dir string, r io.Reader, check func(Blob) error) (Blob, error) {
+	blobsDir := filepath.Join(dir, BlobsDir)
+	if err := os.MkdirAll(blobsDir, 0755); err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	f, err := os.CreateTemp(blobsDir, ".download-*")
+	if err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	blob, err := hashBlob(io.TeeReader(r, f))
+	if err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	if check != nil {
+		if err := check(blob); err != nil {
+			return Blob{}, errors.Trace(err)
+		}
+	}
+	if err := os.Rename(f.Name(), filepath.Join(dir, filepath.FromSlash(blob.Path))); err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	return blob, nil
+}
+
+// VerifyDir reads the manifest of the export in dir, and ensures that
+// every blob it references is present with the expected size and hashes.
+func VerifyDir(dir string) (Manifest, error) {
+	manifestPath := filepath.Join(dir, ManifestFile)
+	f, err := os.Open(manifestPath)
+	if os.IsNotExist(err) {
+		return Manifest{}, errors.NotFoundf("%q", manifestPath)
+	} else if err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	manifest, err := ReadManifest(f)
+	_ = f.Close()
+	if err != nil {
+		return Manifest{}, errors.Annotatef(err, "reading %q", manifestPath)
+	}
+
+	for _, blob := range manifest.Blobs() {
+		if err := verifyBlob(dir, blob); err != nil {
+			return Manifest{}, errors.Trace(err)
+		}
+	}
+	return manifest, nil
+}
+
+func verifyBlob(dir string, expected Blob) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(expected.Path)))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q", expected.Path)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	actual, err := hashBlob(f)
+	if err != nil {
+		return errors.Annotatef(err, "reading %q", expected.Path)
+	}
+	if actual.Size != expected.Size {
+		return errors.NotValidf("%q size %d, expected %d", expected.Path, actual.Size, expected.Size)
+	}
+	if actual.SHA256 != expected.SHA256 || actual.SHA384 != expected.SHA384 {
+		return errors.NotValidf("%q checksum", expected.Path)
+	}
+	return nil
+}
+
+func hashBlob(r io.Reader) (Blob, error) {
+	sha256Hash := sha256.New()
+	sha384Hash := sha512.New384()
+	size, err := io.Copy(io.MultiWriter(sha256Hash, sha384Hash), r)
+	if err != nil {
+		return Blob{}, errors.Trace(err)
+	}
+	sha384 := fmt.Sprintf("%x", sha384Hash.Sum(nil))
+	return Blob{
+		Path:   BlobPath(sha384),
+		Size:   size,
+		SHA256: fmt.Sprintf("%x", sha256Hash.Sum(nil)),
+		SHA384: sha384,
+	}, nil
+}
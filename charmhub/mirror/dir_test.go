@@ -0,0 +1,160 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+)
+
+type dirSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&dirSuite{})
+
+func (s *dirSuite) writeExport(c *gc.C, dir string) mirror.Manifest {
+	archive, err := mirror.AddBlob(dir, strings.NewReader("foo charm"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	resource, err := mirror.AddBlob(dir, strings.NewReader("foo resource"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	manifest := mirror.Manifest{
+		Version: mirror.ManifestVersion,
+		Entities: []mirror.Entity{{
+			Type: transport.CharmType,
+			ID:   "foo-id",
+			Name: "foo",
+			Revisions: []mirror.Revision{{
+				Revision: 1,
+				Bases:    []transport.Base{jammy},
+				Archive:  archive,
+				Resources: []mirror.Resource{{
+					Name: "data", Type: "file", Revision: 3, Blob: resource,
+				}},
+			}},
+			Channels: []mirror.Channel{{
+				Track: "latest", Risk: "stable", Base: jammy, Revision: 1,
+			}},
+		}},
+	}
+	c.Assert(mirror.WriteDir(dir, manifest), jc.ErrorIsNil)
+	return manifest
+}
+
+func (s *dirSuite) TestReadDirEmpty(c *gc.C) {
+	manifest, err := mirror.ReadDir(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest, jc.DeepEquals, mirror.Manifest{Version: mirror.ManifestVersion})
+}
+
+func (s *dirSuite) TestAddBlob(c *gc.C) {
+	dir := c.MkDir()
+	blob, err := mirror.AddBlob(dir, strings.NewReader("foo charm"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(blob, jc.DeepEquals, mirror.Blob{
+		Path:   "blobs/31a34e90bb2212adbfdcfe9768f1bf42ca4bff570f3a78616b0680661fbb49cf65811ee48ba74e228a5e1f3bb9833973",
+		Size:   9,
+		SHA256: "c6a65325fe81bd67b5b9bbd822cb5f16407659b13d62f561566ec51f0826a74c",
+		SHA384: "31a34e90bb2212adbfdcfe9768f1bf42ca4bff570f3a78616b0680661fbb49cf65811ee48ba74e228a5e1f3bb9833973",
+	})
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(blob.Path)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "foo charm")
+
+	// Only the blob itself is left behind.
+	entries, err := os.ReadDir(filepath.Join(dir, mirror.BlobsDir))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 1)
+}
+
+func (s *dirSuite) TestAddBlobCheckFails(c *gc.C) {
+	dir := c.MkDir()
+	var checked mirror.Blob
+	_, err := mirror.AddBlob(dir, strings.NewReader("foo charm"), func(blob mirror.Blob) error {
+		checked = blob
+		return errors.New("checksum mismatch")
+	})
+	c.Assert(err, gc.ErrorMatches, "checksum mismatch")
+	c.Check(checked.Size, gc.Equals, int64(9))
+
+	// Nothing is added to the export.
+	entries, err := os.ReadDir(filepath.Join(dir, mirror.BlobsDir))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *dirSuite) TestWriteAndReadDir(c *gc.C) {
+	dir := c.MkDir()
+	manifest := s.writeExport(c, dir)
+
+	read, err := mirror.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(read, jc.DeepEquals, manifest)
+
+	archive := manifest.Entities[0].Revisions[0].Archive
+	resource := manifest.Entities[0].Revisions[0].Resources[0].Blob
+	lines := []string{
+		archive.SHA256 + "  " + archive.Path,
+		resource.SHA256 + "  " + resource.Path,
+	}
+	if archive.Path > resource.Path {
+		lines[0], lines[1] = lines[1], lines[0]
+	}
+	checksums, err := os.ReadFile(filepath.Join(dir, mirror.ChecksumsFile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(checksums), gc.Equals, strings.Join(lines, "\n")+"\n")
+}
+
+func (s *dirSuite) TestWriteDirInvalid(c *gc.C) {
+	err := mirror.WriteDir(c.MkDir(), mirror.Manifest{})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
+
+func (s *dirSuite) TestVerifyDir(c *gc.C) {
+	dir := c.MkDir()
+	manifest := s.writeExport(c, dir)
+
+	verified, err := mirror.VerifyDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(verified, jc.DeepEquals, manifest)
+}
+
+func (s *dirSuite) TestVerifyDirNoManifest(c *gc.C) {
+	_, err := mirror.VerifyDir(c.MkDir())
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *dirSuite) TestVerifyDirMissingBlob(c *gc.C) {
+	dir := c.MkDir()
+	manifest := s.writeExport(c, dir)
+	path := manifest.Entities[0].Revisions[0].Archive.Path
+	c.Assert(os.Remove(filepath.Join(dir, filepath.FromSlash(path))), jc.ErrorIsNil)
+
+	_, err := mirror.VerifyDir(dir)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+	c.Check(err, gc.ErrorMatches, `".*" not found`)
+}
+
+func (s *dirSuite) TestVerifyDirCorruptBlob(c *gc.C) {
+	dir := c.MkDir()
+	manifest := s.writeExport(c, dir)
+	path := manifest.Entities[0].Revisions[0].Archive.Path
+	err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(path)), []byte("bar charm"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = mirror.VerifyDir(dir)
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+	c.Check(err, gc.ErrorMatches, `".*" checksum not valid`)
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+// Handler serves the charmhub API from a mirror. Requests are expected
+// under the handler's prefix, in the same form as charmhub itself:
+//
+//	GET  <prefix>/v2/charms/info/<name>
+//	GET  <prefix>/v2/charms/find
+//	POST <prefix>/v2/charms/refresh
+//	GET  <prefix>/v2/charms/resources/<charm>/<resource>/revisions
+//	GET  <prefix>/download/<sha384>
+type Handler struct {
+	mirror *Mirror
+	prefix string
+}
+
+// NewHandler returns a handler serving the content of the mirror under
+// prefix, which is used as the charmhub-url by models using the mirror.
+func NewHandler(mirror *Mirror, prefix string) *Handler {
+	return &Handler{
+		mirror: mirror,
+		prefix: strings.TrimSuffix(prefix, "/"),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rest, ok := strings.CutPrefix(req.URL.Path, h.prefix+"/")
+	if !ok {
+		h.sendError(w, http.StatusNotFound, transport.ErrorCodeNotFound, "not found")
+		return
+	}
+	parts := strings.Split(rest, "/")
+
+	if len(parts) == 2 && parts[0] == "download" {
+		if req.Method != http.MethodGet {
+			h.sendError(w, http.StatusMethodNotAllowed, transport.ErrorCodeBadArgument, "method not allowed")
+			return
+		}
+		h.serveDownload(w, parts[1])
+		return
+	}
+	if len(parts) < 3 || parts[0] != "v2" || parts[1] != "charms" {
+		h.sendError(w, http.StatusNotFound, transport.ErrorCodeNotFound, "not found")
+		return
+	}
+
+	manifest, err := h.mirror.Manifest()
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	res := resolver{
+		manifest:    manifest,
+		downloadURL: h.downloadURL(req),
+	}
+
+	switch {
+	case len(parts) == 4 && parts[2] == "info" && req.Method == http.MethodGet:
+		resp, err := res.Info(parts[3], req.URL.Query().Get("channel"))
+		if err != nil {
+			h.sendError(w, http.StatusNotFound, transport.ErrorCodeNotFound, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, resp)
+
+	case len(parts) == 3 && parts[2] == "find" && req.Method == http.MethodGet:
+		query := req.URL.Query()
+		h.sendJSON(w, http.StatusOK, transport.FindResponses{
+			Results: res.Find(query.Get("q"), query.Get("type")),
+		})
+
+	case len(parts) == 3 && parts[2] == "refresh" && req.Method == http.MethodPost:
+		var refreshReq transport.RefreshRequest
+		if err := json.NewDecoder(req.Body).Decode(&refreshReq); err != nil {
+			h.sendError(w, http.StatusBadRequest, transport.ErrorCodeBadArgument, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, res.Refresh(refreshReq))
+
+	case len(parts) == 6 && parts[2] == "resources" && parts[5] == "revisions" && req.Method == http.MethodGet:
+		revisions, err := res.ResourceRevisions(parts[3], parts[4])
+		if err != nil {
+			h.sendError(w, http.StatusNotFound, transport.ErrorCodeResourceNotFound, err.Error())
+			return
+		}
+		h.sendJSON(w, http.StatusOK, transport.ResourcesResponse{Revisions: revisions})
+
+	default:
+		h.sendError(w, http.StatusNotFound, transport.ErrorCodeNotFound, "not found")
+	}
+}
+
+func (h *Handler) serveDownload(w http.ResponseWriter, sha384 string) {
+	r, size, err := h.mirror.OpenBlob(sha384)
+	if errors.Is(err, errors.NotFound) || errors.Is(err, errors.NotValid) {
+		h.sendError(w, http.StatusNotFound, transport.ErrorCodeNotFound, err.Error())
+		return
+	} else if err != nil {
+		h.sendError(w, http.StatusInternalServerError, transport.ErrorCodeAPIError, err.Error())
+		return
+	}
+	defer func() { _ = r.Close() }()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", fmt.Sprint(size))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, r)
+}
+
+// downloadURL returns a function building download URLs on the host the
+// request was made to, so that downloads go through the same controller
+// address as the API requests.
+func (h *Handler) downloadURL(req *http.Request) DownloadURLFunc {
+	scheme := "https"
+	if req.TLS == nil {
+		scheme = "http"
+	}
+	return func(sha384 string) string {
+		return fmt.Sprintf("%s://%s%s/download/%s", scheme, req.Host, h.prefix, sha384)
+	}
+}
+
+func (h *Handler) sendJSON(w http.ResponseWriter, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func (h *Handler) sendError(w http.ResponseWriter, status int, code transport.APIErrorCode, message string) {
+	h.sendJSON(w, status, struct {
+		ErrorList transport.APIErrors `json:"error-list"`
+	}{
+		ErrorList: transport.APIErrors{{
+			Code:    code,
+			Message: message,
+		}},
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/testcharms/repo"
+)
+
+const prefix = "/charmhub"
+
+type handlerSuite struct {
+	testing.IsolationSuite
+
+	server *httptest.Server
+	client *charmhub.Client
+	export *export
+}
+
+var _ = gc.Suite(&handlerSuite{})
+
+func (s *handlerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	charmRepo := repo.NewRepo("../../testcharms/charm-repo", "quantal")
+	charmData, err := os.ReadFile(charmRepo.CharmArchivePath(c.MkDir(), "dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.export = newExport(c)
+	stable := s.export.addBlob(c, charmData)
+	edge := s.export.addBlob(c, append(charmData[:len(charmData):len(charmData)], 0))
+	resource1 := s.export.addBlob(c, []byte("resource one"))
+	resource2 := s.export.addBlob(c, []byte("resource two"))
+	s.export.manifest.Entities = []mirror.Entity{{
+		Type:      transport.CharmType,
+		ID:        "dummy-id",
+		Name:      "dummy",
+		Summary:   "A dummy charm",
+		Publisher: map[string]string{"display-name": "Juju"},
+		Revisions: []mirror.Revision{{
+			Revision: 1,
+			Bases:    []transport.Base{jammy, focal},
+			Archive:  stable,
+			Resources: []mirror.Resource{{
+				Name: "data", Type: "file", Revision: 1, Filename: "data.txt", Blob: resource1,
+			}},
+		}, {
+			Revision: 2,
+			Bases:    []transport.Base{jammy},
+			Archive:  edge,
+			Resources: []mirror.Resource{{
+				Name: "data", Type: "file", Revision: 2, Filename: "data.txt", Blob: resource2,
+			}},
+		}},
+		Channels: []mirror.Channel{
+			{Track: "latest", Risk: "stable", Base: jammy, Revision: 1},
+			{Track: "latest", Risk: "stable", Base: focal, Revision: 1},
+			{Track: "latest", Risk: "edge", Base: jammy, Revision: 2},
+		},
+	}}
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	_, err = m.Import(s.export.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.server = httptest.NewServer(mirror.NewHandler(m, prefix))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+
+	s.client, err = charmhub.NewClient(charmhub.Config{
+		URL:    s.server.URL + prefix,
+		Logger: loggo.GetLogger("juju.charmhub.mirror"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *handlerSuite) TestInfo(c *gc.C) {
+	info, err := s.client.Info(context.Background(), "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Type, gc.Equals, transport.CharmType)
+	c.Check(info.ID, gc.Equals, "dummy-id")
+	c.Check(info.Entity.Summary, gc.Equals, "A dummy charm")
+	c.Check(info.ChannelMap, gc.HasLen, 3)
+	c.Check(info.DefaultRelease.Channel.Name, gc.Equals, "latest/stable")
+	c.Check(info.DefaultRelease.Revision.Revision, gc.Equals, 1)
+	c.Check(info.DefaultRelease.Revision.Download.URL, gc.Equals,
+		s.server.URL+prefix+"/download/"+s.export.manifest.Entities[0].Revisions[0].Archive.SHA384)
+}
+
+func (s *handlerSuite) TestInfoChannel(c *gc.C) {
+	info, err := s.client.Info(context.Background(), "dummy", charmhub.WithInfoChannel("edge"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.DefaultRelease.Channel.Name, gc.Equals, "latest/edge")
+	c.Check(info.DefaultRelease.Revision.Revision, gc.Equals, 2)
+}
+
+func (s *handlerSuite) TestInfoNotFound(c *gc.C) {
+	_, err := s.client.Info(context.Background(), "missing")
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *handlerSuite) TestFind(c *gc.C) {
+	results, err := s.client.Find(context.Background(), "dum")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Name, gc.Equals, "dummy")
+	c.Check(results[0].DefaultRelease.Revision.Revision, gc.Equals, 1)
+
+	results, err = s.client.Find(context.Background(), "dummy", charmhub.WithFindType("bundle"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.HasLen, 0)
+}
+
+func (s *handlerSuite) TestRefreshInstallAndDownload(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", charmhub.RefreshBase{
+		Architecture: "amd64", Name: "ubuntu", Channel: "20.04",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Check(results[0].EffectiveChannel, gc.Equals, "latest/stable")
+	c.Check(results[0].Entity.Revision, gc.Equals, 1)
+	c.Check(results[0].Entity.Resources, gc.HasLen, 1)
+
+	downloadURL, err := url.Parse(results[0].Entity.Download.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := s.client.DownloadAndRead(context.Background(), downloadURL, filepath.Join(c.MkDir(), "dummy.charm"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.Meta().Name, gc.Equals, "dummy")
+}
+
+func (s *handlerSuite) TestRefreshFollowsLessRiskyChannel(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "edge", charmhub.RefreshBase{
+		Architecture: "amd64", Name: "ubuntu", Channel: "20.04",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Check(results[0].EffectiveChannel, gc.Equals, "latest/stable")
+	c.Check(results[0].Entity.Revision, gc.Equals, 1)
+}
+
+func (s *handlerSuite) TestRefreshWithoutBase(c *gc.C) {
+	config, err := charmhub.InstallOneFromChannel("dummy", "stable", charmhub.RefreshBase{
+		Architecture: "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Check(results[0].Error.Code, gc.Equals, transport.ErrorCodeInvalidCharmBase)
+	c.Check(results[0].Error.Extra.DefaultBases, jc.DeepEquals, []transport.Base{jammy, focal})
+}
+
+func (s *handlerSuite) TestRefreshUnknownRevision(c *gc.C) {
+	config, err := charmhub.InstallOneFromRevision("dummy", 42)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.NotNil)
+	c.Check(results[0].Error.Code, gc.Equals, transport.ErrorCodeRevisionNotFound)
+	c.Check(results[0].Error.Extra.Releases, gc.HasLen, 3)
+}
+
+func (s *handlerSuite) TestRefreshPinnedResourceRevision(c *gc.C) {
+	config, err := charmhub.InstallOneFromRevision("dummy", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	config, ok := charmhub.AddResource(config, "data", 1)
+	c.Assert(ok, jc.IsTrue)
+	results, err := s.client.Refresh(context.Background(), config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Check(results[0].Entity.Revision, gc.Equals, 2)
+	c.Assert(results[0].Entity.Resources, gc.HasLen, 1)
+	c.Check(results[0].Entity.Resources[0].Revision, gc.Equals, 1)
+}
+
+func (s *handlerSuite) TestListResourceRevisions(c *gc.C) {
+	revisions, err := s.client.ListResourceRevisions(context.Background(), "dummy", "data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Check(revisions[0].Revision, gc.Equals, 2)
+	c.Check(revisions[1].Revision, gc.Equals, 1)
+
+	downloadURL, err := url.Parse(revisions[1].Download.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	r, err := s.client.DownloadResource(context.Background(), downloadURL)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "resource one")
+}
@@ -0,0 +1,287 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format written by
+	// this package.
+	ManifestVersion = 1
+
+	// ManifestFile is the name of the manifest at the root of an export
+	// directory or archive.
+	ManifestFile = "manifest.json"
+
+	// ChecksumsFile is the name of the optional sha256sum(1) compatible
+	// checksum file at the root of an export directory.
+	ChecksumsFile = "SHA256SUMS"
+
+	// BlobsDir is the directory, relative to the root of an export, that
+	// holds every charm, bundle and resource blob.
+	BlobsDir = "blobs"
+)
+
+var sha384Pattern = regexp.MustCompile(`^[0-9a-f]{96}$`)
+
+// Manifest describes the content of an offline charmhub export: every
+// charm or bundle, the revisions that were exported and the channels they
+// are released to.
+type Manifest struct {
+	Version  int       `json:"version"`
+	Created  time.Time `json:"created"`
+	Entities []Entity  `json:"entities"`
+}
+
+// Entity is a charm or a bundle.
+type Entity struct {
+	Type        transport.Type    `json:"type"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Summary     string            `json:"summary,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Publisher   map[string]string `json:"publisher,omitempty"`
+	License     string            `json:"license,omitempty"`
+	Revisions   []Revision        `json:"revisions"`
+	Channels    []Channel         `json:"channels"`
+}
+
+// Revision is a single revision of a charm or bundle.
+type Revision struct {
+	Revision     int              `json:"revision"`
+	Version      string           `json:"version,omitempty"`
+	CreatedAt    time.Time        `json:"created-at"`
+	Bases        []transport.Base `json:"bases,omitempty"`
+	Archive      Blob             `json:"archive"`
+	MetadataYAML string           `json:"metadata-yaml,omitempty"`
+	ConfigYAML   string           `json:"config-yaml,omitempty"`
+	BundleYAML   string           `json:"bundle-yaml,omitempty"`
+	Resources    []Resource       `json:"resources,omitempty"`
+}
+
+// Resource is a file or oci-image resource revision used by a charm
+// revision.
+type Resource struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Revision    int    `json:"revision"`
+	Filename    string `json:"filename,omitempty"`
+	Description string `json:"description,omitempty"`
+	Blob        Blob   `json:"blob"`
+}
+
+// Blob locates and identifies the content of an archive or a resource
+// within an export.
+type Blob struct {
+	// Path is relative to the root of the export.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	SHA384 string `json:"sha384"`
+}
+
+// Channel records the revision released to a channel for a base.
+type Channel struct {
+	Track      string         `json:"track"`
+	Risk       string         `json:"risk"`
+	Base       transport.Base `json:"base"`
+	Revision   int            `json:"revision"`
+	ReleasedAt time.Time      `json:"released-at"`
+}
+
+// Name returns the channel as "track/risk".
+func (c Channel) Name() string {
+	return fmt.Sprintf("%s/%s", c.Track, c.Risk)
+}
+
+// BlobPath returns the path, relative to the root of an export, of the
+// blob with the given SHA384 hash.
+func BlobPath(sha384 string) string {
+	return path.Join(BlobsDir, sha384)
+}
+
+// ReadManifest decodes a manifest from r and validates it.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return Manifest{}, errors.Annotate(err, "decoding manifest")
+	}
+	if err := m.Validate(); err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// WriteManifest encodes the manifest to w.
+func WriteManifest(w io.Writer, m Manifest) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return errors.Trace(enc.Encode(m))
+}
+
+// Blobs returns every blob referenced by the manifest, keyed by SHA384.
+func (m Manifest) Blobs() map[string]Blob {
+	blobs := make(map[string]Blob)
+	for _, entity := range m.Entities {
+		for _, rev := range entity.Revisions {
+			blobs[rev.Archive.SHA384] = rev.Archive
+			for _, res := range rev.Resources {
+				blobs[res.Blob.SHA384] = res.Blob
+			}
+		}
+	}
+	return blobs
+}
+
+// Validate ensures that the manifest is well formed.
+func (m Manifest) Validate() error {
+	if m.Version != ManifestVersion {
+		return errors.NotSupportedf("manifest version %d", m.Version)
+	}
+	names := make(map[string]bool)
+	for _, entity := range m.Entities {
+		if err := entity.validate(); err != nil {
+			return errors.Annotatef(err, "entity %q", entity.Name)
+		}
+		if names[entity.Name] {
+			return errors.NotValidf("duplicate entity %q", entity.Name)
+		}
+		names[entity.Name] = true
+	}
+	return nil
+}
+
+func (e Entity) validate() error {
+	if e.Name == "" {
+		return errors.NotValidf("empty name")
+	}
+	if e.ID == "" {
+		return errors.NotValidf("empty id")
+	}
+	if e.Type != transport.CharmType && e.Type != transport.BundleType {
+		return errors.NotValidf("type %q", e.Type)
+	}
+	revisions := make(map[int]bool)
+	for _, rev := range e.Revisions {
+		if revisions[rev.Revision] {
+			return errors.NotValidf("duplicate revision %d", rev.Revision)
+		}
+		revisions[rev.Revision] = true
+		if err := rev.Archive.validate(); err != nil {
+			return errors.Annotatef(err, "revision %d archive", rev.Revision)
+		}
+		for _, res := range rev.Resources {
+			if res.Name == "" {
+				return errors.NotValidf("revision %d resource with empty name", rev.Revision)
+			}
+			if err := res.Blob.validate(); err != nil {
+				return errors.Annotatef(err, "revision %d resource %q", rev.Revision, res.Name)
+			}
+		}
+	}
+	for _, ch := range e.Channels {
+		if ch.Track == "" || ch.Risk == "" {
+			return errors.NotValidf("channel %q", ch.Name())
+		}
+		if !revisions[ch.Revision] {
+			return errors.NotValidf("channel %q released revision %d which is not exported", ch.Name(), ch.Revision)
+		}
+	}
+	return nil
+}
+
+func (b Blob) validate() error {
+	if !sha384Pattern.MatchString(b.SHA384) {
+		return errors.NotValidf("sha384 %q", b.SHA384)
+	}
+	if b.Path != BlobPath(b.SHA384) {
+		return errors.NotValidf("path %q", b.Path)
+	}
+	return nil
+}
+
+// Merge returns a manifest containing the entities, revisions and
+// channels of both manifests. Where both contain the same revision or
+// channel, the one from other wins.
+func (m Manifest) Merge(other Manifest) Manifest {
+	result := Manifest{
+		Version: ManifestVersion,
+		Created: m.Created,
+	}
+	if other.Created.After(result.Created) {
+		result.Created = other.Created
+	}
+
+	entities := make(map[string]Entity)
+	for _, entity := range m.Entities {
+		entities[entity.Name] = entity
+	}
+	for _, entity := range other.Entities {
+		existing, ok := entities[entity.Name]
+		if !ok {
+			entities[entity.Name] = entity
+			continue
+		}
+		entities[entity.Name] = existing.merge(entity)
+	}
+	for _, entity := range entities {
+		result.Entities = append(result.Entities, entity)
+	}
+	sort.Slice(result.Entities, func(i, j int) bool {
+		return result.Entities[i].Name < result.Entities[j].Name
+	})
+	return result
+}
+
+func (e Entity) merge(other Entity) Entity {
+	result := other
+
+	revisions := make(map[int]Revision)
+	for _, rev := range e.Revisions {
+		revisions[rev.Revision] = rev
+	}
+	for _, rev := range other.Revisions {
+		revisions[rev.Revision] = rev
+	}
+	result.Revisions = nil
+	for _, rev := range revisions {
+		result.Revisions = append(result.Revisions, rev)
+	}
+	sort.Slice(result.Revisions, func(i, j int) bool {
+		return result.Revisions[i].Revision < result.Revisions[j].Revision
+	})
+
+	type channelKey struct {
+		track, risk string
+		base        transport.Base
+	}
+	channels := make(map[channelKey]Channel)
+	var order []channelKey
+	for _, list := range [][]Channel{e.Channels, other.Channels} {
+		for _, ch := range list {
+			key := channelKey{track: ch.Track, risk: ch.Risk, base: ch.Base}
+			if _, ok := channels[key]; !ok {
+				order = append(order, key)
+			}
+			channels[key] = ch
+		}
+	}
+	result.Channels = nil
+	for _, key := range order {
+		result.Channels = append(result.Channels, channels[key])
+	}
+	return result
+}
@@ -0,0 +1,338 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package mirror provides a charmhub compatible store backed by offline
+// exports, for controllers that cannot reach charmhub.
+//
+// An export is a directory holding a manifest.json, describing the charms,
+// bundles, revisions, resources and channels it contains, along with every
+// archive and resource blob, stored under blobs/ by SHA384 hash. Exports
+// are uploaded to the controller as a tar (optionally gzipped) archive of
+// that directory, with the manifest as the first entry; see ArchiveDir.
+// AddBlob and WriteDir build an export directory, and VerifyDir checks
+// one before it is archived.
+//
+// The controller then answers the subset of the charmhub API used by juju
+// (info, find, refresh, resource revisions and downloads) from the
+// imported content, so that pointing a model's charmhub-url at the mirror
+// is all that is needed for deploy and refresh to work unchanged.
+package mirror
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// PathPrefix is the path under which a controller serves the charmhub
+	// API from its mirror. Models use the mirror by setting charmhub-url
+	// to https://<controller>:17070/charmhub.
+	PathPrefix = "/charmhub"
+
+	// storagePrefix is the path under which the mirror keeps its content in
+	// the controller model storage.
+	storagePrefix = "charmhub-mirror"
+)
+
+// IsMirrorURL returns true if the charmhub URL refers to the mirror hosted
+// by a controller with the given API addresses, in host:port form.
+func IsMirrorURL(charmhubURL string, apiAddresses []string) bool {
+	u, err := url.Parse(charmhubURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if path.Clean("/"+u.Path) != PathPrefix {
+		return false
+	}
+	for _, addr := range apiAddresses {
+		if strings.EqualFold(u.Host, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Storage stores the manifest and blobs of the mirror. It is satisfied by
+// the controller model's state storage.
+type Storage interface {
+	// Get returns the data at path.
+	Get(path string) (io.ReadCloser, int64, error)
+
+	// Put stores the data from r at path.
+	Put(path string, r io.Reader, length int64) error
+
+	// PutAndCheckHash stores the data from r at path, ensuring that the
+	// stored data has the given SHA384 hash.
+	PutAndCheckHash(path string, r io.Reader, length int64, hash string) error
+
+	// Remove removes the data at path.
+	Remove(path string) error
+}
+
+// ManifestStore records which stored manifest is the mirror's current one.
+// It is satisfied by the controller's state.
+type ManifestStore interface {
+	// CharmhubMirrorManifest returns the storage path of the current
+	// manifest, or an empty path if nothing has been imported.
+	CharmhubMirrorManifest() (string, error)
+
+	// UpdateCharmhubMirrorManifest records the storage path returned by
+	// update as the current manifest. update is passed the path of the
+	// current manifest, and is called again if it is replaced
+	// concurrently, so that imports by every controller are merged.
+	UpdateCharmhubMirrorManifest(update func(current string) (string, error)) error
+}
+
+// ImportResult describes the outcome of an import.
+type ImportResult struct {
+	// Entities is the number of charms and bundles in the imported
+	// manifest.
+	Entities int
+
+	// BlobsAdded is the number of blobs that were not already held by the
+	// mirror.
+	BlobsAdded int
+}
+
+// Mirror holds the content of every export imported into the controller.
+//
+// Each merged manifest is stored under its own hash, and the manifest store
+// records which is current, so that imports handled by different
+// controllers are never lost.
+type Mirror struct {
+	storage   Storage
+	manifests ManifestStore
+
+	// mu guards the cached manifest, which is only read from storage
+	// again when the current manifest changes.
+	mu           sync.Mutex
+	manifestPath string
+	manifest     Manifest
+}
+
+// New returns a mirror backed by the given storage, whose current manifest
+// is recorded by the manifest store.
+func New(storage Storage, manifests ManifestStore) *Mirror {
+	return &Mirror{
+		storage:   storage,
+		manifests: manifests,
+	}
+}
+
+// Manifest returns the merged manifest of every export imported so far. An
+// empty manifest is returned if nothing has been imported.
+func (m *Mirror) Manifest() (Manifest, error) {
+	// A manifest may be removed once another controller has replaced it,
+	// so a manifest which can't be found is looked up again.
+	for attempt := 0; ; attempt++ {
+		current, err := m.manifests.CharmhubMirrorManifest()
+		if err != nil {
+			return Manifest{}, errors.Annotate(err, "reading mirror manifest")
+		}
+		manifest, err := m.cachedManifest(current)
+		if errors.Is(err, errors.NotFound) && attempt < 2 {
+			continue
+		}
+		return manifest, errors.Trace(err)
+	}
+}
+
+// cachedManifest returns the manifest stored at manifestPath, reading it
+// from storage only if it isn't the cached manifest.
+func (m *Mirror) cachedManifest(manifestPath string) (Manifest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if manifestPath == m.manifestPath && manifestPath != "" {
+		return m.manifest, nil
+	}
+	manifest, err := m.readManifest(manifestPath)
+	if err != nil {
+		return Manifest{}, errors.Trace(err)
+	}
+	m.manifestPath = manifestPath
+	m.manifest = manifest
+	return manifest, nil
+}
+
+func (m *Mirror) setCachedManifest(manifestPath string, manifest Manifest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.manifestPath = manifestPath
+	m.manifest = manifest
+}
+
+func (m *Mirror) readManifest(manifestPath string) (Manifest, error) {
+	if manifestPath == "" {
+		return Manifest{Version: ManifestVersion}, nil
+	}
+	r, _, err := m.storage.Get(manifestPath)
+	if err != nil {
+		return Manifest{}, errors.Annotate(err, "reading mirror manifest")
+	}
+	defer func() { _ = r.Close() }()
+	return ReadManifest(r)
+}
+
+// writeManifest stores the manifest under its hash, returning its path.
+func (m *Mirror) writeManifest(manifest Manifest) (string, error) {
+	var buf bytes.Buffer
+	if err := WriteManifest(&buf, manifest); err != nil {
+		return "", errors.Trace(err)
+	}
+	manifestPath := path.Join(storagePrefix, "manifests", fmt.Sprintf("%x", sha512.Sum384(buf.Bytes())))
+	if err := m.storage.Put(manifestPath, &buf, int64(buf.Len())); err != nil {
+		return "", errors.Annotate(err, "storing mirror manifest")
+	}
+	return manifestPath, nil
+}
+
+// OpenBlob returns the content of the blob with the given SHA384 hash.
+func (m *Mirror) OpenBlob(sha384 string) (io.ReadCloser, int64, error) {
+	if !sha384Pattern.MatchString(sha384) {
+		return nil, 0, errors.NotValidf("sha384 %q", sha384)
+	}
+	r, size, err := m.storage.Get(m.blobPath(sha384))
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	return r, size, nil
+}
+
+// Import reads an export archive from r, stores every blob it contains and
+// merges its manifest into the mirror's. Blobs the mirror already holds
+// may be left out of the archive.
+func (m *Mirror) Import(r io.Reader) (ImportResult, error) {
+	tr, err := newTarReader(r)
+	if err != nil {
+		return ImportResult{}, errors.Trace(err)
+	}
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return ImportResult{}, errors.Annotate(err, "reading export archive")
+	}
+	if path.Clean(hdr.Name) != ManifestFile {
+		return ImportResult{}, errors.NotValidf("export archive starting with %q rather than %q", hdr.Name, ManifestFile)
+	}
+	manifest, err := ReadManifest(tr)
+	if err != nil {
+		return ImportResult{}, errors.Trace(err)
+	}
+	blobs := manifest.Blobs()
+
+	stored := make(map[string]bool)
+	var result ImportResult
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return ImportResult{}, errors.Annotate(err, "reading export archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if name == ChecksumsFile {
+			continue
+		}
+		blob, ok := blobs[path.Base(name)]
+		if !ok || name != blob.Path {
+			return ImportResult{}, errors.NotValidf("export archive entry %q not in manifest", hdr.Name)
+		}
+		if hdr.Size != blob.Size {
+			return ImportResult{}, errors.NotValidf("%q size %d, expected %d", hdr.Name, hdr.Size, blob.Size)
+		}
+		if m.hasBlob(blob.SHA384) {
+			stored[blob.SHA384] = true
+			continue
+		}
+		if err := m.storage.PutAndCheckHash(m.blobPath(blob.SHA384), tr, hdr.Size, blob.SHA384); err != nil {
+			return ImportResult{}, errors.Annotatef(err, "storing %q", hdr.Name)
+		}
+		stored[blob.SHA384] = true
+		result.BlobsAdded++
+	}
+
+	for sha384, blob := range blobs {
+		if stored[sha384] || m.hasBlob(sha384) {
+			continue
+		}
+		return ImportResult{}, errors.NotFoundf("%q in export archive", blob.Path)
+	}
+
+	var (
+		previous, written string
+		merged            Manifest
+	)
+	err = m.manifests.UpdateCharmhubMirrorManifest(func(current string) (string, error) {
+		if written != "" && written != current {
+			// The manifest written by an earlier attempt was never
+			// recorded, as another import replaced the manifest first.
+			_ = m.storage.Remove(written)
+		}
+		existing, err := m.readManifest(current)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		merged = existing.Merge(manifest)
+		if written, err = m.writeManifest(merged); err != nil {
+			return "", errors.Trace(err)
+		}
+		previous = current
+		return written, nil
+	})
+	if err != nil {
+		return ImportResult{}, errors.Trace(err)
+	}
+	if previous != "" && previous != written {
+		// Nothing refers to the replaced manifest any more. Failing to
+		// remove it only wastes storage, so the import still succeeds.
+		_ = m.storage.Remove(previous)
+	}
+	m.setCachedManifest(written, merged)
+
+	result.Entities = len(manifest.Entities)
+	return result, nil
+}
+
+func (m *Mirror) blobPath(sha384 string) string {
+	return path.Join(storagePrefix, BlobPath(sha384))
+}
+
+func (m *Mirror) hasBlob(sha384 string) bool {
+	r, _, err := m.storage.Get(m.blobPath(sha384))
+	if err != nil {
+		return false
+	}
+	_ = r.Close()
+	return true
+}
+
+// newTarReader returns a tar reader for r, which may be gzip compressed.
+func newTarReader(r io.Reader) (*tar.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading export archive")
+	}
+	if magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading export archive")
+		}
+		return tar.NewReader(zr), nil
+	}
+	return tar.NewReader(br), nil
+}
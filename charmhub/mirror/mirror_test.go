@@ -0,0 +1,327 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+)
+
+type mirrorSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&mirrorSuite{})
+
+var (
+	jammy = transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	focal = transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "20.04"}
+)
+
+func (s *mirrorSuite) TestIsMirrorURL(c *gc.C) {
+	addrs := []string{"10.0.0.1:17070", "controller.example:17070"}
+	for _, t := range []struct {
+		url    string
+		mirror bool
+	}{
+		{"https://10.0.0.1:17070/charmhub", true},
+		{"https://10.0.0.1:17070/charmhub/", true},
+		{"https://Controller.Example:17070/charmhub", true},
+		{"https://10.0.0.1:17070/charmhub-other", false},
+		{"https://10.0.0.2:17070/charmhub", false},
+		{"https://10.0.0.1/charmhub", false},
+		{"https://evil.example/charmhub", false},
+		{"https://api.charmhub.io", false},
+		{"/charmhub", false},
+	} {
+		c.Check(mirror.IsMirrorURL(t.url, addrs), gc.Equals, t.mirror, gc.Commentf("%s", t.url))
+	}
+	c.Check(mirror.IsMirrorURL("https://10.0.0.1:17070/charmhub", nil), jc.IsFalse)
+}
+
+func (s *mirrorSuite) addCharm(c *gc.C, e *export, name string, revision int, data []byte, channels ...string) {
+	blob := e.addBlob(c, data)
+	entity := mirror.Entity{
+		Type: transport.CharmType,
+		ID:   name + "-id",
+		Name: name,
+		Revisions: []mirror.Revision{{
+			Revision: revision,
+			Bases:    []transport.Base{jammy},
+			Archive:  blob,
+		}},
+	}
+	for _, risk := range channels {
+		entity.Channels = append(entity.Channels, mirror.Channel{
+			Track:    "latest",
+			Risk:     risk,
+			Base:     jammy,
+			Revision: revision,
+		})
+	}
+	e.manifest.Entities = append(e.manifest.Entities, entity)
+}
+
+func (s *mirrorSuite) TestManifestEmpty(c *gc.C) {
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest, jc.DeepEquals, mirror.Manifest{Version: mirror.ManifestVersion})
+}
+
+func (s *mirrorSuite) TestImport(c *gc.C) {
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	result, err := m.Import(e.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, mirror.ImportResult{Entities: 1, BlobsAdded: 1})
+
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest.Entities, jc.DeepEquals, e.manifest.Entities)
+
+	r, size, err := m.OpenBlob(e.manifest.Entities[0].Revisions[0].Archive.SHA384)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "foo charm")
+	c.Check(size, gc.Equals, int64(9))
+}
+
+func (s *mirrorSuite) TestImportMergesAndSkipsExistingBlobs(c *gc.C) {
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+
+	first := newExport(c)
+	s.addCharm(c, first, "foo", 1, []byte("foo charm"), "stable")
+	_, err := m.Import(first.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	second := newExport(c)
+	s.addCharm(c, second, "foo", 1, []byte("foo charm"), "stable", "edge")
+	s.addCharm(c, second, "bar", 3, []byte("bar charm"), "stable")
+	result, err := m.Import(second.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, mirror.ImportResult{Entities: 2, BlobsAdded: 1})
+
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Entities, gc.HasLen, 2)
+	c.Check(manifest.Entities[0].Name, gc.Equals, "bar")
+	c.Check(manifest.Entities[1].Name, gc.Equals, "foo")
+	c.Check(manifest.Entities[1].Channels, gc.HasLen, 2)
+}
+
+func (s *mirrorSuite) TestImportWithoutBlobsAlreadyHeld(c *gc.C) {
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+	_, err := m.Import(e.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := m.Import(manifestOnlyArchive(c, e.manifest))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, mirror.ImportResult{Entities: 1})
+}
+
+func (s *mirrorSuite) TestManifestCached(c *gc.C) {
+	storage := newMemStorage()
+	manifests := &memManifestStore{}
+	m := mirror.New(storage, manifests)
+
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+	_, err := m.Import(e.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The manifest written by the import is cached.
+	for i := 0; i < 2; i++ {
+		manifest, err := m.Manifest()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(manifest.Entities, gc.HasLen, 1)
+	}
+	paths := storage.manifestPaths()
+	c.Assert(paths, gc.HasLen, 1)
+	c.Check(storage.gets[paths[0]], gc.Equals, 0)
+
+	// An import by another controller replaces the cached manifest.
+	other := newExport(c)
+	s.addCharm(c, other, "bar", 1, []byte("bar charm"), "stable")
+	_, err = mirror.New(storage, manifests).Import(other.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest.Entities, gc.HasLen, 2)
+	c.Check(storage.manifestPaths(), gc.HasLen, 1)
+}
+
+func (s *mirrorSuite) TestConcurrentImportsAreMerged(c *gc.C) {
+	storage := newMemStorage()
+	manifests := &memManifestStore{}
+
+	first := newExport(c)
+	s.addCharm(c, first, "foo", 1, []byte("foo charm"), "stable")
+	second := newExport(c)
+	s.addCharm(c, second, "bar", 1, []byte("bar charm"), "stable")
+
+	// Another controller imports the second export while the first is
+	// being merged.
+	manifests.beforeSwap = func() {
+		_, err := mirror.New(storage, manifests).Import(second.archive(c))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	m := mirror.New(storage, manifests)
+	_, err := m.Import(first.archive(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Entities, gc.HasLen, 2)
+	c.Check(manifest.Entities[0].Name, gc.Equals, "bar")
+	c.Check(manifest.Entities[1].Name, gc.Equals, "foo")
+	// Only the current manifest is kept.
+	c.Check(storage.manifestPaths(), gc.HasLen, 1)
+}
+
+func (s *mirrorSuite) TestImportMissingBlob(c *gc.C) {
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	_, err := m.Import(manifestOnlyArchive(c, e.manifest))
+	c.Assert(err, gc.ErrorMatches, `"blobs/[0-9a-f]+" in export archive not found`)
+	c.Check(err, jc.ErrorIs, errors.NotFound)
+
+	manifest, err := m.Manifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manifest.Entities, gc.HasLen, 0)
+}
+
+func (s *mirrorSuite) TestImportCorruptBlob(c *gc.C) {
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+	blob := e.manifest.Entities[0].Revisions[0].Archive
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeManifestEntry(c, tw, e.manifest)
+	writeEntry(c, tw, blob.Path, []byte("bad charm"))
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	_, err := m.Import(&buf)
+	c.Assert(err, gc.ErrorMatches, `storing "blobs/[0-9a-f]+": hash mismatch`)
+}
+
+func (s *mirrorSuite) TestImportUnknownEntry(c *gc.C) {
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeManifestEntry(c, tw, e.manifest)
+	writeEntry(c, tw, "../etc/passwd", []byte("root"))
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	_, err := m.Import(&buf)
+	c.Assert(err, gc.ErrorMatches, `export archive entry "../etc/passwd" not in manifest not valid`)
+}
+
+func (s *mirrorSuite) TestImportManifestNotFirst(c *gc.C) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeEntry(c, tw, "blobs/foo", []byte("foo"))
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	m := mirror.New(newMemStorage(), &memManifestStore{})
+	_, err := m.Import(&buf)
+	c.Assert(err, gc.ErrorMatches, `export archive starting with "blobs/foo" rather than "manifest.json" not valid`)
+}
+
+func (s *mirrorSuite) TestValidateChannelWithoutRevision(c *gc.C) {
+	e := newExport(c)
+	s.addCharm(c, e, "foo", 1, []byte("foo charm"), "stable")
+	e.manifest.Entities[0].Channels[0].Revision = 2
+
+	err := e.manifest.Validate()
+	c.Assert(err, gc.ErrorMatches, `entity "foo": channel "latest/stable" released revision 2 which is not exported not valid`)
+}
+
+func (s *mirrorSuite) TestValidateVersion(c *gc.C) {
+	err := mirror.Manifest{Version: 42}.Validate()
+	c.Assert(err, gc.ErrorMatches, `manifest version 42 not supported`)
+}
+
+func (s *mirrorSuite) TestMergeReplacesRevisionsAndChannels(c *gc.C) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	a := mirror.Manifest{Created: older, Entities: []mirror.Entity{{
+		Name:      "foo",
+		Revisions: []mirror.Revision{{Revision: 1}},
+		Channels: []mirror.Channel{
+			{Track: "latest", Risk: "stable", Base: jammy, Revision: 1},
+			{Track: "latest", Risk: "stable", Base: focal, Revision: 1},
+		},
+	}}}
+	b := mirror.Manifest{Created: newer, Entities: []mirror.Entity{{
+		Name:      "foo",
+		Summary:   "new summary",
+		Revisions: []mirror.Revision{{Revision: 2}},
+		Channels: []mirror.Channel{
+			{Track: "latest", Risk: "stable", Base: jammy, Revision: 2},
+		},
+	}}}
+
+	merged := a.Merge(b)
+	c.Check(merged.Created, gc.Equals, newer)
+	c.Assert(merged.Entities, gc.HasLen, 1)
+	entity := merged.Entities[0]
+	c.Check(entity.Summary, gc.Equals, "new summary")
+	c.Check(entity.Revisions, jc.DeepEquals, []mirror.Revision{{Revision: 1}, {Revision: 2}})
+	c.Check(entity.Channels, jc.DeepEquals, []mirror.Channel{
+		{Track: "latest", Risk: "stable", Base: jammy, Revision: 2},
+		{Track: "latest", Risk: "stable", Base: focal, Revision: 1},
+	})
+}
+
+func manifestOnlyArchive(c *gc.C, manifest mirror.Manifest) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	writeManifestEntry(c, tw, manifest)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	return &buf
+}
+
+func writeManifestEntry(c *gc.C, tw *tar.Writer, manifest mirror.Manifest) {
+	var buf bytes.Buffer
+	c.Assert(mirror.WriteManifest(&buf, manifest), jc.ErrorIsNil)
+	writeEntry(c, tw, mirror.ManifestFile, buf.Bytes())
+}
+
+func writeEntry(c *gc.C, tw *tar.Writer, name string, data []byte) {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write(data)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub/mirror"
+)
+
+func Test(t *testing.T) {
+	gc.TestingT(t)
+}
+
+// memStorage is an in-memory mirror.Storage.
+type memStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	gets  map[string]int
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{
+		files: make(map[string][]byte),
+		gets:  make(map[string]int),
+	}
+}
+
+func (s *memStorage) Get(path string) (io.ReadCloser, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets[path]++
+	data, ok := s.files[path]
+	if !ok {
+		return nil, 0, errors.NotFoundf("%q", path)
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+func (s *memStorage) Put(path string, r io.Reader, length int64) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != length {
+		return errors.Errorf("expected %d bytes, got %d", length, len(data))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path] = data
+	return nil
+}
+
+func (s *memStorage) PutAndCheckHash(path string, r io.Reader, length int64, hash string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if got := fmt.Sprintf("%x", sha512.Sum384(data)); got != hash {
+		return errors.New("hash mismatch")
+	}
+	return s.Put(path, bytes.NewReader(data), length)
+}
+
+func (s *memStorage) Remove(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.files[path]; !ok {
+		return errors.NotFoundf("%q", path)
+	}
+	delete(s.files, path)
+	return nil
+}
+
+// manifestPaths returns the paths of every stored manifest.
+func (s *memStorage) manifestPaths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var paths []string
+	for path := range s.files {
+		if strings.HasPrefix(path, "charmhub-mirror/manifests/") {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// memManifestStore is an in-memory mirror.ManifestStore, recording the
+// current manifest with a compare-and-swap like the controller's state.
+type memManifestStore struct {
+	mu       sync.Mutex
+	manifest string
+
+	// beforeSwap, if set, is called once after an update has been
+	// prepared and before it is recorded.
+	beforeSwap func()
+}
+
+func (s *memManifestStore) CharmhubMirrorManifest() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.manifest, nil
+}
+
+func (s *memManifestStore) UpdateCharmhubMirrorManifest(update func(string) (string, error)) error {
+	for {
+		current, _ := s.CharmhubMirrorManifest()
+		manifest, err := update(current)
+		if err != nil {
+			return err
+		}
+		if s.beforeSwap != nil {
+			beforeSwap := s.beforeSwap
+			s.beforeSwap = nil
+			beforeSwap()
+		}
+		s.mu.Lock()
+		if s.manifest == current {
+			s.manifest = manifest
+			s.mu.Unlock()
+			return nil
+		}
+		s.mu.Unlock()
+	}
+}
+
+// export builds an export directory for tests.
+type export struct {
+	dir      string
+	manifest mirror.Manifest
+}
+
+func newExport(c *gc.C) *export {
+	dir := c.MkDir()
+	c.Assert(os.MkdirAll(filepath.Join(dir, mirror.BlobsDir), 0755), jc.ErrorIsNil)
+	return &export{
+		dir:      dir,
+		manifest: mirror.Manifest{Version: mirror.ManifestVersion},
+	}
+}
+
+func (e *export) addBlob(c *gc.C, data []byte) mirror.Blob {
+	sha384 := fmt.Sprintf("%x", sha512.Sum384(data))
+	blob := mirror.Blob{
+		Path:   mirror.BlobPath(sha384),
+		Size:   int64(len(data)),
+		SHA256: fmt.Sprintf("%x", sha256.Sum256(data)),
+		SHA384: sha384,
+	}
+	err := os.WriteFile(filepath.Join(e.dir, filepath.FromSlash(blob.Path)), data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return blob
+}
+
+func (e *export) archive(c *gc.C) *bytes.Buffer {
+	f, err := os.Create(filepath.Join(e.dir, mirror.ManifestFile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mirror.WriteManifest(f, e.manifest), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	var buf bytes.Buffer
+	c.Assert(mirror.ArchiveDir(&buf, e.dir), jc.ErrorIsNil)
+	return &buf
+}
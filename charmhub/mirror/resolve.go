@@ -0,0 +1,475 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/charmhub/transport"
+)
+
+const (
+	// notAvailable is sent by the charmhub client in place of a base name or
+	// channel it doesn't know.
+	notAvailable = "NA"
+
+	defaultTrack = "latest"
+	defaultRisk  = "stable"
+)
+
+// riskFallback mirrors the charmhub behaviour of a closed channel following
+// the next most stable risk.
+var riskFallback = map[string][]string{
+	"stable":    {"stable"},
+	"candidate": {"candidate", "stable"},
+	"beta":      {"beta", "candidate", "stable"},
+	"edge":      {"edge", "beta", "candidate", "stable"},
+}
+
+// DownloadURLFunc returns the URL from which the blob with the given
+// SHA384 hash can be downloaded.
+type DownloadURLFunc func(sha384 string) string
+
+// resolver answers charmhub API requests from a manifest.
+type resolver struct {
+	manifest    Manifest
+	downloadURL DownloadURLFunc
+}
+
+func (r resolver) entityByName(name string) (Entity, bool) {
+	for _, entity := range r.manifest.Entities {
+		if entity.Name == name {
+			return entity, true
+		}
+	}
+	return Entity{}, false
+}
+
+func (r resolver) entityByID(id string) (Entity, bool) {
+	for _, entity := range r.manifest.Entities {
+		if entity.ID == id {
+			return entity, true
+		}
+	}
+	return Entity{}, false
+}
+
+// Info returns the info response for the named entity. If channel is not
+// empty, the default release is taken from that channel.
+func (r resolver) Info(name, channel string) (transport.InfoResponse, error) {
+	entity, ok := r.entityByName(name)
+	if !ok {
+		return transport.InfoResponse{}, errors.NotFoundf("%q", name)
+	}
+	resp := transport.InfoResponse{
+		Type:   entity.Type,
+		ID:     entity.ID,
+		Name:   entity.Name,
+		Entity: r.transportEntity(entity),
+	}
+	for _, ch := range entity.Channels {
+		rev, _ := entity.revision(ch.Revision)
+		resp.ChannelMap = append(resp.ChannelMap, transport.InfoChannelMap{
+			Channel:  transportChannel(ch),
+			Revision: r.infoRevision(rev),
+		})
+	}
+	if released, ok := entity.defaultRelease(channel); ok {
+		rev, _ := entity.revision(released.Revision)
+		resp.DefaultRelease = transport.InfoChannelMap{
+			Channel:  transportChannel(released),
+			Revision: r.infoRevision(rev),
+		}
+	}
+	return resp, nil
+}
+
+// Find returns every entity whose name or summary contains query, optionally
+// restricted to the given type.
+func (r resolver) Find(query string, entityType string) []transport.FindResponse {
+	query = strings.ToLower(query)
+	var results []transport.FindResponse
+	for _, entity := range r.manifest.Entities {
+		if entityType != "" && !entity.Type.Matches(entityType) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(entity.Name), query) &&
+			!strings.Contains(strings.ToLower(entity.Summary), query) {
+			continue
+		}
+		result := transport.FindResponse{
+			Type:   entity.Type,
+			ID:     entity.ID,
+			Name:   entity.Name,
+			Entity: r.transportEntity(entity),
+		}
+		if released, ok := entity.defaultRelease(""); ok {
+			rev, _ := entity.revision(released.Revision)
+			result.DefaultRelease = transport.FindChannelMap{
+				Channel: transportChannel(released),
+				Revision: transport.FindRevision{
+					CreatedAt: rev.CreatedAt.Format(time.RFC3339),
+					Download:  r.download(rev.Archive),
+					Bases:     rev.Bases,
+					Revision:  rev.Revision,
+					Version:   rev.Version,
+				},
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Refresh answers each of the actions in the request.
+func (r resolver) Refresh(req transport.RefreshRequest) transport.RefreshResponses {
+	contexts := make(map[string]transport.RefreshRequestContext)
+	for _, ctx := range req.Context {
+		contexts[ctx.InstanceKey] = ctx
+	}
+	var resp transport.RefreshResponses
+	for _, action := range req.Actions {
+		resp.Results = append(resp.Results, r.refreshAction(action, contexts[action.InstanceKey]))
+	}
+	return resp
+}
+
+func (r resolver) refreshAction(action transport.RefreshRequestAction, ctx transport.RefreshRequestContext) transport.RefreshResponse {
+	result := transport.RefreshResponse{
+		InstanceKey: action.InstanceKey,
+		Result:      action.Action,
+	}
+	fail := func(err *transport.APIError) transport.RefreshResponse {
+		result.Result = "error"
+		result.Error = err
+		return result
+	}
+
+	var (
+		entity Entity
+		found  bool
+	)
+	switch {
+	case action.ID != nil:
+		result.ID = *action.ID
+		entity, found = r.entityByID(*action.ID)
+	case action.Name != nil:
+		result.Name = *action.Name
+		entity, found = r.entityByName(*action.Name)
+	}
+	if !found {
+		return fail(&transport.APIError{
+			Code:    transport.ErrorCodeNotFound,
+			Message: fmt.Sprintf("%s not found in the charmhub mirror", entityRef(result)),
+		})
+	}
+	result.ID = entity.ID
+	result.Name = entity.Name
+
+	var (
+		rev      Revision
+		released Channel
+	)
+	if action.Revision != nil {
+		if rev, found = entity.revision(*action.Revision); !found {
+			return fail(&transport.APIError{
+				Code:    transport.ErrorCodeRevisionNotFound,
+				Message: fmt.Sprintf("revision %d of %q not found in the charmhub mirror", *action.Revision, entity.Name),
+				Extra:   transport.APIErrorExtra{Releases: entity.releases()},
+			})
+		}
+	} else {
+		channel := ctx.TrackingChannel
+		if action.Channel != nil {
+			channel = *action.Channel
+		}
+		base := ctx.Base
+		if action.Base != nil {
+			base = *action.Base
+		}
+		track, risk, err := parseChannel(channel)
+		if err != nil {
+			return fail(&transport.APIError{
+				Code:    transport.ErrorCodeInvalidChannel,
+				Message: err.Error(),
+			})
+		}
+		if base.Name == notAvailable || base.Channel == notAvailable {
+			return fail(&transport.APIError{
+				Code:    transport.ErrorCodeInvalidCharmBase,
+				Message: fmt.Sprintf("no base specified for %q", entity.Name),
+				Extra:   transport.APIErrorExtra{DefaultBases: entity.channelBases(track, risk, base.Architecture)},
+			})
+		}
+		if released, found = entity.channelRelease(track, risk, base); !found {
+			return fail(&transport.APIError{
+				Code:    transport.ErrorCodeRevisionNotFound,
+				Message: fmt.Sprintf("no release of %q found for channel %q and base %s/%s", entity.Name, track+"/"+risk, base.Name, base.Channel),
+				Extra:   transport.APIErrorExtra{Releases: entity.releases()},
+			})
+		}
+		rev, _ = entity.revision(released.Revision)
+		result.EffectiveChannel = released.Name()
+		result.ReleasedAt = released.ReleasedAt
+	}
+
+	resources, apiErr := r.resources(entity, rev, action.ResourceRevisions)
+	if apiErr != nil {
+		return fail(apiErr)
+	}
+	result.Entity = transport.RefreshEntity{
+		Type:         entity.Type,
+		Download:     r.download(rev.Archive),
+		ID:           entity.ID,
+		License:      entity.License,
+		Name:         entity.Name,
+		Publisher:    entity.Publisher,
+		Resources:    resources,
+		Bases:        rev.Bases,
+		Revision:     rev.Revision,
+		Summary:      entity.Summary,
+		Version:      rev.Version,
+		CreatedAt:    rev.CreatedAt,
+		MetadataYAML: rev.MetadataYAML,
+		ConfigYAML:   rev.ConfigYAML,
+	}
+	return result
+}
+
+// resources returns the resources of a revision, swapping in any
+// specifically requested resource revisions.
+func (r resolver) resources(entity Entity, rev Revision, requested []transport.RefreshResourceRevision) ([]transport.ResourceRevision, *transport.APIError) {
+	pinned := make(map[string]int)
+	for _, res := range requested {
+		pinned[res.Name] = res.Revision
+	}
+	var results []transport.ResourceRevision
+	for _, res := range rev.Resources {
+		if revision, ok := pinned[res.Name]; ok && revision != res.Revision {
+			pinnedRes, found := entity.resourceRevision(res.Name, revision)
+			if !found {
+				return nil, &transport.APIError{
+					Code:    transport.ErrorCodeResourceNotFound,
+					Message: fmt.Sprintf("revision %d of resource %q not found in the charmhub mirror", revision, res.Name),
+				}
+			}
+			res = pinnedRes
+		}
+		results = append(results, r.resourceRevision(res))
+	}
+	return results, nil
+}
+
+// ResourceRevisions returns every revision of the named resource of a charm,
+// newest first.
+func (r resolver) ResourceRevisions(charmName, resourceName string) ([]transport.ResourceRevision, error) {
+	entity, ok := r.entityByName(charmName)
+	if !ok {
+		return nil, errors.NotFoundf("%q", charmName)
+	}
+	seen := make(map[int]bool)
+	var results []transport.ResourceRevision
+	for _, rev := range entity.Revisions {
+		for _, res := range rev.Resources {
+			if res.Name != resourceName || seen[res.Revision] {
+				continue
+			}
+			seen[res.Revision] = true
+			results = append(results, r.resourceRevision(res))
+		}
+	}
+	if len(results) == 0 {
+		return nil, errors.NotFoundf("resource %q of %q", resourceName, charmName)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Revision > results[j].Revision
+	})
+	return results, nil
+}
+
+func (r resolver) transportEntity(entity Entity) transport.Entity {
+	return transport.Entity{
+		Description: entity.Description,
+		License:     entity.License,
+		Publisher:   entity.Publisher,
+		Summary:     entity.Summary,
+	}
+}
+
+func (r resolver) infoRevision(rev Revision) transport.InfoRevision {
+	return transport.InfoRevision{
+		ConfigYAML:   rev.ConfigYAML,
+		CreatedAt:    rev.CreatedAt.Format(time.RFC3339),
+		Download:     r.download(rev.Archive),
+		MetadataYAML: rev.MetadataYAML,
+		BundleYAML:   rev.BundleYAML,
+		Bases:        rev.Bases,
+		Revision:     rev.Revision,
+		Version:      rev.Version,
+	}
+}
+
+func (r resolver) resourceRevision(res Resource) transport.ResourceRevision {
+	return transport.ResourceRevision{
+		Download:    r.download(res.Blob),
+		Description: res.Description,
+		Name:        res.Name,
+		Filename:    res.Filename,
+		Revision:    res.Revision,
+		Type:        res.Type,
+	}
+}
+
+func (r resolver) download(blob Blob) transport.Download {
+	return transport.Download{
+		HashSHA256: blob.SHA256,
+		HashSHA384: blob.SHA384,
+		Size:       int(blob.Size),
+		URL:        r.downloadURL(blob.SHA384),
+	}
+}
+
+func (e Entity) revision(revision int) (Revision, bool) {
+	for _, rev := range e.Revisions {
+		if rev.Revision == revision {
+			return rev, true
+		}
+	}
+	return Revision{}, false
+}
+
+func (e Entity) resourceRevision(name string, revision int) (Resource, bool) {
+	for _, rev := range e.Revisions {
+		for _, res := range rev.Resources {
+			if res.Name == name && res.Revision == revision {
+				return res, true
+			}
+		}
+	}
+	return Resource{}, false
+}
+
+// defaultRelease returns the release for the given channel, or for
+// latest/stable if the channel is empty, preferring the highest revision
+// when the channel has a release for more than one base.
+func (e Entity) defaultRelease(channel string) (Channel, bool) {
+	track, risk, err := parseChannel(channel)
+	if err != nil {
+		return Channel{}, false
+	}
+	for _, fallback := range riskFallback[risk] {
+		var (
+			best  Channel
+			found bool
+		)
+		for _, ch := range e.Channels {
+			if ch.Track != track || ch.Risk != fallback {
+				continue
+			}
+			if !found || ch.Revision > best.Revision {
+				best, found = ch, true
+			}
+		}
+		if found {
+			return best, true
+		}
+	}
+	return Channel{}, false
+}
+
+// channelRelease returns the release for a base in the given channel,
+// following less risky channels if the channel itself has no release.
+func (e Entity) channelRelease(track, risk string, base transport.Base) (Channel, bool) {
+	for _, fallback := range riskFallback[risk] {
+		for _, ch := range e.Channels {
+			if ch.Track == track && ch.Risk == fallback && baseMatches(ch.Base, base) {
+				return ch, true
+			}
+		}
+	}
+	return Channel{}, false
+}
+
+// channelBases returns the bases with a release in the given channel for
+// an architecture.
+func (e Entity) channelBases(track, risk, arch string) []transport.Base {
+	var bases []transport.Base
+	seen := make(map[transport.Base]bool)
+	for _, fallback := range riskFallback[risk] {
+		for _, ch := range e.Channels {
+			if ch.Track != track || ch.Risk != fallback || !archMatches(ch.Base.Architecture, arch) {
+				continue
+			}
+			if !seen[ch.Base] {
+				seen[ch.Base] = true
+				bases = append(bases, ch.Base)
+			}
+		}
+	}
+	return bases
+}
+
+func (e Entity) releases() []transport.Release {
+	releases := make([]transport.Release, len(e.Channels))
+	for i, ch := range e.Channels {
+		releases[i] = transport.Release{
+			Base:    ch.Base,
+			Channel: ch.Name(),
+		}
+	}
+	return releases
+}
+
+func baseMatches(released, requested transport.Base) bool {
+	if requested.Name == "" {
+		return archMatches(released.Architecture, requested.Architecture)
+	}
+	return released.Name == requested.Name &&
+		released.Channel == requested.Channel &&
+		archMatches(released.Architecture, requested.Architecture)
+}
+
+func archMatches(released, requested string) bool {
+	return released == requested || released == "all" || requested == "" || requested == "all"
+}
+
+// parseChannel returns the track and risk of a channel, defaulting to
+// latest/stable.
+func parseChannel(channel string) (string, string, error) {
+	if channel == "" {
+		return defaultTrack, defaultRisk, nil
+	}
+	ch, err := charm.ParseChannelNormalize(channel)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	track := ch.Track
+	if track == "" {
+		track = defaultTrack
+	}
+	return track, string(ch.Risk), nil
+}
+
+func transportChannel(ch Channel) transport.Channel {
+	return transport.Channel{
+		Name:       ch.Name(),
+		Base:       ch.Base,
+		ReleasedAt: ch.ReleasedAt.Format(time.RFC3339),
+		Risk:       ch.Risk,
+		Track:      ch.Track,
+	}
+}
+
+func entityRef(result transport.RefreshResponse) string {
+	if result.Name != "" {
+		return fmt.Sprintf("%q", result.Name)
+	}
+	return fmt.Sprintf("id %q", result.ID)
+}
@@ -143,69 +143,175 @@ func GenerateENITemplate(interfaces corenetwork.InterfaceInfos) (string, error)
 // The matchHWAddr argument indicates whether to add a match stanza for the
 // MAC address to each device.
 func GenerateNetplan(interfaces corenetwork.InterfaceInfos, matchHWAddr bool) (string, error) {
+	netPlan, err := NetplanFromInterfaces(interfaces, matchHWAddr)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	out, err := netplan.Marshal(netPlan)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	return string(out), nil
+}
+
+// NetplanFromInterfaces builds netplan configuration for the input non-empty
+// collection of interfaces.
+// Interfaces of type bond are rendered as bonds, with each non-VLAN interface
+// naming the bond as its parent rendered as a member ethernet device.
+// Interfaces of type 802.1q are rendered as VLANs on top of their parent
+// interface.
+// All other interfaces are rendered as ethernet devices.
+// The matchHWAddr argument indicates whether to add a match stanza for the
+// MAC address to each ethernet device.
+func NetplanFromInterfaces(interfaces corenetwork.InterfaceInfos, matchHWAddr bool) (*netplan.Netplan, error) {
 	if len(interfaces) == 0 {
-		return "", errors.Errorf("missing container network config")
+		return nil, errors.Errorf("missing container network config")
 	}
 	logger.Debugf("generating netplan from %#v", interfaces)
+
+	bonds := set.NewStrings()
+	for _, info := range interfaces {
+		if info.InterfaceType == corenetwork.BondDevice {
+			bonds.Add(info.InterfaceName)
+		}
+	}
+	// VLANs may also name a bond as their parent,
+	// but they are stacked on it rather than members of it.
+	isBondMember := func(info corenetwork.InterfaceInfo) bool {
+		return bonds.Contains(info.ParentInterfaceName) && info.InterfaceType != corenetwork.VLAN8021QDevice
+	}
+	bondMembers := make(map[string][]string)
+	for _, info := range interfaces {
+		if isBondMember(info) {
+			bondMembers[info.ParentInterfaceName] = append(bondMembers[info.ParentInterfaceName], info.InterfaceName)
+		}
+	}
+
 	var netPlan netplan.Netplan
-	netPlan.Network.Ethernets = make(map[string]netplan.Ethernet)
 	netPlan.Network.Version = 2
 	for _, info := range interfaces {
-		var iface netplan.Ethernet
-		cidr, err := info.PrimaryAddress().ValueWithMask()
-		if err != nil && !errors.IsNotFound(err) {
-			return "", errors.Trace(err)
+		if isBondMember(info) {
+			// Bond members carry no addressing of their own;
+			// that belongs to the bond.
+			member := netplan.Ethernet{Interface: netplan.Interface{MTU: netplanMTU(info.MTU)}}
+			if matchHWAddr && info.MACAddress != "" {
+				member.Match = map[string]string{"macaddress": info.MACAddress}
+			}
+			addNetplanEthernet(&netPlan, info.InterfaceName, member)
+			continue
 		}
-		if cidr != "" {
-			iface.Addresses = append(iface.Addresses, cidr)
-		} else if info.ConfigType == corenetwork.ConfigDHCP {
-			t := true
-			iface.DHCP4 = &t
+
+		iface, err := netplanInterface(info)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
 
-		for _, dns := range info.DNSServers {
-			// Netplan doesn't support IPv6 link-local addresses, so skip them.
-			if strings.HasPrefix(dns.Value, "fe80:") {
-				continue
+		switch info.InterfaceType {
+		case corenetwork.BondDevice:
+			members := bondMembers[info.InterfaceName]
+			if len(members) == 0 {
+				return nil, errors.NotValidf("bond %q without member interfaces", info.InterfaceName)
+			}
+			if netPlan.Network.Bonds == nil {
+				netPlan.Network.Bonds = make(map[string]netplan.Bond)
+			}
+			netPlan.Network.Bonds[info.InterfaceName] = netplan.Bond{
+				Interfaces: members,
+				Interface:  iface,
 			}
 
-			iface.Nameservers.Addresses = append(iface.Nameservers.Addresses, dns.Value)
-		}
-		iface.Nameservers.Search = append(iface.Nameservers.Search, info.DNSSearchDomains...)
+		case corenetwork.VLAN8021QDevice:
+			if info.VLANTag <= 0 {
+				return nil, errors.NotValidf("VLAN %q without VLAN tag", info.InterfaceName)
+			}
+			// Without a parent, the interface name is that of the
+			// device carrying the VLAN; see ActualInterfaceName.
+			name, link := info.InterfaceName, info.ParentInterfaceName
+			if link == "" {
+				name, link = info.ActualInterfaceName(), info.InterfaceName
+			}
+			id := info.VLANTag
+			if netPlan.Network.VLANs == nil {
+				netPlan.Network.VLANs = make(map[string]netplan.VLAN)
+			}
+			netPlan.Network.VLANs[name] = netplan.VLAN{
+				Id:        &id,
+				Link:      link,
+				Interface: iface,
+			}
 
-		if info.GatewayAddress.Value != "" {
-			switch {
-			case info.GatewayAddress.Type == corenetwork.IPv4Address:
-				iface.Gateway4 = info.GatewayAddress.Value
-			case info.GatewayAddress.Type == corenetwork.IPv6Address:
-				iface.Gateway6 = info.GatewayAddress.Value
+		default:
+			ethernet := netplan.Ethernet{Interface: iface}
+			if matchHWAddr && info.MACAddress != "" {
+				ethernet.Match = map[string]string{"macaddress": info.MACAddress}
 			}
+			addNetplanEthernet(&netPlan, info.InterfaceName, ethernet)
 		}
+	}
+	return &netPlan, nil
+}
 
-		if info.MTU != 0 && info.MTU != 1500 {
-			iface.MTU = info.MTU
+// netplanInterface returns the addressing, gateway, nameserver, MTU and
+// route configuration common to all netplan device types.
+func netplanInterface(info corenetwork.InterfaceInfo) (netplan.Interface, error) {
+	var iface netplan.Interface
+	cidr, err := info.PrimaryAddress().ValueWithMask()
+	if err != nil && !errors.IsNotFound(err) {
+		return iface, errors.Trace(err)
+	}
+	if cidr != "" {
+		iface.Addresses = append(iface.Addresses, cidr)
+	} else if info.ConfigType == corenetwork.ConfigDHCP {
+		t := true
+		iface.DHCP4 = &t
+	}
+
+	for _, dns := range info.DNSServers {
+		// Netplan doesn't support IPv6 link-local addresses, so skip them.
+		if strings.HasPrefix(dns.Value, "fe80:") {
+			continue
 		}
 
-		if matchHWAddr && info.MACAddress != "" {
-			iface.Match = map[string]string{"macaddress": info.MACAddress}
+		iface.Nameservers.Addresses = append(iface.Nameservers.Addresses, dns.Value)
+	}
+	iface.Nameservers.Search = append(iface.Nameservers.Search, info.DNSSearchDomains...)
+
+	if info.GatewayAddress.Value != "" {
+		switch {
+		case info.GatewayAddress.Type == corenetwork.IPv4Address:
+			iface.Gateway4 = info.GatewayAddress.Value
+		case info.GatewayAddress.Type == corenetwork.IPv6Address:
+			iface.Gateway6 = info.GatewayAddress.Value
 		}
+	}
 
-		for _, route := range info.Routes {
-			route := netplan.Route{
-				To:     route.DestinationCIDR,
-				Via:    route.GatewayIP,
-				Metric: &route.Metric,
-			}
-			iface.Routes = append(iface.Routes, route)
+	iface.MTU = netplanMTU(info.MTU)
+
+	for _, route := range info.Routes {
+		route := netplan.Route{
+			To:     route.DestinationCIDR,
+			Via:    route.GatewayIP,
+			Metric: &route.Metric,
 		}
-		netPlan.Network.Ethernets[info.InterfaceName] = iface
+		iface.Routes = append(iface.Routes, route)
 	}
-	out, err := netplan.Marshal(&netPlan)
-	if err != nil {
-		return "", errors.Trace(err)
+	return iface, nil
+}
+
+// netplanMTU returns the MTU to render, omitting the default.
+func netplanMTU(mtu int) int {
+	if mtu == 1500 {
+		return 0
 	}
+	return mtu
+}
 
-	return string(out), nil
+func addNetplanEthernet(netPlan *netplan.Netplan, name string, ethernet netplan.Ethernet) {
+	if netPlan.Network.Ethernets == nil {
+		netPlan.Network.Ethernets = make(map[string]netplan.Ethernet)
+	}
+	netPlan.Network.Ethernets[name] = ethernet
 }
 
 // PreparedConfig holds all the necessary information to render a persistent
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/container"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/network/netplan"
 	"github.com/juju/juju/testing"
 )
 
@@ -407,6 +408,118 @@ network:
 `[1:])
 }
 
+func (s *NetworkUbuntuSuite) TestGenerateNetplanBondsAndVLANs(c *gc.C) {
+	interfaces := corenetwork.InterfaceInfos{{
+		InterfaceName:       "eth0",
+		InterfaceType:       corenetwork.EthernetDevice,
+		ParentInterfaceName: "bond0",
+		MACAddress:          "aa:bb:cc:dd:ee:f0",
+		MTU:                 9000,
+	}, {
+		InterfaceName:       "eth1",
+		InterfaceType:       corenetwork.EthernetDevice,
+		ParentInterfaceName: "bond0",
+		MACAddress:          "aa:bb:cc:dd:ee:f1",
+		MTU:                 9000,
+	}, {
+		InterfaceName: "bond0",
+		InterfaceType: corenetwork.BondDevice,
+		ConfigType:    corenetwork.ConfigStatic,
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		Addresses: corenetwork.ProviderAddresses{
+			corenetwork.NewMachineAddress("10.0.0.5", corenetwork.WithCIDR("10.0.0.0/24")).AsProviderAddress()},
+		DNSServers:       corenetwork.NewMachineAddresses([]string{"10.0.0.2"}).AsProviderAddresses(),
+		DNSSearchDomains: []string{"maas"},
+		GatewayAddress:   corenetwork.NewMachineAddress("10.0.0.1").AsProviderAddress(),
+		MTU:              9000,
+		Routes: []corenetwork.Route{{
+			DestinationCIDR: "10.1.0.0/16",
+			GatewayIP:       "10.0.0.254",
+			Metric:          10,
+		}},
+	}, {
+		InterfaceName:       "bond0.100",
+		InterfaceType:       corenetwork.VLAN8021QDevice,
+		ParentInterfaceName: "bond0",
+		VLANTag:             100,
+		ConfigType:          corenetwork.ConfigStatic,
+		Addresses: corenetwork.ProviderAddresses{
+			corenetwork.NewMachineAddress("10.100.0.5", corenetwork.WithCIDR("10.100.0.0/24")).AsProviderAddress()},
+	}, {
+		InterfaceName: "eth2",
+		InterfaceType: corenetwork.VLAN8021QDevice,
+		VLANTag:       200,
+		ConfigType:    corenetwork.ConfigDHCP,
+	}}
+
+	data, err := cloudinit.GenerateNetplan(interfaces, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        macaddress: aa:bb:cc:dd:ee:f0
+      mtu: 9000
+    eth1:
+      match:
+        macaddress: aa:bb:cc:dd:ee:f1
+      mtu: 9000
+  bonds:
+    bond0:
+      interfaces: [eth0, eth1]
+      addresses:
+      - 10.0.0.5/24
+      gateway4: 10.0.0.1
+      nameservers:
+        search: [maas]
+        addresses: [10.0.0.2]
+      mtu: 9000
+      routes:
+      - to: 10.1.0.0/16
+        via: 10.0.0.254
+        metric: 10
+  vlans:
+    bond0.100:
+      id: 100
+      link: bond0
+      addresses:
+      - 10.100.0.5/24
+    eth2.200:
+      id: 200
+      link: eth2
+      dhcp4: true
+`[1:])
+
+	// The rendered YAML must round-trip through the netplan types.
+	expected, err := cloudinit.NetplanFromInterfaces(interfaces, true)
+	c.Assert(err, jc.ErrorIsNil)
+	var parsed netplan.Netplan
+	err = netplan.Unmarshal([]byte(data), &parsed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed.Network, jc.DeepEquals, expected.Network)
+}
+
+func (s *NetworkUbuntuSuite) TestGenerateNetplanBondWithoutMembers(c *gc.C) {
+	_, err := cloudinit.GenerateNetplan(corenetwork.InterfaceInfos{{
+		InterfaceName: "bond0",
+		InterfaceType: corenetwork.BondDevice,
+		ConfigType:    corenetwork.ConfigDHCP,
+	}}, true)
+	c.Assert(err, gc.ErrorMatches, `bond "bond0" without member interfaces not valid`)
+}
+
+func (s *NetworkUbuntuSuite) TestGenerateNetplanVLANWithoutTag(c *gc.C) {
+	_, err := cloudinit.GenerateNetplan(corenetwork.InterfaceInfos{{
+		InterfaceName:       "eth0.100",
+		InterfaceType:       corenetwork.VLAN8021QDevice,
+		ParentInterfaceName: "eth0",
+		ConfigType:          corenetwork.ConfigDHCP,
+	}}, true)
+	c.Assert(err, gc.ErrorMatches, `VLAN "eth0.100" without VLAN tag not valid`)
+}
+
 func (s *NetworkUbuntuSuite) TestAddNetworkConfigSampleConfig(c *gc.C) {
 	netConfig := container.BridgeNetworkConfig(0, s.fakeInterfaces)
 	cloudConf, err := cloudinit.New("ubuntu", cloudinit.WithNetplanMACMatch(true))
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -196,6 +197,11 @@ type InstanceConfig struct {
 
 	// Profiles is a slice of (lxd) profile names to be used by a container
 	Profiles []string
+
+	// NetworkConfig holds the network interfaces that cloud-init should
+	// configure on the instance, rendered as netplan on Ubuntu. If empty,
+	// the instance's networking is left to the image and provider.
+	NetworkConfig corenetwork.InterfaceInfos
 }
 
 // BootstrapConfig represents bootstrap-specific initialization information
@@ -35,6 +35,7 @@ import (
 	corebase "github.com/juju/juju/core/base"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -1212,6 +1213,31 @@ DefaultEnvironment="http_proxy=http://user@10.0.0.1" "HTTP_PROXY=http://user@10.
 }
 
 // Ensure the bootstrap curl which fetch tools respects the proxy settings
+func (s *cloudinitSuite) TestNetworkConfigWritten(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	instanceCfg.NetworkConfig = corenetwork.InterfaceInfos{{
+		InterfaceName: "eth0",
+		ConfigType:    corenetwork.ConfigDHCP,
+	}}
+	cloudcfg, err := cloudinit.New("ubuntu")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmds := cloudcfg.BootCmds()
+	c.Assert(len(cmds) > 2, jc.IsTrue)
+	c.Check(cmds[0], gc.Equals, "install -D -m 644 /dev/null '/etc/netplan/99-juju.yaml'")
+	c.Check(cmds[1], gc.Equals, `echo 'network:
+  version: 2
+  ethernets:
+    eth0:
+      dhcp4: true
+' > '/etc/netplan/99-juju.yaml'`)
+}
+
 func (s *cloudinitSuite) TestProxyArgsAddedToCurlCommand(c *gc.C) {
 	instcfg := makeBootstrapConfig(jammy, 0).maybeSetModelConfig(
 		minimalModelConfig(c),
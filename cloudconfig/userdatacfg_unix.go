@@ -207,6 +207,12 @@ func (w *unixConfigure) ConfigureBasic() error {
 		}
 	}
 
+	if len(w.icfg.NetworkConfig) > 0 {
+		if err := w.conf.AddNetworkConfig(w.icfg.NetworkConfig); err != nil {
+			return errors.Annotate(err, "adding network config")
+		}
+	}
+
 	w.conf.SetOutput(cloudinit.OutAll, "| tee -a "+w.icfg.CloudInitOutputLog, "")
 	// Create a file in a well-defined location containing the machine's
 	// nonce. The presence and contents of this file will be verified
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
 	apiparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/juju/storage"
 )
@@ -169,8 +170,9 @@ func newDeployCommand() *DeployCommand {
 		}
 
 		return charmhub.NewClient(charmhub.Config{
-			URL:    charmHubURL,
-			Logger: logger,
+			URL:            charmHubURL,
+			Logger:         logger,
+			CACertificates: deployCmd.controllerCACerts(),
 		})
 	}
 	deployCmd.NewDeployAPI = func() (deployer.DeployerAPI, error) {
@@ -937,6 +939,25 @@ func (c *DeployCommand) getCharmHubURL(modelConfigClient ModelConfigGetter) (str
 	return charmHubURL, nil
 }
 
+// controllerCACerts returns the CA certificate of the current controller, so
+// that models using the charmhub mirror hosted by the controller can download
+// bundles from it.
+func (c *DeployCommand) controllerCACerts() []string {
+	return controllerCACerts(c.ClientStore(), c.ControllerName)
+}
+
+func controllerCACerts(store jujuclient.ControllerGetter, controllerName func() (string, error)) []string {
+	name, err := controllerName()
+	if err != nil {
+		return nil
+	}
+	details, err := store.ControllerByName(name)
+	if err != nil || details.CACert == "" {
+		return nil
+	}
+	return []string{details.CACert}
+}
+
 type defaultCharmReader struct{}
 
 // NewCharmAtPath returns the charm represented by this path,
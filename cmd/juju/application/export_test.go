@@ -28,7 +28,7 @@ func NewRefreshCommandForTest(
 	newResourceLister func(base.APICallCloser) (utils.ResourceLister, error),
 	newSpacesClient func(base.APICallCloser) SpacesAPI,
 	newModelConfigClient func(base.APICallCloser) ModelConfigClient,
-	newCharmHubClient func(string) (store.DownloadBundleClient, error),
+	newCharmHubClient func(string, []string) (store.DownloadBundleClient, error),
 ) cmd.Command {
 	cmd := &refreshCommand{
 		DeployResources:       deployResources,
@@ -230,3 +230,11 @@ func NewConfigCommandForTest(api ApplicationAPI, store jujuclient.ClientStore) m
 	c.SetClientStore(store)
 	return c
 }
+
+func NewShowLeadershipHistoryCommandForTest(api LeadershipHistoryAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showLeadershipHistoryCommand{newAPIFunc: func() (LeadershipHistoryAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/leadershiphistory"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showLeadershipHistoryDoc = `
+The command shows the recorded changes to the leadership of an
+application, oldest first. Each change is one of:
+
+    claim    a unit became the leader
+    extend   the leader extended its leadership after it was pinned
+             or unpinned
+    expire   the leader failed to extend its leadership in time
+    revoke   the leader gave up its leadership
+    pin      an entity pinned leadership so that it cannot expire
+    unpin    an entity removed its pin
+
+The leader regularly extends its leadership. Rather than recording each
+extension, the expiry shown for the leader's last claim or extension is
+updated.
+
+Changes are retained by the controller for a limited time, after which
+they are pruned.
+`
+
+const showLeadershipHistoryExamples = `
+    juju show-leadership-history mysql
+    juju show-leadership-history mysql --limit 20
+    juju show-leadership-history mysql --format yaml
+`
+
+// LeadershipHistoryAPI defines the API methods that the
+// show-leadership-history command uses.
+type LeadershipHistoryAPI interface {
+	Close() error
+	LeadershipHistory(application string, limit int) ([]leadershiphistory.Entry, error)
+}
+
+// NewShowLeadershipHistoryCommand returns a command that displays the
+// leadership history of an application.
+func NewShowLeadershipHistoryCommand() cmd.Command {
+	c := &showLeadershipHistoryCommand{}
+	c.newAPIFunc = func() (LeadershipHistoryAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leadershiphistory.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// showLeadershipHistoryCommand displays the leadership history of an
+// application.
+type showLeadershipHistoryCommand struct {
+	modelcmd.ModelCommandBase
+
+	out        cmd.Output
+	app        string
+	limit      int
+	newAPIFunc func() (LeadershipHistoryAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showLeadershipHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "show-leadership-history",
+		Args:     "<application name>",
+		Purpose:  "Displays the leadership history of an application.",
+		Doc:      showLeadershipHistoryDoc,
+		Examples: showLeadershipHistoryExamples,
+		SeeAlso: []string{
+			"show-application",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showLeadershipHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.limit, "limit", 0, "Show only the most recent changes (0 for all)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatLeadershipHistoryTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showLeadershipHistoryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("an application name must be supplied")
+	}
+	c.app, args = args[0], args[1:]
+	if !names.IsValidApplication(c.app) {
+		return errors.NotValidf("application name %q", c.app)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit %d", c.limit)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// leadershipChange is the output form of a leadership history entry.
+type leadershipChange struct {
+	Time   time.Time  `json:"time" yaml:"time"`
+	Action string     `json:"action" yaml:"action"`
+	Entity string     `json:"entity,omitempty" yaml:"entity,omitempty"`
+	Expiry *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *showLeadershipHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.LeadershipHistory(c.app, c.limit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No leadership history recorded for %s.", c.app)
+		return nil
+	}
+
+	changes := make([]leadershipChange, len(entries))
+	for i, entry := range entries {
+		changes[i] = leadershipChange{
+			Time:   entry.Time.UTC(),
+			Action: entry.Action,
+			Entity: entry.Entity,
+		}
+		if !entry.Expiry.IsZero() {
+			expiry := entry.Expiry.UTC()
+			changes[i].Expiry = &expiry
+		}
+	}
+	return c.out.Write(ctx, changes)
+}
+
+func formatLeadershipHistoryTabular(writer io.Writer, value interface{}) error {
+	changes, ok := value.([]leadershipChange)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", changes, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Time\tAction\tEntity\tExpiry")
+	for _, change := range changes {
+		expiry := ""
+		if change.Expiry != nil {
+			expiry = change.Expiry.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			change.Time.Format(time.RFC3339), change.Action, change.Entity, expiry)
+	}
+	return tw.Flush()
+}
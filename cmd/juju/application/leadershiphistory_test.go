@@ -0,0 +1,136 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/client/leadershiphistory"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type ShowLeadershipHistorySuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	api *mockLeadershipHistoryAPI
+}
+
+var _ = gc.Suite(&ShowLeadershipHistorySuite{})
+
+func (s *ShowLeadershipHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &mockLeadershipHistoryAPI{
+		entries: []leadershiphistory.Entry{
+			{Action: "claim", Entity: "redis/0", Expiry: now.Add(time.Minute), Time: now},
+			{Action: "expire", Entity: "redis/0", Time: now.Add(2 * time.Minute)},
+			{Action: "claim", Entity: "redis/1", Expiry: now.Add(3 * time.Minute), Time: now.Add(2 * time.Minute)},
+		},
+	}
+}
+
+func (s *ShowLeadershipHistorySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewShowLeadershipHistoryCommandForTest(s.api, s.store), args...)
+}
+
+func (s *ShowLeadershipHistorySuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "an application name must be supplied",
+	}, {
+		args: []string{"redis/0"},
+		err:  `application name "redis/0" not valid`,
+	}, {
+		args: []string{"redis", "--limit", "-1"},
+		err:  "negative limit -1 not valid",
+	}, {
+		args: []string{"redis", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}} {
+		_, err := s.run(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *ShowLeadershipHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "redis", "--limit", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.application, gc.Equals, "redis")
+	c.Check(s.api.limit, gc.Equals, 3)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Action  Entity   Expiry
+2024-03-01T12:00:00Z  claim   redis/0  2024-03-01T12:01:00Z
+2024-03-01T12:02:00Z  expire  redis/0  
+2024-03-01T12:02:00Z  claim   redis/1  2024-03-01T12:03:00Z
+`[1:])
+}
+
+func (s *ShowLeadershipHistorySuite) TestYAML(c *gc.C) {
+	s.api.entries = s.api.entries[1:2]
+	ctx, err := s.run(c, "redis", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- time: 2024-03-01T12:02:00Z
+  action: expire
+  entity: redis/0
+`[1:])
+}
+
+func (s *ShowLeadershipHistorySuite) TestNoHistory(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c, "redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No leadership history recorded for redis.\n")
+}
+
+func (s *ShowLeadershipHistorySuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c, "redis")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockLeadershipHistoryAPI struct {
+	entries []leadershiphistory.Entry
+	err     error
+
+	application string
+	limit       int
+}
+
+func (m *mockLeadershipHistoryAPI) Close() error {
+	return nil
+}
+
+func (m *mockLeadershipHistoryAPI) LeadershipHistory(application string, limit int) ([]leadershiphistory.Entry, error) {
+	m.application = application
+	m.limit = limit
+	return m.entries, m.err
+}
@@ -63,10 +63,11 @@ func newRefreshCommand() *refreshCommand {
 		ModelConfigClient: func(api base.APICallCloser) ModelConfigClient {
 			return modelconfig.NewClient(api)
 		},
-		NewCharmHubClient: func(url string) (store.DownloadBundleClient, error) {
+		NewCharmHubClient: func(url string, caCerts []string) (store.DownloadBundleClient, error) {
 			return charmhub.NewClient(charmhub.Config{
-				URL:    url,
-				Logger: logger,
+				URL:            url,
+				Logger:         logger,
+				CACertificates: caCerts,
 			})
 		},
 		NewCharmResolver: func(apiRoot base.APICallCloser, downloadClient store.DownloadBundleClient) CharmResolver {
@@ -120,7 +121,7 @@ type refreshCommand struct {
 	NewResourceLister     func(base.APICallCloser) (utils.ResourceLister, error)
 	NewSpacesClient       func(base.APICallCloser) SpacesAPI
 	ModelConfigClient     func(base.APICallCloser) ModelConfigClient
-	NewCharmHubClient     func(string) (store.DownloadBundleClient, error)
+	NewCharmHubClient     func(string, []string) (store.DownloadBundleClient, error)
 	NewRefresherFactory   func(refresher.RefresherDependencies) refresher.RefresherFactory
 
 	ApplicationName string
@@ -666,7 +667,7 @@ func (c *refreshCommand) getRefresherFactory(apiRoot api.Connection) (refresher.
 		return nil, errors.Trace(err)
 	}
 
-	downloadClient, err := c.NewCharmHubClient(charmHubURL)
+	downloadClient, err := c.NewCharmHubClient(charmHubURL, controllerCACerts(c.ClientStore(), c.ControllerName))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
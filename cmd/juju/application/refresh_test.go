@@ -245,7 +245,7 @@ func (s *BaseRefreshSuite) refreshCommand() cmd.Command {
 			s.AddCall("ModelConfigClient", conn)
 			return &s.modelConfigGetter
 		},
-		func(curl string) (store.DownloadBundleClient, error) {
+		func(curl string, _ []string) (store.DownloadBundleClient, error) {
 			s.AddCall("NewCharmHubClient", curl)
 			return &s.downloadBundleClient, nil
 		},
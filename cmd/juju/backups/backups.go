@@ -27,6 +27,14 @@ type APIClient interface {
 	Create(notes string, noDownload bool) (*params.BackupsMetadataResult, error)
 	// Download pulls the backup archive file.
 	Download(filename string) (io.ReadCloser, error)
+	// List returns the metadata of the backups stored on the controller.
+	List() ([]params.BackupsMetadataResult, error)
+	// Remove removes the backups with the given filenames.
+	Remove(filenames ...string) ([]params.ErrorResult, error)
+	// Upload sends a local backup archive to the controller.
+	Upload(archive io.Reader) (string, error)
+	// Restore loads a stored backup onto the controller.
+	Restore(filename string) (*params.BackupsMetadataResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -38,3 +38,33 @@ func NewDownloadCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Down
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c), &DownloadCommand{c}
 }
+
+type ListCommand struct {
+	*listCommand
+}
+
+type RemoveCommand struct {
+	*removeCommand
+}
+
+type RestoreCommand struct {
+	*restoreCommand
+}
+
+func NewListCommandForTest(store jujuclient.ClientStore) (cmd.Command, *ListCommand) {
+	c := &listCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &ListCommand{c}
+}
+
+func NewRemoveCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RemoveCommand) {
+	c := &removeCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RemoveCommand{c}
+}
+
+func NewRestoreCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RestoreCommand) {
+	c := &restoreCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &RestoreCommand{c}
+}
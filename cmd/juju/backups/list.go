@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/rpc/params"
+)
+
+const listDoc = `
+backups lists the backup archives stored on the controller.
+
+The filenames shown can be passed to download-backup, remove-backup
+and restore-backup.
+`
+
+const listExamples = `
+    juju backups
+    juju backups --format yaml
+`
+
+// NewListCommand returns a command used to list stored backups.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand is the sub-command for listing stored backups.
+type listCommand struct {
+	CommandBase
+	out cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "backups",
+		Aliases:  []string{"list-backups"},
+		Purpose:  "List the backup archives stored on the controller.",
+		Doc:      listDoc,
+		Examples: listExamples,
+		SeeAlso: []string{
+			"create-backup",
+			"download-backup",
+			"remove-backup",
+			"restore-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatBackupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// storedBackup is the output form of a stored backup.
+type storedBackup struct {
+	Filename       string    `json:"filename" yaml:"filename"`
+	Started        time.Time `json:"started" yaml:"started"`
+	Size           int64     `json:"size" yaml:"size"`
+	ControllerUUID string    `json:"controller-uuid" yaml:"controller-uuid"`
+	Version        string    `json:"juju-version" yaml:"juju-version"`
+	HANodes        int64     `json:"ha-nodes,omitempty" yaml:"ha-nodes,omitempty"`
+	Notes          string    `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No backups stored on the controller.")
+		return nil
+	}
+	return c.out.Write(ctx, toStoredBackups(results))
+}
+
+func toStoredBackups(results []params.BackupsMetadataResult) []storedBackup {
+	backups := make([]storedBackup, len(results))
+	for i, result := range results {
+		backups[i] = storedBackup{
+			Filename:       result.Filename,
+			Started:        result.Started,
+			Size:           result.Size,
+			ControllerUUID: result.ControllerUUID,
+			Version:        result.Version.String(),
+			HANodes:        result.HANodes,
+			Notes:          result.Notes,
+		}
+	}
+	return backups
+}
+
+func formatBackupsTabular(writer io.Writer, value interface{}) error {
+	backups, ok := value.([]storedBackup)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", backups, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Filename\tStarted\tSize\tVersion\tNotes")
+	for _, b := range backups {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+			b.Filename, b.Started.UTC().Format(time.RFC3339), b.Size, b.Version, b.Notes)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+type listSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, _ = backups.NewListCommandForTest(s.store)
+}
+
+func (s *listSuite) setList() *fakeAPIClient {
+	client := s.setSuccess()
+	client.list = []params.BackupsMetadataResult{{
+		Filename:       "/backups/juju-backup-20240101-000000.tar.gz",
+		Started:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Size:           1024,
+		ControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Version:        version.MustParse("3.4.0"),
+		Notes:          "nightly",
+	}}
+	return client
+}
+
+func (s *listSuite) TestList(c *gc.C) {
+	client := s.setList()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "List")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Filename                                     Started               Size  Version  Notes
+/backups/juju-backup-20240101-000000.tar.gz  2024-01-01T00:00:00Z  1024  3.4.0    nightly
+`[1:])
+}
+
+func (s *listSuite) TestListYAML(c *gc.C) {
+	s.setList()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- filename: /backups/juju-backup-20240101-000000.tar.gz
+  started: 2024-01-01T00:00:00Z
+  size: 1024
+  controller-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  juju-version: 3.4.0
+  notes: nightly
+`[1:])
+}
+
+func (s *listSuite) TestListEmpty(c *gc.C) {
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No backups stored on the controller.\n")
+}
+
+func (s *listSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
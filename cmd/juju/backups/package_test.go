@@ -163,6 +163,9 @@ func (s *BaseBackupsSuite) checkArchive(c *gc.C) {
 type fakeAPIClient struct {
 	metaresult *params.BackupsMetadataResult
 	archive    io.ReadCloser
+	list       []params.BackupsMetadataResult
+	removed    []params.ErrorResult
+	uploaded   string
 	err        error
 
 	calls []string
@@ -206,6 +209,49 @@ func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	return c.archive, nil
 }
 
+func (c *fakeAPIClient) List() ([]params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "List")
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.list, nil
+}
+
+func (c *fakeAPIClient) Remove(filenames ...string) ([]params.ErrorResult, error) {
+	c.calls = append(c.calls, "Remove")
+	c.args = append(c.args, filenames...)
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.removed != nil {
+		return c.removed, nil
+	}
+	return make([]params.ErrorResult, len(filenames)), nil
+}
+
+func (c *fakeAPIClient) Upload(archive io.Reader) (string, error) {
+	c.calls = append(c.calls, "Upload")
+	data, err := io.ReadAll(archive)
+	if err != nil {
+		return "", err
+	}
+	c.args = append(c.args, string(data))
+	if c.err != nil {
+		return "", c.err
+	}
+	return c.uploaded, nil
+}
+
+func (c *fakeAPIClient) Restore(filename string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "Restore")
+	c.args = append(c.args, filename)
+	c.idArg = filename
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeDoc = `
+remove-backup removes backup archives stored on the controller.
+
+The filenames are those shown by the backups command.
+`
+
+const removeExamples = `
+    juju remove-backup /var/lib/juju/backups/juju-backup-20240101-000000.tar.gz
+`
+
+// NewRemoveCommand returns a command used to remove stored backups.
+func NewRemoveCommand() cmd.Command {
+	return modelcmd.Wrap(&removeCommand{})
+}
+
+// removeCommand is the sub-command for removing stored backups.
+type removeCommand struct {
+	CommandBase
+	// Filenames are the stored backups to remove.
+	Filenames []string
+}
+
+// Info implements Command.Info.
+func (c *removeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-backup",
+		Args:     "<filename> [<filename>...]",
+		Purpose:  "Remove backup archives stored on the controller.",
+		Doc:      removeDoc,
+		Examples: removeExamples,
+		SeeAlso: []string{
+			"backups",
+			"create-backup",
+		},
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	c.Filenames = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.Remove(c.Filenames...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "cannot remove %s: %v\n", c.Filenames[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("removed %s", c.Filenames[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/rpc/params"
+)
+
+type removeSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+}
+
+var _ = gc.Suite(&removeSuite{})
+
+func (s *removeSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, _ = backups.NewRemoveCommandForTest(s.store)
+}
+
+func (s *removeSuite) TestRemove(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "one.tar.gz", "two.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Remove")
+	client.CheckArgs(c, "one.tar.gz", "two.tar.gz")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removed one.tar.gz\nremoved two.tar.gz\n")
+}
+
+func (s *removeSuite) TestRemovePartialFailure(c *gc.C) {
+	client := s.setSuccess()
+	client.removed = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `backup file "two.tar.gz" not found`}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "one.tar.gz", "two.tar.gz")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+removed one.tar.gz
+cannot remove two.tar.gz: backup file "two.tar.gz" not found
+`[1:])
+}
+
+func (s *removeSuite) TestMissingFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
+
+func (s *removeSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "one.tar.gz")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
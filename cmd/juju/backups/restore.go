@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const restoreDoc = `
+restore-backup loads a backup archive onto the controller, replacing the
+contents of the controller's databases with those in the backup.
+
+The backup is either one stored on the controller, named by its filename
+as shown by the backups command, or a local archive passed with --file,
+which is uploaded to the controller first.
+
+Restoring a backup rolls the controller back to the state it was in when
+the backup was taken. Backups can only be restored to the controller
+machine they were taken from, which must be the only controller node
+and run the same version of juju as when the backup was taken. Remove
+any other controller nodes first; HA can be enabled again once the
+restore has completed.
+
+If the restore fails part way, the databases are rolled back to their
+state before the restore started. Once the backup has been restored, the
+controller agent restarts so that it picks up the restored state.
+`
+
+const restoreExamples = `
+    juju restore-backup /var/lib/juju/backups/juju-backup-20240101-000000.tar.gz
+    juju restore-backup --file juju-backup-20240101-000000.tar.gz
+`
+
+// NewRestoreCommand returns a command used to restore backups.
+func NewRestoreCommand() cmd.Command {
+	return modelcmd.Wrap(&restoreCommand{})
+}
+
+// restoreCommand is the sub-command for restoring a backup.
+type restoreCommand struct {
+	CommandBase
+	// RemoteFilename is the stored backup to restore.
+	RemoteFilename string
+	// LocalFilename is the local archive to upload and restore.
+	LocalFilename string
+	// AssumeYes means the user isn't prompted for confirmation.
+	AssumeYes bool
+}
+
+// Info implements Command.Info.
+func (c *restoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "restore-backup",
+		Args:     "[<filename>]",
+		Purpose:  "Restore a backup archive to the controller.",
+		Doc:      restoreDoc,
+		Examples: restoreExamples,
+		SeeAlso: []string{
+			"backups",
+			"create-backup",
+			"download-backup",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.LocalFilename, "file", "", "Upload and restore this local backup archive")
+	f.BoolVar(&c.AssumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.AssumeYes, "yes", false, "")
+}
+
+// Init implements Command.Init.
+func (c *restoreCommand) Init(args []string) error {
+	if err := c.CommandBase.Init(args); err != nil {
+		return err
+	}
+	filename, err := cmd.ZeroOrOneArgs(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if filename == "" && c.LocalFilename == "" {
+		return errors.New("missing filename")
+	}
+	if filename != "" && c.LocalFilename != "" {
+		return errors.New("cannot specify both a filename and --file")
+	}
+	c.RemoteFilename = filename
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.AssumeYes {
+		fmt.Fprintln(ctx.Stderr, "Restoring a backup replaces the contents of the controller's databases.")
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "restore-backup")
+		}
+	}
+
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	filename := c.RemoteFilename
+	if c.LocalFilename != "" {
+		archive, err := c.Filesystem().Open(c.LocalFilename)
+		if err != nil {
+			return errors.Annotate(err, "while opening local archive file")
+		}
+		defer archive.Close()
+
+		if filename, err = client.Upload(archive); err != nil {
+			return errors.Annotate(err, "while uploading backup archive")
+		}
+		ctx.Infof("Uploaded backup archive to %s", filename)
+	}
+
+	result, err := client.Restore(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.quiet {
+		fmt.Fprintln(ctx.Stdout, c.metadata(result))
+	}
+	ctx.Infof("Restored backup %s.\nThe controller agent is restarting to use the restored state.", filename)
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/backups"
+)
+
+type restoreSuite struct {
+	BaseBackupsSuite
+	wrappedCommand cmd.Command
+}
+
+var _ = gc.Suite(&restoreSuite{})
+
+func (s *restoreSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.wrappedCommand, _ = backups.NewRestoreCommandForTest(s.store)
+}
+
+func (s *restoreSuite) TestRestoreStored(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "backup-filename", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	client.Check(c, "backup-filename", "", "Restore")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, MetaResultString)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Restored backup backup-filename.
+The controller agent is restarting to use the restored state.
+`[1:])
+}
+
+func (s *restoreSuite) TestRestoreLocalFile(c *gc.C) {
+	client := s.setSuccess()
+	client.uploaded = "uploaded-filename"
+	archive := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := os.WriteFile(archive, []byte(s.data), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--file", archive, "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	client.CheckCalls(c, "Upload", "Restore")
+	client.CheckArgs(c, s.data, "uploaded-filename")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "Uploaded backup archive to uploaded-filename\n")
+}
+
+func (s *restoreSuite) TestRestorePrompt(c *gc.C) {
+	client := s.setSuccess()
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("n\n")
+	err := cmdtesting.InitCommand(s.wrappedCommand, []string{"backup-filename"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wrappedCommand.Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "restore-backup: aborted")
+	client.CheckCalls(c)
+}
+
+func (s *restoreSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
+	c.Check(err, gc.ErrorMatches, "missing filename")
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "backup-filename", "--file", "backup.tar.gz")
+	c.Check(err, gc.ErrorMatches, "cannot specify both a filename and --file")
+}
+
+func (s *restoreSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "backup-filename", "-y")
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
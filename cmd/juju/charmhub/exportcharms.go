@@ -0,0 +1,666 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output/progress"
+	"github.com/juju/juju/core/arch"
+	corebase "github.com/juju/juju/core/base"
+	corecharm "github.com/juju/juju/core/charm"
+	"github.com/juju/juju/version"
+)
+
+const (
+	exportCharmsSummary = "Exports CharmHub charms and bundles for use by a disconnected controller."
+	exportCharmsDoc     = `
+Download charms and bundles, along with every resource they use, from
+CharmHub into a directory that can be uploaded to the charmhub mirror
+of a controller that cannot reach CharmHub, using import-charms.
+
+Each argument is either the name of a charm or bundle on CharmHub, or
+the path to a file. A file is either a bundle, as accepted by deploy, or
+a list of charms in the following form:
+
+    charms:
+      - name: postgresql
+        channel: 14/stable
+        bases: [ubuntu@22.04]
+      - name: ubuntu
+        revision: 24
+
+Charms named on the command line, and those in a list without a channel
+or bases, are resolved using --channel and --base. Every charm used by
+a bundle is resolved using the channel, base and revision given for its
+application in the bundle, and the resource revisions the bundle pins.
+Charms and resources local to the machine are not exported.
+
+The directory holds a manifest.json describing every charm, bundle,
+revision, resource and channel it contains, the archives and resources
+themselves under blobs/, named by SHA384 hash, and a SHA256SUMS file
+that can be checked with sha256sum(1). Exporting again into the same
+directory adds to the export, and only downloads what it does not
+already hold.
+
+The content of OCI image resources is the location of the image, not
+the image itself, which must be made available to the disconnected
+controller's models from a registry they can reach.
+`
+
+	exportCharmsExamples = `
+    juju export-charms postgresql --channel 14/stable
+    juju export-charms kubeflow --channel 1.8/stable --dir ./kubeflow-export
+    juju export-charms ./bundle.yaml ./charms.yaml --base ubuntu@22.04
+`
+
+	// defaultExportDir is the directory exports are written to when no
+	// --dir is given.
+	defaultExportDir = "charmhub-export"
+)
+
+// NewExportCharmsCommand wraps exportCharmsCommand with sane model settings.
+//
+// The command syncs charms from CharmHub into an export directory. It is
+// named export-charms rather than "charmhub sync" because juju commands
+// are not nested, and so that it pairs with import-charms, which uploads
+// the export to a controller.
+func NewExportCharmsCommand() cmd.Command {
+	return &exportCharmsCommand{
+		charmHubCommand: newCharmHubCommand(),
+	}
+}
+
+// exportCharmsCommand supplies the "export-charms" CLI command used for
+// exporting charms, bundles and resources for an offline charmhub mirror.
+type exportCharmsCommand struct {
+	*charmHubCommand
+
+	channel    string
+	dir        string
+	noProgress bool
+	args       []string
+}
+
+// Info returns help related info about the command, it implements
+// part of the cmd.Command interface.
+func (c *exportCharmsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "export-charms",
+		Args:     "[options] <charm|bundle|file> ...",
+		Purpose:  exportCharmsSummary,
+		Doc:      exportCharmsDoc,
+		Examples: exportCharmsExamples,
+		SeeAlso: []string{
+			"download",
+			"import-charms",
+			"info",
+		},
+	})
+}
+
+// SetFlags defines flags which can be used with the export-charms command.
+// It implements part of the cmd.Command interface.
+func (c *exportCharmsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.charmHubCommand.SetFlags(f)
+
+	f.StringVar(&c.arch, "arch", ArchAll, fmt.Sprintf("specify an arch <%s>", c.archArgumentList()))
+	f.StringVar(&c.base, "base", "", "specify the base of charms without one")
+	f.StringVar(&c.channel, "channel", "", "specify the channel of charms without one")
+	f.StringVar(&c.dir, "dir", defaultExportDir, "directory to export to")
+	f.BoolVar(&c.noProgress, "no-progress", false, "disable the progress bar")
+}
+
+// Init initializes the export-charms command, including validating the
+// provided flags. It implements part of the cmd.Command interface.
+func (c *exportCharmsCommand) Init(args []string) error {
+	if err := c.charmHubCommand.Init(args); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return errors.Errorf("expected a charm, bundle or file to export")
+	}
+	if c.dir == "" {
+		return errors.Errorf("expected a directory to export to")
+	}
+	if c.base != "" {
+		if _, err := corebase.ParseBaseFromString(c.base); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.channel != "" {
+		if _, err := charm.ParseChannelNormalize(c.channel); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	c.args = args
+	return nil
+}
+
+// exportRequest identifies a charm or bundle to export.
+type exportRequest struct {
+	Name      string         `yaml:"name"`
+	Channel   string         `yaml:"channel,omitempty"`
+	Bases     []string       `yaml:"bases,omitempty"`
+	Revision  *int           `yaml:"revision,omitempty"`
+	Resources map[string]int `yaml:"resources,omitempty"`
+}
+
+// exportList is the content of a file listing charms to export.
+type exportList struct {
+	Charms []exportRequest `yaml:"charms"`
+}
+
+// Run is the business logic of the export-charms command. It implements
+// the meaty part of the cmd.Command interface.
+func (c *exportCharmsCommand) Run(cmdContext *cmd.Context) error {
+	dir := cmdContext.AbsPath(c.dir)
+	existing, err := mirror.ReadDir(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, mirror.BlobsDir), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	downloadDir, err := os.MkdirTemp(dir, ".download-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(downloadDir) }()
+
+	client, err := c.CharmHubClientFunc(charmhub.Config{
+		URL:    c.charmHubURL,
+		Logger: downloadLogger{Context: cmdContext},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	e := &exporter{
+		cmdContext:  cmdContext,
+		client:      client,
+		dir:         dir,
+		downloadDir: downloadDir,
+		noProgress:  c.noProgress,
+		arch:        c.arch,
+		exported: mirror.Manifest{
+			Version: mirror.ManifestVersion,
+			Created: time.Now().UTC(),
+		},
+		done: make(map[string]bool),
+	}
+	if e.arch == ArchAll {
+		e.arch = arch.DefaultArchitecture
+	}
+	defaultBase := version.DefaultSupportedLTSBase()
+	if c.base != "" {
+		if defaultBase, err = corebase.ParseBaseFromString(c.base); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	defaultChannel := c.channel
+	if defaultChannel == "" {
+		defaultChannel = corecharm.DefaultChannelString
+	}
+
+	for _, arg := range c.args {
+		requests, err := c.requests(cmdContext, arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, req := range requests {
+			if err := e.export(ctx, req, defaultChannel, defaultBase); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	if err := mirror.WriteDir(dir, existing.Merge(e.exported)); err != nil {
+		return errors.Annotate(err, "writing export manifest")
+	}
+
+	cmdContext.Infof(`
+Exported %d charms and bundles to %q. Upload them to a controller with:
+    juju import-charms %s`[1:], len(e.exported.Entities), dir, dir)
+	return nil
+}
+
+// requests returns the charms and bundles to export for an argument,
+// which is either a charm or bundle name, or the path to a bundle or a
+// list of charms.
+func (c *exportCharmsCommand) requests(cmdContext *cmd.Context, arg string) ([]exportRequest, error) {
+	path := cmdContext.AbsPath(arg)
+	if _, err := os.Stat(path); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(arg)
+		if err != nil {
+			logger.Debugf("%s", err)
+			return nil, errors.NotValidf("charm or bundle name, %q, is", arg)
+		}
+		if !charm.CharmHub.Matches(curl.Schema) {
+			return nil, errors.Errorf("%q is not a Charmhub charm", arg)
+		}
+		return []exportRequest{{Name: curl.Name}}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var list exportList
+	if err := yaml.Unmarshal(data, &list); err == nil && len(list.Charms) > 0 {
+		for _, req := range list.Charms {
+			if req.Name == "" {
+				return nil, errors.NotValidf("charm without a name in %q", arg)
+			}
+		}
+		return list.Charms, nil
+	}
+
+	bundleData, err := charm.ReadBundleData(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, errors.Annotatef(err, "%q is neither a list of charms nor a bundle", arg)
+	}
+	return bundleRequests(cmdContext, bundleData)
+}
+
+// bundleRequests returns the charms to export for the applications of a
+// bundle.
+func bundleRequests(cmdContext *cmd.Context, data *charm.BundleData) ([]exportRequest, error) {
+	defaultBase := data.DefaultBase
+	if defaultBase == "" && data.Series != "" {
+		base, err := corebase.GetBaseFromSeries(data.Series)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defaultBase = base.String()
+	}
+
+	names := make([]string, 0, len(data.Applications))
+	for name := range data.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var requests []exportRequest
+	for _, appName := range names {
+		app := data.Applications[appName]
+		if app == nil {
+			continue
+		}
+		if charm.IsValidLocalCharmOrBundlePath(app.Charm) {
+			cmdContext.Warningf("skipping local charm %q of application %q", app.Charm, appName)
+			continue
+		}
+		curl, err := charm.ParseURL(app.Charm)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", appName)
+		}
+		if !charm.CharmHub.Matches(curl.Schema) {
+			cmdContext.Warningf("skipping charm %q of application %q, which is not a Charmhub charm", app.Charm, appName)
+			continue
+		}
+
+		// As with deploy, applications without a channel use the default
+		// channel rather than the one the bundle was resolved from.
+		req := exportRequest{
+			Name:     curl.Name,
+			Channel:  app.Channel,
+			Revision: app.Revision,
+		}
+		if req.Channel == "" {
+			req.Channel = corecharm.DefaultChannelString
+		}
+		if req.Revision == nil && curl.Revision >= 0 {
+			revision := curl.Revision
+			req.Revision = &revision
+		}
+
+		base := app.Base
+		if base == "" && app.Series != "" {
+			b, err := corebase.GetBaseFromSeries(app.Series)
+			if err != nil {
+				return nil, errors.Annotatef(err, "application %q", appName)
+			}
+			base = b.String()
+		}
+		if base == "" {
+			base = defaultBase
+		}
+		if base != "" {
+			req.Bases = []string{base}
+		}
+
+		for resName, value := range app.Resources {
+			// Resource revisions are pinned by number, anything else is
+			// a local file or image that is not on Charmhub.
+			revision, ok := value.(int)
+			if !ok {
+				cmdContext.Warningf("skipping local resource %q of application %q", resName, appName)
+				continue
+			}
+			if req.Resources == nil {
+				req.Resources = make(map[string]int)
+			}
+			req.Resources[resName] = revision
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// exporter downloads charms, bundles and resources into an export
+// directory, and records them in a manifest.
+type exporter struct {
+	cmdContext  *cmd.Context
+	client      CharmHubClient
+	dir         string
+	downloadDir string
+	noProgress  bool
+	arch        string
+
+	exported mirror.Manifest
+
+	// done records the requests that have already been exported, so that
+	// charms shared by several bundles are only resolved once.
+	done map[string]bool
+}
+
+func (e *exporter) export(ctx context.Context, req exportRequest, defaultChannel string, defaultBase corebase.Base) error {
+	channel := req.Channel
+	if channel == "" {
+		channel = defaultChannel
+	}
+	normChannel, err := charm.ParseChannelNormalize(channel)
+	if err != nil {
+		return errors.Annotatef(err, "charm %q", req.Name)
+	}
+
+	bases := []corebase.Base{defaultBase}
+	if len(req.Bases) > 0 {
+		bases = nil
+		for _, b := range req.Bases {
+			base, err := corebase.ParseBaseFromString(b)
+			if err != nil {
+				return errors.Annotatef(err, "charm %q", req.Name)
+			}
+			bases = append(bases, base)
+		}
+	}
+
+	for _, base := range bases {
+		if err := e.exportOne(ctx, req, normChannel, base); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportOne(ctx context.Context, req exportRequest, channel charm.Channel, base corebase.Base) error {
+	refreshBase := charmhub.RefreshBase{
+		Architecture: e.arch,
+		Name:         base.OS,
+		Channel:      base.Channel.Track,
+	}
+
+	var (
+		key           string
+		refreshConfig charmhub.RefreshConfig
+		err           error
+	)
+	if req.Revision != nil {
+		key = fmt.Sprintf("%s/%d", req.Name, *req.Revision)
+		if refreshConfig, err = charmhub.InstallOneFromRevision(req.Name, *req.Revision); err != nil {
+			return errors.Trace(err)
+		}
+		for name, revision := range req.Resources {
+			withResource, ok := charmhub.AddResource(refreshConfig, name, revision)
+			if !ok {
+				return errors.Errorf("cannot pin resource %q of %q to revision %d", name, req.Name, revision)
+			}
+			refreshConfig = withResource
+		}
+	} else {
+		key = fmt.Sprintf("%s/%s/%s/%s", req.Name, channel, e.arch, base)
+		refreshConfig, err = charmhub.InstallOneFromChannel(req.Name, channel.String(), refreshBase)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if e.done[key] {
+		return nil
+	}
+	e.done[key] = true
+
+	results, err := e.client.Refresh(ctx, refreshConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		return errors.NotFoundf(req.Name)
+	}
+	result := results[0]
+	if result.Error != nil {
+		return errors.Errorf("unable to locate %s: %s", req.Name, result.Error.Message)
+	}
+	entity := result.Entity
+
+	if req.Revision == nil {
+		e.cmdContext.Infof("Exporting %s %q revision %d from channel %q for base %q",
+			entity.Type, entity.Name, entity.Revision, result.EffectiveChannel, base.DisplayString())
+	} else {
+		e.cmdContext.Infof("Exporting %s %q revision %d", entity.Type, entity.Name, entity.Revision)
+	}
+
+	archive, bundle, err := e.downloadArchive(ctx, entity)
+	if err != nil {
+		return errors.Annotatef(err, "downloading %s %q", entity.Type, entity.Name)
+	}
+	rev := mirror.Revision{
+		Revision:     entity.Revision,
+		Version:      entity.Version,
+		CreatedAt:    entity.CreatedAt,
+		Bases:        entity.Bases,
+		Archive:      archive,
+		MetadataYAML: entity.MetadataYAML,
+		ConfigYAML:   entity.ConfigYAML,
+	}
+	if bundle != nil {
+		bundleYAML, err := yaml.Marshal(bundle.Data())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rev.BundleYAML = string(bundleYAML)
+	}
+	for _, res := range entity.Resources {
+		resource, err := e.downloadResource(ctx, res)
+		if err != nil {
+			return errors.Annotatef(err, "downloading resource %q of %q", res.Name, entity.Name)
+		}
+		rev.Resources = append(rev.Resources, resource)
+	}
+
+	exported := mirror.Entity{
+		Type:      entity.Type,
+		ID:        entity.ID,
+		Name:      entity.Name,
+		Summary:   entity.Summary,
+		Publisher: entity.Publisher,
+		License:   entity.License,
+		Revisions: []mirror.Revision{rev},
+	}
+	// Only releases resolved from a channel are recorded against it; a
+	// pinned revision need not be the one released to any channel.
+	if req.Revision == nil {
+		effective, err := charm.ParseChannelNormalize(result.EffectiveChannel)
+		if err != nil {
+			effective = channel
+		}
+		exported.Channels = []mirror.Channel{{
+			Track:      effective.Track,
+			Risk:       string(effective.Risk),
+			Base:       transport.Base{Architecture: refreshBase.Architecture, Name: refreshBase.Name, Channel: refreshBase.Channel},
+			Revision:   entity.Revision,
+			ReleasedAt: result.ReleasedAt,
+		}}
+		if exported.Channels[0].Track == "" {
+			exported.Channels[0].Track = "latest"
+		}
+	}
+	e.exported = e.exported.Merge(mirror.Manifest{
+		Version:  mirror.ManifestVersion,
+		Entities: []mirror.Entity{exported},
+	})
+
+	if bundle == nil {
+		return nil
+	}
+	requests, err := bundleRequests(e.cmdContext, bundle.Data())
+	if err != nil {
+		return errors.Annotatef(err, "bundle %q", entity.Name)
+	}
+	for _, req := range requests {
+		if err := e.export(ctx, req, channel.String(), base); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// downloadArchive ensures the export holds the archive of a charm or
+// bundle, and returns the blob for it along with the bundle, for bundles.
+func (e *exporter) downloadArchive(ctx context.Context, entity transport.RefreshEntity) (mirror.Blob, charm.Bundle, error) {
+	blobPath := filepath.Join(e.dir, mirror.BlobsDir, entity.Download.HashSHA384)
+	if e.hasBlob(entity.Download) {
+		e.cmdContext.Verbosef("%s %q revision %d already exported", entity.Type, entity.Name, entity.Revision)
+		blob := mirror.Blob{
+			Path:   mirror.BlobPath(entity.Download.HashSHA384),
+			Size:   int64(entity.Download.Size),
+			SHA256: entity.Download.HashSHA256,
+			SHA384: entity.Download.HashSHA384,
+		}
+		if entity.Type != transport.BundleType {
+			return blob, nil, nil
+		}
+		bundle, err := charm.ReadBundleArchive(blobPath)
+		return blob, bundle, errors.Trace(err)
+	}
+
+	downloadURL, err := url.Parse(entity.Download.URL)
+	if err != nil {
+		return mirror.Blob{}, nil, errors.Trace(err)
+	}
+	var options []charmhub.DownloadOption
+	if !e.noProgress {
+		options = append(options, charmhub.WithProgressBar(progress.MakeProgressBar(e.cmdContext.Stdout)))
+	}
+	ctx = context.WithValue(ctx, charmhub.DownloadNameKey, entity.Name)
+
+	archivePath := filepath.Join(e.downloadDir, fmt.Sprintf("%s_r%d.%s", entity.Name, entity.Revision, entity.Type))
+	var bundle charm.Bundle
+	if entity.Type == transport.BundleType {
+		bundle, err = e.client.DownloadAndReadBundle(ctx, downloadURL, archivePath, options...)
+	} else {
+		_, err = e.client.DownloadAndRead(ctx, downloadURL, archivePath, options...)
+	}
+	if err != nil {
+		return mirror.Blob{}, nil, errors.Trace(err)
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return mirror.Blob{}, nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(archivePath)
+	}()
+	blob, err := e.addBlob(f, entity.Download)
+	if err != nil {
+		return mirror.Blob{}, nil, errors.Trace(err)
+	}
+	return blob, bundle, nil
+}
+
+// downloadResource ensures the export holds the content of a resource
+// revision, and returns the resource.
+func (e *exporter) downloadResource(ctx context.Context, res transport.ResourceRevision) (mirror.Resource, error) {
+	resource := mirror.Resource{
+		Name:        res.Name,
+		Type:        res.Type,
+		Revision:    res.Revision,
+		Filename:    res.Filename,
+		Description: res.Description,
+	}
+	if e.hasBlob(res.Download) {
+		resource.Blob = mirror.Blob{
+			Path:   mirror.BlobPath(res.Download.HashSHA384),
+			Size:   int64(res.Download.Size),
+			SHA256: res.Download.HashSHA256,
+			SHA384: res.Download.HashSHA384,
+		}
+		return resource, nil
+	}
+
+	downloadURL, err := url.Parse(res.Download.URL)
+	if err != nil {
+		return mirror.Resource{}, errors.Trace(err)
+	}
+	r, err := e.client.DownloadResource(ctx, downloadURL)
+	if err != nil {
+		return mirror.Resource{}, errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if resource.Blob, err = e.addBlob(r, res.Download); err != nil {
+		return mirror.Resource{}, errors.Trace(err)
+	}
+	return resource, nil
+}
+
+// hasBlob reports whether the export already holds the download.
+func (e *exporter) hasBlob(download transport.Download) bool {
+	if download.HashSHA384 == "" {
+		return false
+	}
+	info, err := os.Stat(filepath.Join(e.dir, mirror.BlobsDir, download.HashSHA384))
+	return err == nil && info.Size() == int64(download.Size)
+}
+
+// addBlob adds the content read from r to the export, provided that it
+// matches the checksums Charmhub gave for the download.
+func (e *exporter) addBlob(r io.Reader, download transport.Download) (mirror.Blob, error) {
+	blob, err := mirror.AddBlob(e.dir, r, func(blob mirror.Blob) error {
+		if blob.SHA256 != download.HashSHA256 || (download.HashSHA384 != "" && blob.SHA384 != download.HashSHA384) {
+			return errors.Errorf(`Checksum of download failed:
+Expected:   %s
+Calculated: %s`, download.HashSHA256, blob.SHA256)
+		}
+		return nil
+	})
+	return blob, errors.Trace(err)
+}
@@ -0,0 +1,357 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v12"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/cmd/juju/charmhub/mocks"
+	"github.com/juju/juju/core/arch"
+	"github.com/juju/juju/testing"
+)
+
+type exportCharmsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	charmHubAPI *mocks.MockCharmHubClient
+	dir         string
+}
+
+var _ = gc.Suite(&exportCharmsSuite{})
+
+func (s *exportCharmsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "export")
+}
+
+func (s *exportCharmsSuite) setUpMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.charmHubAPI = mocks.NewMockCharmHubClient(ctrl)
+	return ctrl
+}
+
+func (s *exportCharmsSuite) newCommand() *exportCharmsCommand {
+	return &exportCharmsCommand{
+		charmHubCommand: &charmHubCommand{
+			arches: arch.AllArches(),
+			CharmHubClientFunc: func(charmhub.Config) (CharmHubClient, error) {
+				return s.charmHubAPI, nil
+			},
+		},
+	}
+}
+
+func (s *exportCharmsSuite) run(c *gc.C, args ...string) (string, error) {
+	command := s.newCommand()
+	err := cmdtesting.InitCommand(command, append([]string{"--no-progress", "--dir", s.dir}, args...))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := commandContextForTest(c)
+	err = command.Run(ctx)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func download(content, downloadURL string) transport.Download {
+	return transport.Download{
+		HashSHA256: fmt.Sprintf("%x", sha256.Sum256([]byte(content))),
+		HashSHA384: fmt.Sprintf("%x", sha512.Sum384([]byte(content))),
+		Size:       len(content),
+		URL:        downloadURL,
+	}
+}
+
+func blob(content string) mirror.Blob {
+	d := download(content, "")
+	return mirror.Blob{
+		Path:   mirror.BlobPath(d.HashSHA384),
+		Size:   int64(d.Size),
+		SHA256: d.HashSHA256,
+		SHA384: d.HashSHA384,
+	}
+}
+
+// expectRefresh expects a refresh for the named charm or bundle, checking
+// the action built for it, and returns the given entity.
+func (s *exportCharmsSuite) expectRefresh(c *gc.C, check func(transport.RefreshRequestAction), entity transport.RefreshEntity) {
+	s.charmHubAPI.EXPECT().Refresh(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, cfg charmhub.RefreshConfig) ([]transport.RefreshResponse, error) {
+			req, err := cfg.Build()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(req.Actions, gc.HasLen, 1)
+			c.Check(*req.Actions[0].Name, gc.Equals, entity.Name)
+			check(req.Actions[0])
+			return []transport.RefreshResponse{{
+				InstanceKey:      req.Actions[0].InstanceKey,
+				Entity:           entity,
+				EffectiveChannel: "latest/stable",
+			}}, nil
+		})
+}
+
+func (s *exportCharmsSuite) expectDownloadCharm(c *gc.C, downloadURL, content string) {
+	u, err := url.Parse(downloadURL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmHubAPI.EXPECT().DownloadAndRead(gomock.Any(), u, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *url.URL, path string, _ ...charmhub.DownloadOption) (*charm.CharmArchive, error) {
+			return nil, os.WriteFile(path, []byte(content), 0644)
+		})
+}
+
+func (s *exportCharmsSuite) expectDownloadResource(c *gc.C, downloadURL, content string) {
+	u, err := url.Parse(downloadURL)
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmHubAPI.EXPECT().DownloadResource(gomock.Any(), u).Return(io.NopCloser(strings.NewReader(content)), nil)
+}
+
+func postgresql() transport.RefreshEntity {
+	return transport.RefreshEntity{
+		Type:     transport.CharmType,
+		ID:       "postgresql-id",
+		Name:     "postgresql",
+		Revision: 42,
+		Summary:  "PostgreSQL",
+		Bases:    []transport.Base{{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}},
+		Download: download("postgresql charm", "https://example.com/postgresql"),
+		Resources: []transport.ResourceRevision{{
+			Name:     "image",
+			Type:     "oci-image",
+			Revision: 7,
+			Download: download("postgresql image", "https://example.com/postgresql-image"),
+		}},
+		MetadataYAML: "name: postgresql\n",
+	}
+}
+
+func (s *exportCharmsSuite) TestInitNoArgs(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "expected a charm, bundle or file to export")
+}
+
+func (s *exportCharmsSuite) TestInitInvalidBase(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"--base", "foo", "postgresql"})
+	c.Assert(err, gc.ErrorMatches, "expected base string to contain os and channel .*")
+}
+
+func (s *exportCharmsSuite) TestRunCharm(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectRefresh(c, func(action transport.RefreshRequestAction) {
+		c.Check(*action.Channel, gc.Equals, "14/edge")
+		c.Check(*action.Base, gc.Equals, transport.Base{Architecture: "arm64", Name: "ubuntu", Channel: "22.04"})
+	}, postgresql())
+	s.expectDownloadCharm(c, "https://example.com/postgresql", "postgresql charm")
+	s.expectDownloadResource(c, "https://example.com/postgresql-image", "postgresql image")
+
+	stderr, err := s.run(c, "--channel", "14/edge", "--base", "ubuntu@22.04", "--arch", "arm64", "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, fmt.Sprintf(`
+Exporting charm "postgresql" revision 42 from channel "latest/stable" for base "ubuntu@22.04"
+Exported 1 charms and bundles to %q. Upload them to a controller with:
+    juju import-charms %s
+`[1:], s.dir, s.dir))
+
+	manifest, err := mirror.VerifyDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Entities, gc.HasLen, 1)
+	entity := manifest.Entities[0]
+	c.Check(entity.Name, gc.Equals, "postgresql")
+	c.Check(entity.ID, gc.Equals, "postgresql-id")
+	c.Check(entity.Summary, gc.Equals, "PostgreSQL")
+	c.Assert(entity.Revisions, gc.HasLen, 1)
+	c.Check(entity.Revisions[0].Revision, gc.Equals, 42)
+	c.Check(entity.Revisions[0].MetadataYAML, gc.Equals, "name: postgresql\n")
+	c.Check(entity.Revisions[0].Archive, gc.Equals, blob("postgresql charm"))
+	c.Check(entity.Revisions[0].Resources, jc.DeepEquals, []mirror.Resource{{
+		Name:     "image",
+		Type:     "oci-image",
+		Revision: 7,
+		Blob:     blob("postgresql image"),
+	}})
+	c.Check(entity.Channels, jc.DeepEquals, []mirror.Channel{{
+		Track:    "latest",
+		Risk:     "stable",
+		Base:     transport.Base{Architecture: "arm64", Name: "ubuntu", Channel: "22.04"},
+		Revision: 42,
+	}})
+
+	checksums, err := os.ReadFile(filepath.Join(s.dir, mirror.ChecksumsFile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(checksums), jc.Contains, blob("postgresql charm").SHA256+"  "+blob("postgresql charm").Path+"\n")
+	c.Check(string(checksums), jc.Contains, blob("postgresql image").SHA256+"  "+blob("postgresql image").Path+"\n")
+}
+
+func (s *exportCharmsSuite) TestRunSkipsExistingBlobs(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectRefresh(c, func(transport.RefreshRequestAction) {}, postgresql())
+	s.expectDownloadCharm(c, "https://example.com/postgresql", "postgresql charm")
+	s.expectDownloadResource(c, "https://example.com/postgresql-image", "postgresql image")
+	_, err := s.run(c, "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Exporting again only resolves the charm.
+	s.expectRefresh(c, func(transport.RefreshRequestAction) {}, postgresql())
+	_, err = s.run(c, "postgresql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = mirror.VerifyDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *exportCharmsSuite) TestRunCharmList(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	list := filepath.Join(c.MkDir(), "charms.yaml")
+	err := os.WriteFile(list, []byte(`
+charms:
+  - name: postgresql
+    revision: 42
+    resources:
+      image: 7
+  - name: ubuntu
+    channel: 22.04/candidate
+    bases: [ubuntu@20.04, ubuntu@22.04]
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.expectRefresh(c, func(action transport.RefreshRequestAction) {
+		c.Check(*action.Revision, gc.Equals, 42)
+		c.Check(action.ResourceRevisions, jc.DeepEquals, []transport.RefreshResourceRevision{{Name: "image", Revision: 7}})
+	}, postgresql())
+	s.expectDownloadCharm(c, "https://example.com/postgresql", "postgresql charm")
+	s.expectDownloadResource(c, "https://example.com/postgresql-image", "postgresql image")
+
+	ubuntu := transport.RefreshEntity{
+		Type:     transport.CharmType,
+		ID:       "ubuntu-id",
+		Name:     "ubuntu",
+		Revision: 24,
+		Download: download("ubuntu charm", "https://example.com/ubuntu"),
+	}
+	for _, series := range []string{"20.04", "22.04"} {
+		series := series
+		s.expectRefresh(c, func(action transport.RefreshRequestAction) {
+			c.Check(*action.Channel, gc.Equals, "22.04/candidate")
+			c.Check(action.Base.Channel, gc.Equals, series)
+		}, ubuntu)
+	}
+	// The same revision is only downloaded once.
+	s.expectDownloadCharm(c, "https://example.com/ubuntu", "ubuntu charm")
+
+	_, err = s.run(c, list)
+	c.Assert(err, jc.ErrorIsNil)
+
+	manifest, err := mirror.VerifyDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Entities, gc.HasLen, 2)
+	// Pinned revisions are not released to any channel.
+	c.Check(manifest.Entities[0].Name, gc.Equals, "postgresql")
+	c.Check(manifest.Entities[0].Channels, gc.HasLen, 0)
+	c.Check(manifest.Entities[1].Name, gc.Equals, "ubuntu")
+	c.Check(manifest.Entities[1].Channels, gc.HasLen, 2)
+}
+
+func (s *exportCharmsSuite) TestRunBundle(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectRefresh(c, func(transport.RefreshRequestAction) {}, transport.RefreshEntity{
+		Type:     transport.BundleType,
+		ID:       "db-bundle-id",
+		Name:     "db-bundle",
+		Revision: 3,
+		Download: download("db bundle", "https://example.com/db-bundle"),
+	})
+	bundleURL, err := url.Parse("https://example.com/db-bundle")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmHubAPI.EXPECT().DownloadAndReadBundle(gomock.Any(), bundleURL, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *url.URL, path string, _ ...charmhub.DownloadOption) (charm.Bundle, error) {
+			data, err := charm.ReadBundleData(strings.NewReader(`
+default-base: ubuntu@20.04
+applications:
+  db:
+    charm: postgresql
+    channel: 14/stable
+    resources:
+      image: 7
+      config: ./config.txt
+  local:
+    charm: ./local-charm
+`))
+			c.Assert(err, jc.ErrorIsNil)
+			return &fakeBundle{data: data}, os.WriteFile(path, []byte("db bundle"), 0644)
+		})
+
+	s.expectRefresh(c, func(action transport.RefreshRequestAction) {
+		c.Check(*action.Channel, gc.Equals, "14/stable")
+		c.Check(action.Base.Channel, gc.Equals, "20.04")
+	}, postgresql())
+	s.expectDownloadCharm(c, "https://example.com/postgresql", "postgresql charm")
+	s.expectDownloadResource(c, "https://example.com/postgresql-image", "postgresql image")
+
+	_, err = s.run(c, "db-bundle")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), jc.Contains, `skipping local resource "config" of application "db"`)
+	c.Check(c.GetTestLog(), jc.Contains, `skipping local charm "./local-charm" of application "local"`)
+
+	manifest, err := mirror.VerifyDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest.Entities, gc.HasLen, 2)
+	c.Check(manifest.Entities[0].Name, gc.Equals, "db-bundle")
+	c.Check(manifest.Entities[0].Type, gc.Equals, transport.BundleType)
+	c.Check(manifest.Entities[0].Revisions[0].BundleYAML, jc.Contains, "charm: postgresql")
+	c.Check(manifest.Entities[1].Name, gc.Equals, "postgresql")
+}
+
+func (s *exportCharmsSuite) TestRunChecksumMismatch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.expectRefresh(c, func(transport.RefreshRequestAction) {}, postgresql())
+	s.expectDownloadCharm(c, "https://example.com/postgresql", "something else")
+
+	_, err := s.run(c, "postgresql")
+	c.Assert(err, gc.ErrorMatches, `(?s)downloading charm "postgresql": Checksum of download failed.*`)
+
+	_, err = os.Stat(filepath.Join(s.dir, mirror.ManifestFile))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+	// The corrupt download isn't added to the export.
+	entries, err := os.ReadDir(filepath.Join(s.dir, mirror.BlobsDir))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
+
+func (s *exportCharmsSuite) TestRunRefreshError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.charmHubAPI.EXPECT().Refresh(gomock.Any(), gomock.Any()).Return([]transport.RefreshResponse{{
+		Error: &transport.APIError{Message: "not found"},
+	}}, nil)
+
+	_, err := s.run(c, "missing")
+	c.Assert(err, gc.ErrorMatches, "unable to locate missing: not found")
+}
+
+type fakeBundle struct {
+	charm.Bundle
+	data *charm.BundleData
+}
+
+func (b *fakeBundle) Data() *charm.BundleData {
+	return b.data
+}
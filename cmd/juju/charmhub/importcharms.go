@@ -0,0 +1,174 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/client/charmhubmirror"
+	"github.com/juju/juju/charmhub/mirror"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	importCharmsSummary = "Uploads exported charms and bundles to a controller's charmhub mirror."
+	importCharmsDoc     = `
+Upload a directory written by export-charms to the charmhub mirror of
+a controller, so that models on a controller which cannot reach
+CharmHub can deploy and refresh the charms and bundles it holds.
+
+Every archive and resource in the directory is checked against the
+manifest before anything is uploaded. Content the mirror already holds
+is not stored again, so exports can be built up and imported over time.
+
+Models use the mirror by setting their charmhub-url to the mirror's
+address, which is shown once the upload completes, either when they are
+added or later with model-config. A model's charmhub-url can only be
+changed to or from the address of a controller's charmhub mirror.
+
+Only controller administrators can import charms.
+`
+
+	importCharmsExamples = `
+    juju import-charms ./charmhub-export
+    juju import-charms -c offline ./kubeflow-export
+`
+)
+
+// NewImportCharmsCommand returns a command which uploads an export to the
+// controller's charmhub mirror.
+func NewImportCharmsCommand() cmd.Command {
+	c := &importCharmsCommand{}
+	c.newAPIFunc = c.openMirrorAPI
+	return modelcmd.WrapController(c)
+}
+
+// importCharmsCommand supplies the "import-charms" CLI command used for
+// uploading charms exported by export-charms to a controller.
+type importCharmsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	dir string
+
+	newAPIFunc func() (MirrorAPI, error)
+}
+
+// MirrorAPI uploads exports to the controller's charmhub mirror.
+type MirrorAPI interface {
+	Upload(io.ReadSeeker) (charmhubmirror.UploadResult, error)
+	Close() error
+}
+
+// Info returns help related info about the command, it implements
+// part of the cmd.Command interface.
+func (c *importCharmsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "import-charms",
+		Args:     "<directory>",
+		Purpose:  importCharmsSummary,
+		Doc:      importCharmsDoc,
+		Examples: importCharmsExamples,
+		SeeAlso: []string{
+			"add-model",
+			"export-charms",
+			"model-config",
+		},
+	})
+}
+
+// Init initializes the import-charms command. It implements part of the
+// cmd.Command interface.
+func (c *importCharmsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("export directory not specified")
+	}
+	c.dir = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is the business logic of the import-charms command. It implements
+// the meaty part of the cmd.Command interface.
+func (c *importCharmsCommand) Run(ctx *cmd.Context) error {
+	dir := ctx.AbsPath(c.dir)
+	manifest, err := mirror.VerifyDir(dir)
+	if err != nil {
+		return errors.Annotatef(err, "verifying export %q", dir)
+	}
+
+	archive, err := os.CreateTemp("", "juju-charmhub-export-*.tar.gz")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+	if err := mirror.ArchiveDir(archive, dir); err != nil {
+		return errors.Annotate(err, "archiving export")
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := client.Upload(archive)
+	if err != nil {
+		return errors.Annotate(err, "uploading export")
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Imported %d charms and bundles, with %d of %d archives and resources new to controller %q.",
+		result.Entities, result.BlobsAdded, len(manifest.Blobs()), controllerName)
+
+	if mirrorURL := c.mirrorURL(controllerName); mirrorURL != "" {
+		ctx.Infof(`
+Use the charmhub mirror in a new model with:
+    juju add-model <model> --config charmhub-url=%s
+or in an existing model with:
+    juju model-config charmhub-url=%s`, mirrorURL, mirrorURL)
+	}
+	return nil
+}
+
+// mirrorURL returns the URL of the controller's charmhub mirror, or an
+// empty string if the controller's address is not known.
+func (c *importCharmsCommand) mirrorURL(controllerName string) string {
+	details, err := c.ClientStore().ControllerByName(controllerName)
+	if err != nil || len(details.APIEndpoints) == 0 {
+		logger.Debugf("cannot determine address of controller %q: %v", controllerName, err)
+		return ""
+	}
+	return fmt.Sprintf("https://%s%s", details.APIEndpoints[0], mirror.PathPrefix)
+}
+
+func (c *importCharmsCommand) openMirrorAPI() (MirrorAPI, error) {
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &mirrorClient{
+		Client: charmhubmirror.NewClient(conn),
+		Closer: conn,
+	}, nil
+}
+
+// mirrorClient closes the connection used by the charmhub mirror client.
+type mirrorClient struct {
+	*charmhubmirror.Client
+	io.Closer
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmhub
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/client/charmhubmirror"
+	"github.com/juju/juju/charmhub/mirror"
+	"github.com/juju/juju/charmhub/transport"
+	"github.com/juju/juju/cmd/juju/charmhub/mocks"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type importCharmsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	api *mocks.MockMirrorAPI
+	dir string
+}
+
+var _ = gc.Suite(&importCharmsSuite{})
+
+func (s *importCharmsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclienttesting.MinimalStore()
+	s.store.Controllers["arthur"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"10.0.0.1:17070"},
+	}
+
+	s.dir = c.MkDir()
+	archive, err := mirror.AddBlob(s.dir, strings.NewReader("foo charm"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	base := transport.Base{Architecture: "amd64", Name: "ubuntu", Channel: "22.04"}
+	err = mirror.WriteDir(s.dir, mirror.Manifest{
+		Version: mirror.ManifestVersion,
+		Entities: []mirror.Entity{{
+			Type:      transport.CharmType,
+			ID:        "foo-id",
+			Name:      "foo",
+			Revisions: []mirror.Revision{{Revision: 1, Bases: []transport.Base{base}, Archive: archive}},
+			Channels:  []mirror.Channel{{Track: "latest", Risk: "stable", Base: base, Revision: 1}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *importCharmsSuite) setUpMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.api = mocks.NewMockMirrorAPI(ctrl)
+	return ctrl
+}
+
+func (s *importCharmsSuite) runImport(c *gc.C, args ...string) (string, error) {
+	command := &importCharmsCommand{
+		newAPIFunc: func() (MirrorAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	ctx, err := cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+	if ctx == nil {
+		return "", err
+	}
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *importCharmsSuite) TestInitNoArgs(c *gc.C) {
+	_, err := s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "export directory not specified")
+}
+
+func (s *importCharmsSuite) TestRun(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	gomock.InOrder(
+		s.api.EXPECT().Upload(gomock.Any()).DoAndReturn(func(r io.ReadSeeker) (charmhubmirror.UploadResult, error) {
+			zr, err := gzip.NewReader(r)
+			c.Assert(err, jc.ErrorIsNil)
+			hdr, err := tar.NewReader(zr).Next()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Check(hdr.Name, gc.Equals, mirror.ManifestFile)
+			return charmhubmirror.UploadResult{Entities: 1, BlobsAdded: 1}, nil
+		}),
+		s.api.EXPECT().Close().Return(nil),
+	)
+
+	stderr, err := s.runImport(c, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, `
+Imported 1 charms and bundles, with 1 of 1 archives and resources new to controller "arthur".
+
+Use the charmhub mirror in a new model with:
+    juju add-model <model> --config charmhub-url=https://10.0.0.1:17070/charmhub
+or in an existing model with:
+    juju model-config charmhub-url=https://10.0.0.1:17070/charmhub
+`[1:])
+}
+
+func (s *importCharmsSuite) TestRunUploadError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	s.api.EXPECT().Upload(gomock.Any()).Return(charmhubmirror.UploadResult{}, errors.Unauthorizedf("permission denied"))
+	s.api.EXPECT().Close().Return(nil)
+
+	_, err := s.runImport(c, s.dir)
+	c.Assert(err, gc.ErrorMatches, "uploading export: permission denied")
+}
+
+func (s *importCharmsSuite) TestRunCorruptExport(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	blobs, err := os.ReadDir(filepath.Join(s.dir, mirror.BlobsDir))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blobs, gc.HasLen, 1)
+	err = os.WriteFile(filepath.Join(s.dir, mirror.BlobsDir, blobs[0].Name()), []byte("bar charm"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is uploaded.
+	_, err = s.runImport(c, s.dir)
+	c.Assert(err, gc.ErrorMatches, `verifying export ".*": ".*" checksum not valid`)
+}
@@ -5,8 +5,11 @@ package charmhub
 
 import (
 	"context"
+	"io"
 	"net/url"
 
+	"github.com/juju/charm/v12"
+
 	"github.com/juju/juju/charmhub"
 	"github.com/juju/juju/charmhub/transport"
 )
@@ -26,4 +29,7 @@ type CharmHubClient interface {
 	Find(ctx context.Context, query string, options ...charmhub.FindOption) ([]transport.FindResponse, error)
 	Refresh(context.Context, charmhub.RefreshConfig) ([]transport.RefreshResponse, error)
 	Download(ctx context.Context, resourceURL *url.URL, archivePath string, options ...charmhub.DownloadOption) error
+	DownloadAndRead(ctx context.Context, resourceURL *url.URL, archivePath string, options ...charmhub.DownloadOption) (*charm.CharmArchive, error)
+	DownloadAndReadBundle(ctx context.Context, resourceURL *url.URL, archivePath string, options ...charmhub.DownloadOption) (charm.Bundle, error)
+	DownloadResource(ctx context.Context, resourceURL *url.URL) (io.ReadCloser, error)
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/juju/juju/cmd/juju/charmhub (interfaces: CharmHubClient)
+// Source: github.com/juju/juju/cmd/juju/charmhub (interfaces: CharmHubClient,MirrorAPI)
 //
 // Generated by this command:
 //
-//	mockgen -package mocks -destination ./mocks/api_mock.go github.com/juju/juju/cmd/juju/charmhub CharmHubClient
+//	mockgen -package mocks -destination ./mocks/api_mock.go github.com/juju/juju/cmd/juju/charmhub CharmHubClient,MirrorAPI
 //
 
 // Package mocks is a generated GoMock package.
@@ -11,9 +11,12 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	url "net/url"
 	reflect "reflect"
 
+	charm "github.com/juju/charm/v12"
+	charmhubmirror "github.com/juju/juju/api/client/charmhubmirror"
 	charmhub "github.com/juju/juju/charmhub"
 	transport "github.com/juju/juju/charmhub/transport"
 	gomock "go.uber.org/mock/gomock"
@@ -61,6 +64,61 @@ func (mr *MockCharmHubClientMockRecorder) Download(arg0, arg1, arg2 any, arg3 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockCharmHubClient)(nil).Download), varargs...)
 }
 
+// DownloadAndRead mocks base method.
+func (m *MockCharmHubClient) DownloadAndRead(arg0 context.Context, arg1 *url.URL, arg2 string, arg3 ...charmhub.DownloadOption) (*charm.CharmArchive, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadAndRead", varargs...)
+	ret0, _ := ret[0].(*charm.CharmArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAndRead indicates an expected call of DownloadAndRead.
+func (mr *MockCharmHubClientMockRecorder) DownloadAndRead(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAndRead", reflect.TypeOf((*MockCharmHubClient)(nil).DownloadAndRead), varargs...)
+}
+
+// DownloadAndReadBundle mocks base method.
+func (m *MockCharmHubClient) DownloadAndReadBundle(arg0 context.Context, arg1 *url.URL, arg2 string, arg3 ...charmhub.DownloadOption) (charm.Bundle, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadAndReadBundle", varargs...)
+	ret0, _ := ret[0].(charm.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadAndReadBundle indicates an expected call of DownloadAndReadBundle.
+func (mr *MockCharmHubClientMockRecorder) DownloadAndReadBundle(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadAndReadBundle", reflect.TypeOf((*MockCharmHubClient)(nil).DownloadAndReadBundle), varargs...)
+}
+
+// DownloadResource mocks base method.
+func (m *MockCharmHubClient) DownloadResource(arg0 context.Context, arg1 *url.URL) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadResource", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadResource indicates an expected call of DownloadResource.
+func (mr *MockCharmHubClientMockRecorder) DownloadResource(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadResource", reflect.TypeOf((*MockCharmHubClient)(nil).DownloadResource), arg0, arg1)
+}
+
 // Find mocks base method.
 func (m *MockCharmHubClient) Find(arg0 context.Context, arg1 string, arg2 ...charmhub.FindOption) ([]transport.FindResponse, error) {
 	m.ctrl.T.Helper()
@@ -129,3 +187,55 @@ func (mr *MockCharmHubClientMockRecorder) URL() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockCharmHubClient)(nil).URL))
 }
+
+// MockMirrorAPI is a mock of MirrorAPI interface.
+type MockMirrorAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMirrorAPIMockRecorder
+}
+
+// MockMirrorAPIMockRecorder is the mock recorder for MockMirrorAPI.
+type MockMirrorAPIMockRecorder struct {
+	mock *MockMirrorAPI
+}
+
+// NewMockMirrorAPI creates a new mock instance.
+func NewMockMirrorAPI(ctrl *gomock.Controller) *MockMirrorAPI {
+	mock := &MockMirrorAPI{ctrl: ctrl}
+	mock.recorder = &MockMirrorAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMirrorAPI) EXPECT() *MockMirrorAPIMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMirrorAPI) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMirrorAPIMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMirrorAPI)(nil).Close))
+}
+
+// Upload mocks base method.
+func (m *MockMirrorAPI) Upload(arg0 io.ReadSeeker) (charmhubmirror.UploadResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", arg0)
+	ret0, _ := ret[0].(charmhubmirror.UploadResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockMirrorAPIMockRecorder) Upload(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockMirrorAPI)(nil).Upload), arg0)
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -57,6 +58,22 @@ with the prefix will match.
 
 The '--include-label' and '--exclude-label' options filter by logging label. 
 
+The '--grep' and '--exclude-grep' options filter by message, using regular
+expressions in the syntax accepted by the Go regexp package. The filtering
+is done by the controller, so only matching messages are sent.
+
+The '--since' and '--until' options restrict messages to those logged in a
+time window. Each takes either an RFC3339 timestamp, such as
+2024-03-01T10:00:00Z, or a duration before the current time, such as 2h30m.
+Using '--since' implies '--replay'. When '--until' is in the past, the
+command stops once the window has been shown, unless '--tail' is specified.
+
+The '--level' and '--max-level' options set the least and most severe log
+levels to show.
+
+The '--grep', '--exclude-grep', '--until' and '--max-level' options need a
+controller running juju 3.6 or later.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
@@ -64,9 +81,12 @@ The filtering options combine as follows:
 * All --exclude-module options are logically ORed together.
 * All --include-label options are logically ORed together.
 * All --exclude-label options are logically ORed together.
+* All --grep options are logically ORed together.
+* All --exclude-grep options are logically ORed together.
 * The combined --include, --exclude, --include-module, --exclude-module,
-  --include-label and --exclude-label selections are logically ANDed to form
-  the complete filter.
+  --include-label, --exclude-label, --grep and --exclude-grep selections,
+  along with the time window and levels, are logically ANDed to form the
+  complete filter.
 
 `
 
@@ -105,6 +125,18 @@ To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
+
+Show the messages about failed hooks logged between 10:00 and 11:30 UTC on
+the 1st of March 2024:
+
+    juju debug-log --grep "hook .* failed" \
+        --since 2024-03-01T10:00:00Z --until 2024-03-01T11:30:00Z
+
+Show the INFO and DEBUG messages logged in the last hour, other than those
+about leadership:
+
+    juju debug-log --since 1h --level DEBUG --max-level INFO \
+        --exclude-grep leader
 `
 
 func (c *debugLogCommand) Info() *cmd.Info {
@@ -133,8 +165,11 @@ func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.C
 type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
-	level  string
-	params common.DebugLogParams
+	level    string
+	maxLevel string
+	since    string
+	until    string
+	params   common.DebugLogParams
 
 	utc      bool
 	location bool
@@ -150,6 +185,7 @@ type debugLogCommand struct {
 
 	format string
 	tz     *time.Location
+	clock  clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -162,9 +198,14 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages for these logging labels")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages for these logging labels")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "grep", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-grep", "Do not show log messages matching these regular expressions")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
+	f.StringVar(&c.maxLevel, "max-level", "", "Most severe log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
+	f.StringVar(&c.since, "since", "", "Only show log messages logged at or after this time, or this long ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time, or this long ago")
 
 	f.UintVar(&c.params.Backlog, "n", defaultLineCount, "Show this many of the most recent (possibly filtered) lines, and continue to append")
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
@@ -185,14 +226,49 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 }
 
 func (c *debugLogCommand) Init(args []string) error {
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
 	if c.level != "" {
-		level, ok := loggo.ParseLevel(c.level)
-		if !ok || level < loggo.TRACE || level > loggo.ERROR {
-			return errors.Errorf("level value %q is not one of %q, %q, %q, %q, %q",
-				c.level, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+		level, err := parseLevel("level", c.level)
+		if err != nil {
+			return errors.Trace(err)
 		}
 		c.params.Level = level
 	}
+	if c.maxLevel != "" {
+		level, err := parseLevel("max-level", c.maxLevel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.params.MaxLevel = level
+	}
+	if c.params.Level != loggo.UNSPECIFIED && c.params.MaxLevel != loggo.UNSPECIFIED && c.params.MaxLevel < c.params.Level {
+		return errors.Errorf("--max-level %s is less severe than --level %s", c.params.MaxLevel, c.params.Level)
+	}
+	for _, expr := range append(c.params.IncludeMessage, c.params.ExcludeMessage...) {
+		if _, err := regexp.Compile(expr); err != nil {
+			return errors.Annotatef(err, "invalid regular expression %q", expr)
+		}
+	}
+	if c.since != "" {
+		since, err := c.parseTime("since", c.since)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := c.parseTime("until", c.until)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.params.EndTime = until
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.NotValidf("--until before --since")
+	}
 	if c.tail && c.noTail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -218,6 +294,28 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+func parseLevel(name, value string) (loggo.Level, error) {
+	level, ok := loggo.ParseLevel(value)
+	if !ok || level < loggo.TRACE || level > loggo.ERROR {
+		return level, errors.Errorf("%s value %q is not one of %q, %q, %q, %q, %q",
+			name, value, loggo.TRACE, loggo.DEBUG, loggo.INFO, loggo.WARNING, loggo.ERROR)
+	}
+	return level, nil
+}
+
+// parseTime parses a --since or --until value, which is either an
+// RFC3339 timestamp or a duration before the current time.
+func (c *debugLogCommand) parseTime(name, value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%s value %q is not an RFC3339 time or a positive duration", name, value)
+	}
+	return c.clock.Now().Add(-d), nil
+}
+
 func (c *debugLogCommand) parseEntity(entity string) string {
 	tag, err := names.ParseTag(entity)
 	switch {
@@ -262,6 +360,10 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) error {
 		c.params.NoTail = false
 	} else if c.noTail {
 		c.params.NoTail = true
+	} else if !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.clock.Now()) {
+		// There is nothing more to wait for once the time window
+		// has passed.
+		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
 		// using a terminal.
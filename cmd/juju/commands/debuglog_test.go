@@ -6,6 +6,7 @@ package commands
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/v3/cmdtesting"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -24,6 +25,7 @@ type DebugLogSuite struct {
 var _ = gc.Suite(&DebugLogSuite{})
 
 func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
 	for i, test := range []struct {
 		args     []string
 		expected common.DebugLogParams
@@ -122,10 +124,58 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 		}, {
 			args:     []string{"--retry-delay", "-1s"},
 			errMatch: `negative retry delay not valid`,
+		}, {
+			args: []string{"--grep", "^hook", "--grep", "failed", "--exclude-grep", "leader"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"^hook", "failed"},
+				ExcludeMessage: []string{"leader"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--grep", "(unclosed"},
+			errMatch: `invalid regular expression "\(unclosed": .*`,
+		}, {
+			args: []string{"--level", "DEBUG", "--max-level", "WARNING"},
+			expected: common.DebugLogParams{
+				Backlog:  10,
+				Level:    loggo.DEBUG,
+				MaxLevel: loggo.WARNING,
+			},
+		}, {
+			args:     []string{"--max-level", "foo"},
+			errMatch: `max-level value "foo" is not one of "TRACE", "DEBUG", "INFO", "WARNING", "ERROR"`,
+		}, {
+			args:     []string{"--level", "ERROR", "--max-level", "INFO"},
+			errMatch: `--max-level INFO is less severe than --level ERROR`,
+		}, {
+			args: []string{"--since", "2024-03-01T10:00:00Z", "--until", "2024-03-01T11:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--since", "2h", "--until", "30m"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: now.Add(-2 * time.Hour),
+				EndTime:   now.Add(-30 * time.Minute),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `since value "yesterday" is not an RFC3339 time or a positive duration`,
+		}, {
+			args:     []string{"--until", "-1h"},
+			errMatch: `until value "-1h" is not an RFC3339 time or a positive duration`,
+		}, {
+			args:     []string{"--since", "1h", "--until", "2h"},
+			errMatch: `--until before --since not valid`,
 		},
 	} {
 		c.Logf("test %v", i)
-		command := &debugLogCommand{}
+		command := &debugLogCommand{clock: testclock.NewClock(now)}
 		command.SetClientStore(jujuclienttesting.MinimalStore())
 		err := cmdtesting.InitCommand(modelcmd.Wrap(command), test.args)
 		if test.errMatch == "" {
@@ -160,6 +210,24 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestUntilInPastDoesNotTail(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newDebugLogCommand(jujuclienttesting.MinimalStore()),
+		"--grep=failed",
+		"--until=2024-03-01T11:30:00Z",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params, jc.DeepEquals, common.DebugLogParams{
+		IncludeMessage: []string{"failed"},
+		Backlog:        10,
+		EndTime:        time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC),
+		NoTail:         true,
+	})
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
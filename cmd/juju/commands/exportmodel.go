@@ -0,0 +1,208 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/client/charms"
+	"github.com/juju/juju/api/client/resources"
+	"github.com/juju/juju/api/common"
+	jujuhttp "github.com/juju/juju/api/http"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newExportModelCommand() modelcmd.ModelCommand {
+	cmd := &exportModelCommand{clock: clock.WallClock}
+	cmd.newAPI = func() (exportModelAPI, error) {
+		return cmd.NewModelManagerAPIClient()
+	}
+	cmd.newModelBinaries = cmd.openModelBinaries
+	return modelcmd.Wrap(cmd)
+}
+
+// exportModelCommand writes a model, along with the binaries it uses,
+// to an archive file.
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	filename string
+
+	// Overridden by tests
+	clock            clock.Clock
+	newAPI           func() (exportModelAPI, error)
+	newModelBinaries func() (modelBinaries, error)
+}
+
+type exportModelAPI interface {
+	ExportModel(names.ModelTag) (params.SerializedModel, error)
+	Close() error
+}
+
+// modelBinaries provides access to the charms, agent binaries and
+// resources used by a model.
+type modelBinaries interface {
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+
+	// ServerVersion returns the version of the controller.
+	ServerVersion() (version.Number, bool)
+	Close() error
+}
+
+const exportModelDoc = `
+The export-model command writes the model, along with the charms,
+resources and agent binaries it uses, to an archive file. The archive
+can be imported into another controller with the import-model command,
+whether or not the controllers can reach each other, or kept as a
+snapshot to recover the model from.
+
+The model is not changed by the export, so the archive reflects the
+model as it was at the time it was written. Only controller
+administrators can export models.
+`
+
+const exportModelExamples = `
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+`
+
+// Info implements cmd.Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "export-model",
+		Args:     "<file>",
+		Purpose:  "Export a model to an archive file.",
+		Doc:      exportModelDoc,
+		Examples: exportModelExamples,
+		SeeAlso: []string{
+			"import-model",
+			"migrate",
+		},
+	})
+}
+
+// Init implements cmd.Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("archive file not specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) (err error) {
+	modelName, details, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	serialized, err := client.ExportModel(names.NewModelTag(details.ModelUUID))
+	if err != nil {
+		return errors.Annotatef(err, "exporting model %q", modelName)
+	}
+	binaries, err := common.SerializedModelFromParams(serialized)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	source, err := c.newModelBinaries()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = source.Close() }()
+	controllerVersion, _ := source.ServerVersion()
+
+	path := ctx.AbsPath(c.filename)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Annotate(err, "creating archive file")
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = errors.Annotate(closeErr, "writing archive file")
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	archive, err := newModelArchiveWriter(f, serialized, details.ModelUUID, controllerVersion, c.clock.Now())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             binaries.Charms,
+		CharmDownloader:    source,
+		CharmUploader:      archive,
+		Tools:              binaries.Tools,
+		ToolsDownloader:    source,
+		ToolsUploader:      archive,
+		Resources:          binaries.Resources,
+		ResourceDownloader: source,
+		ResourceUploader:   archive,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if err := archive.Close(); err != nil {
+		return errors.Annotate(err, "writing archive file")
+	}
+	ctx.Infof("Exported model %q to %s", modelName, c.filename)
+	return nil
+}
+
+func (c *exportModelCommand) openModelBinaries() (modelBinaries, error) {
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	charmOpener, err := charms.NewCharmOpener(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Trace(err)
+	}
+	uriOpener, err := jujuhttp.NewURIOpener(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Trace(err)
+	}
+	return &apiModelBinaries{
+		Connection:  conn,
+		CharmOpener: charmOpener,
+		URIOpener:   uriOpener,
+	}, nil
+}
+
+// apiModelBinaries reads the binaries used by a model from the
+// controller.
+type apiModelBinaries struct {
+	api.Connection
+	charms.CharmOpener
+	jujuhttp.URIOpener
+}
+
+// OpenResource is part of the migration.ResourceDownloader interface.
+func (b *apiModelBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf(resources.HTTPEndpointPath, application, name)
+	return b.OpenURI(uri, nil)
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/description/v5"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/rpc/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const exportModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+var exportedTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type ExportModelSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	store    *jujuclient.MemStore
+	api      *fakeExportModelAPI
+	binaries *fakeModelBinaries
+}
+
+var _ = gc.Suite(&ExportModelSuite{})
+
+func (s *ExportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{User: "admin"}
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		CurrentModel: "admin/mymodel",
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/mymodel": {ModelUUID: exportModelUUID, ModelType: model.IAAS},
+		},
+	}
+	s.api = &fakeExportModelAPI{serialized: testSerializedModel(c)}
+	s.binaries = &fakeModelBinaries{
+		files: map[string]string{
+			"charm:ch:foo-1":                   "foo charm",
+			"/tools/3.5.0-ubuntu-amd64":        "agent binaries",
+			"resource:foo/bar":                 "bar resource",
+			"resource:foo/placeholder-ignored": "unexpected",
+		},
+	}
+}
+
+func testModelDescription() description.Model {
+	m := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"type":          "dummy",
+			"uuid":          exportModelUUID,
+			"agent-version": "3.5.0",
+		},
+	})
+	m.SetStatus(description.StatusArgs{Value: "available", Updated: exportedTime})
+	return m
+}
+
+func testSerializedModel(c *gc.C) params.SerializedModel {
+	bytes, err := description.Serialize(testModelDescription())
+	c.Assert(err, jc.ErrorIsNil)
+	return params.SerializedModel{
+		Bytes:  bytes,
+		Charms: []string{"ch:foo-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "3.5.0-ubuntu-amd64",
+			URI:     "/tools/3.5.0-ubuntu-amd64",
+		}},
+		Resources: []params.SerializedModelResource{{
+			Application: "foo",
+			Name:        "bar",
+			ApplicationRevision: params.SerializedModelResourceRevision{
+				Revision:  2,
+				Type:      "file",
+				Origin:    "upload",
+				Path:      "bar.txt",
+				Size:      12,
+				Timestamp: exportedTime,
+			},
+			CharmStoreRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "store",
+			},
+			UnitRevisions: map[string]params.SerializedModelResourceRevision{
+				"foo/0": {
+					Revision:  2,
+					Type:      "file",
+					Origin:    "upload",
+					Path:      "bar.txt",
+					Size:      12,
+					Timestamp: exportedTime,
+				},
+			},
+		}, {
+			Application: "foo",
+			Name:        "placeholder-ignored",
+			ApplicationRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "upload",
+			},
+			CharmStoreRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "store",
+			},
+		}},
+	}
+}
+
+func (s *ExportModelSuite) makeCommand() cmd.Command {
+	command := &exportModelCommand{
+		clock: testclock.NewClock(exportedTime),
+		newAPI: func() (exportModelAPI, error) {
+			return s.api, nil
+		},
+		newModelBinaries: func() (modelBinaries, error) {
+			return s.binaries, nil
+		},
+	}
+	wrapped := modelcmd.Wrap(command)
+	wrapped.SetClientStore(s.store)
+	return wrapped
+}
+
+func (s *ExportModelSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand())
+	c.Assert(err, gc.ErrorMatches, "archive file not specified")
+	_, err = cmdtesting.RunCommand(c, s.makeCommand(), "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *ExportModelSuite) TestExport(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Exported model \"admin/mymodel\" to mymodel.tar.gz\n")
+	c.Check(s.api.exported, gc.Equals, names.NewModelTag(exportModelUUID))
+	c.Check(s.api.closed, jc.IsTrue)
+	c.Check(s.binaries.closed, jc.IsTrue)
+
+	archive := extractTestArchive(c, filepath.Join(ctx.Dir, "mymodel.tar.gz"))
+	c.Check(archive.manifest, jc.DeepEquals, modelArchiveManifest{
+		FormatVersion:          modelArchiveFormatVersion,
+		ModelUUID:              exportModelUUID,
+		ControllerAgentVersion: version.MustParse("3.5.1"),
+		Exported:               exportedTime,
+		Charms:                 map[string]string{"ch:foo-1": "charms/0-foo-" + testArchiveHash("foo charm") + ".charm"},
+		Tools: []params.SerializedModelTools{{
+			Version: "3.5.0-ubuntu-amd64",
+			URI:     "tools/3.5.0-ubuntu-amd64.tar.gz",
+		}},
+		Resources:     s.api.serialized.Resources,
+		ResourceFiles: map[string]string{"foo/bar": "resources/foo/bar"},
+	})
+	c.Check(archive.model, jc.DeepEquals, s.api.serialized.Bytes)
+	content := func(r io.ReadCloser, err error) string {
+		c.Assert(err, jc.ErrorIsNil)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		c.Assert(err, jc.ErrorIsNil)
+		return string(data)
+	}
+	c.Check(content(archive.OpenCharm("ch:foo-1")), gc.Equals, "foo charm")
+	c.Check(content(archive.OpenURI("tools/3.5.0-ubuntu-amd64.tar.gz", nil)), gc.Equals, "agent binaries")
+	c.Check(content(archive.OpenResource("foo", "bar")), gc.Equals, "bar resource")
+
+	_, err = archive.OpenResource("foo", "placeholder-ignored")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ExportModelSuite) TestExportFileExists(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	path := filepath.Join(ctx.Dir, "mymodel.tar.gz")
+	err := os.WriteFile(path, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommandInDir(c, s.makeCommand(), []string{"mymodel.tar.gz"}, ctx.Dir)
+	c.Assert(err, gc.ErrorMatches, "creating archive file: .* file exists")
+	content, err := os.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "precious")
+}
+
+func (s *ExportModelSuite) TestExportDownloadFailureRemovesFile(c *gc.C) {
+	delete(s.binaries.files, "resource:foo/bar")
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "cannot upload resources: cannot open resource: resource:foo/bar not found")
+	_, err = os.Stat(filepath.Join(ctx.Dir, "mymodel.tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelSuite) TestExportError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, `exporting model "admin/mymodel": boom`)
+}
+
+func extractTestArchive(c *gc.C, path string) *modelArchive {
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := extractModelArchive(f, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	return archive
+}
+
+// testArchiveHash returns the hash used in the charm reference of
+// charms with the input content.
+func testArchiveHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])[0:7]
+}
+
+type fakeExportModelAPI struct {
+	serialized params.SerializedModel
+	err        error
+	exported   names.ModelTag
+	closed     bool
+}
+
+func (a *fakeExportModelAPI) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	a.exported = model
+	return a.serialized, a.err
+}
+
+func (a *fakeExportModelAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+type fakeModelBinaries struct {
+	files  map[string]string
+	closed bool
+}
+
+func (b *fakeModelBinaries) open(name string) (io.ReadCloser, error) {
+	content, ok := b.files[name]
+	if !ok {
+		return nil, errors.NotFoundf(name)
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func (b *fakeModelBinaries) OpenCharm(curl string) (io.ReadCloser, error) {
+	return b.open("charm:" + curl)
+}
+
+func (b *fakeModelBinaries) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return b.open(uri)
+}
+
+func (b *fakeModelBinaries) OpenResource(application, name string) (io.ReadCloser, error) {
+	return b.open("resource:" + application + "/" + name)
+}
+
+func (b *fakeModelBinaries) ServerVersion() (version.Number, bool) {
+	return version.MustParse("3.5.1"), true
+}
+
+func (b *fakeModelBinaries) Close() error {
+	b.closed = true
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/tools"
+)
+
+func newImportModelCommand() modelcmd.ControllerCommand {
+	cmd := &importModelCommand{}
+	cmd.newAPI = cmd.openImportModelAPI
+	return modelcmd.WrapController(cmd)
+}
+
+// importModelCommand imports a model from an archive file written by
+// export-model.
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	filename string
+
+	// Overridden by tests
+	newAPI func() (importModelAPI, error)
+}
+
+// importModelAPI is the subset of the MigrationTarget facade used to
+// import a model, as in the final phases of a migration.
+type importModelAPI interface {
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(string) error
+	Activate(string, coremigration.SourceControllerInfo, []string) error
+	UploadCharm(string, string, string, io.ReadSeeker) (string, error)
+	UploadTools(string, io.ReadSeeker, version.Binary) (tools.List, error)
+	UploadResource(string, resources.Resource, io.ReadSeeker) error
+	SetPlaceholderResource(string, resources.Resource) error
+	SetUnitResource(string, string, resources.Resource) error
+	Close() error
+}
+
+const importModelDoc = `
+The import-model command creates a model on the controller from an
+archive file written by the export-model command, along with the
+charms, resources and agent binaries the model uses.
+
+The model is imported with the same UUID, name and owner it had when it
+was exported, so there must not already be a model with that UUID, or
+with that name and owner, on the controller. The model's owner, and the
+users with access to it, must exist on the controller.
+
+Unlike a migration, the agents of the model's machines and units are
+not redirected to the controller by the import; they continue to use
+the controller they were connected to, if any. Importing is intended for
+moving a model between controllers which cannot reach each other, or
+for recovering a model from a snapshot, where the machines will be
+reconnected or replaced separately.
+
+Only controller administrators can import models.
+`
+
+const importModelExamples = `
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+`
+
+// Info implements cmd.Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "import-model",
+		Args:     "<file>",
+		Purpose:  "Import a model from an archive file.",
+		Doc:      importModelDoc,
+		Examples: importModelExamples,
+		SeeAlso: []string{
+			"export-model",
+			"migrate",
+			"models",
+		},
+	})
+}
+
+// Init implements cmd.Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("archive file not specified")
+	}
+	c.filename = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	dir, err := os.MkdirTemp("", "juju-import-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	archive, err := extractModelArchive(f, dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := archive.SerializedModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelInfo, err := archive.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "controller cannot accept the model")
+	}
+	if err := client.Import(serialized.Bytes); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := c.uploadBinaries(client, modelInfo.UUID, archive, serialized); err != nil {
+		if abortErr := client.Abort(modelInfo.UUID); abortErr != nil {
+			logger.Errorf("aborting import of model %q: %v", modelInfo.Name, abortErr)
+		}
+		return errors.Trace(err)
+	}
+	if err := client.Activate(modelInfo.UUID, coremigration.SourceControllerInfo{}, nil); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	ctx.Infof("Imported model %q owned by %s", modelInfo.Name, modelInfo.Owner.Id())
+	return nil
+}
+
+func (c *importModelCommand) uploadBinaries(
+	client importModelAPI, modelUUID string, archive *modelArchive, serialized coremigration.SerializedModel,
+) error {
+	uploader := &importModelUploader{client: client, modelUUID: modelUUID}
+	return migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             serialized.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              serialized.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          serialized.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+}
+
+func (c *importModelCommand) openImportModelAPI() (importModelAPI, error) {
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelClient{
+		Client: migrationtarget.NewClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// importModelClient closes the connection used by the MigrationTarget
+// client.
+type importModelClient struct {
+	*migrationtarget.Client
+	conn api.Connection
+}
+
+// Close closes the API connection.
+func (c *importModelClient) Close() error {
+	return c.conn.Close()
+}
+
+// importModelUploader prepends the model UUID to the args passed to the
+// MigrationTarget client.
+type importModelUploader struct {
+	client    importModelAPI
+	modelUUID string
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (u *importModelUploader) UploadCharm(curl string, charmRef string, content io.ReadSeeker) (string, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, charmRef, content)
+}
+
+// UploadTools is part of the migration.ToolsUploader interface.
+func (u *importModelUploader) UploadTools(r io.ReadSeeker, vers version.Binary) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers)
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (u *importModelUploader) UploadResource(res resources.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader
+// interface.
+func (u *importModelUploader) SetPlaceholderResource(res resources.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+func (u *importModelUploader) SetUnitResource(unitName string, res resources.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	charmresource "github.com/juju/charm/v12/resource"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *fakeImportModelAPI
+	dir   string
+}
+
+var _ = gc.Suite(&ImportModelSuite{})
+
+func (s *ImportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{User: "admin"}
+	s.api = &fakeImportModelAPI{uploaded: make(map[string]string)}
+	s.dir = c.MkDir()
+}
+
+func (s *ImportModelSuite) makeCommand() cmd.Command {
+	command := &importModelCommand{
+		newAPI: func() (importModelAPI, error) {
+			return s.api, nil
+		},
+	}
+	wrapped := modelcmd.WrapController(command)
+	wrapped.SetClientStore(s.store)
+	return wrapped
+}
+
+func (s *ImportModelSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommandInDir(c, s.makeCommand(), args, s.dir)
+}
+
+// writeArchive writes an archive of the test model, holding the input
+// binaries, as export-model would.
+func (s *ImportModelSuite) writeArchive(c *gc.C, name string, binaries map[string]string) {
+	f, err := os.Create(filepath.Join(s.dir, name))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+
+	serialized := testSerializedModel(c)
+	w, err := newModelArchiveWriter(f, serialized, exportModelUUID, version.MustParse("3.5.1"), exportedTime)
+	c.Assert(err, jc.ErrorIsNil)
+	for key, content := range binaries {
+		kind, id, _ := strings.Cut(key, ":")
+		switch kind {
+		case "charm":
+			_, err = w.UploadCharm(id, "foo-ref", strings.NewReader(content))
+		case "tools":
+			_, err = w.UploadTools(strings.NewReader(content), version.MustParseBinary(id))
+		case "resource":
+			app, name, _ := strings.Cut(id, "/")
+			res := resources.Resource{
+				ApplicationID: app,
+				Resource:      charmresource.Resource{Meta: charmresource.Meta{Name: name}},
+			}
+			err = w.UploadResource(res, strings.NewReader(content))
+		}
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(w.Close(), jc.ErrorIsNil)
+}
+
+func (s *ImportModelSuite) writeTestArchive(c *gc.C) {
+	s.writeArchive(c, "mymodel.tar.gz", map[string]string{
+		"charm:ch:foo-1":              "foo charm",
+		"tools:3.5.0-ubuntu-amd64":    "agent binaries",
+		"resource:foo/bar":            "bar resource",
+		"resource:foo/not-referenced": "ignored",
+	})
+}
+
+func (s *ImportModelSuite) TestInit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "archive file not specified")
+	_, err = s.run(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *ImportModelSuite) TestImport(c *gc.C) {
+	s.writeTestArchive(c)
+
+	ctx, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Imported model \"mymodel\" owned by admin\n")
+
+	c.Assert(s.api.prechecked, gc.NotNil)
+	c.Check(s.api.prechecked.UUID, gc.Equals, exportModelUUID)
+	c.Check(s.api.prechecked.Name, gc.Equals, "mymodel")
+	c.Check(s.api.prechecked.Owner, gc.Equals, names.NewUserTag("admin"))
+	c.Check(s.api.prechecked.AgentVersion, gc.Equals, version.MustParse("3.5.0"))
+	c.Check(s.api.prechecked.ControllerAgentVersion, gc.Equals, version.MustParse("3.5.1"))
+	c.Check(s.api.imported, jc.DeepEquals, testSerializedModel(c).Bytes)
+	c.Check(s.api.uploaded, jc.DeepEquals, map[string]string{
+		"charm:ch:foo-1":           "foo charm",
+		"tools:3.5.0-ubuntu-amd64": "agent binaries",
+		"resource:foo/bar":         "bar resource",
+		"unit-resource:foo/0":      "bar",
+	})
+	s.api.CheckCallNames(c,
+		"Prechecks", "Import", "UploadCharm", "UploadTools", "UploadResource", "SetUnitResource",
+		"Activate", "Close",
+	)
+	for _, call := range s.api.Calls()[2:7] {
+		c.Check(call.Args[0], gc.Equals, exportModelUUID)
+	}
+}
+
+func (s *ImportModelSuite) TestImportMissingBinaryAborts(c *gc.C) {
+	s.writeArchive(c, "mymodel.tar.gz", map[string]string{
+		"charm:ch:foo-1":           "foo charm",
+		"tools:3.5.0-ubuntu-amd64": "agent binaries",
+	})
+
+	_, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "cannot upload resources: cannot open resource: resource foo/bar in model archive not found")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "UploadCharm", "UploadTools", "Abort", "Close")
+	s.api.CheckCall(c, 4, "Abort", exportModelUUID)
+}
+
+func (s *ImportModelSuite) TestImportPrechecksFail(c *gc.C) {
+	s.writeTestArchive(c)
+	s.api.SetErrors(errors.New("model already exists"))
+
+	_, err := s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "controller cannot accept the model: model already exists")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportModelSuite) TestImportNotAnArchive(c *gc.C) {
+	err := os.WriteFile(filepath.Join(s.dir, "mymodel.tar.gz"), []byte("not an archive"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ImportModelSuite) TestImportRejectsEscapingPaths(c *gc.C) {
+	f, err := os.Create(filepath.Join(s.dir, "mymodel.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	err = tw.WriteHeader(&tar.Header{Name: "../escaped", Typeflag: tar.TypeReg, Mode: 0644, Size: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("oops"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	_, err = s.run(c, "mymodel.tar.gz")
+	c.Assert(err, gc.ErrorMatches, `extracting "../escaped": archive path "../escaped" not valid`)
+	s.api.CheckNoCalls(c)
+}
+
+type fakeImportModelAPI struct {
+	testing.Stub
+	prechecked *coremigration.ModelInfo
+	imported   []byte
+	uploaded   map[string]string
+}
+
+func (a *fakeImportModelAPI) read(c io.ReadSeeker) string {
+	content, _ := io.ReadAll(c)
+	return string(content)
+}
+
+func (a *fakeImportModelAPI) Prechecks(model coremigration.ModelInfo) error {
+	a.MethodCall(a, "Prechecks", model)
+	a.prechecked = &model
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Import(bytes []byte) error {
+	a.MethodCall(a, "Import", bytes)
+	a.imported = bytes
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Abort(modelUUID string) error {
+	a.MethodCall(a, "Abort", modelUUID)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Activate(modelUUID string, source coremigration.SourceControllerInfo, related []string) error {
+	a.MethodCall(a, "Activate", modelUUID, source, related)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadCharm(modelUUID, curl, charmRef string, content io.ReadSeeker) (string, error) {
+	a.MethodCall(a, "UploadCharm", modelUUID, curl, charmRef)
+	a.uploaded["charm:"+curl] = a.read(content)
+	return curl, a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadTools(modelUUID string, content io.ReadSeeker, v version.Binary) (tools.List, error) {
+	a.MethodCall(a, "UploadTools", modelUUID, v)
+	a.uploaded["tools:"+v.String()] = a.read(content)
+	return nil, a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadResource(modelUUID string, res resources.Resource, content io.ReadSeeker) error {
+	a.MethodCall(a, "UploadResource", modelUUID, res)
+	a.uploaded["resource:"+res.ApplicationID+"/"+res.Name] = a.read(content)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) SetPlaceholderResource(modelUUID string, res resources.Resource) error {
+	a.MethodCall(a, "SetPlaceholderResource", modelUUID, res)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) SetUnitResource(modelUUID, unit string, res resources.Resource) error {
+	a.MethodCall(a, "SetUnitResource", modelUUID, unit, res)
+	a.uploaded["unit-resource:"+unit] = res.Name
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
@@ -411,6 +411,9 @@ func registerCommands(r commandRegistry) {
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
+	r.Register(backups.NewListCommand())
+	r.Register(backups.NewRemoveCommand())
+	r.Register(backups.NewRestoreCommand())
 
 	// Manage authorized ssh keys.
 	r.Register(NewAddKeysCommand())
@@ -459,6 +462,8 @@ func registerCommands(r commandRegistry) {
 
 	r.Register(newMigrateCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(newExportModelCommand())
+	r.Register(newImportModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -488,6 +493,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewShowApplicationCommand())
 	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewShowLeadershipHistoryCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -530,6 +536,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewDBQueryCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -576,6 +583,8 @@ func registerCommands(r commandRegistry) {
 	r.Register(charmhub.NewInfoCommand())
 	r.Register(charmhub.NewFindCommand())
 	r.Register(charmhub.NewDownloadCommand())
+	r.Register(charmhub.NewExportCharmsCommand())
+	r.Register(charmhub.NewImportCharmsCommand())
 
 	// Secrets.
 	r.Register(secrets.NewListSecretsCommand())
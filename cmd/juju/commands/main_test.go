@@ -324,6 +324,7 @@ var commandNames = []string{
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"backups",
 	"bind",
 	"bootstrap",
 	"cancel-task",
@@ -339,6 +340,7 @@ var commandNames = []string{
 	"create-storage-pool",
 	"credentials",
 	"dashboard",
+	"db-query",
 	"debug-code",
 	"debug-hook",
 	"debug-hooks",
@@ -362,6 +364,8 @@ var commandNames = []string{
 	"enable-user",
 	"exec",
 	"export-bundle",
+	"export-charms",
+	"export-model",
 	"expose",
 	"find",
 	"find-offers",
@@ -373,13 +377,16 @@ var commandNames = []string{
 	"grant-cloud",
 	"help",
 	"help-tool",
+	"import-charms",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"info",
 	"integrate",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
+	"list-backups",
 	"list-charm-resources",
 	"list-clouds",
 	"list-controllers",
@@ -421,6 +428,7 @@ var commandNames = []string{
 	"relate", // alias for integrate
 	"reload-spaces",
 	"remove-application",
+	"remove-backup",
 	"remove-cloud",
 	"remove-credential",
 	"remove-k8s",
@@ -440,6 +448,7 @@ var commandNames = []string{
 	"resolved",
 	"resolve",
 	"resources",
+	"restore-backup",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -464,6 +473,7 @@ var commandNames = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-leadership-history",
 	"show-machine",
 	"show-model",
 	"show-offer",
@@ -4,12 +4,14 @@
 package commands
 
 import (
+	"io"
 	"strings"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 	"gopkg.in/macaroon.v2"
 
@@ -19,6 +21,8 @@ import (
 	"github.com/juju/juju/api/controller/controller"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/rpc/params"
 )
@@ -34,7 +38,11 @@ func newMigrateCommand() modelcmd.ModelCommand {
 // migrateCommand initiates a model migration.
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
+	out              cmd.Output
 	targetController string
+	dryRun           bool
+	resume           bool
+	abort            bool
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
@@ -45,6 +53,9 @@ type migrateCommand struct {
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	DryRunMigration(spec controller.MigrationSpec) (coremigration.PrecheckIssues, error)
+	ResumeMigration(modelUUID string) error
+	AbortMigration(modelUUID string) error
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -81,15 +92,44 @@ If the migration fails for some reason, the model is returned to its
 original state where it is managed by the original
 controller.
 
+If the model has been imported into the target controller and the
+migration then fails, for example while transferring charms, tools or
+resources, or while validating the imported model, the migration is
+suspended rather than aborted. The model remains quiesced and 'status'
+reports why the migration was suspended. Once the problem has been
+addressed, --resume continues the migration from the phase in which
+it was suspended; binaries which the target controller already holds
+are not transferred again. Alternatively, --abort abandons the
+suspended migration and returns the model to its original controller.
+Only the model is specified with --resume and --abort.
+
+With --dry-run, the checks made before a migration is started are run
+on both controllers, along with checks that the model can be exported
+from the current controller and imported into the target controller,
+but the migration is not started. The model imported into the target
+controller is removed again straight away, as it is when a migration
+is aborted. Rather than stopping at the first problem found, every
+issue which would block the migration, or which may affect it, is
+reported. The command fails if any issue would block the migration.
+
+`
+
+const migrateExamples = `
+    juju migrate mymodel othercontroller
+    juju migrate --dry-run mymodel othercontroller
+    juju migrate --dry-run --format yaml mymodel othercontroller
+    juju migrate --resume mymodel
+    juju migrate --abort mymodel
 `
 
 // Info implements cmd.Command.
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
-		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
-		Purpose: "Migrate a workload model to another controller.",
-		Doc:     migrateDoc,
+		Name:     "migrate",
+		Args:     "<model-name> <target-controller-name>",
+		Purpose:  "Migrate a workload model to another controller.",
+		Doc:      migrateDoc,
+		Examples: migrateExamples,
 		SeeAlso: []string{
 			"login",
 			"controllers",
@@ -98,11 +138,27 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+	f.BoolVar(&c.resume, "resume", false, "Resume the suspended migration of the model")
+	f.BoolVar(&c.abort, "abort", false, "Abort the suspended migration of the model")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatPrecheckIssuesTabular,
+	})
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
+	if c.resume || c.abort {
+		return c.initResume(args)
+	}
 	if len(args) < 2 {
 		return errors.New("target controller not specified")
 	}
@@ -118,8 +174,24 @@ func (c *migrateCommand) Init(args []string) error {
 	return nil
 }
 
+func (c *migrateCommand) initResume(args []string) error {
+	if c.resume && c.abort {
+		return errors.New("cannot specify both --resume and --abort")
+	}
+	if c.dryRun {
+		return errors.New("--dry-run cannot be used with --resume or --abort")
+	}
+	if len(args) > 1 {
+		return errors.New("only the model is specified when resuming or aborting a migration")
+	}
+	return errors.Trace(c.SetModelIdentifier(args[0], false))
+}
+
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	if c.resume || c.abort {
+		return c.runResume(ctx)
+	}
 	spec, err := c.getMigrationSpec()
 	if err != nil {
 		return err
@@ -133,6 +205,12 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		// The users are checked by the controller as part of the
+		// dry run, so that they are reported along with any other
+		// issues.
+		return c.runDryRun(ctx, spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -153,6 +231,101 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) runResume(ctx *cmd.Context) error {
+	modelName, err := c.ModelIdentifier()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	if c.abort {
+		if err := api.AbortMigration(uuids[0]); err != nil {
+			return errors.Annotate(err, "aborting migration")
+		}
+		ctx.Infof("Migration of %q aborted", modelName)
+		return nil
+	}
+	if err := api.ResumeMigration(uuids[0]); err != nil {
+		return errors.Annotate(err, "resuming migration")
+	}
+	ctx.Infof("Migration of %q resumed", modelName)
+	return nil
+}
+
+func (c *migrateCommand) runDryRun(ctx *cmd.Context, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	issues, err := api.DryRunMigration(*spec)
+	if err != nil {
+		return errors.Annotate(err, "checking migration")
+	}
+	if len(issues) == 0 {
+		ctx.Infof("No issues found, model can be migrated to %q", c.targetController)
+		return nil
+	}
+	out := make([]precheckIssue, len(issues))
+	for i, issue := range issues {
+		out[i] = precheckIssue{
+			Severity:   string(issue.Severity),
+			Controller: issue.Controller,
+			Message:    issue.Message,
+		}
+	}
+	if err := c.out.Write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
+	if issues.Blocked() {
+		return errors.Errorf("migration to %q would be blocked", c.targetController)
+	}
+	return nil
+}
+
+// precheckIssue is the output format of an issue found by a migration
+// dry run.
+type precheckIssue struct {
+	Severity   string `yaml:"severity" json:"severity"`
+	Controller string `yaml:"controller" json:"controller"`
+	Message    string `yaml:"message" json:"message"`
+}
+
+func formatPrecheckIssuesTabular(writer io.Writer, value interface{}) error {
+	issues, ok := value.([]precheckIssue)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", issues, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Severity", "Controller", "Message")
+	for _, issue := range issues {
+		// Messages may span several lines; continuation lines are
+		// aligned with the first.
+		lines := strings.Split(strings.TrimSpace(issue.Message), "\n")
+		w.Println(issue.Severity, issue.Controller, lines[0])
+		for _, line := range lines[1:] {
+			w.Println("", "", line)
+		}
+	}
+	return tw.Flush()
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -11,6 +11,7 @@ import (
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/names/v5"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/api/controller/controller"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/rpc/params"
@@ -416,6 +418,127 @@ func (s *MigrateSuite) TestControllerDoesNotExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRunNoIssues(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No issues found, model can be migrated to \"target\"\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) setDryRunIssues() {
+	s.api.issues = coremigration.PrecheckIssues{{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckSource,
+		Message:    "machine 0 is dying",
+	}, {
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "cannot migrate to controller due to issues:\n\"model-name\":\n- no upgrade path",
+	}, {
+		Severity:   coremigration.PrecheckWarning,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "source controller has higher patch version than target controller (3.5.1 > 3.5.0)",
+	}}
+}
+
+func (s *MigrateSuite) TestDryRunTabular(c *gc.C) {
+	s.setDryRunIssues()
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration to "target" would be blocked`)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Severity  Controller  Message
+blocker   source      machine 0 is dying
+blocker   target      cannot migrate to controller due to issues:
+                      "model-name":
+                      - no upgrade path
+warning   target      source controller has higher patch version than target controller (3.5.1 > 3.5.0)
+`[1:])
+}
+
+func (s *MigrateSuite) TestDryRunYAML(c *gc.C) {
+	s.setDryRunIssues()
+	s.api.issues = s.api.issues[2:]
+	ctx, err := s.makeAndRun(c, "--dry-run", "--format", "yaml", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- severity: warning
+  controller: target
+  message: source controller has higher patch version than target controller (3.5.1
+    > 3.5.0)
+`[1:])
+}
+
+func (s *MigrateSuite) TestDryRunSkipsClientSideUserCheck(c *gc.C) {
+	s.userAPI.users = nil
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrateSuite) TestDryRunError(c *gc.C) {
+	s.api.dryRunErr = errors.New("boom")
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "checking migration: boom")
+}
+
+func (s *MigrateSuite) TestResume(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--resume", "model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of \"model\" resumed\n")
+	c.Check(s.api.resumed, gc.Equals, modelUUID)
+	c.Check(s.api.aborted, gc.Equals, "")
+}
+
+func (s *MigrateSuite) TestAbort(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--abort", "model")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of \"model\" aborted\n")
+	c.Check(s.api.aborted, gc.Equals, modelUUID)
+	c.Check(s.api.resumed, gc.Equals, "")
+}
+
+func (s *MigrateSuite) TestResumeError(c *gc.C) {
+	s.api.resumeErr = errors.New("migration is not suspended")
+	_, err := s.makeAndRun(c, "--resume", "model")
+	c.Assert(err, gc.ErrorMatches, "resuming migration: migration is not suspended")
+}
+
+func (s *MigrateSuite) TestResumeInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--resume"},
+		err:  "model not specified",
+	}, {
+		args: []string{"--resume", "model", "target"},
+		err:  "only the model is specified when resuming or aborting a migration",
+	}, {
+		args: []string{"--resume", "--abort", "model"},
+		err:  "cannot specify both --resume and --abort",
+	}, {
+		args: []string{"--abort", "--dry-run", "model"},
+		err:  "--dry-run cannot be used with --resume or --abort",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.makeAndRun(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -441,6 +564,11 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 type fakeMigrateAPI struct {
 	specSeen    *controller.MigrationSpec
 	identityURL string
+	issues      coremigration.PrecheckIssues
+	dryRunErr   error
+	resumed     string
+	aborted     string
+	resumeErr   error
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -448,6 +576,21 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) DryRunMigration(spec controller.MigrationSpec) (coremigration.PrecheckIssues, error) {
+	a.specSeen = &spec
+	return a.issues, a.dryRunErr
+}
+
+func (a *fakeMigrateAPI) ResumeMigration(modelUUID string) error {
+	a.resumed = modelUUID
+	return a.resumeErr
+}
+
+func (a *fakeMigrateAPI) AbortMigration(modelUUID string) error {
+	a.aborted = modelUUID
+	return a.resumeErr
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
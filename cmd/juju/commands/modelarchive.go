@@ -0,0 +1,345 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/description/v5"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/api/common"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/tools"
+)
+
+const (
+	// modelArchiveFormatVersion is the version of the model archive
+	// layout written by export-model.
+	modelArchiveFormatVersion = 1
+
+	// modelArchiveManifestFile holds the manifest describing the
+	// contents of a model archive.
+	modelArchiveManifestFile = "manifest.json"
+
+	// modelArchiveModelFile holds the serialized model description.
+	modelArchiveModelFile = "model.yaml"
+)
+
+// modelArchiveManifest describes the contents of a model archive. The
+// paths of the charms, agent binaries and resources are relative to
+// the root of the archive.
+type modelArchiveManifest struct {
+	FormatVersion          int                              `json:"format-version"`
+	ModelUUID              string                           `json:"model-uuid"`
+	ControllerAgentVersion version.Number                   `json:"controller-agent-version"`
+	Exported               time.Time                        `json:"exported"`
+	Charms                 map[string]string                `json:"charms,omitempty"`
+	Tools                  []params.SerializedModelTools    `json:"tools,omitempty"`
+	Resources              []params.SerializedModelResource `json:"resources,omitempty"`
+	ResourceFiles          map[string]string                `json:"resource-files,omitempty"`
+}
+
+// modelArchiveWriter writes a model archive as a gzipped tarball. It
+// implements the uploaders used by migration.UploadBinaries, so that
+// the binaries used by a model are written to the archive in the same
+// way as they are sent to the target controller in a migration.
+type modelArchiveWriter struct {
+	gzw      *gzip.Writer
+	tw       *tar.Writer
+	manifest modelArchiveManifest
+}
+
+// newModelArchiveWriter returns a writer of a model archive holding the
+// input serialized model to w. The binaries used by the model are added
+// by uploading them to the writer, and the archive is completed when it
+// is closed.
+func newModelArchiveWriter(
+	w io.Writer, serialized params.SerializedModel, modelUUID string, controllerVersion version.Number, exported time.Time,
+) (*modelArchiveWriter, error) {
+	gzw := gzip.NewWriter(w)
+	aw := &modelArchiveWriter{
+		gzw: gzw,
+		tw:  tar.NewWriter(gzw),
+		manifest: modelArchiveManifest{
+			FormatVersion:          modelArchiveFormatVersion,
+			ModelUUID:              modelUUID,
+			ControllerAgentVersion: controllerVersion,
+			Exported:               exported.UTC(),
+			Charms:                 make(map[string]string),
+			Resources:              serialized.Resources,
+			ResourceFiles:          make(map[string]string),
+		},
+	}
+	if err := aw.writeFile(modelArchiveModelFile, serialized.Bytes); err != nil {
+		return nil, errors.Annotate(err, "writing model description")
+	}
+	return aw, nil
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (w *modelArchiveWriter) UploadCharm(curl string, charmRef string, content io.ReadSeeker) (string, error) {
+	// The index keeps the paths unique where the same archive is used
+	// by several revisions of a charm.
+	name := fmt.Sprintf("charms/%d-%s.charm", len(w.manifest.Charms), charmRef)
+	if err := w.writeEntry(name, content); err != nil {
+		return "", errors.Annotatef(err, "writing charm %s", curl)
+	}
+	w.manifest.Charms[curl] = name
+	return curl, nil
+}
+
+// UploadTools is part of the migration.ToolsUploader interface.
+func (w *modelArchiveWriter) UploadTools(content io.ReadSeeker, v version.Binary) (tools.List, error) {
+	name := fmt.Sprintf("tools/%s.tar.gz", v)
+	if err := w.writeEntry(name, content); err != nil {
+		return nil, errors.Annotatef(err, "writing agent binaries %s", v)
+	}
+	w.manifest.Tools = append(w.manifest.Tools, params.SerializedModelTools{
+		Version: v.String(),
+		URI:     name,
+	})
+	return nil, nil
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (w *modelArchiveWriter) UploadResource(res resources.Resource, content io.ReadSeeker) error {
+	key := path.Join(res.ApplicationID, res.Name)
+	name := path.Join("resources", key)
+	if err := w.writeEntry(name, content); err != nil {
+		return errors.Annotatef(err, "writing resource %s", key)
+	}
+	w.manifest.ResourceFiles[key] = name
+	return nil
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader
+// interface. Placeholders are recorded in the manifest's resources, so
+// there is nothing to write.
+func (w *modelArchiveWriter) SetPlaceholderResource(resources.Resource) error {
+	return nil
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+// Unit resources are recorded in the manifest's resources, so there is
+// nothing to write.
+func (w *modelArchiveWriter) SetUnitResource(string, resources.Resource) error {
+	return nil
+}
+
+// Close writes the manifest and completes the archive.
+func (w *modelArchiveWriter) Close() error {
+	manifest, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writeFile(modelArchiveManifestFile, manifest); err != nil {
+		return errors.Annotate(err, "writing manifest")
+	}
+	if err := w.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.gzw.Close())
+}
+
+func (w *modelArchiveWriter) writeFile(name string, data []byte) error {
+	return w.writeEntry(name, bytes.NewReader(data))
+}
+
+func (w *modelArchiveWriter) writeEntry(name string, content io.ReadSeeker) error {
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  w.manifest.Exported,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(w.tw, content)
+	return errors.Trace(err)
+}
+
+// modelArchive is a model archive extracted to a directory. It
+// implements the downloaders used by migration.UploadBinaries, so that
+// the binaries in the archive can be sent to a controller in the same
+// way as in a migration.
+type modelArchive struct {
+	dir      string
+	manifest modelArchiveManifest
+	model    []byte
+}
+
+// extractModelArchive extracts the model archive read from r into dir,
+// which is expected to be empty, and reads its manifest.
+func extractModelArchive(r io.Reader, dir string) (*modelArchive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive")
+	}
+	defer func() { _ = gzr.Close() }()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := extractModelArchiveFile(tr, dir, hdr.Name); err != nil {
+			return nil, errors.Annotatef(err, "extracting %q", hdr.Name)
+		}
+	}
+
+	archive := &modelArchive{dir: dir}
+	manifest, err := archive.readFile(modelArchiveManifestFile)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading manifest")
+	}
+	if err := json.Unmarshal(manifest, &archive.manifest); err != nil {
+		return nil, errors.Annotate(err, "reading manifest")
+	}
+	if v := archive.manifest.FormatVersion; v != modelArchiveFormatVersion {
+		return nil, errors.NotSupportedf("model archive format version %d", v)
+	}
+	if archive.model, err = archive.readFile(modelArchiveModelFile); err != nil {
+		return nil, errors.Annotate(err, "reading model description")
+	}
+	return archive, nil
+}
+
+func extractModelArchiveFile(r io.Reader, dir, name string) error {
+	target, err := modelArchivePath(dir, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+// modelArchivePath returns the path of the named archive file within
+// dir, ensuring that it does not refer to a location outside it.
+func modelArchivePath(dir, name string) (string, error) {
+	clean := path.Clean("/" + name)
+	if clean == "/" || clean != "/"+name {
+		return "", errors.NotValidf("archive path %q", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+func (a *modelArchive) readFile(name string) ([]byte, error) {
+	target, err := modelArchivePath(a.dir, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return os.ReadFile(target)
+}
+
+func (a *modelArchive) open(name string) (io.ReadCloser, error) {
+	target, err := modelArchivePath(a.dir, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return os.Open(target)
+}
+
+// SerializedModel returns the serialized model held in the archive,
+// with the URIs of its agent binaries referring to archive paths.
+func (a *modelArchive) SerializedModel() (coremigration.SerializedModel, error) {
+	serialized := params.SerializedModel{
+		Bytes:     a.model,
+		Tools:     a.manifest.Tools,
+		Resources: a.manifest.Resources,
+	}
+	for curl := range a.manifest.Charms {
+		serialized.Charms = append(serialized.Charms, curl)
+	}
+	return common.SerializedModelFromParams(serialized)
+}
+
+// ModelInfo returns the details of the archived model used to check
+// that a controller can accept it.
+func (a *modelArchive) ModelInfo() (coremigration.ModelInfo, error) {
+	model, err := description.Deserialize(a.model)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "reading model description")
+	}
+	cfg, err := config.New(config.NoDefaults, model.Config())
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "reading model config")
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return coremigration.ModelInfo{}, errors.NotValidf("model config without agent version")
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Owner:                  model.Owner(),
+		Name:                   cfg.Name(),
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: a.manifest.ControllerAgentVersion,
+		ModelDescription:       model,
+	}, nil
+}
+
+// OpenCharm is part of the migration.CharmDownloader interface.
+func (a *modelArchive) OpenCharm(curl string) (io.ReadCloser, error) {
+	name, ok := a.manifest.Charms[curl]
+	if !ok {
+		return nil, errors.NotFoundf("charm %s in model archive", curl)
+	}
+	return a.open(name)
+}
+
+// OpenURI is part of the migration.ToolsDownloader interface. The URIs
+// are the archive paths of the agent binaries.
+func (a *modelArchive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return a.open(uri)
+}
+
+// OpenResource is part of the migration.ResourceDownloader interface.
+func (a *modelArchive) OpenResource(application, name string) (io.ReadCloser, error) {
+	key := path.Join(application, name)
+	file, ok := a.manifest.ResourceFiles[key]
+	if !ok {
+		return nil, errors.NotFoundf("resource %s in model archive", key)
+	}
+	return a.open(file)
+}
@@ -65,13 +65,14 @@ type ModelMachineInfo struct {
 
 // ModelStatus contains the current status of a model.
 type ModelStatus struct {
-	Current        status.Status `json:"current,omitempty" yaml:"current,omitempty"`
-	Message        string        `json:"message,omitempty" yaml:"message,omitempty"`
-	Reason         string        `json:"reason,omitempty" yaml:"reason,omitempty"`
-	Since          string        `json:"since,omitempty" yaml:"since,omitempty"`
-	Migration      string        `json:"migration,omitempty" yaml:"migration,omitempty"`
-	MigrationStart string        `json:"migration-start,omitempty" yaml:"migration-start,omitempty"`
-	MigrationEnd   string        `json:"migration-end,omitempty" yaml:"migration-end,omitempty"`
+	Current            status.Status `json:"current,omitempty" yaml:"current,omitempty"`
+	Message            string        `json:"message,omitempty" yaml:"message,omitempty"`
+	Reason             string        `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Since              string        `json:"since,omitempty" yaml:"since,omitempty"`
+	Migration          string        `json:"migration,omitempty" yaml:"migration,omitempty"`
+	MigrationStart     string        `json:"migration-start,omitempty" yaml:"migration-start,omitempty"`
+	MigrationEnd       string        `json:"migration-end,omitempty" yaml:"migration-end,omitempty"`
+	MigrationSuspended bool          `json:"migration-suspended,omitempty" yaml:"migration-suspended,omitempty"`
 }
 
 // SecretBackendInfo contains the current status of a secret backend.
@@ -157,6 +158,7 @@ func ModelInfoFromParams(info params.ModelInfo, now time.Time) (ModelInfo, error
 		status.Migration = info.Migration.Status
 		status.MigrationStart = FriendlyDuration(info.Migration.Start, now)
 		status.MigrationEnd = FriendlyDuration(info.Migration.End, now)
+		status.MigrationSuspended = info.Migration.Suspended
 	}
 
 	if info.ProviderType != "" {
@@ -350,8 +350,8 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 		// which makes the output messy.
 		valString := strings.TrimSuffix(out.String(), "\n")
 
-		// Special formatting for multiline exclude-methods lists.
-		if name == controller.AuditLogExcludeMethods {
+		// Special formatting for multiline exclude-methods and sinks lists.
+		if name == controller.AuditLogExcludeMethods || name == controller.AuditLogSinks {
 			if strings.Contains(valString, "\n") {
 				valString = "\n" + valString
 			} else {
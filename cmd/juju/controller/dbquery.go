@@ -0,0 +1,176 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/client/controllerdb"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const dbQueryHelpDoc = `
+Runs a single read-only SQL query against the controller database and
+displays the result. Only SELECT, WITH, EXPLAIN and VALUES statements are
+accepted, and the query is always rolled back.
+
+This command is only available to controller superusers. The controller
+limits the number of rows returned and how long the query can run for; use
+--max-rows and --timeout to change these, up to the controller maximums.
+`
+
+const dbQueryHelpExamples = `
+    juju db-query "SELECT * FROM lease"
+    juju db-query -c mycontroller --format json "SELECT uuid, name FROM cloud"
+    juju db-query --max-rows 10 "SELECT * FROM change_log ORDER BY id DESC"
+`
+
+// NewDBQueryCommand returns a command that runs read-only queries
+// against the controller database.
+func NewDBQueryCommand() cmd.Command {
+	return modelcmd.WrapController(&dbQueryCommand{})
+}
+
+// dbQueryCommand runs a read-only query against the controller database.
+type dbQueryCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api DBQueryAPI
+
+	query   string
+	maxRows int
+	timeout time.Duration
+}
+
+// DBQueryAPI defines the API methods that the db-query command uses.
+type DBQueryAPI interface {
+	Close() error
+	Query(query string, maxRows int, timeout time.Duration) (controllerdb.QueryResult, error)
+}
+
+// Info implements Command.
+func (c *dbQueryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "db-query",
+		Args:     "<query>",
+		Purpose:  "Runs a read-only query against the controller database.",
+		Doc:      dbQueryHelpDoc,
+		Examples: dbQueryHelpExamples,
+		SeeAlso: []string{
+			"controller-config",
+			"show-controller",
+		},
+	})
+}
+
+// SetFlags implements Command.
+func (c *dbQueryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.maxRows, "max-rows", 0, "Maximum number of rows to return (0 for the controller default)")
+	f.DurationVar(&c.timeout, "timeout", 0, "How long the query may run for (0 for the controller default)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"json":    cmd.FormatJson,
+		"tabular": formatDBQueryTabular,
+		"yaml":    cmd.FormatYaml,
+	})
+}
+
+// Init implements Command.
+func (c *dbQueryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no query specified")
+	}
+	c.query = strings.TrimSpace(strings.Join(args, " "))
+	if c.query == "" {
+		return errors.New("no query specified")
+	}
+	if c.maxRows < 0 {
+		return errors.NotValidf("negative --max-rows")
+	}
+	if c.timeout < 0 {
+		return errors.NotValidf("negative --timeout")
+	}
+	return nil
+}
+
+func (c *dbQueryCommand) getAPI() (DBQueryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controllerdb.NewClient(root), nil
+}
+
+// dbQueryOutput is the serialisation format of the query result.
+type dbQueryOutput struct {
+	Columns   []string        `json:"columns" yaml:"columns"`
+	Rows      [][]interface{} `json:"rows" yaml:"rows"`
+	Truncated bool            `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+}
+
+// Run implements Command.
+func (c *dbQueryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Query(c.query, c.maxRows, c.timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.out.Write(ctx, dbQueryOutput{
+		Columns:   result.Columns,
+		Rows:      result.Rows,
+		Truncated: result.Truncated,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Truncated {
+		ctx.Warningf("results truncated to %d rows", len(result.Rows))
+	}
+	return nil
+}
+
+func formatDBQueryTabular(writer io.Writer, value interface{}) error {
+	result, ok := value.(dbQueryOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", result, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+
+	header := make([]interface{}, len(result.Columns))
+	for i, column := range result.Columns {
+		header[i] = column
+	}
+	w.Println(header...)
+
+	for _, row := range result.Rows {
+		values := make([]interface{}, len(row))
+		for i, v := range row {
+			if v == nil {
+				values[i] = "NULL"
+				continue
+			}
+			values[i] = fmt.Sprint(v)
+		}
+		w.Println(values...)
+	}
+	return tw.Flush()
+}
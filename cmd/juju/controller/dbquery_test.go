@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/client/controllerdb"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type dbQuerySuite struct {
+	baseControllerSuite
+	api   *fakeDBQueryAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&dbQuerySuite{})
+
+func (s *dbQuerySuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeDBQueryAPI{
+		result: controllerdb.QueryResult{
+			Columns: []string{"uuid", "alias"},
+			Rows: [][]interface{}{
+				{"ctrl-1", "one"},
+				{"ctrl-2", nil},
+			},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *dbQuerySuite) newCommand() cmd.Command {
+	return controller.NewDBQueryCommandForTest(s.api, s.store)
+}
+
+func (s *dbQuerySuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no query specified",
+	}, {
+		args: []string{" "},
+		err:  "no query specified",
+	}, {
+		args: []string{"--max-rows", "-1", "SELECT 1"},
+		err:  "negative --max-rows not valid",
+	}, {
+		args: []string{"--timeout", "-1s", "SELECT 1"},
+		err:  "negative --timeout not valid",
+	}} {
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *dbQuerySuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "SELECT", "uuid, alias", "FROM external_controller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+uuid    alias
+ctrl-1  one
+ctrl-2  NULL
+`[1:])
+	c.Assert(s.api.query, gc.Equals, "SELECT uuid, alias FROM external_controller")
+	c.Assert(s.api.maxRows, gc.Equals, 0)
+	c.Assert(s.api.timeout, gc.Equals, time.Duration(0))
+}
+
+func (s *dbQuerySuite) TestJSON(c *gc.C) {
+	s.api.result.Truncated = true
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--format", "json", "--max-rows", "2", "--timeout", "1m", "SELECT uuid, alias FROM external_controller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`{"columns":["uuid","alias"],"rows":[["ctrl-1","one"],["ctrl-2",null]],"truncated":true}`+"\n")
+	c.Assert(s.api.maxRows, gc.Equals, 2)
+	c.Assert(s.api.timeout, gc.Equals, time.Minute)
+}
+
+func (s *dbQuerySuite) TestError(c *gc.C) {
+	s.api.err = errors.NotValidf("DELETE statement")
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "DELETE FROM lease")
+	c.Assert(err, gc.ErrorMatches, "DELETE statement not valid")
+}
+
+type fakeDBQueryAPI struct {
+	query   string
+	maxRows int
+	timeout time.Duration
+	result  controllerdb.QueryResult
+	err     error
+}
+
+func (f *fakeDBQueryAPI) Close() error {
+	return nil
+}
+
+func (f *fakeDBQueryAPI) Query(query string, maxRows int, timeout time.Duration) (controllerdb.QueryResult, error) {
+	f.query = query
+	f.maxRows = maxRows
+	f.timeout = timeout
+	return f.result, f.err
+}
@@ -165,6 +165,14 @@ func NewConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cm
 	return modelcmd.WrapController(c)
 }
 
+// NewDBQueryCommandForTest returns a db-query command with
+// the api provided as specified.
+func NewDBQueryCommandForTest(api DBQueryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &dbQueryCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
@@ -283,6 +283,7 @@ func (c *modelsCommand) modelSummaryFromParams(apiSummary base.UserModelSummary,
 		status.Migration = apiSummary.Migration.Status
 		status.MigrationStart = common.FriendlyDuration(apiSummary.Migration.StartTime, now)
 		status.MigrationEnd = common.FriendlyDuration(apiSummary.Migration.EndTime, now)
+		status.MigrationSuspended = apiSummary.Migration.Suspended
 	}
 
 	if apiSummary.ProviderType != "" {
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/errors"
@@ -30,7 +31,9 @@ Shows detailed information of a controller.`[1:]
 
 var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
-and user login details.
+and user login details. Controller administrators are also shown the
+status of the controller's scheduled backups, once the first one has
+been taken.
 
 `[1:]
 
@@ -103,6 +106,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	BackupStatus() (status.StatusInfo, error)
 	Close() error
 }
 
@@ -197,6 +201,19 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				mongoVersion = "(error)"
 			}
+			// There is no backup status until the first scheduled
+			// backup is taken.
+			backupStatus, err := client.BackupStatus()
+			if err == nil {
+				details.Backups = &BackupStatusDetails{
+					Status:  backupStatus.Status.String(),
+					Message: backupStatus.Message,
+					Since:   backupStatus.Since,
+					Data:    backupStatus.Data,
+				}
+			} else if !errors.IsNotSupported(err) && !errors.IsNotFound(err) {
+				details.Errors = append(details.Errors, err.Error())
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -294,6 +311,9 @@ type ShowControllerDetails struct {
 	// Models is a collection of all models for this controller.
 	Models map[string]ModelDetails `yaml:"models,omitempty" json:"models,omitempty"`
 
+	// Backups holds the status of the controller's scheduled backups.
+	Backups *BackupStatusDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// CurrentModel is the name of the current model for this controller
 	CurrentModel string `yaml:"current-model,omitempty" json:"current-model,omitempty"`
 
@@ -360,6 +380,24 @@ type MachineDetails struct {
 	HAPrimary bool `yaml:"ha-primary,omitempty" json:"ha-primary,omitempty"`
 }
 
+// BackupStatusDetails holds the status of the controller's scheduled
+// backups to show.
+type BackupStatusDetails struct {
+	// Status is executing while a backup is being taken, active once it
+	// has been taken, and error if it failed.
+	Status string `yaml:"status" json:"status"`
+
+	// Message describes the status.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	// Since is when the status last changed.
+	Since *time.Time `yaml:"since,omitempty" json:"since,omitempty"`
+
+	// Data holds the filename and size of the last backup, and the
+	// bucket it was uploaded to.
+	Data map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
 // ModelDetails holds details of a model to show.
 type ModelDetails struct {
 	// ModelUUID holds the details of a model.
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd/v3"
 	"github.com/juju/cmd/v3/cmdtesting"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 )
@@ -462,6 +464,31 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackupStatus(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "backups:")
+
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = &status.StatusInfo{
+		Status:  status.Active,
+		Message: "backup created",
+		Data:    map[string]interface{}{"filename": "juju-backup-20240101-120000.tar.gz"},
+		Since:   &since,
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `
+  backups:
+    status: active
+    message: backup created
+    since: 2024-01-01T12:00:00Z
+    data:
+      filename: juju-backup-20240101-120000.tar.gz
+`[1:])
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -627,6 +654,7 @@ type fakeController struct {
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
 	emptyModelStatus  bool
+	backupStatus      *status.StatusInfo
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -687,6 +715,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) BackupStatus() (status.StatusInfo, error) {
+	if c.backupStatus == nil {
+		return status.StatusInfo{}, errors.NotFoundf("backup status")
+	}
+	return *c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -326,12 +326,6 @@ func (c *configCommand) setConfig(client configCommandAPI, attrs config.Attrs) e
 			}
 			return errors.Errorf(`%q must be set via "upgrade-model"`,
 				envconfig.AgentVersionKey)
-		} else if k == envconfig.CharmHubURLKey {
-			if c.ignoreReadOnlyFields {
-				continue
-			}
-			return errors.Errorf(`%q must be set via "add-model"`,
-				envconfig.CharmHubURLKey)
 		}
 
 		values[k] = v
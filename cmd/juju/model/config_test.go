@@ -187,8 +187,11 @@ func (s *ConfigCommandSuite) TestSetAgentVersion(c *gc.C) {
 }
 
 func (s *ConfigCommandSuite) TestSetCharmhubURL(c *gc.C) {
-	_, err := s.run(c, "charmhub-url=http://meshuggah.rocks")
-	c.Assert(err, gc.ErrorMatches, `"charmhub-url" must be set via "add-model"`)
+	// The controller decides whether charmhub-url may change, as it
+	// may only be moved to or from a controller's charmhub mirror.
+	_, err := s.run(c, "charmhub-url=https://10.0.0.1:17070/charmhub")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.values["charmhub-url"], gc.Equals, "https://10.0.0.1:17070/charmhub")
 }
 
 func (s *ConfigCommandSuite) TestSetAndReset(c *gc.C) {
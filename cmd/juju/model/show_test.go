@@ -348,6 +348,35 @@ basic-model:
 	s.assertShowOutput(c, "yaml")
 }
 
+func (s *ShowCommandSuite) TestShowBasicWithSuspendedMigrationYaml(c *gc.C) {
+	basicAndMigrationStatusInfo := createBasicModelInfo()
+	addMigrationStatusStatus(basicAndMigrationStatusInfo)
+	basicAndMigrationStatusInfo.Migration.Status = "suspended, model data transfer failed"
+	basicAndMigrationStatusInfo.Migration.Suspended = true
+	s.fake.infos = []params.ModelInfoResult{
+		{Result: basicAndMigrationStatusInfo},
+	}
+	s.expectedDisplay = `
+basic-model:
+  name: owner/basic-model
+  short-name: basic-model
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  model-type: iaas
+  controller-uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  controller-name: testing
+  is-controller: false
+  owner: owner
+  cloud: altostratus
+  region: mid-level
+  life: dead
+  status:
+    migration: suspended, model data transfer failed
+    migration-start: just now
+    migration-suspended: true
+`[1:]
+	s.assertShowOutput(c, "yaml")
+}
+
 func (s *ShowCommandSuite) TestShowBasicWithMigrationIncompleteModelsJson(c *gc.C) {
 	basicAndMigrationStatusInfo := createBasicModelInfo()
 	addMigrationStatusStatus(basicAndMigrationStatusInfo)
@@ -55,6 +55,7 @@ const addSecretBackendsExamples = `
     juju add-secret-backend myvault vault --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault token-rotate=10m --config /path/to/cfg.yaml
     juju add-secret-backend myvault vault endpoint=https://vault.io:8200 token=s.1wshwhw
+    juju add-secret-backend myfiles file path=/srv/juju-secrets key-file=/etc/juju/secrets.key
 `
 
 // AddSecretBackendsAPI is the secrets client API.
@@ -4,6 +4,7 @@
 package status
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/cmd/v3"
 
 	"github.com/juju/juju/cmd/modelcmd"
@@ -13,7 +14,16 @@ func NewTestStatusHistoryCommand(api HistoryAPI) cmd.Command {
 	return &statusHistoryCommand{api: api}
 }
 
+func NewTestStatusHistoryCommandWithClock(api HistoryAPI, clock clock.Clock) cmd.Command {
+	return &statusHistoryCommand{api: api, clock: clock}
+}
+
 func NewTestStatusCommand(statusapi statusAPI, clock Clock) cmd.Command {
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, clock: clock})
 }
+
+func NewTestStatusStreamCommand(statusapi statusAPI, clock Clock, watcher allWatcher) cmd.Command {
+	return modelcmd.Wrap(
+		&statusCommand{statusAPI: statusapi, clock: clock, allWatcher: watcher})
+}
@@ -11,22 +11,23 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v5"
 
+	"github.com/juju/juju/api/client/client"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/rpc/params"
 )
 
-// TODO(peritto666) - add tests
-
 // NewStatusHistoryCommand returns a command that reports the history
 // of status changes for the specified unit.
 func NewStatusHistoryCommand() cmd.Command {
@@ -36,20 +37,27 @@ func NewStatusHistoryCommand() cmd.Command {
 // HistoryAPI is the API surface for the show-status-log command.
 type HistoryAPI interface {
 	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	StatusHistories(requests ...client.StatusHistoryRequest) ([]client.StatusHistoryResult, error)
+	Status(*client.StatusArgs) (*params.FullStatus, error)
 	Close() error
 }
 
 type statusHistoryCommand struct {
 	modelcmd.ModelCommandBase
 	api             HistoryAPI
+	clock           clock.Clock
 	out             cmd.Output
 	outputContent   string
 	backlogSize     int
 	backlogSizeDays int
 	backlogDate     string
+	untilDate       string
 	isoTime         bool
+	export          bool
+	aggregate       bool
 	entityName      string
 	date            time.Time
+	until           time.Time
 }
 
 var statusHistoryDoc = fmt.Sprintf(`
@@ -60,12 +68,34 @@ The statuses are available for the following types.
 %v
  and sorted by time of occurrence.
  The default is unit.
+
+With --export, the workload and agent status history of every unit in
+the model, or in the specified application, is reported together. The
+time window defaults to the past day. The csv format is useful for
+loading the history into other tools.
+
+If only --to-date is specified, all of the history before that date
+is reported.
+
+With --aggregate, the exported history is summarised per application
+instead: the time units spent in each workload and agent status, the
+number of transitions into the error status, and the mean time taken
+to recover from an error. Each unit is considered to be in the last
+status it entered before the start of the time window until its first
+change within it. Durations in yaml, json and csv output are in
+seconds.
+
+Examples:
+
+    juju show-status-log mysql/0
+    juju show-status-log --export --days 7 --format csv
+    juju show-status-log --export mysql --aggregate --from-date 2024-01-01 --to-date 2024-02-01
 `, supportedHistoryKindDescs())
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "show-status-log",
-		Args:    "<entity name>",
+		Args:    "[<entity name>]",
 		Purpose: "Output past statuses for the specified entity.",
 		Doc:     statusHistoryDoc,
 	})
@@ -98,11 +128,15 @@ func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N logs (cannot be combined with --days or --date)")
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
+	f.StringVar(&c.untilDate, "to-date", "", "Returns logs for any date before the passed one, the expected date format is YYYY-MM-DD")
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.export, "export", false, "Report the unit status history of the whole model, or of the specified application")
+	f.BoolVar(&c.aggregate, "aggregate", false, "Report status history aggregates per application (requires --export)")
 
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
+		"csv":     c.formatCSV,
 		"tabular": c.formatTabular,
 	})
 }
@@ -112,12 +146,25 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	case len(args) > 1:
 		return errors.Errorf("unexpected arguments after entity name.")
 	case len(args) == 0:
-		if c.outputContent != status.KindModel.String() {
+		if !c.export && c.outputContent != status.KindModel.String() {
 			return errors.Errorf("entity name is missing.")
 		}
 	default:
 		c.entityName = args[0]
 	}
+	if c.export {
+		if c.outputContent != status.KindUnit.String() {
+			return errors.Errorf("--type cannot be combined with --export")
+		}
+		if c.entityName != "" && !names.IsValidApplication(c.entityName) {
+			return errors.Errorf("%q is not a valid name for an application", c.entityName)
+		}
+	} else if c.aggregate {
+		return errors.Errorf("--aggregate requires --export")
+	}
+	if c.clock == nil {
+		c.clock = clock.WallClock
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -133,7 +180,15 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	emptySize := c.backlogSize == 0
 	emptyDays := c.backlogSizeDays == 0
 	if emptyDate && emptySize && emptyDays {
-		c.backlogSize = 20
+		switch {
+		case c.untilDate != "":
+			// All of the history before the end date is reported.
+			c.date = time.Unix(0, 0).UTC()
+		case c.export:
+			c.backlogSizeDays = 1
+		default:
+			c.backlogSize = 20
+		}
 	}
 	if (!emptyDays && !emptySize) || (!emptyDays && !emptyDate) || (!emptySize && !emptyDate) {
 		return errors.Errorf("backlog size, backlog date and backlog days back cannot be specified together")
@@ -145,6 +200,16 @@ func (c *statusHistoryCommand) Init(args []string) error {
 			return errors.Annotate(err, "parsing backlog date")
 		}
 	}
+	if c.untilDate != "" {
+		var err error
+		c.until, err = time.Parse("2006-01-02", c.untilDate)
+		if err != nil {
+			return errors.Annotate(err, "parsing backlog end date")
+		}
+		if !c.date.IsZero() && !c.until.After(c.date) {
+			return errors.Errorf("backlog end date must be after backlog date")
+		}
+	}
 
 	kind := status.HistoryKind(c.outputContent)
 	if kind.Valid() {
@@ -160,6 +225,10 @@ type DetailedStatus struct {
 	Data    map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
 	Since   *time.Time             `yaml:"since,omitempty" json:"since,omitempty"`
 	Kind    status.HistoryKind     `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Entity is the name of the entity the status belongs to. It is
+	// only set when exporting the history of several entities.
+	Entity string `yaml:"entity,omitempty" json:"entity,omitempty"`
 }
 
 // History holds the status results.
@@ -193,6 +262,9 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	if !c.date.IsZero() {
 		filterArgs.FromDate = &c.date
 	}
+	if c.export {
+		return c.runExport(ctx, apiclient, filterArgs)
+	}
 	var tag names.Tag
 	switch kind {
 	case status.KindModel:
@@ -227,29 +299,42 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	}
 
-	if historyLen == 0 {
+	history := c.history("", statuses)
+	if len(history) == 0 {
 		return errors.Errorf("no status history available")
 	}
+	return c.out.Write(ctx, history)
+}
 
-	history := make(History, len(statuses))
-	for i, h := range statuses {
-		history[i] = DetailedStatus{
+// history converts the statuses of the named entity for output,
+// dropping any after the end of the time window.
+func (c *statusHistoryCommand) history(entity string, statuses status.History) History {
+	var history History
+	for _, h := range statuses {
+		if !c.until.IsZero() && h.Since != nil && !h.Since.Before(c.until) {
+			continue
+		}
+		history = append(history, DetailedStatus{
 			Status:  h.Status,
 			Message: h.Info,
 			Data:    h.Data,
 			Since:   h.Since,
 			Kind:    h.Kind,
-		}
+			Entity:  entity,
+		})
 	}
-	return c.out.Write(ctx, history)
+	return history
 }
 
 func (c *statusHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
-	h, ok := value.(History)
-	if !ok {
+	switch v := value.(type) {
+	case History:
+		c.writeTabular(writer, v)
+	case HistoryAggregates:
+		c.writeAggregatesTabular(writer, v)
+	default:
 		return errors.Errorf("expected value of type %T, got %T", History{}, value)
 	}
-	c.writeTabular(writer, h)
 	return nil
 }
 
@@ -257,8 +342,14 @@ func (c *statusHistoryCommand) writeTabular(writer io.Writer, statuses History)
 	tw := output.TabWriter(writer)
 	w := output.Wrapper{tw}
 
+	if c.export {
+		w.Print("Entity")
+	}
 	w.Println("Time", "Type", "Status", "Message")
 	for _, v := range statuses {
+		if c.export {
+			w.Print(v.Entity)
+		}
 		w.Print(common.FormatTime(v.Since, c.isoTime), v.Kind)
 		w.PrintStatus(v.Status)
 		w.Println(v.Message)
@@ -0,0 +1,377 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api/client/client"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+// exportedKinds are the kinds of status history exported for each unit.
+var exportedKinds = []status.HistoryKind{status.KindWorkload, status.KindUnitAgent}
+
+// HistoryAggregates holds the status history aggregates of several
+// applications.
+type HistoryAggregates []HistoryAggregate
+
+// HistoryAggregate holds aggregates computed from the status history of
+// an application's units.
+type HistoryAggregate struct {
+	Application string          `yaml:"application" json:"application"`
+	Units       int             `yaml:"units" json:"units"`
+	Workload    StatusAggregate `yaml:"workload" json:"workload"`
+	Agent       StatusAggregate `yaml:"juju-unit" json:"juju-unit"`
+}
+
+// StatusAggregate holds aggregates computed from a single kind of
+// status history. Durations are in seconds.
+type StatusAggregate struct {
+	// TimeInStatus holds the total time units spent in each status.
+	TimeInStatus map[status.Status]float64 `yaml:"time-in-status,omitempty" json:"time-in-status,omitempty"`
+
+	// ErrorTransitions is the number of times units entered the
+	// error status.
+	ErrorTransitions int `yaml:"error-transitions" json:"error-transitions"`
+
+	// Recoveries is the number of times units left the error status.
+	Recoveries int `yaml:"recoveries" json:"recoveries"`
+
+	// MeanTimeToRecovery is the mean time units spent in the error
+	// status before recovering. Errors that units have not yet
+	// recovered from are not included.
+	MeanTimeToRecovery float64 `yaml:"mean-time-to-recovery,omitempty" json:"mean-time-to-recovery,omitempty"`
+}
+
+// runExport reports the workload and agent status history of every
+// unit in the model, or in the requested application.
+func (c *statusHistoryCommand) runExport(ctx *cmd.Context, api HistoryAPI, filter status.StatusHistoryFilter) error {
+	var patterns []string
+	if c.entityName != "" {
+		patterns = []string{c.entityName}
+	}
+	fullStatus, err := api.Status(&client.StatusArgs{Patterns: patterns})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := fullStatus.Applications[c.entityName]; c.entityName != "" && !ok {
+		return errors.NotFoundf("application %q", c.entityName)
+	}
+	units := exportedUnits(fullStatus, c.entityName)
+	if len(units) == 0 {
+		return errors.Errorf("no units found")
+	}
+
+	// Aggregates need the status each unit was in at the start of the
+	// time window, so the history before it is fetched too.
+	start := c.windowStart(filter)
+	if c.aggregate && !start.IsZero() {
+		epoch := time.Unix(0, 0).UTC()
+		filter = status.StatusHistoryFilter{FromDate: &epoch}
+	}
+
+	var requests []client.StatusHistoryRequest
+	for _, unit := range units {
+		for _, kind := range exportedKinds {
+			requests = append(requests, client.StatusHistoryRequest{
+				Kind:   kind,
+				Tag:    names.NewUnitTag(unit),
+				Filter: filter,
+			})
+		}
+	}
+	results, err := api.StatusHistories(requests...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var history History
+	for i, result := range results {
+		unit := requests[i].Tag.Id()
+		if result.Error != nil {
+			// Display any error, but continue with the remaining units.
+			fmt.Fprintf(ctx.Stderr, "fetching %s status history for %s: %v\n", requests[i].Kind, unit, result.Error)
+			continue
+		}
+		history = append(history, c.history(unit, result.History)...)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return sinceOf(history[i]).Before(sinceOf(history[j]))
+	})
+
+	if c.aggregate {
+		end := c.until
+		if end.IsZero() {
+			end = c.clock.Now()
+		}
+		return c.out.Write(ctx, aggregateHistory(history, start, end))
+	}
+	if len(history) == 0 {
+		return errors.Errorf("no status history available")
+	}
+	return c.out.Write(ctx, history)
+}
+
+// windowStart returns the start of the time window of the input
+// filter, or the zero time if it is limited by size instead.
+func (c *statusHistoryCommand) windowStart(filter status.StatusHistoryFilter) time.Time {
+	switch {
+	case filter.FromDate != nil:
+		return *filter.FromDate
+	case filter.Delta != nil:
+		return c.clock.Now().Add(-*filter.Delta)
+	}
+	return time.Time{}
+}
+
+// exportedUnits returns the sorted names of the units in the model
+// status, including subordinates, restricted to those of the input
+// application if it is not empty.
+func exportedUnits(fullStatus *params.FullStatus, application string) []string {
+	var units []string
+	var addUnits func(map[string]params.UnitStatus)
+	addUnits = func(statuses map[string]params.UnitStatus) {
+		for name, unit := range statuses {
+			if appName, _ := names.UnitApplication(name); application == "" || appName == application {
+				units = append(units, name)
+			}
+			addUnits(unit.Subordinates)
+		}
+	}
+	for _, app := range fullStatus.Applications {
+		addUnits(app.Units)
+	}
+	sort.Strings(units)
+	return units
+}
+
+// statusAggregator accumulates the aggregates for a single kind of
+// status history.
+type statusAggregator struct {
+	StatusAggregate
+	recoveryTime time.Duration
+}
+
+// add accumulates the time ordered entries of a single unit's status
+// history, within the time window between the input times. Entries
+// before the start of the window are only used to seed the status at
+// its start, from the last of them.
+func (a *statusAggregator) add(entries History, start, end time.Time) {
+	for len(entries) > 1 && sinceOf(entries[1]).Before(start) {
+		entries = entries[1:]
+	}
+	var errorStart time.Time
+	inError := false
+	for i, entry := range entries {
+		since := sinceOf(entry)
+		seed := since.Before(start)
+		if seed {
+			since = start
+		}
+		next := end
+		if i+1 < len(entries) {
+			next = sinceOf(entries[i+1])
+		}
+		if next.After(since) {
+			if a.TimeInStatus == nil {
+				a.TimeInStatus = make(map[status.Status]float64)
+			}
+			a.TimeInStatus[entry.Status] += next.Sub(since).Seconds()
+		}
+
+		switch {
+		case entry.Status == status.Error && !inError:
+			// A unit already in error at the start of the window did
+			// not enter it within the window, but its recovery time
+			// still counts from when it did.
+			if !seed {
+				a.ErrorTransitions++
+			}
+			errorStart = sinceOf(entry)
+			inError = true
+		case entry.Status != status.Error && inError:
+			a.Recoveries++
+			a.recoveryTime += since.Sub(errorStart)
+			inError = false
+		}
+	}
+}
+
+func (a *statusAggregator) result() StatusAggregate {
+	result := a.StatusAggregate
+	if a.Recoveries > 0 {
+		result.MeanTimeToRecovery = (a.recoveryTime / time.Duration(a.Recoveries)).Seconds()
+	}
+	return result
+}
+
+// aggregateHistory computes the aggregates of each application from
+// the time ordered history of its units, within the time window
+// between the input times.
+func aggregateHistory(history History, start, end time.Time) HistoryAggregates {
+	type entityKind struct {
+		entity string
+		kind   status.HistoryKind
+	}
+	entries := make(map[entityKind]History)
+	for _, entry := range history {
+		key := entityKind{entity: entry.Entity, kind: entry.Kind}
+		entries[key] = append(entries[key], entry)
+	}
+
+	type applicationAggregator struct {
+		units    map[string]bool
+		workload statusAggregator
+		agent    statusAggregator
+	}
+	applications := make(map[string]*applicationAggregator)
+	for key, unitEntries := range entries {
+		appName, err := names.UnitApplication(key.entity)
+		if err != nil {
+			continue
+		}
+		app, ok := applications[appName]
+		if !ok {
+			app = &applicationAggregator{units: make(map[string]bool)}
+			applications[appName] = app
+		}
+		app.units[key.entity] = true
+		switch key.kind {
+		case status.KindWorkload:
+			app.workload.add(unitEntries, start, end)
+		case status.KindUnitAgent:
+			app.agent.add(unitEntries, start, end)
+		}
+	}
+
+	aggregates := make(HistoryAggregates, 0, len(applications))
+	for name, app := range applications {
+		aggregates = append(aggregates, HistoryAggregate{
+			Application: name,
+			Units:       len(app.units),
+			Workload:    app.workload.result(),
+			Agent:       app.agent.result(),
+		})
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		return aggregates[i].Application < aggregates[j].Application
+	})
+	return aggregates
+}
+
+func sinceOf(entry DetailedStatus) time.Time {
+	if entry.Since == nil {
+		return time.Time{}
+	}
+	return *entry.Since
+}
+
+func (c *statusHistoryCommand) writeAggregatesTabular(writer io.Writer, aggregates HistoryAggregates) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Application", "Type", "Units", "Errors", "Recoveries", "MTTR", "Time in status")
+	for _, app := range aggregates {
+		for _, kind := range exportedKinds {
+			aggregate := app.aggregate(kind)
+			mttr := "-"
+			if aggregate.Recoveries > 0 {
+				mttr = seconds(aggregate.MeanTimeToRecovery).String()
+			}
+			var times []string
+			for _, s := range sortedStatuses(aggregate.TimeInStatus) {
+				times = append(times, fmt.Sprintf("%s=%s", s, seconds(aggregate.TimeInStatus[s])))
+			}
+			w.Println(app.Application, kind, app.Units, aggregate.ErrorTransitions, aggregate.Recoveries, mttr, strings.Join(times, ", "))
+		}
+	}
+	tw.Flush()
+}
+
+func (a HistoryAggregate) aggregate(kind status.HistoryKind) StatusAggregate {
+	if kind == status.KindUnitAgent {
+		return a.Agent
+	}
+	return a.Workload
+}
+
+// seconds converts a number of seconds into a duration, rounded to
+// the nearest second for display.
+func seconds(s float64) time.Duration {
+	return (time.Duration(s * float64(time.Second))).Round(time.Second)
+}
+
+func sortedStatuses(times map[status.Status]float64) []status.Status {
+	statuses := make([]status.Status, 0, len(times))
+	for s := range times {
+		statuses = append(statuses, s)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	return statuses
+}
+
+// formatCSV writes status history, or status history aggregates, as
+// comma separated values with a header row. Aggregates are written
+// with a row for each application, status type and status.
+func (c *statusHistoryCommand) formatCSV(writer io.Writer, value interface{}) error {
+	w := csv.NewWriter(writer)
+	switch v := value.(type) {
+	case History:
+		_ = w.Write([]string{"entity", "type", "status", "message", "since"})
+		for _, entry := range v {
+			entity := entry.Entity
+			if entity == "" {
+				entity = c.entityName
+			}
+			since := ""
+			if entry.Since != nil {
+				since = entry.Since.UTC().Format(time.RFC3339)
+			}
+			_ = w.Write([]string{entity, string(entry.Kind), string(entry.Status), entry.Message, since})
+		}
+	case HistoryAggregates:
+		_ = w.Write([]string{
+			"application", "type", "units", "error-transitions", "recoveries",
+			"mean-time-to-recovery", "status", "time-in-status",
+		})
+		for _, app := range v {
+			for _, kind := range exportedKinds {
+				aggregate := app.aggregate(kind)
+				row := []string{
+					app.Application, string(kind), strconv.Itoa(app.Units),
+					strconv.Itoa(aggregate.ErrorTransitions), strconv.Itoa(aggregate.Recoveries),
+					formatSeconds(aggregate.MeanTimeToRecovery),
+				}
+				statuses := sortedStatuses(aggregate.TimeInStatus)
+				if len(statuses) == 0 {
+					_ = w.Write(append(row, "", ""))
+				}
+				for _, s := range statuses {
+					_ = w.Write(append(row, string(s), formatSeconds(aggregate.TimeInStatus[s])))
+				}
+			}
+		}
+	default:
+		return errors.Errorf("expected value of type %T, got %T", History{}, value)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatSeconds(s float64) string {
+	return strconv.FormatFloat(s, 'f', -1, 64)
+}
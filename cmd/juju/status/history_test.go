@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/v3"
 	"github.com/juju/cmd/v3/cmdtesting"
 	"github.com/juju/errors"
@@ -15,8 +16,10 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/client/client"
 	statuscmd "github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
 )
 
 type StatusHistorySuite struct {
@@ -157,9 +160,270 @@ func (s *StatusHistorySuite) TestYaml(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestCSV(c *gc.C) {
+	expected := `
+entity,type,status,message,since
+missing/0,juju-unit,allocating,,2017-11-28T12:34:56Z
+missing/0,workload,waiting,waiting for machine,2017-11-28T12:35:56Z
+`[1:]
+	s.api.(*fakeHistoryAPI).history = s.api.(*fakeHistoryAPI).history[:2]
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "missing/0", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+}
+
+func (s *StatusHistorySuite) TestToDate(c *gc.C) {
+	// Entries on or after the end date are dropped.
+	api := s.api.(*fakeHistoryAPI)
+	until := time.Date(2017, 11, 29, 0, 0, 0, 0, time.UTC)
+	api.history[1].Since = &until
+	api.history = api.history[:2]
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "missing/0", "--utc", "--to-date", "2017-11-29")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  Type       Status      Message
+2017-11-28 12:34:56Z  juju-unit  allocating  
+`[1:])
+
+	// The history before the end date is not limited in size.
+	epoch := time.Unix(0, 0).UTC()
+	c.Check(api.filter, jc.DeepEquals, status.StatusHistoryFilter{FromDate: &epoch})
+}
+
+func (s *StatusHistorySuite) TestExportInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--aggregate", "mysql/0"},
+		err:  "--aggregate requires --export",
+	}, {
+		args: []string{"--export", "--type", "workload"},
+		err:  "--type cannot be combined with --export",
+	}, {
+		args: []string{"--export", "mysql/0"},
+		err:  `"mysql/0" is not a valid name for an application`,
+	}, {
+		args: []string{"--export", "--from-date", "2024-02-01", "--to-date", "2024-01-01"},
+		err:  "backlog end date must be after backlog date",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *StatusHistorySuite) setUpExport() *fakeHistoryAPI {
+	at := func(minutes int) *time.Time {
+		t := time.Date(2024, 1, 1, 0, minutes, 0, 0, time.UTC)
+		return &t
+	}
+	api := &fakeHistoryAPI{
+		fullStatus: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {Units: map[string]params.UnitStatus{
+					"mysql/0": {Subordinates: map[string]params.UnitStatus{"logging/0": {}}},
+					"mysql/1": {Subordinates: map[string]params.UnitStatus{"logging/1": {}}},
+				}},
+				"logging": {},
+			},
+		},
+		histories: map[string]status.History{
+			"unit-mysql-0 workload": {
+				{Kind: status.KindWorkload, Status: status.Maintenance, Since: at(0)},
+				{Kind: status.KindWorkload, Status: status.Active, Info: "ready", Since: at(10)},
+				{Kind: status.KindWorkload, Status: status.Error, Info: "boom", Since: at(30)},
+				{Kind: status.KindWorkload, Status: status.Active, Info: "ready", Since: at(34)},
+			},
+			"unit-mysql-0 juju-unit": {
+				{Kind: status.KindUnitAgent, Status: status.Idle, Since: at(5)},
+			},
+			"unit-mysql-1 workload": {
+				{Kind: status.KindWorkload, Status: status.Active, Since: at(20)},
+				{Kind: status.KindWorkload, Status: status.Error, Info: "boom", Since: at(40)},
+				{Kind: status.KindWorkload, Status: status.Error, Info: "still boom", Since: at(42)},
+				{Kind: status.KindWorkload, Status: status.Blocked, Since: at(48)},
+				{Kind: status.KindWorkload, Status: status.Error, Info: "boom again", Since: at(50)},
+			},
+		},
+	}
+	s.api = api
+	return api
+}
+
+func (s *StatusHistorySuite) TestExport(c *gc.C) {
+	api := s.setUpExport()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--export", "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(api.patterns, jc.DeepEquals, []string{"mysql"})
+	c.Assert(api.requests, gc.HasLen, 4)
+	day := 24 * time.Hour
+	c.Check(api.requests[0], jc.DeepEquals, client.StatusHistoryRequest{
+		Kind:   status.KindWorkload,
+		Tag:    names.NewUnitTag("mysql/0"),
+		Filter: status.StatusHistoryFilter{Delta: &day},
+	})
+	c.Check(api.requests[3].Kind, gc.Equals, status.KindUnitAgent)
+	c.Check(api.requests[3].Tag, gc.Equals, names.NewUnitTag("mysql/1"))
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Entity   Time                  Type       Status       Message
+mysql/0  2024-01-01 00:00:00Z  workload   maintenance  
+mysql/0  2024-01-01 00:05:00Z  juju-unit  idle         
+mysql/0  2024-01-01 00:10:00Z  workload   active       ready
+mysql/1  2024-01-01 00:20:00Z  workload   active       
+mysql/0  2024-01-01 00:30:00Z  workload   error        boom
+mysql/0  2024-01-01 00:34:00Z  workload   active       ready
+mysql/1  2024-01-01 00:40:00Z  workload   error        boom
+mysql/1  2024-01-01 00:42:00Z  workload   error        still boom
+mysql/1  2024-01-01 00:48:00Z  workload   blocked      
+mysql/1  2024-01-01 00:50:00Z  workload   error        boom again
+`[1:])
+}
+
+func (s *StatusHistorySuite) TestExportModel(c *gc.C) {
+	api := s.setUpExport()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--export", "--days", "2", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(api.patterns, gc.HasLen, 0)
+	var units []string
+	for _, request := range api.requests {
+		if request.Kind == status.KindWorkload {
+			units = append(units, request.Tag.Id())
+		}
+	}
+	c.Check(units, jc.DeepEquals, []string{"logging/0", "logging/1", "mysql/0", "mysql/1"})
+	c.Check(cmdtesting.Stdout(ctx), jc.HasPrefix, `
+entity,type,status,message,since
+mysql/0,workload,maintenance,,2024-01-01T00:00:00Z
+mysql/0,juju-unit,idle,,2024-01-01T00:05:00Z
+`[1:])
+}
+
+func (s *StatusHistorySuite) TestExportUnknownApplication(c *gc.C) {
+	s.setUpExport()
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "--export", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *StatusHistorySuite) TestExportHistoryError(c *gc.C) {
+	api := s.setUpExport()
+	api.err = errors.New("boom")
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--export", "mysql", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+fetching juju-unit status history for mysql/1: boom
+`[1:])
+}
+
+func (s *StatusHistorySuite) TestAggregate(c *gc.C) {
+	s.setUpExport()
+	clock := testclock.NewClock(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC))
+	command := statuscmd.NewTestStatusHistoryCommandWithClock(s.api, clock)
+	ctx, err := cmdtesting.RunCommand(c, command, "--export", "mysql", "--aggregate", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// mysql/0 recovers from its error after 4 minutes, and mysql/1
+	// recovers from its first error after 8 minutes, but not from
+	// its second.
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+- application: mysql
+  units: 2
+  workload:
+    time-in-status:
+      active: 3960
+      blocked: 120
+      error: 1320
+      maintenance: 600
+    error-transitions: 3
+    recoveries: 2
+    mean-time-to-recovery: 360
+  juju-unit:
+    time-in-status:
+      idle: 3300
+    error-transitions: 0
+    recoveries: 0
+`[1:])
+
+	ctx, err = cmdtesting.RunCommand(c, statuscmd.NewTestStatusHistoryCommandWithClock(s.api, clock),
+		"--export", "mysql", "--aggregate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Application  Type       Units  Errors  Recoveries  MTTR  Time in status
+mysql        workload   2      3       2           6m0s  active=1h6m0s, blocked=2m0s, error=22m0s, maintenance=10m0s
+mysql        juju-unit  2      0       0           -     idle=55m0s
+`[1:])
+
+	ctx, err = cmdtesting.RunCommand(c, statuscmd.NewTestStatusHistoryCommandWithClock(s.api, clock),
+		"--export", "mysql", "--aggregate", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+application,type,units,error-transitions,recoveries,mean-time-to-recovery,status,time-in-status
+mysql,workload,2,3,2,360,active,3960
+mysql,workload,2,3,2,360,blocked,120
+mysql,workload,2,3,2,360,error,1320
+mysql,workload,2,3,2,360,maintenance,600
+mysql,juju-unit,2,0,0,0,idle,3300
+`[1:])
+}
+
+func (s *StatusHistorySuite) TestAggregateToDate(c *gc.C) {
+	// The history ends at the end date rather than now.
+	s.setUpExport()
+	clock := testclock.NewClock(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	command := statuscmd.NewTestStatusHistoryCommandWithClock(s.api, clock)
+	ctx, err := cmdtesting.RunCommand(c, command,
+		"--export", "mysql", "--aggregate", "--format", "json", "--from-date", "2023-12-31", "--to-date", "2024-01-02")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"juju-unit":{"time-in-status":{"idle":86100}`)
+}
+
+func (s *StatusHistorySuite) TestAggregateSeedsFromWindowStart(c *gc.C) {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	api := s.setUpExport()
+	api.histories = map[string]status.History{
+		"unit-mysql-0 workload": {
+			{Kind: status.KindWorkload, Status: status.Active, Since: at(1, 20)},
+			{Kind: status.KindWorkload, Status: status.Error, Info: "boom", Since: at(1, 22)},
+			{Kind: status.KindWorkload, Status: status.Active, Since: at(2, 2)},
+		},
+		"unit-mysql-0 juju-unit": {
+			{Kind: status.KindUnitAgent, Status: status.Idle, Since: at(1, 12)},
+		},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(),
+		"--export", "mysql", "--aggregate", "--from-date", "2024-01-02", "--to-date", "2024-01-03")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The history before the start of the window is fetched.
+	epoch := time.Unix(0, 0).UTC()
+	c.Assert(api.requests, gc.Not(gc.HasLen), 0)
+	c.Check(api.requests[0].Filter, jc.DeepEquals, status.StatusHistoryFilter{FromDate: &epoch})
+
+	// mysql/0 was already in error at the start of the window, so
+	// its recovery counts but its error transition does not, and
+	// its agent was idle throughout.
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Application  Type       Units  Errors  Recoveries  MTTR    Time in status
+mysql        workload   1      0       1           4h0m0s  active=22h0m0s, error=2h0m0s
+mysql        juju-unit  1      0       0           -       idle=24h0m0s
+`[1:])
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+	filter  status.StatusHistoryFilter
+
+	fullStatus *params.FullStatus
+	histories  map[string]status.History
+	patterns   []string
+	requests   []client.StatusHistoryRequest
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -167,5 +431,23 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.filter = filter
 	return f.history, f.err
 }
+
+func (f *fakeHistoryAPI) StatusHistories(requests ...client.StatusHistoryRequest) ([]client.StatusHistoryResult, error) {
+	f.requests = requests
+	results := make([]client.StatusHistoryResult, len(requests))
+	for i, request := range requests {
+		results[i].History = f.histories[request.Tag.String()+" "+string(request.Kind)]
+	}
+	if f.err != nil {
+		results[len(results)-1] = client.StatusHistoryResult{Error: f.err}
+	}
+	return results, nil
+}
+
+func (f *fakeHistoryAPI) Status(args *client.StatusArgs) (*params.FullStatus, error) {
+	f.patterns = args.Patterns
+	return f.fullStatus, nil
+}
@@ -74,6 +74,11 @@ type statusCommand struct {
 
 	// watch indicates the time to wait between consecutive status queries
 	watch time.Duration
+
+	// stream indicates that status changes are streamed from the
+	// model's all watcher, rather than polled.
+	stream     bool
+	allWatcher allWatcher
 }
 
 var usageSummary = `
@@ -149,6 +154,14 @@ Watch the status every five seconds:
 
     juju status --watch 5s
 
+Watch the status as it changes, highlighting changed rows:
+
+    juju status --stream
+
+Stream status changes as newline-delimited JSON events:
+
+    juju status --stream --format=json
+
 Show only applications/units in active status:
 
     juju status active
@@ -188,6 +201,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
 	f.DurationVar(&c.watch, "watch", 0, "Watch the status every period of time")
+	f.BoolVar(&c.stream, "stream", false, "Watch the status as it changes, without polling (tabular and json formats only)")
 
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
@@ -238,6 +252,14 @@ func (c *statusCommand) Init(args []string) error {
 	if c.color && c.noColor {
 		return errors.Errorf("cannot mix --no-color and --color")
 	}
+	if c.stream {
+		if c.watch != 0 {
+			return errors.Errorf("cannot mix --stream and --watch")
+		}
+		if name := c.out.Name(); name != "tabular" && name != "json" {
+			return errors.Errorf("--stream is not supported with the %s format", name)
+		}
+	}
 
 	return nil
 }
@@ -291,6 +313,47 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 		}
 	}
 
+	status, err := c.fetchStatus(ctx, showStorage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted, err := c.formatStatus(status, showIntegrations, showStorage)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err = c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+
+	if !status.IsEmpty() {
+		return nil
+	}
+	if len(c.patterns) == 0 {
+		modelName, err := c.ModelIdentifier()
+		if err != nil {
+			return err
+		}
+		// A change was made in cmd/v3.0.2 output.go that broke the consistency in output for the
+		// default formatter by removing the newline delimiter. Hence we prefix '\n' in the text below.
+		// https://github.com/juju/cmd/commit/be22fa661a798055c801f1511aee226db249ef95
+		ctx.Infof("\nModel %q is empty.", modelName)
+	} else {
+		plural := func() string {
+			if len(c.patterns) == 1 {
+				return ""
+			}
+			return "s"
+		}
+		ctx.Infof("Nothing matched specified filter%v.", plural())
+	}
+
+	return nil
+}
+
+// fetchStatus gets the status of the model, retrying if it fails.
+// Any error is displayed if some status was still returned.
+func (c *statusCommand) fetchStatus(ctx *cmd.Context, showStorage bool) (*params.FullStatus, error) {
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus(showStorage)
 	if err != nil && !modelcmd.IsModelMigratedError(err) {
@@ -309,21 +372,25 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
+	return status, nil
+}
 
+// formatStatus converts the status of the model for output.
+func (c *statusCommand) formatStatus(status *params.FullStatus, showIntegrations, showStorage bool) (formattedStatus, error) {
 	controllerName, err := c.ControllerName()
 	if err != nil {
-		return errors.Trace(err)
+		return formattedStatus{}, errors.Trace(err)
 	}
 	activeBranch, err := c.ActiveBranch()
 	if err != nil {
-		return errors.Trace(err)
+		return formattedStatus{}, errors.Trace(err)
 	}
 
 	formatterParams := NewStatusFormatterParams{
@@ -338,7 +405,7 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 		// TODO: move this into StatusFormatter
 		storageInfo, err := storage.CombinedStorageFromParams(status.Storage, status.Filesystems, status.Volumes)
 		if err != nil {
-			return errors.Trace(err)
+			return formattedStatus{}, errors.Trace(err)
 		}
 		formatterParams.Storage = storageInfo
 		if storageInfo == nil || storageInfo.Empty() {
@@ -349,38 +416,7 @@ func (c *statusCommand) runStatus(ctx *cmd.Context) error {
 		}
 	}
 
-	formatted, err := NewStatusFormatter(formatterParams).Format()
-	if err != nil {
-		return errors.Trace(err)
-	}
-
-	if err = c.out.Write(ctx, formatted); err != nil {
-		return err
-	}
-
-	if !status.IsEmpty() {
-		return nil
-	}
-	if len(c.patterns) == 0 {
-		modelName, err := c.ModelIdentifier()
-		if err != nil {
-			return err
-		}
-		// A change was made in cmd/v3.0.2 output.go that broke the consistency in output for the
-		// default formatter by removing the newline delimiter. Hence we prefix '\n' in the text below.
-		// https://github.com/juju/cmd/commit/be22fa661a798055c801f1511aee226db249ef95
-		ctx.Infof("\nModel %q is empty.", modelName)
-	} else {
-		plural := func() string {
-			if len(c.patterns) == 1 {
-				return ""
-			}
-			return "s"
-		}
-		ctx.Infof("Nothing matched specified filter%v.", plural())
-	}
-
-	return nil
+	return NewStatusFormatter(formatterParams).Format()
 }
 
 // statusCommandForViddy returns the full juju command including all args
@@ -414,6 +450,9 @@ func (c *statusCommand) statusCommandForViddy(args []string) []string {
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.stream {
+		return c.runStream(ctx)
+	}
 	if c.watch != 0 {
 		jujuStatusArgs := c.statusCommandForViddy(os.Args)
 
@@ -0,0 +1,538 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+// allWatcher is the part of the model's all watcher used to stream
+// status changes.
+type allWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(c *statusCommand) (allWatcher, error) {
+	if c.allWatcher != nil {
+		return c.allWatcher, nil
+	}
+	apiclient, err := newAPIClientForStatus(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	watcherAPI, ok := apiclient.(interface {
+		WatchAll() (*api.AllWatcher, error)
+	})
+	if !ok {
+		return nil, errors.NotSupportedf("streaming status")
+	}
+	watcher, err := watcherAPI.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// Kinds of entity reported as changed while streaming status.
+const (
+	streamModel             = "model"
+	streamMachine           = "machine"
+	streamApplication       = "application"
+	streamRemoteApplication = "remote-application"
+	streamUnit              = "unit"
+)
+
+// streamChange identifies an entity whose status has changed.
+type streamChange struct {
+	Kind    string
+	Id      string
+	Removed bool
+}
+
+// streamEvent is written for each change when streaming status in the
+// json format, one event per line.
+type streamEvent struct {
+	// Type is "snapshot" for the initial status of the model,
+	// and "change" or "remove" for subsequent changes.
+	Type   string      `json:"type"`
+	Kind   string      `json:"kind,omitempty"`
+	Id     string      `json:"id,omitempty"`
+	Status interface{} `json:"status,omitempty"`
+}
+
+// runStream reports the status of the model once, then keeps it up to
+// date by applying the deltas from the model's all watcher, rather than
+// polling for the full status.
+func (c *statusCommand) runStream(ctx *cmd.Context) error {
+	// Start watching before taking the snapshot, so no change is missed.
+	// The watcher's first deltas describe the whole model, and only
+	// those that differ from the snapshot are reported.
+	watcher, err := newAllWatcherForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Stopping the watcher on interrupt unblocks the pending Next call.
+	done := make(chan struct{})
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	go func() {
+		select {
+		case <-interrupted:
+			_ = watcher.Stop()
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		_ = watcher.Stop()
+	}()
+
+	status, err := c.fetchStatus(ctx, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stream := &statusStream{
+		status:   status,
+		filtered: len(c.patterns) > 0,
+	}
+	if err := c.writeStream(ctx, stream, nil); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		deltas, err := watcher.Next()
+		if err != nil {
+			select {
+			case <-interrupted:
+				return nil
+			default:
+			}
+			return errors.Trace(err)
+		}
+		if changes := stream.apply(deltas); len(changes) > 0 {
+			if err := c.writeStream(ctx, stream, changes); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// writeStream writes the current status of the stream: a snapshot if
+// there are no changes, or the changes otherwise.
+func (c *statusCommand) writeStream(ctx *cmd.Context, stream *statusStream, changes []streamChange) error {
+	tabular := c.out.Name() == "tabular"
+	formatted, err := c.formatStatus(stream.status, !tabular || c.integrations || c.relations, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !tabular {
+		return writeStreamEvents(ctx.Stdout, formatted, changes)
+	}
+
+	var buf bytes.Buffer
+	if err := c.FormatTabular(&buf, formatted); err != nil {
+		return errors.Trace(err)
+	}
+	if isTerminal(ctx.Stdout) {
+		// Redraw the status in place.
+		fmt.Fprint(ctx.Stdout, "\x1b[H\x1b[2J")
+	} else if changes != nil {
+		fmt.Fprintln(ctx.Stdout)
+	}
+	_, err = io.WriteString(ctx.Stdout, highlightChanges(buf.String(), changes))
+	return err
+}
+
+// writeStreamEvents writes the snapshot event, or an event for each
+// change, as newline-delimited JSON.
+func writeStreamEvents(w io.Writer, formatted formattedStatus, changes []streamChange) error {
+	events := []streamEvent{{Type: "snapshot", Status: formatted}}
+	if changes != nil {
+		events = events[:0]
+		for _, change := range changes {
+			event := streamEvent{Type: "change", Kind: change.Kind, Id: change.Id}
+			if change.Removed {
+				event.Type = "remove"
+			} else {
+				event.Status = formatted.entity(change.Kind, change.Id)
+			}
+			events = append(events, event)
+		}
+	}
+	encoder := json.NewEncoder(w)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// entity returns the formatted status of the identified entity.
+func (s formattedStatus) entity(kind, id string) interface{} {
+	switch kind {
+	case streamModel:
+		return s.Model
+	case streamApplication:
+		return s.Applications[id]
+	case streamRemoteApplication:
+		return s.RemoteApplications[id]
+	case streamMachine:
+		machines := s.Machines
+		parts := strings.Split(id, "/")
+		for i := 1; i < len(parts); i += 2 {
+			machines = machines[strings.Join(parts[:i], "/")].Containers
+		}
+		return machines[id]
+	case streamUnit:
+		for _, app := range s.Applications {
+			if unit, ok := findFormattedUnit(app.Units, id); ok {
+				return unit
+			}
+		}
+	}
+	return nil
+}
+
+func findFormattedUnit(units map[string]unitStatus, name string) (unitStatus, bool) {
+	for unitName, unit := range units {
+		if unitName == name {
+			return unit, true
+		}
+		if sub, ok := findFormattedUnit(unit.Subordinates, name); ok {
+			return sub, true
+		}
+	}
+	return unitStatus{}, false
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// highlightChanges marks the rows of the tabular status belonging to
+// changed entities. Every row is given a gutter, which holds the marker
+// for changed rows.
+func highlightChanges(tabular string, changes []streamChange) string {
+	changed := make(map[string]bool)
+	modelChanged := false
+	for _, change := range changes {
+		if change.Kind == streamModel {
+			modelChanged = true
+			continue
+		}
+		changed[change.Id] = true
+	}
+
+	lines := strings.SplitAfter(tabular, "\n")
+	var buf strings.Builder
+	section, row := 0, 0
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		plain := ansiEscape.ReplaceAllString(line, "")
+		if strings.TrimSpace(plain) == "" {
+			section++
+			row = 0
+			buf.WriteString(line)
+			continue
+		}
+		row++
+		marker := "  "
+		if row > 1 {
+			if section == 0 {
+				// The first section describes the model.
+				if modelChanged {
+					marker = "> "
+				}
+			} else if fields := strings.Fields(plain); changed[strings.TrimSuffix(fields[0], "*")] {
+				marker = "> "
+			}
+		}
+		buf.WriteString(marker + line)
+	}
+	return buf.String()
+}
+
+// statusStream keeps a model status snapshot up to date by applying the
+// deltas from the model's all watcher. Only the model, machines,
+// applications, remote applications and units are updated; other parts
+// of the status, such as integrations, are those of the snapshot.
+type statusStream struct {
+	status *params.FullStatus
+
+	// filtered is true if the snapshot was filtered. Entities that
+	// are not in the snapshot are then ignored, rather than added.
+	filtered bool
+}
+
+// apply updates the status with the deltas, returning the entities
+// whose status changed.
+func (s *statusStream) apply(deltas []params.Delta) []streamChange {
+	var changes []streamChange
+	for _, delta := range deltas {
+		var (
+			change  streamChange
+			changed bool
+		)
+		switch info := delta.Entity.(type) {
+		case *params.ModelUpdate:
+			change = streamChange{Kind: streamModel, Id: info.Name}
+			changed = !delta.Removed && s.applyModel(info)
+		case *params.MachineInfo:
+			change = streamChange{Kind: streamMachine, Id: info.Id, Removed: delta.Removed}
+			changed = s.applyMachine(info, delta.Removed)
+		case *params.ApplicationInfo:
+			change = streamChange{Kind: streamApplication, Id: info.Name, Removed: delta.Removed}
+			changed = s.applyApplication(info, delta.Removed)
+		case *params.RemoteApplicationUpdate:
+			change = streamChange{Kind: streamRemoteApplication, Id: info.Name, Removed: delta.Removed}
+			changed = s.applyRemoteApplication(info, delta.Removed)
+		case *params.UnitInfo:
+			change = streamChange{Kind: streamUnit, Id: info.Name, Removed: delta.Removed}
+			changed = s.applyUnit(info, delta.Removed)
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func (s *statusStream) applyModel(info *params.ModelUpdate) bool {
+	model := &s.status.Model
+	changed := updateStatus(&model.ModelStatus, info.Status)
+	if info.Version != "" && model.Version != info.Version {
+		model.Version = info.Version
+		changed = true
+	}
+	if model.SLA != info.SLA.Level {
+		model.SLA = info.SLA.Level
+		changed = true
+	}
+	return changed
+}
+
+func (s *statusStream) applyMachine(info *params.MachineInfo, removed bool) bool {
+	machines := s.status.Machines
+	parts := strings.Split(info.Id, "/")
+	for i := 1; i < len(parts); i += 2 {
+		parentId := strings.Join(parts[:i], "/")
+		parent, ok := machines[parentId]
+		if !ok {
+			return false
+		}
+		if parent.Containers == nil {
+			parent.Containers = make(map[string]params.MachineStatus)
+			machines[parentId] = parent
+		}
+		machines = parent.Containers
+	}
+	if machines == nil {
+		machines = make(map[string]params.MachineStatus)
+		s.status.Machines = machines
+	}
+
+	machine, ok := machines[info.Id]
+	if removed || (!ok && s.filtered) {
+		delete(machines, info.Id)
+		return ok
+	}
+	changed := !ok
+	if !ok {
+		machine = params.MachineStatus{
+			Id:          info.Id,
+			AgentStatus: params.DetailedStatus{Kind: "machine"},
+			InstanceStatus: params.DetailedStatus{
+				Kind: "machine",
+			},
+			Jobs: info.Jobs,
+		}
+	}
+	changed = updateStatus(&machine.AgentStatus, info.AgentStatus) || changed
+	changed = updateString(&machine.AgentStatus.Life, statusLife(info.Life)) || changed
+	changed = updateStatus(&machine.InstanceStatus, info.InstanceStatus) || changed
+	changed = updateString(&machine.InstanceId, instance.Id(info.InstanceId)) || changed
+	changed = updateString(&machine.Hostname, info.Hostname) || changed
+	changed = updateBool(&machine.HasVote, info.HasVote) || changed
+	changed = updateBool(&machine.WantsVote, info.WantsVote) || changed
+	machines[info.Id] = machine
+	return changed
+}
+
+func (s *statusStream) applyApplication(info *params.ApplicationInfo, removed bool) bool {
+	if s.status.Applications == nil {
+		s.status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	app, ok := s.status.Applications[info.Name]
+	if removed || (!ok && s.filtered) {
+		delete(s.status.Applications, info.Name)
+		return ok
+	}
+	changed := !ok
+	if !ok {
+		app = params.ApplicationStatus{Status: params.DetailedStatus{Kind: "application"}}
+	}
+	changed = updateStatus(&app.Status, info.Status) || changed
+	changed = updateString(&app.Charm, info.CharmURL) || changed
+	changed = updateString(&app.Life, statusLife(info.Life)) || changed
+	changed = updateBool(&app.Exposed, info.Exposed) || changed
+	changed = updateString(&app.WorkloadVersion, info.WorkloadVersion) || changed
+	s.status.Applications[info.Name] = app
+	return changed
+}
+
+func (s *statusStream) applyRemoteApplication(info *params.RemoteApplicationUpdate, removed bool) bool {
+	if s.status.RemoteApplications == nil {
+		s.status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	}
+	app, ok := s.status.RemoteApplications[info.Name]
+	if removed || (!ok && s.filtered) {
+		delete(s.status.RemoteApplications, info.Name)
+		return ok
+	}
+	changed := !ok
+	if !ok {
+		app = params.RemoteApplicationStatus{OfferName: info.Name}
+	}
+	changed = updateStatus(&app.Status, info.Status) || changed
+	changed = updateString(&app.OfferURL, info.OfferURL) || changed
+	changed = updateString(&app.Life, statusLife(info.Life)) || changed
+	s.status.RemoteApplications[info.Name] = app
+	return changed
+}
+
+func (s *statusStream) applyUnit(info *params.UnitInfo, removed bool) bool {
+	app, ok := s.status.Applications[info.Application]
+	if !ok {
+		return false
+	}
+
+	// Subordinate units are held by their principal unit.
+	units := app.Units
+	var principalApp params.ApplicationStatus
+	principal := info.Principal
+	if principal != "" {
+		principalAppName, err := names.UnitApplication(principal)
+		if err != nil {
+			return false
+		}
+		if principalApp, ok = s.status.Applications[principalAppName]; !ok {
+			return false
+		}
+		principalUnit, ok := principalApp.Units[principal]
+		if !ok {
+			return false
+		}
+		if principalUnit.Subordinates == nil {
+			principalUnit.Subordinates = make(map[string]params.UnitStatus)
+			principalApp.Units[principal] = principalUnit
+		}
+		units = principalUnit.Subordinates
+	} else if units == nil {
+		units = make(map[string]params.UnitStatus)
+		app.Units = units
+		s.status.Applications[info.Application] = app
+	}
+
+	unit, ok := units[info.Name]
+	if removed || (!ok && s.filtered) {
+		delete(units, info.Name)
+		return ok
+	}
+	changed := !ok
+	if !ok {
+		unit = params.UnitStatus{
+			AgentStatus:    params.DetailedStatus{Kind: "unit"},
+			WorkloadStatus: params.DetailedStatus{Kind: "workload"},
+		}
+	}
+	changed = updateStatus(&unit.AgentStatus, info.AgentStatus) || changed
+	changed = updateString(&unit.AgentStatus.Life, statusLife(info.Life)) || changed
+	changed = updateStatus(&unit.WorkloadStatus, info.WorkloadStatus) || changed
+	changed = updateString(&unit.PublicAddress, info.PublicAddress) || changed
+	if principal == "" {
+		changed = updateString(&unit.Machine, info.MachineId) || changed
+	}
+
+	var ports []string
+	for _, portRange := range info.PortRanges {
+		ports = append(ports, portRange.NetworkPortRange().String())
+	}
+	sort.Strings(ports)
+	current := append([]string(nil), unit.OpenedPorts...)
+	sort.Strings(current)
+	if len(ports) != len(current) || (len(ports) > 0 && !reflect.DeepEqual(ports, current)) {
+		unit.OpenedPorts = ports
+		changed = true
+	}
+	units[info.Name] = unit
+	return changed
+}
+
+// updateStatus updates the status with the input status info,
+// reporting whether anything changed.
+func updateStatus(status *params.DetailedStatus, info params.StatusInfo) bool {
+	changed := status.Status != string(info.Current) ||
+		status.Info != info.Message ||
+		status.Version != info.Version ||
+		!sameTime(status.Since, info.Since) ||
+		(len(status.Data) > 0 || len(info.Data) > 0) && !reflect.DeepEqual(status.Data, info.Data)
+	status.Status = string(info.Current)
+	status.Info = info.Message
+	status.Version = info.Version
+	status.Since = info.Since
+	status.Data = info.Data
+	return changed
+}
+
+// statusLife returns the life value as reported in the full status,
+// where alive is omitted.
+func statusLife(l life.Value) life.Value {
+	if l == life.Alive {
+		return ""
+	}
+	return l
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func updateString[T ~string](field *T, value T) bool {
+	if *field == value {
+		return false
+	}
+	*field = value
+	return true
+}
+
+func updateBool(field *bool, value bool) bool {
+	if *field == value {
+		return false
+	}
+	*field = value
+	return true
+}
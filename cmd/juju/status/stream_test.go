@@ -0,0 +1,260 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/status"
+	corelife "github.com/juju/juju/core/life"
+	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/testing"
+)
+
+type StreamStatusSuite struct {
+	testing.BaseSuite
+
+	statusapi *fakeStatusAPI
+	watcher   *fakeAllWatcher
+	since     time.Time
+}
+
+var _ = gc.Suite(&StreamStatusSuite{})
+
+func (s *StreamStatusSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.statusapi = &fakeStatusAPI{
+		result: &params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:     "test",
+				CloudTag: "cloud-foo",
+				Version:  "3.5.0",
+			},
+			Machines: map[string]params.MachineStatus{
+				"0": {
+					Id:             "0",
+					AgentStatus:    s.status("started", ""),
+					InstanceStatus: s.status("running", ""),
+					InstanceId:     "i-0",
+					DNSName:        "10.0.0.1",
+				},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "ch:mysql-1",
+					Status: s.status("active", ""),
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							AgentStatus:    s.status("idle", ""),
+							WorkloadStatus: s.status("active", "ready"),
+							Machine:        "0",
+							PublicAddress:  "10.0.0.1",
+						},
+					},
+				},
+			},
+		},
+	}
+	s.watcher = &fakeAllWatcher{}
+	s.SetModelAndController(c, "test", "admin/test")
+}
+
+func (s *StreamStatusSuite) status(current, message string) params.DetailedStatus {
+	return params.DetailedStatus{Status: current, Info: message, Since: &s.since}
+}
+
+func (s *StreamStatusSuite) statusInfo(current corestatus.Status, message string) params.StatusInfo {
+	return params.StatusInfo{Current: current, Message: message, Since: &s.since}
+}
+
+func (s *StreamStatusSuite) unitInfo(workload corestatus.Status, message string) *params.UnitInfo {
+	return &params.UnitInfo{
+		Name:           "mysql/0",
+		Application:    "mysql",
+		MachineId:      "0",
+		PublicAddress:  "10.0.0.1",
+		Life:           corelife.Alive,
+		AgentStatus:    s.statusInfo(corestatus.Idle, ""),
+		WorkloadStatus: s.statusInfo(workload, message),
+	}
+}
+
+func (s *StreamStatusSuite) runStream(c *gc.C, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStatusStreamCommand(s.statusapi, &timeRecorder{}, s.watcher)
+	return cmdtesting.RunCommand(c, statusCmd, append([]string{"--stream"}, args...)...)
+}
+
+func (s *StreamStatusSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runStream(c, "--watch", "5s")
+	c.Check(err, gc.ErrorMatches, "cannot mix --stream and --watch")
+	_, err = s.runStream(c, "--format", "yaml")
+	c.Check(err, gc.ErrorMatches, "--stream is not supported with the yaml format")
+}
+
+func (s *StreamStatusSuite) TestTabular(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{
+		// The unit is as in the snapshot, so there is nothing to report.
+		{Entity: s.unitInfo(corestatus.Active, "ready")},
+	}, {
+		{Entity: s.unitInfo(corestatus.Error, "hook failed")},
+	}}
+	ctx, err := s.runStream(c, "--no-color")
+	c.Assert(err, gc.ErrorMatches, "watcher stopped")
+	c.Check(s.watcher.stopped, jc.IsTrue)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+  Model  Controller  Cloud/Region  Version
+  test   test        foo           3.5.0  
+
+  App    Version  Status  Scale  Charm  Channel  Rev  Exposed  Message
+  mysql           active      1  mysql             1  no       
+
+  Unit     Workload  Agent  Machine  Public address  Ports  Message
+  mysql/0  active    idle   0        10.0.0.1               ready
+
+  Machine  State    Address   Inst id  Base  AZ  Message
+  0        started  10.0.0.1  i-0                
+
+  Model  Controller  Cloud/Region  Version
+  test   test        foo           3.5.0  
+
+  App    Version  Status  Scale  Charm  Channel  Rev  Exposed  Message
+  mysql           active      1  mysql             1  no       
+
+  Unit     Workload  Agent  Machine  Public address  Ports  Message
+> mysql/0  error     idle   0        10.0.0.1               hook failed
+
+  Machine  State    Address   Inst id  Base  AZ  Message
+  0        started  10.0.0.1  i-0                
+`[1:])
+}
+
+func (s *StreamStatusSuite) TestTabularModelChange(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{
+		{Entity: &params.ModelUpdate{
+			Name:    "test",
+			Version: "3.5.1",
+			Status:  params.StatusInfo{Current: corestatus.Available},
+		}},
+	}}
+	ctx, err := s.runStream(c, "--no-color")
+	c.Assert(err, gc.ErrorMatches, "watcher stopped")
+	frames := strings.Split(cmdtesting.Stdout(ctx), "\n\n  Model")
+	c.Assert(frames, gc.HasLen, 2)
+	c.Check(frames[1], jc.HasPrefix, `  Controller  Cloud/Region  Version
+> test   test        foo           3.5.1  
+`)
+}
+
+func (s *StreamStatusSuite) TestJSON(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{
+		{Entity: s.unitInfo(corestatus.Active, "ready")},
+		{Entity: &params.MachineInfo{
+			Id:             "0",
+			InstanceId:     "i-0",
+			AgentStatus:    s.statusInfo(corestatus.Down, "agent lost"),
+			InstanceStatus: s.statusInfo(corestatus.Running, ""),
+			Life:           corelife.Alive,
+		}},
+		// Charms and other entities not in the status are ignored.
+		{Entity: &params.CharmInfo{CharmURL: "ch:mysql-2"}},
+	}, {
+		{Entity: s.unitInfo(corestatus.Error, "hook failed")},
+	}, {
+		{Entity: s.unitInfo(corestatus.Error, "hook failed"), Removed: true},
+		{Entity: &params.UnitInfo{
+			Name:           "mysql/1",
+			Application:    "mysql",
+			MachineId:      "0",
+			AgentStatus:    s.statusInfo(corestatus.Allocating, ""),
+			WorkloadStatus: s.statusInfo(corestatus.Waiting, "waiting for machine"),
+		}},
+	}}
+	ctx, err := s.runStream(c, "--format", "json", "--no-color")
+	c.Assert(err, gc.ErrorMatches, "watcher stopped")
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n") {
+		var event map[string]interface{}
+		c.Assert(json.Unmarshal([]byte(line), &event), jc.ErrorIsNil)
+		events = append(events, event)
+	}
+	c.Assert(events, gc.HasLen, 5)
+
+	c.Check(events[0]["type"], gc.Equals, "snapshot")
+	snapshot := events[0]["status"].(map[string]interface{})
+	c.Check(snapshot["model"].(map[string]interface{})["name"], gc.Equals, "test")
+
+	c.Check(events[1]["type"], gc.Equals, "change")
+	c.Check(events[1]["kind"], gc.Equals, "machine")
+	c.Check(events[1]["id"], gc.Equals, "0")
+	machine := events[1]["status"].(map[string]interface{})
+	c.Check(machine["juju-status"].(map[string]interface{})["current"], gc.Equals, "down")
+
+	c.Check(events[2]["type"], gc.Equals, "change")
+	c.Check(events[2]["kind"], gc.Equals, "unit")
+	c.Check(events[2]["id"], gc.Equals, "mysql/0")
+	unit := events[2]["status"].(map[string]interface{})
+	c.Check(unit["workload-status"].(map[string]interface{})["current"], gc.Equals, "error")
+	c.Check(unit["workload-status"].(map[string]interface{})["message"], gc.Equals, "hook failed")
+
+	c.Check(events[3], jc.DeepEquals, map[string]interface{}{
+		"type": "remove",
+		"kind": "unit",
+		"id":   "mysql/0",
+	})
+
+	c.Check(events[4]["type"], gc.Equals, "change")
+	c.Check(events[4]["id"], gc.Equals, "mysql/1")
+	unit = events[4]["status"].(map[string]interface{})
+	c.Check(unit["juju-status"].(map[string]interface{})["current"], gc.Equals, "allocating")
+}
+
+func (s *StreamStatusSuite) TestFilteredIgnoresNewEntities(c *gc.C) {
+	s.watcher.deltas = [][]params.Delta{{
+		{Entity: &params.UnitInfo{
+			Name:        "mysql/1",
+			Application: "mysql",
+			AgentStatus: s.statusInfo(corestatus.Allocating, ""),
+		}},
+		{Entity: &params.ApplicationInfo{
+			Name:   "wordpress",
+			Status: s.statusInfo(corestatus.Active, ""),
+		}},
+		{Entity: s.unitInfo(corestatus.Maintenance, "upgrading")},
+	}}
+	ctx, err := s.runStream(c, "--format", "json", "mysql")
+	c.Assert(err, gc.ErrorMatches, "watcher stopped")
+	lines := strings.Split(strings.TrimSpace(cmdtesting.Stdout(ctx)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	c.Check(lines[1], jc.HasPrefix, `{"type":"change","kind":"unit","id":"mysql/0",`)
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]params.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher stopped")
+	}
+	deltas := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return deltas, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -63,6 +63,15 @@ Waits for all the application units to start with ubuntu and to be created
 and available.
 
     juju wait-for application ubuntu --query='forEach(units, unit => unit.life=="alive" && unit.status=="available" && startsWith(unit.name, "ubuntu"))'
+
+Waits for at least 80% of the application units to be active.
+
+    juju wait-for application ubuntu --query='percent(units, unit => unit.workload-status == "active") >= 80'
+
+Waits for at least 3 units to be active or idle, and for the application
+status to have been settled for more than 5 minutes.
+
+    juju wait-for application ubuntu --query='count(units, unit => unit.workload-status in ["active", "idle"]) >= 3 && since > 5m'
 `
 
 // applicationCommand defines a command for waiting for applications.
@@ -254,7 +263,7 @@ func MakeApplicationScope(ctx ScopeContext,
 // GetIdents returns the identifiers with in a given scope.
 func (m ApplicationScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.ApplicationInfo)...)
-	return set.NewStrings("units", "machines").Union(idents).SortedValues()
+	return set.NewStrings("units", "machines", "since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -276,6 +285,8 @@ func (m ApplicationScope) GetIdentValue(name string) (query.Box, error) {
 		return query.NewBool(m.ApplicationInfo.Subordinate), nil
 	case "status":
 		return query.NewString(string(m.ApplicationInfo.Status.Current)), nil
+	case "since":
+		return statusSince(m.ApplicationInfo.Status), nil
 	case "workload-version":
 		return query.NewString(m.ApplicationInfo.WorkloadVersion), nil
 	case "units":
@@ -305,6 +316,7 @@ func (m ApplicationScope) GetIdentValue(name string) (query.Box, error) {
 				scopes[k] = MakeMachineScope(m.ctx.Child(name, machine.Id), machine)
 			}
 		}
+		return NewScopedBox(scopes), nil
 	}
 	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on ApplicationInfo", name)
 }
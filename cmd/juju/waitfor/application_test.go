@@ -4,6 +4,8 @@
 package waitfor
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -21,6 +23,7 @@ type applicationScopeSuite struct {
 var _ = gc.Suite(&applicationScopeSuite{})
 
 func (s *applicationScopeSuite) TestGetIdentValue(c *gc.C) {
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		Field           string
 		ApplicationInfo *params.ApplicationInfo
@@ -47,6 +50,16 @@ func (s *applicationScopeSuite) TestGetIdentValue(c *gc.C) {
 			Current: status.Active,
 		}},
 		Expected: query.NewString("active"),
+	}, {
+		Field: "since",
+		ApplicationInfo: &params.ApplicationInfo{Status: params.StatusInfo{
+			Since: &since,
+		}},
+		Expected: query.NewTime(since),
+	}, {
+		Field:           "since",
+		ApplicationInfo: &params.ApplicationInfo{},
+		Expected:        query.NewTime(time.Time{}),
 	}, {
 		Field:           "workload-version",
 		ApplicationInfo: &params.ApplicationInfo{WorkloadVersion: "1.2.3"},
@@ -74,6 +87,45 @@ func (s *applicationScopeSuite) TestGetIdentValueError(c *gc.C) {
 	c.Assert(result, gc.IsNil)
 }
 
+func (s *applicationScopeSuite) TestAggregates(c *gc.C) {
+	since := time.Now().Add(-10 * time.Minute)
+	units := map[string]*params.UnitInfo{
+		"ubuntu/0": {Name: "ubuntu/0", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+		"ubuntu/1": {Name: "ubuntu/1", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+		"ubuntu/2": {Name: "ubuntu/2", WorkloadStatus: params.StatusInfo{Current: status.Idle}},
+		"ubuntu/3": {Name: "ubuntu/3", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+		"ubuntu/4": {Name: "ubuntu/4", WorkloadStatus: params.StatusInfo{Current: status.Blocked}},
+	}
+	scope := MakeApplicationScope(MakeScopeContext(), &params.ApplicationInfo{
+		Name:   "ubuntu",
+		Status: params.StatusInfo{Current: status.Active, Since: &since},
+	}, units, nil)
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `count(units) == 5`, Expected: true},
+		{Query: `percent(units, unit => unit.workload-status == "active") >= 60`, Expected: true},
+		{Query: `percent(units, unit => unit.workload-status == "active") >= 80`, Expected: false},
+		{Query: `count(units, unit => unit.workload-status in ["active", "idle"]) >= 3 && since > 5m`, Expected: true},
+		{Query: `all(units, unit => unit.name =~ "^ubuntu/")`, Expected: true},
+		{Query: `any(units, unit => unit.workload-status == "blocked")`, Expected: true},
+		{Query: `"ubuntu/4" in units`, Expected: true},
+		{Query: `"mysql/0" in units`, Expected: false},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		q, err := query.Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := runQuery(test.Query, q, scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.Equals, test.Expected)
+	}
+}
+
 func (s *applicationScopeSuite) TestDeriveApplicationStatus(c *gc.C) {
 	tests := []struct {
 		status   status.Status
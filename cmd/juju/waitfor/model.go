@@ -75,6 +75,10 @@ Waits for the model to be created and available and for all the model
 applications to be active.
 
     juju wait-for model default --query='life=="alive" && status=="available" && forEach(applications, app => app.status == "active")'
+
+Waits for any of the model applications named like mysql to be blocked.
+
+    juju wait-for model default --query='any(applications, app => app.name =~ "^mysql" && app.status == "blocked")'
 `
 
 // modelCommand defines a command for waiting for models.
@@ -300,7 +304,7 @@ func MakeModelScope(ctx ScopeContext,
 // GetIdents returns the identifiers with in a given scope.
 func (m ModelScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.ModelInfo)...)
-	return set.NewStrings("applications", "machines", "units").Union(idents).SortedValues()
+	return set.NewStrings("applications", "machines", "units", "since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -318,6 +322,9 @@ func (m ModelScope) GetIdentValue(name string) (query.Box, error) {
 	case "status":
 		m.ctx.RecordIdent(name)
 		return query.NewString(string(m.ModelInfo.Status.Current)), nil
+	case "since":
+		m.ctx.RecordIdent(name)
+		return statusSince(m.ModelInfo.Status), nil
 	case "config":
 		m.ctx.RecordIdent(name)
 		return query.NewMapStringInterface(m.ModelInfo.Config), nil
@@ -400,6 +407,15 @@ func (o *ScopedBox) Value() any {
 	return o
 }
 
+// Keys returns the names of the scopes in the box.
+func (o *ScopedBox) Keys() []string {
+	keys := make([]string, 0, len(o.scopes))
+	for k := range o.scopes {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // ForEach iterates over each value in the box.
 func (o *ScopedBox) ForEach(fn func(any) bool) {
 	for _, v := range o.scopes {
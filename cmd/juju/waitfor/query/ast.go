@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -343,6 +344,59 @@ func (i *Float) End() Position {
 
 func (i *Float) String() string { return i.Token.Literal }
 
+// Duration represents a duration for a given AST block
+type Duration struct {
+	Token Token
+	Value time.Duration
+}
+
+// Pos returns the first position of the duration.
+func (i *Duration) Pos() Position {
+	return i.Token.Pos
+}
+
+// End returns the last position of the duration.
+func (i *Duration) End() Position {
+	length := utf8.RuneCountInString(i.Token.Literal)
+	return Position{
+		Line:   i.Token.Pos.Line,
+		Column: i.Token.Pos.Column + length,
+	}
+}
+
+func (i *Duration) String() string { return i.Token.Literal }
+
+// ListExpression represents a list of expressions, eg ["a", "b"].
+type ListExpression struct {
+	Token    Token
+	Elements []Expression
+	EndToken Token
+}
+
+// Pos returns the first position of the list.
+func (ie *ListExpression) Pos() Position {
+	return ie.Token.Pos
+}
+
+// End returns the last position of the list.
+func (ie *ListExpression) End() Position {
+	return ie.EndToken.Pos
+}
+
+func (ie *ListExpression) String() string {
+	var out bytes.Buffer
+
+	var elements []string
+	for _, e := range ie.Elements {
+		elements = append(elements, e.String())
+	}
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
 // Bool represents an bool for a given AST block
 type Bool struct {
 	Token Token
@@ -5,6 +5,7 @@ package query
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/juju/collections/set"
 )
@@ -77,18 +78,24 @@ func NewInteger(value int64) *BoxInteger {
 	return &BoxInteger{value: value}
 }
 
-// Less checks if a BoxInteger is less than another BoxInteger.
+// Less checks if a BoxInteger is less than another BoxInteger or BoxFloat.
 func (o *BoxInteger) Less(other Ord) bool {
-	if i, ok := other.(*BoxInteger); ok {
+	switch i := other.(type) {
+	case *BoxInteger:
 		return o.value < i.value
+	case *BoxFloat:
+		return float64(o.value) < i.value
 	}
 	return false
 }
 
-// Equal checks if an BoxInteger is equal to another BoxInteger.
+// Equal checks if an BoxInteger is equal to another BoxInteger or BoxFloat.
 func (o *BoxInteger) Equal(other Ord) bool {
-	if i, ok := other.(*BoxInteger); ok {
+	switch i := other.(type) {
+	case *BoxInteger:
 		return o.value == i.value
+	case *BoxFloat:
+		return float64(o.value) == i.value
 	}
 	return false
 }
@@ -118,18 +125,24 @@ func NewFloat(value float64) *BoxFloat {
 	return &BoxFloat{value: value}
 }
 
-// Less checks if a BoxFloat is less than another BoxFloat.
+// Less checks if a BoxFloat is less than another BoxFloat or BoxInteger.
 func (o *BoxFloat) Less(other Ord) bool {
-	if i, ok := other.(*BoxFloat); ok {
+	switch i := other.(type) {
+	case *BoxFloat:
 		return o.value < i.value
+	case *BoxInteger:
+		return o.value < float64(i.value)
 	}
 	return false
 }
 
-// Equal checks if an BoxFloat is equal to another BoxFloat.
+// Equal checks if an BoxFloat is equal to another BoxFloat or BoxInteger.
 func (o *BoxFloat) Equal(other Ord) bool {
-	if i, ok := other.(*BoxFloat); ok {
+	switch i := other.(type) {
+	case *BoxFloat:
 		return o.value == i.value
+	case *BoxInteger:
+		return o.value == float64(i.value)
 	}
 	return false
 }
@@ -228,6 +241,162 @@ func (o *BoxBool) ForEach(fn func(any) bool) {
 	fn(o.value)
 }
 
+// BoxDuration defines an ordered duration.
+type BoxDuration struct {
+	value time.Duration
+}
+
+// NewDuration creates a new Box value
+func NewDuration(value time.Duration) *BoxDuration {
+	return &BoxDuration{value: value}
+}
+
+// Less checks if a BoxDuration is less than another BoxDuration. When
+// compared to a BoxTime, it checks if the duration is less than the
+// time elapsed since then.
+func (o *BoxDuration) Less(other Ord) bool {
+	switch i := other.(type) {
+	case *BoxDuration:
+		return o.value < i.value
+	case *BoxTime:
+		if i.value.IsZero() {
+			return false
+		}
+		return o.value < i.elapsed()
+	}
+	return false
+}
+
+// Equal checks if an BoxDuration is equal to another BoxDuration, or
+// to the time elapsed since a BoxTime.
+func (o *BoxDuration) Equal(other Ord) bool {
+	switch i := other.(type) {
+	case *BoxDuration:
+		return o.value == i.value
+	case *BoxTime:
+		return i.Equal(o)
+	}
+	return false
+}
+
+// IsZero returns if the underlying value is zero.
+func (o *BoxDuration) IsZero() bool {
+	return o.value <= 0
+}
+
+// Value defines the shadow type value of the Box.
+func (o *BoxDuration) Value() any {
+	return o.value
+}
+
+// now is the current time, used to work out how long ago a BoxTime was.
+var now = time.Now
+
+// BoxTime defines an ordered time, such as when a status was set.
+type BoxTime struct {
+	value time.Time
+}
+
+// NewTime creates a new Box value
+func NewTime(value time.Time) *BoxTime {
+	return &BoxTime{value: value}
+}
+
+func (o *BoxTime) elapsed() time.Duration {
+	return now().Sub(o.value)
+}
+
+// Less checks if a BoxTime is before another BoxTime. When compared to
+// a BoxDuration, it checks if the time elapsed since the BoxTime is
+// less than the duration, so "since < 5m" means within the last five
+// minutes. An unknown (zero) time is never less or greater than a
+// duration.
+func (o *BoxTime) Less(other Ord) bool {
+	switch i := other.(type) {
+	case *BoxTime:
+		return o.value.Before(i.value)
+	case *BoxDuration:
+		if o.value.IsZero() {
+			return false
+		}
+		return o.elapsed() < i.value
+	}
+	return false
+}
+
+// Equal checks if an BoxTime is equal to another BoxTime, or if the
+// time elapsed since the BoxTime is equal to a BoxDuration.
+func (o *BoxTime) Equal(other Ord) bool {
+	switch i := other.(type) {
+	case *BoxTime:
+		return o.value.Equal(i.value)
+	case *BoxDuration:
+		if o.value.IsZero() {
+			return false
+		}
+		return o.elapsed() == i.value
+	}
+	return false
+}
+
+// IsZero returns if the underlying value is zero.
+func (o *BoxTime) IsZero() bool {
+	return o.value.IsZero()
+}
+
+// Value defines the shadow type value of the Box.
+func (o *BoxTime) Value() any {
+	return o.value
+}
+
+// BoxList defines a list of boxes, created from a list literal.
+type BoxList struct {
+	value []Box
+}
+
+// NewList creates a new Box value
+func NewList(value []Box) *BoxList {
+	return &BoxList{value: value}
+}
+
+// Less checks if a BoxList is less than another BoxList.
+func (o *BoxList) Less(other Ord) bool {
+	return false
+}
+
+// Equal checks if an BoxList is equal to another BoxList.
+func (o *BoxList) Equal(other Ord) bool {
+	i, ok := other.(*BoxList)
+	if !ok || len(o.value) != len(i.value) {
+		return false
+	}
+	for k, v := range o.value {
+		if !v.Equal(i.value[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero returns if the underlying value is zero.
+func (o *BoxList) IsZero() bool {
+	return len(o.value) == 0
+}
+
+// Value defines the shadow type value of the Box.
+func (o *BoxList) Value() any {
+	return o
+}
+
+// ForEach iterates over each value in the box.
+func (o *BoxList) ForEach(fn func(any) bool) {
+	for _, v := range o.value {
+		if !fn(v.Value()) {
+			return
+		}
+	}
+}
+
 // BoxMapStringInterface defines an ordered map[string]any.
 type BoxMapStringInterface struct {
 	value map[string]any
@@ -455,6 +624,12 @@ func shadowType(box Box) string {
 		return "map[string]any"
 	case *BoxSliceString:
 		return "[]string"
+	case *BoxDuration:
+		return "duration"
+	case *BoxTime:
+		return "time"
+	case *BoxList:
+		return "list"
 	}
 	return "<unknown>"
 }
@@ -6,6 +6,7 @@ package query
 import (
 	"errors"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 )
@@ -89,6 +90,12 @@ func (l *Lexer) NextToken() Token {
 					Literal: string(l.char) + string(peek),
 				}
 				l.ReadNext()
+			} else if peek == '~' {
+				tok = Token{
+					Type:    MATCH,
+					Literal: string(l.char) + string(peek),
+				}
+				l.ReadNext()
 			} else {
 				tok = MakeToken(ASSIGN, l.char)
 			}
@@ -99,6 +106,12 @@ func (l *Lexer) NextToken() Token {
 					Literal: string(l.char) + string(peek),
 				}
 				l.ReadNext()
+			} else if peek == '~' {
+				tok = Token{
+					Type:    NMATCH,
+					Literal: string(l.char) + string(peek),
+				}
+				l.ReadNext()
 			} else {
 				tok = MakeToken(BANG, l.char)
 			}
@@ -168,6 +181,17 @@ func (l *Lexer) readRunesToken() Token {
 		return tok
 	case isDigit(l.char):
 		literal := l.readNumber()
+		if isLetter(l.char) {
+			// A number followed by a unit is a duration, eg 5m or 1h30m.
+			literal += l.readDurationUnits()
+			tok.Literal = literal
+			if _, err := time.ParseDuration(literal); err == nil {
+				tok.Type = DURATION
+			} else {
+				tok.Type = UNKNOWN
+			}
+			return tok
+		}
 		if strings.Contains(literal, ".") {
 			tok.Type = FLOAT
 		} else {
@@ -184,6 +208,8 @@ func (l *Lexer) readRunesToken() Token {
 			tok.Type = BOOL
 		case "_":
 			tok.Type = UNDERSCORE
+		case "in":
+			tok.Type = IN
 		default:
 			tok.Type = IDENT
 		}
@@ -252,6 +278,17 @@ func (l *Lexer) readNumber() string {
 	return string(ret)
 }
 
+// readDurationUnits returns the remainder of a duration literal, after
+// the leading number has been read.
+func (l *Lexer) readDurationUnits() string {
+	var ret []rune
+	for isLetter(l.char) || isDigit(l.char) || l.char == '.' {
+		ret = append(ret, l.char)
+		l.ReadNext()
+	}
+	return string(ret)
+}
+
 func (l *Lexer) getPosition() Position {
 	return Position{
 		Offset: l.position,
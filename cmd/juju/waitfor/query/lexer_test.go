@@ -139,6 +139,33 @@ func (p *lexerSuite) TestReadNextComplexTypes(c *gc.C) {
 			Type:    CONDOR,
 			Literal: "||",
 		}},
+	}, {
+		Input: "=~",
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    MATCH,
+			Literal: "=~",
+		}},
+	}, {
+		Input: "!~",
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    NMATCH,
+			Literal: "!~",
+		}},
+	}, {
+		Input: "in",
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    IN,
+			Literal: "in",
+		}},
 	}}
 
 	for _, test := range tests {
@@ -349,6 +376,24 @@ func (p *lexerSuite) TestReadNextNumber(c *gc.C) {
 			Type:    FLOAT,
 			Literal: "0.000002",
 		}},
+	}, {
+		Input: `1h30m`,
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    DURATION,
+			Literal: "1h30m",
+		}},
+	}, {
+		Input: `10abc`,
+		Expected: []Token{{
+			Type: -1,
+		}, {
+			Pos:     Position{Offset: 0, Line: 1, Column: 1},
+			Type:    UNKNOWN,
+			Literal: "10abc",
+		}},
 	}}
 
 	for _, test := range tests {
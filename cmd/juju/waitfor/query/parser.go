@@ -5,6 +5,7 @@ package query
 
 import (
 	"strconv"
+	"time"
 )
 
 const (
@@ -23,6 +24,9 @@ var precedence = map[TokenType]int{
 	CONDAND:  PCONDAND,
 	EQ:       EQUALS,
 	NEQ:      EQUALS,
+	IN:       EQUALS,
+	MATCH:    EQUALS,
+	NMATCH:   EQUALS,
 	LPAREN:   CALL,
 	LAMBDA:   CALL,
 	LT:       LESSGREATER,
@@ -59,11 +63,16 @@ func NewParser(lex *Lexer) *Parser {
 		FLOAT:      p.parseFloat,
 		STRING:     p.parseString,
 		LPAREN:     p.parseGroup,
+		LBRACKET:   p.parseList,
 		BOOL:       p.parseBool,
+		DURATION:   p.parseDuration,
 	}
 	p.infix = map[TokenType]InfixFunc{
 		EQ:       p.parseInfixExpression,
 		NEQ:      p.parseInfixExpression,
+		IN:       p.parseInfixExpression,
+		MATCH:    p.parseInfixExpression,
+		NMATCH:   p.parseInfixExpression,
 		CONDAND:  p.parseInfixExpression,
 		CONDOR:   p.parseInfixExpression,
 		LT:       p.parseInfixExpression,
@@ -142,6 +151,47 @@ func (p *Parser) parseFloat() (Expression, error) {
 	}, nil
 }
 
+func (p *Parser) parseDuration() (Expression, error) {
+	value, err := time.ParseDuration(p.currentToken.Literal)
+	if err != nil {
+		return nil, ErrSyntaxError(p.currentToken.Pos, p.currentToken.Type, DURATION)
+	}
+	return &Duration{
+		Token: p.currentToken,
+		Value: value,
+	}, nil
+}
+
+func (p *Parser) parseList() (Expression, error) {
+	list := &ListExpression{
+		Token: p.currentToken,
+	}
+	if p.isPeekToken(RBRACKET) {
+		p.nextToken()
+		list.EndToken = p.currentToken
+		return list, nil
+	}
+
+	p.nextToken()
+	for {
+		element, err := p.parseExpression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		list.Elements = append(list.Elements, element)
+		if !p.isPeekToken(COMMA) {
+			break
+		}
+		p.nextToken()
+		p.nextToken()
+	}
+	if err := p.expectPeek(RBRACKET); err != nil {
+		return nil, err
+	}
+	list.EndToken = p.currentToken
+	return list, nil
+}
+
 func (p *Parser) parseExpressionStatement() (Expression, error) {
 	stmt := &ExpressionStatement{
 		Token: p.currentToken,
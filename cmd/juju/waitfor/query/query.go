@@ -6,6 +6,9 @@ package query
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -234,6 +237,14 @@ func (q Query) run(e Expression, fnScope FuncScope, scope Scope) (any, error) {
 			return lessThan(right, left), nil
 		case GE:
 			return lessThanOrEqual(right, left), nil
+		case IN:
+			return contains(right, left), nil
+		case MATCH, NMATCH:
+			matched, err := match(left, right)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return matched == (node.Token.Type == MATCH), nil
 		}
 
 		// Everything onwards expects to work on logical operators.
@@ -291,6 +302,24 @@ func (q Query) run(e Expression, fnScope FuncScope, scope Scope) (any, error) {
 	case *Bool:
 		return &BoxBool{value: node.Value}, nil
 
+	case *Duration:
+		return &BoxDuration{value: node.Value}, nil
+
+	case *ListExpression:
+		var elements []Box
+		for _, element := range node.Elements {
+			result, err := q.run(element, fnScope, scope)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			box, err := ConvertRawResult(result)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			elements = append(elements, box)
+		}
+		return &BoxList{value: elements}, nil
+
 	case *Empty:
 		return nil, nil
 	}
@@ -350,6 +379,82 @@ func lessThanOrEqual(left, right any) bool {
 	return a.Less(b) || a.Equal(b)
 }
 
+// contains checks if the needle is within the haystack. Strings are
+// checked for a substring, maps and scopes for a key and everything else
+// for an equal element.
+func contains(haystack, needle any) bool {
+	a, ok1 := haystack.(Box)
+	b, ok2 := needle.(Box)
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	type keyed interface {
+		Keys() []string
+	}
+	switch t := a.(type) {
+	case *BoxString:
+		s, ok := b.Value().(string)
+		return ok && strings.Contains(t.value, s)
+	case *BoxMapStringInterface:
+		s, ok := b.Value().(string)
+		if !ok {
+			return false
+		}
+		_, ok = t.value[s]
+		return ok
+	case *BoxMapInterfaceInterface:
+		_, ok := t.value[b.Value()]
+		return ok
+	case keyed:
+		s, ok := b.Value().(string)
+		if !ok {
+			return false
+		}
+		for _, key := range t.Keys() {
+			if key == s {
+				return true
+			}
+		}
+		return false
+	}
+
+	var found bool
+	ForEach(a, func(value any) bool {
+		box, err := ConvertRawResult(value)
+		if err != nil {
+			return true
+		}
+		found = box.Equal(b)
+		return !found
+	})
+	return found
+}
+
+// match checks if the left hand side matches the regular expression on
+// the right hand side.
+func match(left, right any) (bool, error) {
+	a, ok1 := left.(Box)
+	b, ok2 := right.(Box)
+	if !ok1 || !ok2 {
+		return false, nil
+	}
+
+	pattern, ok := b.Value().(string)
+	if !ok {
+		return false, RuntimeErrorf("expected regular expression string, got %s", shadowType(b))
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, RuntimeErrorf("invalid regular expression %q: %v", pattern, err)
+	}
+	value, ok := a.Value().(string)
+	if !ok {
+		return false, nil
+	}
+	return re.MatchString(value), nil
+}
+
 func ConvertRawResult(value any) (Box, error) {
 	if box, ok := value.(Box); ok {
 		return box, nil
@@ -375,6 +480,15 @@ func ConvertRawResult(value any) (Box, error) {
 		return NewMapStringInterface(t), nil
 	case []string:
 		return NewSliceString(t), nil
+	case time.Duration:
+		return NewDuration(t), nil
+	case time.Time:
+		return NewTime(t), nil
+	case *time.Time:
+		if t == nil {
+			return NewTime(time.Time{}), nil
+		}
+		return NewTime(*t), nil
 	}
 
 	return nil, RuntimeErrorf("%v unexpected index type %T", value, value)
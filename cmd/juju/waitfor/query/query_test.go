@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -359,3 +360,145 @@ func (s *querySuite) TestRunInfixLogicalOR(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.DeepEquals, true)
 }
+
+func (s *querySuite) TestBuiltinsAggregates(c *gc.C) {
+	units := NewList([]Box{
+		&BoxNestedScope{value: testScope{"status": NewString("active")}},
+		&BoxNestedScope{value: testScope{"status": NewString("active")}},
+		&BoxNestedScope{value: testScope{"status": NewString("active")}},
+		&BoxNestedScope{value: testScope{"status": NewString("blocked")}},
+	})
+	scope := testScope{
+		"units": units,
+		"none":  NewList(nil),
+	}
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `count(units) == 4`, Expected: true},
+		{Query: `count(units, u => u.status == "active") == 3`, Expected: true},
+		{Query: `all(units, u => u.status == "active")`, Expected: false},
+		{Query: `all(units, u => u.status in ["active", "blocked"])`, Expected: true},
+		{Query: `any(units, u => u.status == "blocked")`, Expected: true},
+		{Query: `any(units, u => u.status == "error")`, Expected: false},
+		{Query: `percent(units, u => u.status == "active") >= 75`, Expected: true},
+		{Query: `percent(units, u => u.status == "active") >= 80`, Expected: false},
+		{Query: `count(none) == 0`, Expected: true},
+		{Query: `all(none, u => u.status == "active")`, Expected: false},
+		{Query: `any(none, u => u.status == "active")`, Expected: false},
+		{Query: `percent(none, u => u.status == "active") == 0`, Expected: true},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		query, err := Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		done, err := query.BuiltinsRun(scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(done, gc.Equals, test.Expected)
+	}
+}
+
+func (s *querySuite) TestBuiltinsAggregatesShortCircuit(c *gc.C) {
+	// The second unit has no status, so it errors if the lambda is called
+	// for it.
+	units := NewList([]Box{
+		&BoxNestedScope{value: testScope{"status": NewString("active")}},
+		&BoxNestedScope{value: testScope{}},
+	})
+	scope := testScope{"units": units}
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `forEach(units, u => u.status == "blocked")`, Expected: false},
+		{Query: `all(units, u => u.status == "blocked")`, Expected: false},
+		{Query: `any(units, u => u.status == "active")`, Expected: true},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		query, err := Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		done, err := query.BuiltinsRun(scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(done, gc.Equals, test.Expected)
+	}
+
+	query, err := Parse(`all(units, u => u.status == "active")`)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = query.BuiltinsRun(scope)
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *querySuite) TestBuiltinsCountTooManyArguments(c *gc.C) {
+	query, err := Parse(`count(units, 1, 2)`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = query.BuiltinsRun(testScope{"units": NewList(nil)})
+	c.Assert(err, gc.ErrorMatches, `.*expected at most one lambda passed to count, got 2`)
+}
+
+func (s *querySuite) TestRunMatchInvalidRegexp(c *gc.C) {
+	query, err := Parse(`"abc" =~ "[a-"`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = query.BuiltinsRun(testScope{})
+	c.Assert(err, gc.ErrorMatches, `.*invalid regular expression "\[a-".*`)
+}
+
+func (s *querySuite) TestRunSince(c *gc.C) {
+	fixed := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func(old func() time.Time) { now = old }(now)
+	now = func() time.Time { return fixed }
+
+	scope := testScope{
+		"since":   NewTime(fixed.Add(-10 * time.Minute)),
+		"unknown": NewTime(time.Time{}),
+	}
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `since > 5m`, Expected: true},
+		{Query: `since >= 10m`, Expected: true},
+		{Query: `since > 15m`, Expected: false},
+		{Query: `since < 15m`, Expected: true},
+		{Query: `5m < since`, Expected: true},
+		{Query: `unknown > 5m`, Expected: false},
+		{Query: `unknown < 5m`, Expected: false},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		query, err := Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		done, err := query.BuiltinsRun(scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(done, gc.Equals, test.Expected)
+	}
+}
+
+type testScope map[string]Box
+
+func (s testScope) GetIdents() []string {
+	var idents []string
+	for k := range s {
+		idents = append(idents, k)
+	}
+	return idents
+}
+
+func (s testScope) GetIdentValue(name string) (Box, error) {
+	if box, ok := s[name]; ok {
+		return box, nil
+	}
+	return nil, ErrInvalidIdentifier(name, s)
+}
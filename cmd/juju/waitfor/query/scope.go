@@ -47,52 +47,62 @@ func NewGlobalFuncScope(scope Scope) *GlobalFuncScope {
 				return v, nil
 			},
 			"forEach": func(values, expr any) (any, error) {
-				scopes, ok := values.(Box)
-				if !ok {
-					return nil, RuntimeErrorf("unexpected lambda values %T", values)
+				result, err := every(scope, values, expr)
+				if err != nil {
+					return nil, errors.Trace(err)
 				}
-				lambda, ok := expr.(*BoxLambda)
-				if !ok {
-					return nil, RuntimeErrorf("unexpected lambda %T", expr)
+				return result, nil
+			},
+			"count": func(values any, exprs ...any) (int, error) {
+				if len(exprs) > 1 {
+					return -1, RuntimeErrorf("expected at most one lambda passed to count, got %d", len(exprs))
 				}
-
-				var (
-					err    error
-					called bool
-					result = true
-				)
-				ForEach(scopes, func(value any) bool {
-					called = true
-
-					nestedScope, ok := value.(Scope)
-					if !ok {
-						err = RuntimeErrorf("unexpected scope type %T", value)
-						return false
-					}
-
-					namedScope := MakeNestedScope(scope)
-					namedScope.SetScope(lambda.ArgName(), nestedScope)
-
-					var results []Box
-					results, err = lambda.Call(namedScope)
-					if err != nil {
-						return false
-					}
-					var lambdaResult bool
-					for _, result := range results {
-						lambdaResult = !result.IsZero()
+				results, err := applyOptionalLambda(scope, values, exprs)
+				if err != nil {
+					return -1, errors.Trace(err)
+				}
+				var num int
+				for _, result := range results {
+					if result {
+						num++
 					}
-					result = result && lambdaResult
-					return result
-				})
+				}
+				return num, nil
+			},
+			"all": func(values, expr any) (bool, error) {
+				result, err := every(scope, values, expr)
 				if err != nil {
-					return nil, errors.Trace(err)
+					return false, errors.Trace(err)
 				}
-				if !called {
-					return false, nil
+				return result, nil
+			},
+			"any": func(values, expr any) (bool, error) {
+				var result bool
+				err := walkLambda(scope, values, expr, func(lambdaResult bool) bool {
+					result = lambdaResult
+					return !result
+				})
+				if err != nil {
+					return false, errors.Trace(err)
 				}
 				return result, nil
 			},
+			"percent": func(values, expr any) (float64, error) {
+				results, err := applyLambda(scope, values, expr)
+				if err != nil {
+					return 0, errors.Trace(err)
+				}
+				if len(results) == 0 {
+					return 0, nil
+				}
+				var num int
+				for _, result := range results {
+					if result {
+						num++
+					}
+				}
+				return float64(num) * 100 / float64(len(results)), nil
+			},
 			"startsWith": func(v, prefix any) (bool, error) {
 				if _, ok := prefix.(string); !ok {
 					return false, RuntimeErrorf("requires string to be passed to startsWith, got %T", prefix)
@@ -151,8 +161,12 @@ func (s *GlobalFuncScope) Call(ident *Identifier, params []Box) (any, error) {
 	}
 
 	f := reflect.ValueOf(fn)
-	if len(params) != f.Type().NumIn() {
-		return nil, RuntimeErrorf("number of arguments for a function call to be %d, but got: %d", f.Type().NumIn(), len(params))
+	if t := f.Type(); t.IsVariadic() {
+		if len(params) < t.NumIn()-1 {
+			return nil, RuntimeErrorf("number of arguments for a function call to be at least %d, but got: %d", t.NumIn()-1, len(params))
+		}
+	} else if len(params) != t.NumIn() {
+		return nil, RuntimeErrorf("number of arguments for a function call to be %d, but got: %d", t.NumIn(), len(params))
 	}
 	if f.Type().NumOut() != 2 {
 		return nil, RuntimeErrorf("number of results for a given function call must be 2")
@@ -173,6 +187,92 @@ func (s *GlobalFuncScope) Call(ident *Identifier, params []Box) (any, error) {
 	return results[0].Interface(), nil
 }
 
+// applyLambda calls the lambda for every scope within the values,
+// returning whether each call was truthy.
+func applyLambda(scope Scope, values, expr any) ([]bool, error) {
+	var results []bool
+	err := walkLambda(scope, values, expr, func(result bool) bool {
+		results = append(results, result)
+		return true
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+// every returns whether the lambda is truthy for every scope within the
+// values, stopping at the first that is not. It is false if there are no
+// values.
+func every(scope Scope, values, expr any) (bool, error) {
+	var called bool
+	result := true
+	err := walkLambda(scope, values, expr, func(lambdaResult bool) bool {
+		called = true
+		result = lambdaResult
+		return result
+	})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return called && result, nil
+}
+
+// walkLambda calls the lambda for each scope within the values, passing
+// whether the call was truthy to fn. The walk stops when fn returns false.
+func walkLambda(scope Scope, values, expr any, fn func(bool) bool) error {
+	scopes, ok := values.(Box)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda values %T", values)
+	}
+	lambda, ok := expr.(*BoxLambda)
+	if !ok {
+		return RuntimeErrorf("unexpected lambda %T", expr)
+	}
+
+	var err error
+	ForEach(scopes, func(value any) bool {
+		nestedScope, ok := value.(Scope)
+		if !ok {
+			err = RuntimeErrorf("unexpected scope type %T", value)
+			return false
+		}
+
+		namedScope := MakeNestedScope(scope)
+		namedScope.SetScope(lambda.ArgName(), nestedScope)
+
+		var boxes []Box
+		boxes, err = lambda.Call(namedScope)
+		if err != nil {
+			return false
+		}
+		var lambdaResult bool
+		for _, box := range boxes {
+			lambdaResult = !box.IsZero()
+		}
+		return fn(lambdaResult)
+	})
+	return errors.Trace(err)
+}
+
+// applyOptionalLambda is like applyLambda, except that without a lambda
+// every value is truthy.
+func applyOptionalLambda(scope Scope, values any, exprs []any) ([]bool, error) {
+	if len(exprs) > 0 {
+		return applyLambda(scope, values, exprs[0])
+	}
+	box, ok := values.(Box)
+	if !ok {
+		return nil, RuntimeErrorf("unexpected values %T", values)
+	}
+	var results []bool
+	ForEach(box, func(any) bool {
+		results = append(results, true)
+		return true
+	})
+	return results, nil
+}
+
 // NestedScope allows scopes to be nested together in a named manor.
 type NestedScope struct {
 	base   Scope
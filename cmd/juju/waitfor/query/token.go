@@ -44,6 +44,11 @@ const (
 	LAMBDA     // =>
 	UNDERSCORE // _
 	PERIOD     // .
+
+	IN       // in
+	MATCH    // =~
+	NMATCH   // !~
+	DURATION // duration literal
 )
 
 func (t TokenType) String() string {
@@ -102,6 +107,14 @@ func (t TokenType) String() string {
 		return `""`
 	case BOOL:
 		return "BOOL"
+	case IN:
+		return "in"
+	case MATCH:
+		return "=~"
+	case NMATCH:
+		return "!~"
+	case DURATION:
+		return "DURATION"
 	default:
 		return "<UNKNOWN>"
 	}
@@ -0,0 +1,355 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newRelationCommand() cmd.Command {
+	cmd := &relationCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const relationCommandDoc = `
+The wait-for relation command waits for a relation to reach a goal state. The
+goal state can be defined programmatically using the query DSL (domain
+specific language). The default query for a relation just waits for the
+relation to be created and joined.
+
+The relation can be identified either by its id, or by its key, which is made
+up of the two endpoints, eg "wordpress:db mysql:server".
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+The relation query DSL can be used to programmatically define the goal state
+for the units of the applications on either side of the relation. The
+unit-count of a relation is the number of units that have joined it.
+`
+
+const relationCommandExamples = `
+Waits for the relation to be created and joined.
+
+    juju wait-for relation 'wordpress:db mysql:server'
+
+Waits for all the units of both applications to have joined relation 3.
+
+    juju wait-for relation 3 --query='status=="joined" && unit-count == count(units)'
+`
+
+// relationCommand defines a command for waiting for relations.
+type relationCommand struct {
+	waitForCommandBase
+
+	id      int
+	key     string
+	query   string
+	timeout time.Duration
+	summary bool
+
+	relationInfo *params.RelationInfo
+	units        map[string]*params.UnitInfo
+}
+
+// Info implements Command.Info.
+func (c *relationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "relation",
+		Args:     "[<id>|<key>]",
+		Purpose:  "Wait for a relation to reach a specified state.",
+		Doc:      relationCommandDoc,
+		Examples: relationCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for application",
+			"wait-for unit",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *relationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", `life=="alive" && status=="joined"`, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the relation query on exit")
+}
+
+// Init implements Command.Init.
+func (c *relationCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("relation id or key must be supplied when waiting for a relation")
+	}
+	if len(args) != 1 {
+		return errors.New("only one relation id or key can be supplied as an argument to this command")
+	}
+	c.id = -1
+	if id, err := strconv.Atoi(args[0]); err == nil {
+		if id < 0 {
+			return errors.Errorf("%q is not valid relation id", args[0])
+		}
+		c.id = id
+		return nil
+	}
+	if !strings.Contains(args[0], ":") {
+		return errors.Errorf("%q is not valid relation key", args[0])
+	}
+	c.key = args[0]
+	return nil
+}
+
+func (c *relationCommand) name() string {
+	if c.key != "" {
+		return c.key
+	}
+	return strconv.Itoa(c.id)
+}
+
+func (c *relationCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if err != nil || !c.summary || c.relationInfo == nil {
+			return
+		}
+
+		switch c.relationInfo.Life {
+		case life.Dead:
+			ctx.Infof("relation %q has been removed", c.name())
+		case life.Dying:
+			ctx.Infof("relation %q is being removed", c.name())
+		default:
+			ctx.Infof("relation %q is established", c.name())
+			outputRelationSummary(ctx.Stdout, scopedContext, c.relationInfo, c.units)
+		}
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	strategy.Subscribe(func(event EventType) {
+		switch event {
+		case WatchAllStarted:
+			c.primeCache()
+		}
+	})
+	err = strategy.Run(ctx, c.name(), c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *relationCommand) primeCache() {
+	c.units = make(map[string]*params.UnitInfo)
+}
+
+func (c *relationCommand) matches(info *params.RelationInfo) bool {
+	if c.key != "" {
+		return normaliseRelationKey(info.Key) == normaliseRelationKey(c.key)
+	}
+	return info.Id == c.id
+}
+
+// normaliseRelationKey sorts the endpoints of a relation key, so that keys
+// match whatever order their endpoints are given in.
+func normaliseRelationKey(key string) string {
+	endpoints := strings.Fields(key)
+	sort.Strings(endpoints)
+	return strings.Join(endpoints, " ")
+}
+
+func (c *relationCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	run := func(q query.Query) (bool, error) {
+		scope := MakeRelationScope(ctx, c.relationInfo, c.units)
+		if done, err := runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+		return c.relationInfo.Life == life.Dead, nil
+	}
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			switch entityInfo := delta.Entity.(type) {
+			case *params.RelationInfo:
+				if !c.matches(entityInfo) {
+					break
+				}
+
+				if delta.Removed {
+					return false, errors.Errorf("relation %v removed", name)
+				}
+
+				c.relationInfo = entityInfo
+
+			case *params.UnitInfo:
+				if delta.Removed {
+					delete(c.units, entityInfo.Name)
+					break
+				}
+				c.units[entityInfo.Name] = entityInfo
+			}
+		}
+
+		if c.relationInfo != nil {
+			if found, err := run(q); err != nil {
+				return false, errors.Trace(err)
+			} else if found {
+				return true, nil
+			}
+		} else {
+			logger.Infof("relation %q not found, waiting...", name)
+			return false, nil
+		}
+
+		logger.Infof("relation %q found with %q, waiting...", name, c.relationInfo.Status.Current)
+		return false, nil
+	}
+}
+
+// RelationScope allows the query to introspect a relation entity.
+type RelationScope struct {
+	ctx          ScopeContext
+	RelationInfo *params.RelationInfo
+	UnitInfos    map[string]*params.UnitInfo
+}
+
+// MakeRelationScope creates a RelationScope from a RelationInfo. The unit
+// infos are filtered down to the units of the related applications.
+func MakeRelationScope(ctx ScopeContext, info *params.RelationInfo, unitInfos map[string]*params.UnitInfo) RelationScope {
+	applications := relationApplications(info)
+	units := make(map[string]*params.UnitInfo)
+	for name, unit := range unitInfos {
+		if applications.Contains(unit.Application) {
+			units[name] = unit
+		}
+	}
+	return RelationScope{
+		ctx:          ctx,
+		RelationInfo: info,
+		UnitInfos:    units,
+	}
+}
+
+func relationApplications(info *params.RelationInfo) set.Strings {
+	applications := set.NewStrings()
+	for _, ep := range info.Endpoints {
+		applications.Add(ep.ApplicationName)
+	}
+	return applications
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m RelationScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.RelationInfo)...)
+	return set.NewStrings(
+		"applications", "interface", "status", "message", "since", "units",
+	).Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m RelationScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewInteger(int64(m.RelationInfo.Id)), nil
+	case "key":
+		return query.NewString(m.RelationInfo.Key), nil
+	case "life":
+		return query.NewString(string(m.RelationInfo.Life)), nil
+	case "status":
+		return query.NewString(string(m.RelationInfo.Status.Current)), nil
+	case "message":
+		return query.NewString(m.RelationInfo.Status.Message), nil
+	case "since":
+		return statusSince(m.RelationInfo.Status), nil
+	case "unit-count":
+		return query.NewInteger(int64(m.RelationInfo.UnitCount)), nil
+	case "suspended":
+		return query.NewBool(m.RelationInfo.Suspended), nil
+	case "interface":
+		var iface string
+		if len(m.RelationInfo.Endpoints) > 0 {
+			iface = m.RelationInfo.Endpoints[0].Relation.Interface
+		}
+		return query.NewString(iface), nil
+	case "applications":
+		return query.NewSliceString(relationApplications(m.RelationInfo).SortedValues()), nil
+	case "units":
+		scopes := make(map[string]query.Scope)
+		for k, unit := range m.UnitInfos {
+			scopes[k] = MakeUnitScope(m.ctx.Child(name, unit.Name), unit, nil)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on RelationInfo", name)
+}
+
+func outputRelationSummary(writer io.Writer, scopedContext ScopeContext, relationInfo *params.RelationInfo, units map[string]*params.UnitInfo) {
+	result := struct {
+		Properties map[string]any            `yaml:"properties"`
+		Units      map[string]map[string]any `yaml:"units,omitempty"`
+	}{
+		Properties: make(map[string]any),
+		Units:      make(map[string]map[string]any),
+	}
+
+	scope := MakeRelationScope(scopedContext, relationInfo, units)
+	for _, ident := range scopedContext.RecordedIdents() {
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Properties[ident] = box.Value()
+	}
+	for name, sctx := range scopedContext.children["units"] {
+		unitInfo, ok := scope.UnitInfos[name]
+		if !ok {
+			continue
+		}
+		unitScope := MakeUnitScope(sctx, unitInfo, nil)
+
+		result.Units[name] = make(map[string]any)
+		for _, ident := range sctx.RecordedIdents() {
+			box, err := unitScope.GetIdentValue(ident)
+			if err != nil {
+				continue
+			}
+			result.Units[name][ident] = box.Value()
+		}
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
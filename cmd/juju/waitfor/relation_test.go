@@ -0,0 +1,157 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/rpc/params"
+)
+
+type relationScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationScopeSuite{})
+
+func (s *relationScopeSuite) TestGetIdentValue(c *gc.C) {
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	endpoints := []params.Endpoint{{
+		ApplicationName: "wordpress",
+		Relation:        params.CharmRelation{Name: "db", Interface: "mysql"},
+	}, {
+		ApplicationName: "mysql",
+		Relation:        params.CharmRelation{Name: "server", Interface: "mysql"},
+	}}
+	tests := []struct {
+		Field        string
+		RelationInfo *params.RelationInfo
+		Expected     query.Box
+	}{{
+		Field:        "id",
+		RelationInfo: &params.RelationInfo{Id: 3},
+		Expected:     query.NewInteger(3),
+	}, {
+		Field:        "key",
+		RelationInfo: &params.RelationInfo{Key: "wordpress:db mysql:server"},
+		Expected:     query.NewString("wordpress:db mysql:server"),
+	}, {
+		Field:        "life",
+		RelationInfo: &params.RelationInfo{Life: life.Alive},
+		Expected:     query.NewString("alive"),
+	}, {
+		Field: "status",
+		RelationInfo: &params.RelationInfo{Status: params.StatusInfo{
+			Current: status.Joined,
+		}},
+		Expected: query.NewString("joined"),
+	}, {
+		Field: "message",
+		RelationInfo: &params.RelationInfo{Status: params.StatusInfo{
+			Message: "relation is broken",
+		}},
+		Expected: query.NewString("relation is broken"),
+	}, {
+		Field: "since",
+		RelationInfo: &params.RelationInfo{Status: params.StatusInfo{
+			Since: &since,
+		}},
+		Expected: query.NewTime(since),
+	}, {
+		Field:        "unit-count",
+		RelationInfo: &params.RelationInfo{UnitCount: 2},
+		Expected:     query.NewInteger(2),
+	}, {
+		Field:        "suspended",
+		RelationInfo: &params.RelationInfo{Suspended: true},
+		Expected:     query.NewBool(true),
+	}, {
+		Field:        "interface",
+		RelationInfo: &params.RelationInfo{Endpoints: endpoints},
+		Expected:     query.NewString("mysql"),
+	}, {
+		Field:        "applications",
+		RelationInfo: &params.RelationInfo{Endpoints: endpoints},
+		Expected:     query.NewSliceString([]string{"mysql", "wordpress"}),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeRelationScope(MakeScopeContext(), test.RelationInfo, nil)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *relationScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeRelationScope(MakeScopeContext(), &params.RelationInfo{}, nil)
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `"bad" on RelationInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *relationScopeSuite) TestUnits(c *gc.C) {
+	units := map[string]*params.UnitInfo{
+		"wordpress/0": {Name: "wordpress/0", Application: "wordpress", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+		"mysql/0":     {Name: "mysql/0", Application: "mysql", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+		"mysql/1":     {Name: "mysql/1", Application: "mysql", WorkloadStatus: params.StatusInfo{Current: status.Waiting}},
+		"ubuntu/0":    {Name: "ubuntu/0", Application: "ubuntu", WorkloadStatus: params.StatusInfo{Current: status.Active}},
+	}
+	scope := MakeRelationScope(MakeScopeContext(), &params.RelationInfo{
+		Endpoints: []params.Endpoint{
+			{ApplicationName: "wordpress"},
+			{ApplicationName: "mysql"},
+		},
+		Status:    params.StatusInfo{Current: status.Joined},
+		UnitCount: 3,
+	}, units)
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `count(units) == 3`, Expected: true},
+		{Query: `status=="joined" && unit-count == count(units)`, Expected: true},
+		{Query: `"ubuntu/0" in units`, Expected: false},
+		{Query: `all(units, unit => unit.workload-status == "active")`, Expected: false},
+		{Query: `"mysql" in applications`, Expected: true},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		q, err := query.Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := runQuery(test.Query, q, scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.Equals, test.Expected)
+	}
+}
+
+type relationCommandSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&relationCommandSuite{})
+
+func (s *relationCommandSuite) TestMatchesKeyInAnyOrder(c *gc.C) {
+	info := &params.RelationInfo{Id: 3, Key: "wordpress:db mysql:server"}
+
+	for _, key := range []string{"wordpress:db mysql:server", "mysql:server wordpress:db"} {
+		cmd := &relationCommand{}
+		c.Assert(cmd.Init([]string{key}), jc.ErrorIsNil)
+		c.Check(cmd.matches(info), jc.IsTrue, gc.Commentf("key %q", key))
+	}
+
+	cmd := &relationCommand{}
+	c.Assert(cmd.Init([]string{"wordpress:db mysql:cluster"}), jc.ErrorIsNil)
+	c.Check(cmd.matches(info), jc.IsFalse)
+}
@@ -0,0 +1,306 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/waitfor/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/rpc/params"
+)
+
+func newSecretCommand() cmd.Command {
+	cmd := &secretCommand{}
+	cmd.newWatchAllAPIFunc = func() (api.WatchAllAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return modelAllWatchShim{
+			Client: client,
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const secretCommandDoc = `
+The wait-for secret command waits for a secret to reach a goal state. The goal
+state can be defined programmatically using the query DSL (domain specific
+language). The default query for a secret waits for every consumer of the
+secret to be tracking the latest revision.
+
+The secret can be identified either by its URI or by its ID.
+
+The wait-for command is an optimized alternative to the status command for
+determining programmatically if a goal state has been reached. The wait-for
+command streams delta changes from the underlying database, unlike the status
+command which performs a full query of the database.
+
+The secret query DSL can be used to programmatically define the goal state
+for the consumers of the secret. Each consumer has a name, which is the tag
+of the consuming unit or application, and the revision it is tracking.
+`
+
+const secretCommandExamples = `
+Waits for all consumers of the secret to be tracking the latest revision.
+
+    juju wait-for secret secret:9m4e2mr0ui3e8a215n4g
+
+Waits for the secret to have been rotated at least three times.
+
+    juju wait-for secret 9m4e2mr0ui3e8a215n4g --query='latest-revision > 3'
+
+Waits for the wordpress/0 unit to start consuming the secret.
+
+    juju wait-for secret 9m4e2mr0ui3e8a215n4g --query='"unit-wordpress-0" in consumers'
+`
+
+// defaultSecretQuery waits for every consumer of the secret to be tracking
+// the latest revision. It is also satisfied by a secret with no consumers.
+const defaultSecretQuery = `count(consumers, consumer => consumer.revision != latest-revision) == 0`
+
+// secretCommand defines a command for waiting for secrets.
+type secretCommand struct {
+	waitForCommandBase
+
+	id      string
+	query   string
+	timeout time.Duration
+	summary bool
+
+	secretInfo *params.SecretInfo
+}
+
+// Info implements Command.Info.
+func (c *secretCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "secret",
+		Args:     "[<uri>|<id>]",
+		Purpose:  "Wait for a secret to reach a specified state.",
+		Doc:      secretCommandDoc,
+		Examples: secretCommandExamples,
+		SeeAlso: []string{
+			"wait-for model",
+			"wait-for application",
+			"wait-for relation",
+		},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *secretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.waitForCommandBase.SetFlags(f)
+	f.StringVar(&c.query, "query", defaultSecretQuery, "query the goal state")
+	f.DurationVar(&c.timeout, "timeout", time.Minute*10, "how long to wait, before timing out")
+	f.BoolVar(&c.summary, "summary", true, "output a summary of the secret query on exit")
+}
+
+// Init implements Command.Init.
+func (c *secretCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("secret URI or ID must be supplied when waiting for a secret")
+	}
+	if len(args) != 1 {
+		return errors.New("only one secret URI or ID can be supplied as an argument to this command")
+	}
+	uri, err := secrets.ParseURI(args[0])
+	if err != nil {
+		return errors.Errorf("%q is not valid secret URI or ID", args[0])
+	}
+	c.id = uri.ID
+
+	return nil
+}
+
+func (c *secretCommand) Run(ctx *cmd.Context) (err error) {
+	scopedContext := MakeScopeContext()
+
+	defer func() {
+		if err != nil || !c.summary || c.secretInfo == nil {
+			return
+		}
+
+		ctx.Infof("secret %q is at revision %d", c.id, c.secretInfo.LatestRevision)
+		outputSecretSummary(ctx.Stdout, scopedContext, c.secretInfo)
+	}()
+
+	strategy := &Strategy{
+		ClientFn: c.newWatchAllAPIFunc,
+		Timeout:  c.timeout,
+	}
+	err = strategy.Run(ctx, c.id, c.query, c.waitFor(c.query, scopedContext, ctx), emptyNotify)
+	return errors.Trace(err)
+}
+
+func (c *secretCommand) waitFor(input string, ctx ScopeContext, logger Logger) func(string, []params.Delta, query.Query) (bool, error) {
+	return func(name string, deltas []params.Delta, q query.Query) (bool, error) {
+		for _, delta := range deltas {
+			logger.Verbosef("delta %T: %v", delta.Entity, delta.Entity)
+
+			entityInfo, ok := delta.Entity.(*params.SecretInfo)
+			if !ok || entityInfo.Id != name {
+				continue
+			}
+
+			if delta.Removed {
+				return false, errors.Errorf("secret %v removed", name)
+			}
+
+			c.secretInfo = entityInfo
+		}
+
+		if c.secretInfo == nil {
+			logger.Infof("secret %q not found, waiting...", name)
+			return false, nil
+		}
+
+		scope := MakeSecretScope(ctx, c.secretInfo)
+		if done, err := runQuery(input, q, scope); err != nil {
+			return false, errors.Trace(err)
+		} else if done {
+			return true, nil
+		}
+
+		logger.Infof("secret %q found at revision %d, waiting...", name, c.secretInfo.LatestRevision)
+		return false, nil
+	}
+}
+
+// SecretScope allows the query to introspect a secret entity.
+type SecretScope struct {
+	ctx        ScopeContext
+	SecretInfo *params.SecretInfo
+}
+
+// MakeSecretScope creates a SecretScope from a SecretInfo.
+func MakeSecretScope(ctx ScopeContext, info *params.SecretInfo) SecretScope {
+	return SecretScope{
+		ctx:        ctx,
+		SecretInfo: info,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m SecretScope) GetIdents() []string {
+	idents := set.NewStrings(getIdents(m.SecretInfo)...)
+	return set.NewStrings("owner", "latest-expire", "consumers").Union(idents).SortedValues()
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m SecretScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "id":
+		return query.NewString(m.SecretInfo.Id), nil
+	case "uri":
+		return query.NewString(m.SecretInfo.URI), nil
+	case "label":
+		return query.NewString(m.SecretInfo.Label), nil
+	case "description":
+		return query.NewString(m.SecretInfo.Description), nil
+	case "owner", "owner-tag":
+		return query.NewString(m.SecretInfo.OwnerTag), nil
+	case "rotate-policy":
+		return query.NewString(m.SecretInfo.RotatePolicy), nil
+	case "latest-revision":
+		return query.NewInteger(int64(m.SecretInfo.LatestRevision)), nil
+	case "latest-expire":
+		if m.SecretInfo.LatestExpire == nil {
+			return query.NewTime(time.Time{}), nil
+		}
+		return query.NewTime(*m.SecretInfo.LatestExpire), nil
+	case "consumers":
+		scopes := make(map[string]query.Scope)
+		for consumer, revision := range m.SecretInfo.Consumers {
+			scopes[consumer] = MakeSecretConsumerScope(m.ctx.Child(name, consumer), consumer, revision)
+		}
+		return NewScopedBox(scopes), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on SecretInfo", name)
+}
+
+// SecretConsumerScope allows the query to introspect a consumer of a
+// secret.
+type SecretConsumerScope struct {
+	ctx      ScopeContext
+	Name     string
+	Revision int
+}
+
+// MakeSecretConsumerScope creates a SecretConsumerScope for the named
+// consumer tracking the given revision.
+func MakeSecretConsumerScope(ctx ScopeContext, name string, revision int) SecretConsumerScope {
+	return SecretConsumerScope{
+		ctx:      ctx,
+		Name:     name,
+		Revision: revision,
+	}
+}
+
+// GetIdents returns the identifiers with in a given scope.
+func (m SecretConsumerScope) GetIdents() []string {
+	return []string{"name", "revision"}
+}
+
+// GetIdentValue returns the value of the identifier in a given scope.
+func (m SecretConsumerScope) GetIdentValue(name string) (query.Box, error) {
+	m.ctx.RecordIdent(name)
+
+	switch name {
+	case "name":
+		return query.NewString(m.Name), nil
+	case "revision":
+		return query.NewInteger(int64(m.Revision)), nil
+	}
+	return nil, errors.Annotatef(query.ErrInvalidIdentifier(name, m), "%q on secret consumer", name)
+}
+
+func outputSecretSummary(writer io.Writer, scopedContext ScopeContext, secretInfo *params.SecretInfo) {
+	result := struct {
+		Properties map[string]any            `yaml:"properties"`
+		Consumers  map[string]map[string]any `yaml:"consumers,omitempty"`
+	}{
+		Properties: make(map[string]any),
+		Consumers:  make(map[string]map[string]any),
+	}
+
+	scope := MakeSecretScope(scopedContext, secretInfo)
+	for _, ident := range scopedContext.RecordedIdents() {
+		box, err := scope.GetIdentValue(ident)
+		if err != nil {
+			continue
+		}
+		result.Properties[ident] = box.Value()
+	}
+	for name, sctx := range scopedContext.children["consumers"] {
+		revision, ok := secretInfo.Consumers[name]
+		if !ok {
+			continue
+		}
+		consumerScope := MakeSecretConsumerScope(sctx, name, revision)
+
+		result.Consumers[name] = make(map[string]any)
+		for _, ident := range sctx.RecordedIdents() {
+			box, err := consumerScope.GetIdentValue(ident)
+			if err != nil {
+				continue
+			}
+			result.Consumers[name][ident] = box.Value()
+		}
+	}
+
+	_ = yaml.NewEncoder(writer).Encode(result)
+}
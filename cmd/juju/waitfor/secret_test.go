@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/rpc/params"
+)
+
+type secretScopeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&secretScopeSuite{})
+
+func (s *secretScopeSuite) TestGetIdentValue(c *gc.C) {
+	expire := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Field      string
+		SecretInfo *params.SecretInfo
+		Expected   query.Box
+	}{{
+		Field:      "id",
+		SecretInfo: &params.SecretInfo{Id: "9m4e2mr0ui3e8a215n4g"},
+		Expected:   query.NewString("9m4e2mr0ui3e8a215n4g"),
+	}, {
+		Field:      "uri",
+		SecretInfo: &params.SecretInfo{URI: "secret:9m4e2mr0ui3e8a215n4g"},
+		Expected:   query.NewString("secret:9m4e2mr0ui3e8a215n4g"),
+	}, {
+		Field:      "label",
+		SecretInfo: &params.SecretInfo{Label: "password"},
+		Expected:   query.NewString("password"),
+	}, {
+		Field:      "description",
+		SecretInfo: &params.SecretInfo{Description: "db password"},
+		Expected:   query.NewString("db password"),
+	}, {
+		Field:      "owner",
+		SecretInfo: &params.SecretInfo{OwnerTag: "application-mysql"},
+		Expected:   query.NewString("application-mysql"),
+	}, {
+		Field:      "rotate-policy",
+		SecretInfo: &params.SecretInfo{RotatePolicy: "daily"},
+		Expected:   query.NewString("daily"),
+	}, {
+		Field:      "latest-revision",
+		SecretInfo: &params.SecretInfo{LatestRevision: 2},
+		Expected:   query.NewInteger(2),
+	}, {
+		Field:      "latest-expire",
+		SecretInfo: &params.SecretInfo{LatestExpire: &expire},
+		Expected:   query.NewTime(expire),
+	}, {
+		Field:      "latest-expire",
+		SecretInfo: &params.SecretInfo{},
+		Expected:   query.NewTime(time.Time{}),
+	}}
+	for i, test := range tests {
+		c.Logf("%d: GetIdentValue %q", i, test.Field)
+		scope := MakeSecretScope(MakeScopeContext(), test.SecretInfo)
+		result, err := scope.GetIdentValue(test.Field)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.DeepEquals, test.Expected)
+	}
+}
+
+func (s *secretScopeSuite) TestGetIdentValueError(c *gc.C) {
+	scope := MakeSecretScope(MakeScopeContext(), &params.SecretInfo{})
+	result, err := scope.GetIdentValue("bad")
+	c.Assert(err, gc.ErrorMatches, `"bad" on SecretInfo.*`)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *secretScopeSuite) TestDefaultQueryWithoutConsumers(c *gc.C) {
+	scope := MakeSecretScope(MakeScopeContext(), &params.SecretInfo{LatestRevision: 2})
+
+	q, err := query.Parse(defaultSecretQuery)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := runQuery(defaultSecretQuery, q, scope)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.IsTrue)
+}
+
+func (s *secretScopeSuite) TestConsumers(c *gc.C) {
+	scope := MakeSecretScope(MakeScopeContext(), &params.SecretInfo{
+		LatestRevision: 2,
+		Consumers: map[string]int{
+			"unit-wordpress-0": 2,
+			"unit-wordpress-1": 1,
+		},
+	})
+
+	tests := []struct {
+		Query    string
+		Expected bool
+	}{
+		{Query: `all(consumers, consumer => consumer.revision == latest-revision)`, Expected: false},
+		{Query: `count(consumers, consumer => consumer.revision == latest-revision) == 1`, Expected: true},
+		{Query: `"unit-wordpress-1" in consumers`, Expected: true},
+		{Query: `any(consumers, consumer => consumer.name =~ "^unit-mysql-")`, Expected: false},
+		{Query: defaultSecretQuery, Expected: false},
+	}
+	for i, test := range tests {
+		c.Logf("%d: %s", i, test.Query)
+
+		q, err := query.Parse(test.Query)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := runQuery(test.Query, q, scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result, gc.Equals, test.Expected)
+	}
+}
@@ -36,11 +36,21 @@ type Callback = func(EventType)
 // can be changed depending on the callee.
 type StrategyFunc func(string, []params.Delta, query.Query) (bool, error)
 
+// defaultReevaluateInterval is how often the query is run again when no
+// deltas arrive, so that queries on how long something has been in a
+// state can become true in a settled model.
+const defaultReevaluateInterval = 5 * time.Second
+
 // Strategy defines a series of instructions to run for a given wait for
 // plan.
 type Strategy struct {
-	ClientFn    func() (api.WatchAllAPI, error)
-	Timeout     time.Duration
+	ClientFn func() (api.WatchAllAPI, error)
+	Timeout  time.Duration
+
+	// ReevaluateInterval overrides how often the query is run again when
+	// no deltas arrive.
+	ReevaluateInterval time.Duration
+
 	subscribers []Callback
 }
 
@@ -106,14 +116,50 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 		}
 	}()
 
+	// Only one call to Next is outstanding at a time, so that the query can
+	// be run again on each tick while waiting for deltas.
+	type next struct {
+		deltas []params.Delta
+		err    error
+	}
+	nexts := make(chan next, 1)
+	readNext := func() {
+		go func() {
+			deltas, err := watcher.Next()
+			nexts <- next{deltas: deltas, err: err}
+		}()
+	}
+	readNext()
+
+	interval := s.ReevaluateInterval
+	if interval <= 0 {
+		interval = defaultReevaluateInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var evaluated bool
 	for {
-		deltas, err := watcher.Next()
-		if err != nil {
-			select {
-			case <-timeout:
-				return errors.Errorf("timed out waiting for %q to reach goal state", name)
-			default:
-				return errors.Trace(err)
+		var (
+			deltas   []params.Delta
+			fromNext bool
+		)
+		select {
+		case n := <-nexts:
+			if n.err != nil {
+				select {
+				case <-timeout:
+					return errors.Errorf("timed out waiting for %q to reach goal state", name)
+				default:
+					return errors.Trace(n.err)
+				}
+			}
+			deltas, fromNext = n.deltas, true
+		case <-ticker.C:
+			// Nothing has changed, but time based queries may now be
+			// true. Only re-run once the initial state has been seen.
+			if !evaluated {
+				continue
 			}
 		}
 
@@ -123,6 +169,10 @@ func (s *Strategy) run(q query.Query, name string, input string, fn StrategyFunc
 		} else if done {
 			return nil
 		}
+		if fromNext {
+			readNext()
+		}
+		evaluated = true
 	}
 }
 
@@ -215,6 +265,7 @@ func MakeScopeContext() ScopeContext {
 			"applications": make(map[string]ScopeContext),
 			"machines":     make(map[string]ScopeContext),
 			"units":        make(map[string]ScopeContext),
+			"consumers":    make(map[string]ScopeContext),
 		},
 	}
 }
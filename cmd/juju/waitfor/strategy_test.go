@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -95,6 +96,51 @@ func (s *strategySuite) TestRunWithCallback(c *gc.C) {
 	c.Assert(eventType, gc.Equals, WatchAllStarted)
 }
 
+func (s *strategySuite) TestRunReevaluatesWithoutDeltas(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	expected := []params.Delta{{
+		Entity: &MockEntityInfo{
+			Name: "meshuggah",
+		},
+	}}
+
+	// The model settles after the first deltas, so Next blocks until the
+	// watcher is stopped.
+	stopped := make(chan struct{})
+	allWatcher := mocks.NewMockAllWatcher(ctrl)
+	gomock.InOrder(
+		allWatcher.EXPECT().Next().Return(expected, nil),
+		allWatcher.EXPECT().Next().DoAndReturn(func() ([]params.Delta, error) {
+			<-stopped
+			return nil, errors.New("watcher was stopped")
+		}),
+	)
+	allWatcher.EXPECT().Stop().DoAndReturn(func() error {
+		close(stopped)
+		return nil
+	})
+
+	client := mocks.NewMockWatchAllAPI(ctrl)
+	client.EXPECT().WatchAll().Return(allWatcher, nil)
+
+	var calls [][]params.Delta
+	strategy := Strategy{
+		ClientFn: func() (api.WatchAllAPI, error) {
+			return client, nil
+		},
+		Timeout:            time.Minute,
+		ReevaluateInterval: time.Millisecond,
+	}
+	err := strategy.Run(context.Background(), "generic", `life=="active"`, func(_ string, d []params.Delta, _ query.Query) (bool, error) {
+		calls = append(calls, d)
+		return len(calls) == 2, nil
+	}, emptyNotify)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.DeepEquals, [][]params.Delta{expected, nil})
+}
+
 func (s *strategySuite) TestRunWithInvalidQuery(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -58,6 +58,10 @@ Waits for a units to be machines to be length of 1.
 
     juju wait-for unit ubuntu/0 --query='len(machines) == 1'
 
+Waits for the unit workload to have been active for more than 5 minutes.
+
+    juju wait-for unit ubuntu/0 --query='workload-status == "active" && workload-since > 5m'
+
 Waits for the unit to be created and active.
 
     juju wait-for unit ubuntu/0 --query='life=="alive" && workload-status=="active"'
@@ -223,7 +227,7 @@ func MakeUnitScope(ctx ScopeContext, info *params.UnitInfo, machineInfos map[str
 // GetIdents returns the identifiers with in a given scope.
 func (m UnitScope) GetIdents() []string {
 	idents := set.NewStrings(getIdents(m.UnitInfo)...)
-	return set.NewStrings("machines").Union(idents).SortedValues()
+	return set.NewStrings("machines", "workload-since", "agent-since").Union(idents).SortedValues()
 }
 
 // GetIdentValue returns the value of the identifier in a given scope.
@@ -262,6 +266,10 @@ func (m UnitScope) GetIdentValue(name string) (query.Box, error) {
 		return query.NewString(m.UnitInfo.WorkloadStatus.Message), nil
 	case "agent-status":
 		return query.NewString(string(m.UnitInfo.AgentStatus.Current)), nil
+	case "workload-since":
+		return statusSince(m.UnitInfo.WorkloadStatus), nil
+	case "agent-since":
+		return statusSince(m.UnitInfo.AgentStatus), nil
 	case "machines":
 		scopes := make(map[string]query.Scope)
 		for k, machine := range m.MachineInfos {
@@ -4,6 +4,8 @@
 package waitfor
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -21,6 +23,7 @@ type unitScopeSuite struct {
 var _ = gc.Suite(&unitScopeSuite{})
 
 func (s *unitScopeSuite) TestGetIdentValue(c *gc.C) {
+	since := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
 	tests := []struct {
 		Field    string
 		UnitInfo *params.UnitInfo
@@ -83,6 +86,18 @@ func (s *unitScopeSuite) TestGetIdentValue(c *gc.C) {
 			Current: status.Active,
 		}},
 		Expected: query.NewString("active"),
+	}, {
+		Field: "workload-since",
+		UnitInfo: &params.UnitInfo{WorkloadStatus: params.StatusInfo{
+			Since: &since,
+		}},
+		Expected: query.NewTime(since),
+	}, {
+		Field: "agent-since",
+		UnitInfo: &params.UnitInfo{AgentStatus: params.StatusInfo{
+			Since: &since,
+		}},
+		Expected: query.NewTime(since),
 	}}
 	for i, test := range tests {
 		c.Logf("%d: GetIdentValue %q", i, test.Field)
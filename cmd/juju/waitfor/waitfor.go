@@ -14,9 +14,9 @@ type Logger interface {
 }
 
 var waitForDoc = `
-The wait-for set of commands (model, application, machine and unit) defines 
-a way to wait for a goal state to be reached. The goal state can be defined
-programmatically using the query DSL (domain specific language).
+The wait-for set of commands (model, application, machine, unit, relation and
+secret) defines a way to wait for a goal state to be reached. The goal state
+can be defined programmatically using the query DSL (domain specific language).
 
 The wait-for command is an optimized alternative to the status command for 
 determining programmatically if a goal state has been reached. The wait-for
@@ -32,6 +32,17 @@ Built-in functions are provided to help define the goal state. The built-in
 functions are defined in the query package. Examples of built-in functions
 include len, print, forEach (lambda), startsWith and endsWith.
 
+Aggregate functions work over collections such as units:
+count(units) or count(units, lambda) counts the entries (matching the lambda);
+all and any check whether every or at least one entry matches the lambda;
+percent returns the percentage (0-100) of entries that match the lambda.
+
+Values can be checked against a list or collection with "in", eg
+status in ["active", "idle"], and strings matched against a regular
+expression with "=~" and "!~". Durations such as 30s, 5m or 1h30m can be
+compared with the since fields, so that since > 5m holds once a status
+has been unchanged for more than five minutes.
+
 Examples:
 
 Waits for the mysql/0 unit to be created and active.
@@ -46,11 +57,17 @@ Waits for the model units to all start with ubuntu.
 
     juju wait-for model default --query='forEach(units, unit => startsWith(unit.name, "ubuntu"))'
 
+Waits for at least 80% of the mysql units to be active.
+
+    juju wait-for application mysql --query='percent(units, u => u.workload-status == "active") >= 80'
+
 See also:
     wait-for model
     wait-for application
     wait-for machine
     wait-for unit
+    wait-for relation
+    wait-for secret
 `
 
 // NewWaitForCommand creates the wait-for supercommand and registers the
@@ -67,5 +84,7 @@ func NewWaitForCommand() cmd.Command {
 	waitFor.Register(newMachineCommand())
 	waitFor.Register(newModelCommand())
 	waitFor.Register(newUnitCommand())
+	waitFor.Register(newRelationCommand())
+	waitFor.Register(newSecretCommand())
 	return waitFor
 }
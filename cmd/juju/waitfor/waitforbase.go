@@ -6,11 +6,13 @@ package waitfor
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	apiclient "github.com/juju/juju/api/client/client"
 	"github.com/juju/juju/cmd/juju/waitfor/api"
 	"github.com/juju/juju/cmd/juju/waitfor/query"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/rpc/params"
 )
 
 type waitForCommandBase struct {
@@ -53,3 +55,13 @@ func getIdents(q interface{}) []string {
 	}
 	return res
 }
+
+// statusSince returns a box holding when the status was last set, so
+// that queries can compare it against a duration, eg "since > 5m". An
+// unknown time never compares true.
+func statusSince(info params.StatusInfo) query.Box {
+	if info.Since == nil {
+		return query.NewTime(time.Time{})
+	}
+	return query.NewTime(*info.Since)
+}
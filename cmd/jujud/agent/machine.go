@@ -80,11 +80,13 @@ import (
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/introspection"
+	"github.com/juju/juju/worker/lease"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelworkermanager"
 	psworker "github.com/juju/juju/worker/pubsub"
+	"github.com/juju/juju/worker/querylogger"
 	"github.com/juju/juju/worker/upgradedatabase"
 	"github.com/juju/juju/worker/upgradesteps"
 	"github.com/juju/juju/wrench"
@@ -569,6 +571,8 @@ func (a *MachineAgent) makeEngineCreator(
 		})
 		pubsubReporter := psworker.NewReporter()
 		presenceRecorder := presence.New(clock.WallClock)
+		slowQueryProfile := querylogger.NewProfile()
+		leaseReporter := lease.NewReporter()
 		updateAgentConfLogging := func(loggingConfig string) error {
 			return a.AgentConfigWriter.ChangeConfig(func(setter agent.ConfigSetter) error {
 				setter.SetLoggingConfig(loggingConfig)
@@ -592,8 +596,10 @@ func (a *MachineAgent) makeEngineCreator(
 
 		// Create a single HTTP client so we can reuse HTTP connections, for
 		// example across the various Charmhub API requests required for deploy.
+		// The controller CA is trusted so that models can use the charmhub
+		// mirror hosted by the controller.
 		charmhubLogger := loggo.GetLoggerWithLabels("juju.charmhub", corelogger.CHARMHUB)
-		charmhubHTTPClient := charmhub.DefaultHTTPClient(charmhubLogger)
+		charmhubHTTPClient := charmhub.DefaultHTTPClient(charmhubLogger, a.CurrentConfig().CACert())
 
 		manifoldsCfg := machine.ManifoldsConfig{
 			PreviousAgentVersion:    previousAgentVersion,
@@ -617,6 +623,8 @@ func (a *MachineAgent) makeEngineCreator(
 			LocalHub:                localHub,
 			PubSubReporter:          pubsubReporter,
 			PresenceRecorder:        presenceRecorder,
+			SlowQueryProfile:        slowQueryProfile,
+			LeaseReporter:           leaseReporter,
 			UpdateLoggerConfig:      updateAgentConfLogging,
 			UpdateControllerAPIPort: updateControllerAPIPort,
 			NewAgentStatusSetter: func(apiConn api.Connection) (upgradesteps.StatusSetter, error) {
@@ -662,6 +670,8 @@ func (a *MachineAgent) makeEngineCreator(
 			Clock:              clock.WallClock,
 			LocalHub:           localHub,
 			CentralHub:         a.centralHub,
+			SlowQueries:        slowQueryProfile,
+			LeaseReporter:      leaseReporter,
 		}); err != nil {
 			// If the introspection worker failed to start, we just log error
 			// but continue. It is very unlikely to happen in the real world
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/paths"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/internal/s3client"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/upgrades"
 	proxyconfig "github.com/juju/juju/utils/proxy"
@@ -46,6 +47,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasunitsmanager"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
@@ -69,6 +71,7 @@ import (
 	"github.com/juju/juju/worker/httpserverargs"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/instancemutater"
+	"github.com/juju/juju/worker/lease"
 	leasemanager "github.com/juju/juju/worker/lease/manifold"
 	"github.com/juju/juju/worker/leaseexpiry"
 	"github.com/juju/juju/worker/logger"
@@ -195,6 +198,14 @@ type ManifoldsConfig struct {
 	// PresenceRecorder
 	PresenceRecorder presence.Recorder
 
+	// SlowQueryProfile aggregates the slow queries recorded by the query
+	// logger, so that they can be reported on by the introspection worker.
+	SlowQueryProfile *querylogger.Profile
+
+	// LeaseReporter exposes the leases held by the lease manager,
+	// so that they can be reported on by the introspection worker.
+	LeaseReporter *lease.Reporter
+
 	// UpdateLoggerConfig is a function that will save the specified
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
@@ -472,6 +483,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		upgradeDatabaseName: ifController(upgradedatabase.Manifold(upgradedatabase.ManifoldConfig{
 			AgentName:         agentName,
 			UpgradeDBGateName: upgradeDatabaseGateName,
+			DBAccessorName:    dbAccessorName,
 			OpenState:         config.OpenStateForUpgrade,
 			Logger:            loggo.GetLogger("juju.worker.upgradedatabase"),
 			Clock:             config.Clock,
@@ -696,9 +708,12 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 
 		queryLoggerName: ifController(querylogger.Manifold(querylogger.ManifoldConfig{
-			LogDir: agentConfig.LogDir(),
-			Clock:  config.Clock,
-			Logger: loggo.GetLogger("juju.worker.querylogger"),
+			LogDir:               agentConfig.LogDir(),
+			Clock:                config.Clock,
+			Logger:               loggo.GetLogger("juju.worker.querylogger"),
+			Profile:              config.SlowQueryProfile,
+			PrometheusRegisterer: config.PrometheusRegisterer,
+			NewMetricsCollector:  querylogger.NewMetricsCollector,
 		})),
 
 		fileNotifyWatcherName: ifController(filenotifywatcher.Manifold(filenotifywatcher.ManifoldConfig{
@@ -725,10 +740,12 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		// The lease expiry worker constantly deletes
 		// leases with an expiry time in the past.
 		leaseExpiryName: ifController(leaseexpiry.Manifold(leaseexpiry.ManifoldConfig{
-			ClockName:      clockName,
-			DBAccessorName: dbAccessorName,
-			Logger:         loggo.GetLogger("juju.worker.leaseexpiry"),
-			NewWorker:      leaseexpiry.NewWorker,
+			ClockName:           clockName,
+			DBAccessorName:      dbAccessorName,
+			StateName:           stateName,
+			Logger:              loggo.GetLogger("juju.worker.leaseexpiry"),
+			GetControllerConfig: leaseexpiry.GetControllerConfig,
+			NewWorker:           leaseexpiry.NewWorker,
 		})),
 
 		// The global lease manager tracks lease information in the Dqlite database.
@@ -741,6 +758,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PrometheusRegisterer: config.PrometheusRegisterer,
 			NewWorker:            leasemanager.NewWorker,
 			NewStore:             leasemanager.NewStore,
+			Reporter:             config.LeaseReporter,
 		})),
 
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
@@ -775,6 +793,21 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		// The backup scheduler worker takes controller backups on the
+		// schedule defined in controller config, prunes old scheduled
+		// backups and optionally uploads them to an S3 bucket.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(backupscheduler.ManifoldConfig{
+			AgentName:            agentName,
+			StateName:            stateName,
+			DBAccessorName:       dbAccessorName,
+			Clock:                config.Clock,
+			Logger:               loggo.GetLogger("juju.worker.backupscheduler"),
+			PrometheusRegisterer: config.PrometheusRegisterer,
+			NewMetricsCollector:  backupscheduler.NewMetricsCollector,
+			NewS3Client:          s3client.NewExternalS3Client,
+			NewWorker:            backupscheduler.NewWorker,
+		}))),
+
 		// The controlsocket worker runs on the controller machine.
 		controlSocketName: ifController(controlsocket.Manifold(controlsocket.ManifoldConfig{
 			StateName:  stateName,
@@ -1176,6 +1209,7 @@ const (
 	kvmContainerProvisioner       = "kvm-container-provisioner"
 
 	secretBackendRotateName = "secret-backend-rotate"
+	backupSchedulerName     = "backup-scheduler"
 
 	upgradeSeriesWorkerName = "upgrade-series"
 
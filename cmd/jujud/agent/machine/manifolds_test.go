@@ -65,6 +65,7 @@ func (s *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-scheduler",
 			"broker-tracker",
 			"central-hub",
 			"certificate-updater",
@@ -147,6 +148,7 @@ func (s *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"api-config-watcher",
 			"api-server",
 			"audit-config-updater",
+			"backup-scheduler",
 			"caas-units-manager",
 			"central-hub",
 			"certificate-watcher",
@@ -313,6 +315,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 
 	// Explicitly guarded by ifPrimaryController.
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"secret-backend-rotate",
 	)
@@ -499,6 +502,24 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"db-accessor",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"query-logger",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"broker-tracker": {
 		"agent",
 		"api-caller",
@@ -698,6 +719,7 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 		"db-accessor",
 		"is-controller-flag",
 		"query-logger",
+		"state",
 		"state-config-watcher",
 	},
 
@@ -1026,7 +1048,9 @@ var expectedMachineManifoldsWithDependenciesIAAS = map[string][]string{
 
 	"upgrade-database-runner": {
 		"agent",
+		"db-accessor",
 		"is-controller-flag",
+		"query-logger",
 		"state-config-watcher",
 		"upgrade-database-gate",
 	},
@@ -1122,6 +1146,24 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"db-accessor",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"query-logger",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-watcher": {
@@ -1236,6 +1278,7 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 		"db-accessor",
 		"is-controller-flag",
 		"query-logger",
+		"state",
 		"state-config-watcher",
 	},
 
@@ -1440,7 +1483,9 @@ var expectedMachineManifoldsWithDependenciesCAAS = map[string][]string{
 
 	"upgrade-database-runner": {
 		"agent",
+		"db-accessor",
 		"is-controller-flag",
+		"query-logger",
 		"state-config-watcher",
 		"upgrade-database-gate",
 	},
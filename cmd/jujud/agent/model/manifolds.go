@@ -312,11 +312,8 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
-			}},
-			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
+			Sinks:         sinks.All(),
+			Logger:        config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
 		// The environ upgrader runs on all controller agents, and
 		// unlocks the gate when the environ is up-to-date. The
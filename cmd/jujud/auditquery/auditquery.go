@@ -0,0 +1,218 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditquery provides a command for reading back the
+// conversations recorded in a controller's audit log files.
+package auditquery
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/jujud/agent/config"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/auditlog"
+)
+
+const auditQueryDoc = `
+Query the audit log written by a Juju controller.
+
+Audit records are read from audit.log and its rotated backups
+(including gzipped ones) in the log directory, or from the files
+given as arguments. Requests and responses are gathered into the
+conversations they belong to, and conversations can be filtered by
+user, model, API call, time window and whether any call failed.
+
+Times are given in RFC3339 format, eg 2024-01-31T17:00:00Z.
+
+Examples:
+
+    jujud audit-query --user admin --errors
+    jujud audit-query --call Application.Deploy --from 2024-01-31T00:00:00Z
+    jujud audit-query --format json /tmp/audit-2024-01-31T17-00-00.000.log.gz
+`
+
+// NewCommand returns a command that queries the audit log.
+func NewCommand() cmd.Command {
+	return &auditQueryCommand{}
+}
+
+type auditQueryCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	logDir     string
+	files      []string
+	who        string
+	modelUUID  string
+	call       string
+	from       string
+	to         string
+	errorsOnly bool
+
+	filter auditlog.QueryFilter
+}
+
+// Info implements cmd.Command.
+func (c *auditQueryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-query",
+		Args:    "[<audit log file> ...]",
+		Purpose: "query the controller audit log",
+		Doc:     auditQueryDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *auditQueryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatTabular,
+	})
+	f.StringVar(&c.logDir, "log-dir", filepath.Join(config.LogDir, "juju"), "directory containing the audit log files")
+	f.StringVar(&c.who, "user", "", "only show conversations started by this user")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "only show conversations with this model")
+	f.StringVar(&c.call, "call", "", "only show calls to this Facade or Facade.Method")
+	f.StringVar(&c.from, "from", "", "only show conversations started at or after this time")
+	f.StringVar(&c.to, "to", "", "only show conversations started at or before this time")
+	f.BoolVar(&c.errorsOnly, "errors", false, "only show calls that returned errors")
+}
+
+// Init implements cmd.Command.
+func (c *auditQueryCommand) Init(args []string) error {
+	c.files = args
+	c.filter = auditlog.QueryFilter{
+		Who:        c.who,
+		ModelUUID:  c.modelUUID,
+		ErrorsOnly: c.errorsOnly,
+	}
+	if c.call != "" {
+		facade, method, _ := strings.Cut(c.call, ".")
+		if facade == "" {
+			return errors.Errorf("--call %q: expected Facade or Facade.Method", c.call)
+		}
+		c.filter.Facade, c.filter.Method = facade, method
+	}
+	var err error
+	if c.filter.From, err = parseTime("from", c.from); err != nil {
+		return errors.Trace(err)
+	}
+	if c.filter.To, err = parseTime("to", c.to); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.filter.From.IsZero() && !c.filter.To.IsZero() && c.filter.To.Before(c.filter.From) {
+		return errors.New("--to must not be before --from")
+	}
+	return nil
+}
+
+func parseTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("--%s %q: expected an RFC3339 time", flag, value)
+	}
+	return t, nil
+}
+
+// Run implements cmd.Command.
+func (c *auditQueryCommand) Run(ctx *cmd.Context) error {
+	files := c.files
+	if len(files) == 0 {
+		var err error
+		if files, err = auditlog.LogFiles(c.logDir); err != nil {
+			return errors.Trace(err)
+		}
+		if len(files) == 0 {
+			return errors.Errorf("no audit log files found in %q", c.logDir)
+		}
+	} else {
+		for i, file := range files {
+			files[i] = ctx.AbsPath(file)
+		}
+	}
+
+	assembler := auditlog.NewAssembler()
+	for _, file := range files {
+		if err := readFile(file, assembler); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	conversations := assembler.Conversations(c.filter)
+	if conversations == nil {
+		conversations = []auditlog.ConversationLog{}
+	}
+	return c.out.Write(ctx, conversations)
+}
+
+func readFile(path string, assembler *auditlog.Assembler) error {
+	f, err := auditlog.OpenLogFile(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	err = auditlog.ReadRecords(f, func(r auditlog.Record) error {
+		assembler.Add(r)
+		return nil
+	})
+	return errors.Annotatef(err, "reading %q", path)
+}
+
+func formatTabular(writer io.Writer, value interface{}) error {
+	conversations, ok := value.([]auditlog.ConversationLog)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", conversations, value)
+	}
+	if len(conversations) == 0 {
+		return nil
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("When", "User", "Model", "Conversation", "Command", "Request", "Call", "Errors")
+	for _, conv := range conversations {
+		c := conv.Conversation
+		if len(conv.Requests) == 0 {
+			w.Println(c.When, c.Who, c.ModelName, c.ConversationID, c.What, "", "", "")
+			continue
+		}
+		for _, r := range conv.Requests {
+			w.Println(
+				c.When, c.Who, c.ModelName, c.ConversationID, c.What,
+				fmt.Sprint(r.Request.RequestID),
+				fmt.Sprintf("%s.%s", r.Request.Facade, r.Request.Method),
+				errorSummary(r),
+			)
+		}
+	}
+	return tw.Flush()
+}
+
+func errorSummary(r auditlog.RequestLog) string {
+	if !r.HasErrors() {
+		return ""
+	}
+	var messages []string
+	for _, e := range r.Errors.Errors {
+		if e == nil {
+			continue
+		}
+		message := e.Message
+		if e.Code != "" {
+			message += " (" + e.Code + ")"
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditquery_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/jujud/auditquery"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/testing"
+)
+
+type AuditQuerySuite struct {
+	testing.BaseSuite
+	logDir string
+}
+
+var _ = gc.Suite(&AuditQuerySuite{})
+
+const auditLog = `
+{"conversation":{"who":"alice","what":"juju deploy mysql","when":"2024-01-31T10:00:00Z","model-name":"alice/prod","model-uuid":"uuid-1","conversation-id":"c1","connection-id":"A1"}}
+{"request":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2024-01-31T10:00:01Z","facade":"Application","method":"Deploy","version":19}}
+{"conversation":{"who":"bob","what":"juju remove-unit mysql/0","when":"2024-01-31T12:00:00Z","model-name":"bob/dev","model-uuid":"uuid-2","conversation-id":"c2","connection-id":"A2"}}
+{"request":{"conversation-id":"c2","connection-id":"A2","request-id":7,"when":"2024-01-31T12:00:01Z","facade":"Application","method":"DestroyUnit","version":19}}
+{"errors":{"conversation-id":"c2","connection-id":"A2","request-id":7,"when":"2024-01-31T12:00:02Z","errors":[{"message":"unit not found","code":"not found"}]}}
+`
+
+func (s *AuditQuerySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.logDir = c.MkDir()
+	err := os.WriteFile(filepath.Join(s.logDir, "audit.log"), []byte(auditLog[1:]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditQuerySuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--from", "yesterday"},
+		err:  `--from "yesterday": expected an RFC3339 time`,
+	}, {
+		args: []string{"--from", "2024-01-31T12:00:00Z", "--to", "2024-01-31T10:00:00Z"},
+		err:  `--to must not be before --from`,
+	}, {
+		args: []string{"--call", ".Deploy"},
+		err:  `--call ".Deploy": expected Facade or Facade.Method`,
+	}} {
+		err := cmdtesting.InitCommand(auditquery.NewCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditQuerySuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, auditquery.NewCommand(), "--log-dir", s.logDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+When                  User   Model       Conversation  Command                   Request  Call                     Errors
+2024-01-31T10:00:00Z  alice  alice/prod  c1            juju deploy mysql         1        Application.Deploy       
+2024-01-31T12:00:00Z  bob    bob/dev     c2            juju remove-unit mysql/0  7        Application.DestroyUnit  unit not found (not found)
+`[1:])
+}
+
+func (s *AuditQuerySuite) TestJSONWithFilter(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, auditquery.NewCommand(),
+		"--log-dir", s.logDir, "--format", "json", "--errors")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var convs []auditlog.ConversationLog
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &convs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(convs, gc.HasLen, 1)
+	c.Assert(convs[0].Conversation.Who, gc.Equals, "bob")
+	c.Assert(convs[0].Requests, gc.HasLen, 1)
+	c.Assert(convs[0].Requests[0].Errors.Errors[0].Code, gc.Equals, "not found")
+}
+
+func (s *AuditQuerySuite) TestYAMLFromFile(c *gc.C) {
+	path := filepath.Join(s.logDir, "audit.log")
+	ctx, err := cmdtesting.RunCommand(c, auditquery.NewCommand(),
+		"--format", "yaml", "--user", "alice", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- conversation:
+    who: alice
+    what: juju deploy mysql
+    when: "2024-01-31T10:00:00Z"
+    model-name: alice/prod
+    model-uuid: uuid-1
+    conversation-id: c1
+    connection-id: A1
+  requests:
+  - request:
+      conversation-id: c1
+      connection-id: A1
+      request-id: 1
+      when: "2024-01-31T10:00:01Z"
+      facade: Application
+      method: Deploy
+      version: 19
+`[1:])
+}
+
+func (s *AuditQuerySuite) TestNoLogFiles(c *gc.C) {
+	dir := c.MkDir()
+	_, err := cmdtesting.RunCommand(c, auditquery.NewCommand(), "--log-dir", dir)
+	c.Assert(err, gc.ErrorMatches, `no audit log files found in ".*"`)
+}
@@ -36,6 +36,10 @@ type IntrospectCommand struct {
 	post    bool
 	form    url.Values
 
+	leases    bool
+	model     string
+	namespace string
+
 	// IntrospectionSocketName returns the socket name
 	// for a given tag. If IntrospectionSocketName is nil,
 	// agent.DefaultIntrospectionSocketName is used.
@@ -67,13 +71,21 @@ unit agent on the machine, you can specify the
 agent using --agent. e.g.
 
     juju-introspect --agent=unit-mysql-0 metrics
+
+The --leases flag reports on the leases held by the
+controller's lease manager. The report may be filtered
+by partial model UUID, by namespace, and by partial
+lease names given as arguments. e.g.
+
+    juju-introspect --leases --model=deadbeef \
+        --namespace=application-leadership mysql
 `
 
 // Info returns usage information for the command.
 func (c *IntrospectCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "juju-introspect",
-		Args:    "(--listen=...|<path> [key=value [...]])",
+		Args:    "(--listen=...|--leases [<lease> ...]|<path> [key=value [...]])",
 		Purpose: "introspect Juju agents running on this machine",
 		Doc:     introspectCommandDoc,
 	})
@@ -86,9 +98,18 @@ func (c *IntrospectCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.listen, "listen", "", "address on which to expose the introspection socket")
 	f.BoolVar(&c.post, "post", false, "perform a POST action rather than a GET")
 	f.BoolVar(&c.verbose, "verbose", false, "show query path and args")
+	f.BoolVar(&c.leases, "leases", false, "report on the leases held by the lease manager")
+	f.StringVar(&c.model, "model", "", "only report leases for models with this UUID prefix (with --leases)")
+	f.StringVar(&c.namespace, "namespace", "", "only report leases in this namespace (with --leases)")
 }
 
 func (c *IntrospectCommand) Init(args []string) error {
+	if c.leases {
+		return c.initLeases(args)
+	}
+	if c.model != "" || c.namespace != "" {
+		return errors.New("--model and --namespace may only be specified with --leases")
+	}
 	if len(args) >= 1 {
 		c.path, args = args[0], args[1:]
 	}
@@ -116,6 +137,32 @@ func (c *IntrospectCommand) Init(args []string) error {
 	return nil
 }
 
+// initLeases builds the query path for the leases introspection
+// endpoint from the filter flags and lease name arguments.
+func (c *IntrospectCommand) initLeases(args []string) error {
+	if c.listen != "" {
+		return errors.New("--leases may not be specified with --listen")
+	}
+	if c.post {
+		return errors.New("--leases may not be specified with --post")
+	}
+	query := url.Values{}
+	if c.model != "" {
+		query.Set("model", c.model)
+	}
+	if c.namespace != "" {
+		query.Set("namespace", c.namespace)
+	}
+	for _, lease := range args {
+		query.Add("lease", lease)
+	}
+	c.path = "leases"
+	if len(query) > 0 {
+		c.path += "?" + query.Encode()
+	}
+	return nil
+}
+
 func (c *IntrospectCommand) Run(ctx *cmd.Context) error {
 	targetURL, err := url.Parse("http://unix.socket/" + c.path)
 	if err != nil {
@@ -45,6 +45,9 @@ func (s *IntrospectCommandSuite) TestInitErrors(c *gc.C) {
 	s.assertInitError(c, "a query path may not be specified with --listen", "query-path", "--listen=foo")
 	s.assertInitError(c, `unrecognized args: \["path"\]`, "query", "path")
 	s.assertInitError(c, "form value missing '='", "--post", "query-path", "foo")
+	s.assertInitError(c, "--model and --namespace may only be specified with --leases", "--model=foo", "query-path")
+	s.assertInitError(c, "--leases may not be specified with --listen", "--leases", "--listen=foo")
+	s.assertInitError(c, "--leases may not be specified with --post", "--leases", "--post")
 }
 
 func (*IntrospectCommandSuite) assertInitError(c *gc.C, expect string, args ...string) {
@@ -150,6 +153,26 @@ single="value"
 `[1:])
 }
 
+func (s *IntrospectCommandSuite) TestLeases(c *gc.C) {
+	listener, err := net.Listen("unix", "@"+filepath.Join(config.DataDir, "jujud-machine-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	srv := newServer(listener)
+	go srv.Serve(listener)
+	defer srv.Shutdown(context.Background())
+
+	ctx, err := s.run(c, "--leases", "--agent=machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "leases: \n")
+
+	ctx, err = s.run(c, "--leases", "--agent=machine-0",
+		"--model=deadbeef", "--namespace=application-leadership", "mysql", "redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"leases: lease=mysql&lease=redis&model=deadbeef&namespace=application-leadership\n")
+}
+
 func (s *IntrospectCommandSuite) TestListen(c *gc.C) {
 	socketName := filepath.Join(config.DataDir, "jujud-machine-0")
 	listener, err := net.Listen("unix", "@"+socketName)
@@ -190,6 +213,9 @@ func newServer(l net.Listener) *http.Server {
 	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("hello"))
 	})
+	mux.HandleFunc("/leases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "leases: %s\n", r.URL.RawQuery)
+	})
 	mux.HandleFunc("/badness", func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "argh", http.StatusInternalServerError)
 	})
@@ -33,6 +33,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/agentconf"
 	"github.com/juju/juju/cmd/jujud/agent/caasoperator"
 	"github.com/juju/juju/cmd/jujud/agent/config"
+	"github.com/juju/juju/cmd/jujud/auditquery"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
 	"github.com/juju/juju/cmd/jujud/introspect"
 	"github.com/juju/juju/cmd/jujud/run"
@@ -283,6 +284,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 	jujud.Register(caasOperatorAgent)
 
 	jujud.Register(jujudagentcmd.NewCheckConnectionCommand(agentConf, jujudagentcmd.ConnectAsAgent))
+	jujud.Register(auditquery.NewCommand())
 
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
@@ -15,9 +15,11 @@ import (
 	"github.com/juju/names/v5"
 	"github.com/juju/romulus"
 	"github.com/juju/utils/v3"
+	"github.com/robfig/cron/v3"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/core/auditlog"
 	"github.com/juju/juju/pki"
 )
 
@@ -92,6 +94,38 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogSinks is the list of sinks that audit records are
+	// written to, eg "file", "syslog", "socket" or "webhook".
+	AuditLogSinks = "audit-log-sinks"
+
+	// AuditLogBufferSize is the number of audit records buffered for
+	// each sink before API requests are made to wait.
+	AuditLogBufferSize = "audit-log-buffer-size"
+
+	// AuditLogSocketPath is the Unix socket the "socket" audit log
+	// sink writes to.
+	AuditLogSocketPath = "audit-log-socket-path"
+
+	// AuditLogWebhookURL is the URL the "webhook" audit log sink
+	// posts records to.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
+	// AuditLogSyslogHost is the host:port of the syslog server the
+	// "syslog" audit log sink forwards records to.
+	AuditLogSyslogHost = "audit-log-syslog-host"
+
+	// AuditLogSyslogCACert is the CA certificate used to validate the
+	// audit syslog server's certificate.
+	AuditLogSyslogCACert = "audit-log-syslog-ca-cert"
+
+	// AuditLogSyslogClientCert is the client certificate used when
+	// connecting to the audit syslog server.
+	AuditLogSyslogClientCert = "audit-log-syslog-client-cert"
+
+	// AuditLogSyslogClientKey is the client key used when connecting
+	// to the audit syslog server.
+	AuditLogSyslogClientKey = "audit-log-syslog-client-key"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -271,6 +305,44 @@ const (
 	// Can be set to "legacy", "snapstore", "local" or "local-dangerous".
 	// Cannot be changed.
 	JujudControllerSnapSource = "jujud-controller-snap-source"
+
+	// BackupSchedule is the cron style schedule, in UTC, on which the
+	// controller takes backups of itself. Scheduled backups are disabled
+	// when it is empty.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepLast is the number of the most recent scheduled backups
+	// to keep.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the most recent
+	// scheduled backup taken on each day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupS3Endpoint is the endpoint of the S3 compatible object store
+	// that scheduled backups are uploaded to. When it is empty, but a
+	// bucket is set, AWS S3 is used.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the S3 bucket.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket that scheduled backups are uploaded
+	// to. Scheduled backups are only kept on the controller when it is
+	// empty.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to upload scheduled
+	// backups.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to upload scheduled
+	// backups.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// LeaseHistoryMaxAge is how long the history of lease claims and
+	// expiries is kept for. Cannot be changed.
+	LeaseHistoryMaxAge = "lease-history-max-age"
 )
 
 // Attribute Defaults
@@ -307,6 +379,10 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogBufferSize is the default number of audit
+	// records buffered for each sink.
+	DefaultAuditLogBufferSize = 1000
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -400,6 +476,18 @@ const (
 	// snap source, which is the snapstore.
 	// TODO(jujud-controller-snap): change this to "snapstore" once it is implemented.
 	DefaultJujudControllerSnapSource = "legacy"
+
+	// DefaultBackupKeepLast is the default number of the most recent
+	// scheduled backups to keep.
+	DefaultBackupKeepLast = 7
+
+	// DefaultBackupKeepDaily is the default number of days for which a
+	// daily scheduled backup is kept.
+	DefaultBackupKeepDaily = 0
+
+	// DefaultLeaseHistoryMaxAge is the default duration that lease
+	// history is kept for.
+	DefaultLeaseHistoryMaxAge = 72 * time.Hour
 )
 
 var (
@@ -443,6 +531,14 @@ var (
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogSinks,
+		AuditLogBufferSize,
+		AuditLogSocketPath,
+		AuditLogWebhookURL,
+		AuditLogSyslogHost,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -455,6 +551,15 @@ var (
 		QueryTracingEnabled,
 		QueryTracingThreshold,
 		JujudControllerSnapSource,
+		BackupSchedule,
+		BackupKeepLast,
+		BackupKeepDaily,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		LeaseHistoryMaxAge,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -480,10 +585,26 @@ var (
 		APIPortOpenDelay,
 		ApplicationResourceDownloadLimit,
 		AuditingEnabled,
+		AuditLogBufferSize,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
 		AuditLogMaxBackups,
 		AuditLogMaxSize,
+		AuditLogSinks,
+		AuditLogSocketPath,
+		AuditLogSyslogCACert,
+		AuditLogSyslogClientCert,
+		AuditLogSyslogClientKey,
+		AuditLogSyslogHost,
+		AuditLogWebhookURL,
+		BackupKeepDaily,
+		BackupKeepLast,
+		BackupS3AccessKey,
+		BackupS3Bucket,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3SecretKey,
+		BackupSchedule,
 		CAASImageRepo,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
@@ -518,6 +639,12 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultAuditLogSinks is the default list of sinks audit records
+	// are written to.
+	DefaultAuditLogSinks = []string{
+		"file",
+	}
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -590,6 +717,19 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// nonNegativeIntOrDefault returns the named attribute as an integer,
+// where zero is a valid value, or the default if it isn't set.
+func (c Config) nonNegativeIntOrDefault(name string, defaultVal int) int {
+	switch value := c[name].(type) {
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(value)
+	case int:
+		return value
+	}
+	return defaultVal
+}
+
 func (c Config) intOrDefault(name string, defaultVal int) int {
 	if _, ok := c[name]; ok {
 		return c.mustInt(name)
@@ -773,6 +913,62 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogSinks returns the names of the sinks that audit records
+// are written to.
+func (c Config) AuditLogSinks() []string {
+	if value, ok := c[AuditLogSinks]; ok {
+		value := value.([]interface{})
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = item.(string)
+		}
+		return items
+	}
+	return append([]string(nil), DefaultAuditLogSinks...)
+}
+
+// AuditLogBufferSize returns the number of audit records buffered
+// for each sink.
+func (c Config) AuditLogBufferSize() int {
+	return c.intOrDefault(AuditLogBufferSize, DefaultAuditLogBufferSize)
+}
+
+// AuditLogSocketPath returns the Unix socket path used by the socket
+// audit log sink.
+func (c Config) AuditLogSocketPath() string {
+	return c.asString(AuditLogSocketPath)
+}
+
+// AuditLogWebhookURL returns the URL used by the webhook audit log
+// sink.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
+// AuditLogSyslogHost returns the syslog host used by the syslog
+// audit log sink.
+func (c Config) AuditLogSyslogHost() string {
+	return c.asString(AuditLogSyslogHost)
+}
+
+// AuditLogSyslogCACert returns the CA certificate used to validate
+// the audit syslog server.
+func (c Config) AuditLogSyslogCACert() string {
+	return c.asString(AuditLogSyslogCACert)
+}
+
+// AuditLogSyslogClientCert returns the client certificate used to
+// connect to the audit syslog server.
+func (c Config) AuditLogSyslogClientCert() string {
+	return c.asString(AuditLogSyslogClientCert)
+}
+
+// AuditLogSyslogClientKey returns the client key used to connect to
+// the audit syslog server.
+func (c Config) AuditLogSyslogClientKey() string {
+	return c.asString(AuditLogSyslogClientKey)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -1014,6 +1210,59 @@ func (c Config) QueryTracingThreshold() time.Duration {
 	return c.durationOrDefault(QueryTracingThreshold, DefaultQueryTracingThreshold)
 }
 
+// BackupSchedule returns the cron style schedule on which the controller
+// takes backups of itself, or an empty string if scheduled backups are
+// disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupKeepLast returns the number of the most recent scheduled backups
+// to keep.
+func (c Config) BackupKeepLast() int {
+	return c.nonNegativeIntOrDefault(BackupKeepLast, DefaultBackupKeepLast)
+}
+
+// BackupKeepDaily returns the number of days for which the most recent
+// scheduled backup taken on each day is kept.
+func (c Config) BackupKeepDaily() int {
+	return c.nonNegativeIntOrDefault(BackupKeepDaily, DefaultBackupKeepDaily)
+}
+
+// BackupS3Endpoint returns the endpoint of the S3 compatible object
+// store that scheduled backups are uploaded to.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the backup S3 bucket.
+func (c Config) BackupS3Region() string {
+	return c.asString(BackupS3Region)
+}
+
+// BackupS3Bucket returns the bucket that scheduled backups are uploaded
+// to, or an empty string if they aren't uploaded.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key used to upload scheduled
+// backups.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key used to upload scheduled
+// backups.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// LeaseHistoryMaxAge returns how long lease history is kept for.
+func (c Config) LeaseHistoryMaxAge() time.Duration {
+	return c.durationOrDefault(LeaseHistoryMaxAge, DefaultLeaseHistoryMaxAge)
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -1194,6 +1443,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateAuditLogSinks(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -1260,6 +1513,16 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateBackupSchedule(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if d, ok := c[LeaseHistoryMaxAge].(time.Duration); ok {
+		if d <= 0 {
+			return errors.Errorf("%s value %q must be a positive duration", LeaseHistoryMaxAge, d)
+		}
+	}
+
 	if v, ok := c[JujudControllerSnapSource].(string); ok {
 		switch v {
 		case "legacy": // TODO(jujud-controller-snap): remove once jujud-controller snap is fully implemented.
@@ -1272,6 +1535,72 @@ func Validate(c Config) error {
 	return nil
 }
 
+func (c Config) validateBackupSchedule() error {
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.ParseStandard(v); err != nil {
+			return errors.Errorf("invalid %s %q: %v", BackupSchedule, v, err)
+		}
+	}
+	for _, key := range []string{BackupKeepLast, BackupKeepDaily} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("%s value %d must not be negative", key, v)
+		}
+	}
+	if v, ok := c[BackupS3Endpoint].(string); ok && v != "" {
+		if _, err := url.Parse(v); err != nil {
+			return errors.Errorf("invalid %s %q: %v", BackupS3Endpoint, v, err)
+		}
+	}
+	if c.BackupS3Bucket() == "" {
+		for _, key := range []string{BackupS3Endpoint, BackupS3Region, BackupS3AccessKey, BackupS3SecretKey} {
+			if c.asString(key) != "" {
+				return errors.Errorf("%s requires %s to be set", key, BackupS3Bucket)
+			}
+		}
+	}
+	if (c.BackupS3AccessKey() == "") != (c.BackupS3SecretKey() == "") {
+		return errors.Errorf("%s and %s must be set together", BackupS3AccessKey, BackupS3SecretKey)
+	}
+	return nil
+}
+
+func (c Config) validateAuditLogSinks() error {
+	if v, ok := c[AuditLogBufferSize].(int); ok {
+		if v < 1 {
+			return errors.Errorf("invalid audit log buffer size: should be a positive number of records, got %d", v)
+		}
+	}
+	if _, ok := c[AuditLogSinks].([]interface{}); !ok {
+		return nil
+	}
+	for _, name := range c.AuditLogSinks() {
+		switch name {
+		case "":
+			return errors.Errorf("invalid audit log sinks: empty sink name")
+		case auditlog.FileSink:
+		case auditlog.SyslogSink:
+			if c.AuditLogSyslogHost() == "" || c.AuditLogSyslogCACert() == "" ||
+				c.AuditLogSyslogClientCert() == "" || c.AuditLogSyslogClientKey() == "" {
+				return errors.Errorf("invalid audit log sinks: %q sink requires %s, %s, %s and %s", name,
+					AuditLogSyslogHost, AuditLogSyslogCACert, AuditLogSyslogClientCert, AuditLogSyslogClientKey)
+			}
+		case auditlog.SocketSink:
+			if c.AuditLogSocketPath() == "" {
+				return errors.Errorf("invalid audit log sinks: %q sink requires %s", name, AuditLogSocketPath)
+			}
+		case auditlog.WebhookSink:
+			u, err := url.Parse(c.AuditLogWebhookURL())
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Errorf("invalid audit log sinks: %q sink requires an http(s) %s", name, AuditLogWebhookURL)
+			}
+		default:
+			return errors.Errorf("invalid audit log sinks: unknown sink %q, expected one of %q, %q, %q or %q",
+				name, auditlog.FileSink, auditlog.SyslogSink, auditlog.SocketSink, auditlog.WebhookSink)
+		}
+	}
+	return nil
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -166,6 +166,37 @@ var newConfigTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log buffer size",
+	config: controller.Config{
+		controller.AuditLogBufferSize: 0,
+	},
+	expectError: `invalid audit log buffer size: should be a positive number of records, got 0`,
+}, {
+	about: "audit log syslog sink without host",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "syslog"},
+	},
+	expectError: `invalid audit log sinks: "syslog" sink requires audit-log-syslog-host, audit-log-syslog-ca-cert, audit-log-syslog-client-cert and audit-log-syslog-client-key`,
+}, {
+	about: "audit log socket sink without path",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"socket"},
+	},
+	expectError: `invalid audit log sinks: "socket" sink requires audit-log-socket-path`,
+}, {
+	about: "audit log webhook sink with invalid url",
+	config: controller.Config{
+		controller.AuditLogSinks:      []interface{}{"webhook"},
+		controller.AuditLogWebhookURL: "ftp://audit.example.com",
+	},
+	expectError: `invalid audit log sinks: "webhook" sink requires an http\(s\) audit-log-webhook-url`,
+}, {
+	about: "audit log unknown sink",
+	config: controller.Config{
+		controller.AuditLogSinks: []interface{}{"file", "kafka"},
+	},
+	expectError: `invalid audit log sinks: unknown sink "kafka", expected one of "file", "syslog", "socket" or "webhook"`,
 }, {
 	about: "invalid model log max size",
 	config: controller.Config{
@@ -379,6 +410,37 @@ var newConfigTests = []struct {
 		controller.JujudControllerSnapSource: "latest/stable",
 	},
 	expectError: `jujud-controller-snap-source value "latest/stable" must be one of legacy, snapstore, local or local-dangerous.`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "every day",
+	},
+	expectError: `invalid backup-schedule "every day": .*`,
+}, {
+	about: "negative backup keep last",
+	config: controller.Config{
+		controller.BackupKeepLast: -1,
+	},
+	expectError: `backup-keep-last value -1 must not be negative`,
+}, {
+	about: "backup s3 endpoint without bucket",
+	config: controller.Config{
+		controller.BackupS3Endpoint: "https://s3.example.com",
+	},
+	expectError: `backup-s3-endpoint requires backup-s3-bucket to be set`,
+}, {
+	about: "backup s3 access key without secret key",
+	config: controller.Config{
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `backup-s3-access-key and backup-s3-secret-key must be set together`,
+}, {
+	about: "zero lease history max age",
+	config: controller.Config{
+		controller.LeaseHistoryMaxAge: "0s",
+	},
+	expectError: `lease-history-max-age value "0s" must be a positive duration`,
 }, {
 	about: "empty controller name",
 	config: controller.Config{
@@ -549,6 +611,37 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, []string{"file"})
+	c.Assert(cfg.AuditLogBufferSize(), gc.Equals, 1000)
+	c.Assert(cfg.AuditLogSocketPath(), gc.Equals, "")
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+	c.Assert(cfg.AuditLogSyslogHost(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestAuditLogSinkValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-sinks":              []string{"file", "socket", "webhook", "syslog"},
+			"audit-log-buffer-size":        50,
+			"audit-log-socket-path":        "/run/audit.sock",
+			"audit-log-webhook-url":        "https://audit.example.com/juju",
+			"audit-log-syslog-host":        "syslog.example.com:6514",
+			"audit-log-syslog-ca-cert":     "ca",
+			"audit-log-syslog-client-cert": "cert",
+			"audit-log-syslog-client-key":  "key",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSinks(), gc.DeepEquals, []string{"file", "socket", "webhook", "syslog"})
+	c.Assert(cfg.AuditLogBufferSize(), gc.Equals, 50)
+	c.Assert(cfg.AuditLogSocketPath(), gc.Equals, "/run/audit.sock")
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://audit.example.com/juju")
+	c.Assert(cfg.AuditLogSyslogHost(), gc.Equals, "syslog.example.com:6514")
+	c.Assert(cfg.AuditLogSyslogCACert(), gc.Equals, "ca")
+	c.Assert(cfg.AuditLogSyslogClientCert(), gc.Equals, "cert")
+	c.Assert(cfg.AuditLogSyslogClientKey(), gc.Equals, "key")
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -906,6 +999,52 @@ func (s *ConfigSuite) TestQueryTraceEnabled(c *gc.C) {
 	c.Assert(cfg.QueryTracingEnabled(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, controller.DefaultBackupKeepLast)
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, controller.DefaultBackupKeepDaily)
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, map[string]interface{}{
+			controller.BackupSchedule:    "0 3 * * *",
+			controller.BackupKeepLast:    "3",
+			controller.BackupKeepDaily:   14,
+			controller.BackupS3Bucket:    "backups",
+			controller.BackupS3AccessKey: "access",
+			controller.BackupS3SecretKey: "secret",
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "0 3 * * *")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, 3)
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 14)
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, "backups")
+	c.Assert(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Assert(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+}
+
+func (s *ConfigSuite) TestLeaseHistoryMaxAge(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LeaseHistoryMaxAge(), gc.Equals, controller.DefaultLeaseHistoryMaxAge)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, map[string]interface{}{
+			controller.LeaseHistoryMaxAge: "24h",
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.LeaseHistoryMaxAge(), gc.Equals, 24*time.Hour)
+}
+
 func (s *ConfigSuite) TestQueryTraceThreshold(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -19,6 +19,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  schema.String(),
 	AuditLogMaxBackups:               schema.ForceInt(),
 	AuditLogExcludeMethods:           schema.List(schema.String()),
+	AuditLogSinks:                    schema.List(schema.String()),
+	AuditLogBufferSize:               schema.ForceInt(),
+	AuditLogSocketPath:               schema.String(),
+	AuditLogWebhookURL:               schema.String(),
+	AuditLogSyslogHost:               schema.String(),
+	AuditLogSyslogCACert:             schema.String(),
+	AuditLogSyslogClientCert:         schema.String(),
+	AuditLogSyslogClientKey:          schema.String(),
 	APIPort:                          schema.ForceInt(),
 	APIPortOpenDelay:                 schema.TimeDuration(),
 	ControllerAPIPort:                schema.ForceInt(),
@@ -59,6 +67,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	QueryTracingEnabled:              schema.Bool(),
 	QueryTracingThreshold:            schema.TimeDuration(),
 	JujudControllerSnapSource:        schema.String(),
+	BackupSchedule:                   schema.String(),
+	BackupKeepLast:                   schema.ForceInt(),
+	BackupKeepDaily:                  schema.ForceInt(),
+	BackupS3Endpoint:                 schema.String(),
+	BackupS3Region:                   schema.String(),
+	BackupS3Bucket:                   schema.String(),
+	BackupS3AccessKey:                schema.String(),
+	BackupS3SecretKey:                schema.String(),
+	LeaseHistoryMaxAge:               schema.TimeDuration(),
 }, schema.Defaults{
 	AgentRateLimitMax:                schema.Omit,
 	AgentRateLimitRate:               schema.Omit,
@@ -71,6 +88,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:                  fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:               DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:           DefaultAuditLogExcludeMethods,
+	AuditLogSinks:                    DefaultAuditLogSinks,
+	AuditLogBufferSize:               DefaultAuditLogBufferSize,
+	AuditLogSocketPath:               schema.Omit,
+	AuditLogWebhookURL:               schema.Omit,
+	AuditLogSyslogHost:               schema.Omit,
+	AuditLogSyslogCACert:             schema.Omit,
+	AuditLogSyslogClientCert:         schema.Omit,
+	AuditLogSyslogClientKey:          schema.Omit,
 	StatePort:                        DefaultStatePort,
 	LoginTokenRefreshURL:             schema.Omit,
 	IdentityURL:                      schema.Omit,
@@ -107,6 +132,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	QueryTracingEnabled:              DefaultQueryTracingEnabled,
 	QueryTracingThreshold:            DefaultQueryTracingThreshold,
 	JujudControllerSnapSource:        DefaultJujudControllerSnapSource,
+	BackupSchedule:                   schema.Omit,
+	BackupKeepLast:                   DefaultBackupKeepLast,
+	BackupKeepDaily:                  DefaultBackupKeepDaily,
+	BackupS3Endpoint:                 schema.Omit,
+	BackupS3Region:                   schema.Omit,
+	BackupS3Bucket:                   schema.Omit,
+	BackupS3AccessKey:                schema.Omit,
+	BackupS3SecretKey:                schema.Omit,
+	LeaseHistoryMaxAge:               DefaultLeaseHistoryMaxAge,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -148,6 +182,38 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tlist,
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	AuditLogSinks: {
+		Type:        environschema.Tlist,
+		Description: `The list of sinks audit records are written to ("file", "syslog", "socket" or "webhook")`,
+	},
+	AuditLogBufferSize: {
+		Type:        environschema.Tint,
+		Description: "The number of audit records buffered for each sink before API requests wait",
+	},
+	AuditLogSocketPath: {
+		Type:        environschema.Tstring,
+		Description: "The Unix socket the socket audit log sink writes to",
+	},
+	AuditLogWebhookURL: {
+		Type:        environschema.Tstring,
+		Description: "The URL the webhook audit log sink posts records to",
+	},
+	AuditLogSyslogHost: {
+		Type:        environschema.Tstring,
+		Description: "The host:port of the syslog server the syslog audit log sink forwards to",
+	},
+	AuditLogSyslogCACert: {
+		Type:        environschema.Tstring,
+		Description: "The CA certificate used to validate the audit syslog server",
+	},
+	AuditLogSyslogClientCert: {
+		Type:        environschema.Tstring,
+		Description: "The client certificate used to connect to the audit syslog server",
+	},
+	AuditLogSyslogClientKey: {
+		Type:        environschema.Tstring,
+		Description: "The client key used to connect to the audit syslog server",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
@@ -312,4 +378,43 @@ will be output if tracing is enabled.`,
 		Type:        environschema.Tstring,
 		Description: `The source for the jujud-controller snap.`,
 	},
+	BackupSchedule: {
+		Type: environschema.Tstring,
+		Description: `The cron style schedule, in UTC, on which the controller takes backups
+of itself, for example "0 3 * * *". Scheduled backups are disabled when empty.`,
+	},
+	BackupKeepLast: {
+		Type:        environschema.Tint,
+		Description: `The number of the most recent scheduled backups to keep on the controller`,
+	},
+	BackupKeepDaily: {
+		Type: environschema.Tint,
+		Description: `The number of days for which the most recent scheduled backup taken on
+each day is kept on the controller. When both this and backup-keep-last are
+0, scheduled backups are never removed.`,
+	},
+	BackupS3Endpoint: {
+		Type:        environschema.Tstring,
+		Description: `The endpoint of the S3 compatible object store that scheduled backups are uploaded to`,
+	},
+	BackupS3Region: {
+		Type:        environschema.Tstring,
+		Description: `The region of the bucket that scheduled backups are uploaded to`,
+	},
+	BackupS3Bucket: {
+		Type:        environschema.Tstring,
+		Description: `The bucket that scheduled backups are uploaded to. Backups are only kept on the controller when empty.`,
+	},
+	BackupS3AccessKey: {
+		Type:        environschema.Tstring,
+		Description: `The access key used to upload scheduled backups`,
+	},
+	BackupS3SecretKey: {
+		Type:        environschema.Tstring,
+		Description: `The secret key used to upload scheduled backups`,
+	},
+	LeaseHistoryMaxAge: {
+		Type:        environschema.Tstring,
+		Description: `How long the history of lease claims and expiries is kept for`,
+	},
 }
@@ -27,13 +27,13 @@ var logger = loggo.GetLogger("core.auditlog")
 // connection from the client, with zero or more associated
 // Request/ResponseErrors pairs.
 type Conversation struct {
-	Who            string `json:"who"`        // username@idm
-	What           string `json:"what"`       // "juju deploy ./foo/bar"
-	When           string `json:"when"`       // ISO 8601 to second precision
-	ModelName      string `json:"model-name"` // full representation "user/name"
-	ModelUUID      string `json:"model-uuid"`
-	ConversationID string `json:"conversation-id"` // uint64 in hex
-	ConnectionID   string `json:"connection-id"`   // uint64 in hex (using %X to match the value in log files)
+	Who            string `json:"who" yaml:"who"`               // username@idm
+	What           string `json:"what" yaml:"what"`             // "juju deploy ./foo/bar"
+	When           string `json:"when" yaml:"when"`             // ISO 8601 to second precision
+	ModelName      string `json:"model-name" yaml:"model-name"` // full representation "user/name"
+	ModelUUID      string `json:"model-uuid" yaml:"model-uuid"`
+	ConversationID string `json:"conversation-id" yaml:"conversation-id"` // uint64 in hex
+	ConnectionID   string `json:"connection-id" yaml:"connection-id"`     // uint64 in hex (using %X to match the value in log files)
 }
 
 // ConversationArgs is the information needed to create a method recorder.
@@ -48,14 +48,14 @@ type ConversationArgs struct {
 // Request represents a call to an API facade made as part of
 // a specific conversation.
 type Request struct {
-	ConversationID string `json:"conversation-id"`
-	ConnectionID   string `json:"connection-id"`
-	RequestID      uint64 `json:"request-id"`
-	When           string `json:"when"`
-	Facade         string `json:"facade"`
-	Method         string `json:"method"`
-	Version        int    `json:"version"`
-	Args           string `json:"args,omitempty"`
+	ConversationID string `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64 `json:"request-id" yaml:"request-id"`
+	When           string `json:"when" yaml:"when"`
+	Facade         string `json:"facade" yaml:"facade"`
+	Method         string `json:"method" yaml:"method"`
+	Version        int    `json:"version" yaml:"version"`
+	Args           string `json:"args,omitempty" yaml:"args,omitempty"`
 }
 
 // RequestArgs is the information about an API call that we want to
@@ -71,11 +71,11 @@ type RequestArgs struct {
 // ResponseErrors captures any errors coming back from the API in
 // response to a request.
 type ResponseErrors struct {
-	ConversationID string   `json:"conversation-id"`
-	ConnectionID   string   `json:"connection-id"`
-	RequestID      uint64   `json:"request-id"`
-	When           string   `json:"when"`
-	Errors         []*Error `json:"errors"`
+	ConversationID string   `json:"conversation-id" yaml:"conversation-id"`
+	ConnectionID   string   `json:"connection-id" yaml:"connection-id"`
+	RequestID      uint64   `json:"request-id" yaml:"request-id"`
+	When           string   `json:"when" yaml:"when"`
+	Errors         []*Error `json:"errors" yaml:"errors"`
 }
 
 // ResponseErrorsArgs has errors from an API response to record in the
@@ -87,16 +87,16 @@ type ResponseErrorsArgs struct {
 
 // Error holds the details of an error sent back from the API.
 type Error struct {
-	Message string `json:"message"`
-	Code    string `json:"code"`
+	Message string `json:"message" yaml:"message"`
+	Code    string `json:"code" yaml:"code"`
 }
 
 // Record is the top-level entry type in an audit log, which serves as
 // a type discriminator. Only one of Conversation/Request/Errors should be set.
 type Record struct {
-	Conversation *Conversation   `json:"conversation,omitempty"`
-	Request      *Request        `json:"request,omitempty"`
-	Errors       *ResponseErrors `json:"errors,omitempty"`
+	Conversation *Conversation   `json:"conversation,omitempty" yaml:"conversation,omitempty"`
+	Request      *Request        `json:"request,omitempty" yaml:"request,omitempty"`
+	Errors       *ResponseErrors `json:"errors,omitempty" yaml:"errors,omitempty"`
 }
 
 // AuditLog represents something that can store calls, requests and
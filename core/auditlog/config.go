@@ -6,6 +6,8 @@ package auditlog
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // Config holds parameters to control audit logging.
@@ -29,6 +31,23 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Sinks lists the names of the registered sinks that audit
+	// records are written to.
+	Sinks []string
+
+	// BufferSize is the number of records buffered for each sink
+	// before writers block.
+	BufferSize int
+
+	// SocketPath is the Unix socket used by the socket sink.
+	SocketPath string
+
+	// WebhookURL is the URL used by the webhook sink.
+	WebhookURL string
+
+	// Syslog holds the connection details used by the syslog sink.
+	Syslog syslog.RawConfig
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -40,3 +59,37 @@ func (cfg Config) Validate() error {
 	}
 	return nil
 }
+
+// SinksChanged returns whether the sink settings in other differ
+// from those in cfg, meaning a new target needs to be created. No
+// sinks is treated as just the file sink, and a zero buffer size as
+// the default.
+func (cfg Config) SinksChanged(other Config) bool {
+	sinks, otherSinks := cfg.sinkNames(), other.sinkNames()
+	if len(sinks) != len(otherSinks) {
+		return true
+	}
+	for i, name := range sinks {
+		if otherSinks[i] != name {
+			return true
+		}
+	}
+	return cfg.bufferSize() != other.bufferSize() ||
+		cfg.SocketPath != other.SocketPath ||
+		cfg.WebhookURL != other.WebhookURL ||
+		cfg.Syslog != other.Syslog
+}
+
+func (cfg Config) sinkNames() []string {
+	if len(cfg.Sinks) == 0 {
+		return []string{FileSink}
+	}
+	return cfg.Sinks
+}
+
+func (cfg Config) bufferSize() int {
+	if cfg.BufferSize == 0 {
+		return DefaultBufferSize
+	}
+	return cfg.BufferSize
+}
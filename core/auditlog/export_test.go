@@ -0,0 +1,8 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+type SyslogSender = syslogSender
+
+var OpenSyslog = &openSyslog
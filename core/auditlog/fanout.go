@@ -0,0 +1,207 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultBufferSize is the number of records buffered for each
+	// sink if no size is specified.
+	DefaultBufferSize = 1000
+
+	// DefaultBlockTimeout is how long a write will wait for space in
+	// a full sink buffer if no timeout is specified.
+	DefaultBlockTimeout = 5 * time.Second
+)
+
+// FanOutConfig holds the parameters for creating a fan-out audit log.
+type FanOutConfig struct {
+	// Sinks holds the audit logs that records are written to, keyed
+	// by name.
+	Sinks map[string]AuditLog
+
+	// BufferSize is the number of records that can be queued for
+	// each sink before writers start to block.
+	BufferSize int
+
+	// BlockTimeout is how long a write waits for a full sink buffer
+	// to drain before giving up on that sink.
+	BlockTimeout time.Duration
+
+	// Clock is used to time out blocked writes.
+	Clock clock.Clock
+}
+
+// Validate checks the fan-out configuration.
+func (cfg FanOutConfig) Validate() error {
+	if len(cfg.Sinks) == 0 {
+		return errors.NotValidf("no sinks")
+	}
+	for name, sink := range cfg.Sinks {
+		if sink == nil {
+			return errors.NotValidf("nil sink %q", name)
+		}
+	}
+	if cfg.BufferSize < 0 {
+		return errors.NotValidf("negative BufferSize")
+	}
+	if cfg.BlockTimeout < 0 {
+		return errors.NotValidf("negative BlockTimeout")
+	}
+	return nil
+}
+
+// NewFanOut returns an AuditLog that writes every record to all of
+// the configured sinks. Each sink gets its own buffer and goroutine
+// so a slow sink doesn't hold up the others; when a sink's buffer is
+// full, writers block (applying backpressure to API requests) until
+// there is space or the block timeout expires, at which point the
+// write fails for that sink.
+func NewFanOut(cfg FanOutConfig) (AuditLog, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cfg.BufferSize == 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	if cfg.BlockTimeout == 0 {
+		cfg.BlockTimeout = DefaultBlockTimeout
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.WallClock
+	}
+	names := make([]string, 0, len(cfg.Sinks))
+	for name := range cfg.Sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f := &fanOut{
+		clock:        cfg.Clock,
+		blockTimeout: cfg.BlockTimeout,
+	}
+	for _, name := range names {
+		s := &bufferedSink{
+			name:    name,
+			sink:    cfg.Sinks[name],
+			records: make(chan Record, cfg.BufferSize),
+			done:    make(chan struct{}),
+		}
+		go s.loop()
+		f.sinks = append(f.sinks, s)
+	}
+	return f, nil
+}
+
+type fanOut struct {
+	clock        clock.Clock
+	blockTimeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	sinks  []*bufferedSink
+}
+
+// AddConversation implements AuditLog.
+func (f *fanOut) AddConversation(c Conversation) error {
+	return errors.Trace(f.add(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (f *fanOut) AddRequest(r Request) error {
+	return errors.Trace(f.add(Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (f *fanOut) AddResponse(r ResponseErrors) error {
+	return errors.Trace(f.add(Record{Errors: &r}))
+}
+
+func (f *fanOut) add(r Record) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return errors.New("audit log closed")
+	}
+	var failed []string
+	for _, s := range f.sinks {
+		select {
+		case s.records <- r:
+			continue
+		default:
+		}
+		// The buffer is full: wait for the sink to catch up.
+		select {
+		case s.records <- r:
+		case <-f.clock.After(f.blockTimeout):
+			failed = append(failed, s.name)
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("audit log sink buffer full: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Close implements AuditLog. Any buffered records are written before
+// the sinks are closed.
+func (f *fanOut) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	f.mu.Unlock()
+
+	var messages []string
+	for _, s := range f.sinks {
+		close(s.records)
+		<-s.done
+		if err := s.sink.Close(); err != nil {
+			messages = append(messages, s.name+": "+err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return errors.Errorf("closing audit log sinks: %s", strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// bufferedSink feeds records from its buffer to a single sink.
+type bufferedSink struct {
+	name    string
+	sink    AuditLog
+	records chan Record
+	done    chan struct{}
+}
+
+func (s *bufferedSink) loop() {
+	defer close(s.done)
+	for r := range s.records {
+		if err := s.write(r); err != nil {
+			logger.Errorf("writing to audit log sink %q: %v", s.name, err)
+		}
+	}
+}
+
+func (s *bufferedSink) write(r Record) error {
+	switch {
+	case r.Conversation != nil:
+		return s.sink.AddConversation(*r.Conversation)
+	case r.Request != nil:
+		return s.sink.AddRequest(*r.Request)
+	case r.Errors != nil:
+		return s.sink.AddResponse(*r.Errors)
+	}
+	return nil
+}
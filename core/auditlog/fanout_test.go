@@ -0,0 +1,134 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type FanOutSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FanOutSuite{})
+
+func (s *FanOutSuite) TestValidate(c *gc.C) {
+	_, err := auditlog.NewFanOut(auditlog.FanOutConfig{})
+	c.Assert(err, gc.ErrorMatches, "no sinks not valid")
+
+	_, err = auditlog.NewFanOut(auditlog.FanOutConfig{
+		Sinks: map[string]auditlog.AuditLog{"file": nil},
+	})
+	c.Assert(err, gc.ErrorMatches, `nil sink "file" not valid`)
+}
+
+func (s *FanOutSuite) TestWritesToAllSinks(c *gc.C) {
+	var log1, log2 fakeLog
+	fanOut, err := auditlog.NewFanOut(auditlog.FanOutConfig{
+		Sinks: map[string]auditlog.AuditLog{
+			"one": &log1,
+			"two": &log2,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fanOut.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	err = fanOut.AddRequest(auditlog.Request{RequestID: 25})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fanOut.AddResponse(auditlog.ResponseErrors{RequestID: 25})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Close flushes the buffered records before closing the sinks.
+	err = fanOut.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, log := range []*fakeLog{&log1, &log2} {
+		log.stub.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse", "Close")
+		log.stub.CheckCall(c, 0, "AddConversation", testConversation)
+	}
+
+	err = fanOut.AddConversation(testConversation)
+	c.Assert(err, gc.ErrorMatches, "audit log closed")
+}
+
+func (s *FanOutSuite) TestSinkErrorsDontStopOthers(c *gc.C) {
+	var log1, log2 fakeLog
+	log2.stub.SetErrors(errors.New("boom"))
+	fanOut, err := auditlog.NewFanOut(auditlog.FanOutConfig{
+		Sinks: map[string]auditlog.AuditLog{
+			"one": &log1,
+			"two": &log2,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fanOut.AddConversation(testConversation), jc.ErrorIsNil)
+	c.Assert(fanOut.AddRequest(auditlog.Request{RequestID: 25}), jc.ErrorIsNil)
+	c.Assert(fanOut.Close(), jc.ErrorIsNil)
+
+	log1.stub.CheckCallNames(c, "AddConversation", "AddRequest", "Close")
+	log2.stub.CheckCallNames(c, "AddConversation", "AddRequest", "Close")
+}
+
+func (s *FanOutSuite) TestBackpressure(c *gc.C) {
+	blocked := &blockingLog{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	clock := testclock.NewClock(time.Time{})
+	fanOut, err := auditlog.NewFanOut(auditlog.FanOutConfig{
+		Sinks:        map[string]auditlog.AuditLog{"slow": blocked},
+		BufferSize:   1,
+		BlockTimeout: time.Second,
+		Clock:        clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first record is picked up by the sink, which then blocks,
+	// and the second fills the buffer.
+	c.Assert(fanOut.AddConversation(testConversation), jc.ErrorIsNil)
+	select {
+	case <-blocked.started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("sink never received record")
+	}
+	c.Assert(fanOut.AddRequest(auditlog.Request{RequestID: 1}), jc.ErrorIsNil)
+
+	// The third has to wait for space, and gives up at the timeout.
+	result := make(chan error, 1)
+	go func() {
+		result <- fanOut.AddRequest(auditlog.Request{RequestID: 2})
+	}()
+	c.Assert(clock.WaitAdvance(time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-result:
+		c.Assert(err, gc.ErrorMatches, "audit log sink buffer full: slow")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("write never timed out")
+	}
+
+	close(blocked.release)
+	c.Assert(fanOut.Close(), jc.ErrorIsNil)
+}
+
+type blockingLog struct {
+	fakeLog
+	started chan struct{}
+	release chan struct{}
+}
+
+func (l *blockingLog) AddConversation(m auditlog.Conversation) error {
+	l.started <- struct{}{}
+	<-l.release
+	return l.fakeLog.AddConversation(m)
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// ConversationLog is a conversation rebuilt from audit records, along
+// with the requests made as part of it.
+type ConversationLog struct {
+	Conversation Conversation `json:"conversation" yaml:"conversation"`
+	Requests     []RequestLog `json:"requests,omitempty" yaml:"requests,omitempty"`
+}
+
+// RequestLog pairs a request with the errors returned in response to
+// it, if there were any.
+type RequestLog struct {
+	Request Request         `json:"request" yaml:"request"`
+	Errors  *ResponseErrors `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// HasErrors returns whether the request failed.
+func (r RequestLog) HasErrors() bool {
+	return r.Errors != nil && len(r.Errors.Errors) > 0
+}
+
+// QueryFilter selects conversations from the audit log. Zero values
+// match everything.
+type QueryFilter struct {
+	// Who matches the user that started the conversation.
+	Who string
+
+	// ModelUUID matches the model the conversation was with.
+	ModelUUID string
+
+	// Facade and Method match the API calls made in the
+	// conversation. Only matching requests are kept.
+	Facade string
+	Method string
+
+	// From and To bound the time the conversation started.
+	From time.Time
+	To   time.Time
+
+	// ErrorsOnly keeps only requests which returned errors.
+	ErrorsOnly bool
+}
+
+func (f QueryFilter) filtersRequests() bool {
+	return f.Facade != "" || f.Method != "" || f.ErrorsOnly
+}
+
+func (f QueryFilter) matchConversation(c Conversation) bool {
+	if f.Who != "" && c.Who != f.Who {
+		return false
+	}
+	if f.ModelUUID != "" && c.ModelUUID != f.ModelUUID {
+		return false
+	}
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+	when, err := time.Parse(time.RFC3339, c.When)
+	if err != nil {
+		return false
+	}
+	if !f.From.IsZero() && when.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && when.After(f.To) {
+		return false
+	}
+	return true
+}
+
+func (f QueryFilter) matchRequest(r RequestLog) bool {
+	if f.Facade != "" && r.Request.Facade != f.Facade {
+		return false
+	}
+	if f.Method != "" && r.Request.Method != f.Method {
+		return false
+	}
+	if f.ErrorsOnly && !r.HasErrors() {
+		return false
+	}
+	return true
+}
+
+// Assembler rebuilds conversations from a stream of audit records.
+type Assembler struct {
+	conversations map[string]*ConversationLog
+	requests      map[requestKey]int
+	order         []string
+}
+
+type requestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+// NewAssembler returns an empty Assembler.
+func NewAssembler() *Assembler {
+	return &Assembler{
+		conversations: make(map[string]*ConversationLog),
+		requests:      make(map[requestKey]int),
+	}
+}
+
+// conversation returns the log for the conversation ID, creating it
+// if needed. Requests may be seen without their conversation if it
+// was written to a log file that has since been removed.
+func (a *Assembler) conversation(id string) *ConversationLog {
+	c, ok := a.conversations[id]
+	if !ok {
+		c = &ConversationLog{Conversation: Conversation{ConversationID: id}}
+		a.conversations[id] = c
+		a.order = append(a.order, id)
+	}
+	return c
+}
+
+// Add adds a record to the conversation it belongs to.
+func (a *Assembler) Add(r Record) {
+	switch {
+	case r.Conversation != nil:
+		a.conversation(r.Conversation.ConversationID).Conversation = *r.Conversation
+	case r.Request != nil:
+		c := a.conversation(r.Request.ConversationID)
+		key := requestKey{r.Request.ConversationID, r.Request.RequestID}
+		a.requests[key] = len(c.Requests)
+		c.Requests = append(c.Requests, RequestLog{Request: *r.Request})
+	case r.Errors != nil:
+		c := a.conversation(r.Errors.ConversationID)
+		key := requestKey{r.Errors.ConversationID, r.Errors.RequestID}
+		i, ok := a.requests[key]
+		if !ok {
+			i = len(c.Requests)
+			a.requests[key] = i
+			c.Requests = append(c.Requests, RequestLog{Request: Request{
+				ConversationID: r.Errors.ConversationID,
+				ConnectionID:   r.Errors.ConnectionID,
+				RequestID:      r.Errors.RequestID,
+			}})
+		}
+		errs := *r.Errors
+		c.Requests[i].Errors = &errs
+	}
+}
+
+// Conversations returns the conversations matching the filter, in the
+// order they were first seen.
+func (a *Assembler) Conversations(filter QueryFilter) []ConversationLog {
+	var result []ConversationLog
+	for _, id := range a.order {
+		c := *a.conversations[id]
+		if !filter.matchConversation(c.Conversation) {
+			continue
+		}
+		if filter.filtersRequests() {
+			var requests []RequestLog
+			for _, r := range c.Requests {
+				if filter.matchRequest(r) {
+					requests = append(requests, r)
+				}
+			}
+			if len(requests) == 0 {
+				continue
+			}
+			c.Requests = requests
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// ReadRecords decodes the newline-delimited audit records in r,
+// calling fn with each one.
+func ReadRecords(r io.Reader, fn func(Record) error) error {
+	reader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			var record Record
+			if err := json.Unmarshal(line, &record); err != nil {
+				return errors.Annotatef(err, "line %d", lineNum)
+			}
+			if err := fn(record); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// LogFiles returns the audit log files in logDir, oldest first: the
+// rotated backups (which may be gzipped) followed by audit.log.
+func LogFiles(logDir string) ([]string, error) {
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Backups are named with their rotation time, so sorting by
+	// name puts them in order.
+	sort.Strings(backups)
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		backups = append(backups, current)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return backups, nil
+}
+
+// OpenLogFile opens an audit log file for reading, decompressing it
+// if it is a gzipped backup.
+func OpenLogFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.Annotatef(err, "reading %q", path)
+	}
+	return &gzipFile{Reader: gz, file: f}, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes both the decompressor and the underlying file.
+func (g *gzipFile) Close() error {
+	gzErr := g.Reader.Close()
+	if err := g.file.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzErr)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+const queryLog = `
+{"conversation":{"who":"alice","what":"juju deploy mysql","when":"2024-01-31T10:00:00Z","model-name":"alice/prod","model-uuid":"uuid-1","conversation-id":"c1","connection-id":"A1"}}
+{"request":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2024-01-31T10:00:01Z","facade":"Application","method":"Deploy","version":19}}
+{"conversation":{"who":"bob","what":"juju remove-unit mysql/0","when":"2024-01-31T12:00:00Z","model-name":"bob/dev","model-uuid":"uuid-2","conversation-id":"c2","connection-id":"A2"}}
+{"errors":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2024-01-31T10:00:02Z","errors":[]}}
+{"request":{"conversation-id":"c2","connection-id":"A2","request-id":7,"when":"2024-01-31T12:00:01Z","facade":"Application","method":"DestroyUnit","version":19}}
+{"request":{"conversation-id":"c1","connection-id":"A1","request-id":2,"when":"2024-01-31T10:00:03Z","facade":"Client","method":"FullStatus","version":6}}
+{"errors":{"conversation-id":"c2","connection-id":"A2","request-id":7,"when":"2024-01-31T12:00:02Z","errors":[{"message":"unit not found","code":"not found"}]}}
+`
+
+func (s *QuerySuite) assemble(c *gc.C) *auditlog.Assembler {
+	assembler := auditlog.NewAssembler()
+	err := auditlog.ReadRecords(strings.NewReader(queryLog[1:]), func(r auditlog.Record) error {
+		assembler.Add(r)
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return assembler
+}
+
+func (s *QuerySuite) TestConversationsRebuilt(c *gc.C) {
+	convs := s.assemble(c).Conversations(auditlog.QueryFilter{})
+	c.Assert(convs, gc.HasLen, 2)
+
+	c.Check(convs[0].Conversation.Who, gc.Equals, "alice")
+	c.Assert(convs[0].Requests, gc.HasLen, 2)
+	c.Check(convs[0].Requests[0].Request.Method, gc.Equals, "Deploy")
+	c.Check(convs[0].Requests[0].Errors, gc.NotNil)
+	c.Check(convs[0].Requests[0].HasErrors(), jc.IsFalse)
+	c.Check(convs[0].Requests[1].Request.Method, gc.Equals, "FullStatus")
+	c.Check(convs[0].Requests[1].Errors, gc.IsNil)
+
+	c.Check(convs[1].Conversation.Who, gc.Equals, "bob")
+	c.Assert(convs[1].Requests, gc.HasLen, 1)
+	c.Check(convs[1].Requests[0].HasErrors(), jc.IsTrue)
+	c.Check(convs[1].Requests[0].Errors.Errors[0].Message, gc.Equals, "unit not found")
+}
+
+func (s *QuerySuite) TestFilterConversations(c *gc.C) {
+	assembler := s.assemble(c)
+	for i, test := range []struct {
+		about    string
+		filter   auditlog.QueryFilter
+		expected []string
+	}{{
+		about:    "user",
+		filter:   auditlog.QueryFilter{Who: "bob"},
+		expected: []string{"c2"},
+	}, {
+		about:    "model",
+		filter:   auditlog.QueryFilter{ModelUUID: "uuid-1"},
+		expected: []string{"c1"},
+	}, {
+		about:    "facade",
+		filter:   auditlog.QueryFilter{Facade: "Application"},
+		expected: []string{"c1", "c2"},
+	}, {
+		about:    "facade and method",
+		filter:   auditlog.QueryFilter{Facade: "Client", Method: "FullStatus"},
+		expected: []string{"c1"},
+	}, {
+		about:    "errors",
+		filter:   auditlog.QueryFilter{ErrorsOnly: true},
+		expected: []string{"c2"},
+	}, {
+		about: "time window",
+		filter: auditlog.QueryFilter{
+			From: time.Date(2024, 1, 31, 11, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 31, 13, 0, 0, 0, time.UTC),
+		},
+		expected: []string{"c2"},
+	}, {
+		about:    "no match",
+		filter:   auditlog.QueryFilter{Who: "carol"},
+		expected: nil,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		var ids []string
+		for _, conv := range assembler.Conversations(test.filter) {
+			ids = append(ids, conv.Conversation.ConversationID)
+		}
+		c.Check(ids, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *QuerySuite) TestFilterKeepsMatchingRequests(c *gc.C) {
+	convs := s.assemble(c).Conversations(auditlog.QueryFilter{Facade: "Client"})
+	c.Assert(convs, gc.HasLen, 1)
+	c.Assert(convs[0].Requests, gc.HasLen, 1)
+	c.Assert(convs[0].Requests[0].Request.Method, gc.Equals, "FullStatus")
+}
+
+func (s *QuerySuite) TestReadRecordsBadLine(c *gc.C) {
+	err := auditlog.ReadRecords(strings.NewReader("{}\nnot json\n"), func(auditlog.Record) error {
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, "line 2: .*")
+}
+
+func (s *QuerySuite) TestLogFiles(c *gc.C) {
+	dir := c.MkDir()
+	for _, name := range []string{
+		"audit.log",
+		"audit-2024-01-31T10-00-00.000.log.gz",
+		"audit-2024-01-30T10-00-00.000.log",
+		"machine-0.log",
+	} {
+		c.Assert(os.WriteFile(filepath.Join(dir, name), nil, 0600), jc.ErrorIsNil)
+	}
+	files, err := auditlog.LogFiles(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(files, jc.DeepEquals, []string{
+		filepath.Join(dir, "audit-2024-01-30T10-00-00.000.log"),
+		filepath.Join(dir, "audit-2024-01-31T10-00-00.000.log.gz"),
+		filepath.Join(dir, "audit.log"),
+	})
+}
+
+func (s *QuerySuite) TestOpenLogFileGzipped(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "audit-2024-01-31T10-00-00.000.log.gz")
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(queryLog[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	r, err := auditlog.OpenLogFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, queryLog[1:])
+}
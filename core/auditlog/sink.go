@@ -0,0 +1,338 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+const (
+	// FileSink is the name of the sink that writes records to a
+	// rotating audit.log file.
+	FileSink = "file"
+
+	// SyslogSink is the name of the sink that forwards records to a
+	// remote syslog host.
+	SyslogSink = "syslog"
+
+	// SocketSink is the name of the sink that writes records to a
+	// local Unix socket.
+	SocketSink = "socket"
+
+	// WebhookSink is the name of the sink that posts records to an
+	// HTTP endpoint.
+	WebhookSink = "webhook"
+)
+
+// defaultSinkTimeout is used to bound dialling and sending for the
+// network based sinks.
+const defaultSinkTimeout = 10 * time.Second
+
+// HTTPClient is the subset of *http.Client used by the webhook sink.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// SinkConfig holds the parameters needed to construct any of the
+// registered audit log sinks. Each sink only uses the fields that
+// are relevant to it.
+type SinkConfig struct {
+	// LogDir is the directory the file sink writes audit.log into.
+	LogDir string
+
+	// MaxSizeMB is the maximum size of the audit log file before it
+	// is rotated.
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated audit log files to keep.
+	MaxBackups int
+
+	// Syslog holds the connection details for the syslog sink.
+	Syslog syslog.RawConfig
+
+	// Origin identifies the controller agent writing audit records
+	// to syslog.
+	Origin logfwd.Origin
+
+	// SocketPath is the path of the Unix socket the socket sink
+	// writes to.
+	SocketPath string
+
+	// WebhookURL is the URL the webhook sink posts records to.
+	WebhookURL string
+
+	// HTTPClient is used by the webhook sink. If nil a client with
+	// a default timeout is used.
+	HTTPClient HTTPClient
+
+	// Clock is used to timestamp forwarded records.
+	Clock clock.Clock
+}
+
+// SinkFactory creates an audit log sink from the supplied config.
+type SinkFactory func(SinkConfig) (AuditLog, error)
+
+var (
+	sinksMu sync.Mutex
+	sinks   = map[string]SinkFactory{
+		FileSink:    newFileSink,
+		SyslogSink:  newSyslogSink,
+		SocketSink:  newSocketSink,
+		WebhookSink: newWebhookSink,
+	}
+)
+
+// RegisterSink makes a sink factory available under the given name.
+// It is an error to register the same name twice.
+func RegisterSink(name string, factory SinkFactory) error {
+	if name == "" {
+		return errors.NotValidf("empty sink name")
+	}
+	if factory == nil {
+		return errors.NotValidf("nil factory for sink %q", name)
+	}
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	if _, ok := sinks[name]; ok {
+		return errors.AlreadyExistsf("audit log sink %q", name)
+	}
+	sinks[name] = factory
+	return nil
+}
+
+// SinkNames returns the sorted names of all registered sinks.
+func SinkNames() []string {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	names := make([]string, 0, len(sinks))
+	for name := range sinks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSink creates the named sink using the supplied config. Apart
+// from the file sink, sinks aren't safe for concurrent use: wrap them
+// with NewFanOut to serialise writes.
+func NewSink(name string, cfg SinkConfig) (AuditLog, error) {
+	sinksMu.Lock()
+	factory, ok := sinks[name]
+	sinksMu.Unlock()
+	if !ok {
+		return nil, errors.NotFoundf("audit log sink %q", name)
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.WallClock
+	}
+	sink, err := factory(cfg)
+	return sink, errors.Annotatef(err, "creating audit log sink %q", name)
+}
+
+// recordWriter adapts a function writing a single Record into an
+// AuditLog.
+type recordWriter struct {
+	write func(Record) error
+	close func() error
+}
+
+// AddConversation implements AuditLog.
+func (w *recordWriter) AddConversation(c Conversation) error {
+	return errors.Trace(w.write(Record{Conversation: &c}))
+}
+
+// AddRequest implements AuditLog.
+func (w *recordWriter) AddRequest(r Request) error {
+	return errors.Trace(w.write(Record{Request: &r}))
+}
+
+// AddResponse implements AuditLog.
+func (w *recordWriter) AddResponse(r ResponseErrors) error {
+	return errors.Trace(w.write(Record{Errors: &r}))
+}
+
+// Close implements AuditLog.
+func (w *recordWriter) Close() error {
+	if w.close == nil {
+		return nil
+	}
+	return errors.Trace(w.close())
+}
+
+func newFileSink(cfg SinkConfig) (AuditLog, error) {
+	if cfg.LogDir == "" {
+		return nil, errors.NotValidf("empty log dir")
+	}
+	return NewLogFile(cfg.LogDir, cfg.MaxSizeMB, cfg.MaxBackups), nil
+}
+
+// syslogSender is the subset of *syslog.Client used by the syslog
+// sink.
+type syslogSender interface {
+	Send([]logfwd.Record) error
+	Close() error
+}
+
+var openSyslog = func(cfg syslog.RawConfig) (syslogSender, error) {
+	return syslog.Open(cfg)
+}
+
+func newSyslogSink(cfg SinkConfig) (AuditLog, error) {
+	syslogCfg := cfg.Syslog
+	syslogCfg.Enabled = true
+	if err := syslogCfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &syslogSink{cfg: syslogCfg, origin: cfg.Origin, clock: cfg.Clock}
+	return &recordWriter{
+		write: s.write,
+		close: s.close,
+	}, nil
+}
+
+// syslogSink forwards records to a remote syslog host. Like
+// socketSink, the connection is made lazily and re-established if a
+// send fails, so an unreachable host neither stops the sink from
+// being created nor needs the controller to be reconfigured once it
+// comes back.
+type syslogSink struct {
+	cfg    syslog.RawConfig
+	origin logfwd.Origin
+	clock  clock.Clock
+	client syslogSender
+	id     int64
+}
+
+func (s *syslogSink) write(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.client == nil {
+		if s.client, err = openSyslog(s.cfg); err != nil {
+			s.client = nil
+			return errors.Annotatef(err, "connecting to syslog host %q", s.cfg.Host)
+		}
+	}
+	s.id++
+	err = s.client.Send([]logfwd.Record{{
+		ID:        s.id,
+		Origin:    s.origin,
+		Timestamp: s.clock.Now(),
+		Level:     loggo.INFO,
+		Location:  logfwd.SourceLocation{Module: "juju.audit"},
+		Message:   string(data),
+	}})
+	if err != nil {
+		_ = s.client.Close()
+		s.client = nil
+		return errors.Annotatef(err, "sending to syslog host %q", s.cfg.Host)
+	}
+	return nil
+}
+
+func (s *syslogSink) close() error {
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	return errors.Trace(err)
+}
+
+func newSocketSink(cfg SinkConfig) (AuditLog, error) {
+	if cfg.SocketPath == "" {
+		return nil, errors.NotValidf("empty socket path")
+	}
+	s := &socketSink{path: cfg.SocketPath}
+	return &recordWriter{
+		write: s.write,
+		close: s.close,
+	}, nil
+}
+
+// socketSink writes newline-delimited JSON records to a Unix
+// socket. The connection is made lazily and re-established if a
+// write fails, so a listener that restarts doesn't need the
+// controller to be reconfigured.
+type socketSink struct {
+	path string
+	conn net.Conn
+}
+
+func (s *socketSink) write(r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data = append(data, '\n')
+	if s.conn == nil {
+		if s.conn, err = net.DialTimeout("unix", s.path, defaultSinkTimeout); err != nil {
+			s.conn = nil
+			return errors.Annotatef(err, "connecting to audit socket %q", s.path)
+		}
+	}
+	if _, err := s.conn.Write(data); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return errors.Annotatef(err, "writing to audit socket %q", s.path)
+	}
+	return nil
+}
+
+func (s *socketSink) close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return errors.Trace(err)
+}
+
+func newWebhookSink(cfg SinkConfig) (AuditLog, error) {
+	if cfg.WebhookURL == "" {
+		return nil, errors.NotValidf("empty webhook URL")
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultSinkTimeout}
+	}
+	return &recordWriter{
+		write: func(r Record) error {
+			data, err := json.Marshal(r)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			req, err := http.NewRequest(http.MethodPost, cfg.WebhookURL, bytes.NewReader(data))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			if err != nil {
+				return errors.Annotate(err, "posting audit record")
+			}
+			defer resp.Body.Close()
+			_, _ = io.Copy(io.Discard, resp.Body)
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return errors.Errorf("posting audit record: unexpected status %q", resp.Status)
+			}
+			return nil
+		},
+	}, nil
+}
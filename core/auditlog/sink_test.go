@@ -0,0 +1,263 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SinkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkSuite{})
+
+var testConversation = auditlog.Conversation{
+	Who:            "deerhoof",
+	What:           "gojira",
+	When:           "2017-11-27T13:21:24Z",
+	ModelName:      "admin/default",
+	ConversationID: "0123456789abcdef",
+	ConnectionID:   "AC1",
+}
+
+func (s *SinkSuite) TestBuiltinSinkNames(c *gc.C) {
+	names := set.NewStrings(auditlog.SinkNames()...)
+	c.Assert(names.Contains("file"), jc.IsTrue)
+	c.Assert(names.Contains("socket"), jc.IsTrue)
+	c.Assert(names.Contains("syslog"), jc.IsTrue)
+	c.Assert(names.Contains("webhook"), jc.IsTrue)
+}
+
+func (s *SinkSuite) TestNewSinkNotFound(c *gc.C) {
+	_, err := auditlog.NewSink("carrier-pigeon", auditlog.SinkConfig{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SinkSuite) TestRegisterSink(c *gc.C) {
+	var log fakeLog
+	err := auditlog.RegisterSink("test-sink", func(auditlog.SinkConfig) (auditlog.AuditLog, error) {
+		return &log, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = auditlog.RegisterSink("test-sink", func(auditlog.SinkConfig) (auditlog.AuditLog, error) {
+		return nil, nil
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	sink, err := auditlog.NewSink("test-sink", auditlog.SinkConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink, gc.Equals, auditlog.AuditLog(&log))
+}
+
+func (s *SinkSuite) TestFileSink(c *gc.C) {
+	dir := c.MkDir()
+	sink, err := auditlog.NewSink("file", auditlog.SinkConfig{
+		LogDir:     dir,
+		MaxSizeMB:  300,
+		MaxBackups: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+
+	_, err = os.Stat(filepath.Join(dir, "audit.log"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SinkSuite) TestSocketSink(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "audit.sock")
+	listener, err := net.Listen("unix", path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	sink, err := auditlog.NewSink("socket", auditlog.SinkConfig{SocketPath: path})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+
+	select {
+	case line := <-received:
+		var record auditlog.Record
+		c.Assert(json.Unmarshal([]byte(line), &record), jc.ErrorIsNil)
+		c.Assert(*record.Conversation, jc.DeepEquals, testConversation)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for record")
+	}
+}
+
+func (s *SinkSuite) TestSocketSinkRequiresPath(c *gc.C) {
+	_, err := auditlog.NewSink("socket", auditlog.SinkConfig{})
+	c.Assert(err, gc.ErrorMatches, `creating audit log sink "socket": empty socket path not valid`)
+}
+
+func (s *SinkSuite) TestWebhookSink(c *gc.C) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, _ := io.ReadAll(req.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	sink, err := auditlog.NewSink("webhook", auditlog.SinkConfig{WebhookURL: server.URL})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		RequestID:      25,
+		Facade:         "Application",
+		Method:         "Deploy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var record auditlog.Record
+	c.Assert(json.Unmarshal(<-received, &record), jc.ErrorIsNil)
+	c.Assert(record.Request.Method, gc.Equals, "Deploy")
+}
+
+func (s *SinkSuite) TestWebhookSinkErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := auditlog.NewSink("webhook", auditlog.SinkConfig{WebhookURL: server.URL})
+	c.Assert(err, jc.ErrorIsNil)
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, gc.ErrorMatches, `posting audit record: unexpected status "503 Service Unavailable"`)
+}
+
+func (s *SinkSuite) TestSyslogSink(c *gc.C) {
+	sender := &fakeSyslogSender{}
+	var openedWith syslog.RawConfig
+	s.PatchValue(auditlog.OpenSyslog, func(cfg syslog.RawConfig) (auditlog.SyslogSender, error) {
+		openedWith = cfg
+		return sender, nil
+	})
+
+	now := time.Date(2017, 11, 27, 13, 21, 24, 0, time.UTC)
+	origin := logfwd.Origin{ControllerUUID: "controller-uuid"}
+	sink, err := auditlog.NewSink("syslog", auditlog.SinkConfig{
+		Syslog: syslog.RawConfig{
+			Host:       "syslog.example.com",
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+		Origin: origin,
+		Clock:  testclock.NewClock(now),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// The host isn't contacted until there is something to send.
+	c.Assert(openedWith.Host, gc.Equals, "")
+
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openedWith.Host, gc.Equals, "syslog.example.com")
+	c.Assert(openedWith.Enabled, jc.IsTrue)
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+
+	sender.CheckCallNames(c, "Send", "Close")
+	records := sender.Calls()[0].Args[0].([]logfwd.Record)
+	c.Assert(records, gc.HasLen, 1)
+	c.Assert(records[0].ID, gc.Equals, int64(1))
+	c.Assert(records[0].Origin, jc.DeepEquals, origin)
+	c.Assert(records[0].Timestamp, gc.Equals, now)
+	c.Assert(records[0].Location.Module, gc.Equals, "juju.audit")
+
+	var record auditlog.Record
+	c.Assert(json.Unmarshal([]byte(records[0].Message), &record), jc.ErrorIsNil)
+	c.Assert(*record.Conversation, jc.DeepEquals, testConversation)
+}
+
+func (s *SinkSuite) TestSyslogSinkReconnects(c *gc.C) {
+	var senders []*fakeSyslogSender
+	s.PatchValue(auditlog.OpenSyslog, func(cfg syslog.RawConfig) (auditlog.SyslogSender, error) {
+		if len(senders) == 0 {
+			senders = append(senders, nil)
+			return nil, errors.New("connection refused")
+		}
+		sender := &fakeSyslogSender{}
+		senders = append(senders, sender)
+		return sender, nil
+	})
+
+	sink, err := auditlog.NewSink("syslog", auditlog.SinkConfig{
+		Syslog: syslog.RawConfig{
+			Host:       "syslog.example.com",
+			CACert:     coretesting.CACert,
+			ClientCert: coretesting.ServerCert,
+			ClientKey:  coretesting.ServerKey,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, gc.ErrorMatches, `connecting to syslog host "syslog.example.com": connection refused`)
+
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(senders, gc.HasLen, 2)
+
+	// A failed send drops the connection, and the next record is sent
+	// over a new one.
+	senders[1].SetErrors(errors.New("broken pipe"))
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, gc.ErrorMatches, `sending to syslog host "syslog.example.com": broken pipe`)
+	senders[1].CheckCallNames(c, "Send", "Send", "Close")
+
+	err = sink.AddConversation(testConversation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(senders, gc.HasLen, 3)
+	senders[2].CheckCallNames(c, "Send")
+}
+
+type fakeSyslogSender struct {
+	testing.Stub
+}
+
+func (s *fakeSyslogSender) Send(records []logfwd.Record) error {
+	s.AddCall("Send", records)
+	return s.NextErr()
+}
+
+func (s *fakeSyslogSender) Close() error {
+	s.AddCall("Close")
+	return s.NextErr()
+}
@@ -70,6 +70,35 @@ type Key struct {
 	Lease     string
 }
 
+// Filter restricts a set of leases to those matching every non-empty
+// field. Model UUIDs and lease names are matched by prefix, so that
+// partial values can be used when debugging; namespaces must match
+// exactly.
+type Filter struct {
+	ModelUUIDs []string
+	Namespaces []string
+	Leases     []string
+}
+
+// Match returns true if the supplied key satisfies the filter.
+func (f Filter) Match(key Key) bool {
+	return matchAny(f.ModelUUIDs, key.ModelUUID, strings.HasPrefix) &&
+		matchAny(f.Namespaces, key.Namespace, func(s, v string) bool { return s == v }) &&
+		matchAny(f.Leases, key.Lease, strings.HasPrefix)
+}
+
+func matchAny(values []string, s string, match func(string, string) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if match(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
 // Info holds substrate-independent information about a lease.
 type Info struct {
 	// Holder is the name of the current leaseholder.
@@ -80,6 +109,51 @@ type Info struct {
 	Expiry time.Time
 }
 
+// HistoryAction identifies the kind of change recorded in lease history.
+type HistoryAction string
+
+const (
+	// HistoryClaim records a holder claiming a lease.
+	HistoryClaim HistoryAction = "claim"
+
+	// HistoryExtend records a holder extending its lease after other
+	// changes to it. Consecutive extensions update the expiry of the
+	// holder's last claim or extension instead of being recorded.
+	HistoryExtend HistoryAction = "extend"
+
+	// HistoryExpire records a lease expiring.
+	HistoryExpire HistoryAction = "expire"
+
+	// HistoryRevoke records a holder revoking its lease.
+	HistoryRevoke HistoryAction = "revoke"
+
+	// HistoryPin records an entity pinning a lease.
+	HistoryPin HistoryAction = "pin"
+
+	// HistoryUnpin records an entity removing its pin from a lease.
+	HistoryUnpin HistoryAction = "unpin"
+)
+
+// HistoryEntry is a single change to a lease, as recorded in the lease
+// history.
+type HistoryEntry struct {
+	// Key identifies the lease that was changed.
+	Key Key
+
+	// Action is the kind of change made to the lease.
+	Action HistoryAction
+
+	// Entity is the lease holder, or for pins and unpins, the entity
+	// responsible for the pin.
+	Entity string
+
+	// Expiry is the lease expiry time following the change, if known.
+	Expiry time.Time
+
+	// Time is when the change was recorded.
+	Time time.Time
+}
+
 // Request describes a lease request.
 type Request struct {
 
@@ -33,6 +33,10 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Suspended is true when the migration has stopped at its current
+	// phase after a failure, and is waiting to be resumed or aborted.
+	Suspended bool
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -62,6 +66,24 @@ type SerializedModelResource struct {
 	UnitRevisions       map[string]resources.Resource
 }
 
+// ImportedBinaries describes the binaries a target controller already
+// holds for a model it is importing. It allows a resumed migration to
+// skip binaries which were transferred by an earlier attempt.
+type ImportedBinaries struct {
+	// Charms maps the URLs of charms whose archives have been
+	// uploaded to the SHA256 of the archive they were uploaded from.
+	// The target repackages uploaded charms, so this is not the hash
+	// of the archive it stores.
+	Charms map[string]string
+
+	// Tools maps agent binary versions to the SHA256 of the tarball.
+	Tools map[version.Binary]string
+
+	// Resources maps application names to resource names and the
+	// SHA384 fingerprint of each resource.
+	Resources map[string]map[string]string
+}
+
 // ModelInfo is used to report basic details about a model.
 type ModelInfo struct {
 	UUID                   string
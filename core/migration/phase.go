@@ -103,6 +103,19 @@ func (p Phase) IsRunning() bool {
 	}
 }
 
+// CanSuspend returns true if a migration which fails in the phase may
+// be suspended, to be resumed from the same phase later, rather than
+// aborted. These are the phases in which the target controller holds
+// the imported model but it has not yet been activated.
+func (p Phase) CanSuspend() bool {
+	switch p {
+	case IMPORT, VALIDATION:
+		return true
+	default:
+		return false
+	}
+}
+
 // Define all possible phase transitions.
 //
 // The keys are the "from" states and the values enumerate the
@@ -72,6 +72,18 @@ func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 	c.Check(migration.ABORTDONE.IsRunning(), jc.IsFalse)
 }
 
+func (s *PhaseSuite) TestCanSuspend(c *gc.C) {
+	c.Check(migration.IMPORT.CanSuspend(), jc.IsTrue)
+	c.Check(migration.VALIDATION.CanSuspend(), jc.IsTrue)
+
+	c.Check(migration.UNKNOWN.CanSuspend(), jc.IsFalse)
+	c.Check(migration.QUIESCE.CanSuspend(), jc.IsFalse)
+	c.Check(migration.PROCESSRELATIONS.CanSuspend(), jc.IsFalse)
+	c.Check(migration.SUCCESS.CanSuspend(), jc.IsFalse)
+	c.Check(migration.ABORT.CanSuspend(), jc.IsFalse)
+	c.Check(migration.DONE.CanSuspend(), jc.IsFalse)
+}
+
 func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.SUCCESS), jc.IsFalse)
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.ABORT), jc.IsTrue)
@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+// PrecheckSeverity describes how an issue found by the migration
+// prechecks affects a migration.
+type PrecheckSeverity string
+
+const (
+	// PrecheckBlocker is the severity of issues which prevent a
+	// migration from starting.
+	PrecheckBlocker PrecheckSeverity = "blocker"
+
+	// PrecheckWarning is the severity of issues which do not prevent a
+	// migration, but which may affect it or the migrated model.
+	PrecheckWarning PrecheckSeverity = "warning"
+)
+
+const (
+	// PrecheckSource identifies issues found on the source controller.
+	PrecheckSource = "source"
+
+	// PrecheckTarget identifies issues found on the target controller.
+	PrecheckTarget = "target"
+)
+
+// PrecheckIssue is a single issue found by the migration prechecks.
+type PrecheckIssue struct {
+	// Severity describes how the issue affects the migration.
+	Severity PrecheckSeverity
+
+	// Controller identifies the controller on which the issue was
+	// found, either PrecheckSource or PrecheckTarget.
+	Controller string
+
+	// Message describes the issue.
+	Message string
+}
+
+// PrecheckIssues holds the issues found by the migration prechecks.
+type PrecheckIssues []PrecheckIssue
+
+// Blocked returns true if any of the issues prevent a migration.
+func (issues PrecheckIssues) Blocked() bool {
+	for _, issue := range issues {
+		if issue.Severity == PrecheckBlocker {
+			return true
+		}
+	}
+	return false
+}
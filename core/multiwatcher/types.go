@@ -28,6 +28,7 @@ const (
 	ModelKind             = "model"
 	RelationKind          = "relation"
 	RemoteApplicationKind = "remoteApplication"
+	SecretKind            = "secret"
 	UnitKind              = "unit"
 )
 
@@ -456,6 +457,10 @@ type RelationInfo struct {
 	Key       string
 	ID        int
 	Endpoints []Endpoint
+	Life      life.Value
+	Status    StatusInfo
+	UnitCount int
+	Suspended bool
 }
 
 // Endpoint holds an application-relation pair.
@@ -496,6 +501,49 @@ func (i *RelationInfo) Clone() EntityInfo {
 	return &clone
 }
 
+// SecretInfo holds the information about a secret that is tracked
+// by multiwatcherStore.
+type SecretInfo struct {
+	ModelUUID      string
+	ID             string
+	URI            string
+	Label          string
+	Description    string
+	OwnerTag       string
+	RotatePolicy   string
+	LatestRevision int
+	LatestExpire   *time.Time
+	// Consumers maps each consumer tag to the revision it is currently
+	// using.
+	Consumers map[string]int
+}
+
+// EntityID returns a unique identifier for a secret across
+// models.
+func (i *SecretInfo) EntityID() EntityID {
+	return EntityID{
+		Kind:      SecretKind,
+		ModelUUID: i.ModelUUID,
+		ID:        i.ID,
+	}
+}
+
+// Clone returns a clone of the EntityInfo.
+func (i *SecretInfo) Clone() EntityInfo {
+	clone := *i
+	if i.LatestExpire != nil {
+		expire := *i.LatestExpire
+		clone.LatestExpire = &expire
+	}
+	if i.Consumers != nil {
+		clone.Consumers = make(map[string]int, len(i.Consumers))
+		for k, v := range i.Consumers {
+			clone.Consumers[k] = v
+		}
+	}
+	return &clone
+}
+
 // AnnotationInfo holds the information about an annotation that is
 // tracked by multiwatcherStore.
 type AnnotationInfo struct {
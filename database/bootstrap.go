@@ -12,7 +12,6 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/database/app"
 	"github.com/juju/juju/database/pragma"
-	"github.com/juju/juju/database/schema"
 )
 
 type bootstrapNodeManager interface {
@@ -47,8 +46,8 @@ type bootstrapNodeManager interface {
 	WithTracingOption() app.Option
 }
 
-// BootstrapDqlite opens a new database for the controller, and runs the
-// DDL to create its schema.
+// BootstrapDqlite opens a new database for the controller, and applies the
+// versioned schema patches to create its schema.
 //
 // It accepts an optional list of functions to perform operations on the
 // controller database.
@@ -108,7 +107,7 @@ func BootstrapDqlite(
 		return errors.Annotate(err, "setting foreign keys pragma")
 	}
 
-	if err := NewDBMigration(db, logger, schema.ControllerDDL()).Apply(); err != nil {
+	if err := NewControllerSchemaMigration(sqlDBRunner{db: db}, logger).Apply(ctx); err != nil {
 		return errors.Annotate(err, "creating controller database schema")
 	}
 
@@ -3,21 +3,28 @@
 
 package schema
 
-// ControllerDDL is used to create the controller database schema at bootstrap.
-func ControllerDDL() []string {
-	schemas := []func() string{
-		leaseSchema,
-		changeLogSchema,
-		cloudSchema,
-		externalControllerSchema,
-	}
-
-	var deltas []string
-	for _, fn := range schemas {
-		deltas = append(deltas, fn())
+// ControllerBaselineVersion is the last controller schema version that was
+// created at bootstrap before schema versions were recorded. A controller
+// database with these tables but no recorded versions is taken to be at
+// this version.
+const ControllerBaselineVersion = 4
+
+// ControllerPatches returns the versioned patches that make up the
+// controller database schema. New changes to the schema must be added as
+// new patches at the end of the list; released patches must not be edited.
+func ControllerPatches() Patches {
+	return Patches{
+		{Version: 1, Name: "lease", DDL: leaseSchema()},
+		{Version: 2, Name: "change log", DDL: changeLogSchema()},
+		{Version: 3, Name: "cloud", DDL: cloudSchema()},
+		{Version: 4, Name: "external controller", DDL: externalControllerSchema()},
+		{Version: 5, Name: "lease history", DDL: leaseHistorySchema()},
 	}
+}
 
-	return deltas
+// ControllerDDL is used to create the controller database schema at bootstrap.
+func ControllerDDL() []string {
+	return ControllerPatches().DDL()
 }
 
 func leaseSchema() string {
@@ -72,6 +79,55 @@ ON lease_pin (lease_uuid);
 `[1:]
 }
 
+func leaseHistorySchema() string {
+	return `
+CREATE TABLE lease_history_action (
+    id     INT PRIMARY KEY,
+    action TEXT
+);
+
+CREATE UNIQUE INDEX idx_lease_history_action_action
+ON lease_history_action (action);
+
+INSERT INTO lease_history_action VALUES
+    (0, 'claim'),
+    (1, 'extend'),
+    (2, 'expire'),
+    (3, 'revoke'),
+    (4, 'pin'),
+    (5, 'unpin');
+
+-- Rows are only removed from the lease history when they are pruned for
+-- being older than the history retention period. Extensions are recorded
+-- by updating the expiry of the holder's claim or extension when that is
+-- the lease's last change, so that a lease held for a long time doesn't
+-- fill the history.
+CREATE TABLE lease_history (
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    lease_type_id   INT NOT NULL,
+    model_uuid      TEXT NOT NULL,
+    name            TEXT NOT NULL,
+    action_id       INT NOT NULL,
+    -- The lease holder, or for pins the entity requiring the pin.
+    entity          TEXT,
+    expiry          TIMESTAMP,
+    created_at      DATETIME NOT NULL DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW', 'utc')),
+    CONSTRAINT      fk_lease_history_lease_type
+        FOREIGN KEY (lease_type_id)
+        REFERENCES  lease_type(id),
+    CONSTRAINT      fk_lease_history_action
+        FOREIGN KEY (action_id)
+        REFERENCES  lease_history_action(id)
+);
+
+CREATE INDEX idx_lease_history_model_type_name
+ON lease_history (model_uuid, lease_type_id, name);
+
+CREATE INDEX idx_lease_history_created_at
+ON lease_history (created_at);
+`[1:]
+}
+
 func changeLogSchema() string {
 	return `
 CREATE TABLE change_log_edit_type (
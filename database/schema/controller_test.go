@@ -56,6 +56,8 @@ func (s *schemaSuite) TestDDLApply(c *gc.C) {
 		"lease",
 		"lease_type",
 		"lease_pin",
+		"lease_history",
+		"lease_history_action",
 
 		// Change log
 		"change_log",
@@ -79,6 +81,34 @@ func (s *schemaSuite) TestDDLApply(c *gc.C) {
 	c.Assert(readTableNames(c, s.db), jc.SameContents, expected.Union(internalTableNames).SortedValues())
 }
 
+func (s *schemaSuite) TestControllerPatchesVersioned(c *gc.C) {
+	patches := ControllerPatches()
+	for i, patch := range patches {
+		c.Check(patch.Version, gc.Equals, i+1)
+		c.Check(patch.Name, gc.Not(gc.Equals), "")
+	}
+	c.Check(patches.Latest() >= ControllerBaselineVersion, jc.IsTrue)
+	c.Check(ControllerDDL(), jc.DeepEquals, patches.DDL())
+}
+
+// TestControllerPatchesUnchanged ensures that released patches are not
+// edited. Schema changes must be added as new patches instead.
+func (s *schemaSuite) TestControllerPatchesUnchanged(c *gc.C) {
+	released := map[int]string{
+		1: "80ca7c46680739c389f3f07dc20c74b8dc8251a41f9a9be741d0a925e8814d65",
+		2: "b9751cabcfcc92623ff348deb010de94cef16ee9d7f4856a71ea373ad15af2ff",
+		3: "5b0acfbe1c42f1b3384bd0e1999f5ca673a78fee523bb665e33aca667118fb75",
+		4: "d814cf15f0c33a3216e13d8e05e926627cedf4acfc959fdc69ea72f863637509",
+	}
+	for _, patch := range ControllerPatches() {
+		checksum, ok := released[patch.Version]
+		if !ok {
+			continue
+		}
+		c.Check(patch.Checksum(), gc.Equals, checksum, gc.Commentf("patch %d (%s)", patch.Version, patch.Name))
+	}
+}
+
 // NewCleanDB returns a new sql.DB reference.
 func (s *schemaSuite) NewCleanDB(c *gc.C) *sql.DB {
 	dir := c.MkDir()
@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Patch is a single versioned change to a database schema. Patches are
+// applied in version order, and once released a patch must never be
+// changed; its checksum is recorded when it is applied so that drift
+// between the running code and the database can be detected.
+type Patch struct {
+	// Version is the schema version that the patch brings the database
+	// up to. Versions start at 1 and increase by one for each patch.
+	Version int
+
+	// Name is a short human readable description of the patch.
+	Name string
+
+	// DDL is the statement (or statements) to execute.
+	DDL string
+}
+
+// Checksum returns the hex encoded SHA-256 of the patch DDL.
+func (p Patch) Checksum() string {
+	sum := sha256.Sum256([]byte(p.DDL))
+	return hex.EncodeToString(sum[:])
+}
+
+// Patches is an ordered list of schema patches.
+type Patches []Patch
+
+// Latest returns the version of the last patch, or 0 if there are none.
+func (p Patches) Latest() int {
+	if len(p) == 0 {
+		return 0
+	}
+	return p[len(p)-1].Version
+}
+
+// DDL returns the statements of all of the patches, in order.
+func (p Patches) DDL() []string {
+	deltas := make([]string, len(p))
+	for i, patch := range p {
+		deltas[i] = patch.DDL
+	}
+	return deltas
+}
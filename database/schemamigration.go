@@ -0,0 +1,235 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/database/schema"
+)
+
+// ErrSchemaNewer is returned when the database has schema patches applied
+// that are not known to this version of the code. This happens when an
+// older controller is started against a database that has already been
+// upgraded, and it is not safe to continue.
+const ErrSchemaNewer = errors.ConstError("database schema is newer than supported")
+
+// TxnRunner runs functions within a database transaction.
+type TxnRunner interface {
+	// Txn executes the input function within a transaction.
+	Txn(context.Context, func(context.Context, *sql.Tx) error) error
+}
+
+// SchemaLogger is the logging interface required by SchemaMigration.
+type SchemaLogger interface {
+	Warningf(string, ...interface{})
+	Debugf(string, ...interface{})
+}
+
+// sqlDBRunner adapts a sql.DB to the TxnRunner interface.
+type sqlDBRunner struct {
+	db *sql.DB
+}
+
+// Txn is part of the TxnRunner interface.
+func (r sqlDBRunner) Txn(ctx context.Context, fn func(context.Context, *sql.Tx) error) error {
+	return Txn(ctx, r.db, fn)
+}
+
+const (
+	createSchemaVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+    version     INT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    checksum    TEXT NOT NULL,
+    applied_at  DATETIME NOT NULL DEFAULT(STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW', 'utc'))
+);`
+
+	selectSchemaVersions = `SELECT version, checksum FROM schema_version ORDER BY version;`
+
+	insertSchemaVersion = `INSERT INTO schema_version (version, name, checksum) VALUES (?, ?, ?);`
+
+	selectTableExists = `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?;`
+)
+
+// SchemaMigration applies versioned schema patches to a database, recording
+// each applied patch in the schema_version table. Only patches that have
+// not yet been recorded are applied, each in its own transaction.
+type SchemaMigration struct {
+	runner  TxnRunner
+	logger  SchemaLogger
+	patches schema.Patches
+
+	// baselineVersion and baselineTable allow a database that was created
+	// before schema versions were recorded to be adopted. If the table
+	// exists but no versions are recorded, all patches up to and including
+	// the baseline version are recorded as applied without running them.
+	baselineVersion int
+	baselineTable   string
+}
+
+// NewSchemaMigration returns a reference to a new migration that is used
+// to apply the input patches to the database.
+func NewSchemaMigration(runner TxnRunner, logger SchemaLogger, patches schema.Patches) *SchemaMigration {
+	return &SchemaMigration{
+		runner:  runner,
+		logger:  logger,
+		patches: patches,
+	}
+}
+
+// NewControllerSchemaMigration returns a migration for the controller
+// database schema.
+func NewControllerSchemaMigration(runner TxnRunner, logger SchemaLogger) *SchemaMigration {
+	m := NewSchemaMigration(runner, logger, schema.ControllerPatches())
+	m.baselineVersion = schema.ControllerBaselineVersion
+	m.baselineTable = "lease"
+	return m
+}
+
+// Pending returns the patches that are yet to be applied to the database.
+// It returns an error satisfying ErrSchemaNewer if the database has been
+// patched beyond the versions known to this migration, and an error if
+// the checksum of an applied patch doesn't match the known patch.
+func (m *SchemaMigration) Pending(ctx context.Context) (schema.Patches, error) {
+	var pending schema.Patches
+	err := m.runner.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, createSchemaVersionTable); err != nil {
+			return errors.Annotate(err, "creating schema version table")
+		}
+		applied, err := m.appliedVersions(ctx, tx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pending, err = m.pending(applied)
+		return errors.Trace(err)
+	})
+	return pending, errors.Trace(err)
+}
+
+// Apply brings the database schema up to date, applying each pending patch
+// inside its own transaction.
+func (m *SchemaMigration) Apply(ctx context.Context) error {
+	if err := m.adoptBaseline(ctx); err != nil {
+		return errors.Trace(err)
+	}
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, patch := range pending {
+		m.logger.Debugf("applying schema patch %d (%s)", patch.Version, patch.Name)
+
+		err := m.runner.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			// Another controller may have applied the patch since the
+			// pending patches were read, in which case there is nothing
+			// more to do.
+			applied, err := m.appliedVersions(ctx, tx)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, ok := applied[patch.Version]; ok {
+				return nil
+			}
+
+			if _, err := tx.ExecContext(ctx, patch.DDL); err != nil {
+				return errors.Trace(err)
+			}
+			_, err = tx.ExecContext(ctx, insertSchemaVersion, patch.Version, patch.Name, patch.Checksum())
+			return errors.Trace(err)
+		})
+		if err != nil {
+			return errors.Annotatef(err, "applying schema patch %d (%s)", patch.Version, patch.Name)
+		}
+	}
+	return nil
+}
+
+// adoptBaseline records the baseline patches as applied, if the database
+// was created before schema versions were recorded.
+func (m *SchemaMigration) adoptBaseline(ctx context.Context) error {
+	if m.baselineVersion == 0 {
+		return nil
+	}
+	return m.runner.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, createSchemaVersionTable); err != nil {
+			return errors.Annotate(err, "creating schema version table")
+		}
+		applied, err := m.appliedVersions(ctx, tx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(applied) > 0 {
+			return nil
+		}
+
+		var count int
+		if err := tx.QueryRowContext(ctx, selectTableExists, m.baselineTable).Scan(&count); err != nil {
+			return errors.Trace(err)
+		}
+		if count == 0 {
+			return nil
+		}
+
+		m.logger.Warningf("recording unversioned database schema as version %d", m.baselineVersion)
+		for _, patch := range m.patches {
+			if patch.Version > m.baselineVersion {
+				break
+			}
+			if _, err := tx.ExecContext(ctx, insertSchemaVersion, patch.Version, patch.Name, patch.Checksum()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *SchemaMigration) appliedVersions(ctx context.Context, tx *sql.Tx) (map[int]string, error) {
+	rows, err := tx.QueryContext(ctx, selectSchemaVersions)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading schema versions")
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]string)
+	for rows.Next() {
+		var (
+			version  int
+			checksum string
+		)
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, errors.Trace(err)
+		}
+		applied[version] = checksum
+	}
+	return applied, errors.Trace(rows.Err())
+}
+
+func (m *SchemaMigration) pending(applied map[int]string) (schema.Patches, error) {
+	latest := m.patches.Latest()
+	for version := range applied {
+		if version > latest {
+			return nil, errors.Annotatef(ErrSchemaNewer, "database at version %d, latest known version %d", version, latest)
+		}
+	}
+
+	var pending schema.Patches
+	for _, patch := range m.patches {
+		checksum, ok := applied[patch.Version]
+		if !ok {
+			pending = append(pending, patch)
+			continue
+		}
+		if checksum != patch.Checksum() {
+			return nil, errors.Errorf("schema patch %d (%s) checksum mismatch: database has %q, expected %q",
+				patch.Version, patch.Name, checksum, patch.Checksum())
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	_ "github.com/mattn/go-sqlite3"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/database/schema"
+)
+
+type schemaMigrationSuite struct {
+	testing.IsolationSuite
+
+	db *sql.DB
+}
+
+var _ = gc.Suite(&schemaMigrationSuite{})
+
+func (s *schemaMigrationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	// Each test gets its own file backed database, so that the recorded
+	// schema versions don't leak between tests.
+	url := fmt.Sprintf("file:%s/db.sqlite3?_foreign_keys=1", c.MkDir())
+	db, err := sql.Open("sqlite3", url)
+	c.Assert(err, jc.ErrorIsNil)
+	s.db = db
+
+	s.AddCleanup(func(c *gc.C) {
+		c.Assert(s.db.Close(), jc.ErrorIsNil)
+	})
+}
+
+var bandPatches = schema.Patches{
+	{Version: 1, Name: "band", DDL: "CREATE TABLE band(name TEXT PRIMARY KEY);"},
+	{Version: 2, Name: "album", DDL: "CREATE TABLE album(name TEXT PRIMARY KEY, band TEXT);"},
+}
+
+func (s *schemaMigrationSuite) TestApply(c *gc.C) {
+	m := NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches)
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+
+	c.Check(s.readVersions(c), jc.DeepEquals, []int{1, 2})
+	_, err := s.db.Exec("INSERT INTO album VALUES ('Hidden History of the Human Race', 'Blood Incantation');")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Applying again is a no-op.
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+	pending, err := m.Pending(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pending, gc.HasLen, 0)
+}
+
+func (s *schemaMigrationSuite) TestApplyOnlyPending(c *gc.C) {
+	m := NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches[:1])
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+	c.Check(s.readVersions(c), jc.DeepEquals, []int{1})
+
+	m = NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches)
+	pending, err := m.Pending(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pending, jc.DeepEquals, bandPatches[1:])
+
+	// If the first patch was run again, creating the band table would fail.
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+	c.Check(s.readVersions(c), jc.DeepEquals, []int{1, 2})
+}
+
+func (s *schemaMigrationSuite) TestApplyNewerSchema(c *gc.C) {
+	m := NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches)
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+
+	m = NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches[:1])
+	err := m.Apply(context.Background())
+	c.Assert(err, jc.ErrorIs, ErrSchemaNewer)
+	c.Assert(err, gc.ErrorMatches, `database at version 2, latest known version 1: database schema is newer than supported`)
+}
+
+func (s *schemaMigrationSuite) TestApplyChecksumMismatch(c *gc.C) {
+	m := NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, bandPatches[:1])
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+
+	m = NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, schema.Patches{
+		{Version: 1, Name: "band", DDL: "CREATE TABLE band(name TEXT PRIMARY KEY, genre TEXT);"},
+	})
+	err := m.Apply(context.Background())
+	c.Assert(err, gc.ErrorMatches, `schema patch 1 \(band\) checksum mismatch: .*`)
+}
+
+func (s *schemaMigrationSuite) TestApplyFailureRollsBack(c *gc.C) {
+	m := NewSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{}, schema.Patches{
+		bandPatches[0],
+		{Version: 2, Name: "broken", DDL: `
+CREATE TABLE album(name TEXT PRIMARY KEY);
+INSERT INTO nowhere VALUES ('nothing');`},
+	})
+	err := m.Apply(context.Background())
+	c.Assert(err, gc.ErrorMatches, `applying schema patch 2 \(broken\): .*`)
+
+	// The first patch was applied, but nothing from the second was.
+	c.Check(s.readVersions(c), jc.DeepEquals, []int{1})
+	_, err = s.db.Exec("SELECT * FROM album;")
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *schemaMigrationSuite) TestControllerSchema(c *gc.C) {
+	m := NewControllerSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{})
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+
+	var expected []int
+	for _, patch := range schema.ControllerPatches() {
+		expected = append(expected, patch.Version)
+	}
+	c.Check(s.readVersions(c), jc.DeepEquals, expected)
+}
+
+func (s *schemaMigrationSuite) TestControllerSchemaAdoptsBaseline(c *gc.C) {
+	// Create the schema the way bootstrap used to, without recording
+	// any versions.
+	err := NewDBMigration(s.db, stubLogger{}, schema.ControllerPatches()[:schema.ControllerBaselineVersion].DDL()).Apply()
+	c.Assert(err, jc.ErrorIsNil)
+
+	m := NewControllerSchemaMigration(sqlDBRunner{db: s.db}, stubLogger{})
+	c.Assert(m.Apply(context.Background()), jc.ErrorIsNil)
+
+	var expected []int
+	for _, patch := range schema.ControllerPatches() {
+		expected = append(expected, patch.Version)
+	}
+	c.Check(s.readVersions(c), jc.DeepEquals, expected)
+}
+
+func (s *schemaMigrationSuite) readVersions(c *gc.C) []int {
+	rows, err := s.db.Query("SELECT version FROM schema_version ORDER BY version;")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = rows.Close() }()
+
+	var versions []int
+	for rows.Next() {
+		var version int
+		c.Assert(rows.Scan(&version), jc.ErrorIsNil)
+		versions = append(versions, version)
+	}
+	c.Assert(rows.Err(), jc.ErrorIsNil)
+	return versions
+}
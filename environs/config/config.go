@@ -25,6 +25,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/charmhub"
+	"github.com/juju/juju/charmhub/mirror"
 	"github.com/juju/juju/controller"
 	corebase "github.com/juju/juju/core/base"
 	corelogger "github.com/juju/juju/core/logger"
@@ -32,6 +33,8 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/logfwd/otlp"
 	"github.com/juju/juju/logfwd/syslog"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -223,6 +226,51 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdJSONEnabled determines whether logs are forwarded as
+	// newline-delimited JSON over TCP.
+	LogFwdJSONEnabled = "logforward-json-enabled"
+
+	// LogFwdJSONHost sets the hostname:port that JSON log records are
+	// sent to.
+	LogFwdJSONHost = "logforward-json-host"
+
+	// LogFwdJSONCACert sets the certificate of the CA that signed the
+	// JSON log server certificate.
+	LogFwdJSONCACert = "logforward-json-ca-cert"
+
+	// LogFwdJSONClientCert sets the client certificate for JSON log
+	// forwarding.
+	LogFwdJSONClientCert = "logforward-json-client-cert"
+
+	// LogFwdJSONClientKey sets the client key for JSON log forwarding.
+	LogFwdJSONClientKey = "logforward-json-client-key"
+
+	// LogFwdJSONBatchSize sets the maximum number of JSON log records
+	// written at once.
+	LogFwdJSONBatchSize = "logforward-json-batch-size"
+
+	// LogFwdOTLPEnabled determines whether logs are forwarded to an
+	// OpenTelemetry collector using OTLP/HTTP.
+	LogFwdOTLPEnabled = "logforward-otlp-enabled"
+
+	// LogFwdOTLPEndpoint sets the URL of the OTLP/HTTP logs endpoint.
+	LogFwdOTLPEndpoint = "logforward-otlp-endpoint"
+
+	// LogFwdOTLPCACert sets the certificate of the CA that signed the
+	// OTLP collector certificate.
+	LogFwdOTLPCACert = "logforward-otlp-ca-cert"
+
+	// LogFwdOTLPClientCert sets the client certificate for OTLP log
+	// forwarding.
+	LogFwdOTLPClientCert = "logforward-otlp-client-cert"
+
+	// LogFwdOTLPClientKey sets the client key for OTLP log forwarding.
+	LogFwdOTLPClientKey = "logforward-otlp-client-key"
+
+	// LogFwdOTLPBatchSize sets the maximum number of log records sent
+	// in a single OTLP export request.
+	LogFwdOTLPBatchSize = "logforward-otlp-batch-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -425,6 +473,21 @@ type Config struct {
 	// unknown holds the other attributes that are passed in (aka UnknownAttrs).
 	// the union of these two are AllAttrs
 	defined, unknown map[string]interface{}
+
+	// controllerAPIAddresses holds the API addresses of the controller
+	// hosting the model. They aren't part of the configuration, but
+	// are needed to validate changes to charmhub-url.
+	controllerAPIAddresses []string
+}
+
+// WithControllerAPIAddresses returns a copy of the configuration which
+// records the API addresses, in host:port form, of the controller
+// hosting the model. Validate only allows charmhub-url to be changed to
+// or from the charmhub mirror served at one of these addresses.
+func (c *Config) WithControllerAPIAddresses(addrs []string) *Config {
+	result := *c
+	result.controllerAPIAddresses = addrs
+	return &result
 }
 
 // Defaulting is a value that specifies whether a configuration
@@ -751,6 +814,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdJSON(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid JSON log forwarding config")
+		}
+	}
+
+	if lfCfg, ok := cfg.LogFwdOTLP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid OTLP log forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -919,9 +994,14 @@ func Validate(cfg, old *Config) error {
 			if !ok {
 				continue
 			}
-			if newv := cfg.defined[attr]; newv != oldv {
-				return fmt.Errorf("cannot change %s from %#v to %#v", attr, oldv, newv)
+			newv := cfg.defined[attr]
+			if newv == oldv {
+				continue
+			}
+			if attr == CharmHubURLKey && charmHubURLChangeAllowed(oldv, newv, cfg.controllerAPIAddresses) {
+				continue
 			}
+			return fmt.Errorf("cannot change %s from %#v to %#v", attr, oldv, newv)
 		}
 		if _, oldFound := old.AgentVersion(); oldFound {
 			if _, newFound := cfg.AgentVersion(); !newFound {
@@ -1333,6 +1413,86 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdJSON returns the JSON-over-TCP log forwarding config.
+func (c *Config) LogFwdJSON() (*jsonstream.RawConfig, bool) {
+	partial := false
+	var lfCfg jsonstream.RawConfig
+
+	if s, ok := c.defined[LogFwdJSONEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdJSONHost]; ok && s != "" {
+		partial = true
+		lfCfg.Host = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONClientCert]; ok && s != "" {
+		partial = true
+		lfCfg.ClientCert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONClientKey]; ok && s != "" {
+		partial = true
+		lfCfg.ClientKey = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdJSONBatchSize]; ok {
+		lfCfg.BatchSize = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdOTLP returns the OTLP/HTTP log forwarding config.
+func (c *Config) LogFwdOTLP() (*otlp.RawConfig, bool) {
+	partial := false
+	var lfCfg otlp.RawConfig
+
+	if s, ok := c.defined[LogFwdOTLPEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdOTLPEndpoint]; ok && s != "" {
+		partial = true
+		lfCfg.Endpoint = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdOTLPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdOTLPClientCert]; ok && s != "" {
+		partial = true
+		lfCfg.ClientCert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdOTLPClientKey]; ok && s != "" {
+		partial = true
+		lfCfg.ClientKey = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdOTLPBatchSize]; ok {
+		lfCfg.BatchSize = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1566,6 +1726,16 @@ func (c *Config) CharmHubURL() (string, bool) {
 	return charmhub.DefaultServerURL, false
 }
 
+// charmHubURLChangeAllowed returns true if charmhub-url may be changed from
+// oldv to newv. A model may only be moved to or from the charmhub mirror
+// hosted by its controller, which has the given API addresses, so that the
+// charms it uses still resolve.
+func charmHubURLChangeAllowed(oldv, newv interface{}, apiAddresses []string) bool {
+	oldURL, _ := oldv.(string)
+	newURL, _ := newv.(string)
+	return mirror.IsMirrorURL(oldURL, apiAddresses) || mirror.IsMirrorURL(newURL, apiAddresses)
+}
+
 func (c *Config) validateCharmHubURL() error {
 	if v, ok := c.defined[CharmHubURLKey].(string); ok {
 		if v == "" {
@@ -1905,6 +2075,18 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdJSONEnabled:      schema.Omit,
+	LogFwdJSONHost:         schema.Omit,
+	LogFwdJSONCACert:       schema.Omit,
+	LogFwdJSONClientCert:   schema.Omit,
+	LogFwdJSONClientKey:    schema.Omit,
+	LogFwdJSONBatchSize:    schema.Omit,
+	LogFwdOTLPEnabled:      schema.Omit,
+	LogFwdOTLPEndpoint:     schema.Omit,
+	LogFwdOTLPCACert:       schema.Omit,
+	LogFwdOTLPClientCert:   schema.Omit,
+	LogFwdOTLPClientKey:    schema.Omit,
+	LogFwdOTLPBatchSize:    schema.Omit,
 	LoggingOutputKey:       schema.Omit,
 
 	// Storage related config.
@@ -2412,6 +2594,66 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdJSONEnabled: {
+		Description: `Whether logs are forwarded as newline-delimited JSON over TCP.`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONHost: {
+		Description: `The hostname:port that JSON log records are sent to.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONCACert: {
+		Description: `The certificate of the CA that signed the JSON log server certificate, in PEM format. If no certificates are set the connection is not encrypted.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONClientCert: {
+		Description: `The JSON log forwarding client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONClientKey: {
+		Description: `The JSON log forwarding client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdJSONBatchSize: {
+		Description: `The maximum number of JSON log records written at once (0 for no limit).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPEnabled: {
+		Description: `Whether logs are forwarded to an OpenTelemetry collector using OTLP/HTTP.`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPEndpoint: {
+		Description: `The URL of the OTLP/HTTP logs endpoint, eg https://otel.example.com:4318/v1/logs.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPCACert: {
+		Description: `The certificate of the CA that signed the OTLP collector certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPClientCert: {
+		Description: `The OTLP log forwarding client certificate in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPClientKey: {
+		Description: `The OTLP log forwarding client key in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdOTLPBatchSize: {
+		Description: `The maximum number of log records sent in a single OTLP export request (0 for no limit).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
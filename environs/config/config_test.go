@@ -608,6 +608,43 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "JSON log forwarding",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-json-enabled":     true,
+			"logforward-json-host":        "10.0.0.1:5170",
+			"logforward-json-ca-cert":     testing.CACert,
+			"logforward-json-client-cert": testing.ServerCert,
+			"logforward-json-client-key":  testing.ServerKey,
+			"logforward-json-batch-size":  100,
+		}),
+	}, {
+		about:       "JSON log forwarding without a port",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-json-enabled": true,
+			"logforward-json-host":    "10.0.0.1",
+		}),
+		err: `invalid JSON log forwarding config: Host "10.0.0.1" not valid`,
+	}, {
+		about:       "OTLP log forwarding",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-otlp-enabled":    true,
+			"logforward-otlp-endpoint":   "https://otel.example.com:4318/v1/logs",
+			"logforward-otlp-ca-cert":    testing.CACert,
+			"logforward-otlp-batch-size": 500,
+		}),
+	}, {
+		about:       "OTLP log forwarding with negative batch size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-otlp-enabled":    true,
+			"logforward-otlp-endpoint":   "https://otel.example.com:4318/v1/logs",
+			"logforward-otlp-batch-size": -1,
+		}),
+		err: `invalid OTLP log forwarding config: negative BatchSize not valid`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -778,6 +815,21 @@ func (test configTest) check(c *gc.C) {
 	keys, _ := test.attrs["authorized-keys"].(string)
 	c.Check(cfg.AuthorizedKeys(), gc.Equals, keys)
 
+	if v, ok := test.attrs["logforward-json-host"].(string); ok {
+		jsonCfg, hasJSONCfg := cfg.LogFwdJSON()
+		if c.Check(hasJSONCfg, jc.IsTrue) {
+			c.Check(jsonCfg.Host, gc.Equals, v)
+			c.Check(jsonCfg.BatchSize, gc.Equals, test.attrs["logforward-json-batch-size"])
+		}
+	}
+	if v, ok := test.attrs["logforward-otlp-endpoint"].(string); ok {
+		otlpCfg, hasOTLPCfg := cfg.LogFwdOTLP()
+		if c.Check(hasOTLPCfg, jc.IsTrue) {
+			c.Check(otlpCfg.Endpoint, gc.Equals, v)
+			c.Check(otlpCfg.BatchSize, gc.Equals, test.attrs["logforward-otlp-batch-size"])
+		}
+	}
+
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		if c.Check(hasLogCfg, jc.IsTrue) {
@@ -1012,6 +1064,29 @@ var validationTests = []validationTest{{
 	old:   testing.Attrs{"charmhub-url": "http://a.com"},
 	new:   testing.Attrs{"charmhub-url": "http://b.com"},
 	err:   `cannot change charmhub-url from "http://a.com" to "http://b.com"`,
+}, {
+	about: "Can change the charmhub-url to a controller mirror",
+	old:   testing.Attrs{"charmhub-url": "https://api.charmhub.io"},
+	new:   testing.Attrs{"charmhub-url": "https://10.0.0.1:17070/charmhub"},
+}, {
+	about: "Can change the charmhub-url from a controller mirror",
+	old:   testing.Attrs{"charmhub-url": "https://10.0.0.1:17070/charmhub"},
+	new:   testing.Attrs{"charmhub-url": "https://api.charmhub.io"},
+}, {
+	about: "Can't change the charmhub-url to a path other than a mirror",
+	old:   testing.Attrs{"charmhub-url": "https://api.charmhub.io"},
+	new:   testing.Attrs{"charmhub-url": "https://10.0.0.1:17070/charmhub-other"},
+	err:   `cannot change charmhub-url from "https://api.charmhub.io" to "https://10.0.0.1:17070/charmhub-other"`,
+}, {
+	about: "Can't change the charmhub-url to a mirror on another host",
+	old:   testing.Attrs{"charmhub-url": "https://api.charmhub.io"},
+	new:   testing.Attrs{"charmhub-url": "https://evil.example/charmhub"},
+	err:   `cannot change charmhub-url from "https://api.charmhub.io" to "https://evil.example/charmhub"`,
+}, {
+	about: "Can't change the charmhub-url from a mirror on another host",
+	old:   testing.Attrs{"charmhub-url": "https://10.0.0.2:17070/charmhub"},
+	new:   testing.Attrs{"charmhub-url": "https://evil.example"},
+	err:   `cannot change charmhub-url from "https://10.0.0.2:17070/charmhub" to "https://evil.example"`,
 }, {
 	about: "Can't clear apt-mirror",
 	old:   testing.Attrs{"apt-mirror": "http://mirror"},
@@ -1026,7 +1101,7 @@ func (s *ConfigSuite) TestValidateChange(c *gc.C) {
 
 	for i, test := range validationTests {
 		c.Logf("test %d: %s", i, test.about)
-		newConfig := newTestConfig(c, test.new)
+		newConfig := newTestConfig(c, test.new).WithControllerAPIAddresses([]string{"10.0.0.1:17070"})
 		oldConfig := newTestConfig(c, test.old)
 		err := config.Validate(newConfig, oldConfig)
 		if test.err == "" {
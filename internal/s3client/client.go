@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go/logging"
 	"github.com/juju/errors"
@@ -31,11 +32,13 @@ type Logger interface {
 // S3Client represents the S3 client methods required by objectClient
 type S3Client interface {
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
 }
 
 // Session represents the interface objectClient exports to interact with S3
 type Session interface {
 	GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error)
+	PutObject(ctx context.Context, bucketName, objectName string, body io.Reader) error
 }
 
 // objectsClient is a Juju shim around the AWS S3 client,
@@ -61,6 +64,23 @@ func (c *objectsClient) GetObject(ctx context.Context, bucketName, objectName st
 	return obj.Body, nil
 }
 
+// PutObject stores an object in an S3 object store, replacing any
+// existing object with the same name.
+func (c *objectsClient) PutObject(ctx context.Context, bucketName, objectName string, body io.Reader) error {
+	c.logger.Tracef("storing bucket %s object %s in s3 storage", bucketName, objectName)
+
+	_, err := c.client.PutObject(ctx,
+		&s3.PutObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(objectName),
+			Body:   body,
+		})
+	if err != nil {
+		return errors.Annotatef(err, "unable to put object %s on bucket %s using S3 client", objectName, bucketName)
+	}
+	return nil
+}
+
 type awsEndpointResolver struct {
 	endpoint string
 }
@@ -149,3 +169,45 @@ func NewS3Client(apiConn api.Connection, logger Logger) (Session, error) {
 		logger: logger,
 	}, nil
 }
+
+// ExternalConfig holds the details of an S3 compatible object store that
+// isn't hosted by the apiserver.
+type ExternalConfig struct {
+	// Endpoint is the URL of the object store. When empty, AWS S3 is
+	// used.
+	Endpoint string
+
+	// Region is the region of the object store. It defaults to us-east-1.
+	Region string
+
+	// AccessKey and SecretKey are the credentials used to access the
+	// object store. When empty, anonymous access is used.
+	AccessKey string
+	SecretKey string
+}
+
+// NewExternalS3Client creates a generic S3 client for an object store
+// that isn't hosted by the apiserver, such as somewhere to keep copies
+// of controller backups.
+func NewExternalS3Client(cfg ExternalConfig, logger Logger) (Session, error) {
+	opts := s3.Options{
+		Region: cfg.Region,
+		Logger: &awsLogger{logger: logger},
+		// Most S3 compatible stores don't support virtual hosted buckets.
+		UsePathStyle: true,
+		Credentials:  aws.AnonymousCredentials{},
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if cfg.Endpoint != "" {
+		opts.BaseEndpoint = aws.String(cfg.Endpoint)
+	}
+	if cfg.AccessKey != "" {
+		opts.Credentials = credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, "")
+	}
+	return &objectsClient{
+		client: s3.New(opts),
+		logger: logger,
+	}, nil
+}
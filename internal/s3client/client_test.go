@@ -10,6 +10,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"go.uber.org/mock/gomock"
@@ -50,3 +51,34 @@ func (s *s3ClientSuite) TestGetObject(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(blob), gc.Equals, "blob")
 }
+
+func (s *s3ClientSuite) TestPutObject(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	body := strings.NewReader("blob")
+	s.s3Client.EXPECT().PutObject(gomock.Any(), &s3.PutObjectInput{
+		Bucket: aws.String("bucket"),
+		Key:    aws.String("object"),
+		Body:   body,
+	}, gomock.Any()).Return(&s3.PutObjectOutput{}, nil)
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	err := cli.PutObject(context.Background(), "bucket", "object", body)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *s3ClientSuite) TestPutObjectError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	s.s3Client.EXPECT().PutObject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+
+	cli := objectsClient{
+		client: s.s3Client,
+		logger: loggo.GetLogger("juju.testing.s3client"),
+	}
+	err := cli.PutObject(context.Background(), "bucket", "object", strings.NewReader("blob"))
+	c.Assert(err, gc.ErrorMatches, "unable to put object object on bucket bucket using S3 client: boom")
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonstream
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	dialTimeout  = 30 * time.Second
+	writeTimeout = 30 * time.Second
+)
+
+// DialFunc opens the connection to the target host. If tlsCfg is not
+// nil the connection must use TLS.
+type DialFunc func(host string, tlsCfg *tls.Config) (net.Conn, error)
+
+func dial(host string, tlsCfg *tls.Config) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if tlsCfg == nil {
+		conn, err := dialer.Dial("tcp", host)
+		return conn, errors.Trace(err)
+	}
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, tlsCfg)
+	return conn, errors.Trace(err)
+}
+
+// Message is the JSON document written for each log record.
+type Message struct {
+	ID             int64     `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module,omitempty"`
+	Source         string    `json:"source,omitempty"`
+	Message        string    `json:"message"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Hostname       string    `json:"hostname,omitempty"`
+	OriginType     string    `json:"origin-type"`
+	OriginName     string    `json:"origin-name,omitempty"`
+	Software       string    `json:"software,omitempty"`
+	Version        string    `json:"version,omitempty"`
+}
+
+// Client writes log records to a TCP connection, one JSON document
+// per line.
+type Client struct {
+	conn      net.Conn
+	batchSize int
+}
+
+// Open connects to the remote host and wraps that connection in a
+// new client.
+func Open(cfg RawConfig) (*Client, error) {
+	client, err := OpenWithDialer(cfg, dial)
+	return client, errors.Trace(err)
+}
+
+// OpenWithDialer connects to the remote host using the given dial
+// function and wraps that connection in a new client.
+func OpenWithDialer(cfg RawConfig, dial DialFunc) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	conn, err := dial(cfg.Host, tlsCfg)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening client connection")
+	}
+	return &Client{
+		conn:      conn,
+		batchSize: cfg.BatchSize,
+	}, nil
+}
+
+// Close closes the client's connection.
+func (client *Client) Close() error {
+	return errors.Trace(client.conn.Close())
+}
+
+// Send writes the records to the remote host. Each batch of records
+// is written to the connection in one go.
+func (client *Client) Send(records []logfwd.Record) error {
+	for _, batch := range logfwd.Batches(records, client.batchSize) {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		for _, rec := range batch {
+			if err := encoder.Encode(messageFromRecord(rec)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if err := client.write(buf.Bytes()); err != nil {
+			return errors.Annotate(err, "writing log records")
+		}
+	}
+	return nil
+}
+
+func (client *Client) write(data []byte) error {
+	if err := client.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := client.conn.Write(data)
+	return errors.Trace(err)
+}
+
+func messageFromRecord(rec logfwd.Record) Message {
+	msg := Message{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Message:        rec.Message,
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Software:       rec.Origin.Software.Name,
+	}
+	if rec.Location.Filename != "" {
+		msg.Source = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+	}
+	if rec.Origin.Software.Name != "" {
+		msg.Version = rec.Origin.Software.Version.String()
+	}
+	return msg
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonstream_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonstream"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) record(id int64) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-deadbeef-machine-0",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "0",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("3.5.0"),
+			},
+		},
+		Timestamp: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "hook failed",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C, batchSize int) (*jsonstream.Client, net.Conn) {
+	clientConn, serverConn := net.Pipe()
+	var dialedHost string
+	var dialedTLS *tls.Config
+	client, err := jsonstream.OpenWithDialer(jsonstream.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c:9876",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		BatchSize:  batchSize,
+	}, func(host string, tlsCfg *tls.Config) (net.Conn, error) {
+		dialedHost, dialedTLS = host, tlsCfg
+		return clientConn, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dialedHost, gc.Equals, "a.b.c:9876")
+	c.Assert(dialedTLS, gc.NotNil)
+	c.Assert(dialedTLS.Certificates, gc.HasLen, 1)
+	return client, serverConn
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	client, server := s.open(c, 0)
+	defer client.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		reader := bufio.NewReader(server)
+		for i := 0; i < 2; i++ {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- line
+		}
+	}()
+
+	err := client.Send([]logfwd.Record{s.record(10), s.record(11)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, id := range []int64{10, 11} {
+		var msg jsonstream.Message
+		select {
+		case line := <-lines:
+			c.Assert(json.Unmarshal([]byte(line), &msg), jc.ErrorIsNil)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for record %d", id)
+		}
+		c.Check(msg, jc.DeepEquals, jsonstream.Message{
+			ID:             id,
+			Timestamp:      time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			Level:          "ERROR",
+			Module:         "juju.worker.uniter",
+			Source:         "uniter.go:42",
+			Message:        "hook failed",
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-deadbeef-machine-0",
+			OriginType:     "machine",
+			OriginName:     "0",
+			Software:       "jujud-machine-agent",
+			Version:        "3.5.0",
+		})
+	}
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client, server := s.open(c, 2)
+	defer client.Close()
+
+	// Each batch is a single write on the connection.
+	writes := make(chan int, 3)
+	go func() {
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := server.Read(buf)
+			if err != nil {
+				return
+			}
+			lines := 0
+			for _, b := range buf[:n] {
+				if b == '\n' {
+					lines++
+				}
+			}
+			writes <- lines
+		}
+	}()
+
+	err := client.Send([]logfwd.Record{s.record(1), s.record(2), s.record(3)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(<-writes, gc.Equals, 2)
+	c.Assert(<-writes, gc.Equals, 1)
+}
+
+func (s *ClientSuite) TestSendError(c *gc.C) {
+	client, server := s.open(c, 0)
+	server.Close()
+
+	err := client.Send([]logfwd.Record{s.record(1)})
+	c.Assert(err, gc.ErrorMatches, "writing log records: .*")
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonstream
+
+import (
+	"crypto/tls"
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// RawConfig holds the raw configuration data for a connection to a
+// JSON log forwarding target.
+type RawConfig struct {
+	// Enabled is true if forwarding to the target is enabled.
+	Enabled bool
+
+	// Host is the host-port of the target, in the form
+	// [domain-or-ip-addr]:[port].
+	Host string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If no
+	// certificates are set the connection is not encrypted.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting.
+	ClientKey string
+
+	// BatchSize is the maximum number of records written to the
+	// connection at once. Zero means no limit.
+	BatchSize int
+}
+
+// IsEnabled returns whether forwarding to the target is enabled.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Enabled || cfg.Host != "" {
+		if _, _, err := net.SplitHostPort(cfg.Host); err != nil {
+			return errors.NotValidf("Host %q", cfg.Host)
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS config to connect with, or nil if the
+// connection should not use TLS.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" && cfg.ClientCert == "" && cfg.ClientKey == "" {
+		return nil, nil
+	}
+	tlsCfg, err := logfwd.TLSConfig(cfg.CACert, cfg.ClientCert, cfg.ClientKey)
+	return tlsCfg, errors.Trace(err)
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonstream_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/jsonstream"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := jsonstream.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c:9876",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		BatchSize:  100,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutTLS(c *gc.C) {
+	cfg := jsonstream.RawConfig{
+		Enabled: true,
+		Host:    "a.b.c:9876",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg jsonstream.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingPort(c *gc.C) {
+	cfg := jsonstream.RawConfig{
+		Enabled: true,
+		Host:    "a.b.c",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Host "a.b.c" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeBatchSize(c *gc.C) {
+	cfg := jsonstream.RawConfig{
+		Host:      "a.b.c:9876",
+		BatchSize: -1,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `negative BatchSize not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadClientKeyPair(c *gc.C) {
+	cfg := jsonstream.RawConfig{
+		Enabled:    true,
+		Host:       "a.b.c:9876",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing client key pair: .*`)
+}
@@ -0,0 +1,6 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package jsonstream holds the tools needed to perform log forwarding
+// from Juju to a remote host as newline-delimited JSON over TCP.
+package jsonstream
@@ -0,0 +1,231 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const requestTimeout = 30 * time.Second
+
+// HTTPClient sends the export requests to the collector.
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to an OTLP/HTTP collector.
+type Client struct {
+	endpoint   string
+	batchSize  int
+	httpClient HTTPClient
+}
+
+// Open returns a client that sends logs to the configured collector.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	client, err := OpenWithHTTPClient(cfg, &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	})
+	return client, errors.Trace(err)
+}
+
+// OpenWithHTTPClient returns a client that sends logs to the
+// configured collector using the given HTTP client.
+func OpenWithHTTPClient(cfg RawConfig, httpClient HTTPClient) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		endpoint:   cfg.Endpoint,
+		batchSize:  cfg.BatchSize,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Close implements io.Closer. Requests are not kept open between
+// sends, so there is nothing to release.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send exports the records to the collector, one request per batch.
+func (client *Client) Send(records []logfwd.Record) error {
+	for _, batch := range logfwd.Batches(records, client.batchSize) {
+		if err := client.export(batch); err != nil {
+			return errors.Annotate(err, "exporting log records")
+		}
+	}
+	return nil
+}
+
+func (client *Client) export(records []logfwd.Record) error {
+	body, err := json.Marshal(exportRequestFromRecords(records))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, client.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("unexpected status %q", resp.Status)
+	}
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP
+// ExportLogsServiceRequest, restricted to the fields Juju sets.
+
+type exportRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type logRecord struct {
+	TimeUnixNano   string     `json:"timeUnixNano"`
+	SeverityNumber int        `json:"severityNumber"`
+	SeverityText   string     `json:"severityText"`
+	Body           anyValue   `json:"body"`
+	Attributes     []keyValue `json:"attributes"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int64) keyValue {
+	s := strconv.FormatInt(value, 10)
+	return keyValue{Key: key, Value: anyValue{IntValue: &s}}
+}
+
+// exportRequestFromRecords groups the records by origin, each origin
+// being an OTLP resource, keeping the records in order.
+func exportRequestFromRecords(records []logfwd.Record) exportRequest {
+	var req exportRequest
+	index := make(map[logfwd.Origin]int)
+	for _, rec := range records {
+		i, ok := index[rec.Origin]
+		if !ok {
+			i = len(req.ResourceLogs)
+			index[rec.Origin] = i
+			req.ResourceLogs = append(req.ResourceLogs, resourceLogs{
+				Resource:  resourceFromOrigin(rec.Origin),
+				ScopeLogs: []scopeLogs{{Scope: scope{Name: "juju"}}},
+			})
+		}
+		scope := &req.ResourceLogs[i].ScopeLogs[0]
+		scope.LogRecords = append(scope.LogRecords, logRecordFromRecord(rec))
+	}
+	return req
+}
+
+func resourceFromOrigin(origin logfwd.Origin) resource {
+	attrs := []keyValue{
+		stringAttr("service.name", origin.Software.Name),
+		stringAttr("service.version", origin.Software.Version.String()),
+		stringAttr("host.name", origin.Hostname),
+		stringAttr("juju.controller.uuid", origin.ControllerUUID),
+		stringAttr("juju.model.uuid", origin.ModelUUID),
+		stringAttr("juju.origin.type", origin.Type.String()),
+	}
+	if origin.Name != "" {
+		attrs = append(attrs, stringAttr("juju.origin.name", origin.Name))
+	}
+	return resource{Attributes: attrs}
+}
+
+func logRecordFromRecord(rec logfwd.Record) logRecord {
+	attrs := []keyValue{intAttr("juju.record.id", rec.ID)}
+	if rec.Location.Module != "" {
+		attrs = append(attrs, stringAttr("code.namespace", rec.Location.Module))
+	}
+	if rec.Location.Filename != "" {
+		attrs = append(attrs, stringAttr("code.filepath", rec.Location.Filename))
+		if rec.Location.Line > 0 {
+			attrs = append(attrs, intAttr("code.lineno", int64(rec.Location.Line)))
+		}
+	}
+	message := rec.Message
+	return logRecord{
+		TimeUnixNano:   fmt.Sprint(rec.Timestamp.UnixNano()),
+		SeverityNumber: severityNumber(rec.Level),
+		SeverityText:   rec.Level.String(),
+		Body:           anyValue{StringValue: &message},
+		Attributes:     attrs,
+	}
+}
+
+// severityNumber maps the log level to the start of the matching
+// OpenTelemetry severity range.
+func severityNumber(level loggo.Level) int {
+	switch level {
+	case loggo.TRACE:
+		return 1
+	case loggo.DEBUG:
+		return 5
+	case loggo.INFO:
+		return 9
+	case loggo.WARNING:
+		return 13
+	case loggo.ERROR:
+		return 17
+	case loggo.CRITICAL:
+		return 21
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/otlp"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) record(id int64, machine string) logfwd.Record {
+	return logfwd.Record{
+		ID: id,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-deadbeef-machine-" + machine,
+			Type:           logfwd.OriginTypeMachine,
+			Name:           machine,
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("3.5.0"),
+			},
+		},
+		Timestamp: time.Unix(1706695200, 0),
+		Level:     loggo.WARNING,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "hook failed",
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.URL.Path, gc.Equals, "/v1/logs")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	client, err := otlp.OpenWithHTTPClient(otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: server.URL + "/v1/logs",
+	}, server.Client())
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	err = client.Send([]logfwd.Record{s.record(10, "0"), s.record(11, "1")})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(bodies, gc.HasLen, 1)
+	c.Check(bodies[0], jc.JSONEquals, map[string]interface{}{
+		"resourceLogs": []interface{}{
+			expectedResourceLogs("0", "10"),
+			expectedResourceLogs("1", "11"),
+		},
+	})
+}
+
+func expectedResourceLogs(machine, id string) map[string]interface{} {
+	str := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": map[string]interface{}{"stringValue": value}}
+	}
+	integer := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": map[string]interface{}{"intValue": value}}
+	}
+	return map[string]interface{}{
+		"resource": map[string]interface{}{
+			"attributes": []interface{}{
+				str("service.name", "jujud-machine-agent"),
+				str("service.version", "3.5.0"),
+				str("host.name", "juju-deadbeef-machine-"+machine),
+				str("juju.controller.uuid", "feebdaed-2f18-4fd2-967d-db9663db7bea"),
+				str("juju.model.uuid", "deadbeef-2f18-4fd2-967d-db9663db7bea"),
+				str("juju.origin.type", "machine"),
+				str("juju.origin.name", machine),
+			},
+		},
+		"scopeLogs": []interface{}{map[string]interface{}{
+			"scope": map[string]interface{}{"name": "juju"},
+			"logRecords": []interface{}{map[string]interface{}{
+				"timeUnixNano":   "1706695200000000000",
+				"severityNumber": 13,
+				"severityText":   "WARNING",
+				"body":           map[string]interface{}{"stringValue": "hook failed"},
+				"attributes": []interface{}{
+					integer("juju.record.id", id),
+					str("code.namespace", "juju.worker.uniter"),
+					str("code.filepath", "uniter.go"),
+					integer("code.lineno", "42"),
+				},
+			}},
+		}},
+	}
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client, err := otlp.OpenWithHTTPClient(otlp.RawConfig{
+		Enabled:   true,
+		Endpoint:  server.URL,
+		BatchSize: 2,
+	}, server.Client())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.record(1, "0"), s.record(2, "0"), s.record(3, "0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(requests, gc.Equals, 2)
+}
+
+func (s *ClientSuite) TestSendErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client, err := otlp.OpenWithHTTPClient(otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: server.URL,
+	}, server.Client())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.record(1, "0")})
+	c.Assert(err, gc.ErrorMatches, `exporting log records: unexpected status "400 Bad Request"`)
+}
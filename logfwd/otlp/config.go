@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp
+
+import (
+	"crypto/tls"
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// RawConfig holds the raw configuration data for sending logs to an
+// OTLP/HTTP collector.
+type RawConfig struct {
+	// Enabled is true if forwarding to the collector is enabled.
+	Enabled bool
+
+	// Endpoint is the full URL of the collector's logs endpoint,
+	// for example https://otel.example.com:4318/v1/logs.
+	Endpoint string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the collector's certificate. If it is not set
+	// the host's root CAs are used.
+	CACert string
+
+	// ClientCert is the TLS certificate (x.509, PEM-encoded) to use
+	// when connecting.
+	ClientCert string
+
+	// ClientKey is the TLS private key (x.509, PEM-encoded) to use
+	// when connecting.
+	ClientKey string
+
+	// BatchSize is the maximum number of records sent in a single
+	// export request. Zero means no limit.
+	BatchSize int
+}
+
+// IsEnabled returns whether forwarding to the collector is enabled.
+func (cfg RawConfig) IsEnabled() bool {
+	return cfg.Enabled
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Enabled || cfg.Endpoint != "" {
+		if err := cfg.validateEndpoint(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateEndpoint() error {
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil || u.Host == "" {
+		return errors.NotValidf("Endpoint %q", cfg.Endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("Endpoint scheme %q", u.Scheme)
+	}
+	if u.Scheme == "http" && (cfg.CACert != "" || cfg.ClientCert != "" || cfg.ClientKey != "") {
+		return errors.NotValidf("TLS certificates with http Endpoint")
+	}
+	return nil
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	tlsCfg, err := logfwd.TLSConfig(cfg.CACert, cfg.ClientCert, cfg.ClientKey)
+	return tlsCfg, errors.Trace(err)
+}
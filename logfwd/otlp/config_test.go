@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package otlp_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/otlp"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:    true,
+		Endpoint:   "https://otel.example.com:4318/v1/logs",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+		BatchSize:  500,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg otlp.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadEndpoint(c *gc.C) {
+	for i, test := range []struct {
+		endpoint string
+		err      string
+	}{{
+		endpoint: "",
+		err:      `Endpoint "" not valid`,
+	}, {
+		endpoint: "otel.example.com:4318",
+		err:      `Endpoint "otel.example.com:4318" not valid`,
+	}, {
+		endpoint: "ftp://otel.example.com/v1/logs",
+		err:      `Endpoint scheme "ftp" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.endpoint)
+		cfg := otlp.RawConfig{
+			Enabled:  true,
+			Endpoint: test.endpoint,
+		}
+		c.Check(cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestRawValidateCertsNeedHTTPS(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Enabled:  true,
+		Endpoint: "http://otel.example.com:4318/v1/logs",
+		CACert:   coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `TLS certificates with http Endpoint not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeBatchSize(c *gc.C) {
+	cfg := otlp.RawConfig{
+		Endpoint:  "https://otel.example.com/v1/logs",
+		BatchSize: -10,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `negative BatchSize not valid`)
+}
@@ -0,0 +1,7 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package otlp holds the tools needed to perform log forwarding from
+// Juju to an OpenTelemetry collector, using the OTLP/HTTP protocol
+// with JSON encoding.
+package otlp
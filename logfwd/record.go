@@ -145,3 +145,21 @@ func (loc SourceLocation) Validate() error {
 
 	return nil
 }
+
+// Batches splits records into consecutive batches holding at most
+// size records each. A size of zero or less puts all of the records
+// into a single batch.
+func Batches(records []Record, size int) [][]Record {
+	if len(records) == 0 {
+		return nil
+	}
+	if size <= 0 || len(records) <= size {
+		return [][]Record{records}
+	}
+	batches := make([][]Record, 0, (len(records)+size-1)/size)
+	for len(records) > size {
+		batches = append(batches, records[:size])
+		records = records[size:]
+	}
+	return append(batches, records)
+}
@@ -173,3 +173,26 @@ var validRecord = logfwd.Record{
 	Location:  validLocation,
 	Message:   "uh-oh",
 }
+
+func (s *RecordSuite) TestBatches(c *gc.C) {
+	var recs []logfwd.Record
+	for i := int64(1); i <= 5; i++ {
+		rec := validRecord
+		rec.ID = i
+		recs = append(recs, rec)
+	}
+
+	batches := logfwd.Batches(recs, 2)
+
+	c.Assert(batches, gc.HasLen, 3)
+	c.Check(batches[0], jc.DeepEquals, recs[0:2])
+	c.Check(batches[1], jc.DeepEquals, recs[2:4])
+	c.Check(batches[2], jc.DeepEquals, recs[4:])
+}
+
+func (s *RecordSuite) TestBatchesUnlimited(c *gc.C) {
+	recs := []logfwd.Record{validRecord, validRecord}
+
+	c.Check(logfwd.Batches(recs, 0), jc.DeepEquals, [][]logfwd.Record{recs})
+	c.Check(logfwd.Batches(nil, 2), gc.HasLen, 0)
+}
@@ -123,7 +123,7 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 			Hostname: rfc5424.Hostname{
 				FQDN: rec.Origin.Hostname,
 			},
-			AppName: rfc5424.AppName((rec.Origin.Software.Name + "-" + rec.Origin.ModelUUID)[:48]),
+			AppName: appName(rec.Origin),
 		},
 		StructuredData: rfc5424.StructuredData{
 			&sdelements.Origin{
@@ -177,3 +177,13 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// appName returns the RFC 5424 APP-NAME for the origin, which is
+// limited to 48 characters.
+func appName(origin logfwd.Origin) rfc5424.AppName {
+	name := origin.Software.Name + "-" + origin.ModelUUID
+	if len(name) > 48 {
+		name = name[:48]
+	}
+	return rfc5424.AppName(name)
+}
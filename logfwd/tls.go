@@ -0,0 +1,37 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v3/cert"
+)
+
+// TLSConfig builds the TLS config for connecting to a forwarding
+// target from PEM-encoded certificates. The CA certificate is used to
+// validate the server; if it is empty the host's root CAs are used.
+// The client certificate and key are optional but must be given
+// together.
+func TLSConfig(caCert, clientCert, clientKey string) (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if clientCert != "" || clientKey != "" {
+		keyPair, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing client key pair")
+		}
+		cfg.Certificates = []tls.Certificate{keyPair}
+	}
+	if caCert != "" {
+		ca, err := cert.ParseCert(caCert)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing CA certificate")
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		cfg.RootCAs.AddCert(ca)
+	}
+	return cfg, nil
+}
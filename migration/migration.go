@@ -97,6 +97,16 @@ func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (
 	return dbModel, dbState, nil
 }
 
+// ValidateModel deserializes a model description from the bytes and
+// checks that it is consistent, without importing it.
+func ValidateModel(bytes []byte) error {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.Validate())
+}
+
 // CharmDownloader defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
@@ -150,6 +160,11 @@ type UploadBinariesConfig struct {
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// Imported optionally describes the binaries which the target
+	// controller already holds, having been uploaded by an earlier
+	// attempt at the migration. These are not uploaded again.
+	Imported migration.ImportedBinaries
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -222,16 +237,26 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 }
 
 func hashArchive(archive io.ReadSeeker) (string, error) {
+	hash, err := hashContent(archive)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return hash[0:7], nil
+}
+
+// hashContent returns the hex encoded SHA256 of the content, leaving
+// it positioned at the start to be read again.
+func hashContent(content io.ReadSeeker) (string, error) {
 	hash := sha256.New()
-	_, err := io.Copy(hash, archive)
+	_, err := io.Copy(hash, content)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	_, err = archive.Seek(0, os.SEEK_SET)
+	_, err = content.Seek(0, os.SEEK_SET)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	return hex.EncodeToString(hash.Sum(nil))[0:7], nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func uploadCharms(config UploadBinariesConfig) error {
@@ -241,7 +266,6 @@ func uploadCharms(config UploadBinariesConfig) error {
 	naturalsort.Sort(config.Charms)
 
 	for _, charmURL := range config.Charms {
-		logger.Debugf("sending charm %s to target", charmURL)
 		reader, err := config.CharmDownloader.OpenCharm(charmURL)
 		if err != nil {
 			return errors.Annotate(err, "cannot open charm")
@@ -254,6 +278,18 @@ func uploadCharms(config UploadBinariesConfig) error {
 		}
 		defer cleanup()
 
+		if importedHash, ok := config.Imported.Charms[charmURL]; ok {
+			hash, err := hashContent(content)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if hash == importedHash {
+				logger.Debugf("charm %s already uploaded to target", charmURL)
+				continue
+			}
+		}
+		logger.Debugf("sending charm %s to target", charmURL)
+
 		curl, err := charm.ParseURL(charmURL)
 		if err != nil {
 			return errors.Annotate(err, "bad charm URL")
@@ -290,6 +326,17 @@ func uploadTools(config UploadBinariesConfig) error {
 		}
 		defer cleanup()
 
+		if importedHash, ok := config.Imported.Tools[v]; ok {
+			hash, err := hashContent(content)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if hash == importedHash {
+				logger.Debugf("agent binaries %s already uploaded to target", v)
+				continue
+			}
+		}
+
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload agent binaries")
 		}
@@ -321,6 +368,10 @@ func uploadResources(config UploadBinariesConfig) error {
 }
 
 func uploadAppResource(config UploadBinariesConfig, rev resources.Resource) error {
+	if fingerprint, ok := config.Imported.Resources[rev.ApplicationID][rev.Name]; ok && fingerprint == rev.Fingerprint.Hex() {
+		logger.Debugf("application resource for %s: %s already uploaded to target", rev.ApplicationID, rev.Name)
+		return nil
+	}
 	logger.Debugf("opening application resource for %s: %s", rev.ApplicationID, rev.Name)
 	reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
 	if err != nil {
@@ -5,6 +5,8 @@ package migration_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/url"
@@ -109,6 +111,22 @@ func (s *ImportSuite) TestImportsLeadership(c *gc.C) {
 	claimer.stub.CheckCall(c, 0, "ClaimLeadership", "wordpress", "wordpress/1", time.Minute)
 }
 
+func (s *ImportSuite) TestValidateModel(c *gc.C) {
+	s.makeApplicationWithUnits(c, "wordpress", 2)
+	model, err := s.State.Export(map[string]string{})
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = migration.ValidateModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportSuite) TestValidateModelBadBytes(c *gc.C) {
+	err := migration.ValidateModel([]byte("not a model"))
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *ImportSuite) makeApplicationWithUnits(c *gc.C, applicationname string, count int) {
 	units := make([]*state.Unit, count)
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
@@ -227,6 +245,77 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
 }
 
+func (s *ImportSuite) TestBinariesMigrationSkipsImported(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+
+	v210 := version.MustParseBinary("2.1.0-ubuntu-amd64")
+	v200 := version.MustParseBinary("2.0.0-ubuntu-amd64")
+	toolsMap := map[version.Binary]string{
+		v210: "/tools/0",
+		v200: "/tools/1",
+	}
+
+	app0Res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	app1Res := resourcetesting.NewResource(c, nil, "blob1", "app1", "blob1").Resource
+	resources := []coremigration.SerializedModelResource{
+		{ApplicationRevision: app0Res},
+		{ApplicationRevision: app1Res},
+	}
+
+	tools0Hash := sha256.Sum256([]byte("/tools/0"))
+	postgresqlHash := sha256.Sum256([]byte("ch:trusty/postgresql-42 content"))
+	config := migration.UploadBinariesConfig{
+		Charms: []string{
+			"local:trusty/magic-2",
+			"ch:trusty/postgresql-42",
+		},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              toolsMap,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		Resources:          resources,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Imported: coremigration.ImportedBinaries{
+			Charms: map[string]string{
+				// The content differs, so this is uploaded again.
+				"local:trusty/magic-2": "deadbeef",
+				// The content matches, so this isn't uploaded again.
+				"ch:trusty/postgresql-42": hex.EncodeToString(postgresqlHash[:]),
+			},
+			Tools: map[version.Binary]string{
+				// The content matches, so these aren't uploaded again.
+				v210: hex.EncodeToString(tools0Hash[:]),
+				// The content differs, so these are uploaded again.
+				v200: "deadbeef",
+			},
+			Resources: map[string]map[string]string{
+				"app0": {"blob0": app0Res.Fingerprint.Hex()},
+				"app1": {"blob1": "deadbeef"},
+			},
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(downloader.curls, jc.DeepEquals, []string{"local:trusty/magic-2", "ch:trusty/postgresql-42"})
+	c.Assert(uploader.curls, jc.DeepEquals, []string{"local:trusty/magic-2"})
+
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		v200: "/tools/1",
+	})
+
+	c.Assert(downloader.resources, jc.DeepEquals, []string{"app1/blob1"})
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"app1/blob1": "blob1",
+	})
+}
+
 func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 	downloader := &fakeDownloader{}
 	uploader := &fakeUploader{
@@ -26,7 +26,32 @@ func SourcePrecheck(
 	modelPresence ModelPresence, controllerPresence ModelPresence,
 	environscloudspecGetter environsCloudSpecGetter,
 ) error {
-	ctx := newPrecheckSource(backend, modelPresence, environscloudspecGetter)
+	return errors.Trace(sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, nil))
+}
+
+// SourcePrecheckReport runs the same checks as SourcePrecheck, but
+// rather than failing on the first issue found, it returns every issue
+// found. An error is returned only if the checks could not be run.
+func SourcePrecheckReport(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+) (coremigration.PrecheckIssues, error) {
+	report := newPrecheckReport(coremigration.PrecheckSource)
+	err := sourcePrecheck(backend, modelPresence, controllerPresence, environscloudspecGetter, report)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return *report.issues, nil
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence, controllerPresence ModelPresence,
+	environscloudspecGetter environsCloudSpecGetter,
+	report *precheckReport,
+) error {
+	ctx := newPrecheckSource(backend, modelPresence, environscloudspecGetter, report)
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -47,7 +72,9 @@ func SourcePrecheck(
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.block(errors.New("cleanup needed")); err != nil {
+			return err
+		}
 	}
 
 	// Check the source controller.
@@ -55,7 +82,7 @@ func SourcePrecheck(
 	if err != nil {
 		return errors.Trace(err)
 	}
-	controllerCtx := newPrecheckTarget(controllerBackend, controllerPresence, environscloudspecGetter)
+	controllerCtx := newPrecheckTarget(controllerBackend, controllerPresence, environscloudspecGetter, report.withPrefix("controller"))
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
@@ -66,6 +93,27 @@ func SourcePrecheck(
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	return errors.Trace(targetPrecheck(backend, pool, modelInfo, presence, nil))
+}
+
+// TargetPrecheckReport runs the same checks as TargetPrecheck, but
+// rather than failing on the first issue found, it returns every issue
+// found. An error is returned only if the checks could not be run.
+func TargetPrecheckReport(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence,
+) (coremigration.PrecheckIssues, error) {
+	report := newPrecheckReport(coremigration.PrecheckTarget)
+	if err := targetPrecheck(backend, pool, modelInfo, presence, report); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return *report.issues, nil
+}
+
+func targetPrecheck(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence,
+	report *precheckReport,
+) error {
+	ctx := newPrecheckTarget(backend, presence, nil, report)
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -80,7 +128,9 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := ctx.block(errors.New("model is being migrated out of target controller")); err != nil {
+			return err
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
@@ -89,12 +139,19 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if err := ctx.block(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion)); err != nil {
+			return err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
+		if err := ctx.block(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion)); err != nil {
+			return err
+		}
+	} else if controllerVersion.Compare(modelInfo.ControllerAgentVersion) < 0 {
+		ctx.warn("source controller has higher patch version than target controller (%s > %s)",
 			modelInfo.ControllerAgentVersion, controllerVersion)
 	}
 
@@ -105,11 +162,12 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		return errors.Maskf(err, "unknown target controller version %v", controllerVersion)
 	}
 	if !allowed {
-		return errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)
+		if err := ctx.block(errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)); err != nil {
+			return err
+		}
 	}
 
-	controllerCtx := newPrecheckTarget(backend, presence, nil)
-	if err := controllerCtx.checkController(); err != nil {
+	if err := ctx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -129,10 +187,15 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if err := ctx.block(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)); err != nil {
+				return err
+			}
+			continue
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := ctx.block(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -143,12 +206,16 @@ type precheckTarget struct {
 	precheckContext
 }
 
-func newPrecheckTarget(backend PrecheckBackend, presence ModelPresence, environscloudspecGetter environsCloudSpecGetter) *precheckTarget {
+func newPrecheckTarget(
+	backend PrecheckBackend, presence ModelPresence, environscloudspecGetter environsCloudSpecGetter,
+	report *precheckReport,
+) *precheckTarget {
 	return &precheckTarget{
 		precheckContext: precheckContext{
 			backend:                 backend,
 			presence:                presence,
 			environscloudspecGetter: environscloudspecGetter,
+			report:                  report,
 		},
 	}
 }
@@ -157,6 +224,29 @@ type precheckContext struct {
 	backend                 PrecheckBackend
 	presence                ModelPresence
 	environscloudspecGetter environsCloudSpecGetter
+
+	// report collects the issues found by the checks, if they are
+	// being reported rather than failing on the first issue.
+	report *precheckReport
+}
+
+// block handles an issue which prevents a migration. If the issues
+// are being reported, the issue is recorded and nil is returned so
+// that the checks continue; otherwise the issue is returned.
+func (ctx *precheckContext) block(issue error) error {
+	if ctx.report == nil {
+		return issue
+	}
+	ctx.report.add(coremigration.PrecheckBlocker, issue.Error())
+	return nil
+}
+
+// warn records an issue which does not prevent a migration, if the
+// issues are being reported.
+func (ctx *precheckContext) warn(format string, args ...interface{}) {
+	if ctx.report != nil {
+		ctx.report.add(coremigration.PrecheckWarning, fmt.Sprintf(format, args...))
+	}
 }
 
 func (ctx *precheckContext) checkController() error {
@@ -165,13 +255,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.block(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.block(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -190,29 +284,38 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := ctx.block(errors.Errorf("machine %s is %s", machine.Id(), machine.Life())); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			if err := ctx.block(newStatusError("machine %s not running", machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			if err := ctx.block(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			if err := ctx.block(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)); err != nil {
+				return err
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -236,7 +339,10 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.block(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -253,17 +359,22 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.block(errors.Errorf("application %s is below its minimum units threshold", app.Name())); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 	if appCharmURL == nil {
-		return errors.Errorf("application charm url is nil")
+		return ctx.block(errors.Errorf("application charm url is nil"))
 	}
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := ctx.block(errors.Errorf("unit %s is %s", unit.Name(), unit.Life())); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
@@ -271,14 +382,16 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		}
 
 		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 				return errors.Trace(err)
 			}
 		}
 
 		unitCharmURL := unit.CharmURL()
 		if unitCharmURL == nil || *appCharmURL != *unitCharmURL {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := ctx.block(errors.Errorf("unit %s is upgrading", unit.Name())); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -295,7 +408,7 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.block(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
@@ -324,7 +437,7 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 				return errors.Trace(err)
 			}
 			if !inScope {
-				return errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel)
+				return ctx.block(errors.Errorf("unit %s hasn't joined relation %q yet", ru.UnitName(), rel))
 			}
 			return nil
 		}
@@ -362,12 +475,16 @@ type precheckSource struct {
 	precheckContext
 }
 
-func newPrecheckSource(backend PrecheckBackend, presence ModelPresence, environscloudspecGetter environsCloudSpecGetter) *precheckSource {
+func newPrecheckSource(
+	backend PrecheckBackend, presence ModelPresence, environscloudspecGetter environsCloudSpecGetter,
+	report *precheckReport,
+) *precheckSource {
 	return &precheckSource{
 		precheckContext: precheckContext{
 			backend:                 backend,
 			presence:                presence,
 			environscloudspecGetter: environscloudspecGetter,
+			report:                  report,
 		},
 	}
 }
@@ -378,10 +495,14 @@ func (ctx *precheckSource) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.block(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.block(errors.New("model is being imported as part of another migration")); err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -389,7 +510,9 @@ func (ctx *precheckSource) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			if err := ctx.block(errors.New("model has revoked credentials")); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -406,22 +529,61 @@ func (ctx *precheckSource) checkModel() error {
 	if blockers == nil {
 		return nil
 	}
-	return errors.NewNotSupported(nil, fmt.Sprintf("cannot migrate to controller due to issues:\n%s", blockers))
+	return ctx.block(errors.NewNotSupported(nil, fmt.Sprintf("cannot migrate to controller due to issues:\n%s", blockers)))
+}
+
+// precheckReport collects the issues found by the prechecks.
+type precheckReport struct {
+	controller string
+	prefix     string
+	issues     *coremigration.PrecheckIssues
+}
+
+func newPrecheckReport(controller string) *precheckReport {
+	return &precheckReport{
+		controller: controller,
+		issues:     &coremigration.PrecheckIssues{},
+	}
+}
+
+// withPrefix returns a report adding to the same issues, whose
+// messages are prefixed with the input string. It returns nil if the
+// report is nil.
+func (r *precheckReport) withPrefix(prefix string) *precheckReport {
+	if r == nil {
+		return nil
+	}
+	return &precheckReport{
+		controller: r.controller,
+		prefix:     prefix,
+		issues:     r.issues,
+	}
+}
+
+func (r *precheckReport) add(severity coremigration.PrecheckSeverity, message string) {
+	if r.prefix != "" {
+		message = r.prefix + ": " + message
+	}
+	*r.issues = append(*r.issues, coremigration.PrecheckIssue{
+		Severity:   severity,
+		Controller: r.controller,
+		Message:    message,
+	})
 }
 
 type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return ctx.block(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
 	return nil
 }
@@ -473,6 +473,68 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unit remote-mysql/0 hasn't joined relation "foo:db remote-mysql:db" yet`)
 }
 
+func (s *SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	issues, err := sourcePrecheckReport(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestReportAllIssues(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.model.life = state.Dying
+	backend.cleanupNeeded = true
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{name: "foo", life: state.Dying},
+		&fakeApp{
+			name:     "bar",
+			minunits: 2,
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "bar/0", life: state.Dead},
+			},
+		},
+	}
+	backend.controllerBackend = newBackendWithRebootingMachine()
+
+	issues, err := sourcePrecheckReport(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, coremigration.PrecheckIssues{
+		sourceBlocker("model is dying"),
+		sourceBlocker("machine 0 is dying"),
+		sourceBlocker("application foo is dying"),
+		sourceBlocker("application bar is below its minimum units threshold"),
+		sourceBlocker("unit bar/0 is dead"),
+		sourceBlocker("cleanup needed"),
+		sourceBlocker("controller: machine 0 is scheduled to reboot"),
+	})
+	c.Check(issues.Blocked(), jc.IsTrue)
+}
+
+func (s *SourcePrecheckSuite) TestReportError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := sourcePrecheckReport(backend)
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func sourcePrecheckReport(backend migration.PrecheckBackend) (coremigration.PrecheckIssues, error) {
+	return migration.SourcePrecheckReport(
+		backend, allAlivePresence(), allAlivePresence(),
+		func(names.ModelTag) (environscloudspec.CloudSpec, error) {
+			return environscloudspec.CloudSpec{Type: "dummy"}, nil
+		},
+	)
+}
+
+func sourceBlocker(message string) coremigration.PrecheckIssue {
+	return coremigration.PrecheckIssue{
+		Severity:   coremigration.PrecheckBlocker,
+		Controller: coremigration.PrecheckSource,
+		Message:    message,
+	}
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -722,6 +784,51 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestReportAllIssues(c *gc.C) {
+	backend := newBackendWithMismatchingTools()
+	backend.migrationActive = true
+	backend.isUpgrading = true
+	backend.models = []string{"uuid"}
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: "uuid", name: modelName, owner: modelOwner},
+		},
+	}
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+
+	issues, err := migration.TargetPrecheckReport(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	blocker := func(message string) coremigration.PrecheckIssue {
+		return coremigration.PrecheckIssue{
+			Severity:   coremigration.PrecheckBlocker,
+			Controller: coremigration.PrecheckTarget,
+			Message:    message,
+		}
+	}
+	c.Check(issues, jc.DeepEquals, coremigration.PrecheckIssues{
+		blocker("model is being migrated out of target controller"),
+		blocker("model has higher version than target controller (1.2.4 > 1.2.3)"),
+		blocker("upgrade in progress"),
+		blocker("machine 1 agent binaries don't match model (1.3.1 != 1.2.3)"),
+		blocker(`model named "model-name" already exists`),
+	})
+}
+
+func (s *TargetPrecheckSuite) TestReportPatchAheadWarning(c *gc.C) {
+	sourceVersion := backendVersion
+	sourceVersion.Patch++
+	s.modelInfo.ControllerAgentVersion = sourceVersion
+
+	issues, err := migration.TargetPrecheckReport(newFakeBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, coremigration.PrecheckIssues{{
+		Severity:   coremigration.PrecheckWarning,
+		Controller: coremigration.PrecheckTarget,
+		Message:    "source controller has higher patch version than target controller (1.2.4 > 1.2.3)",
+	}})
+	c.Check(issues.Blocked(), jc.IsFalse)
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -33,14 +33,20 @@ const (
 type DropCommand struct {
 	DestinationAddress string
 	Interface          string
+	Delete             bool
 }
 
 // Render renders the command to a string which can be executed via
-// bash in order to install the iptables rule.
+// bash in order to install the iptables rule, or remove it if Delete
+// is set. The command is idempotent.
 func (c DropCommand) Render() string {
+	return renderIdempotent(c.render, c.Delete)
+}
+
+func (c DropCommand) render(commandFlag string) string {
 	args := []string{
 		"sudo iptables",
-		"-I INPUT",
+		commandFlag, "INPUT",
 		"-m state --state NEW",
 		"-j DROP",
 		"-m comment --comment", fmt.Sprintf("'%s'", iptablesInternalComment),
@@ -65,14 +71,20 @@ type AcceptInternalCommand struct {
 	DestinationAddress string
 	DestinationPort    int
 	Protocol           string
+	Delete             bool
 }
 
 // Render renders the command to a string which can be executed via
-// bash in order to install the iptables rule.
+// bash in order to install the iptables rule, or remove it if Delete
+// is set. The command is idempotent.
 func (c AcceptInternalCommand) Render() string {
+	return renderIdempotent(c.render, c.Delete)
+}
+
+func (c AcceptInternalCommand) render(commandFlag string) string {
 	args := []string{
 		"sudo iptables",
-		"-I INPUT",
+		commandFlag, "INPUT",
 		"-j ACCEPT",
 		"-m comment --comment", fmt.Sprintf("'%s'", iptablesInternalComment),
 	}
@@ -105,12 +117,19 @@ func (c IngressRuleCommand) Render() string {
 	// existing rules first, and only insert or remove as
 	// needed. Fixing the firewaller is much more difficult,
 	// and it really needs an overhaul.
-	checkCommand := c.render("-C")
-	if c.Delete {
-		deleteCommand := c.render("-D")
+	return renderIdempotent(c.render, c.Delete)
+}
+
+// renderIdempotent renders a command that checks for the rule before
+// inserting it, or before deleting it if remove is true, so that it
+// can safely be run whether or not the rule is already in place.
+func renderIdempotent(render func(commandFlag string) string, remove bool) string {
+	checkCommand := render("-C")
+	if remove {
+		deleteCommand := render("-D")
 		return fmt.Sprintf("(%s) && (%s)", checkCommand, deleteCommand)
 	}
-	insertCommand := c.render("-I")
+	insertCommand := render("-I")
 	return fmt.Sprintf("(%s) || (%s)", checkCommand, insertCommand)
 }
 
@@ -24,30 +24,50 @@ var _ = gc.Suite(&IptablesSuite{})
 func (*IptablesSuite) TestDropCommand(c *gc.C) {
 	assertRender(c,
 		iptables.DropCommand{},
-		"sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal'",
+		"(sudo iptables -C INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal') || "+
+			"(sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal')",
 	)
 	assertRender(c,
 		iptables.DropCommand{DestinationAddress: "1.2.3.4"},
-		"sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4",
+		"(sudo iptables -C INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4) || "+
+			"(sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4)",
 	)
 	assertRender(c,
 		iptables.DropCommand{Interface: "eth0"},
-		"sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -i eth0",
+		"(sudo iptables -C INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -i eth0) || "+
+			"(sudo iptables -I INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -i eth0)",
+	)
+	assertRender(c,
+		iptables.DropCommand{DestinationAddress: "1.2.3.4", Delete: true},
+		"(sudo iptables -C INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4) && "+
+			"(sudo iptables -D INPUT -m state --state NEW -j DROP -m comment --comment 'juju internal' -d 1.2.3.4)",
 	)
 }
 
 func (*IptablesSuite) TestAcceptInternalPortCommand(c *gc.C) {
 	assertRender(c,
 		iptables.AcceptInternalCommand{},
-		"sudo iptables -I INPUT -j ACCEPT -m comment --comment 'juju internal'",
+		"(sudo iptables -C INPUT -j ACCEPT -m comment --comment 'juju internal') || "+
+			"(sudo iptables -I INPUT -j ACCEPT -m comment --comment 'juju internal')",
+	)
+	assertRender(c,
+		iptables.AcceptInternalCommand{
+			DestinationAddress: "1.2.3.4",
+			DestinationPort:    17070,
+			Protocol:           "tcp",
+		},
+		"(sudo iptables -C INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 17070) || "+
+			"(sudo iptables -I INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 17070)",
 	)
 	assertRender(c,
 		iptables.AcceptInternalCommand{
 			DestinationAddress: "1.2.3.4",
 			DestinationPort:    17070,
 			Protocol:           "tcp",
+			Delete:             true,
 		},
-		"sudo iptables -I INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 17070",
+		"(sudo iptables -C INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 17070) && "+
+			"(sudo iptables -D INPUT -j ACCEPT -m comment --comment 'juju internal' -p tcp -d 1.2.3.4 --dport 17070)",
 	)
 }
 
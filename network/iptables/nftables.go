@@ -0,0 +1,340 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+)
+
+const (
+	// nftSetupCommand creates the table and chain holding Juju's rules,
+	// if they do not already exist. Rules in other tables are left
+	// alone.
+	nftSetupCommand = "sudo nft add table inet juju && " +
+		"sudo nft add chain inet juju input '{ type filter hook input priority 0; policy accept; }'"
+
+	// nftListCommand lists Juju's rules, along with their handles.
+	nftListCommand = "sudo nft -a list chain inet juju input 2>/dev/null"
+)
+
+// nftablesRules renders rules for the nftables backend. Each rule
+// carries a comment identifying it by a hash of its expression, which
+// is used to check for the rule before inserting it, and to find its
+// handle when deleting it.
+type nftablesRules struct{}
+
+// Backend is part of the Rules interface.
+func (nftablesRules) Backend() Backend {
+	return BackendNftables
+}
+
+// DropCommand is part of the Rules interface.
+func (nftablesRules) DropCommand(cmd DropCommand) string {
+	var exprs []string
+	if cmd.Interface != "" {
+		exprs = append(exprs, fmt.Sprintf("iifname %q", cmd.Interface))
+	}
+	if cmd.DestinationAddress != "" {
+		exprs = append(exprs, nftFamily(cmd.DestinationAddress)+" daddr "+cmd.DestinationAddress)
+	}
+	exprs = append(exprs, "ct state new", "drop")
+	return renderNftRule(iptablesInternalComment, exprs, cmd.Delete)
+}
+
+// AcceptInternalCommand is part of the Rules interface.
+func (nftablesRules) AcceptInternalCommand(cmd AcceptInternalCommand) string {
+	var exprs []string
+	if cmd.DestinationAddress != "" {
+		exprs = append(exprs, nftFamily(cmd.DestinationAddress)+" daddr "+cmd.DestinationAddress)
+	}
+	if cmd.Protocol != "" {
+		if cmd.DestinationPort > 0 {
+			exprs = append(exprs, fmt.Sprintf("%s dport %d", cmd.Protocol, cmd.DestinationPort))
+		} else {
+			exprs = append(exprs, "meta l4proto "+cmd.Protocol)
+		}
+	}
+	exprs = append(exprs, "accept")
+	return renderNftRule(iptablesInternalComment, exprs, cmd.Delete)
+}
+
+// IngressRuleCommand is part of the Rules interface.
+//
+// Each nftables rule matches a single address family, so a rule is
+// rendered for each family of the source CIDRs. If there is a
+// destination address, only sources of its family are matched, and
+// nothing is rendered if there are none. A family whose sources include
+// all networks is matched without restricting the source address.
+func (nftablesRules) IngressRuleCommand(cmd IngressRuleCommand) string {
+	var (
+		families      []string
+		familySources = make(map[string][]string)
+		allNetworks   = make(map[string]bool)
+		destFamily    string
+	)
+	if cmd.DestinationAddress != "" {
+		destFamily = nftFamily(cmd.DestinationAddress)
+	}
+	for _, cidr := range cmd.Rule.SourceCIDRs.SortedValues() {
+		family := nftFamily(cidr)
+		if destFamily != "" && family != destFamily {
+			continue
+		}
+		if _, ok := familySources[family]; !ok {
+			families = append(families, family)
+		}
+		if cidr == firewall.AllNetworksIPV4CIDR || cidr == firewall.AllNetworksIPV6CIDR {
+			allNetworks[family] = true
+		}
+		familySources[family] = append(familySources[family], cidr)
+	}
+	if len(cmd.Rule.SourceCIDRs) == 0 {
+		// A rule without sources is open to all networks.
+		family := destFamily
+		if family == "" {
+			family = "ip"
+		}
+		families = []string{family}
+		allNetworks[family] = true
+	}
+
+	var cmds []string
+	for _, family := range families {
+		var exprs []string
+		switch {
+		case cmd.DestinationAddress != "":
+			exprs = append(exprs, family+" daddr "+cmd.DestinationAddress)
+		case allNetworks[family]:
+			exprs = append(exprs, "meta nfproto "+nftProto(family))
+		}
+		if !allNetworks[family] {
+			exprs = append(exprs, fmt.Sprintf("%s saddr { %s }", family, strings.Join(familySources[family], ", ")))
+		}
+		exprs = append(exprs, nftPortExpr(family, cmd.Rule.PortRange), "accept")
+		cmds = append(cmds, renderNftRule(iptablesIngressComment, exprs, cmd.Delete))
+	}
+	return strings.Join(cmds, " && ")
+}
+
+// nftPortExpr returns the expression matching the input port range for
+// the input address family.
+func nftPortExpr(family string, portRange network.PortRange) string {
+	switch {
+	case portRange.Protocol == "icmp" && family == "ip6":
+		return "icmpv6 type echo-request"
+	case portRange.Protocol == "icmp":
+		return "icmp type echo-request"
+	case portRange.ToPort-portRange.FromPort > 0:
+		return fmt.Sprintf("%s dport %d-%d", portRange.Protocol, portRange.FromPort, portRange.ToPort)
+	default:
+		return fmt.Sprintf("%s dport %d", portRange.Protocol, portRange.FromPort)
+	}
+}
+
+// ListIngressRulesCommand is part of the Rules interface.
+func (nftablesRules) ListIngressRulesCommand() string {
+	return nftListCommand + " || true"
+}
+
+// renderNftRule renders a command to insert the rule made up of the
+// input expressions, unless it is already present, or to delete every
+// copy of it if remove is true.
+func renderNftRule(comment string, exprs []string, remove bool) string {
+	rule := strings.Join(exprs, " ")
+	hash := sha256.Sum256([]byte(rule))
+	match := fmt.Sprintf(`comment "%s %x"`, comment, hash[:4])
+	if remove {
+		return fmt.Sprintf(
+			`for handle in $(%s | grep -F '%s' | sed -n 's/.* # handle \([0-9]*\)$/\1/p'); `+
+				`do sudo nft delete rule inet juju input handle $handle; done`,
+			nftListCommand, match,
+		)
+	}
+	checkCommand := fmt.Sprintf("%s | grep -qF '%s'", nftListCommand, match)
+	insertCommand := fmt.Sprintf("sudo nft insert rule inet juju input '%s %s'", rule, match)
+	return fmt.Sprintf("(%s) && ((%s) || (%s))", nftSetupCommand, checkCommand, insertCommand)
+}
+
+// nftFamily returns the nftables address family of the input address
+// or CIDR.
+func nftFamily(addr string) string {
+	if ip, _, err := net.ParseCIDR(addr); err == nil {
+		addr = ip.String()
+	}
+	if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+		return "ip6"
+	}
+	return "ip"
+}
+
+// nftProto returns the nfproto value for the input address family.
+func nftProto(family string) string {
+	if family == "ip6" {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// ParseIngressRules is part of the Rules interface.
+//
+// The rules we care about have the following format, and we will skip
+// all other rules:
+//
+//	table inet juju {
+//		chain input {
+//			type filter hook input priority filter; policy accept;
+//			ip daddr 192.168.0.1 tcp dport 3456-3458 accept comment "juju ingress 1a2b3c4d" # handle 4
+//			ip saddr { 1.2.3.0/24, 5.6.7.8 } udp dport 53 accept comment "juju ingress 5e6f7a8b" # handle 5
+//			meta nfproto ipv6 icmpv6 type echo-request accept comment "juju ingress 9c0d1e2f" # handle 6
+//		}
+//	}
+func (nftablesRules) ParseIngressRules(r io.Reader) (firewall.IngressRules, error) {
+	var rules firewall.IngressRules
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		rule, ok, err := parseNftIngressRule(strings.TrimSpace(line))
+		if err != nil {
+			logger.Warningf("failed to parse nftables line %q: %v", line, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotate(err, "reading nftables output")
+	}
+	return rules, nil
+}
+
+// parseNftIngressRule parses a single nftables output line, extracting
+// an ingress rule if the line represents one, or returning false
+// otherwise.
+func parseNftIngressRule(line string) (firewall.IngressRule, bool, error) {
+	fail := func(err error) (firewall.IngressRule, bool, error) {
+		return firewall.IngressRule{}, false, err
+	}
+	commentStart := strings.Index(line, `comment "`+iptablesIngressComment+" ")
+	if commentStart == -1 {
+		return firewall.IngressRule{}, false, nil
+	}
+	fields := strings.Fields(strings.ReplaceAll(line[:commentStart], ",", " "))
+
+	var (
+		family           = "ip"
+		sources          []string
+		protocol         string
+		fromPort, toPort int
+	)
+	for len(fields) > 0 {
+		field := fields[0]
+		fields = fields[1:]
+		switch field {
+		case "ip", "ip6":
+			if len(fields) < 2 {
+				return fail(errors.Errorf("could not extract %s match", field))
+			}
+			family = field
+			direction := fields[0]
+			var addrs []string
+			addrs, fields = popNftValues(fields[1:])
+			if direction != "saddr" {
+				continue
+			}
+			for _, addr := range addrs {
+				if !strings.Contains(addr, "/") {
+					addr += map[string]string{"ip": "/32", "ip6": "/128"}[field]
+				}
+				sources = append(sources, addr)
+			}
+		case "meta":
+			if len(fields) < 2 || fields[0] != "nfproto" {
+				return fail(errors.New("could not extract address family"))
+			}
+			family = map[string]string{"ipv4": "ip", "ipv6": "ip6"}[fields[1]]
+			if family == "" {
+				return fail(errors.Errorf("unexpected address family %q", fields[1]))
+			}
+			fields = fields[2:]
+		case "icmp", "icmpv6":
+			if field == "icmpv6" {
+				family = "ip6"
+			}
+			protocol, fromPort, toPort = "icmp", -1, -1
+			if len(fields) >= 2 && fields[0] == "type" {
+				fields = fields[2:]
+			}
+		case "tcp", "udp":
+			if len(fields) < 2 || fields[0] != "dport" {
+				return fail(errors.New("could not extract destination port"))
+			}
+			protocol = field
+			ports := fields[1]
+			fields = fields[2:]
+			var err error
+			if strings.Contains(ports, "-") {
+				fromPort, toPort, err = parsePortRange(strings.Replace(ports, "-", ":", 1))
+			} else {
+				fromPort, err = parsePort(ports)
+				toPort = fromPort
+			}
+			if err != nil {
+				return fail(errors.Trace(err))
+			}
+		case "accept":
+			// The verdict is always accept for ingress rules.
+		default:
+			return fail(errors.Errorf("unexpected expression %q", field))
+		}
+	}
+	if protocol == "" {
+		return fail(errors.New("could not extract protocol"))
+	}
+	if len(sources) == 0 {
+		sources = []string{firewall.AllNetworksIPV4CIDR}
+		if family == "ip6" {
+			sources = []string{firewall.AllNetworksIPV6CIDR}
+		}
+	}
+
+	rule := firewall.NewIngressRule(network.PortRange{
+		FromPort: fromPort,
+		ToPort:   toPort,
+		Protocol: protocol,
+	}, sources...)
+	if err := rule.Validate(); err != nil {
+		return fail(errors.Trace(err))
+	}
+	return rule, true, nil
+}
+
+// popNftValues pops a single value, or a set of values enclosed in
+// braces, off the front of the given fields.
+func popNftValues(fields []string) (values, remainder []string) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	if fields[0] != "{" {
+		return fields[:1], fields[1:]
+	}
+	for i, field := range fields[1:] {
+		if field == "}" {
+			return values, fields[i+2:]
+		}
+		values = append(values, field)
+	}
+	return values, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables_test
+
+import (
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/network/iptables"
+)
+
+const nftSetup = "(sudo nft add table inet juju && " +
+	"sudo nft add chain inet juju input '{ type filter hook input priority 0; policy accept; }') && "
+
+type NftablesSuite struct {
+	testing.IsolationSuite
+
+	rules iptables.Rules
+}
+
+var _ = gc.Suite(&NftablesSuite{})
+
+func (s *NftablesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	var err error
+	s.rules, err = iptables.NewRules(iptables.BackendNftables)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NftablesSuite) TestDropCommand(c *gc.C) {
+	c.Check(s.rules.DropCommand(iptables.DropCommand{
+		DestinationAddress: "1.2.3.4",
+		Interface:          "eth0",
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju internal 70f5b0ee"') || `+
+		`(sudo nft insert rule inet juju input 'iifname "eth0" ip daddr 1.2.3.4 ct state new drop comment "juju internal 70f5b0ee"'))`,
+	)
+
+	// Deleting removes every rule with the same comment,
+	// and does nothing if there are none.
+	c.Check(s.rules.DropCommand(iptables.DropCommand{
+		DestinationAddress: "1.2.3.4",
+		Interface:          "eth0",
+		Delete:             true,
+	}), gc.Equals,
+		`for handle in $(sudo nft -a list chain inet juju input 2>/dev/null | grep -F 'comment "juju internal 70f5b0ee"' | `+
+			`sed -n 's/.* # handle \([0-9]*\)$/\1/p'); do sudo nft delete rule inet juju input handle $handle; done`,
+	)
+}
+
+func (s *NftablesSuite) TestAcceptInternalCommand(c *gc.C) {
+	c.Check(s.rules.AcceptInternalCommand(iptables.AcceptInternalCommand{
+		DestinationAddress: "1.2.3.4",
+		DestinationPort:    17070,
+		Protocol:           "tcp",
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju internal 82100016"') || `+
+		`(sudo nft insert rule inet juju input 'ip daddr 1.2.3.4 tcp dport 17070 accept comment "juju internal 82100016"'))`,
+	)
+}
+
+func (s *NftablesSuite) TestIngressRuleCommand(c *gc.C) {
+	// Source CIDRs of the other address family are not matched.
+	c.Check(s.rules.IngressRuleCommand(iptables.IngressRuleCommand{
+		Rule: firewall.NewIngressRule(network.MustParsePortRange("6001-6007/tcp"),
+			"1.2.3.0/24", "5.6.7.8/32", "2001:db8::/64"),
+		DestinationAddress: "10.0.0.1",
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress 84c848ef"') || `+
+		`(sudo nft insert rule inet juju input 'ip daddr 10.0.0.1 ip saddr { 1.2.3.0/24, 5.6.7.8/32 } tcp dport 6001-6007 accept comment "juju ingress 84c848ef"'))`,
+	)
+
+	// Sources including all networks only match the address family.
+	c.Check(s.rules.IngressRuleCommand(iptables.IngressRuleCommand{
+		Rule: firewall.NewIngressRule(network.MustParsePortRange("icmp"),
+			firewall.AllNetworksIPV4CIDR, firewall.AllNetworksIPV6CIDR),
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress 06d775c2"') || `+
+		`(sudo nft insert rule inet juju input 'meta nfproto ipv4 icmp type echo-request accept comment "juju ingress 06d775c2"')) && `+
+		nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress 0e621151"') || `+
+		`(sudo nft insert rule inet juju input 'meta nfproto ipv6 icmpv6 type echo-request accept comment "juju ingress 0e621151"'))`,
+	)
+
+	// Without a destination address, a rule is rendered for each family.
+	c.Check(s.rules.IngressRuleCommand(iptables.IngressRuleCommand{
+		Rule: firewall.NewIngressRule(network.MustParsePortRange("53/udp"), "1.2.3.0/24", "2001:db8::/64"),
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress 77b75666"') || `+
+		`(sudo nft insert rule inet juju input 'ip saddr { 1.2.3.0/24 } udp dport 53 accept comment "juju ingress 77b75666"')) && `+
+		nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress deee0817"') || `+
+		`(sudo nft insert rule inet juju input 'ip6 saddr { 2001:db8::/64 } udp dport 53 accept comment "juju ingress deee0817"'))`,
+	)
+
+	c.Check(s.rules.IngressRuleCommand(iptables.IngressRuleCommand{
+		Rule:               firewall.NewIngressRule(network.MustParsePortRange("53/udp"), "2001:db8::/64"),
+		DestinationAddress: "2001:db8::1",
+	}), gc.Equals, nftSetup+
+		`((sudo nft -a list chain inet juju input 2>/dev/null | grep -qF 'comment "juju ingress 5857f2dc"') || `+
+		`(sudo nft insert rule inet juju input 'ip6 daddr 2001:db8::1 ip6 saddr { 2001:db8::/64 } udp dport 53 accept comment "juju ingress 5857f2dc"'))`,
+	)
+}
+
+func (s *NftablesSuite) TestIngressRuleCommandNoSourcesOfFamily(c *gc.C) {
+	// The rule must not be opened to every source when none of the
+	// sources can reach the destination address.
+	cmd := iptables.IngressRuleCommand{
+		Rule:               firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "2001:db8::/64"),
+		DestinationAddress: "10.0.0.1",
+	}
+	c.Check(s.rules.IngressRuleCommand(cmd), gc.Equals, "")
+	cmd.Delete = true
+	c.Check(s.rules.IngressRuleCommand(cmd), gc.Equals, "")
+}
+
+func (s *NftablesSuite) TestIngressRuleCommandIsStable(c *gc.C) {
+	// The same rule must always render the same comment,
+	// so that it can be found again to check for or delete it.
+	cmd := iptables.IngressRuleCommand{
+		Rule:               firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "1.2.3.0/24", "5.6.7.0/24"),
+		DestinationAddress: "10.0.0.1",
+	}
+	insert := s.rules.IngressRuleCommand(cmd)
+	cmd.Rule = firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "5.6.7.0/24", "1.2.3.0/24")
+	c.Check(s.rules.IngressRuleCommand(cmd), gc.Equals, insert)
+
+	cmd.Delete = true
+	c.Check(s.rules.IngressRuleCommand(cmd), jc.Contains, `grep -F 'comment "juju ingress 68d51a50"'`)
+	c.Check(insert, jc.Contains, `comment "juju ingress 68d51a50"`)
+}
+
+func (s *NftablesSuite) TestListIngressRulesCommand(c *gc.C) {
+	c.Check(s.rules.ListIngressRulesCommand(), gc.Equals, "sudo nft -a list chain inet juju input 2>/dev/null || true")
+}
+
+func (s *NftablesSuite) TestParseIngressRulesEmpty(c *gc.C) {
+	rules, err := s.rules.ParseIngressRules(strings.NewReader(""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, gc.HasLen, 0)
+}
+
+func (s *NftablesSuite) TestParseIngressRules(c *gc.C) {
+	rules, err := s.rules.ParseIngressRules(strings.NewReader(`
+table inet juju {
+	chain input { # handle 1
+		type filter hook input priority filter; policy accept;
+		ip daddr 192.168.0.1 tcp dport 3456-3458 accept comment "juju ingress 1a2b3c4d" # handle 4
+		ip saddr { 1.2.3.0/24, 5.6.7.8 } udp dport 53 accept comment "juju ingress 5e6f7a8b" # handle 5
+		ip6 daddr 2001:db8::1 ip6 saddr 2001:db8::/64 tcp dport 80 accept comment "juju ingress 0a0b0c0d" # handle 6
+		icmp type echo-request accept comment "juju ingress 9c0d1e2f" # handle 7
+		meta nfproto ipv6 tcp dport 443 accept comment "juju ingress 3e4f5a6b" # handle 11
+		meta nfproto ipv6 icmpv6 type echo-request accept comment "juju ingress 7c8d9e0f" # handle 12
+		ip daddr 192.168.0.1 tcp dport 17070 accept comment "juju internal 82100016" # handle 8
+		tcp dport 22 accept comment "managed by someone else" # handle 9
+		ct state new drop comment "juju internal 70f5b0ee" # handle 10
+	}
+}
+`[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("3456-3458/tcp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("53/udp"), "1.2.3.0/24", "5.6.7.8/32"),
+		firewall.NewIngressRule(network.MustParsePortRange("80/tcp"), "2001:db8::/64"),
+		firewall.NewIngressRule(network.MustParsePortRange("icmp"), firewall.AllNetworksIPV4CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("443/tcp"), firewall.AllNetworksIPV6CIDR),
+		firewall.NewIngressRule(network.MustParsePortRange("icmp"), firewall.AllNetworksIPV6CIDR),
+	})
+}
+
+func (s *NftablesSuite) TestParseIngressRulesSkipsGarbage(c *gc.C) {
+	rules, err := s.rules.ParseIngressRules(strings.NewReader(`
+sctp dport 9 accept comment "juju ingress 1a2b3c4d"
+tcp dport banana accept comment "juju ingress 1a2b3c4d"
+udp dport 53 accept comment "juju ingress 5e6f7a8b"
+`[1:]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("53/udp"), firewall.AllNetworksIPV4CIDR),
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network/firewall"
+)
+
+// Backend identifies the firewall implementation used to manage a
+// host's rules.
+type Backend string
+
+const (
+	// BackendIptables manages rules with the iptables command.
+	BackendIptables Backend = "iptables"
+
+	// BackendNftables manages rules with the nft command.
+	BackendNftables Backend = "nftables"
+)
+
+// DetectBackendCommand is a bash command that prints the backend to use
+// for managing the host's rules, to be parsed by ParseBackend.
+//
+// Hosts with the nft command use nftables, unless they also have the
+// legacy variant of the iptables command, whose rules are not visible
+// to nft. Hosts using the nf_tables variant of iptables, or with only
+// the nft command, use nftables, unless Juju has already added rules
+// with iptables. Those rules would not be found in Juju's nftables
+// chain, and rules accepted there could not override the drop rule
+// added with iptables, so the host keeps using iptables.
+const DetectBackendCommand = `if command -v nft >/dev/null 2>&1 && ` +
+	`{ ! command -v iptables >/dev/null 2>&1 || ` +
+	`{ iptables --version 2>/dev/null | grep -qF nf_tables && ` +
+	`! sudo iptables -S INPUT 2>/dev/null | grep -qF -e '"` + iptablesIngressComment + `"' -e '"` + iptablesInternalComment + `"'; }; }; ` +
+	`then echo nftables; else echo iptables; fi`
+
+// ParseBackend parses the output of DetectBackendCommand.
+func ParseBackend(output string) (Backend, error) {
+	switch backend := Backend(strings.TrimSpace(output)); backend {
+	case BackendIptables, BackendNftables:
+		return backend, nil
+	}
+	return "", errors.NotValidf("firewall backend %q", strings.TrimSpace(output))
+}
+
+// Rules renders the commands used to manage Juju's rules on a host, and
+// parses the ingress rules in place. All rendered commands are
+// idempotent, so they can be run repeatedly to reconcile the host's
+// rules, and rules can be removed whether or not they are present.
+type Rules interface {
+	// Backend returns the backend the rules are rendered for.
+	Backend() Backend
+
+	// DropCommand renders a command to drop new connections.
+	DropCommand(cmd DropCommand) string
+
+	// AcceptInternalCommand renders a command to accept traffic for
+	// Juju's internal use, such as the API or SSH.
+	AcceptInternalCommand(cmd AcceptInternalCommand) string
+
+	// IngressRuleCommand renders a command to accept traffic for an
+	// ingress rule. The command is empty if the rule cannot be rendered
+	// for the destination address.
+	IngressRuleCommand(cmd IngressRuleCommand) string
+
+	// ListIngressRulesCommand renders a command whose output can be
+	// passed to ParseIngressRules.
+	ListIngressRulesCommand() string
+
+	// ParseIngressRules parses the output of ListIngressRulesCommand,
+	// extracting the ingress rules added by IngressRuleCommand.
+	ParseIngressRules(r io.Reader) (firewall.IngressRules, error)
+}
+
+// NewRules returns the Rules for the input backend.
+func NewRules(backend Backend) (Rules, error) {
+	switch backend {
+	case BackendIptables:
+		return iptablesRules{}, nil
+	case BackendNftables:
+		return nftablesRules{}, nil
+	}
+	return nil, errors.NotValidf("firewall backend %q", backend)
+}
+
+// iptablesRules renders rules for the iptables backend.
+type iptablesRules struct{}
+
+// Backend is part of the Rules interface.
+func (iptablesRules) Backend() Backend {
+	return BackendIptables
+}
+
+// DropCommand is part of the Rules interface.
+func (iptablesRules) DropCommand(cmd DropCommand) string {
+	return cmd.Render()
+}
+
+// AcceptInternalCommand is part of the Rules interface.
+func (iptablesRules) AcceptInternalCommand(cmd AcceptInternalCommand) string {
+	return cmd.Render()
+}
+
+// IngressRuleCommand is part of the Rules interface.
+func (iptablesRules) IngressRuleCommand(cmd IngressRuleCommand) string {
+	return cmd.Render()
+}
+
+// ListIngressRulesCommand is part of the Rules interface.
+func (iptablesRules) ListIngressRulesCommand() string {
+	return "sudo iptables -L INPUT -n"
+}
+
+// ParseIngressRules is part of the Rules interface.
+func (iptablesRules) ParseIngressRules(r io.Reader) (firewall.IngressRules, error) {
+	return ParseIngressRules(r)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package iptables_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/network/firewall"
+	"github.com/juju/juju/network/iptables"
+)
+
+type RulesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RulesSuite{})
+
+func (*RulesSuite) TestParseBackend(c *gc.C) {
+	backend, err := iptables.ParseBackend("iptables\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(backend, gc.Equals, iptables.BackendIptables)
+
+	backend, err = iptables.ParseBackend("nftables\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(backend, gc.Equals, iptables.BackendNftables)
+
+	_, err = iptables.ParseBackend("bash: command not found\n")
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (*RulesSuite) TestDetectBackendCommand(c *gc.C) {
+	// The isolation suite clears PATH, so grep is found directly.
+	var grep string
+	for _, path := range []string{"/usr/bin/grep", "/bin/grep"} {
+		if _, err := os.Stat(path); err == nil {
+			grep = path
+			break
+		}
+	}
+	if grep == "" {
+		c.Skip("grep not found")
+	}
+	tests := []struct {
+		about    string
+		commands map[string]string
+		rules    string
+		expected string
+	}{{
+		about:    "no firewall commands",
+		expected: "iptables",
+	}, {
+		about:    "legacy iptables only",
+		commands: map[string]string{"iptables": "iptables v1.8.4 (legacy)"},
+		expected: "iptables",
+	}, {
+		about: "legacy iptables and nft",
+		commands: map[string]string{
+			"iptables": "iptables v1.8.7 (legacy)",
+			"nft":      "nftables v1.0.2",
+		},
+		expected: "iptables",
+	}, {
+		about: "nf_tables iptables and nft",
+		commands: map[string]string{
+			"iptables": "iptables v1.8.7 (nf_tables)",
+			"nft":      "nftables v1.0.2",
+		},
+		expected: "nftables",
+	}, {
+		about: "nf_tables iptables with juju's iptables rules",
+		commands: map[string]string{
+			"iptables": "iptables v1.8.7 (nf_tables)",
+			"nft":      "nftables v1.0.2",
+		},
+		rules: `-P INPUT ACCEPT
+-A INPUT -d 10.0.0.1/32 -p tcp -m tcp --dport 17070 -m comment --comment "juju internal" -j ACCEPT
+-A INPUT -d 10.0.0.1/32 -m state --state NEW -m comment --comment "juju internal" -j DROP`,
+		expected: "iptables",
+	}, {
+		about: "nf_tables iptables with other iptables rules",
+		commands: map[string]string{
+			"iptables": "iptables v1.8.7 (nf_tables)",
+			"nft":      "nftables v1.0.2",
+		},
+		rules: `-P INPUT ACCEPT
+-A INPUT -p tcp -m tcp --dport 22 -m comment --comment "ssh" -j ACCEPT`,
+		expected: "nftables",
+	}, {
+		about:    "nft only",
+		commands: map[string]string{"nft": "nftables v1.0.2"},
+		expected: "nftables",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.about)
+		dir := c.MkDir()
+		err := os.Symlink(grep, filepath.Join(dir, "grep"))
+		c.Assert(err, jc.ErrorIsNil)
+		// Commands print their version, or for any other arguments,
+		// the host's rules.
+		for name, version := range test.commands {
+			script := fmt.Sprintf("#!/bin/sh\nif [ \"$1\" = --version ]; then echo '%s'; else echo '%s'; fi\n", version, test.rules)
+			err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+		err = os.WriteFile(filepath.Join(dir, "sudo"), []byte("#!/bin/sh\nexec \"$@\"\n"), 0755)
+		c.Assert(err, jc.ErrorIsNil)
+		cmd := exec.Command("/bin/bash", "-c", iptables.DetectBackendCommand)
+		cmd.Env = []string{"PATH=" + dir}
+		output, err := cmd.Output()
+		c.Assert(err, jc.ErrorIsNil)
+		backend, err := iptables.ParseBackend(string(output))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(backend, gc.Equals, iptables.Backend(test.expected))
+	}
+}
+
+func (*RulesSuite) TestNewRules(c *gc.C) {
+	for _, backend := range []iptables.Backend{iptables.BackendIptables, iptables.BackendNftables} {
+		rules, err := iptables.NewRules(backend)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(rules.Backend(), gc.Equals, backend)
+	}
+
+	_, err := iptables.NewRules("ipfw")
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (*RulesSuite) TestIptablesRules(c *gc.C) {
+	rules, err := iptables.NewRules(iptables.BackendIptables)
+	c.Assert(err, jc.ErrorIsNil)
+
+	drop := iptables.DropCommand{DestinationAddress: "1.2.3.4"}
+	c.Check(rules.DropCommand(drop), gc.Equals, drop.Render())
+	accept := iptables.AcceptInternalCommand{Protocol: "tcp", DestinationPort: 22}
+	c.Check(rules.AcceptInternalCommand(accept), gc.Equals, accept.Render())
+	ingress := iptables.IngressRuleCommand{Rule: firewall.NewIngressRule(network.MustParsePortRange("53/udp"))}
+	c.Check(rules.IngressRuleCommand(ingress), gc.Equals, ingress.Render())
+	c.Check(rules.ListIngressRulesCommand(), gc.Equals, "sudo iptables -L INPUT -n")
+
+	parsed, err := rules.ParseIngressRules(strings.NewReader(
+		"ACCEPT     udp  --  0.0.0.0/0            0.0.0.0/0            udp dpt:53 /* juju ingress */\n",
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed, jc.DeepEquals, firewall.IngressRules{
+		firewall.NewIngressRule(network.MustParsePortRange("53/udp"), firewall.AllNetworksIPV4CIDR),
+	})
+}
@@ -309,6 +309,12 @@ func Marshal(in *Netplan) (out []byte, err error) {
 	return goyaml.Marshal(in)
 }
 
+// Unmarshal YAML into a Netplan instance. It is the inverse of Marshal,
+// and rejects fields that the Netplan types do not represent.
+func Unmarshal(in []byte, out *Netplan) error {
+	return errors.Trace(goyaml.UnmarshalStrict(in, out))
+}
+
 type sortableDirEntries []os.DirEntry
 
 func (fil sortableDirEntries) Len() int {
@@ -18,7 +18,6 @@ import (
 	"github.com/kr/pretty"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/yaml.v2"
-	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/network/netplan"
 	coretesting "github.com/juju/juju/testing"
@@ -35,7 +34,7 @@ func MustNetplanFromYaml(c *gc.C, input string) *netplan.Netplan {
 	if strings.HasPrefix(input, "\n") {
 		input = input[1:]
 	}
-	err := goyaml.UnmarshalStrict([]byte(input), &np)
+	err := netplan.Unmarshal([]byte(input), &np)
 	c.Assert(err, jc.ErrorIsNil)
 	return &np
 }
@@ -50,6 +49,19 @@ func checkNetplanRoundTrips(c *gc.C, input string) {
 	c.Check(string(out), gc.Equals, input)
 }
 
+func (s *NetplanSuite) TestUnmarshalUnknownField(c *gc.C) {
+	var np netplan.Netplan
+	err := netplan.Unmarshal([]byte(`
+network:
+  version: 2
+  bonds:
+    bond0:
+      interfaces: [eth0]
+      bogus: true
+`[1:]), &np)
+	c.Assert(err, gc.ErrorMatches, `(?s).*field bogus not found.*`)
+}
+
 func (s *NetplanSuite) TestStructures(c *gc.C) {
 	checkNetplanRoundTrips(c, `
 network:
@@ -34,6 +34,10 @@ type sshInstanceConfigurator struct {
 	client  ssh.Client
 	host    string
 	options *ssh.Options
+
+	// rules renders firewall commands for the backend
+	// detected on the host. It is set on first use.
+	rules iptables.Rules
 }
 
 // NewSshInstanceConfigurator creates new sshInstanceConfigurator.
@@ -68,17 +72,42 @@ func (c *sshInstanceConfigurator) runCommand(cmd string) (string, error) {
 	return string(output), nil
 }
 
+// firewallRules returns the rules for the firewall backend used by the
+// host, detecting it if this is the first use.
+func (c *sshInstanceConfigurator) firewallRules() (iptables.Rules, error) {
+	if c.rules != nil {
+		return c.rules, nil
+	}
+	output, err := c.runCommand(iptables.DetectBackendCommand)
+	if err != nil {
+		return nil, errors.Annotatef(err, "detecting firewall backend: %s", output)
+	}
+	backend, err := iptables.ParseBackend(output)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("using %s firewall backend on %s", backend, c.host)
+	if c.rules, err = iptables.NewRules(backend); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.rules, nil
+}
+
 // DropAllPorts implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) error {
+	rules, err := c.firewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	cmds := []string{
-		iptables.DropCommand{DestinationAddress: addr}.Render(),
+		rules.DropCommand(iptables.DropCommand{DestinationAddress: addr}),
 	}
 	for _, port := range exceptPorts {
-		cmds = append(cmds, iptables.AcceptInternalCommand{
+		cmds = append(cmds, rules.AcceptInternalCommand(iptables.AcceptInternalCommand{
 			Protocol:           "tcp",
 			DestinationAddress: addr,
 			DestinationPort:    port,
-		}.Render())
+		}))
 	}
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
@@ -91,13 +120,23 @@ func (c *sshInstanceConfigurator) DropAllPorts(exceptPorts []int, addr string) e
 
 // ChangeIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bool, rules firewall.IngressRules) error {
+	firewallRules, err := c.firewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	var cmds []string
 	for _, rule := range rules {
-		cmds = append(cmds, iptables.IngressRuleCommand{
+		cmd := firewallRules.IngressRuleCommand(iptables.IngressRuleCommand{
 			Rule:               rule,
 			DestinationAddress: ipAddress,
 			Delete:             !insert,
-		}.Render())
+		})
+		if cmd != "" {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return nil
 	}
 
 	output, err := c.runCommand(strings.Join(cmds, "\n"))
@@ -110,10 +149,14 @@ func (c *sshInstanceConfigurator) ChangeIngressRules(ipAddress string, insert bo
 
 // FindIngressRules implements InstanceConfigurator interface.
 func (c *sshInstanceConfigurator) FindIngressRules() (firewall.IngressRules, error) {
-	output, err := c.runCommand("sudo iptables -L INPUT -n")
+	rules, err := c.firewallRules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	output, err := c.runCommand(rules.ListIngressRulesCommand())
 	if err != nil {
 		return nil, errors.Errorf("failed to list open ports: %s", output)
 	}
 	logger.Tracef("find open ports output: %s", output)
-	return iptables.ParseIngressRules(strings.NewReader(output))
+	return rules.ParseIngressRules(strings.NewReader(output))
 }
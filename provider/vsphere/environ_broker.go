@@ -235,10 +235,7 @@ func (env *sessionEnviron) newRawInstance(
 	}
 	// TODO(wpk) There's no (known) way to tell cloud-init to disable network (using cloudinit.CloudInitNetworkConfigDisabled)
 	// so the network might be double-configured. That should be ok as long as we're using DHCP.
-	err = cloudcfg.AddNetworkConfig(interfaces)
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
+	args.InstanceConfig.NetworkConfig = interfaces
 	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, VsphereRenderer{})
 	if err != nil {
 		return nil, nil, environs.ZoneIndependentError(
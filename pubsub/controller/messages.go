@@ -22,3 +22,15 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// Restored messages are published by the apiserver client backups facade
+// when the controller databases have been restored from a backup. The
+// controller agent restarts, so that no worker carries on with state read
+// from the databases before they were replaced.
+// data: `RestoredMessage`
+const Restored = "controller.restored"
+
+// RestoredMessage identifies the backup which was restored.
+type RestoredMessage struct {
+	BackupID string
+}
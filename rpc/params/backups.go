@@ -58,3 +58,33 @@ type BackupsMetadataResult struct {
 	// HANodes reflects HA configuration: number of controller nodes in HA.
 	HANodes int64 `json:"ha-nodes"`
 }
+
+// BackupsUploadResult holds the result of uploading a backup archive.
+type BackupsUploadResult struct {
+	ID string `json:"id"`
+}
+
+// BackupsListResult holds the list of stored backups returned by the
+// API List method.
+type BackupsListResult struct {
+	List []BackupsMetadataResult `json:"list"`
+}
+
+// BackupsRemoveArgs holds the args for the API Remove method.
+type BackupsRemoveArgs struct {
+	IDs []string `json:"ids"`
+}
+
+// BackupsRestoreArgs holds the args for the API Restore method.
+type BackupsRestoreArgs struct {
+	ID string `json:"id"`
+}
+
+// BackupStatusResult holds the status of the controller's scheduled
+// backups.
+type BackupStatusResult struct {
+	Status string                 `json:"status"`
+	Info   string                 `json:"info"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since,omitempty"`
+}
@@ -148,3 +148,28 @@ type DashboardConnectionInfo struct {
 	SSHConnection   *DashboardConnectionSSHTunnel `json:"ssh-connection"`
 	Error           *Error                        `json:"error,omitempty"`
 }
+
+// DBQueryArgs holds the arguments for running a read-only query
+// against the controller database.
+type DBQueryArgs struct {
+	// Query is the SQL statement to run. Only a single read-only
+	// statement is accepted.
+	Query string `json:"query"`
+
+	// MaxRows limits the number of rows returned. If zero, the
+	// server default is used.
+	MaxRows int `json:"max-rows,omitempty"`
+
+	// Timeout limits how long the query may run for. If zero, the
+	// server default is used.
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// DBQueryResult holds the result of a controller database query.
+type DBQueryResult struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+
+	// Truncated is true if there were more rows than the row limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
@@ -738,6 +738,19 @@ type CharmsResponse struct {
 	Files    []string `json:"files,omitempty"`
 }
 
+// CharmhubMirrorImportResult is the server response to uploading an offline
+// charmhub export to the controller's charmhub mirror.
+type CharmhubMirrorImportResult struct {
+	// Entities is the number of charms and bundles in the export.
+	Entities int `json:"entities"`
+
+	// BlobsAdded is the number of archives and resources that the
+	// mirror did not already hold.
+	BlobsAdded int `json:"blobs-added"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 // RunParams is used to provide the parameters to the Run method.
 // Commands and Timeout are expected to have values, and one or more
 // values should be in the Machines, Applications, or Units slices.
@@ -906,6 +919,17 @@ type LogMessage struct {
 	Labels    []string  `json:"lab"`
 }
 
+// ChangeLogEvent is a single row of the controller change log, as
+// streamed by the change log endpoint. The ID can be used as a cursor
+// to resume the stream from where it left off.
+type ChangeLogEvent struct {
+	ID          int64     `json:"id"`
+	Namespace   string    `json:"namespace"`
+	EditType    string    `json:"edit-type"`
+	ChangedUUID string    `json:"changed-uuid"`
+	CreatedAt   time.Time `json:"created-at"`
+}
+
 // ResourceUploadResult is used to return some details about an
 // uploaded resource.
 type ResourceUploadResult struct {
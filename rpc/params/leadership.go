@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // ClaimLeadershipBulkParams is a collection of parameters for making
 // a bulk leadership claim.
 type ClaimLeadershipBulkParams struct {
@@ -98,3 +100,43 @@ type PinnedLeadershipResult struct {
 	// reading lease data, if one occurred.
 	Error *Error `json:"error,omitempty"`
 }
+
+// LeadershipHistoryArgs holds the applications for which leadership
+// history is requested.
+type LeadershipHistoryArgs struct {
+	// Entities holds the tags of the applications.
+	Entities []Entity `json:"entities"`
+
+	// Limit restricts each result to the most recent changes.
+	// If zero, all recorded changes are returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// LeadershipHistoryResults holds the leadership history of a number of
+// applications.
+type LeadershipHistoryResults struct {
+	Results []LeadershipHistoryResult `json:"results"`
+}
+
+// LeadershipHistoryResult holds the leadership history of a single
+// application, oldest change first.
+type LeadershipHistoryResult struct {
+	History []LeadershipHistoryEntry `json:"history,omitempty"`
+	Error   *Error                   `json:"error,omitempty"`
+}
+
+// LeadershipHistoryEntry is a single change to an application's
+// leadership lease.
+type LeadershipHistoryEntry struct {
+	// Action is one of claim, extend, expire, revoke, pin or unpin.
+	Action string `json:"action"`
+
+	// Entity is the leader, or for pins, the entity requiring the pin.
+	Entity string `json:"entity,omitempty"`
+
+	// Expiry is when the lease was due to expire following the change.
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// Time is when the change was recorded.
+	Time time.Time `json:"time"`
+}
@@ -63,6 +63,19 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// ResumeMigrationArgs holds the details required to resume or abort
+// one or more suspended model migrations.
+type ResumeMigrationArgs struct {
+	Specs []ResumeMigrationSpec `json:"specs"`
+}
+
+// ResumeMigrationSpec identifies a model whose suspended migration
+// should be resumed, or aborted if Abort is true.
+type ResumeMigrationSpec struct {
+	ModelTag string `json:"model-tag"`
+	Abort    bool   `json:"abort,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -90,6 +103,17 @@ type SerializedModel struct {
 	Resources []SerializedModelResource `json:"resources"`
 }
 
+// SerializedModelResult holds a serialized model, or an error.
+type SerializedModelResult struct {
+	Result SerializedModel `json:"result"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// SerializedModelResults holds the results of a bulk model export.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -131,6 +155,36 @@ type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
 }
 
+// MigrationImportedBinaries describes the binaries which a target
+// controller already holds for a model being imported.
+type MigrationImportedBinaries struct {
+	Charms    []MigrationImportedCharm    `json:"charms"`
+	Tools     []MigrationImportedTools    `json:"tools"`
+	Resources []MigrationImportedResource `json:"resources"`
+}
+
+// MigrationImportedCharm holds the URL of a charm held by a migration
+// target and the hash of the archive it was uploaded from.
+type MigrationImportedCharm struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// MigrationImportedTools holds the version and tarball hash of agent
+// binaries held by a migration target.
+type MigrationImportedTools struct {
+	Version version.Binary `json:"version"`
+	SHA256  string         `json:"sha256"`
+}
+
+// MigrationImportedResource holds the fingerprint of an application
+// resource held by a migration target.
+type MigrationImportedResource struct {
+	Application string `json:"application"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
 // ActivateModelArgs holds args used to
 // activate a newly migrated model.
 type ActivateModelArgs struct {
@@ -158,6 +212,7 @@ type MasterMigrationStatus struct {
 	MigrationId      string        `json:"migration-id"`
 	Phase            string        `json:"phase"`
 	PhaseChangedTime time.Time     `json:"phase-changed-time"`
+	Suspended        bool          `json:"suspended,omitempty"`
 }
 
 // MigrationModelInfo is used to report basic model information to the
@@ -172,6 +227,28 @@ type MigrationModelInfo struct {
 	ModelDescription       []byte           `json:"model-description,omitempty"`
 }
 
+// MigrationPrecheckIssue describes an issue found by the migration
+// prechecks.
+type MigrationPrecheckIssue struct {
+	Severity   string `json:"severity"`
+	Controller string `json:"controller"`
+	Message    string `json:"message"`
+}
+
+// MigrationPrecheckResult holds the issues found by the migration
+// prechecks for a single model.
+type MigrationPrecheckResult struct {
+	ModelTag string                   `json:"model-tag"`
+	Issues   []MigrationPrecheckIssue `json:"issues"`
+	Error    *Error                   `json:"error,omitempty"`
+}
+
+// MigrationPrecheckResults holds the issues found by the migration
+// prechecks for one or more models.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
 	MigrationId string `json:"migration-id"`
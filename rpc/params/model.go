@@ -132,9 +132,10 @@ type SetModelAgentVersion struct {
 // ModelMigrationStatus holds information about the progress of a (possibly
 // failed) migration.
 type ModelMigrationStatus struct {
-	Status string     `json:"status"`
-	Start  *time.Time `json:"start"`
-	End    *time.Time `json:"end,omitempty"`
+	Status    string     `json:"status"`
+	Start     *time.Time `json:"start"`
+	End       *time.Time `json:"end,omitempty"`
+	Suspended bool       `json:"suspended,omitempty"`
 }
 
 // ModelInfo holds information about the Juju model.
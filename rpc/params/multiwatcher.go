@@ -107,6 +107,8 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(RelationInfo)
 	case "remoteApplication":
 		d.Entity = new(RemoteApplicationUpdate)
+	case "secret":
+		d.Entity = new(SecretInfo)
 	case "unit":
 		d.Entity = new(UnitInfo)
 	default:
@@ -320,6 +322,10 @@ type RelationInfo struct {
 	Key       string     `json:"key"`
 	Id        int        `json:"id"`
 	Endpoints []Endpoint `json:"endpoints"`
+	Life      life.Value `json:"life,omitempty"`
+	Status    StatusInfo `json:"status"`
+	UnitCount int        `json:"unit-count"`
+	Suspended bool       `json:"suspended,omitempty"`
 }
 
 // NewCharmRelation creates a new local CharmRelation structure from  the
@@ -353,6 +359,31 @@ func (i *RelationInfo) EntityId() EntityId {
 	}
 }
 
+// SecretInfo holds the information about a secret that is tracked
+// by multiwatcherStore.
+type SecretInfo struct {
+	ModelUUID      string         `json:"model-uuid"`
+	Id             string         `json:"id"`
+	URI            string         `json:"uri"`
+	Label          string         `json:"label,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	OwnerTag       string         `json:"owner-tag"`
+	RotatePolicy   string         `json:"rotate-policy,omitempty"`
+	LatestRevision int            `json:"latest-revision"`
+	LatestExpire   *time.Time     `json:"latest-expire,omitempty"`
+	Consumers      map[string]int `json:"consumers,omitempty"`
+}
+
+// EntityId returns a unique identifier for a secret across
+// models.
+func (i *SecretInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "secret",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
 // AnnotationInfo holds the information about an annotation that is
 // tracked by multiwatcherStore.
 type AnnotationInfo struct {
@@ -155,9 +155,30 @@ var marshalTestCases = []struct {
 						Scope:     "container"},
 				},
 			},
+			Life: life.Alive,
+			Status: params.StatusInfo{
+				Current: status.Joined,
+			},
+			UnitCount: 2,
+		},
+	},
+	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}], "life":"alive", "status":{"current":"joined", "message":"", "version":""}, "unit-count":2}]`,
+}, {
+	about: "SecretInfo Delta",
+	value: params.Delta{
+		Entity: &params.SecretInfo{
+			ModelUUID:      "uuid",
+			Id:             "9m4e2mr0ui3e8a215n4g",
+			URI:            "secret:9m4e2mr0ui3e8a215n4g",
+			Label:          "password",
+			OwnerTag:       "application-mysql",
+			LatestRevision: 2,
+			Consumers: map[string]int{
+				"unit-wordpress-0": 1,
+			},
 		},
 	},
-	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}]}]`,
+	json: `["secret","change",{"model-uuid": "uuid", "id":"9m4e2mr0ui3e8a215n4g", "uri":"secret:9m4e2mr0ui3e8a215n4g", "label":"password", "owner-tag":"application-mysql", "latest-revision":2, "consumers":{"unit-wordpress-0":1}}]`,
 }, {
 	about: "AnnotationInfo Delta",
 	value: params.Delta{
@@ -180,7 +201,7 @@ var marshalTestCases = []struct {
 			Key:       "Benji",
 		},
 	},
-	json: `["relation","remove",{"model-uuid": "uuid", "key":"Benji", "id": 0, "endpoints": null}]`,
+	json: `["relation","remove",{"model-uuid": "uuid", "key":"Benji", "id": 0, "endpoints": null, "status":{"current":"", "message":"", "version":""}, "unit-count":0}]`,
 }}
 
 func (s *MarshalSuite) TestDeltaMarshalJSON(c *gc.C) {
@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type fileBackend struct {
+	store  objectStore
+	sealer *sealer
+	prefix string
+}
+
+func (k fileBackend) objectKey(revisionId string) string {
+	if k.prefix == "" {
+		return revisionId
+	}
+	return k.prefix + "/" + revisionId
+}
+
+// GetContent implements SecretsBackend.
+func (k fileBackend) GetContent(ctx context.Context, revisionId string) (secrets.SecretValue, error) {
+	data, err := k.store.Get(ctx, k.objectKey(revisionId))
+	if errors.Is(err, errors.NotFound) {
+		return nil, errors.NotFoundf("secret revision %q", revisionId)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting secret %q", revisionId)
+	}
+	plaintext, err := k.sealer.open(revisionId, data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	val := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &val); err != nil {
+		return nil, errors.Annotatef(err, "decoding secret %q", revisionId)
+	}
+	return secrets.NewSecretValue(val), nil
+}
+
+// DeleteContent implements SecretsBackend.
+func (k fileBackend) DeleteContent(ctx context.Context, revisionId string) error {
+	err := k.store.Delete(ctx, k.objectKey(revisionId))
+	if errors.Is(err, errors.NotFound) {
+		return errors.NotFoundf("secret revision %q", revisionId)
+	}
+	return errors.Annotatef(err, "deleting secret %q", revisionId)
+}
+
+// SaveContent implements SecretsBackend.
+func (k fileBackend) SaveContent(ctx context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (string, error) {
+	revisionId := uri.Name(revision)
+	plaintext, err := json.Marshal(value.EncodedValues())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err := k.sealer.seal(revisionId, plaintext)
+	if err != nil {
+		return "", errors.Annotatef(err, "encrypting secret content for %q", revisionId)
+	}
+	if err := k.store.Put(ctx, k.objectKey(revisionId), data); err != nil {
+		return "", errors.Annotatef(err, "saving secret content for %q", revisionId)
+	}
+	return revisionId, nil
+}
+
+// Ping implements SecretsBackend.
+func (k fileBackend) Ping() error {
+	return errors.Trace(k.store.Ping(context.Background()))
+}
+
+// agentBackend is used by agents, which can't access the store or the
+// encryption key. The controller gives agents the content of revisions
+// held in the backend, and saves content they send to it.
+type agentBackend struct{}
+
+// GetContent implements SecretsBackend.
+func (agentBackend) GetContent(ctx context.Context, revisionId string) (secrets.SecretValue, error) {
+	return nil, errors.NotSupportedf("reading file backend content from an agent")
+}
+
+// DeleteContent implements SecretsBackend. Content is deleted by the
+// controller when its revision is removed or drained to another backend.
+func (agentBackend) DeleteContent(ctx context.Context, revisionId string) error {
+	return nil
+}
+
+// SaveContent implements SecretsBackend. Returning NotSupported has agents
+// send the content to the controller, which saves it to the backend.
+func (agentBackend) SaveContent(ctx context.Context, uri *secrets.URI, revision int, value secrets.SecretValue) (string, error) {
+	return "", errors.NotSupportedf("saving file backend content from an agent")
+}
+
+// Ping implements SecretsBackend.
+func (agentBackend) Ping() error {
+	return nil
+}
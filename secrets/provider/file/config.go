@@ -0,0 +1,228 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"encoding/base64"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	coreconfig "github.com/juju/juju/core/config"
+	"github.com/juju/juju/secrets/provider"
+)
+
+const (
+	StoreKey     = "store"
+	PathKey      = "path"
+	EndpointKey  = "endpoint"
+	RegionKey    = "region"
+	BucketKey    = "bucket"
+	AccessKeyKey = "access-key"
+	SecretKeyKey = "secret-key"
+	KeyFileKey   = "key-file"
+)
+
+const (
+	// StoreFilesystem stores secret content as files beneath a directory.
+	StoreFilesystem = "filesystem"
+
+	// StoreS3 stores secret content as objects in an S3 compatible bucket.
+	StoreS3 = "s3"
+)
+
+// keySize is the size of the AES-256 encryption key in bytes.
+const keySize = 32
+
+var configSchema = environschema.Fields{
+	StoreKey: {
+		Description: "Where to store the encrypted secret content, either filesystem or s3.",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{StoreFilesystem, StoreS3},
+		Immutable:   true,
+	},
+	PathKey: {
+		Description: "The directory in which to store secrets when using the filesystem store. It must be available at the same path on every controller machine.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	EndpointKey: {
+		Description: "The S3 compatible object store endpoint.",
+		Type:        environschema.Tstring,
+	},
+	RegionKey: {
+		Description: "The S3 region.",
+		Type:        environschema.Tstring,
+	},
+	BucketKey: {
+		Description: "The S3 bucket in which to store secrets.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+	AccessKeyKey: {
+		Description: "The S3 access key.",
+		Type:        environschema.Tstring,
+		Secret:      true,
+	},
+	SecretKeyKey: {
+		Description: "The S3 secret key.",
+		Type:        environschema.Tstring,
+		Secret:      true,
+	},
+	KeyFileKey: {
+		Description: "The path, on each controller machine, of the file holding the base64 encoded 256 bit encryption key.",
+		Type:        environschema.Tstring,
+		Immutable:   true,
+	},
+}
+
+var configDefaults = schema.Defaults{
+	StoreKey: StoreFilesystem,
+}
+
+type backendConfig struct {
+	validAttrs map[string]interface{}
+}
+
+func (c *backendConfig) store() string {
+	return c.validAttrs[StoreKey].(string)
+}
+
+func (c *backendConfig) path() string {
+	v, _ := c.validAttrs[PathKey].(string)
+	return v
+}
+
+func (c *backendConfig) endpoint() string {
+	v, _ := c.validAttrs[EndpointKey].(string)
+	return v
+}
+
+func (c *backendConfig) region() string {
+	v, _ := c.validAttrs[RegionKey].(string)
+	return v
+}
+
+func (c *backendConfig) bucket() string {
+	v, _ := c.validAttrs[BucketKey].(string)
+	return v
+}
+
+func (c *backendConfig) accessKey() string {
+	v, _ := c.validAttrs[AccessKeyKey].(string)
+	return v
+}
+
+func (c *backendConfig) secretKey() string {
+	v, _ := c.validAttrs[SecretKeyKey].(string)
+	return v
+}
+
+func (c *backendConfig) keyFile() string {
+	v, _ := c.validAttrs[KeyFileKey].(string)
+	return v
+}
+
+// encryptionKey returns the encryption key read from the key file.
+func (c *backendConfig) encryptionKey() ([]byte, error) {
+	data, err := os.ReadFile(c.keyFile())
+	if err != nil {
+		return nil, errors.Annotate(err, "reading encryption key file")
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, errors.NotValidf("encryption key encoding")
+	}
+	if len(key) != keySize {
+		return nil, errors.NotValidf("encryption key length %d, expected %d", len(key), keySize)
+	}
+	return key, nil
+}
+
+// validate checks the config is consistent, without accessing the
+// store or the key file, which may not exist where the config is
+// being validated.
+func (c *backendConfig) validate() error {
+	switch c.store() {
+	case StoreFilesystem:
+		if c.path() == "" {
+			return errors.NotValidf("filesystem store without a path")
+		}
+		if !filepath.IsAbs(c.path()) {
+			return errors.NotValidf("relative path %q", c.path())
+		}
+	case StoreS3:
+		if c.bucket() == "" {
+			return errors.NotValidf("s3 store without a bucket")
+		}
+		if endpoint := c.endpoint(); endpoint != "" {
+			if _, err := url.Parse(endpoint); err != nil {
+				return errors.Annotatef(err, "invalid endpoint %q", endpoint)
+			}
+		}
+		if (c.accessKey() == "") != (c.secretKey() == "") {
+			return errors.NotValidf("s3 config with only one of access key and secret key")
+		}
+	}
+	if c.keyFile() == "" {
+		return errors.NotValidf("config without a key file")
+	}
+	if c.keyFile() != "" && !filepath.IsAbs(c.keyFile()) {
+		return errors.NotValidf("relative key file %q", c.keyFile())
+	}
+	return nil
+}
+
+// ConfigSchema implements ProviderConfig.
+func (p fileProvider) ConfigSchema() environschema.Fields {
+	return configSchema
+}
+
+// ConfigDefaults implements ProviderConfig.
+func (p fileProvider) ConfigDefaults() schema.Defaults {
+	return configDefaults
+}
+
+// ValidateConfig implements ProviderConfig.
+func (p fileProvider) ValidateConfig(oldCfg, newCfg provider.ConfigAttrs) error {
+	newValidCfg, err := newConfig(newCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := newValidCfg.validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if oldCfg == nil {
+		return nil
+	}
+	oldValidCfg, err := newConfig(oldCfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for n, field := range configSchema {
+		if !field.Immutable {
+			continue
+		}
+		oldV := oldValidCfg.validAttrs[n]
+		newV := newValidCfg.validAttrs[n]
+		if oldV != newV {
+			return errors.Errorf("cannot change immutable field %q", n)
+		}
+	}
+	return nil
+}
+
+func newConfig(attrs map[string]interface{}) (*backendConfig, error) {
+	cfg, err := coreconfig.NewConfig(attrs, configSchema, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &backendConfig{cfg.Attributes()}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/file"
+)
+
+type configSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&configSuite{})
+
+func (s *configSuite) TestValidateConfig(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	configValidator, ok := p.(provider.ProviderConfig)
+	c.Assert(ok, jc.IsTrue)
+	for i, t := range []struct {
+		cfg    map[string]interface{}
+		oldCfg map[string]interface{}
+		err    string
+	}{{
+		cfg: map[string]interface{}{"path": "/srv/secrets", "key-file": "/etc/key"},
+	}, {
+		cfg: map[string]interface{}{"store": "s3", "bucket": "secrets", "key-file": "/etc/key"},
+	}, {
+		cfg: map[string]interface{}{"store": "tape", "key-file": "/etc/key"},
+		err: `store: expected one of .*`,
+	}, {
+		cfg: map[string]interface{}{"key-file": "/etc/key"},
+		err: `filesystem store without a path not valid`,
+	}, {
+		cfg: map[string]interface{}{"path": "secrets", "key-file": "/etc/key"},
+		err: `relative path "secrets" not valid`,
+	}, {
+		cfg: map[string]interface{}{"path": "/srv/secrets"},
+		err: `config without a key file not valid`,
+	}, {
+		cfg: map[string]interface{}{"path": "/srv/secrets", "key-file": "key"},
+		err: `relative key file "key" not valid`,
+	}, {
+		cfg: map[string]interface{}{"store": "s3", "key-file": "/etc/key"},
+		err: `s3 store without a bucket not valid`,
+	}, {
+		cfg: map[string]interface{}{"store": "s3", "bucket": "secrets", "access-key": "ak", "key-file": "/etc/key"},
+		err: `s3 config with only one of access key and secret key not valid`,
+	}, {
+		cfg:    map[string]interface{}{"path": "/srv/new", "key-file": "/etc/key"},
+		oldCfg: map[string]interface{}{"path": "/srv/old", "key-file": "/etc/key"},
+		err:    `cannot change immutable field "path"`,
+	}, {
+		cfg:    map[string]interface{}{"store": "s3", "bucket": "secrets", "endpoint": "http://new", "key-file": "/etc/key"},
+		oldCfg: map[string]interface{}{"store": "s3", "bucket": "secrets", "endpoint": "http://old", "key-file": "/etc/key"},
+	}} {
+		c.Logf("test %d", i)
+		err = configValidator.ValidateConfig(t.oldCfg, t.cfg)
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
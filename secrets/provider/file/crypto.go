@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// envelopeVersion is the version of the encrypted content format.
+const envelopeVersion = 1
+
+// envelope is the serialisation format of encrypted secret content.
+type envelope struct {
+	Version    int    `json:"version"`
+	KeyID      string `json:"key-id"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// sealer encrypts and decrypts secret content with AES-256-GCM.
+type sealer struct {
+	aead  cipher.AEAD
+	keyID string
+}
+
+func newSealer(key []byte) (*sealer, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The key id allows content encrypted with a different key to be
+	// reported as such, rather than as corrupt.
+	sum := sha256.Sum256(key)
+	return &sealer{
+		aead:  aead,
+		keyID: hex.EncodeToString(sum[:8]),
+	}, nil
+}
+
+// seal encrypts the plaintext. The revision id is authenticated along
+// with the content, so that content can't be swapped between revisions.
+func (s *sealer) seal(revisionID string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Annotate(err, "generating nonce")
+	}
+	data, err := json.Marshal(envelope{
+		Version:    envelopeVersion,
+		KeyID:      s.keyID,
+		Nonce:      nonce,
+		Ciphertext: s.aead.Seal(nil, nonce, plaintext, []byte(revisionID)),
+	})
+	return data, errors.Trace(err)
+}
+
+// open decrypts content previously encrypted by seal.
+func (s *sealer) open(revisionID string, data []byte) ([]byte, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, errors.Annotatef(err, "decoding secret revision %q", revisionID)
+	}
+	if env.Version != envelopeVersion {
+		return nil, errors.NotSupportedf("secret revision %q format version %d", revisionID, env.Version)
+	}
+	if env.KeyID != s.keyID {
+		return nil, errors.Errorf("secret revision %q encrypted with key %q, have key %q", revisionID, env.KeyID, s.keyID)
+	}
+	if len(env.Nonce) != s.aead.NonceSize() {
+		return nil, errors.NotValidf("secret revision %q nonce", revisionID)
+	}
+	plaintext, err := s.aead.Open(nil, env.Nonce, env.Ciphertext, []byte(revisionID))
+	if err != nil {
+		return nil, errors.Annotatef(err, "decrypting secret revision %q", revisionID)
+	}
+	return plaintext, nil
+}
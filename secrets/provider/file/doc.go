@@ -0,0 +1,13 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package file provides a secrets backend which stores AES-GCM encrypted
+// secret content on a filesystem or in an S3 compatible object store. It
+// is intended for sites that can't run Vault but don't want secret content
+// held in the controller database.
+//
+// The encryption key is read from a file on each controller machine and
+// is never stored in the backend config. Only controllers access the store
+// and the key; agents read and save secret content through the controller
+// API, as they do for secrets held in the controller database.
+package file
@@ -0,0 +1,16 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package mocks -destination mocks/s3_mock.go github.com/juju/juju/secrets/provider/file S3Client
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
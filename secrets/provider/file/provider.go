@@ -0,0 +1,127 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v5"
+
+	"github.com/juju/juju/secrets/provider"
+)
+
+var logger = loggo.GetLogger("juju.secrets.file")
+
+const (
+	// BackendType is the type of the encrypted file secrets backend.
+	BackendType = "file"
+)
+
+// NewProvider returns an encrypted file secrets provider.
+func NewProvider() provider.SecretBackendProvider {
+	return fileProvider{}
+}
+
+type fileProvider struct {
+}
+
+func (p fileProvider) Type() string {
+	return BackendType
+}
+
+// Initialise is not used; objects are created as they are saved.
+func (p fileProvider) Initialise(*provider.ModelBackendConfig) error {
+	return nil
+}
+
+// CleanupModel deletes all secrets associated with the model.
+func (p fileProvider) CleanupModel(cfg *provider.ModelBackendConfig) error {
+	k, err := p.newBackend(cfg.ModelUUID, &cfg.BackendConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if k.prefix == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	keys, err := k.store.List(ctx, k.prefix+"/")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("removing %d secret revisions for model %q", len(keys), cfg.ModelUUID)
+	for _, key := range keys {
+		if err := k.store.Delete(ctx, key); err != nil && !errors.Is(err, errors.NotFound) {
+			return errors.Annotatef(err, "deleting secret %q", key)
+		}
+	}
+	return nil
+}
+
+// CleanupSecrets is not used; there are no per secret
+// resources beyond the content itself.
+func (p fileProvider) CleanupSecrets(cfg *provider.ModelBackendConfig, tag names.Tag, removed provider.SecretRevisions) error {
+	return nil
+}
+
+// RestrictedConfig returns the config needed to create a
+// secrets backend client for the given entity tag.
+// The store has no access control of its own, so agents are
+// not given the store config or the encryption key; the
+// controller reads and saves content on their behalf.
+func (p fileProvider) RestrictedConfig(
+	adminCfg *provider.ModelBackendConfig, sameController, forDrain bool, tag names.Tag, owned provider.SecretRevisions, read provider.SecretRevisions,
+) (*provider.BackendConfig, error) {
+	return &provider.BackendConfig{
+		BackendType: BackendType,
+	}, nil
+}
+
+// ControllerOnlyAccess implements provider.SupportControllerOnlyAccess.
+func (p fileProvider) ControllerOnlyAccess() {}
+
+// NewBackend returns an encrypted file backed secrets backend client.
+// Agents, which are given an empty restricted config, get a backend
+// which leaves reading and saving content to the controller.
+func (p fileProvider) NewBackend(cfg *provider.ModelBackendConfig) (provider.SecretsBackend, error) {
+	if len(cfg.Config) == 0 {
+		return agentBackend{}, nil
+	}
+	return p.newBackend(cfg.ModelUUID, &cfg.BackendConfig)
+}
+
+func (p fileProvider) newBackend(modelUUID string, cfg *provider.BackendConfig) (*fileBackend, error) {
+	validCfg, err := newConfig(cfg.Config)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid file backend config")
+	}
+	if err := validCfg.validate(); err != nil {
+		return nil, errors.Annotatef(err, "invalid file backend config")
+	}
+
+	key, err := validCfg.encryptionKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s, err := newSealer(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var store objectStore
+	switch validCfg.store() {
+	case StoreS3:
+		client := NewS3Client(validCfg.endpoint(), validCfg.region(), validCfg.accessKey(), validCfg.secretKey())
+		store = s3Store{client: client, bucket: validCfg.bucket()}
+	default:
+		store = fileStore{root: validCfg.path()}
+	}
+	return &fileBackend{
+		store:  store,
+		sealer: s,
+		prefix: modelUUID,
+	}, nil
+}
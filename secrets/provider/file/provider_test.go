@@ -0,0 +1,273 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/juju/errors"
+	"github.com/juju/names/v5"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/secrets/provider"
+	_ "github.com/juju/juju/secrets/provider/all"
+	"github.com/juju/juju/secrets/provider/file"
+	"github.com/juju/juju/secrets/provider/file/mocks"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type providerSuite struct {
+	testing.IsolationSuite
+
+	dir     string
+	keyFile string
+	key     []byte
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.dir = c.MkDir()
+	s.key = []byte(strings.Repeat("k", 32))
+	s.keyFile = filepath.Join(c.MkDir(), "secrets.key")
+	err := os.WriteFile(s.keyFile, []byte(base64.StdEncoding.EncodeToString(s.key)+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) adminConfig() *provider.ModelBackendConfig {
+	return &provider.ModelBackendConfig{
+		ControllerUUID: coretesting.ControllerTag.Id(),
+		ModelUUID:      coretesting.ModelTag.Id(),
+		ModelName:      "fred",
+		BackendConfig: provider.BackendConfig{
+			BackendType: file.BackendType,
+			Config: map[string]interface{}{
+				"path":     s.dir,
+				"key-file": s.keyFile,
+			},
+		},
+	}
+}
+
+func (s *providerSuite) newBackend(c *gc.C, cfg *provider.ModelBackendConfig) provider.SecretsBackend {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	b, err := p.NewBackend(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return b
+}
+
+func (s *providerSuite) TestSaveGetDeleteContent(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig())
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+
+	uri := coresecrets.NewURI()
+	value := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	revisionId, err := b.SaveContent(context.Background(), uri, 1, value)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisionId, gc.Equals, uri.ID+"-1")
+
+	// The content on disk is encrypted.
+	data, err := os.ReadFile(filepath.Join(s.dir, coretesting.ModelTag.Id(), revisionId))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Contains(string(data), "YmFy"), jc.IsFalse)
+
+	got, err := b.GetContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	err = b.DeleteContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = b.GetContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+	err = b.DeleteContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *providerSuite) TestGetContentWrongKey(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig())
+	uri := coresecrets.NewURI()
+	revisionId, err := b.SaveContent(context.Background(), uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherKeyFile := filepath.Join(c.MkDir(), "other.key")
+	err = os.WriteFile(otherKeyFile, []byte(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 32)))), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := s.adminConfig()
+	cfg.Config["key-file"] = otherKeyFile
+	other := s.newBackend(c, cfg)
+	_, err = other.GetContent(context.Background(), revisionId)
+	c.Assert(err, gc.ErrorMatches, `secret revision ".*" encrypted with key ".*", have key ".*"`)
+}
+
+func (s *providerSuite) TestGetContentTampered(c *gc.C) {
+	b := s.newBackend(c, s.adminConfig())
+	uri := coresecrets.NewURI()
+	value := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	rev1, err := b.SaveContent(context.Background(), uri, 1, value)
+	c.Assert(err, jc.ErrorIsNil)
+	rev2, err := b.SaveContent(context.Background(), uri, 2, value)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Content moved between revisions fails authentication.
+	modelDir := filepath.Join(s.dir, coretesting.ModelTag.Id())
+	err = os.Rename(filepath.Join(modelDir, rev1), filepath.Join(modelDir, rev2))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = b.GetContent(context.Background(), rev2)
+	c.Assert(err, gc.ErrorMatches, `decrypting secret revision ".*": .*`)
+}
+
+func (s *providerSuite) TestNewBackendBadKeyFile(c *gc.C) {
+	err := os.WriteFile(s.keyFile, []byte("c2hvcnQ="), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.NewBackend(s.adminConfig())
+	c.Assert(err, gc.ErrorMatches, `encryption key length 5, expected 32 not valid`)
+}
+
+func (s *providerSuite) TestRestrictedConfig(c *gc.C) {
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.HasControllerOnlyAccess(p), jc.IsTrue)
+
+	adminCfg := s.adminConfig()
+	adminCfg.Config["store"] = "s3"
+	adminCfg.Config["bucket"] = "secrets"
+	adminCfg.Config["access-key"] = "ak"
+	adminCfg.Config["secret-key"] = "sk"
+	cfg, err := p.RestrictedConfig(adminCfg, true, false, names.NewUnitTag("ubuntu/0"), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Neither the store credentials nor the key are given to agents.
+	c.Assert(cfg, jc.DeepEquals, &provider.BackendConfig{
+		BackendType: file.BackendType,
+	})
+}
+
+func (s *providerSuite) TestAgentBackend(c *gc.C) {
+	agentCfg := s.adminConfig()
+	agentCfg.BackendConfig = provider.BackendConfig{BackendType: file.BackendType}
+	b := s.newBackend(c, agentCfg)
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+
+	// Agents send content to the controller to save.
+	uri := coresecrets.NewURI()
+	_, err := b.SaveContent(context.Background(), uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+	_, err = b.GetContent(context.Background(), uri.Name(1))
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+
+	// The controller deletes content when revisions are removed.
+	err = b.DeleteContent(context.Background(), uri.Name(1))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestCleanupModel(c *gc.C) {
+	adminCfg := s.adminConfig()
+	b := s.newBackend(c, adminCfg)
+	uri := coresecrets.NewURI()
+	value := coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"})
+	for rev := 1; rev <= 3; rev++ {
+		_, err := b.SaveContent(context.Background(), uri, rev, value)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	otherCfg := s.adminConfig()
+	otherCfg.ModelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	otherRevisionId, err := s.newBackend(c, otherCfg).SaveContent(context.Background(), uri, 1, value)
+	c.Assert(err, jc.ErrorIsNil)
+
+	p, err := provider.Provider(file.BackendType)
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.CleanupModel(adminCfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for rev := 1; rev <= 3; rev++ {
+		_, err := b.GetContent(context.Background(), uri.Name(rev))
+		c.Check(err, jc.ErrorIs, errors.NotFound)
+	}
+	_, err = s.newBackend(c, otherCfg).GetContent(context.Background(), otherRevisionId)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestS3Store(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	client := mocks.NewMockS3Client(ctrl)
+	s.PatchValue(&file.NewS3Client, func(endpoint, region, accessKey, secretKey string) file.S3Client {
+		c.Check(endpoint, gc.Equals, "http://minio:9000")
+		c.Check(accessKey, gc.Equals, "ak")
+		c.Check(secretKey, gc.Equals, "sk")
+		return client
+	})
+
+	cfg := s.adminConfig()
+	cfg.Config = map[string]interface{}{
+		"store":      "s3",
+		"endpoint":   "http://minio:9000",
+		"bucket":     "secrets",
+		"access-key": "ak",
+		"secret-key": "sk",
+		"key-file":   s.keyFile,
+	}
+	b := s.newBackend(c, cfg)
+
+	uri := coresecrets.NewURI()
+	objectKey := coretesting.ModelTag.Id() + "/" + uri.Name(1)
+
+	var stored []byte
+	client.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+			c.Check(aws.ToString(in.Bucket), gc.Equals, "secrets")
+			c.Check(aws.ToString(in.Key), gc.Equals, objectKey)
+			var err error
+			stored, err = io.ReadAll(in.Body)
+			c.Check(err, jc.ErrorIsNil)
+			return &s3.PutObjectOutput{}, nil
+		})
+	revisionId, err := b.SaveContent(context.Background(), uri, 1, coresecrets.NewSecretValue(map[string]string{"foo": "YmFy"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.EXPECT().GetObject(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			c.Check(aws.ToString(in.Key), gc.Equals, objectKey)
+			return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(string(stored)))}, nil
+		})
+	got, err := b.GetContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.EncodedValues(), jc.DeepEquals, map[string]string{"foo": "YmFy"})
+
+	client.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(nil, &s3types.NotFound{})
+	err = b.DeleteContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	client.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(&s3.HeadObjectOutput{}, nil)
+	client.EXPECT().DeleteObject(gomock.Any(), gomock.Any()).Return(&s3.DeleteObjectOutput{}, nil)
+	err = b.DeleteContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.EXPECT().GetObject(gomock.Any(), gomock.Any()).Return(nil, &s3types.NoSuchKey{})
+	_, err = b.GetContent(context.Background(), revisionId)
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+
+	client.EXPECT().HeadBucket(gomock.Any(), gomock.Any()).Return(&s3.HeadBucketOutput{}, nil)
+	c.Assert(b.Ping(), jc.ErrorIsNil)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/juju/errors"
+)
+
+// S3Client represents the S3 client methods used by the s3 store.
+type S3Client interface {
+	PutObject(context.Context, *s3.PutObjectInput, ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(context.Context, *s3.GetObjectInput, ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(context.Context, *s3.HeadObjectInput, ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	DeleteObject(context.Context, *s3.DeleteObjectInput, ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	ListObjectsV2(context.Context, *s3.ListObjectsV2Input, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	HeadBucket(context.Context, *s3.HeadBucketInput, ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+}
+
+// NewS3Client is patched for testing.
+var NewS3Client = func(endpoint, region, accessKey, secretKey string) S3Client {
+	opts := s3.Options{
+		Region: region,
+		// Most S3 compatible stores don't support virtual hosted buckets.
+		UsePathStyle: true,
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if endpoint != "" {
+		opts.BaseEndpoint = aws.String(endpoint)
+	}
+	if accessKey != "" {
+		opts.Credentials = credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
+	}
+	return s3.New(opts)
+}
+
+// s3Store stores objects in an S3 bucket.
+type s3Store struct {
+	client S3Client
+	bucket string
+}
+
+// Put is part of the objectStore interface.
+func (s s3Store) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	return errors.Annotatef(err, "putting object %q", key)
+}
+
+// Get is part of the objectStore interface.
+func (s s3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("object %q", key)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting object %q", key)
+	}
+	defer func() { _ = out.Body.Close() }()
+	data, err := io.ReadAll(out.Body)
+	return data, errors.Annotatef(err, "reading object %q", key)
+}
+
+// Delete is part of the objectStore interface.
+func (s s3Store) Delete(ctx context.Context, key string) error {
+	// Deleting a missing object succeeds, so check it exists first
+	// in order to return a not found error.
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if isS3NotFound(err) {
+		return errors.NotFoundf("object %q", key)
+	} else if err != nil {
+		return errors.Annotatef(err, "checking object %q", key)
+	}
+	_, err = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return errors.Annotatef(err, "deleting object %q", key)
+}
+
+// List is part of the objectStore interface.
+func (s s3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var (
+		keys  []string
+		token *string
+	)
+	for {
+		out, err := s.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(s.bucket),
+			Prefix:            aws.String(prefix),
+			ContinuationToken: token,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing objects with prefix %q", prefix)
+		}
+		for _, obj := range out.Contents {
+			keys = append(keys, aws.ToString(obj.Key))
+		}
+		if !aws.ToBool(out.IsTruncated) {
+			return keys, nil
+		}
+		token = out.NextContinuationToken
+	}
+}
+
+// Ping is part of the objectStore interface.
+func (s s3Store) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	return errors.Annotate(err, "backend not reachable")
+}
+
+func isS3NotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NoSuchKey", "NotFound":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// objectStore stores opaque objects by key. Keys are slash separated.
+type objectStore interface {
+	// Put writes the object, replacing any existing object.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get returns the object. It returns a NotFound error
+	// if the object doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Delete removes the object. It returns a NotFound error
+	// if the object doesn't exist.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys of all of the objects with the prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Ping checks that the store is reachable.
+	Ping(ctx context.Context) error
+}
+
+// fileStore stores objects as files beneath a root directory.
+type fileStore struct {
+	root string
+}
+
+func (s fileStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(s.root)+string(filepath.Separator)) {
+		return "", errors.NotValidf("key %q", key)
+	}
+	return path, nil
+}
+
+// Put is part of the objectStore interface.
+func (s fileStore) Put(_ context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file and rename it, so that a partially
+	// written object is never read.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return errors.Trace(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return errors.Trace(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(tmp.Name(), path))
+}
+
+// Get is part of the objectStore interface.
+func (s fileStore) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("object %q", key)
+	}
+	return data, errors.Trace(err)
+}
+
+// Delete is part of the objectStore interface.
+func (s fileStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("object %q", key)
+	}
+	return errors.Trace(err)
+}
+
+// List is part of the objectStore interface.
+func (s fileStore) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return errors.Trace(err)
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, errors.Trace(err)
+}
+
+// Ping is part of the objectStore interface.
+func (s fileStore) Ping(_ context.Context) error {
+	info, err := os.Stat(s.root)
+	if err != nil {
+		return errors.Annotate(err, "backend not reachable")
+	}
+	if !info.IsDir() {
+		return errors.Errorf("backend path %q is not a directory", s.root)
+	}
+	return nil
+}
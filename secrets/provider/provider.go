@@ -100,3 +100,18 @@ func HasAuthRefresh(p SecretBackendProvider) bool {
 	_, ok := p.(SupportAuthRefresh)
 	return ok
 }
+
+// SupportControllerOnlyAccess is implemented by providers whose backends
+// can only be accessed by controllers. Agents are not given any config for
+// such backends; the controller reads and saves secret content on their
+// behalf, as it does for the internal backend.
+type SupportControllerOnlyAccess interface {
+	ControllerOnlyAccess()
+}
+
+// HasControllerOnlyAccess returns true if only controllers can access the
+// provider's backends.
+func HasControllerOnlyAccess(p SecretBackendProvider) bool {
+	_, ok := p.(SupportControllerOnlyAccess)
+	return ok
+}
@@ -5,6 +5,8 @@ package state
 
 import (
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/juju/charm/v12"
@@ -19,6 +21,7 @@ import (
 	"github.com/juju/juju/core/multiwatcher"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state/watcher"
@@ -108,6 +111,11 @@ func makeAllWatcherCollectionInfo(collNames []string) map[string]allWatcherState
 		case podSpecsC:
 			collection.docType = reflect.TypeOf(backingPodSpec{})
 			collection.subsidiary = true
+		case secretMetadataC:
+			collection.docType = reflect.TypeOf(backingSecretMetadata{})
+		case secretConsumersC:
+			collection.docType = reflect.TypeOf(backingSecretConsumer{})
+			collection.subsidiary = true
 		default:
 			allWatcherLogger.Criticalf("programming error: unknown collection %q", collName)
 		}
@@ -1037,6 +1045,21 @@ func (r *backingRelation) updated(ctx *allWatcherContext) error {
 		Key:       r.Key,
 		ID:        r.Id,
 		Endpoints: eps,
+		Life:      life.Value(r.Life.String()),
+		UnitCount: r.UnitCount,
+		Suspended: r.Suspended,
+	}
+	oldInfo := ctx.store.Get(info.EntityID())
+	if oldInfo == nil {
+		// Relation status is optional, so may not be there.
+		relationStatus, err := ctx.getStatus(relationGlobalScope(r.Id), "relation")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "reading relation status for %q", r.Key)
+		}
+		info.Status = relationStatus
+	} else {
+		// The entry already exists, so preserve the current status.
+		info.Status = oldInfo.(*multiwatcher.RelationInfo).Status
 	}
 	ctx.store.Update(info)
 	return nil
@@ -1067,6 +1090,99 @@ func (r *backingRelation) mongoID() string {
 	return r.Key
 }
 
+type backingSecretMetadata secretMetadataDoc
+
+func (s *backingSecretMetadata) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	uri := secrets.URI{ID: ctx.id}
+	info := &multiwatcher.SecretInfo{
+		ModelUUID:      ctx.modelUUID,
+		ID:             ctx.id,
+		URI:            uri.String(),
+		Label:          s.Label,
+		Description:    s.Description,
+		OwnerTag:       s.OwnerTag,
+		RotatePolicy:   s.RotatePolicy,
+		LatestRevision: s.LatestRevision,
+		LatestExpire:   s.LatestExpireTime,
+	}
+	oldInfo := ctx.store.Get(info.EntityID())
+	if oldInfo == nil {
+		consumers, err := ctx.getSecretConsumers(ctx.id)
+		if err != nil {
+			return errors.Annotatef(err, "reading consumers for secret %q", ctx.id)
+		}
+		info.Consumers = consumers
+	} else {
+		// The entry already exists, so preserve the current consumers.
+		info.Consumers = oldInfo.(*multiwatcher.SecretInfo).Consumers
+	}
+	ctx.store.Update(info)
+	return nil
+}
+
+func (s *backingSecretMetadata) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	ctx.removeFromStore(multiwatcher.SecretKind)
+	return nil
+}
+
+func (s *backingSecretMetadata) mongoID() string {
+	_, id, ok := splitDocID(s.DocID)
+	if !ok {
+		allWatcherLogger.Criticalf("secret ID not valid: %v", s.DocID)
+	}
+	return id
+}
+
+type backingSecretConsumer secretConsumerDoc
+
+func (s *backingSecretConsumer) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret consumer "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return s.updateSecret(ctx, false)
+}
+
+func (s *backingSecretConsumer) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`secret consumer "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	return s.updateSecret(ctx, true)
+}
+
+// updateSecret records the consumer's current revision on the secret it
+// consumes. Consumers of secrets owned by another model are ignored, as
+// only secrets owned by this model are tracked.
+func (s *backingSecretConsumer) updateSecret(ctx *allWatcherContext, removed bool) error {
+	secretID, consumer := splitSecretConsumerKey(ctx.id)
+	if secretID == "" || strings.Contains(secretID, "/") {
+		return nil
+	}
+	info0 := ctx.store.Get(multiwatcher.EntityID{
+		Kind:      multiwatcher.SecretKind,
+		ModelUUID: ctx.modelUUID,
+		ID:        secretID,
+	})
+	info, ok := info0.(*multiwatcher.SecretInfo)
+	if !ok {
+		// The parent info doesn't exist. Ignore the consumer until it does.
+		return nil
+	}
+	newInfo := info.Clone().(*multiwatcher.SecretInfo)
+	if removed {
+		delete(newInfo.Consumers, consumer)
+	} else {
+		if newInfo.Consumers == nil {
+			newInfo.Consumers = make(map[string]int)
+		}
+		newInfo.Consumers[consumer] = s.CurrentRevision
+	}
+	ctx.store.Update(newInfo)
+	return nil
+}
+
+func (s *backingSecretConsumer) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from secret consumer document")
+	return ""
+}
+
 type backingAnnotation annotatorDoc
 
 func (a *backingAnnotation) updated(ctx *allWatcherContext) error {
@@ -1233,6 +1349,10 @@ func (s *backingStatus) updated(ctx *allWatcherContext) error {
 		newInfo := *info
 		newInfo.Status = s.toStatusInfo()
 		info0 = &newInfo
+	case *multiwatcher.RelationInfo:
+		newInfo := *info
+		newInfo.Status = s.toStatusInfo()
+		info0 = &newInfo
 	case *multiwatcher.MachineInfo:
 		newInfo := *info
 		switch suffix {
@@ -1652,6 +1772,8 @@ func NewAllWatcherBacking(pool *StatePool) (AllWatcherBacking, error) {
 		permissionsC,
 		relationsC,
 		remoteApplicationsC,
+		secretMetadataC,
+		secretConsumersC,
 		statusesC,
 		settingsC,
 		// And for CAAS we need to watch these...
@@ -2153,6 +2275,27 @@ func (ctx *allWatcherContext) getStatus(key, badge string) (multiwatcher.StatusI
 	}, nil
 }
 
+// getSecretConsumers returns the revision currently used by each local
+// consumer of the secret.
+func (ctx *allWatcherContext) getSecretConsumers(secretID string) (map[string]int, error) {
+	col, closer := ctx.state.db().GetCollection(secretConsumersC)
+	defer closer()
+
+	var docs []secretConsumerDoc
+	err := col.Find(bson.D{{"_id", bson.D{{"$regex", "^" + regexp.QuoteMeta(secretID) + "#"}}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	consumers := make(map[string]int, len(docs))
+	for _, doc := range docs {
+		consumers[doc.ConsumerTag] = doc.CurrentRevision
+	}
+	return consumers, nil
+}
+
 func (ctx *allWatcherContext) getInstanceData(id string) (instanceData, error) {
 	if ctx.instances != nil {
 		gKey := ensureModelUUID(ctx.modelUUID, id)
@@ -2261,12 +2404,46 @@ func (ctx *allWatcherContext) entityIDForGlobalKey(key string) (multiwatcher.Ent
 			ModelUUID: ctx.modelUUID,
 			Name:      id,
 		}
+	case "r":
+		// Relations are tracked by key rather than id, so look up the
+		// relation's key. A relation not yet in the store reads its
+		// status when it is added.
+		relID, err := strconv.Atoi(id)
+		if err != nil {
+			return multiwatcher.EntityID{}, "", false
+		}
+		relKey, err := ctx.relationKeyForID(relID)
+		if err != nil {
+			return multiwatcher.EntityID{}, "", false
+		}
+		result = &multiwatcher.RelationInfo{
+			ModelUUID: ctx.modelUUID,
+			Key:       relKey,
+		}
 	default:
 		return multiwatcher.EntityID{}, "", false
 	}
 	return result.EntityID(), suffix, true
 }
 
+// relationKeyForID returns the key of the relation with the given id
+// in the context's model.
+func (ctx *allWatcherContext) relationKeyForID(id int) (string, error) {
+	col, closer := ctx.state.db().GetCollection(relationsC)
+	defer closer()
+
+	var doc struct {
+		Key string `bson:"key"`
+	}
+	err := col.Find(bson.D{{"id", id}}).Select(bson.D{{"key", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("relation %d", id)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Key, nil
+}
+
 func (ctx *allWatcherContext) modelType() (ModelType, error) {
 	if ctx.modelType_ != modelTypeNone {
 		return ctx.modelType_, nil
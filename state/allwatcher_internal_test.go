@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/core/network"
 	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/testing"
@@ -257,6 +258,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 			{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+		Life: life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	for i := 0; i < units; i++ {
@@ -432,6 +439,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress2", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Life: life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 
 	applicationOfferInfo, rel2 := addTestingApplicationOffer(
@@ -444,6 +457,12 @@ func (s *allWatcherBaseSuite) setUpScenario(c *gc.C, st *State, units int) (enti
 		Endpoints: []multiwatcher.Endpoint{
 			{ApplicationName: "mysql", Relation: multiwatcher.CharmRelation{Name: "server", Role: "provider", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}},
 			{ApplicationName: "remote-wordpress", Relation: multiwatcher.CharmRelation{Name: "db", Role: "requirer", Interface: "mysql", Optional: false, Limit: 0, Scope: "global"}}},
+		Life: life.Alive,
+		Status: multiwatcher.StatusInfo{
+			Current: status.Joining,
+			Data:    map[string]interface{}{},
+			Since:   &now,
+		},
 	})
 	add(&applicationOfferInfo)
 
@@ -653,6 +672,10 @@ func (s *allWatcherStateSuite) TestChangeRelations(c *gc.C) {
 	testChangeRelations(c, s.owner, s.performChangeTestCases)
 }
 
+func (s *allWatcherStateSuite) TestChangeSecrets(c *gc.C) {
+	testChangeSecrets(c, s.owner, s.performChangeTestCases)
+}
+
 func (s *allWatcherStateSuite) TestChangeApplications(c *gc.C) {
 	testChangeApplications(c, s.owner, s.performChangeTestCases)
 }
@@ -2064,8 +2087,9 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 			AddTestingApplication(c, st, "logging", AddTestingCharm(c, st, "logging"))
 			eps, err := st.InferEndpoints("logging", "wordpress")
 			c.Assert(err, jc.ErrorIsNil)
-			_, err = st.AddRelation(eps...)
+			rel, err := st.AddRelation(eps...)
 			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
 
 			return changeTestCase{
 				about: "relation is added if it's in backing but not in Store",
@@ -2077,9 +2101,154 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 					&multiwatcher.RelationInfo{
 						ModelUUID: st.ModelUUID(),
 						Key:       "logging:logging-directory wordpress:logging-dir",
+						ID:        rel.Id(),
 						Endpoints: []multiwatcher.Endpoint{
 							{ApplicationName: "logging", Relation: multiwatcher.CharmRelation{Name: "logging-directory", Role: "requirer", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}},
 							{ApplicationName: "wordpress", Relation: multiwatcher.CharmRelation{Name: "logging-dir", Role: "provider", Interface: "logging", Optional: false, Limit: 0, Scope: "container"}}},
+						Life: life.Alive,
+						Status: multiwatcher.StatusInfo{
+							Current: status.Joining,
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
+					}}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			AddTestingApplication(c, st, "logging", AddTestingCharm(c, st, "logging"))
+			eps, err := st.InferEndpoints("logging", "wordpress")
+			c.Assert(err, jc.ErrorIsNil)
+			rel, err := st.AddRelation(eps...)
+			c.Assert(err, jc.ErrorIsNil)
+			now := st.clock().Now()
+			err = rel.SetStatus(status.StatusInfo{
+				Status: status.Joined,
+				Since:  &now,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "relation status is updated",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.RelationInfo{
+					ModelUUID: st.ModelUUID(),
+					Key:       "logging:logging-directory wordpress:logging-dir",
+					ID:        rel.Id(),
+					Life:      life.Alive,
+					Status: multiwatcher.StatusInfo{
+						Current: status.Joining,
+					},
+				}},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(fmt.Sprintf("r#%d", rel.Id())),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.RelationInfo{
+						ModelUUID: st.ModelUUID(),
+						Key:       "logging:logging-directory wordpress:logging-dir",
+						ID:        rel.Id(),
+						Life:      life.Alive,
+						Status: multiwatcher.StatusInfo{
+							Current: status.Joined,
+							Data:    map[string]interface{}{},
+							Since:   &now,
+						},
+					}}}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
+type allWatcherLeaderToken struct{}
+
+func (allWatcherLeaderToken) Check() error {
+	return nil
+}
+
+func testChangeSecrets(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []changeTestFunc)) {
+	addSecret := func(c *gc.C, st *State) (*secrets.URI, *Application) {
+		app := AddTestingApplication(c, st, "mysql", AddTestingCharm(c, st, "mysql"))
+		uri := secrets.NewURI()
+		_, err := NewSecrets(st).CreateSecret(uri, CreateSecretParams{
+			Version: 1,
+			Owner:   app.Tag(),
+			UpdateSecretParams: UpdateSecretParams{
+				LeaderToken: allWatcherLeaderToken{},
+				Label:       ptr("password"),
+				Data:        map[string]string{"foo": "bar"},
+			},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		return uri, app
+	}
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no secret in state, no secret in store -> do nothing",
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID("cbd7tgkl6r0mesk3f5j0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "secret is removed if it's not in backing",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.SecretInfo{
+					ModelUUID: st.ModelUUID(),
+					ID:        "cbd7tgkl6r0mesk3f5j0",
+				}},
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID("cbd7tgkl6r0mesk3f5j0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			uri, app := addSecret(c, st)
+			return changeTestCase{
+				about: "secret is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  "secretMetadata",
+					Id: st.docID(uri.ID),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID:      st.ModelUUID(),
+						ID:             uri.ID,
+						URI:            uri.String(),
+						Label:          "password",
+						OwnerTag:       app.Tag().String(),
+						LatestRevision: 1,
+					}}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			uri, app := addSecret(c, st)
+			consumer := names.NewUnitTag("wordpress/0")
+			err := st.SaveSecretConsumer(uri, consumer, &secrets.SecretConsumerMetadata{
+				CurrentRevision: 1,
+				LatestRevision:  1,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "secret consumer revision is recorded on the secret",
+				initialContents: []multiwatcher.EntityInfo{&multiwatcher.SecretInfo{
+					ModelUUID:      st.ModelUUID(),
+					ID:             uri.ID,
+					URI:            uri.String(),
+					OwnerTag:       app.Tag().String(),
+					LatestRevision: 1,
+				}},
+				change: watcher.Change{
+					C:  "secretConsumers",
+					Id: st.docID(uri.ID + "#" + consumer.String()),
+				},
+				expectContents: []multiwatcher.EntityInfo{
+					&multiwatcher.SecretInfo{
+						ModelUUID:      st.ModelUUID(),
+						ID:             uri.ID,
+						URI:            uri.String(),
+						OwnerTag:       app.Tag().String(),
+						LatestRevision: 1,
+						Consumers:      map[string]int{consumer.String(): 1},
 					}}}
 		},
 	}
@@ -21,6 +21,7 @@ const (
 	filesBundle  = "root.tar"
 	dbDumpDir    = "dump"
 	metadataFile = "metadata.json"
+	controllerDB = "controller-db.json"
 )
 
 var legacyVersion = version.Number{Major: 1, Minor: 20}
@@ -46,6 +47,10 @@ type ArchivePaths struct {
 
 	// MetadataFile is the path to the metadata file.
 	MetadataFile string
+
+	// ControllerDBFile is the path to the file containing the dump of
+	// the controller's dqlite database.
+	ControllerDBFile string
 }
 
 // NewCanonicalArchivePaths composes a new ArchivePaths with default
@@ -54,10 +59,11 @@ type ArchivePaths struct {
 // resolving the paths in a backup archive file (which is a tar file).
 func NewCanonicalArchivePaths() ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   contentDir,
-		FilesBundle:  path.Join(contentDir, filesBundle),
-		DBDumpDir:    path.Join(contentDir, dbDumpDir),
-		MetadataFile: path.Join(contentDir, metadataFile),
+		ContentDir:       contentDir,
+		FilesBundle:      path.Join(contentDir, filesBundle),
+		DBDumpDir:        path.Join(contentDir, dbDumpDir),
+		MetadataFile:     path.Join(contentDir, metadataFile),
+		ControllerDBFile: path.Join(contentDir, controllerDB),
 	}
 }
 
@@ -68,10 +74,11 @@ func NewCanonicalArchivePaths() ArchivePaths {
 // been unpacked.
 func NewNonCanonicalArchivePaths(rootDir string) ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   filepath.Join(rootDir, contentDir),
-		FilesBundle:  filepath.Join(rootDir, contentDir, filesBundle),
-		DBDumpDir:    filepath.Join(rootDir, contentDir, dbDumpDir),
-		MetadataFile: filepath.Join(rootDir, contentDir, metadataFile),
+		ContentDir:       filepath.Join(rootDir, contentDir),
+		FilesBundle:      filepath.Join(rootDir, contentDir, filesBundle),
+		DBDumpDir:        filepath.Join(rootDir, contentDir, dbDumpDir),
+		MetadataFile:     filepath.Join(rootDir, contentDir, metadataFile),
+		ControllerDBFile: filepath.Join(rootDir, contentDir, controllerDB),
 	}
 }
 
@@ -82,8 +89,8 @@ type ArchiveWorkspace struct {
 	RootDir string
 }
 
-func newArchiveWorkspace() (*ArchiveWorkspace, error) {
-	rootdir, err := os.MkdirTemp("", "juju-backups-")
+func newArchiveWorkspace(dir string) (*ArchiveWorkspace, error) {
+	rootdir, err := os.MkdirTemp(dir, "juju-backups-")
 	if err != nil {
 		return nil, errors.Annotate(err, "while creating workspace dir")
 	}
@@ -101,7 +108,14 @@ func newArchiveWorkspace() (*ArchiveWorkspace, error) {
 // "temporary" directory. For relatively large archives this could have
 // adverse effects on hosts with little disk space.
 func NewArchiveWorkspaceReader(archive io.Reader) (*ArchiveWorkspace, error) {
-	ws, err := newArchiveWorkspace()
+	return newArchiveWorkspaceReader("", archive)
+}
+
+// newArchiveWorkspaceReader unpacks the archive into a new workspace
+// directory created within dir, or the host's temporary directory if
+// dir is empty.
+func newArchiveWorkspaceReader(dir string, archive io.Reader) (*ArchiveWorkspace, error) {
+	ws, err := newArchiveWorkspace(dir)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
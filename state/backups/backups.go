@@ -4,6 +4,7 @@
 package backups
 
 import (
+	"compress/gzip"
 	"fmt"
 	"io"
 	"io/fs"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/v3/du"
+	"github.com/juju/utils/v3/tar"
 )
 
 const (
@@ -31,6 +33,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getDBRestorer    = NewDBRestorer
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -52,6 +55,28 @@ type Backups interface {
 
 	// Get returns the metadata and specified archive file.
 	Get(fileName string) (*Metadata, io.ReadCloser, error)
+
+	// Add stores the archive on the machine, returning its file name.
+	Add(archive io.Reader) (string, error)
+
+	// List returns the backup archives stored on the machine.
+	List() ([]StoredBackup, error)
+
+	// Remove deletes the specified archive file.
+	Remove(fileName string) error
+
+	// Restore loads the databases in the specified archive file onto
+	// the controller, returning the metadata of the archive.
+	Restore(fileName string, args RestoreArgs) (*Metadata, error)
+}
+
+// StoredBackup describes a backup archive stored on the machine.
+type StoredBackup struct {
+	// Filename is the path to the archive file.
+	Filename string
+
+	// Metadata is read from the archive file.
+	Metadata *Metadata
 }
 
 type backups struct {
@@ -156,6 +181,7 @@ func (b *backups) Create(meta *Metadata, dbInfo *DBInfo) (string, error) {
 		destinationDir: destinationDir,
 		filesToBackUp:  filesToBackUp,
 		db:             dumper,
+		controllerDB:   dbInfo.ControllerDB,
 		metadataReader: metadataFile,
 	}
 	result, err := runCreate(&args)
@@ -231,3 +257,240 @@ func (b *backups) Get(fileName string) (_ *Metadata, _ io.ReadCloser, err error)
 
 	return meta, readCloser, nil
 }
+
+// Add writes the archive to a new file in the backup directory, so that
+// a backup taken elsewhere can be restored. The archive must contain
+// backup metadata.
+func (b *backups) Add(archive io.Reader) (_ string, err error) {
+	file, err := os.CreateTemp(b.paths.BackupDir, FilenamePrefix+"upload-*.tar.gz")
+	if err != nil {
+		return "", errors.Annotate(err, "while creating archive file")
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err2 := os.Remove(file.Name()); err2 != nil && !os.IsNotExist(err2) {
+			logger.Errorf("error removing backup archive: %v", err2)
+		}
+	}()
+
+	if _, err := io.Copy(file, archive); err != nil {
+		_ = file.Close()
+		return "", errors.Annotate(err, "while writing archive file")
+	}
+	if err := file.Close(); err != nil {
+		return "", errors.Annotate(err, "while writing archive file")
+	}
+	if _, err := readArchiveMetadata(file.Name()); err != nil {
+		return "", errors.Annotate(err, "while reading archive metadata")
+	}
+	return file.Name(), nil
+}
+
+// List returns the backup archives in the backup directory, along with
+// the metadata stored in each of them. Archives which can't be read are
+// logged and skipped.
+func (b *backups) List() ([]StoredBackup, error) {
+	entries, err := os.ReadDir(b.paths.BackupDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading backup directory")
+	}
+
+	var result []StoredBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, FilenamePrefix) || !strings.HasSuffix(name, ".tar.gz") {
+			continue
+		}
+		fileName := filepath.Join(b.paths.BackupDir, name)
+		meta, err := readArchiveMetadata(fileName)
+		if err != nil {
+			logger.Warningf("skipping backup archive %q: %v", fileName, err)
+			continue
+		}
+		result = append(result, StoredBackup{
+			Filename: fileName,
+			Metadata: meta,
+		})
+	}
+	return result, nil
+}
+
+// readArchiveMetadata returns the metadata stored in the archive file,
+// updated with the size and timestamp of the file. The archive is read
+// only as far as the metadata file, so that large archives can be
+// listed cheaply.
+func readArchiveMetadata(fileName string) (*Metadata, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	gzr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errors.Annotate(err, "while uncompressing archive file")
+	}
+	defer func() { _ = gzr.Close() }()
+
+	_, metaFile, err := tar.FindFile(gzr, NewCanonicalArchivePaths().MetadataFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := NewMetadataJSONReader(metaFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := meta.SetFileInfo(fi.Size(), "", ""); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if meta.Finished == nil {
+		finished := fileTimestamp(fi)
+		meta.Finished = &finished
+	}
+	return meta, nil
+}
+
+// Remove deletes the archive file from the backup directory.
+func (b *backups) Remove(fileName string) error {
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !valid {
+		return errors.NotFoundf("backup file %q", fileName)
+	}
+	if err := os.Remove(fileName); err != nil {
+		return errors.Annotate(err, "while removing backup archive")
+	}
+	return nil
+}
+
+// Restore validates the archive against the target controller, and then
+// replaces the controller's dqlite and mongo databases with the dumps in
+// the archive. The files bundled in the archive are not restored; they
+// belong to the controller machine the backup was taken from, which is
+// the only machine it can be restored to.
+//
+// The current databases are dumped before anything is changed, and are
+// restored if the restore fails part way. If that fails too, the restore
+// is left marked as incomplete, and further restores are refused.
+func (b *backups) Restore(fileName string, args RestoreArgs) (*Metadata, error) {
+	valid, err := isValidFilepath(b.paths.BackupDir, fileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !valid {
+		return nil, errors.NotFoundf("backup file %q", fileName)
+	}
+	if args.DBInfo == nil || args.DBInfo.ControllerDB == nil {
+		return nil, errors.NotValidf("restore without a controller database")
+	}
+
+	markerFile := filepath.Join(b.paths.BackupDir, restoreMarkerFile)
+	if rollbackDir, err := os.ReadFile(markerFile); err == nil {
+		return nil, errors.Errorf("a previous restore did not complete and could not be rolled back; "+
+			"the databases as they were before it started were dumped to %q. "+
+			"Restore them, then remove %q", strings.TrimSpace(string(rollbackDir)), markerFile)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+
+	restorer, err := getDBRestorer(args.DBInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "while preparing for DB restore")
+	}
+	dumper, err := getDBDumper(args.DBInfo)
+	if err != nil {
+		return nil, errors.Annotate(err, "while preparing for DB restore")
+	}
+
+	archive, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Annotate(err, "while opening archive file")
+	}
+	defer func() { _ = archive.Close() }()
+
+	// The archive is unpacked alongside it, rather than in the host's
+	// temporary directory, to avoid running out of space. The snap
+	// can only see its own private temporary directory.
+	workspaceDir := b.paths.BackupDir
+	if restorer.IsSnap() && workspaceDir == os.TempDir() {
+		workspaceDir = filepath.Join(snapTmpDir, workspaceDir)
+	}
+	ws, err := newArchiveWorkspaceReader(workspaceDir, archive)
+	if ws != nil {
+		defer func() {
+			if err := ws.Close(); err != nil {
+				logger.Errorf("error removing backup workspace: %v", err)
+			}
+		}()
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "while unpacking archive file")
+	}
+
+	meta, err := ws.Metadata()
+	if err != nil {
+		return nil, errors.Annotate(err, "while reading archive metadata")
+	}
+	if err := ValidateRestore(meta, args.Target); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := os.Stat(ws.ControllerDBFile); os.IsNotExist(err) {
+		return nil, errors.NotSupportedf("restoring a backup without a controller database dump")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Dump the current databases, so that a restore which fails part
+	// way can be rolled back.
+	rollbackDir, err := os.MkdirTemp(workspaceDir, restoreRollbackPrefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rollback := newRestoreDumps(rollbackDir)
+	removeRollback := func() {
+		if err := os.RemoveAll(rollbackDir); err != nil {
+			logger.Errorf("error removing restore rollback dumps: %v", err)
+		}
+	}
+	logger.Infof("dumping current databases to %q", rollbackDir)
+	if err := rollback.dump(dumper, args.DBInfo.ControllerDB); err != nil {
+		removeRollback()
+		return nil, errors.Annotate(err, "while dumping current databases")
+	}
+	if err := os.WriteFile(markerFile, []byte(rollbackDir+"\n"), 0600); err != nil {
+		removeRollback()
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("restoring databases from %q", fileName)
+	backup := restoreDumps{controllerDBFile: ws.ControllerDBFile, dbDumpDir: ws.DBDumpDir}
+	changed, err := backup.restore(restorer, args.DBInfo.ControllerDB)
+	if err != nil && changed {
+		logger.Errorf("restoring %q failed, rolling back: %v", fileName, err)
+		if _, rollbackErr := rollback.restore(restorer, args.DBInfo.ControllerDB); rollbackErr != nil {
+			// The marker file is left in place, along with the dumps
+			// needed to restore the databases by hand.
+			return nil, errors.Errorf("restoring backup failed: %v; rolling back failed: %v; "+
+				"the databases as they were before the restore were dumped to %q", err, rollbackErr, rollbackDir)
+		}
+		err = errors.Annotate(err, "restore rolled back")
+	}
+	removeRollback()
+	if rmErr := os.Remove(markerFile); rmErr != nil {
+		logger.Errorf("error removing restore marker: %v", rmErr)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
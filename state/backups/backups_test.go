@@ -230,3 +230,88 @@ func (s *backupsSuite) TestGetFileName(c *gc.C) {
 	_, err = os.Stat(backupFilename)
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("stat %s: no such file or directory", backupFilename))
 }
+
+func (s *backupsSuite) writeArchive(c *gc.C, name string, meta *backups.Metadata) string {
+	archive, err := backupstesting.NewArchive(meta, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(s.paths.BackupDir, name)
+	err = os.WriteFile(fileName, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return fileName
+}
+
+func (s *backupsSuite) TestList(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Notes = "some notes"
+	meta.Controller.UUID = "controller-uuid"
+	fileName := s.writeArchive(c, "juju-backup-20240101-000000.tar.gz", meta)
+
+	// Files which aren't backup archives are ignored, and archives
+	// which can't be read are skipped.
+	err := os.WriteFile(filepath.Join(s.paths.BackupDir, "notes.txt"), []byte("notes"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.WriteFile(filepath.Join(s.paths.BackupDir, "juju-backup-20240102-000000.tar.gz"), []byte("junk"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, gc.HasLen, 1)
+	c.Check(stored[0].Filename, gc.Equals, fileName)
+
+	fi, err := os.Stat(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored[0].Metadata.Size(), gc.Equals, fi.Size())
+	c.Check(stored[0].Metadata.Notes, gc.Equals, "some notes")
+	c.Check(stored[0].Metadata.Controller.UUID, gc.Equals, "controller-uuid")
+	c.Check(stored[0].Metadata.Started.Equal(meta.Started), jc.IsTrue)
+	c.Check(stored[0].Metadata.Finished, gc.NotNil)
+}
+
+func (s *backupsSuite) TestListEmpty(c *gc.C) {
+	stored, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRemove(c *gc.C) {
+	fileName := s.writeArchive(c, "juju-backup-20240101-000000.tar.gz", backupstesting.NewMetadataStarted())
+
+	err := s.api.Remove(fileName)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(fileName)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+
+	err = s.api.Remove(fileName)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *backupsSuite) TestRemoveOutsideBackupDir(c *gc.C) {
+	err := s.api.Remove("/etc/hostname")
+	c.Check(err, gc.ErrorMatches, `backup file "/etc/hostname" not found`)
+}
+
+func (s *backupsSuite) TestAdd(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Notes = "uploaded"
+	archive, err := backupstesting.NewArchive(meta, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fileName, err := s.api.Add(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(filepath.Dir(fileName), gc.Equals, s.paths.BackupDir)
+
+	stored, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, gc.HasLen, 1)
+	c.Check(stored[0].Filename, gc.Equals, fileName)
+	c.Check(stored[0].Metadata.Notes, gc.Equals, "uploaded")
+}
+
+func (s *backupsSuite) TestAddInvalidArchive(c *gc.C) {
+	_, err := s.api.Add(bytes.NewBufferString("junk"))
+	c.Assert(err, gc.ErrorMatches, "while reading archive metadata: while uncompressing archive file: .*")
+
+	entries, err := os.ReadDir(s.paths.BackupDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+}
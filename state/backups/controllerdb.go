@@ -0,0 +1,324 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ControllerDB runs transactions against the controller's dqlite
+// database. It is satisfied by a database.TrackedDB.
+type ControllerDB interface {
+	// TxnNoRetry executes the input function within a transaction,
+	// without retrying on failure.
+	TxnNoRetry(context.Context, func(context.Context, *sql.Tx) error) error
+}
+
+const (
+	// schemaVersionTable records the schema patches applied to the
+	// database. It isn't restored, the schema of the target database
+	// must match the backup instead.
+	schemaVersionTable = "schema_version"
+
+	// changeLogTable is populated by triggers as other tables are
+	// changed, so it is restored after all of the other tables.
+	changeLogTable = "change_log"
+)
+
+// controllerDBDump is the serialised form of the controller database
+// in a backup archive.
+type controllerDBDump struct {
+	// SchemaVersions maps each applied schema patch version to the
+	// checksum of the patch.
+	SchemaVersions map[int]string `json:"schema-versions,omitempty"`
+
+	// Tables holds the contents of each table in the database.
+	Tables []controllerDBTable `json:"tables"`
+}
+
+// controllerDBTable holds the contents of a single table.
+type controllerDBTable struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// controllerDBBlob holds a BLOB value in a dumped row. BLOBs are wrapped
+// so that they are restored as bytes, rather than as base64 text.
+type controllerDBBlob struct {
+	Blob []byte `json:"blob"`
+}
+
+// dumpControllerDB writes the contents of every table in the controller
+// database to the writer, within a single transaction.
+func dumpControllerDB(ctx context.Context, db ControllerDB, w io.Writer) error {
+	var dump controllerDBDump
+	err := db.TxnNoRetry(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		dump = controllerDBDump{}
+
+		tables, err := controllerDBTables(ctx, tx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, table := range tables {
+			if table == schemaVersionTable {
+				if dump.SchemaVersions, err = controllerDBSchemaVersions(ctx, tx); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			contents, err := dumpControllerDBTable(ctx, tx, table)
+			if err != nil {
+				return errors.Annotatef(err, "dumping table %q", table)
+			}
+			dump.Tables = append(dump.Tables, contents)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.NewEncoder(w).Encode(dump))
+}
+
+func dumpControllerDBTable(ctx context.Context, tx *sql.Tx, table string) (controllerDBTable, error) {
+	result := controllerDBTable{
+		Name: table,
+		Rows: [][]interface{}{},
+	}
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s", quoteIdentifier(table)))
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	if result.Columns, err = rows.Columns(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for rows.Next() {
+		row := make([]interface{}, len(result.Columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return result, errors.Trace(err)
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = controllerDBBlob{Blob: b}
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, errors.Trace(rows.Err())
+}
+
+// restoreControllerDB replaces the contents of the controller database
+// with the dump read from the reader, within a single transaction. The
+// schema of the database must match the schema recorded in the dump.
+func restoreControllerDB(ctx context.Context, db ControllerDB, r io.Reader) error {
+	var dump controllerDBDump
+	decoder := json.NewDecoder(r)
+	// Integers must survive the round trip without becoming floats.
+	decoder.UseNumber()
+	if err := decoder.Decode(&dump); err != nil {
+		return errors.Annotate(err, "reading controller database dump")
+	}
+
+	// The change log is written to by triggers as the other tables are
+	// restored, so it must be cleared and restored last.
+	var tables []controllerDBTable
+	var changeLog *controllerDBTable
+	for i, table := range dump.Tables {
+		if table.Name == changeLogTable {
+			changeLog = &dump.Tables[i]
+			continue
+		}
+		tables = append(tables, table)
+	}
+	if changeLog != nil {
+		tables = append(tables, *changeLog)
+	}
+
+	return db.TxnNoRetry(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		existing, err := controllerDBTables(ctx, tx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		known := make(map[string]bool)
+		var schemaVersions map[int]string
+		for _, table := range existing {
+			known[table] = true
+			if table == schemaVersionTable {
+				if schemaVersions, err = controllerDBSchemaVersions(ctx, tx); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		if err := checkSchemaVersions(dump.SchemaVersions, schemaVersions); err != nil {
+			return errors.Trace(err)
+		}
+
+		// Foreign keys are checked when the transaction is committed,
+		// so that tables can be restored in any order.
+		if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+			return errors.Trace(err)
+		}
+		for _, table := range tables {
+			if !known[table.Name] {
+				return errors.NotValidf("backup of unknown table %q", table.Name)
+			}
+		}
+		for _, table := range tables {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", quoteIdentifier(table.Name))); err != nil {
+				return errors.Annotatef(err, "clearing table %q", table.Name)
+			}
+		}
+		for _, table := range tables {
+			// Clearing the change log again straight before it is
+			// restored removes the entries written by the triggers
+			// as the other tables were cleared and restored.
+			if table.Name == changeLogTable {
+				if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", quoteIdentifier(table.Name))); err != nil {
+					return errors.Annotatef(err, "clearing table %q", table.Name)
+				}
+			}
+			if err := restoreControllerDBTable(ctx, tx, table); err != nil {
+				return errors.Annotatef(err, "restoring table %q", table.Name)
+			}
+		}
+		return nil
+	})
+}
+
+func restoreControllerDBTable(ctx context.Context, tx *sql.Tx, table controllerDBTable) error {
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(table.Columns))
+	placeholders := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		columns[i] = quoteIdentifier(column)
+		placeholders[i] = "?"
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(table.Name), strings.Join(columns, ", "), strings.Join(placeholders, ", ")))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, row := range table.Rows {
+		if len(row) != len(table.Columns) {
+			return errors.NotValidf("row with %d values for %d columns", len(row), len(table.Columns))
+		}
+		args := make([]interface{}, len(row))
+		for i, v := range row {
+			if args[i], err = controllerDBValue(v); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// controllerDBValue converts numbers decoded from the dump back to
+// integers where possible, and BLOBs back to bytes.
+func controllerDBValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, errors.NotValidf("number %q", v)
+		}
+		return f, nil
+	case map[string]interface{}:
+		encoded, ok := v["blob"].(string)
+		if !ok || len(v) != 1 {
+			return nil, errors.NotValidf("value %v", v)
+		}
+		b, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.NotValidf("blob encoding")
+		}
+		return b, nil
+	}
+	return v, nil
+}
+
+// checkSchemaVersions returns an error if the schema of the backup
+// doesn't match the schema of the database it is being restored to.
+func checkSchemaVersions(backup, target map[int]string) error {
+	if len(backup) != len(target) {
+		return errors.Errorf("backup has %d schema patches applied, controller has %d",
+			len(backup), len(target))
+	}
+	for version, checksum := range backup {
+		if target[version] != checksum {
+			return errors.Errorf("backup schema patch %d does not match the controller", version)
+		}
+	}
+	return nil
+}
+
+func controllerDBTables(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+SELECT name FROM sqlite_master
+WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+ORDER BY name`)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Trace(err)
+		}
+		tables = append(tables, name)
+	}
+	return tables, errors.Trace(rows.Err())
+}
+
+func controllerDBSchemaVersions(ctx context.Context, tx *sql.Tx) (map[int]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT version, checksum FROM schema_version")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	versions := make(map[int]string)
+	for rows.Next() {
+		var (
+			version  int
+			checksum string
+		)
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, errors.Trace(err)
+		}
+		versions[version] = checksum
+	}
+	return versions, errors.Trace(rows.Err())
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,304 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	databasetesting "github.com/juju/juju/database/testing"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type controllerDBSuite struct {
+	databasetesting.ControllerSuite
+}
+
+var _ = gc.Suite(&controllerDBSuite{})
+
+func (s *controllerDBSuite) addExternalController(c *gc.C, uuid, alias string) {
+	_, err := s.DB().Exec(`INSERT INTO external_controller (uuid, alias, ca_cert_uuid) VALUES (?, ?, 'cert')`, uuid, alias)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.DB().Exec(`INSERT INTO external_model (uuid, controller_uuid) VALUES (?, ?)`, uuid+"-model", uuid)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *controllerDBSuite) query(c *gc.C, query string) [][]string {
+	rows, err := s.DB().Query(query)
+	c.Assert(err, jc.ErrorIsNil)
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	c.Assert(err, jc.ErrorIsNil)
+	var result [][]string
+	for rows.Next() {
+		row := make([]string, len(columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		c.Assert(rows.Scan(dest...), jc.ErrorIsNil)
+		result = append(result, row)
+	}
+	c.Assert(rows.Err(), jc.ErrorIsNil)
+	return result
+}
+
+func (s *controllerDBSuite) TestDumpRestore(c *gc.C) {
+	s.addExternalController(c, "ctrl-1", "one")
+
+	var dump bytes.Buffer
+	err := backups.DumpControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllers := s.query(c, `SELECT * FROM external_controller`)
+	models := s.query(c, `SELECT * FROM external_model`)
+	changes := s.query(c, `SELECT * FROM change_log ORDER BY id`)
+	c.Assert(changes, gc.HasLen, 1)
+
+	// Change the database, so that restoring has something to undo.
+	_, err = s.DB().Exec(`DELETE FROM external_model`)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addExternalController(c, "ctrl-2", "two")
+
+	err = backups.RestoreControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.query(c, `SELECT * FROM external_controller`), jc.DeepEquals, controllers)
+	c.Check(s.query(c, `SELECT * FROM external_model`), jc.DeepEquals, models)
+	// The change log entries written by the triggers during the restore
+	// are replaced by those in the dump.
+	c.Check(s.query(c, `SELECT * FROM change_log ORDER BY id`), jc.DeepEquals, changes)
+}
+
+func (s *controllerDBSuite) TestRestoreSchemaMismatch(c *gc.C) {
+	var dump bytes.Buffer
+	err := backups.DumpControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.DB().Exec(`
+CREATE TABLE schema_version (version INT PRIMARY KEY, name TEXT, checksum TEXT);
+INSERT INTO schema_version VALUES (1, 'lease', 'abc');`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = backups.RestoreControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, gc.ErrorMatches, `backup has 0 schema patches applied, controller has 1`)
+}
+
+func (s *controllerDBSuite) TestRestoreUnknownTable(c *gc.C) {
+	dump := strings.NewReader(`{"tables":[{"name":"bogus","columns":["id"],"rows":[[1]]}]}`)
+	err := backups.RestoreControllerDB(context.Background(), s.TrackedDB(), dump)
+	c.Assert(err, gc.ErrorMatches, `backup of unknown table "bogus" not valid`)
+}
+
+func (s *controllerDBSuite) TestRestoreRollsBack(c *gc.C) {
+	s.addExternalController(c, "ctrl-1", "one")
+	controllers := s.query(c, `SELECT * FROM external_controller`)
+
+	dump := strings.NewReader(`{"tables":[
+{"name":"external_controller","columns":["uuid","alias","ca_cert_uuid"],"rows":[["ctrl-2","two"]]}
+]}`)
+	err := backups.RestoreControllerDB(context.Background(), s.TrackedDB(), dump)
+	c.Assert(err, gc.ErrorMatches, `restoring table "external_controller": row with 2 values for 3 columns not valid`)
+
+	c.Check(s.query(c, `SELECT * FROM external_controller`), jc.DeepEquals, controllers)
+}
+
+type fakeRestorer struct {
+	dumpDirs []string
+	errs     []error
+}
+
+func (r *fakeRestorer) Restore(dumpDir string) error {
+	r.dumpDirs = append(r.dumpDirs, dumpDir)
+	if len(r.errs) == 0 {
+		return nil
+	}
+	err := r.errs[0]
+	r.errs = r.errs[1:]
+	return err
+}
+
+func (*fakeRestorer) IsSnap() bool {
+	return false
+}
+
+func (s *controllerDBSuite) TestDumpRestoreBlob(c *gc.C) {
+	_, err := s.DB().Exec(`CREATE TABLE blob_test (id INT PRIMARY KEY, data BLOB, name TEXT)`)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.DB().Exec(`INSERT INTO blob_test VALUES (1, ?, 'one')`, []byte{0, 1, 0xff})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var dump bytes.Buffer
+	err = backups.DumpControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.DB().Exec(`DELETE FROM blob_test`)
+	c.Assert(err, jc.ErrorIsNil)
+	err = backups.RestoreControllerDB(context.Background(), s.TrackedDB(), &dump)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var (
+		data     []byte
+		dataType string
+		name     string
+		nameType string
+	)
+	err = s.DB().QueryRow(`SELECT data, typeof(data), name, typeof(name) FROM blob_test`).Scan(&data, &dataType, &name, &nameType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, jc.DeepEquals, []byte{0, 1, 0xff})
+	c.Check(dataType, gc.Equals, "blob")
+	c.Check(name, gc.Equals, "one")
+	c.Check(nameType, gc.Equals, "text")
+}
+
+func (s *controllerDBSuite) patchCreate(c *gc.C, restorer *fakeRestorer) (*backups.Paths, *backups.DBInfo) {
+	s.PatchValue(backups.AvailableDisk, func(string) uint64 { return 100 * 1024 })
+	s.PatchValue(backups.TotalDisk, func(string) uint64 { return 100 * 1024 })
+	dataDir := c.MkDir()
+	err := os.WriteFile(filepath.Join(dataDir, "agent.conf"), []byte("agent"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(string, *backups.Paths) ([]string, error) {
+		return []string{filepath.Join(dataDir, "agent.conf")}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	s.PatchValue(backups.GetDBRestorer, func(*backups.DBInfo) (backups.DBRestorer, error) {
+		return restorer, nil
+	})
+	return &backups.Paths{BackupDir: c.MkDir(), DataDir: dataDir}, &backups.DBInfo{ControllerDB: s.TrackedDB()}
+}
+
+func (s *controllerDBSuite) create(c *gc.C, api backups.Backups, dbInfo *backups.DBInfo) (string, backups.RestoreTarget) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Controller.UUID = restoreControllerUUID
+	meta.Controller.MachineID = "0"
+	meta.Controller.MachineInstanceID = "inst-0"
+	fileName, err := api.Create(meta, dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	return fileName, backups.RestoreTarget{
+		ControllerUUID:  restoreControllerUUID,
+		Version:         meta.Origin.Version,
+		ControllerNodes: 1,
+		MachineID:       "0",
+		InstanceID:      "inst-0",
+	}
+}
+
+func (s *controllerDBSuite) TestCreateAndRestore(c *gc.C) {
+	restorer := &fakeRestorer{}
+	paths, dbInfo := s.patchCreate(c, restorer)
+
+	s.addExternalController(c, "ctrl-1", "one")
+	controllers := s.query(c, `SELECT * FROM external_controller`)
+
+	api := backups.NewBackups(paths)
+	fileName, target := s.create(c, api, dbInfo)
+
+	_, err := s.DB().Exec(`DELETE FROM external_model`)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.DB().Exec(`DELETE FROM external_controller`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored, err := api.Restore(fileName, backups.RestoreArgs{DBInfo: dbInfo, Target: target})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restored.Controller.UUID, gc.Equals, restoreControllerUUID)
+	c.Check(s.query(c, `SELECT * FROM external_controller`), jc.DeepEquals, controllers)
+	c.Assert(restorer.dumpDirs, gc.HasLen, 1)
+	c.Check(filepath.Base(restorer.dumpDirs[0]), gc.Equals, "dump")
+
+	// The workspace the archive was unpacked into, and the dumps taken
+	// for rolling back, are removed.
+	entries, err := os.ReadDir(paths.BackupDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 1)
+}
+
+func (s *controllerDBSuite) TestRestoreFailureRollsBack(c *gc.C) {
+	restorer := &fakeRestorer{errs: []error{errors.New("boom")}}
+	paths, dbInfo := s.patchCreate(c, restorer)
+
+	api := backups.NewBackups(paths)
+	fileName, target := s.create(c, api, dbInfo)
+
+	s.addExternalController(c, "ctrl-1", "one")
+	controllers := s.query(c, `SELECT * FROM external_controller`)
+
+	_, err := api.Restore(fileName, backups.RestoreArgs{DBInfo: dbInfo, Target: target})
+	c.Assert(err, gc.ErrorMatches, `restore rolled back: while restoring juju state database: boom`)
+
+	// The controller database restored from the backup is rolled back,
+	// and the mongo dump taken before the restore is restored.
+	c.Check(s.query(c, `SELECT * FROM external_controller`), jc.DeepEquals, controllers)
+	c.Assert(restorer.dumpDirs, gc.HasLen, 2)
+	c.Check(restorer.dumpDirs[1], gc.Matches, `.*/juju-restore-rollback-.*/dump`)
+
+	entries, err := os.ReadDir(paths.BackupDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 1)
+
+	// Nothing stops the backup being restored again.
+	_, err = api.Restore(fileName, backups.RestoreArgs{DBInfo: dbInfo, Target: target})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *controllerDBSuite) TestRestoreRollbackFailure(c *gc.C) {
+	restorer := &fakeRestorer{errs: []error{errors.New("boom"), errors.New("bang")}}
+	paths, dbInfo := s.patchCreate(c, restorer)
+
+	api := backups.NewBackups(paths)
+	fileName, target := s.create(c, api, dbInfo)
+
+	_, err := api.Restore(fileName, backups.RestoreArgs{DBInfo: dbInfo, Target: target})
+	c.Assert(err, gc.ErrorMatches, `restoring backup failed: while restoring juju state database: boom; `+
+		`rolling back failed: while restoring juju state database: bang; `+
+		`the databases as they were before the restore were dumped to ".*/juju-restore-rollback-.*"`)
+
+	// Further restores are refused until the databases are restored
+	// by hand.
+	_, err = api.Restore(fileName, backups.RestoreArgs{DBInfo: dbInfo, Target: target})
+	c.Assert(err, gc.ErrorMatches, `a previous restore did not complete and could not be rolled back; .*`)
+	c.Assert(restorer.dumpDirs, gc.HasLen, 2)
+}
+
+func (s *controllerDBSuite) TestRestoreInvalidTarget(c *gc.C) {
+	s.PatchValue(backups.GetDBRestorer, func(*backups.DBInfo) (backups.DBRestorer, error) {
+		return &fakeRestorer{}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	paths := &backups.Paths{BackupDir: c.MkDir()}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Controller.UUID = restoreControllerUUID
+	meta.Controller.MachineID = "0"
+	meta.Controller.MachineInstanceID = "inst-0"
+	archive, err := backupstesting.NewArchive(meta, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	fileName := filepath.Join(paths.BackupDir, "juju-backup-20240101-000000.tar.gz")
+	err = os.WriteFile(fileName, archive.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.NewBackups(paths).Restore(fileName, backups.RestoreArgs{
+		DBInfo: &backups.DBInfo{ControllerDB: s.TrackedDB()},
+		Target: backups.RestoreTarget{
+			ControllerUUID:  restoreControllerUUID,
+			Version:         version.MustParse("1.2.3"),
+			ControllerNodes: 1,
+			MachineID:       "0",
+			InstanceID:      "inst-0",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `backup taken with juju .* cannot be restored to a controller running juju 1.2.3`)
+}
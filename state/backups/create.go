@@ -5,6 +5,7 @@ package backups
 
 import (
 	"compress/gzip"
+	"context"
 	"crypto/sha1"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ type createArgs struct {
 	destinationDir string
 	filesToBackUp  []string
 	db             DBDumper
+	controllerDB   ControllerDB
 	metadataReader io.Reader
 }
 
@@ -48,6 +50,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.controllerDB = args.controllerDB
 	defer func() {
 		if cerr := builder.cleanUp(err != nil); cerr != nil {
 			cerr.Log(logger)
@@ -99,6 +102,8 @@ type builder struct {
 	filesToBackUp []string
 	// db is the wrapper around the DB dump command and args.
 	db DBDumper
+	// controllerDB is the controller's dqlite database.
+	controllerDB ControllerDB
 	// checksum is the checksum of the archive file.
 	checksum string
 	// archiveFile is the backup archive file.
@@ -304,6 +309,24 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
+func (b *builder) buildControllerDBDump() error {
+	logger.Infof("dumping controller database")
+	if b.controllerDB == nil {
+		logger.Infof("nothing to do")
+		return nil
+	}
+
+	dumpFile, err := os.Create(b.archivePaths.ControllerDBFile)
+	if err != nil {
+		return errors.Annotate(err, "while creating controller database dump file")
+	}
+	if err := dumpControllerDB(context.Background(), b.controllerDB, dumpFile); err != nil {
+		_ = dumpFile.Close()
+		return errors.Annotate(err, "while dumping controller database")
+	}
+	return errors.Trace(dumpFile.Close())
+}
+
 func (b *builder) buildArchive(outFile io.Writer) error {
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
@@ -356,6 +379,11 @@ func (b *builder) buildAll() error {
 		return errors.Trace(err)
 	}
 
+	// Dump the controller database.
+	if err := b.buildControllerDBDump(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Bundle it all into a tarball.
 	if err := b.buildArchiveAndChecksum(); err != nil {
 		return errors.Trace(err)
@@ -42,6 +42,9 @@ type DBInfo struct {
 	Targets set.Strings
 	// ApproxSizeMB is the storage needed to back up the database.
 	ApproxSizeMB int
+	// ControllerDB is the controller's dqlite database, which is backed
+	// up alongside the mongo databases when it is set.
+	ControllerDB ControllerDB
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -117,6 +120,7 @@ func getBackupTargetDatabases(session DBSession) (set.Strings, error) {
 
 const (
 	dumpName       = "mongodump"
+	restoreName    = "mongorestore"
 	snapToolPrefix = "juju-db."
 	snapTmpDir     = "/tmp/snap-private-tmp/snap.juju-db"
 )
@@ -134,6 +138,10 @@ var getMongodumpPath = func() (string, error) {
 	return getMongoToolPath(dumpName, os.Stat, exec.LookPath)
 }
 
+var getMongorestorePath = func() (string, error) {
+	return getMongoToolPath(restoreName, os.Stat, exec.LookPath)
+}
+
 var getMongodPath = func() (string, error) {
 	finder := mongo.NewMongodFinder()
 	path, err := finder.InstalledAt()
@@ -236,6 +244,73 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+// DBRestorer is any type that restores something from a dump dir.
+type DBRestorer interface {
+	// Restore something from dumpDir.
+	Restore(dumpDir string) error
+
+	// IsSnap returns true if we are using the juju-db snap.
+	IsSnap() bool
+}
+
+type mongoRestorer struct {
+	*DBInfo
+	// binPath is the path to the restore executable.
+	binPath string
+}
+
+// NewDBRestorer returns a new value with a Restore method for loading
+// a dump of the juju state database.
+func NewDBRestorer(info *DBInfo) (DBRestorer, error) {
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+
+	restorer := mongoRestorer{
+		DBInfo:  info,
+		binPath: mongorestorePath,
+	}
+	return &restorer, nil
+}
+
+func (mr *mongoRestorer) options(dumpDir string) []string {
+	options := []string{
+		"--ssl",
+		"--tlsInsecure",
+		"--authenticationDatabase", "admin",
+		"--host", mr.Address,
+		"--username", mr.Username,
+		"--password", mr.Password,
+		"--drop",
+		"--oplogReplay",
+		"--dir", dumpDir,
+	}
+	return options
+}
+
+// IsSnap returns true if we are using the juju-db snap.
+func (mr *mongoRestorer) IsSnap() bool {
+	return filepath.Base(mr.binPath) == snapToolPrefix+restoreName
+}
+
+// Restore replaces the juju state-related databases with those in the
+// dump dir. Collections in the dump are dropped before being restored.
+func (mr *mongoRestorer) Restore(dumpDir string) error {
+	logger.Tracef("restoring Mongo database from %q", dumpDir)
+
+	// See the equivalent workaround in mongoDumper.dump.
+	dumpDirArg := dumpDir
+	if mr.IsSnap() && strings.HasPrefix(dumpDirArg, snapTmpDir) {
+		dumpDirArg = strings.TrimPrefix(dumpDirArg, snapTmpDir)
+	}
+
+	if err := runCommandFn(mr.binPath, mr.options(dumpDirArg)...); err != nil {
+		return errors.Annotate(err, "error restoring databases")
+	}
+	return nil
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -11,11 +11,14 @@ import (
 )
 
 var (
-	Create        = create
-	FileTimestamp = fileTimestamp
+	Create              = create
+	FileTimestamp       = fileTimestamp
+	DumpControllerDB    = dumpControllerDB
+	RestoreControllerDB = restoreControllerDB
 
 	TestGetFilesToBackUp = &getFilesToBackUp
 	GetDBDumper          = &getDBDumper
+	GetDBRestorer        = &getDBRestorer
 	RunCreate            = &runCreate
 	FinishMeta           = &finishMeta
 	GetMongodumpPath     = &getMongodumpPath
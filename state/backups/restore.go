@@ -0,0 +1,144 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+)
+
+const (
+	// restoreMarkerFile is written to the backup directory while the
+	// databases are being restored, and left there if a failed restore
+	// could not be rolled back.
+	restoreMarkerFile = "juju-restore-in-progress"
+
+	// restoreRollbackPrefix is the prefix of the directory in which the
+	// databases are dumped before they are restored.
+	restoreRollbackPrefix = "juju-restore-rollback-"
+)
+
+// RestoreTarget describes the controller that a backup is being
+// restored to.
+type RestoreTarget struct {
+	// ControllerUUID is the UUID of the controller.
+	ControllerUUID string
+
+	// Version is the version of juju running on the controller.
+	Version version.Number
+
+	// ControllerNodes is the number of nodes in the controller.
+	ControllerNodes int
+
+	// MachineID is the ID of the controller machine.
+	MachineID string
+
+	// InstanceID is the instance ID of the controller machine.
+	InstanceID string
+}
+
+// RestoreArgs holds the information needed to restore a backup.
+type RestoreArgs struct {
+	// DBInfo is used to connect to the databases being restored.
+	DBInfo *DBInfo
+
+	// Target describes the controller being restored to.
+	Target RestoreTarget
+}
+
+// ValidateRestore checks that the backup described by the metadata can
+// be restored to the target controller. Backups can only be restored to
+// the controller machine they were taken from, which must be the only
+// controller node, running the same juju version. The agent credentials,
+// instance ID and addresses recorded in the backup are then those of the
+// machine being restored to.
+func ValidateRestore(meta *Metadata, target RestoreTarget) error {
+	if meta.FormatVersion != currentFormatVersion {
+		return errors.NotSupportedf("restoring backup format %d", meta.FormatVersion)
+	}
+
+	controllerUUID := meta.Controller.UUID
+	if controllerUUID == "" || controllerUUID == UnknownString {
+		return errors.NotValidf("backup without a controller UUID")
+	}
+	if controllerUUID != target.ControllerUUID {
+		return errors.Errorf("backup of controller %q cannot be restored to controller %q",
+			controllerUUID, target.ControllerUUID)
+	}
+
+	machineID, instanceID := meta.Controller.MachineID, meta.Controller.MachineInstanceID
+	if machineID == "" || machineID == UnknownString || instanceID == "" || instanceID == UnknownString {
+		return errors.NotValidf("backup without a controller machine")
+	}
+	if machineID != target.MachineID || instanceID != target.InstanceID {
+		return errors.Errorf("backup of machine %s (instance %q) cannot be restored to machine %s (instance %q); "+
+			"backups can only be restored to the machine they were taken from",
+			machineID, instanceID, target.MachineID, target.InstanceID)
+	}
+
+	if backupVersion := meta.Origin.Version.ToPatch(); backupVersion != target.Version.ToPatch() {
+		return errors.Errorf("backup taken with juju %s cannot be restored to a controller running juju %s",
+			backupVersion, target.Version.ToPatch())
+	}
+
+	if target.ControllerNodes > 1 {
+		return errors.Errorf("cannot restore to a controller with %d nodes; "+
+			"remove the other controller nodes and enable HA again afterwards", target.ControllerNodes)
+	}
+	return nil
+}
+
+// restoreDumps holds the dumps of the dqlite and mongo databases
+// restored from.
+type restoreDumps struct {
+	controllerDBFile string
+	dbDumpDir        string
+}
+
+func newRestoreDumps(dir string) restoreDumps {
+	return restoreDumps{
+		controllerDBFile: filepath.Join(dir, "controller.json"),
+		dbDumpDir:        filepath.Join(dir, "dump"),
+	}
+}
+
+// dump writes the current dqlite and mongo databases to the dumps.
+func (d restoreDumps) dump(dumper DBDumper, controllerDB ControllerDB) error {
+	f, err := os.OpenFile(d.controllerDBFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := dumpControllerDB(context.Background(), controllerDB, f); err != nil {
+		_ = f.Close()
+		return errors.Annotate(err, "dumping controller database")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(dumper.Dump(d.dbDumpDir), "dumping juju state database")
+}
+
+// restore replaces the dqlite and mongo databases with the dumps. The
+// dqlite database is restored first, in a single transaction, and is
+// checked against the schema of the controller before anything is
+// changed. It returns whether any database was changed.
+func (d restoreDumps) restore(restorer DBRestorer, controllerDB ControllerDB) (bool, error) {
+	f, err := os.Open(d.controllerDBFile)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := restoreControllerDB(context.Background(), controllerDB, f); err != nil {
+		return false, errors.Annotate(err, "while restoring controller database")
+	}
+	if err := restorer.Restore(d.dbDumpDir); err != nil {
+		return true, errors.Annotate(err, "while restoring juju state database")
+	}
+	return true, nil
+}
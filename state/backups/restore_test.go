@@ -0,0 +1,99 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type validateRestoreSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&validateRestoreSuite{})
+
+const restoreControllerUUID = "deadbeef-1bad-500d-9000-4b1d0d06f00d"
+
+func (s *validateRestoreSuite) metadata() *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Origin.Version = version.MustParse("3.3.1.4")
+	meta.Controller.UUID = restoreControllerUUID
+	meta.Controller.HANodes = 3
+	meta.Controller.MachineID = "0"
+	meta.Controller.MachineInstanceID = "inst-0"
+	return meta
+}
+
+func (s *validateRestoreSuite) target() backups.RestoreTarget {
+	return backups.RestoreTarget{
+		ControllerUUID:  restoreControllerUUID,
+		Version:         version.MustParse("3.3.1"),
+		ControllerNodes: 1,
+		MachineID:       "0",
+		InstanceID:      "inst-0",
+	}
+}
+
+func (s *validateRestoreSuite) TestValid(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), s.target())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *validateRestoreSuite) TestFormatVersion(c *gc.C) {
+	meta := s.metadata()
+	meta.FormatVersion = 0
+	err := backups.ValidateRestore(meta, s.target())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `restoring backup format 0 not supported`)
+}
+
+func (s *validateRestoreSuite) TestUnknownControllerUUID(c *gc.C) {
+	meta := s.metadata()
+	meta.Controller = backups.UnknownController()
+	err := backups.ValidateRestore(meta, s.target())
+	c.Assert(err, gc.ErrorMatches, `backup without a controller UUID not valid`)
+}
+
+func (s *validateRestoreSuite) TestControllerUUIDMismatch(c *gc.C) {
+	target := s.target()
+	target.ControllerUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	err := backups.ValidateRestore(s.metadata(), target)
+	c.Assert(err, gc.ErrorMatches, `backup of controller "deadbeef-.*" cannot be restored to controller "f47ac10b-.*"`)
+}
+
+func (s *validateRestoreSuite) TestVersionMismatch(c *gc.C) {
+	target := s.target()
+	target.Version = version.MustParse("3.3.2")
+	err := backups.ValidateRestore(s.metadata(), target)
+	c.Assert(err, gc.ErrorMatches, `backup taken with juju 3.3.1 cannot be restored to a controller running juju 3.3.2`)
+}
+
+func (s *validateRestoreSuite) TestHAController(c *gc.C) {
+	target := s.target()
+	target.ControllerNodes = 3
+	err := backups.ValidateRestore(s.metadata(), target)
+	c.Assert(err, gc.ErrorMatches, `cannot restore to a controller with 3 nodes; remove the other controller nodes and enable HA again afterwards`)
+}
+
+func (s *validateRestoreSuite) TestUnknownMachine(c *gc.C) {
+	meta := s.metadata()
+	meta.Controller.MachineInstanceID = backups.UnknownString
+	err := backups.ValidateRestore(meta, s.target())
+	c.Assert(err, gc.ErrorMatches, `backup without a controller machine not valid`)
+}
+
+func (s *validateRestoreSuite) TestOtherMachine(c *gc.C) {
+	target := s.target()
+	target.InstanceID = "inst-1"
+	err := backups.ValidateRestore(s.metadata(), target)
+	c.Assert(err, gc.ErrorMatches, `backup of machine 0 \(instance "inst-0"\) cannot be restored to machine 0 \(instance "inst-1"\); `+
+		`backups can only be restored to the machine they were taken from`)
+}
@@ -22,6 +22,8 @@ type FakeBackups struct {
 	Meta *backups.Metadata
 	// MetaList holds the Metadata list to return.
 	MetaList []*backups.Metadata
+	// Stored holds the stored backups to return.
+	Stored []backups.StoredBackup
 	// Archive holds the archive file to return.
 	Archive io.ReadCloser
 	// Error holds the error to return.
@@ -41,6 +43,8 @@ type FakeBackups struct {
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// RestoreArgs holds the restore args that were passed in.
+	RestoreArgs backups.RestoreArgs
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -69,3 +73,31 @@ func (b *FakeBackups) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
 	b.IDArg = id
 	return b.Meta, b.Archive, b.Error
 }
+
+// Add stores the backup archive file.
+func (b *FakeBackups) Add(archive io.Reader) (string, error) {
+	b.Calls = append(b.Calls, "Add")
+	b.ArchiveArg = archive
+	return b.Filename, b.Error
+}
+
+// List returns the stored backups.
+func (b *FakeBackups) List() ([]backups.StoredBackup, error) {
+	b.Calls = append(b.Calls, "List")
+	return b.Stored, b.Error
+}
+
+// Remove removes the backup archive file.
+func (b *FakeBackups) Remove(fileName string) error {
+	b.Calls = append(b.Calls, "Remove")
+	b.IDArg = fileName
+	return b.Error
+}
+
+// Restore restores the backup archive file.
+func (b *FakeBackups) Restore(fileName string, args backups.RestoreArgs) (*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "Restore")
+	b.IDArg = fileName
+	b.RestoreArgs = args
+	return b.Meta, b.Error
+}
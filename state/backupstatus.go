@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3/txn"
+	jujutxn "github.com/juju/txn/v3"
+
+	"github.com/juju/juju/core/status"
+)
+
+// backupsGlobalKey identifies the status of the controller's scheduled
+// backups. It is only recorded in the controller model.
+const backupsGlobalKey = "backups"
+
+// SetBackupStatus records the status of the controller's scheduled
+// backups. The status is one of executing, while a backup is being
+// taken, active, once it has succeeded, or error, if it failed.
+func (st *State) SetBackupStatus(sInfo status.StatusInfo) error {
+	if !st.IsController() {
+		return errors.NotSupportedf("backup status outside of the controller model")
+	}
+	switch sInfo.Status {
+	case status.Executing, status.Active, status.Error:
+	default:
+		return errors.Errorf("cannot set invalid status %q", sInfo.Status)
+	}
+	updated := timeOrNow(sInfo.Since, st.clock())
+
+	// The status document is only created once the first
+	// scheduled backup starts.
+	buildTxn := func(int) ([]txn.Op, error) {
+		_, err := getStatus(st.db(), backupsGlobalKey, "backups")
+		if err == nil {
+			return nil, jujutxn.ErrNoOperations
+		} else if !errors.Is(err, errors.NotFound) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{createStatusOp(st, backupsGlobalKey, statusDoc{
+			ModelUUID: st.ModelUUID(),
+			Status:    status.Unknown,
+			Updated:   updated.UnixNano(),
+		})}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot create backup status")
+	}
+
+	return setStatus(st.db(), setStatusParams{
+		badge:     "backups",
+		globalKey: backupsGlobalKey,
+		status:    sInfo.Status,
+		message:   sInfo.Message,
+		rawData:   sInfo.Data,
+		updated:   updated,
+	})
+}
+
+// BackupStatus returns the status of the controller's scheduled backups.
+// A NotFound error is returned if no scheduled backup has been taken.
+func (st *State) BackupStatus() (status.StatusInfo, error) {
+	return getStatus(st.db(), backupsGlobalKey, "backup status")
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+type BackupStatusSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BackupStatusSuite{})
+
+func (s *BackupStatusSuite) TestNoStatus(c *gc.C) {
+	_, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIs, errors.NotFound)
+}
+
+func (s *BackupStatusSuite) TestSetStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	err := s.State.SetBackupStatus(status.StatusInfo{
+		Status:  status.Executing,
+		Message: "creating backup",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	later := now.Add(time.Minute)
+	err = s.State.SetBackupStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "backup created",
+		Data:    map[string]interface{}{"filename": "juju-backup.tar.gz"},
+		Since:   &later,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sInfo, err := s.State.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sInfo.Status, gc.Equals, status.Active)
+	c.Check(sInfo.Message, gc.Equals, "backup created")
+	c.Check(sInfo.Data, jc.DeepEquals, map[string]interface{}{"filename": "juju-backup.tar.gz"})
+	c.Check(sInfo.Since.Equal(later), jc.IsTrue)
+}
+
+func (s *BackupStatusSuite) TestSetInvalidStatus(c *gc.C) {
+	err := s.State.SetBackupStatus(status.StatusInfo{Status: status.Blocked})
+	c.Assert(err, gc.ErrorMatches, `cannot set invalid status "blocked"`)
+}
+
+func (s *BackupStatusSuite) TestSetStatusHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := st.SetBackupStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, jc.ErrorIs, errors.NotSupported)
+}
@@ -162,6 +162,11 @@ type charmDoc struct {
 	BundleSha256 string `bson:"bundlesha256"`
 	StoragePath  string `bson:"storagepath"`
 
+	// SourceSha256 is the SHA256 digest of the archive the charm was
+	// uploaded from while its model was being migrated. Uploaded
+	// archives are repackaged, so it differs from BundleSha256.
+	SourceSha256 string `bson:"sourcesha256,omitempty"`
+
 	// The remaining fields hold data sufficient to define a
 	// charm.Charm.
 
@@ -701,6 +706,13 @@ func (c *Charm) BundleSha256() string {
 	return c.doc.BundleSha256
 }
 
+// SourceSha256 returns the SHA256 digest of the archive the charm was
+// uploaded from while its model was being migrated, or an empty string
+// if it was not uploaded during a migration.
+func (c *Charm) SourceSha256() string {
+	return c.doc.SourceSha256
+}
+
 // IsUploaded returns whether the charm has been uploaded to the
 // model storage. Response is only valid when the charm is
 // not a placeholder.
@@ -1017,6 +1029,26 @@ func (st *State) PrepareCharmUpload(curl string) (*Charm, error) {
 	return nil, errors.Trace(err)
 }
 
+// SetCharmSourceSha256 records the SHA256 digest of the archive the
+// charm with the given URL was uploaded from while its model was being
+// migrated.
+func (st *State) SetCharmSourceSha256(curl, sourceSha256 string) error {
+	charms, closer := st.db().GetCollection(charmsC)
+	defer closer()
+
+	op, err := nsLife.aliveOp(charms, curl)
+	if err != nil {
+		return errors.Annotate(err, "charm")
+	}
+	op.Update = bson.D{{"$set", bson.D{{"sourcesha256", sourceSha256}}}}
+	if err := st.db().RunTransaction([]txn.Op{op}); err == txn.ErrAborted {
+		return errors.NotFoundf("charm %q", curl)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set source sha256 of charm %q", curl)
+	}
+	return nil
+}
+
 var (
 	stillPending     = bson.D{{"pendingupload", true}}
 	stillPlaceholder = bson.D{{"placeholder", true}}
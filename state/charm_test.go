@@ -529,6 +529,23 @@ func (s *CharmSuite) TestUpdateUploadedCharm(c *gc.C) {
 	c.Assert(sch.BundleSha256(), gc.Equals, "missing")
 }
 
+func (s *CharmSuite) TestSetCharmSourceSha256(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	sch, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.SourceSha256(), gc.Equals, "")
+
+	err = s.State.SetCharmSourceSha256(info.ID, "cafef00d")
+	c.Assert(err, jc.ErrorIsNil)
+	sch, err = s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.SourceSha256(), gc.Equals, "cafef00d")
+	c.Assert(sch.BundleSha256(), gc.Equals, info.SHA256)
+
+	err = s.State.SetCharmSourceSha256("local:quantal/missing-1", "cafef00d")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *CharmSuite) TestUpdateUploadedCharmEscapesSpecialCharsInConfig(c *gc.C) {
 	// Make sure when we have mongodb special characters like "$" and
 	// "." in the name of any charm config option, we do proper
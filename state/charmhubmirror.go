@@ -0,0 +1,87 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/mgo/v3/bson"
+	"github.com/juju/mgo/v3/txn"
+	jujutxn "github.com/juju/txn/v3"
+)
+
+const charmhubMirrorKey = "charmhubMirror"
+
+// charmhubMirrorDoc records which stored manifest is the current one for
+// the controller's charmhub mirror.
+type charmhubMirrorDoc struct {
+	// Manifest is the storage path, in the controller model, of the
+	// mirror's current manifest.
+	Manifest string `bson:"manifest"`
+	TxnRevno int64  `bson:"txn-revno"`
+}
+
+// CharmhubMirrorManifest returns the storage path, in the controller
+// model, of the charmhub mirror's current manifest. An empty path is
+// returned if nothing has been imported into the mirror.
+func (st *State) CharmhubMirrorManifest() (string, error) {
+	doc, _, err := st.charmhubMirrorDoc()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Manifest, nil
+}
+
+// UpdateCharmhubMirrorManifest records the storage path returned by update
+// as the charmhub mirror's current manifest. update is passed the path of
+// the current manifest, and is called again if the manifest is replaced
+// concurrently, for example by another controller, so that no import into
+// the mirror is lost.
+func (st *State) UpdateCharmhubMirrorManifest(update func(current string) (string, error)) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, exists, err := st.charmhubMirrorDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		manifest, err := update(doc.Manifest)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if manifest == doc.Manifest {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if !exists {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     charmhubMirrorKey,
+				Assert: txn.DocMissing,
+				Insert: &charmhubMirrorDoc{Manifest: manifest},
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     charmhubMirrorKey,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"manifest", manifest}}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot update charmhub mirror manifest")
+	}
+	return nil
+}
+
+func (st *State) charmhubMirrorDoc() (charmhubMirrorDoc, bool, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc charmhubMirrorDoc
+	err := controllers.FindId(charmhubMirrorKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return charmhubMirrorDoc{}, false, nil
+	} else if err != nil {
+		return charmhubMirrorDoc{}, false, errors.Annotate(err, "cannot read charmhub mirror manifest")
+	}
+	return doc, true, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmhubMirrorSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmhubMirrorSuite{})
+
+func (s *CharmhubMirrorSuite) TestManifestEmpty(c *gc.C) {
+	manifest, err := s.State.CharmhubMirrorManifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, gc.Equals, "")
+}
+
+func (s *CharmhubMirrorSuite) TestUpdateManifest(c *gc.C) {
+	var seen []string
+	update := func(path string) func(string) (string, error) {
+		return func(current string) (string, error) {
+			seen = append(seen, current)
+			return path, nil
+		}
+	}
+	err := s.State.UpdateCharmhubMirrorManifest(update("first"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateCharmhubMirrorManifest(update("second"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seen, jc.DeepEquals, []string{"", "first"})
+
+	manifest, err := s.State.CharmhubMirrorManifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, gc.Equals, "second")
+}
+
+func (s *CharmhubMirrorSuite) TestUpdateManifestConcurrently(c *gc.C) {
+	err := s.State.UpdateCharmhubMirrorManifest(func(string) (string, error) {
+		return "first", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Another controller replaces the manifest while this update is
+	// being prepared, so it is prepared again from the new manifest.
+	var seen []string
+	err = s.State.UpdateCharmhubMirrorManifest(func(current string) (string, error) {
+		seen = append(seen, current)
+		if len(seen) == 1 {
+			s.setManifest(c, s.State, "other")
+		}
+		return current + "+mine", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(seen, jc.DeepEquals, []string{"first", "other"})
+
+	manifest, err := s.State.CharmhubMirrorManifest()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(manifest, gc.Equals, "other+mine")
+}
+
+func (s *CharmhubMirrorSuite) setManifest(c *gc.C, st *state.State, path string) {
+	err := st.UpdateCharmhubMirrorManifest(func(string) (string, error) {
+		return path, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -114,3 +115,36 @@ func (s *ConfigValidatorSuite) TestConfigValidateNoPolicy(c *gc.C) {
 	err := s.updateModelConfig(c)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type configValidatorFunc func(cfg, old *config.Config) (*config.Config, error)
+
+func (f configValidatorFunc) Validate(cfg, old *config.Config) (*config.Config, error) {
+	return f(cfg, old)
+}
+
+func (s *ConfigValidatorSuite) TestUpdateModelConfigCharmHubURLMirror(c *gc.C) {
+	s.policy.GetConfigValidator = func() (config.Validator, error) {
+		return configValidatorFunc(func(cfg, old *config.Config) (*config.Config, error) {
+			return cfg, config.Validate(cfg, old)
+		}), nil
+	}
+	err := s.State.SetAPIHostPorts([]network.SpaceHostPorts{
+		network.NewSpaceHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the mirror hosted by the model's controller may be used.
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"charmhub-url": "https://evil.example/charmhub",
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot change charmhub-url from ".*" to "https://evil.example/charmhub"`)
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"charmhub-url": "https://10.0.0.1:17070/charmhub",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	url, _ := cfg.CharmHubURL()
+	c.Assert(url, gc.Equals, "https://10.0.0.1:17070/charmhub")
+}
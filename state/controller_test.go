@@ -38,8 +38,20 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AllowModelAccessKey,
 		controller.APIPortOpenDelay,
 		controller.AuditLogExcludeMethods,
+		controller.AuditLogSocketPath,
+		controller.AuditLogSyslogCACert,
+		controller.AuditLogSyslogClientCert,
+		controller.AuditLogSyslogClientKey,
+		controller.AuditLogSyslogHost,
+		controller.AuditLogWebhookURL,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.BackupSchedule,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 		controller.CAASImageRepo,
 		controller.CAASOperatorImagePath,
 		controller.ControllerAPIPort,
@@ -328,9 +328,45 @@ func (st *State) buildAndValidateModelConfig(updateAttrs attrValues, removeAttrs
 	if err := checkModelConfig(newConfig); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The controller's API addresses are needed to check that
+	// charmhub-url is only changed to or from the controller's mirror.
+	oldURL, _ := oldConfig.CharmHubURL()
+	newURL, _ := newConfig.CharmHubURL()
+	if newURL != oldURL {
+		apiAddresses, err := st.ControllerAPIAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newConfig = newConfig.WithControllerAPIAddresses(apiAddresses)
+	}
 	return st.validate(newConfig, oldConfig)
 }
 
+// ControllerAPIAddresses returns the addresses, in host:port form, at
+// which clients connect to the controller's API.
+func (st *State) ControllerAPIAddresses() ([]string, error) {
+	controllerSt := st
+	if st.ModelUUID() != st.ControllerModelUUID() {
+		// The API addresses of a CAAS controller are only available
+		// from the controller model.
+		var err error
+		controllerSt, err = st.newStateNoWorkers(st.ControllerModelUUID())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer controllerSt.Close()
+	}
+	apiHostPorts, err := controllerSt.APIHostPortsForClients()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var addrs []string
+	for _, hps := range apiHostPorts {
+		addrs = append(addrs, hps.HostPorts().Strings()...)
+	}
+	return addrs, nil
+}
+
 type ValidateConfigFunc func(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error
 
 // UpdateModelConfig adds, updates or removes attributes in the current
@@ -76,6 +76,18 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// Suspend stops the migration at its current phase after a
+	// failure, recording the given message as the migration's
+	// status. The model remains quiesced until the migration is
+	// resumed or aborted.
+	Suspend(message string) error
+
+	// Resume continues a suspended migration from its current phase.
+	Resume() error
+
+	// IsSuspended returns true if the migration has been suspended.
+	IsSuspended() bool
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -205,6 +217,10 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// Suspended is true when the migration has stopped at its
+	// current phase and is waiting to be resumed or aborted.
+	Suspended bool `bson:"suspended,omitempty"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -318,6 +334,10 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		nextDoc.SuccessTime = now
 		update["success-time"] = now
 	}
+	if mig.statusDoc.Suspended {
+		nextDoc.Suspended = false
+		update["suspended"] = false
+	}
 
 	ops, err := migStatusHistoryAndOps(mig.st, nextPhase, now, mig.StatusMessage())
 	if err != nil {
@@ -338,6 +358,9 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 
 	// Set end timestamps and mark migration as no longer active if a
 	// terminal phase is hit.
+	if !nextPhase.IsTerminal() && mig.statusDoc.Suspended {
+		ops = append(ops, mig.setActiveSuspendedOp(false))
+	}
 	if nextPhase.IsTerminal() {
 		nextDoc.EndTime = now
 		update["end-time"] = now
@@ -427,6 +450,92 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// Suspend implements ModelMigration.
+func (mig *modelMigration) Suspend(message string) error {
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !phase.CanSuspend() {
+		return errors.Errorf("migration can't be suspended in %s phase", phase)
+	}
+	if mig.statusDoc.Suspended {
+		return errors.New("migration already suspended")
+	}
+	ops, err := migStatusHistoryAndOps(mig.st, phase, mig.st.clock().Now().UnixNano(), message)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, txn.Op{
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{
+			"suspended":      true,
+			"status-message": message,
+		}},
+		// Ensure phase hasn't changed underneath us
+		Assert: bson.D{{"phase", mig.statusDoc.Phase}, {"suspended", bson.M{"$ne": true}}},
+	}, mig.setActiveSuspendedOp(true))
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("migration phase or suspension already changed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to suspend migration")
+	}
+	mig.statusDoc.Suspended = true
+	mig.statusDoc.StatusMessage = message
+	return nil
+}
+
+// Resume implements ModelMigration.
+func (mig *modelMigration) Resume() error {
+	if !mig.statusDoc.Suspended {
+		return errors.New("migration is not suspended")
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	message := "resuming"
+	ops, err := migStatusHistoryAndOps(mig.st, phase, mig.st.clock().Now().UnixNano(), message)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, txn.Op{
+		C:  migrationsStatusC,
+		Id: mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{
+			"suspended":      false,
+			"status-message": message,
+		}},
+		Assert: bson.D{{"phase", mig.statusDoc.Phase}, {"suspended", true}},
+	}, mig.setActiveSuspendedOp(false))
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("migration phase or suspension already changed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to resume migration")
+	}
+	mig.statusDoc.Suspended = false
+	mig.statusDoc.StatusMessage = message
+	return nil
+}
+
+// setActiveSuspendedOp records the suspension state on the model's
+// active migration document, so that watchers of the active migration
+// (such as the migrationmaster's) are woken when it changes.
+func (mig *modelMigration) setActiveSuspendedOp(suspended bool) txn.Op {
+	return txn.Op{
+		C:      migrationsActiveC,
+		Id:     mig.doc.ModelUUID,
+		Assert: txn.DocExists,
+		Update: bson.M{"$set": bson.M{"suspended": suspended}},
+	}
+}
+
+// IsSuspended implements ModelMigration.
+func (mig *modelMigration) IsSuspended() bool {
+	return mig.statusDoc.Suspended
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
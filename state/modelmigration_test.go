@@ -534,6 +534,96 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestSuspendAndResume(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+
+	err = mig.Suspend("suspended: upload failed")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsTrue)
+	c.Check(mig.StatusMessage(), gc.Equals, "suspended: upload failed")
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.IsSuspended(), jc.IsTrue)
+	assertPhase(c, mig2, migration.IMPORT)
+
+	err = mig2.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.IsSuspended(), jc.IsFalse)
+	c.Check(mig2.StatusMessage(), gc.Equals, "resuming")
+
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+	assertPhase(c, mig, migration.IMPORT)
+	assertMigrationActive(c, s.State2)
+}
+
+func (s *MigrationSuite) TestSuspendInvalidPhase(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.Suspend("nope")
+	c.Assert(err, gc.ErrorMatches, "migration can't be suspended in QUIESCE phase")
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+}
+
+func (s *MigrationSuite) TestSuspendAlreadySuspended(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.Suspend("once"), jc.ErrorIsNil)
+
+	err = mig.Suspend("twice")
+	c.Assert(err, gc.ErrorMatches, "migration already suspended")
+}
+
+func (s *MigrationSuite) TestResumeNotSuspended(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.Resume()
+	c.Assert(err, gc.ErrorMatches, "migration is not suspended")
+}
+
+func (s *MigrationSuite) TestAbortSuspended(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.Suspend("suspended"), jc.ErrorIsNil)
+
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Check(mig.IsSuspended(), jc.IsFalse)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.IsSuspended(), jc.IsFalse)
+	assertPhase(c, mig2, migration.ABORT)
+}
+
+func (s *MigrationSuite) TestWatchForMigrationSuspendResume(c *gc.C) {
+	w, wc := s.createMigrationWatcher(c, s.State2)
+	wc.AssertOneChange()
+
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Suspending and resuming wake watchers of the active
+	// migration.
+	c.Assert(mig.Suspend("suspended"), jc.ErrorIsNil)
+	wc.AssertOneChange()
+	c.Assert(mig.Resume(), jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
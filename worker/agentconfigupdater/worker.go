@@ -85,6 +85,12 @@ func (w *agentConfigUpdater) loop(started chan struct{}) error {
 		return errors.Trace(err)
 	}
 	defer unsubscribe()
+	unsubscribeRestored, err := w.config.Hub.Subscribe(controllermsg.Restored, w.onRestored)
+	if err != nil {
+		w.config.Logger.Criticalf("programming error in subscribe function: %v", err)
+		return errors.Trace(err)
+	}
+	defer unsubscribeRestored()
 	// Let the caller know we are done.
 	close(started)
 	// Don't exit until we are told to. Exiting unsubscribes.
@@ -144,6 +150,17 @@ func (w *agentConfigUpdater) onConfigChanged(topic string, data controllermsg.Co
 	w.tomb.Kill(jworker.ErrRestartAgent)
 }
 
+// onRestored restarts the agent once the controller databases have been
+// restored from a backup.
+func (w *agentConfigUpdater) onRestored(topic string, data controllermsg.RestoredMessage, err error) {
+	if err != nil {
+		w.config.Logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	w.config.Logger.Infof("controller restored from backup %q, restarting agent", data.BackupID)
+	w.tomb.Kill(jworker.ErrRestartAgent)
+}
+
 // Kill implements Worker.Kill().
 func (w *agentConfigUpdater) Kill() {
 	w.tomb.Kill(nil)
@@ -127,6 +127,23 @@ func (s *WorkerSuite) TestNormalStart(c *gc.C) {
 	workertest.CleanKill(c, w)
 }
 
+func (s *WorkerSuite) TestRestored(c *gc.C) {
+	w, err := agentconfigupdater.NewWorker(s.config)
+	c.Assert(w, gc.NotNil)
+	c.Check(err, jc.ErrorIsNil)
+
+	handled, err := s.hub.Publish(controllermsg.Restored, controllermsg.RestoredMessage{BackupID: "backup-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-pubsub.Wait(handled):
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+}
+
 func (s *WorkerSuite) TestUpdateMongoProfile(c *gc.C) {
 	w, err := agentconfigupdater.NewWorker(s.config)
 	c.Assert(w, gc.NotNil)
@@ -5,11 +5,14 @@ package auditconfigupdater
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v5"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/dependency"
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -68,7 +71,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	logDir := agent.CurrentConfig().LogDir()
+	agentConfig := agent.CurrentConfig()
 
 	st, err := statePool.SystemState()
 	if err != nil {
@@ -77,7 +80,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		return newTarget(cfg, agentConfig)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -127,6 +130,66 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sinks:          cfg.AuditLogSinks(),
+		BufferSize:     cfg.AuditLogBufferSize(),
+		SocketPath:     cfg.AuditLogSocketPath(),
+		WebhookURL:     cfg.AuditLogWebhookURL(),
+		Syslog:         syslogConfig(cfg),
 	}
 	return result, nil
 }
+
+// newTarget creates each of the configured audit log sinks and
+// returns an audit log fanning records out to them. Sinks that can't
+// be created are logged and skipped; if none can be created the
+// audit.log file is used so audit records aren't silently dropped.
+func newTarget(cfg auditlog.Config, agentConfig jujuagent.Config) auditlog.AuditLog {
+	sinkConfig := auditlog.SinkConfig{
+		LogDir:     agentConfig.LogDir(),
+		MaxSizeMB:  cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		Syslog:     cfg.Syslog,
+		SocketPath: cfg.SocketPath,
+		WebhookURL: cfg.WebhookURL,
+	}
+	sinks := make(map[string]auditlog.AuditLog)
+	for _, name := range cfg.Sinks {
+		if name == auditlog.SyslogSink {
+			sinkConfig.Origin = auditOrigin(agentConfig)
+		}
+		sink, err := auditlog.NewSink(name, sinkConfig)
+		if err != nil {
+			logger.Errorf("%v", err)
+			continue
+		}
+		sinks[name] = sink
+	}
+	if len(sinks) == 0 {
+		return auditlog.NewLogFile(sinkConfig.LogDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	}
+	target, err := auditlog.NewFanOut(auditlog.FanOutConfig{
+		Sinks:      sinks,
+		BufferSize: cfg.BufferSize,
+	})
+	if err != nil {
+		// The config is built above, so this can't happen.
+		logger.Errorf("creating audit log fan-out: %v", err)
+		return auditlog.NewLogFile(sinkConfig.LogDir, cfg.MaxSizeMB, cfg.MaxBackups)
+	}
+	return target
+}
+
+// auditOrigin describes the controller agent as the origin of
+// forwarded audit records.
+func auditOrigin(agentConfig jujuagent.Config) logfwd.Origin {
+	controllerUUID := agentConfig.Controller().Id()
+	modelUUID := agentConfig.Model().Id()
+	if tag, ok := agentConfig.Tag().(names.MachineTag); ok {
+		return logfwd.OriginForMachineAgent(tag, controllerUUID, modelUUID, jujuversion.Current)
+	}
+	origin, err := logfwd.OriginForJuju(agentConfig.Tag(), controllerUUID, modelUUID, jujuversion.Current)
+	if err != nil {
+		logger.Warningf("determining audit log origin: %v", err)
+	}
+	return origin
+}
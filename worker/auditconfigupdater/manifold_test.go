@@ -126,6 +126,8 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 		ExcludeMethods: set.NewStrings("This.Method"),
 		MaxSizeMB:      10,
 		MaxBackups:     10,
+		Sinks:          []string{"file"},
+		BufferSize:     1000,
 	})
 
 	c.Assert(args[2], gc.NotNil)
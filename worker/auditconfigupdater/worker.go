@@ -7,14 +7,18 @@ import (
 	"sync"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/catacomb"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.auditconfigupdater")
+
 // ConfigSource lets us get notifications of changes to controller
 // configuration, and then get the changed config. (Primary
 // implementation is State.)
@@ -34,6 +38,11 @@ func New(source ConfigSource, initial auditlog.Config, logFactory AuditLogFactor
 		current:    initial,
 		logFactory: logFactory,
 	}
+	if initial.Target != nil {
+		u.target = &switchingLog{target: initial.Target}
+		u.current.Target = u.target
+		u.targetConfig = initial
+	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
 		Work: u.loop,
@@ -50,6 +59,17 @@ type updater struct {
 	source     ConfigSource
 	current    auditlog.Config
 	logFactory AuditLogFactory
+
+	// target is handed out as the audit log target of every config, so
+	// that API connections, which capture the target when they log in,
+	// keep logging to the current sinks when they are reconfigured.
+	target *switchingLog
+
+	// targetConfig is the config the current target was built from.
+	// The sink settings in current follow the controller config even
+	// while auditing is disabled, so they can't be used to tell
+	// whether the target needs replacing.
+	targetConfig auditlog.Config
 }
 
 // Kill is part of the worker.Worker interface.
@@ -95,10 +115,27 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Sinks:          cfg.AuditLogSinks(),
+		BufferSize:     cfg.AuditLogBufferSize(),
+		SocketPath:     cfg.AuditLogSocketPath(),
+		WebhookURL:     cfg.AuditLogWebhookURL(),
+		Syslog:         syslogConfig(cfg),
 	}
-	if result.Enabled && u.current.Target == nil {
-		result.Target = u.logFactory(result)
-	} else {
+	switch {
+	case result.Enabled && u.target == nil:
+		u.target = &switchingLog{target: u.logFactory(result)}
+		u.targetConfig = result
+		result.Target = u.target
+	case result.Enabled && u.targetConfig.SinksChanged(result):
+		// The sinks have been reconfigured, so switch to a new target
+		// and flush the old one, which nothing writes to any more.
+		old := u.target.replace(u.logFactory(result))
+		if err := old.Close(); err != nil {
+			logger.Warningf("closing previous audit log target: %v", err)
+		}
+		u.targetConfig = result
+		result.Target = u.target
+	default:
 		// Keep the existing target to avoid file handle leaks from
 		// disabling and enabling auditing - we'll still stop logging
 		// because enabled is false.
@@ -119,3 +156,58 @@ func (u *updater) CurrentConfig() auditlog.Config {
 	defer u.mu.Unlock()
 	return u.current
 }
+
+// switchingLog is an audit log that writes to whichever target it was
+// last given.
+type switchingLog struct {
+	mu     sync.RWMutex
+	target auditlog.AuditLog
+}
+
+// replace switches to the new target, returning the previous one once
+// no writes to it are in flight.
+func (l *switchingLog) replace(target auditlog.AuditLog) auditlog.AuditLog {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old := l.target
+	l.target = target
+	return old
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (l *switchingLog) AddConversation(c auditlog.Conversation) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddConversation(c)
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (l *switchingLog) AddRequest(r auditlog.Request) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddRequest(r)
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *switchingLog) AddResponse(r auditlog.ResponseErrors) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.AddResponse(r)
+}
+
+// Close implements auditlog.AuditLog.
+func (l *switchingLog) Close() error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.target.Close()
+}
+
+func syslogConfig(cfg controller.Config) syslog.RawConfig {
+	return syslog.RawConfig{
+		Enabled:    cfg.AuditLogSyslogHost() != "",
+		Host:       cfg.AuditLogSyslogHost(),
+		CACert:     cfg.AuditLogSyslogCACert(),
+		ClientCert: cfg.AuditLogSyslogClientCert(),
+		ClientKey:  cfg.AuditLogSyslogClientKey(),
+	}
+}
@@ -62,7 +62,7 @@ func (s *updaterSuite) TestWorker(c *gc.C) {
 	c.Assert(newConfig.Enabled, gc.Equals, true)
 	c.Assert(newConfig.CaptureAPIArgs, gc.Equals, false)
 	c.Assert(newConfig.ExcludeMethods, gc.DeepEquals, set.NewStrings())
-	c.Assert(newConfig.Target, gc.Equals, auditlog.AuditLog(&fakeTarget))
+	assertLogsTo(c, newConfig.Target, &fakeTarget)
 	c.Assert(calls, gc.HasLen, 1)
 }
 
@@ -102,7 +102,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenAuditingDisabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, false)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	assertLogsTo(c, newConfig.Target, initial.Target.(*apitesting.FakeAuditLog))
 }
 
 func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
@@ -130,7 +130,7 @@ func (s *updaterSuite) TestKeepsLogFileWhenEnabled(c *gc.C) {
 	})
 
 	c.Assert(newConfig.Enabled, gc.Equals, true)
-	c.Assert(newConfig.Target, gc.Equals, initial.Target)
+	assertLogsTo(c, newConfig.Target, initial.Target.(*apitesting.FakeAuditLog))
 }
 
 func (s *updaterSuite) TestChangingExcludeMethod(c *gc.C) {
@@ -188,6 +188,116 @@ func (s *updaterSuite) TestChangingCaptureArgs(c *gc.C) {
 	})
 }
 
+func (s *updaterSuite) TestChangingSinksReplacesTarget(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Connections capture the target when they log in.
+	loggedIn := getWorkerConfig(c, w).Target
+
+	cfg := makeControllerConfig(true, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "socket"}
+	cfg["audit-log-socket-path"] = "/run/audit.sock"
+	source.setConfig(cfg)
+	configChanged <- ding
+
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return len(cfg.Sinks) == 2
+	})
+
+	c.Assert(newConfig.Sinks, gc.DeepEquals, []string{"file", "socket"})
+	c.Assert(newConfig.SocketPath, gc.Equals, "/run/audit.sock")
+	c.Assert(calls, gc.HasLen, 1)
+	oldTarget.CheckCallNames(c, "Close")
+	assertLogsTo(c, newConfig.Target, newTarget)
+
+	// Existing connections log to the new target too, rather than to
+	// the closed one.
+	newTarget.ResetCalls()
+	assertLogsTo(c, loggedIn, newTarget)
+	oldTarget.CheckCallNames(c, "Close")
+}
+
+func (s *updaterSuite) TestChangingSinksWhileDisabledReplacesTargetWhenEnabled(c *gc.C) {
+	configChanged := make(chan struct{}, 1)
+	oldTarget := &apitesting.FakeAuditLog{}
+	initial := auditlog.Config{
+		Enabled: true,
+		Target:  oldTarget,
+	}
+	source := configSource{
+		watcher: watchertest.NewNotifyWatcher(configChanged),
+		cfg:     makeControllerConfig(true, false),
+	}
+
+	newTarget := &apitesting.FakeAuditLog{}
+	var calls []auditlog.Config
+	factory := func(cfg auditlog.Config) auditlog.AuditLog {
+		calls = append(calls, cfg)
+		return newTarget
+	}
+
+	w, err := auditconfigupdater.New(&source, initial, factory)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	source.setConfig(makeControllerConfig(false, false))
+	configChanged <- ding
+	waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return !cfg.Enabled
+	})
+
+	cfg := makeControllerConfig(false, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "socket"}
+	cfg["audit-log-socket-path"] = "/run/audit.sock"
+	source.setConfig(cfg)
+	configChanged <- ding
+	waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return len(cfg.Sinks) == 2
+	})
+	c.Assert(calls, gc.HasLen, 0)
+
+	cfg = makeControllerConfig(true, false)
+	cfg["audit-log-sinks"] = []interface{}{"file", "socket"}
+	cfg["audit-log-socket-path"] = "/run/audit.sock"
+	source.setConfig(cfg)
+	configChanged <- ding
+	newConfig := waitForConfig(c, w, func(cfg auditlog.Config) bool {
+		return cfg.Enabled
+	})
+
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].Sinks, gc.DeepEquals, []string{"file", "socket"})
+	oldTarget.CheckCallNames(c, "Close")
+	assertLogsTo(c, newConfig.Target, newTarget)
+}
+
+func assertLogsTo(c *gc.C, target auditlog.AuditLog, fake *apitesting.FakeAuditLog) {
+	c.Assert(target, gc.NotNil)
+	err := target.AddRequest(auditlog.Request{RequestID: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "AddRequest")
+}
+
 func makeControllerConfig(auditEnabled bool, captureArgs bool, methods ...interface{}) controller.Config {
 	result := map[string]interface{}{
 		"other-setting":             "something",
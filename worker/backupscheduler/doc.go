@@ -0,0 +1,8 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes backups of the
+// controller on the schedule in the controller config, removes the
+// scheduled backups that are no longer covered by the retention policy,
+// and optionally uploads each backup to an S3 compatible bucket.
+package backupscheduler
@@ -0,0 +1,155 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/dependency"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/agent"
+	coredatabase "github.com/juju/juju/core/database"
+	"github.com/juju/juju/internal/s3client"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/common"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a backup
+// scheduler in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName      string
+	StateName      string
+	DBAccessorName string
+
+	Clock                clock.Clock
+	Logger               Logger
+	PrometheusRegisterer prometheus.Registerer
+	NewMetricsCollector  func() *Collector
+	NewS3Client          func(s3client.ExternalConfig, s3client.Logger) (s3client.Session, error)
+	NewWorker            func(Config) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.DBAccessorName == "" {
+		return errors.NotValidf("empty DBAccessorName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
+	if config.NewMetricsCollector == nil {
+		return errors.NotValidf("nil NewMetricsCollector")
+	}
+	if config.NewS3Client == nil {
+		return errors.NotValidf("nil NewS3Client")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a backup
+// scheduler.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.StateName,
+			config.DBAccessorName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var dbGetter coredatabase.DBGetter
+	if err := context.Get(config.DBAccessorName, &dbGetter); err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerDB, err := dbGetter.GetDB(coredatabase.ControllerNS)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, model, err := systemStateAndModel(statePool)
+	if err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	metricsCollector := config.NewMetricsCollector()
+	if err := config.PrometheusRegisterer.Register(metricsCollector); err != nil {
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend: st,
+		Backups: &backupsShim{
+			st:           st,
+			model:        model,
+			agentConfig:  agent.CurrentConfig(),
+			controllerDB: controllerDB,
+		},
+		NewS3Client:      config.NewS3Client,
+		MetricsCollector: metricsCollector,
+		Clock:            config.Clock,
+		Logger:           config.Logger,
+	})
+	if err != nil {
+		config.PrometheusRegisterer.Unregister(metricsCollector)
+		_ = stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	return common.NewCleanupWorker(w, func() {
+		config.PrometheusRegisterer.Unregister(metricsCollector)
+		_ = stTracker.Done()
+	}), nil
+}
+
+func systemStateAndModel(pool *state.StatePool) (*state.State, *state.Model, error) {
+	st, err := pool.SystemState()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return st, model, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/internal/s3client"
+)
+
+type manifoldSuite struct{}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) validConfig() ManifoldConfig {
+	return ManifoldConfig{
+		AgentName:            "agent",
+		StateName:            "state",
+		DBAccessorName:       "db-accessor",
+		Clock:                clock.WallClock,
+		Logger:               loggo.GetLogger("test"),
+		PrometheusRegisterer: prometheus.NewRegistry(),
+		NewMetricsCollector:  NewMetricsCollector,
+		NewS3Client:          s3client.NewExternalS3Client,
+		NewWorker:            func(Config) (worker.Worker, error) { return nil, nil },
+	}
+}
+
+func (s *manifoldSuite) TestValidateConfig(c *gc.C) {
+	cfg := s.validConfig()
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+
+	cfg.StateName = ""
+	c.Check(cfg.Validate(), jc.ErrorIs, errors.NotValid)
+
+	cfg = s.validConfig()
+	cfg.DBAccessorName = ""
+	c.Check(cfg.Validate(), jc.ErrorIs, errors.NotValid)
+
+	cfg = s.validConfig()
+	cfg.NewS3Client = nil
+	c.Check(cfg.Validate(), jc.ErrorIs, errors.NotValid)
+
+	cfg = s.validConfig()
+	cfg.PrometheusRegisterer = nil
+	c.Check(cfg.Validate(), jc.ErrorIs, errors.NotValid)
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Check(Manifold(s.validConfig()).Inputs, jc.SameContents, []string{
+		"agent", "state", "db-accessor",
+	})
+}
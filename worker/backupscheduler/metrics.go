@@ -0,0 +1,92 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	backupsMetricsNamespace   = "juju"
+	backupsSubsystemNamespace = "backups"
+
+	resultLabel   = "result"
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// Collector defines a prometheus collector for scheduled backups.
+type Collector struct {
+	Backups      *prometheus.CounterVec
+	Uploads      *prometheus.CounterVec
+	Removed      prometheus.Counter
+	LastSuccess  prometheus.Gauge
+	LastDuration prometheus.Gauge
+	LastSize     prometheus.Gauge
+}
+
+// NewMetricsCollector returns a new Collector.
+func NewMetricsCollector() *Collector {
+	return &Collector{
+		Backups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "scheduled_total",
+			Help:      "Total number of scheduled backups, by result.",
+		}, []string{resultLabel}),
+		Uploads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "uploads_total",
+			Help:      "Total number of scheduled backup uploads, by result.",
+		}, []string{resultLabel}),
+		Removed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "removed_total",
+			Help:      "Total number of scheduled backups removed by the retention policy.",
+		}),
+		LastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "The time the last successful scheduled backup finished.",
+		}),
+		LastDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "last_duration_seconds",
+			Help:      "How long the last successful scheduled backup took.",
+		}),
+		LastSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: backupsMetricsNamespace,
+			Subsystem: backupsSubsystemNamespace,
+			Name:      "last_size_bytes",
+			Help:      "The size of the last successful scheduled backup archive.",
+		}),
+	}
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		c.Backups,
+		c.Uploads,
+		c.Removed,
+		c.LastSuccess,
+		c.LastDuration,
+		c.LastSize,
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
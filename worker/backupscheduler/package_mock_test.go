@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/juju/juju/worker/backupscheduler (interfaces: Backend,Backups)
+//
+// Generated by this command:
+//
+//	mockgen -package backupscheduler -destination package_mock_test.go github.com/juju/juju/worker/backupscheduler Backend,Backups
+//
+
+// Package backupscheduler is a generated GoMock package.
+package backupscheduler
+
+import (
+	reflect "reflect"
+
+	controller "github.com/juju/juju/controller"
+	status "github.com/juju/juju/core/status"
+	state "github.com/juju/juju/state"
+	backups "github.com/juju/juju/state/backups"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackend is a mock of Backend interface.
+type MockBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackendMockRecorder
+}
+
+// MockBackendMockRecorder is the mock recorder for MockBackend.
+type MockBackendMockRecorder struct {
+	mock *MockBackend
+}
+
+// NewMockBackend creates a new mock instance.
+func NewMockBackend(ctrl *gomock.Controller) *MockBackend {
+	mock := &MockBackend{ctrl: ctrl}
+	mock.recorder = &MockBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
+	return m.recorder
+}
+
+// ControllerConfig mocks base method.
+func (m *MockBackend) ControllerConfig() (controller.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControllerConfig")
+	ret0, _ := ret[0].(controller.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControllerConfig indicates an expected call of ControllerConfig.
+func (mr *MockBackendMockRecorder) ControllerConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControllerConfig", reflect.TypeOf((*MockBackend)(nil).ControllerConfig))
+}
+
+// SetBackupStatus mocks base method.
+func (m *MockBackend) SetBackupStatus(arg0 status.StatusInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBackupStatus", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBackupStatus indicates an expected call of SetBackupStatus.
+func (mr *MockBackendMockRecorder) SetBackupStatus(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackupStatus", reflect.TypeOf((*MockBackend)(nil).SetBackupStatus), arg0)
+}
+
+// WatchControllerConfig mocks base method.
+func (m *MockBackend) WatchControllerConfig() state.NotifyWatcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchControllerConfig")
+	ret0, _ := ret[0].(state.NotifyWatcher)
+	return ret0
+}
+
+// WatchControllerConfig indicates an expected call of WatchControllerConfig.
+func (mr *MockBackendMockRecorder) WatchControllerConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchControllerConfig", reflect.TypeOf((*MockBackend)(nil).WatchControllerConfig))
+}
+
+// MockBackups is a mock of Backups interface.
+type MockBackups struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupsMockRecorder
+}
+
+// MockBackupsMockRecorder is the mock recorder for MockBackups.
+type MockBackupsMockRecorder struct {
+	mock *MockBackups
+}
+
+// NewMockBackups creates a new mock instance.
+func NewMockBackups(ctrl *gomock.Controller) *MockBackups {
+	mock := &MockBackups{ctrl: ctrl}
+	mock.recorder = &MockBackupsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackups) EXPECT() *MockBackupsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBackups) Create(arg0 string) (*backups.Metadata, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*backups.Metadata)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBackupsMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBackups)(nil).Create), arg0)
+}
+
+// List mocks base method.
+func (m *MockBackups) List() ([]backups.StoredBackup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]backups.StoredBackup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBackupsMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBackups)(nil).List))
+}
+
+// Remove mocks base method.
+func (m *MockBackups) Remove(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockBackupsMockRecorder) Remove(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockBackups)(nil).Remove), arg0)
+}
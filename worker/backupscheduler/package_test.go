@@ -0,0 +1,17 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"testing"
+
+	gc "gopkg.in/check.v1"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -package backupscheduler -destination package_mock_test.go github.com/juju/juju/worker/backupscheduler Backend,Backups
+//go:generate go run go.uber.org/mock/mockgen -package backupscheduler -destination s3client_mock_test.go github.com/juju/juju/internal/s3client Session
+
+func TestPackage(t *testing.T) {
+	gc.TestingT(t)
+}
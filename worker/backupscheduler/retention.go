@@ -0,0 +1,62 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+)
+
+// Backup identifies a stored scheduled backup.
+type Backup struct {
+	Filename string
+	Started  time.Time
+}
+
+// Retention describes which scheduled backups are kept.
+type Retention struct {
+	// KeepLast is the number of the most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days, including today, for which the
+	// most recent backup taken on each day is kept.
+	KeepDaily int
+}
+
+// Expired returns the backups which aren't kept by the retention policy,
+// oldest first. Days are measured in UTC. When neither rule is set, no
+// backups expire.
+func (r Retention) Expired(backups []Backup, now time.Time) []Backup {
+	if r.KeepLast <= 0 && r.KeepDaily <= 0 {
+		return nil
+	}
+
+	sorted := append([]Backup(nil), backups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	oldestDay := today.AddDate(0, 0, 1-r.KeepDaily)
+	keptDays := make(map[time.Time]bool)
+
+	var expired []Backup
+	for i, backup := range sorted {
+		// A backup kept because it is one of the most recent also
+		// counts as the daily backup for its day.
+		day := backup.Started.UTC().Truncate(24 * time.Hour)
+		inWindow := r.KeepDaily > 0 && !day.Before(oldestDay)
+		if i < r.KeepLast || (inWindow && !keptDays[day]) {
+			keptDays[day] = true
+			continue
+		}
+		expired = append(expired, backup)
+	}
+
+	// Report the oldest first, so they're removed in order.
+	for i, j := 0, len(expired)-1; i < j; i, j = i+1, j-1 {
+		expired[i], expired[j] = expired[j], expired[i]
+	}
+	return expired
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type retentionSuite struct{}
+
+var _ = gc.Suite(&retentionSuite{})
+
+var retentionNow = time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+func backupsAt(times ...time.Time) []Backup {
+	result := make([]Backup, len(times))
+	for i, t := range times {
+		result[i] = Backup{
+			Filename: t.Format("backup-20060102-1504"),
+			Started:  t,
+		}
+	}
+	return result
+}
+
+func filenames(backups []Backup) []string {
+	var result []string
+	for _, b := range backups {
+		result = append(result, b.Filename)
+	}
+	return result
+}
+
+func (s *retentionSuite) TestNoRetention(c *gc.C) {
+	backups := backupsAt(retentionNow, retentionNow.Add(-time.Hour))
+	c.Check(Retention{}.Expired(backups, retentionNow), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestKeepLast(c *gc.C) {
+	backups := backupsAt(
+		retentionNow.Add(-2*time.Hour),
+		retentionNow,
+		retentionNow.Add(-3*time.Hour),
+		retentionNow.Add(-time.Hour),
+	)
+	expired := Retention{KeepLast: 2}.Expired(backups, retentionNow)
+	c.Check(filenames(expired), jc.DeepEquals, []string{
+		"backup-20240110-0900",
+		"backup-20240110-1000",
+	})
+}
+
+func (s *retentionSuite) TestKeepDaily(c *gc.C) {
+	backups := backupsAt(
+		retentionNow,
+		retentionNow.Add(-time.Hour),
+		retentionNow.Add(-24*time.Hour),
+		retentionNow.Add(-25*time.Hour),
+		retentionNow.Add(-48*time.Hour),
+	)
+	expired := Retention{KeepDaily: 2}.Expired(backups, retentionNow)
+	c.Check(filenames(expired), jc.DeepEquals, []string{
+		"backup-20240108-1200",
+		"backup-20240109-1100",
+		"backup-20240110-1100",
+	})
+}
+
+func (s *retentionSuite) TestKeepLastAndDaily(c *gc.C) {
+	backups := backupsAt(
+		retentionNow,
+		retentionNow.Add(-time.Hour),
+		retentionNow.Add(-2*time.Hour),
+		retentionNow.Add(-24*time.Hour),
+		retentionNow.Add(-25*time.Hour),
+		retentionNow.Add(-72*time.Hour),
+	)
+	// The most recent two are kept, and also count as today's daily
+	// backup, so only the newest from yesterday is kept by the daily
+	// rule.
+	expired := Retention{KeepLast: 2, KeepDaily: 3}.Expired(backups, retentionNow)
+	c.Check(filenames(expired), jc.DeepEquals, []string{
+		"backup-20240107-1200",
+		"backup-20240109-1100",
+		"backup-20240110-1000",
+	})
+}
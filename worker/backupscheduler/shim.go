@@ -0,0 +1,134 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v3"
+	"github.com/juju/replicaset/v3"
+
+	"github.com/juju/juju/agent"
+	corebase "github.com/juju/juju/core/base"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// backupsShim takes backups of the controller the agent is running on,
+// in the same way as the Backups facade.
+type backupsShim struct {
+	st           *state.State
+	model        *state.Model
+	agentConfig  agent.Config
+	controllerDB backups.ControllerDB
+}
+
+// backups returns the backups for the backup directory currently in
+// the controller model config.
+func (b *backupsShim) backups() (backups.Backups, error) {
+	modelConfig, err := b.model.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return backups.NewBackups(&backups.Paths{
+		BackupDir: backups.BackupDirToUse(modelConfig.BackupDir()),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}), nil
+}
+
+// Create implements Backups.
+func (b *backupsShim) Create(notes string) (*backups.Metadata, string, error) {
+	api, err := b.backups()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, "", errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, "", errors.New("no mongo info found in agent config")
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, sessionShim{session})
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	dbInfo.ControllerDB = b.controllerDB
+
+	machineID := b.agentConfig.Tag().Id()
+	m, err := b.st.Machine(machineID)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	mBase, err := corebase.ParseBase(m.Base().OS, m.Base().Channel)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(metadataShim{b.st, b.model}, machineID, mBase.DisplayString())
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Controller.MachineID = machineID
+	instanceID, err := m.InstanceId()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta.Controller.MachineInstanceID = string(instanceID)
+	nodes, err := b.st.ControllerNodes()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	meta.Controller.HANodes = int64(len(nodes))
+
+	fileName, err := api.Create(meta, dbInfo)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return meta, fileName, nil
+}
+
+// List implements Backups.
+func (b *backupsShim) List() ([]backups.StoredBackup, error) {
+	api, err := b.backups()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.List()
+}
+
+// Remove implements Backups.
+func (b *backupsShim) Remove(fileName string) error {
+	api, err := b.backups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.Remove(fileName)
+}
+
+// metadataShim disambiguates the methods used to populate the backup
+// metadata.
+type metadataShim struct {
+	*state.State
+	*state.Model
+}
+
+type sessionShim struct {
+	*mgo.Session
+}
+
+func (s sessionShim) DB(name string) backups.Database {
+	return s.Session.DB(name)
+}
@@ -0,0 +1,327 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/catacomb"
+	"github.com/robfig/cron/v3"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/internal/s3client"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduledNotes are the notes recorded in the metadata of scheduled
+// backups. Only backups with these notes are subject to the retention
+// policy, so that backups taken by hand are never removed.
+const ScheduledNotes = "scheduled backup"
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Errorf(string, ...interface{})
+	Warningf(string, ...interface{})
+	Infof(string, ...interface{})
+	Debugf(string, ...interface{})
+	Tracef(string, ...interface{})
+}
+
+// Backend provides the controller state used by the worker.
+type Backend interface {
+	// ControllerConfig returns the current controller config.
+	ControllerConfig() (controller.Config, error)
+
+	// WatchControllerConfig notifies of changes to the controller
+	// config.
+	WatchControllerConfig() state.NotifyWatcher
+
+	// SetBackupStatus records the status of scheduled backups.
+	SetBackupStatus(status.StatusInfo) error
+}
+
+// Backups creates, lists and removes backups of the controller.
+type Backups interface {
+	// Create takes a backup of the controller with the given notes,
+	// returning its metadata and the file it is stored in.
+	Create(notes string) (*backups.Metadata, string, error)
+
+	// List returns the stored backups.
+	List() ([]backups.StoredBackup, error)
+
+	// Remove removes a stored backup.
+	Remove(fileName string) error
+}
+
+// Config defines the operation of the Worker.
+type Config struct {
+	Backend          Backend
+	Backups          Backups
+	NewS3Client      func(s3client.ExternalConfig, s3client.Logger) (s3client.Session, error)
+	MetricsCollector *Collector
+	Clock            clock.Clock
+	Logger           Logger
+}
+
+// Validate returns an error if config cannot drive the Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.NewS3Client == nil {
+		return errors.NotValidf("nil NewS3Client")
+	}
+	if config.MetricsCollector == nil {
+		return errors.NotValidf("nil MetricsCollector")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that takes scheduled backups.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker takes backups of the controller on the schedule in the
+// controller config.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	schedule string
+	timer    clock.Timer
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	configWatcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+	}()
+
+	for {
+		var timeout <-chan time.Time
+		if w.timer != nil {
+			timeout = w.timer.Chan()
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.updateSchedule(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-timeout:
+			cfg, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.backup(cfg); err != nil {
+				return errors.Trace(err)
+			}
+			w.scheduleNext()
+		}
+	}
+}
+
+// updateSchedule reads the schedule from the controller config, and
+// resets the timer if it has changed.
+func (w *Worker) updateSchedule() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schedule := cfg.BackupSchedule()
+	if schedule == w.schedule {
+		return nil
+	}
+	w.schedule = schedule
+	if schedule == "" {
+		w.config.Logger.Infof("scheduled backups disabled")
+	} else {
+		w.config.Logger.Infof("scheduled backups enabled with schedule %q", schedule)
+	}
+	w.scheduleNext()
+	return nil
+}
+
+// scheduleNext sets the timer to fire at the next time in the schedule.
+func (w *Worker) scheduleNext() {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if w.schedule == "" {
+		return
+	}
+	// The schedule was validated with the controller config.
+	schedule, err := cron.ParseStandard(w.schedule)
+	if err != nil {
+		w.config.Logger.Errorf("invalid backup schedule %q: %v", w.schedule, err)
+		return
+	}
+	now := w.config.Clock.Now()
+	next := schedule.Next(now.UTC())
+	w.config.Logger.Debugf("next scheduled backup at %s", next.Format(time.RFC3339))
+	w.timer = w.config.Clock.NewTimer(next.Sub(now))
+}
+
+// backup takes a scheduled backup, uploads it and then applies the
+// retention policy. Failures are reported as the backup status and in
+// the metrics, rather than stopping the worker. Only a failure to
+// record the status is returned.
+func (w *Worker) backup(cfg controller.Config) error {
+	logger := w.config.Logger
+	metrics := w.config.MetricsCollector
+
+	if err := w.setStatus(status.Executing, "creating backup", nil); err != nil {
+		return errors.Trace(err)
+	}
+
+	started := w.config.Clock.Now()
+	meta, fileName, err := w.config.Backups.Create(ScheduledNotes)
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		metrics.Backups.WithLabelValues(resultFailure).Inc()
+		return errors.Trace(w.setStatus(status.Error, fmt.Sprintf("backup failed: %v", err), nil))
+	}
+	finished := w.config.Clock.Now()
+	logger.Infof("created scheduled backup %s", fileName)
+	metrics.Backups.WithLabelValues(resultSuccess).Inc()
+	metrics.LastSuccess.Set(float64(finished.Unix()))
+	metrics.LastDuration.Set(finished.Sub(started).Seconds())
+	metrics.LastSize.Set(float64(meta.Size()))
+
+	data := map[string]interface{}{
+		"filename": fileName,
+		"size":     meta.Size(),
+	}
+
+	if bucket := cfg.BackupS3Bucket(); bucket != "" {
+		// The retention policy isn't applied when the upload fails,
+		// so that no local copies are removed.
+		if err := w.upload(cfg, fileName); err != nil {
+			logger.Errorf("uploading scheduled backup %s: %v", fileName, err)
+			metrics.Uploads.WithLabelValues(resultFailure).Inc()
+			return errors.Trace(w.setStatus(status.Error,
+				fmt.Sprintf("backup created but upload to bucket %q failed: %v", bucket, err), data))
+		}
+		metrics.Uploads.WithLabelValues(resultSuccess).Inc()
+		data["bucket"] = bucket
+	}
+
+	w.applyRetention(cfg)
+
+	return errors.Trace(w.setStatus(status.Active, "backup created", data))
+}
+
+func (w *Worker) upload(cfg controller.Config, fileName string) error {
+	session, err := w.config.NewS3Client(s3client.ExternalConfig{
+		Endpoint:  cfg.BackupS3Endpoint(),
+		Region:    cfg.BackupS3Region(),
+		AccessKey: cfg.BackupS3AccessKey(),
+		SecretKey: cfg.BackupS3SecretKey(),
+	}, w.config.Logger)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	archive, err := os.Open(fileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	ctx := w.catacomb.Context(context.Background())
+	return errors.Trace(session.PutObject(ctx, cfg.BackupS3Bucket(), filepath.Base(fileName), archive))
+}
+
+// applyRetention removes the scheduled backups that aren't kept by the
+// retention policy. Failures are logged, and retried after the next
+// scheduled backup.
+func (w *Worker) applyRetention(cfg controller.Config) {
+	stored, err := w.config.Backups.List()
+	if err != nil {
+		w.config.Logger.Warningf("listing backups: %v", err)
+		return
+	}
+	var scheduled []Backup
+	for _, b := range stored {
+		if b.Metadata.Notes != ScheduledNotes {
+			continue
+		}
+		scheduled = append(scheduled, Backup{
+			Filename: b.Filename,
+			Started:  b.Metadata.Started,
+		})
+	}
+
+	retention := Retention{
+		KeepLast:  cfg.BackupKeepLast(),
+		KeepDaily: cfg.BackupKeepDaily(),
+	}
+	for _, b := range retention.Expired(scheduled, w.config.Clock.Now()) {
+		if err := w.config.Backups.Remove(b.Filename); err != nil {
+			w.config.Logger.Warningf("removing expired backup %s: %v", b.Filename, err)
+			continue
+		}
+		w.config.Logger.Infof("removed expired backup %s", b.Filename)
+		w.config.MetricsCollector.Removed.Inc()
+	}
+}
+
+func (w *Worker) setStatus(s status.Status, message string, data map[string]interface{}) error {
+	now := w.config.Clock.Now()
+	return errors.Annotate(w.config.Backend.SetBackupStatus(status.StatusInfo{
+		Status:  s,
+		Message: message,
+		Data:    data,
+		Since:   &now,
+	}), "setting backup status")
+}
@@ -0,0 +1,290 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v3"
+	"github.com/juju/worker/v3/workertest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/mock/gomock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/internal/s3client"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workerSuite struct {
+	backend *MockBackend
+	backups *MockBackups
+	session *MockSession
+
+	clock         *testclock.Clock
+	configChanged chan struct{}
+	metrics       *Collector
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+// workerNow is half an hour before the daily schedule used by the tests.
+var workerNow = time.Date(2024, 1, 10, 2, 30, 0, 0, time.UTC)
+
+func (s *workerSuite) setupMocks(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.backend = NewMockBackend(ctrl)
+	s.backups = NewMockBackups(ctrl)
+	s.session = NewMockSession(ctrl)
+
+	s.clock = testclock.NewClock(workerNow)
+	s.configChanged = make(chan struct{}, 1)
+	s.configChanged <- struct{}{}
+	s.metrics = NewMetricsCollector()
+
+	s.backend.EXPECT().WatchControllerConfig().Return(watchertest.NewNotifyWatcher(s.configChanged))
+	return ctrl
+}
+
+func (s *workerSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := NewWorker(Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		NewS3Client: func(cfg s3client.ExternalConfig, _ s3client.Logger) (s3client.Session, error) {
+			c.Check(cfg, jc.DeepEquals, s3client.ExternalConfig{
+				Endpoint:  "https://s3.example.com",
+				AccessKey: "access",
+				SecretKey: "secret",
+			})
+			return s.session, nil
+		},
+		MetricsCollector: s.metrics,
+		Clock:            s.clock,
+		Logger:           loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *workerSuite) newArchive(c *gc.C, started time.Time) (*backups.Metadata, string) {
+	meta := backups.NewMetadata()
+	meta.Started = started
+	meta.Notes = ScheduledNotes
+	err := meta.MarkComplete(1024, "checksum")
+	c.Assert(err, jc.ErrorIsNil)
+
+	fileName := filepath.Join(c.MkDir(), started.Format("juju-backup-20060102-150405.tar.gz"))
+	err = os.WriteFile(fileName, []byte("archive"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return meta, fileName
+}
+
+func (s *workerSuite) waitDone(c *gc.C, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scheduled backup")
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := NewWorker(Config{})
+	c.Assert(err, jc.ErrorIs, errors.NotValid)
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	read := make(chan struct{})
+	s.backend.EXPECT().ControllerConfig().DoAndReturn(func() (controller.Config, error) {
+		close(read)
+		return controller.Config{}, nil
+	})
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.waitDone(c, read)
+
+	// No timer is started when scheduled backups are disabled.
+	err := s.clock.WaitAdvance(24*time.Hour, coretesting.ShortWait, 0)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestScheduledBackup(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := controller.Config{
+		controller.BackupSchedule:  "0 3 * * *",
+		controller.BackupKeepLast:  1,
+		controller.BackupKeepDaily: 0,
+	}
+	s.backend.EXPECT().ControllerConfig().Return(cfg, nil).Times(2)
+
+	started := workerNow.Add(30 * time.Minute)
+	meta, fileName := s.newArchive(c, started)
+	oldMeta, oldFileName := s.newArchive(c, started.Add(-24*time.Hour))
+	manual, manualFileName := s.newArchive(c, started.Add(-48*time.Hour))
+	manual.Notes = "by hand"
+
+	done := make(chan struct{})
+	gomock.InOrder(
+		s.expectStatusCall(status.Executing, "creating backup"),
+		s.backups.EXPECT().Create(ScheduledNotes).Return(meta, fileName, nil),
+		s.backups.EXPECT().List().Return([]backups.StoredBackup{
+			{Filename: fileName, Metadata: meta},
+			{Filename: oldFileName, Metadata: oldMeta},
+			{Filename: manualFileName, Metadata: manual},
+		}, nil),
+		s.backups.EXPECT().Remove(oldFileName).Return(nil),
+		s.backend.EXPECT().SetBackupStatus(gomock.Any()).DoAndReturn(func(sInfo status.StatusInfo) error {
+			c.Check(sInfo.Status, gc.Equals, status.Active)
+			c.Check(sInfo.Message, gc.Equals, "backup created")
+			c.Check(sInfo.Data, jc.DeepEquals, map[string]interface{}{
+				"filename": fileName,
+				"size":     int64(1024),
+			})
+			close(done)
+			return nil
+		}),
+	)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c, done)
+
+	c.Check(testutil.ToFloat64(s.metrics.Backups.WithLabelValues(resultSuccess)), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.metrics.Removed), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.metrics.LastSize), gc.Equals, float64(1024))
+}
+
+func (s *workerSuite) expectStatusCall(st status.Status, message string) *gomock.Call {
+	return s.backend.EXPECT().SetBackupStatus(gomock.Any()).DoAndReturn(func(sInfo status.StatusInfo) error {
+		if sInfo.Status != st || sInfo.Message != message {
+			return errors.Errorf("unexpected status %q %q", sInfo.Status, sInfo.Message)
+		}
+		return nil
+	})
+}
+
+func (s *workerSuite) TestScheduledBackupUpload(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := controller.Config{
+		controller.BackupSchedule:    "0 3 * * *",
+		controller.BackupKeepLast:    0,
+		controller.BackupKeepDaily:   0,
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	}
+	s.backend.EXPECT().ControllerConfig().Return(cfg, nil).Times(2)
+
+	meta, fileName := s.newArchive(c, workerNow.Add(30*time.Minute))
+	done := make(chan struct{})
+	gomock.InOrder(
+		s.expectStatusCall(status.Executing, "creating backup"),
+		s.backups.EXPECT().Create(ScheduledNotes).Return(meta, fileName, nil),
+		s.session.EXPECT().PutObject(gomock.Any(), "backups", filepath.Base(fileName), gomock.Any()).Return(nil),
+		s.backups.EXPECT().List().Return(nil, nil),
+		s.backend.EXPECT().SetBackupStatus(gomock.Any()).DoAndReturn(func(sInfo status.StatusInfo) error {
+			c.Check(sInfo.Status, gc.Equals, status.Active)
+			c.Check(sInfo.Data["bucket"], gc.Equals, "backups")
+			close(done)
+			return nil
+		}),
+	)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c, done)
+
+	c.Check(testutil.ToFloat64(s.metrics.Uploads.WithLabelValues(resultSuccess)), gc.Equals, float64(1))
+}
+
+func (s *workerSuite) TestScheduledBackupUploadFails(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := controller.Config{
+		controller.BackupSchedule:    "0 3 * * *",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	}
+	s.backend.EXPECT().ControllerConfig().Return(cfg, nil).Times(2)
+
+	meta, fileName := s.newArchive(c, workerNow.Add(30*time.Minute))
+	done := make(chan struct{})
+	gomock.InOrder(
+		s.expectStatusCall(status.Executing, "creating backup"),
+		s.backups.EXPECT().Create(ScheduledNotes).Return(meta, fileName, nil),
+		s.session.EXPECT().PutObject(gomock.Any(), "backups", filepath.Base(fileName), gomock.Any()).Return(errors.New("boom")),
+		s.backend.EXPECT().SetBackupStatus(gomock.Any()).DoAndReturn(func(sInfo status.StatusInfo) error {
+			c.Check(sInfo.Status, gc.Equals, status.Error)
+			c.Check(sInfo.Message, gc.Equals, `backup created but upload to bucket "backups" failed: boom`)
+			close(done)
+			return nil
+		}),
+	)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c, done)
+
+	c.Check(testutil.ToFloat64(s.metrics.Backups.WithLabelValues(resultSuccess)), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.metrics.Uploads.WithLabelValues(resultFailure)), gc.Equals, float64(1))
+}
+
+func (s *workerSuite) TestScheduledBackupFails(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	cfg := controller.Config{
+		controller.BackupSchedule: "0 3 * * *",
+	}
+	// The worker carries on after a failure, and schedules the next
+	// backup for the following day.
+	s.backend.EXPECT().ControllerConfig().Return(cfg, nil).Times(2)
+
+	done := make(chan struct{})
+	gomock.InOrder(
+		s.expectStatusCall(status.Executing, "creating backup"),
+		s.backups.EXPECT().Create(ScheduledNotes).Return(nil, "", errors.New("boom")),
+		s.backend.EXPECT().SetBackupStatus(gomock.Any()).DoAndReturn(func(sInfo status.StatusInfo) error {
+			c.Check(sInfo.Status, gc.Equals, status.Error)
+			c.Check(sInfo.Message, gc.Equals, "backup failed: boom")
+			close(done)
+			return nil
+		}),
+	)
+
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	err := s.clock.WaitAdvance(30*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitDone(c, done)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testutil.ToFloat64(s.metrics.Backups.WithLabelValues(resultFailure)), gc.Equals, float64(1))
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/worker/v3/dependency"
+)
+
+// depEngineGraph is the dependency engine report arranged as a graph
+// of manifolds, suitable for rendering as JSON or Graphviz DOT.
+type depEngineGraph struct {
+	State     string          `json:"state"`
+	Error     string          `json:"error,omitempty"`
+	Manifolds []depEngineNode `json:"manifolds"`
+}
+
+// depEngineNode describes a single manifold in the dependency graph.
+type depEngineNode struct {
+	Name       string   `json:"name"`
+	State      string   `json:"state"`
+	Inputs     []string `json:"inputs"`
+	StartCount int      `json:"start-count"`
+	Started    string   `json:"started,omitempty"`
+	Error      string   `json:"error,omitempty"`
+
+	// BlockedBy holds the inputs that are preventing this manifold's
+	// worker from starting: those that are not currently started,
+	// including any not installed in the engine, and those that are
+	// started but that the worker last failed to start without, such
+	// as an unset flag.
+	BlockedBy []string `json:"blocked-by,omitempty"`
+}
+
+// newDepEngineGraph builds a graph from the report of a dependency
+// engine.
+func newDepEngineGraph(report map[string]interface{}) depEngineGraph {
+	graph := depEngineGraph{
+		State: stringValue(report[dependency.KeyState]),
+		Error: stringValue(report[dependency.KeyError]),
+	}
+
+	manifolds, _ := report[dependency.KeyManifolds].(map[string]interface{})
+	names := make([]string, 0, len(manifolds))
+	for name := range manifolds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		details, _ := manifolds[name].(map[string]interface{})
+		node := depEngineNode{
+			Name:    name,
+			State:   stringValue(details[dependency.KeyState]),
+			Inputs:  stringsValue(details[dependency.KeyInputs]),
+			Started: stringValue(details[dependency.KeyLastStart]),
+			Error:   stringValue(details[dependency.KeyError]),
+		}
+		if node.Inputs == nil {
+			node.Inputs = []string{}
+		}
+		node.StartCount, _ = details[dependency.KeyStartCount].(int)
+		for _, input := range node.Inputs {
+			inputDetails, ok := manifolds[input].(map[string]interface{})
+			if !ok || stringValue(inputDetails[dependency.KeyState]) != "started" ||
+				missingDependency(node.Error, input) {
+				node.BlockedBy = append(node.BlockedBy, input)
+			}
+		}
+		graph.Manifolds = append(graph.Manifolds, node)
+	}
+	return graph
+}
+
+// missingDependency returns whether the error reported for a manifold
+// is a missing dependency on the named input. The error is only
+// available as a string, so this relies on the dependency engine, and
+// the flag housing, naming the input that was missing in the error:
+// `"is-controller-flag" not set: dependency not available`.
+func missingDependency(err, input string) bool {
+	if !strings.HasSuffix(err, dependency.ErrMissing.Error()) {
+		return false
+	}
+	return strings.Contains(err, fmt.Sprintf("%q not ", input))
+}
+
+// dotStateColours maps worker states to the colours used to render
+// them as DOT nodes.
+var dotStateColours = map[string]string{
+	"started":  "darkgreen",
+	"starting": "goldenrod",
+	"stopping": "darkorange",
+	"stopped":  "red",
+}
+
+// writeDOT renders the graph in the Graphviz DOT language. Edges run
+// from each input to the manifold that depends on it; edges from
+// inputs blocking a manifold are drawn dashed in red.
+func (g depEngineGraph) writeDOT(w io.Writer) error {
+	var buf strings.Builder
+	buf.WriteString("digraph depengine {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=box, style=rounded];\n")
+	for _, node := range g.Manifolds {
+		label := fmt.Sprintf("%s\n%s", node.Name, node.State)
+		if node.StartCount > 0 {
+			label += fmt.Sprintf(" (%d)", node.StartCount)
+		}
+		if node.Error != "" {
+			label += "\n" + node.Error
+		}
+		colour, ok := dotStateColours[node.State]
+		if !ok {
+			colour = "grey"
+		}
+		fmt.Fprintf(&buf, "  %s [label=%s, color=%s];\n", dotQuote(node.Name), dotQuote(label), colour)
+	}
+	for _, node := range g.Manifolds {
+		blocked := make(map[string]bool, len(node.BlockedBy))
+		for _, input := range node.BlockedBy {
+			blocked[input] = true
+		}
+		for _, input := range node.Inputs {
+			attrs := ""
+			if blocked[input] {
+				attrs = " [color=red, style=dashed]"
+			}
+			fmt.Fprintf(&buf, "  %s -> %s%s;\n", dotQuote(input), dotQuote(node.Name), attrs)
+		}
+	}
+	buf.WriteString("}\n")
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// dotQuote returns s as a quoted DOT identifier.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func stringsValue(v interface{}) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, s := range v {
+			result = append(result, stringValue(s))
+		}
+		return result
+	}
+	return nil
+}
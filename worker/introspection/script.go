@@ -136,6 +136,15 @@ juju_engine_report () {
   juju_agent depengine
 }
 
+juju_engine_graph () {
+  # Optionally takes the output format, dot (the default) or json.
+  local format=dot
+  if test -n "$1"; then
+    format=$1
+  fi
+  juju_agent "depengine?format=$format"
+}
+
 juju_statepool_report () {
   juju_agent statepool
 }
@@ -144,6 +153,20 @@ juju_pubsub_report () {
   juju_agent pubsub
 }
 
+juju_slow_queries () {
+  # Optionally takes the number of statements to report, and --stacks
+  # to include sample stack traces.
+  local top=10
+  local stacks
+  for i in "$@"; do
+    case $i in
+      --stacks) stacks="&stacks=1" ;;
+      *) top=$i ;;
+    esac
+  done
+  juju_agent "slowqueries?top=$top$stacks"
+}
+
 juju_metrics () {
   juju_agent metrics
 }
@@ -193,26 +216,34 @@ juju_start_unit () {
 }
 
 juju_leases () {
-  # This requires some arguments.
+  # Optionally filtered by partial model UUID, namespace and
+  # partial lease (application) names.
   local query
-  local model
-  if [ "$1" = "-m" ]; then
-    if [ "$#" -lt 2 ]; then
-      echo "usage: juju_leases [-m <partial-model-uuid>] [<partial-app-name>...]"
-      return 1
-    fi
-    shift
-    model=$1
-    shift
-    query="&model=$model"
-  fi
-  for i in "$@"; do
-    query="$query&app=$i"
+  while [ "$#" -gt 0 ]; do
+    case $1 in
+      -m|--model)
+        if [ "$#" -lt 2 ]; then
+          echo "usage: juju_leases [-m <partial-model-uuid>] [-n <namespace>] [<partial-app-name>...]"
+          return 1
+        fi
+        query="$query&model=$2"; shift; shift
+      ;;
+      -n|--namespace)
+        if [ "$#" -lt 2 ]; then
+          echo "usage: juju_leases [-m <partial-model-uuid>] [-n <namespace>] [<partial-app-name>...]"
+          return 1
+        fi
+        query="$query&namespace=$2"; shift; shift
+      ;;
+      *)
+        query="$query&app=$1"; shift
+      ;;
+    esac
   done
   if [ -z "$query" ]; then
     juju_agent leases
   else
-    juju_agent "leases?q=y&$query"
+    juju_agent "leases?q=y$query"
   fi
 }
 
@@ -269,6 +300,7 @@ if [ "$shell" = "bash" ]; then
   export -f juju_statepool_report
   export -f juju_statetracker_report
   export -f juju_pubsub_report
+  export -f juju_slow_queries
   export -f juju_presence_report
   export -f juju_machine_lock
   export -f juju_unit_status
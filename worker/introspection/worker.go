@@ -4,11 +4,14 @@
 package introspection
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"runtime"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +23,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/agent"
@@ -41,6 +45,20 @@ type Reporter interface {
 	IntrospectionReport() string
 }
 
+// SlowQueryReporter provides a report of the slowest database statements.
+type SlowQueryReporter interface {
+	// SlowQueryReport returns a report of the top n slow statements,
+	// optionally including sample stack traces.
+	SlowQueryReport(n int, stacks bool) string
+}
+
+// LeaseReporter provides a report of the leases held by the lease manager.
+type LeaseReporter interface {
+	// LeaseReport returns the leases matching the filter, along with
+	// their holders, expiry times and pins.
+	LeaseReport(ctx context.Context, filter lease.Filter) (map[string]interface{}, error)
+}
+
 // Clock represents the ability to wait for a bit.
 type Clock interface {
 	Now() time.Time
@@ -72,6 +90,8 @@ type Config struct {
 	Clock              Clock
 	LocalHub           SimpleHub
 	CentralHub         StructuredHub
+	SlowQueries        SlowQueryReporter
+	Leases             LeaseReporter
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -101,6 +121,8 @@ type socketListener struct {
 	clock              Clock
 	localHub           SimpleHub
 	centralHub         StructuredHub
+	slowQueries        SlowQueryReporter
+	leases             LeaseReporter
 	done               chan struct{}
 }
 
@@ -137,6 +159,8 @@ func NewWorker(config Config) (worker.Worker, error) {
 		clock:              config.Clock,
 		localHub:           config.LocalHub,
 		centralHub:         config.CentralHub,
+		slowQueries:        config.SlowQueries,
+		leases:             config.Leases,
 		done:               make(chan struct{}),
 	}
 	go w.serve()
@@ -223,8 +247,16 @@ func (w *socketListener) RegisterHTTPHandlers(
 	} else {
 		handle("/units", notSupportedHandler{"Units"})
 	}
-	// TODO(leases) - add metrics
-	handle("/leases", notSupportedHandler{"Leases"})
+	if w.slowQueries != nil {
+		handle("/slowqueries", slowQueryHandler{w.slowQueries})
+	} else {
+		handle("/slowqueries", notSupportedHandler{"Slow Queries"})
+	}
+	if w.leases != nil {
+		handle("/leases", leasesHandler{w.leases})
+	} else {
+		handle("/leases", notSupportedHandler{"Leases"})
+	}
 }
 
 type notSupportedHandler struct {
@@ -246,6 +278,27 @@ func (h depengineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "missing dependency engine reporter", http.StatusNotFound)
 		return
 	}
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "yaml":
+	case "json":
+		graph := newDepEngineGraph(h.reporter.Report())
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(graph); err != nil {
+			logger.Errorf("writing dependency engine graph: %v", err)
+		}
+		return
+	case "dot":
+		graph := newDepEngineGraph(h.reporter.Report())
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		if err := graph.writeDOT(w); err != nil {
+			logger.Errorf("writing dependency engine graph: %v", err)
+		}
+		return
+	default:
+		http.Error(w, fmt.Sprintf("invalid format %q, expected yaml, json or dot", format), http.StatusBadRequest)
+		return
+	}
+
 	bytes, err := yaml.Marshal(h.reporter.Report())
 	if err != nil {
 		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
@@ -308,6 +361,70 @@ func (h introspectionReporterHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	fmt.Fprint(w, h.reporter.IntrospectionReport())
 }
 
+// defaultSlowQueryTop is the number of slow statements reported when
+// the top query parameter isn't supplied.
+const defaultSlowQueryTop = 10
+
+type slowQueryHandler struct {
+	reporter SlowQueryReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h slowQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	top := defaultSlowQueryTop
+	if v := q.Get("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("invalid top value: %q", v), http.StatusBadRequest)
+			return
+		}
+		top = n
+	}
+	stacks := q.Get("stacks") != ""
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Slow Query Report:\n\n")
+	fmt.Fprint(w, h.reporter.SlowQueryReport(top, stacks))
+}
+
+type leasesHandler struct {
+	reporter LeaseReporter
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h leasesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The model and app parameters are those used by the
+	// juju_leases shell helper; lease is accepted as an alias of app
+	// as it also matches non-leadership leases.
+	q := r.URL.Query()
+	filter := lease.Filter{
+		ModelUUIDs: q["model"],
+		Namespaces: q["namespace"],
+		Leases:     append(q["app"], q["lease"]...),
+	}
+
+	report, err := h.reporter.LeaseReport(r.Context(), filter)
+	if errors.Is(err, errors.NotYetAvailable) {
+		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+		return
+	}
+	bytes, err := yaml.Marshal(report)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Lease Report\n\n")
+	_, _ = w.Write(bytes)
+}
+
 type presenceHandler struct {
 	presence presence.Recorder
 }
@@ -4,6 +4,7 @@
 package introspection_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub/v2"
 	"github.com/juju/testing"
@@ -24,6 +26,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/agent"
 	_ "github.com/juju/juju/state"
@@ -70,14 +73,16 @@ func (s *suite) TestStartStop(c *gc.C) {
 type introspectionSuite struct {
 	testing.IsolationSuite
 
-	name       string
-	worker     worker.Worker
-	reporter   introspection.DepEngineReporter
-	gatherer   prometheus.Gatherer
-	recorder   presence.Recorder
-	localHub   *pubsub.SimpleHub
-	centralHub introspection.StructuredHub
-	clock      *testclock.Clock
+	name        string
+	worker      worker.Worker
+	reporter    introspection.DepEngineReporter
+	gatherer    prometheus.Gatherer
+	recorder    presence.Recorder
+	localHub    *pubsub.SimpleHub
+	centralHub  introspection.StructuredHub
+	clock       *testclock.Clock
+	slowQueries introspection.SlowQueryReporter
+	leases      introspection.LeaseReporter
 }
 
 var _ = gc.Suite(&introspectionSuite{})
@@ -90,6 +95,8 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	s.reporter = nil
 	s.worker = nil
 	s.recorder = nil
+	s.slowQueries = nil
+	s.leases = nil
 	s.gatherer = newPrometheusGatherer()
 	s.localHub = pubsub.NewSimpleHub(&pubsub.SimpleHubConfig{Logger: loggo.GetLogger("test.localhub")})
 	s.centralHub = pubsub.NewStructuredHub(&pubsub.StructuredHubConfig{Logger: loggo.GetLogger("test.centralhub")})
@@ -107,6 +114,8 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 		Clock:              s.clock,
 		LocalHub:           s.localHub,
 		CentralHub:         s.centralHub,
+		SlowQueries:        s.slowQueries,
+		Leases:             s.leases,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.worker = w
@@ -224,6 +233,121 @@ Dependency Engine Report
 working: true`[1:])
 }
 
+func (s *introspectionSuite) startEngineGraphWorker(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.reporter = &reporter{
+		values: map[string]interface{}{
+			"state": "started",
+			"manifolds": map[string]interface{}{
+				"agent": map[string]interface{}{
+					"state":       "started",
+					"inputs":      []string{},
+					"start-count": 1,
+					"started":     "2024-01-02 03:04:05",
+				},
+				"api-caller": map[string]interface{}{
+					"state":       "stopped",
+					"inputs":      []string{"agent", "flag"},
+					"start-count": 2,
+					"error":       `connection "refused"`,
+				},
+				"is-controller-flag": map[string]interface{}{
+					"state":  "started",
+					"inputs": []string{"agent"},
+				},
+				"migrator": map[string]interface{}{
+					"state":  "stopped",
+					"inputs": []string{"agent", "is-controller-flag"},
+					"error":  `"is-controller-flag" not set: dependency not available`,
+				},
+				"uniter": map[string]interface{}{
+					"state":  "stopped",
+					"inputs": []string{"api-caller"},
+				},
+			},
+		},
+	}
+	s.startWorker(c)
+}
+
+func (s *introspectionSuite) TestEngineReporterJSON(c *gc.C) {
+	s.startEngineGraphWorker(c)
+	response := s.call(c, "/depengine?format=json")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(response.Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Assert(s.body(c, response), jc.JSONEquals, map[string]interface{}{
+		"state": "started",
+		"manifolds": []interface{}{
+			map[string]interface{}{
+				"name":        "agent",
+				"state":       "started",
+				"inputs":      []interface{}{},
+				"start-count": 1,
+				"started":     "2024-01-02 03:04:05",
+			},
+			map[string]interface{}{
+				"name":        "api-caller",
+				"state":       "stopped",
+				"inputs":      []interface{}{"agent", "flag"},
+				"start-count": 2,
+				"error":       `connection "refused"`,
+				"blocked-by":  []interface{}{"flag"},
+			},
+			map[string]interface{}{
+				"name":        "is-controller-flag",
+				"state":       "started",
+				"inputs":      []interface{}{"agent"},
+				"start-count": 0,
+			},
+			map[string]interface{}{
+				"name":        "migrator",
+				"state":       "stopped",
+				"inputs":      []interface{}{"agent", "is-controller-flag"},
+				"start-count": 0,
+				"error":       `"is-controller-flag" not set: dependency not available`,
+				"blocked-by":  []interface{}{"is-controller-flag"},
+			},
+			map[string]interface{}{
+				"name":        "uniter",
+				"state":       "stopped",
+				"inputs":      []interface{}{"api-caller"},
+				"start-count": 0,
+				"blocked-by":  []interface{}{"api-caller"},
+			},
+		},
+	})
+}
+
+func (s *introspectionSuite) TestEngineReporterDOT(c *gc.C) {
+	s.startEngineGraphWorker(c)
+	response := s.call(c, "/depengine?format=dot")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	c.Assert(s.body(c, response), gc.Equals, `
+digraph depengine {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "agent" [label="agent\nstarted (1)", color=darkgreen];
+  "api-caller" [label="api-caller\nstopped (2)\nconnection \"refused\"", color=red];
+  "is-controller-flag" [label="is-controller-flag\nstarted", color=darkgreen];
+  "migrator" [label="migrator\nstopped\n\"is-controller-flag\" not set: dependency not available", color=red];
+  "uniter" [label="uniter\nstopped", color=red];
+  "agent" -> "api-caller";
+  "flag" -> "api-caller" [color=red, style=dashed];
+  "agent" -> "is-controller-flag";
+  "agent" -> "migrator";
+  "is-controller-flag" -> "migrator" [color=red, style=dashed];
+  "api-caller" -> "uniter" [color=red, style=dashed];
+}
+`[1:])
+}
+
+func (s *introspectionSuite) TestEngineReporterInvalidFormat(c *gc.C) {
+	s.startEngineGraphWorker(c)
+	response := s.call(c, "/depengine?format=svg")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusBadRequest)
+	s.assertBody(c, response, `invalid format "svg", expected yaml, json or dot`)
+}
+
 func (s *introspectionSuite) TestMissingPresenceReporter(c *gc.C) {
 	response := s.call(c, "/presence")
 	c.Assert(response.StatusCode, gc.Equals, http.StatusNotFound)
@@ -261,6 +385,80 @@ agent-1  server  42       alive
 `[1:])
 }
 
+func (s *introspectionSuite) TestMissingSlowQueryReporter(c *gc.C) {
+	response := s.call(c, "/slowqueries")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusNotFound)
+	s.assertBody(c, response, `"Slow Queries" introspection not supported`)
+}
+
+func (s *introspectionSuite) TestSlowQueryReporter(c *gc.C) {
+	// We need to make sure the existing worker is shut down
+	// so we can connect to the socket.
+	workertest.CheckKill(c, s.worker)
+	reporter := &slowQueryReporter{}
+	s.slowQueries = reporter
+	s.startWorker(c)
+
+	response := s.call(c, "/slowqueries")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	s.assertBody(c, response, `
+Slow Query Report:
+
+top 10, stacks false`[1:])
+
+	response = s.call(c, "/slowqueries?top=3&stacks=1")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	s.assertBodyContains(c, response, "top 3, stacks true")
+
+	response = s.call(c, "/slowqueries?top=many")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusBadRequest)
+	s.assertBody(c, response, `invalid top value: "many"`)
+}
+
+func (s *introspectionSuite) TestMissingLeaseReporter(c *gc.C) {
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusNotFound)
+	s.assertBody(c, response, `"Leases" introspection not supported`)
+}
+
+func (s *introspectionSuite) TestLeaseReporter(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	reporter := &leaseReporter{}
+	s.leases = reporter
+	s.startWorker(c)
+
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	s.assertBody(c, response, `
+Lease Report
+
+count: 1
+models:
+  deadbeef:
+    application-leadership:
+      mysql:
+        holder: mysql/0`[1:])
+	c.Check(reporter.filter, jc.DeepEquals, lease.Filter{})
+
+	response = s.call(c, "/leases?model=dead&namespace=application-leadership&app=my&lease=sql")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
+	c.Check(reporter.filter, jc.DeepEquals, lease.Filter{
+		ModelUUIDs: []string{"dead"},
+		Namespaces: []string{"application-leadership"},
+		Leases:     []string{"my", "sql"},
+	})
+}
+
+func (s *introspectionSuite) TestLeaseReporterNotStarted(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leases = &leaseReporter{err: errors.NotYetAvailablef("lease manager not started")}
+	s.startWorker(c)
+
+	response := s.call(c, "/leases")
+	c.Assert(response.StatusCode, gc.Equals, http.StatusServiceUnavailable)
+	s.assertBody(c, response, "error: lease manager not started")
+}
+
 func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	response := s.call(c, "/metrics")
 	c.Assert(response.StatusCode, gc.Equals, http.StatusOK)
@@ -401,3 +599,33 @@ func unixSocketHTTPTransport(socketPath string) *http.Transport {
 		},
 	}
 }
+
+type slowQueryReporter struct{}
+
+func (slowQueryReporter) SlowQueryReport(n int, stacks bool) string {
+	return fmt.Sprintf("top %d, stacks %v\n", n, stacks)
+}
+
+type leaseReporter struct {
+	filter lease.Filter
+	err    error
+}
+
+func (r *leaseReporter) LeaseReport(_ context.Context, filter lease.Filter) (map[string]interface{}, error) {
+	r.filter = filter
+	if r.err != nil {
+		return nil, r.err
+	}
+	return map[string]interface{}{
+		"count": 1,
+		"models": map[string]interface{}{
+			"deadbeef": map[string]interface{}{
+				"application-leadership": map[string]interface{}{
+					"mysql": map[string]interface{}{
+						"holder": "mysql/0",
+					},
+				},
+			},
+		},
+	}, nil
+}
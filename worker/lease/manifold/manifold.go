@@ -44,6 +44,10 @@ type ManifoldConfig struct {
 	PrometheusRegisterer prometheus.Registerer
 	NewWorker            func(lease.ManagerConfig) (worker.Worker, error)
 	NewStore             func(lease.StoreConfig) *lease.Store
+
+	// Reporter, if set, is given the lease manager once it has
+	// started so that it can be queried by the introspection worker.
+	Reporter *lease.Reporter
 }
 
 // Validate checks that the config has all the required values.
@@ -118,7 +122,13 @@ func (s *manifoldState) start(context dependency.Context) (worker.Worker, error)
 		LogDir:               s.config.LogDir,
 		PrometheusRegisterer: s.config.PrometheusRegisterer,
 	})
-	return w, errors.Trace(err)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.config.Reporter != nil {
+		s.config.Reporter.SetManager(w)
+	}
+	return w, nil
 }
 
 func (s *manifoldState) output(in worker.Worker, out interface{}) error {
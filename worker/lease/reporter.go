@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/worker/v3"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// Reporter gives the introspection worker visibility of the leases
+// tracked by the lease manager. It is created before the dependency
+// engine, and the lease manager is attached to it once started.
+type Reporter struct {
+	mu      sync.Mutex
+	manager *Manager
+}
+
+// NewReporter returns a reporter for the lease manager.
+func NewReporter() *Reporter {
+	return &Reporter{}
+}
+
+// SetManager attaches the running lease manager to the reporter.
+// Workers that are not lease managers are ignored.
+func (r *Reporter) SetManager(w worker.Worker) {
+	manager, ok := w.(*Manager)
+	if !ok {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.manager = manager
+}
+
+// LeaseReport is the method called by the introspection worker to get
+// the leases to show to the user.
+func (r *Reporter) LeaseReport(ctx context.Context, filter lease.Filter) (map[string]interface{}, error) {
+	r.mu.Lock()
+	manager := r.manager
+	if manager != nil {
+		select {
+		case <-manager.tomb.Dying():
+			// The manager has stopped, and the manifold will attach
+			// its replacement when it starts.
+			r.manager, manager = nil, nil
+		default:
+		}
+	}
+	r.mu.Unlock()
+	if manager == nil {
+		return nil, errors.NotYetAvailablef("lease manager not started")
+	}
+	return manager.LeaseReport(ctx, filter)
+}
+
+// LeaseReport returns the current leases, their holders, expiry and
+// pins, grouped by model and namespace. Only leases matching the
+// filter are included.
+func (manager *Manager) LeaseReport(ctx context.Context, filter lease.Filter) (map[string]interface{}, error) {
+	leases, err := manager.config.Store.Leases(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pinned, err := manager.config.Store.Pinned(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	models := make(map[string]interface{})
+	entry := func(key lease.Key) map[string]interface{} {
+		namespaces, ok := models[key.ModelUUID].(map[string]interface{})
+		if !ok {
+			namespaces = make(map[string]interface{})
+			models[key.ModelUUID] = namespaces
+		}
+		group, ok := namespaces[key.Namespace].(map[string]interface{})
+		if !ok {
+			group = make(map[string]interface{})
+			namespaces[key.Namespace] = group
+		}
+		details, ok := group[key.Lease].(map[string]interface{})
+		if !ok {
+			details = make(map[string]interface{})
+			group[key.Lease] = details
+		}
+		return details
+	}
+
+	now := manager.config.Clock.Now()
+	var count int
+	for key, info := range leases {
+		if !filter.Match(key) {
+			continue
+		}
+		count++
+		details := entry(key)
+		details["holder"] = info.Holder
+		details["expiry"] = info.Expiry.UTC().Format(time.RFC3339)
+		details["expires-in"] = info.Expiry.Sub(now).Round(time.Second).String()
+	}
+	for key, entities := range pinned {
+		if !filter.Match(key) {
+			continue
+		}
+		pinnedBy := append([]string(nil), entities...)
+		sort.Strings(pinnedBy)
+		entry(key)["pinned-by"] = pinnedBy
+	}
+
+	return map[string]interface{}{
+		"count":  count,
+		"models": models,
+	}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ReporterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReporterSuite{})
+
+func (s *ReporterSuite) TestReporterNoManager(c *gc.C) {
+	reporter := lease.NewReporter()
+	_, err := reporter.LeaseReport(context.Background(), corelease.Filter{})
+	c.Assert(err, jc.ErrorIs, errors.NotYetAvailable)
+}
+
+func (s *ReporterSuite) TestReporterManagerStopped(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		reporter := lease.NewReporter()
+		reporter.SetManager(manager)
+
+		manager.Kill()
+		c.Assert(manager.Wait(), jc.ErrorIsNil)
+
+		_, err := reporter.LeaseReport(context.Background(), corelease.Filter{})
+		c.Assert(err, jc.ErrorIs, errors.NotYetAvailable)
+	})
+}
+
+func (s *ReporterSuite) TestLeaseReport(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+			key("other-namespace", "modelUUID", "mysql"): {
+				Holder: "mysql/1",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{method: "Pinned"}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		reporter := lease.NewReporter()
+		reporter.SetManager(manager)
+
+		report, err := reporter.LeaseReport(context.Background(), corelease.Filter{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(report, jc.DeepEquals, map[string]interface{}{
+			"count": 2,
+			"models": map[string]interface{}{
+				"modelUUID": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"redis": map[string]interface{}{
+							"holder":     "redis/0",
+							"expiry":     offset(time.Minute).UTC().Format(time.RFC3339),
+							"expires-in": "1m0s",
+							"pinned-by":  []string{"machine-0"},
+						},
+					},
+					"other-namespace": map[string]interface{}{
+						"mysql": map[string]interface{}{
+							"holder":     "mysql/1",
+							"expiry":     offset(time.Second).UTC().Format(time.RFC3339),
+							"expires-in": "1s",
+						},
+					},
+				},
+				"ignored modelUUID": map[string]interface{}{
+					"ignored-namespace": map[string]interface{}{
+						"lolwut": map[string]interface{}{
+							"pinned-by": []string{"machine-666"},
+						},
+					},
+				},
+			},
+		})
+	})
+}
+
+func (s *ReporterSuite) TestLeaseReportFiltered(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+			key("other-namespace", "modelUUID", "mysql"): {
+				Holder: "mysql/1",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{method: "Pinned"}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		report, err := manager.LeaseReport(context.Background(), corelease.Filter{
+			ModelUUIDs: []string{"model"},
+			Namespaces: []string{"namespace"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(report, jc.DeepEquals, map[string]interface{}{
+			"count": 1,
+			"models": map[string]interface{}{
+				"modelUUID": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"redis": map[string]interface{}{
+							"holder":     "redis/0",
+							"expiry":     offset(time.Minute).UTC().Format(time.RFC3339),
+							"expires-in": "1m0s",
+							"pinned-by":  []string{"machine-0"},
+						},
+					},
+				},
+			},
+		})
+	})
+}
@@ -107,8 +107,10 @@ WHERE  type = ?;`[1:]
 	err := s.trackedDB.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		d := fmt.Sprintf("+%d seconds", int64(math.Ceil(req.Duration.Seconds())))
 
-		_, err := tx.ExecContext(ctx, q, uuid, key.ModelUUID, key.Lease, req.Holder, d, key.Namespace)
-		return errors.Trace(err)
+		if _, err := tx.ExecContext(ctx, q, uuid, key.ModelUUID, key.Lease, req.Holder, d, key.Namespace); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(recordHistory(ctx, tx, key, lease.HistoryClaim, req.Holder))
 	})
 	if database.IsErrConstraintUnique(err) {
 		return lease.ErrHeld
@@ -149,7 +151,10 @@ WHERE  uuid = (
 				err = lease.ErrInvalid
 			}
 		}
-		return errors.Trace(err)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(recordExtension(ctx, tx, key, req.Holder))
 	})
 	return errors.Trace(err)
 }
@@ -179,6 +184,13 @@ WHERE  uuid = (
 				err = lease.ErrInvalid
 			}
 		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		// The lease has gone, so the history is recorded from the key.
+		_, err = tx.ExecContext(ctx, historyFromKey,
+			key.ModelUUID, key.Lease, holder, key.Namespace, string(lease.HistoryRevoke))
 		return errors.Trace(err)
 	})
 	return errors.Trace(err)
@@ -219,8 +231,14 @@ AND    l.model_uuid = ?
 AND    l.name = ?;`[1:]
 
 	err := s.trackedDB.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, q, utils.MustNewUUID().String(), entity, key.Namespace, key.ModelUUID, key.Lease)
-		return errors.Trace(err)
+		result, err := tx.ExecContext(ctx, q, utils.MustNewUUID().String(), entity, key.Namespace, key.ModelUUID, key.Lease)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			return errors.Trace(err)
+		}
+		return errors.Trace(recordHistory(ctx, tx, key, lease.HistoryPin, entity))
 	})
 	if database.IsErrConstraintUnique(err) {
 		return nil
@@ -247,8 +265,14 @@ WHERE  uuid = (
     AND    p.entity_id = ?   
 );`[1:]
 	err := s.trackedDB.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, q, key.Namespace, key.ModelUUID, key.Lease, entity)
-		return errors.Trace(err)
+		result, err := tx.ExecContext(ctx, q, key.Namespace, key.ModelUUID, key.Lease, entity)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			return errors.Trace(err)
+		}
+		return errors.Trace(recordHistory(ctx, tx, key, lease.HistoryUnpin, entity))
 	})
 	return errors.Trace(err)
 }
@@ -295,6 +319,135 @@ ORDER BY l.uuid;`[1:]
 	return result, errors.Trace(err)
 }
 
+// LeaseHistory returns the recorded changes to the lease indicated by
+// the input key, oldest first. If limit is greater than zero, only the
+// most recent limit changes are returned.
+func (s *Store) LeaseHistory(ctx context.Context, key lease.Key, limit int) ([]lease.HistoryEntry, error) {
+	q := `
+SELECT * FROM (
+    SELECT   h.id, a.action, h.entity, h.expiry, h.created_at
+    FROM     lease_history h
+             JOIN lease_type t ON h.lease_type_id = t.id
+             JOIN lease_history_action a ON h.action_id = a.id
+    WHERE    t.type = ?
+    AND      h.model_uuid = ?
+    AND      h.name = ?
+    ORDER BY h.id DESC
+    LIMIT    ?
+)
+ORDER BY id;`[1:]
+
+	if limit <= 0 {
+		// A negative limit means no limit in SQLite.
+		limit = -1
+	}
+
+	var result []lease.HistoryEntry
+	err := s.trackedDB.Txn(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, q, key.Namespace, key.ModelUUID, key.Lease, limit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		result = nil
+		for rows.Next() {
+			var (
+				id     int64
+				action string
+				entity sql.NullString
+				expiry sql.NullTime
+			)
+			entry := lease.HistoryEntry{Key: key}
+			if err := rows.Scan(&id, &action, &entity, &expiry, &entry.Time); err != nil {
+				return errors.Trace(err)
+			}
+			entry.Action = lease.HistoryAction(action)
+			entry.Entity = entity.String
+			entry.Expiry = expiry.Time
+			result = append(result, entry)
+		}
+		return errors.Trace(rows.Err())
+	})
+	return result, errors.Trace(err)
+}
+
+// historyFromLease records a change to an existing lease, along with
+// its expiry following the change.
+const historyFromLease = `
+INSERT INTO lease_history (lease_type_id, model_uuid, name, action_id, entity, expiry)
+SELECT l.lease_type_id, l.model_uuid, l.name, a.id, ?, l.expiry
+FROM   lease l
+       JOIN lease_type t ON l.lease_type_id = t.id,
+       lease_history_action a
+WHERE  a.action = ?
+AND    t.type = ?
+AND    l.model_uuid = ?
+AND    l.name = ?;`
+
+// historyFromKey records a change to a lease that no longer exists.
+const historyFromKey = `
+INSERT INTO lease_history (lease_type_id, model_uuid, name, action_id, entity)
+SELECT t.id, ?, ?, a.id, ?
+FROM   lease_type t, lease_history_action a
+WHERE  t.type = ?
+AND    a.action = ?;`
+
+// recordHistory appends an entry for the lease indicated by the input
+// key to the lease history, as part of the input transaction.
+func recordHistory(ctx context.Context, tx *sql.Tx, key lease.Key, action lease.HistoryAction, entity string) error {
+	_, err := tx.ExecContext(ctx, historyFromLease, entity, string(action), key.Namespace, key.ModelUUID, key.Lease)
+	return errors.Trace(err)
+}
+
+// extendHistory updates the expiry of the last change to a lease, if
+// it is the input holder's claim or extension.
+const extendHistory = `
+UPDATE lease_history
+SET    expiry = (
+    SELECT l.expiry
+    FROM   lease l JOIN lease_type t ON l.lease_type_id = t.id
+    WHERE  t.type = ?
+    AND    l.model_uuid = ?
+    AND    l.name = ?
+)
+WHERE  id = (
+    SELECT   h.id
+    FROM     lease_history h JOIN lease_type t ON h.lease_type_id = t.id
+    WHERE    t.type = ?
+    AND      h.model_uuid = ?
+    AND      h.name = ?
+    ORDER BY h.id DESC
+    LIMIT    1
+)
+AND    entity = ?
+AND    action_id IN (
+    SELECT id FROM lease_history_action WHERE action IN (?, ?)
+);`
+
+// recordExtension records the input holder extending the lease
+// indicated by the input key, as part of the input transaction. The
+// history isn't appended to when the lease's last change was the
+// holder's claim or extension, so that regularly extended leases don't
+// fill it; the expiry of that change is updated instead.
+func recordExtension(ctx context.Context, tx *sql.Tx, key lease.Key, holder string) error {
+	result, err := tx.ExecContext(ctx, extendHistory,
+		key.Namespace, key.ModelUUID, key.Lease,
+		key.Namespace, key.ModelUUID, key.Lease,
+		holder, string(lease.HistoryClaim), string(lease.HistoryExtend))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+	return errors.Trace(recordHistory(ctx, tx, key, lease.HistoryExtend, holder))
+}
+
 // leasesFromRows returns lease info from rows returned from the backing DB.
 func leasesFromRows(rows *sql.Rows) (map[lease.Key]lease.Info, error) {
 	result := map[lease.Key]lease.Info{}
@@ -254,3 +254,81 @@ func (s *storeSuite) TestLeaseOperationCancellation(c *gc.C) {
 	err := s.store.ClaimLease(ctx, key, req)
 	c.Assert(err, gc.ErrorMatches, "context canceled")
 }
+
+func (s *storeSuite) TestLeaseHistory(c *gc.C) {
+	ctx := context.Background()
+	key := corelease.Key{
+		Namespace: "application-leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "postgresql",
+	}
+	req := corelease.Request{
+		Holder:   "postgresql/0",
+		Duration: time.Minute,
+	}
+
+	err := s.store.ClaimLease(ctx, key, req)
+	c.Assert(err, jc.ErrorIsNil)
+	// Extending the lease updates the expiry of the claim.
+	err = s.store.ExtendLease(ctx, key, corelease.Request{Holder: "postgresql/0", Duration: time.Hour})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.PinLease(ctx, key, "machine/6")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Pinning again, and failed operations, are not recorded.
+	err = s.store.PinLease(ctx, key, "machine/6")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.ExtendLease(ctx, key, corelease.Request{Holder: "postgresql/1", Duration: time.Minute})
+	c.Assert(errors.Is(err, corelease.ErrInvalid), jc.IsTrue)
+
+	// An extension following other changes is recorded once.
+	err = s.store.ExtendLease(ctx, key, req)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.ExtendLease(ctx, key, req)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.UnpinLease(ctx, key, "machine/6")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.RevokeLease(ctx, key, "postgresql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Changes to other leases are not included.
+	otherKey := key
+	otherKey.Lease = "mattermost"
+	err = s.store.ClaimLease(ctx, otherKey, corelease.Request{Holder: "mattermost/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.ExtendLease(ctx, otherKey, corelease.Request{Holder: "mattermost/0", Duration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.store.LeaseHistory(ctx, key, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 5)
+
+	type change struct {
+		action corelease.HistoryAction
+		entity string
+	}
+	var changes []change
+	for _, entry := range history {
+		c.Check(entry.Key, gc.Equals, key)
+		c.Check(entry.Time.IsZero(), jc.IsFalse)
+		changes = append(changes, change{action: entry.Action, entity: entry.Entity})
+	}
+	c.Check(changes, jc.DeepEquals, []change{
+		{action: corelease.HistoryClaim, entity: "postgresql/0"},
+		{action: corelease.HistoryPin, entity: "machine/6"},
+		{action: corelease.HistoryExtend, entity: "postgresql/0"},
+		{action: corelease.HistoryUnpin, entity: "machine/6"},
+		{action: corelease.HistoryRevoke, entity: "postgresql/0"},
+	})
+	c.Check(history[0].Expiry.After(time.Now().Add(30*time.Minute).UTC()), jc.IsTrue)
+	c.Check(history[2].Expiry.After(time.Now().UTC()), jc.IsTrue)
+	c.Check(history[4].Expiry.IsZero(), jc.IsTrue)
+
+	// A limit returns the most recent changes, oldest first.
+	history, err = s.store.LeaseHistory(ctx, key, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Action, gc.Equals, corelease.HistoryUnpin)
+	c.Check(history[1].Action, gc.Equals, corelease.HistoryRevoke)
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/dependency"
 
+	"github.com/juju/juju/controller"
 	coredatabase "github.com/juju/juju/core/database"
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
 )
 
 // Logger represents the methods used by the worker to log details.
@@ -23,10 +26,12 @@ type Logger interface {
 type ManifoldConfig struct {
 	ClockName      string
 	DBAccessorName string
+	StateName      string
 
 	Logger Logger
 
-	NewWorker func(Config) (worker.Worker, error)
+	GetControllerConfig func(*state.StatePool) (controller.Config, error)
+	NewWorker           func(Config) (worker.Worker, error)
 }
 
 // Validate checks that the config has all the required values.
@@ -37,9 +42,15 @@ func (c ManifoldConfig) Validate() error {
 	if c.DBAccessorName == "" {
 		return errors.NotValidf("empty DBAccessorName")
 	}
+	if c.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
 	if c.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if c.GetControllerConfig == nil {
+		return errors.NotValidf("nil GetControllerConfig")
+	}
 	if c.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -67,10 +78,26 @@ func (c ManifoldConfig) start(ctx dependency.Context) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 
-	w, err := NewWorker(Config{
-		Clock:     clk,
-		Logger:    c.Logger,
-		TrackedDB: trackedDB,
+	var stTracker workerstate.StateTracker
+	if err := ctx.Get(c.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = stTracker.Done() }()
+
+	controllerConfig, err := c.GetControllerConfig(statePool)
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to get controller config")
+	}
+
+	w, err := c.NewWorker(Config{
+		Clock:         clk,
+		Logger:        c.Logger,
+		TrackedDB:     trackedDB,
+		HistoryMaxAge: controllerConfig.LeaseHistoryMaxAge(),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -85,7 +112,18 @@ func Manifold(cfg ManifoldConfig) dependency.Manifold {
 		Inputs: []string{
 			cfg.ClockName,
 			cfg.DBAccessorName,
+			cfg.StateName,
 		},
 		Start: cfg.start,
 	}
 }
+
+// GetControllerConfig gets the controller config from the given state
+// pool - it's a shim so we can test the manifold without a state suite.
+func GetControllerConfig(pool *state.StatePool) (controller.Config, error) {
+	st, err := pool.SystemState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st.ControllerConfig()
+}
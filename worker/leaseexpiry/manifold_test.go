@@ -4,15 +4,20 @@
 package leaseexpiry_test
 
 import (
+	"time"
+
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3"
 	dt "github.com/juju/worker/v3/dependency/testing"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/database/testing"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/leaseexpiry"
 )
 
@@ -25,7 +30,7 @@ var _ = gc.Suite(&manifoldSuite{})
 func (s *manifoldSuite) TestInputs(c *gc.C) {
 	cfg := newManifoldConfig()
 
-	c.Check(leaseexpiry.Manifold(cfg).Inputs, jc.DeepEquals, []string{"clock-name", "db-accessor-name"})
+	c.Check(leaseexpiry.Manifold(cfg).Inputs, jc.DeepEquals, []string{"clock-name", "db-accessor-name", "state-name"})
 }
 
 func (s *manifoldSuite) TestConfigValidate(c *gc.C) {
@@ -39,10 +44,18 @@ func (s *manifoldSuite) TestConfigValidate(c *gc.C) {
 	cfg.DBAccessorName = ""
 	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
 
+	cfg = validCfg
+	cfg.StateName = ""
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
 	cfg = validCfg
 	cfg.Logger = nil
 	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
 
+	cfg = validCfg
+	cfg.GetControllerConfig = nil
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
 	cfg = validCfg
 	cfg.NewWorker = nil
 	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
@@ -50,10 +63,33 @@ func (s *manifoldSuite) TestConfigValidate(c *gc.C) {
 
 func (s *manifoldSuite) TestStartSuccess(c *gc.C) {
 	cfg := newManifoldConfig()
+	var workerConfig leaseexpiry.Config
+	cfg.NewWorker = func(config leaseexpiry.Config) (worker.Worker, error) {
+		workerConfig = config
+		return leaseexpiry.NewWorker(config)
+	}
+	stTracker := &stubStateTracker{}
 
-	work, err := leaseexpiry.Manifold(cfg).Start(s.newStubContext())
+	work, err := leaseexpiry.Manifold(cfg).Start(s.newStubContext(stTracker))
+	c.Assert(err, jc.ErrorIsNil)
 	c.Check(work, gc.NotNil)
-	c.Check(err, jc.ErrorIsNil)
+	work.Kill()
+	c.Check(work.Wait(), jc.ErrorIsNil)
+
+	c.Check(workerConfig.HistoryMaxAge, gc.Equals, 12*time.Hour)
+	stTracker.CheckCallNames(c, "Use", "Done")
+}
+
+func (s *manifoldSuite) TestStartControllerConfigError(c *gc.C) {
+	cfg := newManifoldConfig()
+	cfg.GetControllerConfig = func(*state.StatePool) (controller.Config, error) {
+		return nil, errors.New("boom")
+	}
+	stTracker := &stubStateTracker{}
+
+	_, err := leaseexpiry.Manifold(cfg).Start(s.newStubContext(stTracker))
+	c.Check(err, gc.ErrorMatches, "unable to get controller config: boom")
+	stTracker.CheckCallNames(c, "Use", "Done")
 }
 
 // newManifoldConfig creates and returns a new ManifoldConfig instance based on
@@ -62,18 +98,42 @@ func newManifoldConfig() leaseexpiry.ManifoldConfig {
 	return leaseexpiry.ManifoldConfig{
 		ClockName:      "clock-name",
 		DBAccessorName: "db-accessor-name",
+		StateName:      "state-name",
 		Logger:         leaseexpiry.StubLogger{},
-		NewWorker:      func(config leaseexpiry.Config) (worker.Worker, error) { return nil, nil },
+		GetControllerConfig: func(*state.StatePool) (controller.Config, error) {
+			return controller.Config{controller.LeaseHistoryMaxAge: "12h"}, nil
+		},
+		NewWorker: func(config leaseexpiry.Config) (worker.Worker, error) { return nil, nil },
 	}
 }
 
-func (s *manifoldSuite) newStubContext() *dt.Context {
+func (s *manifoldSuite) newStubContext(stTracker *stubStateTracker) *dt.Context {
 	return dt.StubContext(nil, map[string]interface{}{
 		"clock-name":       clock.WallClock,
 		"db-accessor-name": stubDBGetter{s.TrackedDB()},
+		"state-name":       stTracker,
 	})
 }
 
+type stubStateTracker struct {
+	jujutesting.Stub
+}
+
+func (s *stubStateTracker) Use() (*state.StatePool, error) {
+	s.MethodCall(s, "Use")
+	return nil, s.NextErr()
+}
+
+func (s *stubStateTracker) Done() error {
+	s.MethodCall(s, "Done")
+	return s.NextErr()
+}
+
+func (s *stubStateTracker) Report() map[string]interface{} {
+	s.MethodCall(s, "Report")
+	return nil
+}
+
 type stubDBGetter struct {
 	trackedDB coredatabase.TrackedDB
 }
@@ -6,6 +6,7 @@ package leaseexpiry
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/juju/clock"
@@ -13,16 +14,30 @@ import (
 	"github.com/juju/worker/v3"
 	"gopkg.in/tomb.v2"
 
+	"github.com/juju/juju/controller"
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/database/txn"
 )
 
+const (
+	// DefaultHistoryMaxAge is how long lease history is kept for
+	// when no other maximum age is configured.
+	DefaultHistoryMaxAge = controller.DefaultLeaseHistoryMaxAge
+
+	// historyPruneInterval is how often old lease history is pruned.
+	historyPruneInterval = time.Minute
+)
+
 // Config encapsulates the configuration options for
 // instantiating a new lease expiry worker.
 type Config struct {
 	Clock     clock.Clock
 	Logger    Logger
 	TrackedDB coredatabase.TrackedDB
+
+	// HistoryMaxAge is how long lease history is kept before it is
+	// pruned. If zero, DefaultHistoryMaxAge is used.
+	HistoryMaxAge time.Duration
 }
 
 // Validate checks whether the worker configuration settings are valid.
@@ -36,6 +51,9 @@ func (cfg Config) Validate() error {
 	if cfg.TrackedDB == nil {
 		return errors.NotValidf("nil TrackedDB")
 	}
+	if cfg.HistoryMaxAge < 0 {
+		return errors.NotValidf("negative HistoryMaxAge")
+	}
 
 	return nil
 }
@@ -46,7 +64,12 @@ type expiryWorker struct {
 	clock     clock.Clock
 	logger    Logger
 	trackedDB coredatabase.TrackedDB
-	dml       string
+
+	historyDML string
+	expiryDML  string
+
+	historyMaxAge time.Duration
+	lastPrune     time.Time
 }
 
 // NewWorker returns a worker that periodically deletes
@@ -58,17 +81,31 @@ func NewWorker(cfg Config) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 
+	historyMaxAge := cfg.HistoryMaxAge
+	if historyMaxAge == 0 {
+		historyMaxAge = DefaultHistoryMaxAge
+	}
+
 	w := &expiryWorker{
 		clock:     cfg.Clock,
 		logger:    cfg.Logger,
 		trackedDB: cfg.TrackedDB,
-		dml: `
+		historyDML: `
+INSERT INTO lease_history (lease_type_id, model_uuid, name, action_id, entity, expiry)
+SELECT l.lease_type_id, l.model_uuid, l.name, a.id, l.holder, l.expiry
+FROM   lease l LEFT JOIN lease_pin p ON l.uuid = p.lease_uuid,
+       lease_history_action a
+WHERE  a.action = 'expire'
+AND    p.uuid IS NULL
+AND    l.expiry < ?`[1:],
+		expiryDML: `
 DELETE FROM lease WHERE uuid in (
     SELECT l.uuid 
     FROM   lease l LEFT JOIN lease_pin p ON l.uuid = p.lease_uuid
     WHERE  p.uuid IS NULL
-    AND    l.expiry < datetime('now')
+    AND    l.expiry < ?
 )`[1:],
+		historyMaxAge: historyMaxAge,
 	}
 
 	w.tomb.Go(w.loop)
@@ -93,6 +130,12 @@ func (w *expiryWorker) loop() error {
 			if err := w.expireLeases(ctx); err != nil {
 				return errors.Trace(err)
 			}
+			if now := w.clock.Now(); now.Sub(w.lastPrune) >= historyPruneInterval {
+				if err := w.pruneHistory(ctx); err != nil {
+					return errors.Trace(err)
+				}
+				w.lastPrune = now
+			}
 			timer.Reset(time.Second)
 		}
 	}
@@ -100,7 +143,17 @@ func (w *expiryWorker) loop() error {
 
 func (w *expiryWorker) expireLeases(ctx context.Context) error {
 	err := w.trackedDB.TxnNoRetry(ctx, func(ctx context.Context, tx *sql.Tx) error {
-		res, err := tx.ExecContext(ctx, w.dml)
+		// Use the same cut-off for recording and deleting the expired
+		// leases, so that every deleted lease has its expiry recorded.
+		var cutoff string
+		err := tx.QueryRowContext(ctx, "SELECT datetime('now')").Scan(&cutoff)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, w.historyDML, cutoff)
+		}
+		var res sql.Result
+		if err == nil {
+			res, err = tx.ExecContext(ctx, w.expiryDML, cutoff)
+		}
 		if err != nil {
 			// TODO (manadart 2022-12-15): This incarnation of the worker runs on
 			// all controller nodes. Retryable errors are those that occur due to
@@ -131,6 +184,35 @@ func (w *expiryWorker) expireLeases(ctx context.Context) error {
 	return errors.Trace(w.trackedDB.Err())
 }
 
+// pruneHistory removes lease history older than the maximum age.
+func (w *expiryWorker) pruneHistory(ctx context.Context) error {
+	q := `
+DELETE FROM lease_history
+WHERE  created_at < datetime('now', ?)`[1:]
+
+	age := fmt.Sprintf("-%d seconds", int64(w.historyMaxAge.Seconds()))
+	err := w.trackedDB.TxnNoRetry(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, q, age)
+		if err != nil {
+			if txn.IsErrRetryable(err) {
+				w.logger.Debugf("ignoring error during lease history pruning: %s", err.Error())
+				return nil
+			}
+			return errors.Trace(err)
+		}
+
+		pruned, err := res.RowsAffected()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if pruned > 0 {
+			w.logger.Debugf("pruned %d lease history records", pruned)
+		}
+		return nil
+	})
+	return errors.Trace(err)
+}
+
 // Kill is part of the worker.Worker interface.
 func (w *expiryWorker) Kill() {
 	w.tomb.Kill(nil)
@@ -44,6 +44,10 @@ func (s *workerSuite) TestConfigValidate(c *gc.C) {
 	cfg = validCfg
 	cfg.TrackedDB = nil
 	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
+
+	cfg = validCfg
+	cfg.HistoryMaxAge = -time.Second
+	c.Check(errors.Is(cfg.Validate(), errors.NotValid), jc.IsTrue)
 }
 
 func (s *workerSuite) TestWorkerDeletesExpiredLeases(c *gc.C) {
@@ -57,6 +61,7 @@ func (s *workerSuite) TestWorkerDeletesExpiredLeases(c *gc.C) {
 	var wmutex sync.Mutex
 
 	clk.EXPECT().NewTimer(time.Second).Return(timer)
+	clk.EXPECT().Now().Return(time.Now()).AnyTimes()
 
 	// Kill the worker on the first pass through the loop,
 	// after we've processed one expiration.
@@ -105,4 +110,80 @@ VALUES (?, 1, 'some-model-uuid', ?, ?, datetime('now'), datetime('now', ?))`[1:]
 	c.Assert(row.Err(), jc.ErrorIsNil)
 
 	c.Check(name, gc.Equals, "postgresql")
+
+	// The expiry of the redis lease should be recorded in its history.
+	row = s.DB().QueryRow(`
+SELECT h.name, h.entity, a.action
+FROM   lease_history h JOIN lease_history_action a ON h.action_id = a.id`[1:])
+	var entity, action string
+	err = row.Scan(&name, &entity, &action)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(name, gc.Equals, "redis")
+	c.Check(entity, gc.Equals, "redis/0")
+	c.Check(action, gc.Equals, "expire")
+}
+
+func (s *workerSuite) TestWorkerPrunesLeaseHistory(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	clk := NewMockClock(ctrl)
+	timer := NewMockTimer(ctrl)
+
+	var w worker.Worker
+	var wmutex sync.Mutex
+
+	clk.EXPECT().NewTimer(time.Second).Return(timer)
+	clk.EXPECT().Now().Return(time.Now()).AnyTimes()
+
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	timer.EXPECT().Chan().Return(ch).MinTimes(1)
+	timer.EXPECT().Reset(time.Second).Do(func(any) {
+		wmutex.Lock()
+		defer wmutex.Unlock()
+		w.Kill()
+	})
+	timer.EXPECT().Stop().Return(true)
+
+	// Record one change two hours ago and another just now.
+	q := `
+INSERT INTO lease_history (lease_type_id, model_uuid, name, action_id, entity, created_at)
+VALUES (1, 'some-model-uuid', ?, 0, ?, datetime('now', ?))`[1:]
+
+	stmt, err := s.DB().Prepare(q)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stmt.Exec("postgresql", "postgresql/0", "-1 seconds")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stmt.Exec("redis", "redis/0", "-2 hours")
+	c.Assert(err, jc.ErrorIsNil)
+
+	wmutex.Lock()
+	w, err = leaseexpiry.NewWorker(leaseexpiry.Config{
+		Clock:         clk,
+		Logger:        leaseexpiry.StubLogger{},
+		TrackedDB:     s.TrackedDB(),
+		HistoryMaxAge: time.Hour,
+	})
+	wmutex.Unlock()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the recent postgresql record should remain.
+	rows, err := s.DB().Query("SELECT name FROM lease_history")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { _ = rows.Close() }()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		c.Assert(rows.Scan(&name), jc.ErrorIsNil)
+		names = append(names, name)
+	}
+	c.Assert(rows.Err(), jc.ErrorIsNil)
+	c.Check(names, jc.DeepEquals, []string{"postgresql"})
 }
@@ -43,9 +43,6 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -70,6 +67,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkConfig reads the log sink's config. If it is nil the
+	// syslog config is used.
+	SinkConfig LogSinkConfigFn
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -81,7 +82,7 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -96,13 +97,17 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	sinkConfig := lf.args.SinkConfig
+	if sinkConfig == nil {
+		sinkConfig = SyslogConfig
+	}
+	cfg, ok, err := sinkConfig(lf.args.LogForwardConfig)
 	if err != nil {
 		_ = closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		lf.args.Logger.Infof("config change - log forwarding not enabled")
+	if !ok || !cfg.IsEnabled() {
+		lf.args.Logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -150,7 +155,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		lf.args.Logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -235,7 +240,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
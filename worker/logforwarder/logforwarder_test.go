@@ -16,6 +16,8 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/logfwd/otlp"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/rpc/params"
 	coretesting "github.com/juju/juju/testing"
@@ -85,8 +87,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -207,6 +209,42 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestSinkConfig(c *gc.C) {
+	s.stream.addRecords(c, s.rec)
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.SinkConfig = logforwarder.OTLPConfig
+	args.OpenSink = func(cfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+		s.sender.host = cfg.(*otlp.RawConfig).Endpoint
+		return &logforwarder.LogSink{s.sender}, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	rec := s.rec
+	rec.Message = "send to https://10.0.0.1/v1/logs"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSinkNotConfigured(c *gc.C) {
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.SinkConfig = logforwarder.JSONConfig
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
@@ -259,6 +297,17 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) LogForwardJSONConfig() (*jsonstream.RawConfig, bool, error) {
+	return nil, false, nil
+}
+
+func (c *mockLogForwardConfig) LogForwardOTLPConfig() (*otlp.RawConfig, bool, error) {
+	return &otlp.RawConfig{
+		Enabled:  c.enabled,
+		Endpoint: "https://" + c.host + "/v1/logs",
+	}, true, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
@@ -5,6 +5,8 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/logfwd/otlp"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -14,22 +16,74 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
+	// LogForwardConfig returns the current syslog log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// LogForwardJSONConfig returns the current JSON-over-TCP log
+	// forward configuration.
+	LogForwardJSONConfig() (*jsonstream.RawConfig, bool, error)
+
+	// LogForwardOTLPConfig returns the current OTLP/HTTP log forward
+	// configuration.
+	LogForwardOTLPConfig() (*otlp.RawConfig, bool, error)
+}
+
+// LogSinkConfig is the configuration for a single log sink.
+type LogSinkConfig interface {
+	// IsEnabled returns whether forwarding to the sink is enabled.
+	IsEnabled() bool
+
+	// Validate ensures that the config is valid.
+	Validate() error
 }
 
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. It is also used to track
+	// the last record sent to the sink.
 	Name string
 
+	// Config is a function that reads the sink's config. If it is
+	// nil the syslog config is used.
+	Config LogSinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
+// LogSinkConfigFn is a function that reads the config for a log sink.
+type LogSinkConfigFn func(LogForwardConfig) (LogSinkConfig, bool, error)
+
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg LogSinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 }
+
+// SyslogConfig reads the syslog sink config.
+func SyslogConfig(api LogForwardConfig) (LogSinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, true, nil
+}
+
+// JSONConfig reads the JSON-over-TCP sink config.
+func JSONConfig(api LogForwardConfig) (LogSinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardJSONConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, true, nil
+}
+
+// OTLPConfig reads the OTLP/HTTP sink config.
+func OTLPConfig(api LogForwardConfig) (LogSinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardOTLPConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, true, nil
+}
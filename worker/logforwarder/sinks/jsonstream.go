@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenJSON returns a sink that forwards log messages as
+// newline-delimited JSON over TCP.
+func OpenJSON(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*jsonstream.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected JSON log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := jsonstream.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
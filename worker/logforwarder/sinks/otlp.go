@@ -0,0 +1,28 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/otlp"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenOTLP returns a sink that forwards log messages to an
+// OpenTelemetry collector over OTLP/HTTP.
+func OpenOTLP(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*otlp.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected OTLP log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := otlp.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{SendCloser: client}, nil
+}
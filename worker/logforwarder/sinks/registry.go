@@ -0,0 +1,83 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+const (
+	// SyslogSinkName is the name of the syslog sink. It predates
+	// the other sinks, hence the generic name, which is kept so the
+	// last record sent to syslog is still tracked after upgrade.
+	SyslogSinkName = "juju-log-forward"
+
+	// JSONSinkName is the name of the JSON-over-TCP sink.
+	JSONSinkName = "juju-log-forward-json"
+
+	// OTLPSinkName is the name of the OTLP/HTTP sink.
+	OTLPSinkName = "juju-log-forward-otlp"
+)
+
+var (
+	mu       sync.Mutex
+	registry = map[string]logforwarder.LogSinkSpec{}
+)
+
+func init() {
+	for _, spec := range []logforwarder.LogSinkSpec{{
+		Name:   SyslogSinkName,
+		Config: logforwarder.SyslogConfig,
+		OpenFn: OpenSyslog,
+	}, {
+		Name:   JSONSinkName,
+		Config: logforwarder.JSONConfig,
+		OpenFn: OpenJSON,
+	}, {
+		Name:   OTLPSinkName,
+		Config: logforwarder.OTLPConfig,
+		OpenFn: OpenOTLP,
+	}} {
+		if err := Register(spec); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a log sink to the registry. Every registered sink
+// is run by the log forwarder, each with its own log stream.
+func Register(spec logforwarder.LogSinkSpec) error {
+	if spec.Name == "" {
+		return errors.NotValidf("empty sink name")
+	}
+	if spec.Config == nil || spec.OpenFn == nil {
+		return errors.NotValidf("sink %q without config or open functions", spec.Name)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[spec.Name]; ok {
+		return errors.AlreadyExistsf("log sink %q", spec.Name)
+	}
+	registry[spec.Name] = spec
+	return nil
+}
+
+// All returns the registered log sinks, sorted by name.
+func All() []logforwarder.LogSinkSpec {
+	mu.Lock()
+	defer mu.Unlock()
+	specs := make([]logforwarder.LogSinkSpec, 0, len(registry))
+	for _, spec := range registry {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
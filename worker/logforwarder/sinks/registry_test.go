@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd/jsonstream"
+	"github.com/juju/juju/logfwd/otlp"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type RegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) TestBuiltinSinks(c *gc.C) {
+	var names []string
+	for _, spec := range sinks.All() {
+		names = append(names, spec.Name)
+	}
+	c.Assert(names, jc.DeepEquals, []string{
+		"juju-log-forward",
+		"juju-log-forward-json",
+		"juju-log-forward-otlp",
+	})
+}
+
+func (s *RegistrySuite) TestBuiltinSinkConfig(c *gc.C) {
+	api := &stubConfig{}
+	for _, spec := range sinks.All() {
+		cfg, ok, err := spec.Config(api)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ok, jc.IsTrue)
+		switch spec.Name {
+		case sinks.SyslogSinkName:
+			c.Check(cfg, gc.FitsTypeOf, &syslog.RawConfig{})
+		case sinks.JSONSinkName:
+			c.Check(cfg, gc.FitsTypeOf, &jsonstream.RawConfig{})
+		case sinks.OTLPSinkName:
+			c.Check(cfg, gc.FitsTypeOf, &otlp.RawConfig{})
+		}
+	}
+}
+
+func (s *RegistrySuite) TestRegisterDuplicate(c *gc.C) {
+	err := sinks.Register(logforwarder.LogSinkSpec{
+		Name:   sinks.SyslogSinkName,
+		Config: logforwarder.SyslogConfig,
+		OpenFn: sinks.OpenSyslog,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *RegistrySuite) TestRegisterInvalid(c *gc.C) {
+	err := sinks.Register(logforwarder.LogSinkSpec{Name: "incomplete"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *RegistrySuite) TestOpenWrongConfigType(c *gc.C) {
+	_, err := sinks.OpenJSON(&otlp.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `expected JSON log forwarding config, got \*otlp.RawConfig`)
+}
+
+type stubConfig struct{}
+
+func (*stubConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	return nil, errors.NotImplementedf("watching")
+}
+
+func (*stubConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+	return &syslog.RawConfig{}, true, nil
+}
+
+func (*stubConfig) LogForwardJSONConfig() (*jsonstream.RawConfig, bool, error) {
+	return &jsonstream.RawConfig{}, true, nil
+}
+
+func (*stubConfig) LogForwardOTLPConfig() (*otlp.RawConfig, bool, error) {
+	return &otlp.RawConfig{}, true, nil
+}
@@ -12,7 +12,11 @@ import (
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+func OpenSyslog(sinkCfg logforwarder.LogSinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*syslog.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected syslog config, got %T", sinkCfg)
+	}
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
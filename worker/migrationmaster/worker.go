@@ -43,6 +43,11 @@ var (
 	// this case.
 	ErrMigrated = errors.New("model has migrated")
 
+	// errSuspended is returned by a phase handler when the migration
+	// has been suspended at its current phase and the worker should
+	// wait for it to be resumed or aborted.
+	errSuspended = errors.New("migration suspended")
+
 	// utcZero matches the deserialised zero times coming back from
 	// MigrationTarget.LatestLogTime, because they have a non-nil
 	// location.
@@ -73,6 +78,11 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// Suspend pauses the currently active model migration at its
+	// current phase, recording the supplied message, so that it can
+	// later be resumed or aborted.
+	Suspend(string) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -194,6 +204,10 @@ type Worker struct {
 	logger              loggo.Logger
 	lastFailure         string
 	minionReportTimeout time.Duration
+
+	// resumed is set once a suspended migration has been resumed,
+	// so that work already completed on the target can be reused.
+	resumed bool
 }
 
 // Kill implements worker.Worker.
@@ -227,6 +241,13 @@ func (w *Worker) run() error {
 
 	for {
 		var err error
+		if status.Suspended {
+			if status, err = w.waitForResume(); err != nil {
+				return errors.Trace(err)
+			}
+			phase = status.Phase
+		}
+
 		switch phase {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
@@ -248,6 +269,10 @@ func (w *Worker) run() error {
 			return errors.Errorf("unknown phase: %v [%d]", phase.String(), phase)
 		}
 
+		if errors.Is(err, errSuspended) {
+			status.Suspended = true
+			continue
+		}
 		if err != nil {
 			// A phase handler should only return an error if the
 			// migration master should exit. In the face of other
@@ -381,14 +406,30 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 }
 
 func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+	resumable, err := w.transferModel(targetInfo, modelUUID)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
-		return coremigration.ABORT, nil
+		return w.suspendOrAbort(coremigration.IMPORT, resumable)
 	}
 	return coremigration.PROCESSRELATIONS, nil
 }
 
+// suspendOrAbort suspends the migration at the given phase if the
+// failure is resumable, leaving the imported model in place on the
+// target controller. Otherwise the migration is aborted.
+func (w *Worker) suspendOrAbort(phase coremigration.Phase, resumable bool) (coremigration.Phase, error) {
+	if !resumable {
+		return coremigration.ABORT, nil
+	}
+	message := fmt.Sprintf("suspended, %s; resume with \"juju migrate --resume\"", w.lastFailure)
+	if err := w.config.Facade.Suspend(message); err != nil {
+		w.logger.Errorf("failed to suspend migration, aborting: %v", err)
+		return coremigration.ABORT, nil
+	}
+	w.logger.Infof("migration suspended in %s phase", phase)
+	return phase, errSuspended
+}
+
 type uploadWrapper struct {
 	client    *migrationtarget.Client
 	modelUUID string
@@ -419,28 +460,54 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resources.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
+// transferModel exports the model and transfers it, along with its
+// binaries, to the target controller. The returned bool reports
+// whether a failure may be retried by resuming the migration, which
+// is only the case once the model has been imported into a target
+// controller that can report on the binaries it already holds.
+func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) (bool, error) {
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
 	if err != nil {
-		return errors.Annotate(err, "model export failed")
+		return false, errors.Annotate(err, "model export failed")
 	}
 
-	w.setInfoStatus("importing model into target controller")
 	conn, err := w.openAPIConn(targetInfo)
 	if err != nil {
-		return errors.Annotate(err, "failed to connect to target controller")
+		return false, errors.Annotate(err, "failed to connect to target controller")
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
-	if err != nil {
-		return errors.Annotate(err, "failed to import model into target controller")
+	resumable := targetClient.BestFacadeVersion() >= 5
+
+	// When resuming, the model may already have been imported into
+	// the target, in which case only the missing binaries need to be
+	// transferred.
+	var imported coremigration.ImportedBinaries
+	needImport := true
+	if w.resumed && resumable {
+		imported, err = targetClient.ImportedBinaries(modelUUID)
+		switch {
+		case err == nil:
+			w.logger.Infof("model already imported into target controller, skipping import")
+			needImport = false
+		case params.IsCodeNotFound(err):
+		default:
+			return true, errors.Annotate(err, "failed to query binaries imported into target controller")
+		}
+	}
+
+	if needImport {
+		w.setInfoStatus("importing model into target controller")
+		err = targetClient.Import(serialized.Bytes)
+		if err != nil {
+			return false, errors.Annotate(err, "failed to import model into target controller")
+		}
 	}
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
 		// Simulate a abort causing failure to test last status not over written.
-		return errors.New("wrench in the transferModel works")
+		return false, errors.New("wrench in the transferModel works")
 	}
 
 	w.setInfoStatus("uploading model binaries into target controller")
@@ -457,8 +524,10 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Resources:          serialized.Resources,
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		Imported: imported,
 	})
-	return errors.Annotate(err, "failed to migrate binaries")
+	return resumable, errors.Annotate(err, "failed to migrate binaries")
 }
 
 func (w *Worker) doPROCESSRELATIONS(status coremigration.MigrationStatus) (coremigration.Phase, error) {
@@ -497,12 +566,14 @@ func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigrati
 
 	// Check that the provider and target controller agree about what
 	// machines belong to the migrated model.
+	// The model remains imported on the target, so failures from
+	// here on suspend the migration rather than aborting it.
 	ok, err = w.checkTargetMachines(client, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
 	if !ok {
-		return coremigration.ABORT, nil
+		return w.suspendOrAbort(coremigration.VALIDATION, true)
 	}
 
 	// Once all agents have validated, activate the model in the
@@ -510,7 +581,7 @@ func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigrati
 	err = w.activateModel(client, status.ModelUUID)
 	if err != nil {
 		w.setErrorStatus("model activation failed, %v", err)
-		return coremigration.ABORT, nil
+		return w.suspendOrAbort(coremigration.VALIDATION, true)
 	}
 	return coremigration.SUCCESS, nil
 }
@@ -739,6 +810,42 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 	}
 }
 
+// waitForResume blocks until a suspended migration is resumed or
+// aborted, returning the migration's status at that point.
+func (w *Worker) waitForResume() (coremigration.MigrationStatus, error) {
+	var empty coremigration.MigrationStatus
+
+	w.logger.Infof("migration suspended, waiting for it to be resumed or aborted")
+	watcher, err := w.config.Facade.Watch()
+	if err != nil {
+		return empty, errors.Annotate(err, "watching for migration")
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer watcher.Kill()
+
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return empty, w.catacomb.ErrDying()
+		case <-watcher.Changes():
+		}
+
+		status, err := w.config.Facade.MigrationStatus()
+		switch {
+		case err != nil:
+			return empty, errors.Annotate(err, "retrieving migration status")
+		case status.Phase.IsTerminal():
+			return empty, ErrInactive
+		case !status.Suspended:
+			w.logger.Infof("migration resumed in %s phase", status.Phase)
+			w.resumed = true
+			return status, nil
+		}
+	}
+}
+
 // Possible values for waitForMinion's waitPolicy argument.
 const failFast = false  // Stop waiting at first minion failure report
 const waitForAll = true // Wait for all minion reports to arrive (or timeout)
@@ -155,6 +155,22 @@ var (
 		apiCloseCall,
 		{FuncName: "facade.SetPhase", Args: []interface{}{coremigration.ABORTDONE}},
 	}
+	// suspendAbortCalls are the calls made when a suspended migration
+	// is subsequently aborted.
+	suspendAbortCalls = []jujutesting.StubCall{
+		{FuncName: "facade.Watch", Args: nil},
+		{FuncName: "facade.MigrationStatus", Args: nil},
+		apiOpenControllerCall,
+		abortCall,
+		apiCloseCall,
+		{FuncName: "facade.SetPhase", Args: []interface{}{coremigration.ABORTDONE}},
+	}
+	importedBinariesCall = jujutesting.StubCall{
+		FuncName: "MigrationTarget.ImportedBinaries",
+		Args: []interface{}{
+			params.ModelArgs{ModelTag: modelTag.String()},
+		},
+	}
 	openDestLogStreamCall = jujutesting.StubCall{FuncName: "ConnectControllerStream", Args: []interface{}{
 		"/migrate/logtransfer",
 		url.Values{},
@@ -615,6 +631,142 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestUploadBinariesFailureAbortsWithOldTarget(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.config.UploadBinaries = func(migration.UploadBinariesConfig) error {
+		return errors.New("boom")
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{FuncName: "facade.MinionReportTimeout", Args: nil},
+			{FuncName: "facade.Export", Args: nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestUploadBinariesFailureSuspendsAndResumes(c *gc.C) {
+	s.connection.facadeVersion = 5
+	s.connection.importedBinaries = params.MigrationImportedBinaries{
+		Charms: []params.MigrationImportedCharm{{
+			URL:    "charm0",
+			SHA256: "f00d",
+		}},
+		Tools: []params.MigrationImportedTools{{
+			Version: version.MustParseBinary("2.1.0-ubuntu-amd64"),
+			SHA256:  "deadbeef",
+		}},
+		Resources: []params.MigrationImportedResource{{
+			Application: "app",
+			Name:        "blob",
+			Fingerprint: "cafe",
+		}},
+	}
+	s.facade.processRelationsErr = errors.New("stop here")
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	// The user resumes the suspended migration.
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+
+	var uploads []coremigration.ImportedBinaries
+	s.config.UploadBinaries = func(config migration.UploadBinariesConfig) error {
+		uploads = append(uploads, config.Imported)
+		if len(uploads) == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{FuncName: "facade.MinionReportTimeout", Args: nil},
+			{FuncName: "facade.Export", Args: nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+			{FuncName: "facade.Suspend", Args: []interface{}{
+				`suspended, model data transfer failed, failed to migrate binaries: boom; resume with "juju migrate --resume"`,
+			}},
+
+			// Resumed: the model isn't imported again.
+			{FuncName: "facade.Watch", Args: nil},
+			{FuncName: "facade.MigrationStatus", Args: nil},
+			{FuncName: "facade.Export", Args: nil},
+			apiOpenControllerCall,
+			importedBinariesCall,
+			apiCloseCall,
+			{FuncName: "facade.SetPhase", Args: []interface{}{coremigration.PROCESSRELATIONS}},
+			{FuncName: "facade.ProcessRelations", Args: []interface{}{""}},
+		},
+		abortCalls,
+	))
+	c.Assert(uploads, gc.HasLen, 2)
+	c.Check(uploads[0], gc.DeepEquals, coremigration.ImportedBinaries{})
+	c.Check(uploads[1].Charms, gc.DeepEquals, map[string]string{"charm0": "f00d"})
+	c.Check(uploads[1].Tools, gc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.1.0-ubuntu-amd64"): "deadbeef",
+	})
+	c.Check(uploads[1].Resources, gc.DeepEquals, map[string]map[string]string{
+		"app": {"blob": "cafe"},
+	})
+}
+
+func (s *Suite) TestResumeSuspendedMigrationWithoutImportedModel(c *gc.C) {
+	s.connection.facadeVersion = 5
+	s.connection.importedBinariesErr = &params.Error{Code: params.CodeNotFound, Message: "not found"}
+	s.facade.processRelationsErr = errors.New("stop here")
+	s.config.UploadBinaries = makeStubUploadBinaries(s.stub)
+
+	// The worker starts with the migration already suspended.
+	status := s.makeStatus(coremigration.IMPORT)
+	status.Suspended = true
+	s.facade.queueStatus(status)
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCallNames(c, callNames(joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{FuncName: "facade.MinionReportTimeout", Args: nil},
+			{FuncName: "facade.Watch", Args: nil},
+			{FuncName: "facade.MigrationStatus", Args: nil},
+			{FuncName: "facade.Export", Args: nil},
+			apiOpenControllerCall,
+			importedBinariesCall,
+			importCall,
+			{FuncName: "UploadBinaries"},
+			apiCloseCall,
+			{FuncName: "facade.SetPhase", Args: []interface{}{coremigration.PROCESSRELATIONS}},
+			{FuncName: "facade.ProcessRelations", Args: []interface{}{""}},
+		},
+		abortCalls,
+	))...)
+}
+
+func (s *Suite) TestSuspendedMigrationAbortedWhileWaiting(c *gc.C) {
+	status := s.makeStatus(coremigration.VALIDATION)
+	status.Suspended = true
+	s.facade.queueStatus(status)
+	s.facade.queueStatus(s.makeStatus(coremigration.ABORTDONE))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{FuncName: "facade.MinionReportTimeout", Args: nil},
+			{FuncName: "facade.Watch", Args: nil},
+			{FuncName: "facade.MigrationStatus", Args: nil},
+		},
+	))
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -658,6 +810,8 @@ func (s *Suite) TestVALIDATIONFailedAgent(c *gc.C) {
 func (s *Suite) TestVALIDATIONCheckMachinesOneError(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.VALIDATION))
 	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	// The migration is suspended and then aborted by the user.
+	s.facade.queueStatus(s.makeStatus(coremigration.ABORT))
 
 	s.connection.machineErrs = []string{"been so strange"}
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
@@ -669,9 +823,12 @@ func (s *Suite) TestVALIDATIONCheckMachinesOneError(c *gc.C) {
 			{FuncName: "facade.MinionReports", Args: nil},
 			apiOpenControllerCall,
 			checkMachinesCall,
+			{FuncName: "facade.Suspend", Args: []interface{}{
+				`suspended, machine sanity check failed, 1 error found; resume with "juju migrate --resume"`,
+			}},
 			apiCloseCall,
 		},
-		abortCalls,
+		suspendAbortCalls,
 	))
 	lastMessages := s.facade.statuses[len(s.facade.statuses)-2:]
 	c.Assert(lastMessages, gc.DeepEquals, []string{
@@ -683,6 +840,7 @@ func (s *Suite) TestVALIDATIONCheckMachinesOneError(c *gc.C) {
 func (s *Suite) TestVALIDATIONCheckMachinesSeveralErrors(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.VALIDATION))
 	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	s.facade.queueStatus(s.makeStatus(coremigration.ABORT))
 	s.connection.machineErrs = []string{"been so strange", "lit up"}
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
@@ -693,9 +851,12 @@ func (s *Suite) TestVALIDATIONCheckMachinesSeveralErrors(c *gc.C) {
 			{FuncName: "facade.MinionReports", Args: nil},
 			apiOpenControllerCall,
 			checkMachinesCall,
+			{FuncName: "facade.Suspend", Args: []interface{}{
+				`suspended, machine sanity check failed, 2 errors found; resume with "juju migrate --resume"`,
+			}},
 			apiCloseCall,
 		},
-		abortCalls,
+		suspendAbortCalls,
 	))
 	lastMessages := s.facade.statuses[len(s.facade.statuses)-2:]
 	c.Assert(lastMessages, gc.DeepEquals, []string{
@@ -1390,6 +1551,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) Suspend(message string) error {
+	f.stub.AddCall("facade.Suspend", message)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
@@ -1446,6 +1612,9 @@ type stubConnection struct {
 	machineErrs     []string
 	checkMachineErr error
 
+	importedBinaries    params.MigrationImportedBinaries
+	importedBinariesErr error
+
 	facadeVersion int
 
 	controllerVersion params.ControllerVersionResults
@@ -1468,6 +1637,9 @@ func (c *stubConnection) APICall(objType string, _ int, _, request string, args,
 			return c.processRelationsErr
 		case "Activate", "AdoptResources":
 			return nil
+		case "ImportedBinaries":
+			*response.(*params.MigrationImportedBinaries) = c.importedBinaries
+			return c.importedBinariesErr
 		case "LatestLogTime":
 			responseTime := response.(*time.Time)
 			// This is needed because even if a zero time comes back
@@ -0,0 +1,150 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// valueListRegexp matches a parenthesised list of placeholders, once
+	// literals have been replaced, e.g. "(?, ?, ?)".
+	valueListRegexp = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+	// repeatedListRegexp matches repeated collapsed lists, such as those
+	// found in multi-row inserts, e.g. "(?+), (?+)".
+	repeatedListRegexp = regexp.MustCompile(`\(\?\+\)(?:\s*,\s*\(\?\+\))+`)
+)
+
+// Fingerprint returns a normalised form of the statement, so that
+// statements which differ only by their literal values or placeholders
+// are grouped together. Comments are removed, string and numeric literals
+// and bind parameters are replaced with "?", keywords and identifiers are
+// lower cased and whitespace is collapsed. Lists of values, such as the
+// arguments to IN or the rows of an insert, are collapsed to "(?+)" so
+// that the number of values doesn't create a new fingerprint.
+func Fingerprint(stmt string) string {
+	var (
+		b     strings.Builder
+		space bool
+	)
+	writeSpace := func() {
+		if b.Len() > 0 {
+			space = true
+		}
+	}
+	write := func(s string) {
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteString(s)
+	}
+
+	for i := 0; i < len(stmt); i++ {
+		ch := stmt[i]
+		switch {
+		case isSpace(ch):
+			writeSpace()
+
+		case ch == '-' && strings.HasPrefix(stmt[i:], "--"):
+			end := strings.IndexByte(stmt[i:], '\n')
+			if end < 0 {
+				i = len(stmt)
+				break
+			}
+			i += end
+			writeSpace()
+
+		case ch == '/' && strings.HasPrefix(stmt[i:], "/*"):
+			end := strings.Index(stmt[i+2:], "*/")
+			if end < 0 {
+				i = len(stmt)
+				break
+			}
+			i += end + 3
+			writeSpace()
+
+		case ch == '\'':
+			// String literal, where a quote is escaped by doubling it.
+			j := i + 1
+			for ; j < len(stmt); j++ {
+				if stmt[j] != '\'' {
+					continue
+				}
+				if j+1 < len(stmt) && stmt[j+1] == '\'' {
+					j++
+					continue
+				}
+				break
+			}
+			i = j
+			write("?")
+
+		case ch == '"' || ch == '`':
+			// Quoted identifiers are kept verbatim.
+			end := strings.IndexByte(stmt[i+1:], ch)
+			if end < 0 {
+				write(stmt[i:])
+				i = len(stmt)
+				break
+			}
+			write(stmt[i : i+end+2])
+			i += end + 1
+
+		case ch == '?' || ch == '$' || ch == ':' || ch == '@':
+			// Bind parameters: ?, ?NNN, $name, :name and @name.
+			j := i + 1
+			for j < len(stmt) && isIdentifier(stmt[j]) {
+				j++
+			}
+			if ch != '?' && j == i+1 {
+				write(string(ch))
+				break
+			}
+			i = j - 1
+			write("?")
+
+		case isDigit(ch) || (ch == '.' && i+1 < len(stmt) && isDigit(stmt[i+1])):
+			// Numeric literal, including decimals, exponents and hex.
+			j := i + 1
+			for j < len(stmt) && (isIdentifier(stmt[j]) || stmt[j] == '.') {
+				j++
+			}
+			i = j - 1
+			write("?")
+
+		case isIdentifier(ch):
+			j := i + 1
+			for j < len(stmt) && isIdentifier(stmt[j]) {
+				j++
+			}
+			write(strings.ToLower(stmt[i:j]))
+			i = j - 1
+
+		case ch == ';':
+			// Trailing statement terminators don't change the statement.
+			writeSpace()
+
+		default:
+			write(string(ch))
+		}
+	}
+
+	result := valueListRegexp.ReplaceAllString(b.String(), "(?+)")
+	return repeatedListRegexp.ReplaceAllString(result, "(?+)")
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f' || ch == '\v'
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+func isIdentifier(ch byte) bool {
+	return ch == '_' || isDigit(ch) || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch >= 0x80
+}
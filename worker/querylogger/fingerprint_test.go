@@ -0,0 +1,56 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+)
+
+type fingerprintSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&fingerprintSuite{})
+
+func (s *fingerprintSuite) TestFingerprint(c *gc.C) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{{
+		stmt:     "SELECT * FROM foo",
+		expected: "select * from foo",
+	}, {
+		stmt:     "  SELECT *\n\tFROM   foo ; ",
+		expected: "select * from foo",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE id = 42 AND ratio > 0.5 AND mask = 0xFF",
+		expected: "select * from foo where id = ? and ratio > ? and mask = ?",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE name = 'it''s' AND other = ''",
+		expected: "select * from foo where name = ? and other = ?",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE a = ? AND b = ?2 AND c = $c AND d = :d AND e = @e",
+		expected: "select * from foo where a = ? and b = ? and c = ? and d = ? and e = ?",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE id IN (1, 2, 3)",
+		expected: "select * from foo where id in (?+)",
+	}, {
+		stmt:     "SELECT * FROM foo WHERE id IN (?,?)",
+		expected: "select * from foo where id in (?+)",
+	}, {
+		stmt:     "INSERT INTO foo (a, b) VALUES (1, 'x'), (2, 'y'), (3, 'z')",
+		expected: "insert into foo (a, b) values (?+)",
+	}, {
+		stmt:     "SELECT \"Name\", `Other` FROM foo -- trailing comment",
+		expected: "select \"Name\", `Other` from foo",
+	}, {
+		stmt:     "SELECT /* hint */ uuid FROM model_2 WHERE life_id=1",
+		expected: "select uuid from model_2 where life_id=?",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %q", i, test.stmt)
+		c.Check(Fingerprint(test.stmt), gc.Equals, test.expected)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/worker/v3"
 	"github.com/juju/worker/v3/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	coredatabase "github.com/juju/juju/core/database"
 	"github.com/juju/juju/worker/common"
@@ -26,9 +27,12 @@ type Logger interface {
 // - The names of other manifolds on which the DB accessor depends.
 // - Other dependencies from ManifoldsConfig required by the worker.
 type ManifoldConfig struct {
-	LogDir string
-	Clock  clock.Clock
-	Logger Logger
+	LogDir               string
+	Clock                clock.Clock
+	Logger               Logger
+	Profile              *Profile
+	PrometheusRegisterer prometheus.Registerer
+	NewMetricsCollector  func() *Collector
 }
 
 func (cfg ManifoldConfig) Validate() error {
@@ -41,6 +45,15 @@ func (cfg ManifoldConfig) Validate() error {
 	if cfg.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if cfg.Profile == nil {
+		return errors.NotValidf("nil Profile")
+	}
+	if cfg.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
+	if cfg.NewMetricsCollector == nil {
+		return errors.NotValidf("nil NewMetricsCollector")
+	}
 	return nil
 }
 
@@ -55,6 +68,12 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Trace(err)
 			}
 
+			// Register the metrics collector against the prometheus register.
+			metricsCollector := config.NewMetricsCollector()
+			if err := config.PrometheusRegisterer.Register(metricsCollector); err != nil {
+				return nil, errors.Trace(err)
+			}
+
 			cfg := &WorkerConfig{
 				LogDir: config.LogDir,
 				Clock:  config.Clock,
@@ -64,13 +83,20 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 					// include the slow query logger.
 					return debug.Stack()
 				},
+				Profile:          config.Profile,
+				MetricsCollector: metricsCollector,
 			}
 
 			w, err := newWorker(cfg)
 			if err != nil {
+				config.PrometheusRegisterer.Unregister(metricsCollector)
 				return nil, errors.Trace(err)
 			}
-			return w, nil
+			return common.NewCleanupWorker(w, func() {
+				// Clean up the metrics for the worker, so the next time a
+				// worker is created we can safely register the metrics again.
+				config.PrometheusRegisterer.Unregister(metricsCollector)
+			}), nil
 		},
 	}
 }
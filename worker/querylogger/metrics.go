@@ -0,0 +1,39 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	queryloggerMetricsNamespace   = "juju"
+	queryloggerSubsystemNamespace = "db"
+)
+
+// Collector defines a prometheus collector for the slow query logger.
+type Collector struct {
+	SlowQueryDuration *prometheus.HistogramVec
+}
+
+// NewMetricsCollector returns a new Collector.
+func NewMetricsCollector() *Collector {
+	return &Collector{
+		SlowQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: queryloggerMetricsNamespace,
+			Subsystem: queryloggerSubsystemNamespace,
+			Name:      "slow_query_duration_seconds",
+			Help:      "Duration of slow queries by statement fingerprint.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		}, []string{"fingerprint"}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.SlowQueryDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.SlowQueryDuration.Collect(ch)
+}
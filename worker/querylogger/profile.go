@@ -0,0 +1,175 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxStatements is the number of distinct fingerprints that are
+	// tracked. When a new fingerprint is seen and the profile is full,
+	// the fingerprint with the least total time is evicted.
+	maxStatements = 200
+
+	// maxSampleStacks is the number of stack traces that are kept for
+	// each fingerprint. The stacks of the slowest queries are kept.
+	maxSampleStacks = 3
+)
+
+// SampleStack is the stack trace of a single slow query.
+type SampleStack struct {
+	Duration time.Duration
+	Stack    string
+}
+
+// SlowQuery aggregates all of the slow queries recorded for a single
+// statement fingerprint.
+type SlowQuery struct {
+	Fingerprint string
+	Example     string
+	Count       int
+	Total       time.Duration
+	Max         time.Duration
+	LastSeen    time.Time
+	Stacks      []SampleStack
+}
+
+// Mean returns the average duration of the slow queries.
+func (q SlowQuery) Mean() time.Duration {
+	if q.Count == 0 {
+		return 0
+	}
+	return q.Total / time.Duration(q.Count)
+}
+
+// Profile aggregates slow queries by their fingerprint. It lives
+// outside of the dependency engine, so that the introspection worker can
+// report on it regardless of the state of the query logger worker.
+type Profile struct {
+	mu      sync.Mutex
+	queries map[string]*SlowQuery
+}
+
+// NewProfile returns a new, empty, Profile.
+func NewProfile() *Profile {
+	return &Profile{
+		queries: make(map[string]*SlowQuery),
+	}
+}
+
+// Record adds a slow query to the profile. If recording the query caused
+// another fingerprint to be evicted, the evicted fingerprint is returned.
+func (p *Profile) Record(fingerprint, stmt string, duration time.Duration, stack []byte, at time.Time) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var evicted string
+	query, ok := p.queries[fingerprint]
+	if !ok {
+		if len(p.queries) >= maxStatements {
+			evicted = p.evict()
+		}
+		query = &SlowQuery{Fingerprint: fingerprint}
+		p.queries[fingerprint] = query
+	}
+
+	query.Count++
+	query.Total += duration
+	query.LastSeen = at
+	if duration >= query.Max {
+		query.Max = duration
+		query.Example = stmt
+	}
+
+	if len(stack) > 0 {
+		query.Stacks = append(query.Stacks, SampleStack{
+			Duration: duration,
+			Stack:    string(stack),
+		})
+		sort.SliceStable(query.Stacks, func(i, j int) bool {
+			return query.Stacks[i].Duration > query.Stacks[j].Duration
+		})
+		if len(query.Stacks) > maxSampleStacks {
+			query.Stacks = query.Stacks[:maxSampleStacks]
+		}
+	}
+	return evicted
+}
+
+// evict removes the fingerprint with the least total time. The lock must
+// be held by the caller.
+func (p *Profile) evict() string {
+	var victim *SlowQuery
+	for _, query := range p.queries {
+		if victim == nil || query.Total < victim.Total ||
+			(query.Total == victim.Total && query.LastSeen.Before(victim.LastSeen)) {
+			victim = query
+		}
+	}
+	if victim == nil {
+		return ""
+	}
+	delete(p.queries, victim.Fingerprint)
+	return victim.Fingerprint
+}
+
+// Top returns up to n slow queries, ordered by the total time spent
+// running them. If n is less than or equal to zero, all are returned.
+func (p *Profile) Top(n int) []SlowQuery {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	queries := make([]SlowQuery, 0, len(p.queries))
+	for _, query := range p.queries {
+		q := *query
+		q.Stacks = append([]SampleStack(nil), query.Stacks...)
+		queries = append(queries, q)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		if queries[i].Total != queries[j].Total {
+			return queries[i].Total > queries[j].Total
+		}
+		return queries[i].Fingerprint < queries[j].Fingerprint
+	})
+	if n > 0 && len(queries) > n {
+		queries = queries[:n]
+	}
+	return queries
+}
+
+// SlowQueryReport returns a human readable report of the top n slow
+// queries, optionally including the sample stack traces.
+func (p *Profile) SlowQueryReport(n int, stacks bool) string {
+	queries := p.Top(n)
+	if len(queries) == 0 {
+		return "No slow queries recorded.\n"
+	}
+
+	var b strings.Builder
+	for i, query := range queries {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[%d] %s\n", i+1, query.Fingerprint)
+		fmt.Fprintf(&b, "  count: %d, total: %0.3fs, mean: %0.3fs, max: %0.3fs\n",
+			query.Count, query.Total.Seconds(), query.Mean().Seconds(), query.Max.Seconds())
+		fmt.Fprintf(&b, "  last seen: %s\n", query.LastSeen.UTC().Format(time.RFC3339))
+		fmt.Fprintf(&b, "  slowest: %s\n", query.Example)
+		if !stacks {
+			continue
+		}
+		for _, sample := range query.Stacks {
+			fmt.Fprintf(&b, "  stack (%0.3fs):\n", sample.Duration.Seconds())
+			for _, line := range strings.Split(strings.TrimRight(sample.Stack, "\n"), "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package querylogger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type profileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&profileSuite{})
+
+func (s *profileSuite) TestRecordAggregates(c *gc.C) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	p := NewProfile()
+	p.Record("select ?", "SELECT 1", time.Second, []byte("one"), now)
+	p.Record("select ?", "SELECT 2", 3*time.Second, []byte("two"), now.Add(time.Minute))
+	p.Record("select ?", "SELECT 3", 2*time.Second, nil, now.Add(2*time.Minute))
+
+	top := p.Top(0)
+	c.Assert(top, gc.HasLen, 1)
+	c.Check(top[0], jc.DeepEquals, SlowQuery{
+		Fingerprint: "select ?",
+		Example:     "SELECT 2",
+		Count:       3,
+		Total:       6 * time.Second,
+		Max:         3 * time.Second,
+		LastSeen:    now.Add(2 * time.Minute),
+		Stacks: []SampleStack{
+			{Duration: 3 * time.Second, Stack: "two"},
+			{Duration: time.Second, Stack: "one"},
+		},
+	})
+	c.Check(top[0].Mean(), gc.Equals, 2*time.Second)
+}
+
+func (s *profileSuite) TestRecordKeepsSlowestStacks(c *gc.C) {
+	p := NewProfile()
+	for i := 1; i <= 5; i++ {
+		p.Record("select ?", "SELECT ?", time.Duration(i)*time.Second, []byte(fmt.Sprint(i)), time.Now())
+	}
+
+	top := p.Top(1)
+	c.Assert(top, gc.HasLen, 1)
+	c.Check(top[0].Stacks, jc.DeepEquals, []SampleStack{
+		{Duration: 5 * time.Second, Stack: "5"},
+		{Duration: 4 * time.Second, Stack: "4"},
+		{Duration: 3 * time.Second, Stack: "3"},
+	})
+}
+
+func (s *profileSuite) TestTopOrdersByTotal(c *gc.C) {
+	p := NewProfile()
+	p.Record("a", "a", time.Second, nil, time.Now())
+	p.Record("b", "b", 3*time.Second, nil, time.Now())
+	p.Record("c", "c", 2*time.Second, nil, time.Now())
+	p.Record("a", "a", 3*time.Second, nil, time.Now())
+
+	var fingerprints []string
+	for _, q := range p.Top(2) {
+		fingerprints = append(fingerprints, q.Fingerprint)
+	}
+	c.Check(fingerprints, jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *profileSuite) TestRecordEvictsLeastTotal(c *gc.C) {
+	p := NewProfile()
+	for i := 0; i < maxStatements; i++ {
+		evicted := p.Record(fmt.Sprintf("select %d", i), "", time.Duration(i+1)*time.Second, nil, time.Now())
+		c.Assert(evicted, gc.Equals, "")
+	}
+
+	evicted := p.Record("select new", "", time.Second, nil, time.Now())
+	c.Check(evicted, gc.Equals, "select 0")
+	c.Check(p.Top(0), gc.HasLen, maxStatements)
+}
+
+func (s *profileSuite) TestSlowQueryReport(c *gc.C) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	p := NewProfile()
+	c.Check(p.SlowQueryReport(10, true), gc.Equals, "No slow queries recorded.\n")
+
+	p.Record("select * from foo where id = ?", "SELECT * FROM foo WHERE id = 1", 1500*time.Millisecond, []byte("goroutine 1\nmain.main()\n"), now)
+	p.Record("select * from bar", "SELECT * FROM bar", 500*time.Millisecond, []byte("goroutine 2\n"), now)
+
+	c.Check(p.SlowQueryReport(1, false), gc.Equals, `
+[1] select * from foo where id = ?
+  count: 1, total: 1.500s, mean: 1.500s, max: 1.500s
+  last seen: 2024-01-02T03:04:05Z
+  slowest: SELECT * FROM foo WHERE id = 1
+`[1:])
+
+	c.Check(p.SlowQueryReport(0, true), gc.Equals, `
+[1] select * from foo where id = ?
+  count: 1, total: 1.500s, mean: 1.500s, max: 1.500s
+  last seen: 2024-01-02T03:04:05Z
+  slowest: SELECT * FROM foo WHERE id = 1
+  stack (1.500s):
+    goroutine 1
+    main.main()
+
+[2] select * from bar
+  count: 1, total: 0.500s, mean: 0.500s, max: 0.500s
+  last seen: 2024-01-02T03:04:05Z
+  slowest: SELECT * FROM bar
+  stack (0.500s):
+    goroutine 2
+`[1:])
+}
@@ -23,10 +23,12 @@ const (
 // WorkerConfig encapsulates the configuration options for the
 // dbaccessor worker.
 type WorkerConfig struct {
-	LogDir        string
-	Clock         clock.Clock
-	Logger        Logger
-	StackGatherer func() []byte
+	LogDir           string
+	Clock            clock.Clock
+	Logger           Logger
+	StackGatherer    func() []byte
+	Profile          *Profile
+	MetricsCollector *Collector
 }
 
 // Validate ensures that the config values are valid.
@@ -43,6 +45,12 @@ func (c *WorkerConfig) Validate() error {
 	if c.StackGatherer == nil {
 		return errors.NotValidf("missing StackGatherer")
 	}
+	if c.Profile == nil {
+		return errors.NotValidf("missing Profile")
+	}
+	if c.MetricsCollector == nil {
+		return errors.NotValidf("missing MetricsCollector")
+	}
 	return nil
 }
 
@@ -51,9 +59,11 @@ func (c *WorkerConfig) Validate() error {
 type loggerWorker struct {
 	tomb tomb.Tomb
 
-	clock         clock.Clock
-	logger        Logger
-	stackGatherer func() []byte
+	clock            clock.Clock
+	logger           Logger
+	stackGatherer    func() []byte
+	profile          *Profile
+	metricsCollector *Collector
 
 	logDir string
 	logs   chan payload
@@ -68,10 +78,12 @@ func newWorker(cfg *WorkerConfig) (*loggerWorker, error) {
 	}
 
 	l := &loggerWorker{
-		logDir:        cfg.LogDir,
-		clock:         cfg.Clock,
-		logger:        cfg.Logger,
-		stackGatherer: cfg.StackGatherer,
+		logDir:           cfg.LogDir,
+		clock:            cfg.Clock,
+		logger:           cfg.Logger,
+		stackGatherer:    cfg.StackGatherer,
+		profile:          cfg.Profile,
+		metricsCollector: cfg.MetricsCollector,
 
 		logs: make(chan payload),
 	}
@@ -85,6 +97,15 @@ func (l *loggerWorker) RecordSlowQuery(msg, stmt string, args []any, duration fl
 	// TODO (stickupkid): Prune the stack to remove the first few frames.
 	stack := l.stackGatherer()
 
+	// Aggregate the query by its fingerprint, so that the statements
+	// hitting the database the hardest can be reported on.
+	fingerprint := Fingerprint(stmt)
+	evicted := l.profile.Record(fingerprint, stmt, time.Duration(duration*float64(time.Second)), stack, l.clock.Now())
+	if evicted != "" {
+		l.metricsCollector.SlowQueryDuration.DeleteLabelValues(evicted)
+	}
+	l.metricsCollector.SlowQueryDuration.WithLabelValues(fingerprint).Observe(duration)
+
 	done := make(chan error)
 	select {
 	case l.logs <- payload{
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v3/workertest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/mock/gomock"
 	gc "gopkg.in/check.v1"
 )
@@ -101,6 +102,43 @@ dummy stack
 	workertest.CleanKill(c, w)
 }
 
+func (s *loggerSuite) TestLoggerAggregatesByFingerprint(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	dir := c.MkDir()
+
+	ch := make(chan time.Time)
+	s.timer.EXPECT().Chan().Return(ch).AnyTimes()
+	s.logger.EXPECT().Warningf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	profile := NewProfile()
+	collector := NewMetricsCollector()
+	w := s.newWorkerWithProfile(c, dir, profile, collector)
+	defer workertest.DirtyKill(c, w)
+
+	w.RecordSlowQuery("hello", "SELECT * FROM foo WHERE id = 1", nil, 0.5)
+	w.RecordSlowQuery("hello", "SELECT * FROM foo WHERE id = 2", nil, 1.5)
+	w.RecordSlowQuery("hello", "SELECT * FROM bar", nil, 0.1)
+
+	select {
+	case ch <- time.Now():
+	case <-time.After(testing.ShortWait):
+		c.Fatal("timed out waiting for log to be written")
+	}
+
+	top := profile.Top(0)
+	c.Assert(top, gc.HasLen, 2)
+	c.Check(top[0].Fingerprint, gc.Equals, "select * from foo where id = ?")
+	c.Check(top[0].Count, gc.Equals, 2)
+	c.Check(top[0].Total, gc.Equals, 2*time.Second)
+	c.Check(top[0].Example, gc.Equals, "SELECT * FROM foo WHERE id = 2")
+	c.Check(top[1].Fingerprint, gc.Equals, "select * from bar")
+
+	c.Check(testutil.CollectAndCount(collector, "juju_db_slow_query_duration_seconds"), gc.Equals, 2)
+
+	workertest.CleanKill(c, w)
+}
+
 func (s *loggerSuite) expectLogResult(c *gc.C, dir string, match string) {
 	data, err := os.ReadFile(filepath.Join(dir, filename))
 	c.Assert(err, jc.ErrorIsNil)
@@ -116,6 +154,7 @@ func (s *loggerSuite) setupMocks(c *gc.C) *gomock.Controller {
 
 	s.clock = NewMockClock(ctrl)
 	s.clock.EXPECT().NewTimer(PollInterval).Return(s.timer)
+	s.clock.EXPECT().Now().Return(time.Now()).AnyTimes()
 
 	s.logger = NewMockLogger(ctrl)
 
@@ -123,6 +162,10 @@ func (s *loggerSuite) setupMocks(c *gc.C) *gomock.Controller {
 }
 
 func (s *loggerSuite) newWorker(c *gc.C, dir string) *loggerWorker {
+	return s.newWorkerWithProfile(c, dir, NewProfile(), NewMetricsCollector())
+}
+
+func (s *loggerSuite) newWorkerWithProfile(c *gc.C, dir string, profile *Profile, collector *Collector) *loggerWorker {
 	w, err := newWorker(&WorkerConfig{
 		LogDir: dir,
 		Clock:  s.clock,
@@ -130,6 +173,8 @@ func (s *loggerSuite) newWorker(c *gc.C, dir string) *loggerWorker {
 		StackGatherer: func() []byte {
 			return []byte("dummy stack")
 		},
+		Profile:          profile,
+		MetricsCollector: collector,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -4,6 +4,7 @@
 package upgradedatabase
 
 import (
+	stdcontext "context"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,6 +14,8 @@ import (
 	"github.com/juju/worker/v3/dependency"
 
 	"github.com/juju/juju/agent"
+	coredatabase "github.com/juju/juju/core/database"
+	"github.com/juju/juju/database"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/upgrades"
 	"github.com/juju/juju/worker/gate"
@@ -22,6 +25,7 @@ import (
 type ManifoldConfig struct {
 	AgentName         string
 	UpgradeDBGateName string
+	DBAccessorName    string
 	Logger            Logger
 	OpenState         func() (*state.StatePool, error)
 	Clock             Clock
@@ -32,6 +36,9 @@ func (cfg ManifoldConfig) Validate() error {
 	if cfg.UpgradeDBGateName == "" {
 		return errors.NotValidf("empty UpgradeDBGateName")
 	}
+	if cfg.DBAccessorName == "" {
+		return errors.NotValidf("empty DBAccessorName")
+	}
 	if cfg.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
@@ -51,6 +58,7 @@ func Manifold(cfg ManifoldConfig) dependency.Manifold {
 		Inputs: []string{
 			cfg.AgentName,
 			cfg.UpgradeDBGateName,
+			cfg.DBAccessorName,
 		},
 		Start: func(context dependency.Context) (worker.Worker, error) {
 			// Get the completed lock.
@@ -66,6 +74,21 @@ func Manifold(cfg ManifoldConfig) dependency.Manifold {
 			}
 			tag := controllerAgent.CurrentConfig().Tag()
 
+			var dbGetter coredatabase.DBGetter
+			if err := context.Get(cfg.DBAccessorName, &dbGetter); err != nil {
+				return nil, errors.Trace(err)
+			}
+
+			// Apply any pending controller schema patches, each inside
+			// its own transaction.
+			upgradeSchema := func(ctx stdcontext.Context) error {
+				db, err := dbGetter.GetDB(coredatabase.ControllerNS)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				return errors.Trace(database.NewControllerSchemaMigration(db, cfg.Logger).Apply(ctx))
+			}
+
 			// Wrap the state pool factory to return our implementation.
 			openState := func() (Pool, error) {
 				p, err := cfg.OpenState()
@@ -85,6 +108,7 @@ func Manifold(cfg ManifoldConfig) dependency.Manifold {
 				Tag:             tag,
 				Agent:           controllerAgent,
 				Logger:          cfg.Logger,
+				UpgradeSchema:   upgradeSchema,
 				OpenState:       openState,
 				PerformUpgrade:  performUpgrade,
 				RetryStrategy:   retry.CallArgs{Clock: cfg.Clock, Delay: 2 * time.Minute, Attempts: 5},
@@ -30,6 +30,10 @@ func (s *manifoldSuite) TestValidateConfig(c *gc.C) {
 	cfg.UpgradeDBGateName = ""
 	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
 
+	cfg = s.getConfig()
+	cfg.DBAccessorName = ""
+	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
+
 	cfg = s.getConfig()
 	cfg.Logger = nil
 	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
@@ -47,6 +51,7 @@ func (s *manifoldSuite) getConfig() upgradedatabase.ManifoldConfig {
 	return upgradedatabase.ManifoldConfig{
 		AgentName:         "agent-name",
 		UpgradeDBGateName: "upgrade-database-lock",
+		DBAccessorName:    "db-accessor",
 		Logger:            s.logger,
 		OpenState:         func() (*state.StatePool, error) { return nil, nil },
 		Clock:             clock.WallClock,
@@ -18,6 +18,7 @@ import (
 type Logger interface {
 	Debugf(message string, args ...interface{})
 	Infof(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
 	Errorf(message string, args ...interface{})
 }
 
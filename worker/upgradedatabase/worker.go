@@ -4,6 +4,7 @@
 package upgradedatabase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -57,6 +58,11 @@ type Config struct {
 	// Logger is the logger for this worker.
 	Logger Logger
 
+	// UpgradeSchema is a function pointer for applying any pending patches
+	// to the controller database schema. It must return an error if the
+	// schema is newer than this version of the agent knows about.
+	UpgradeSchema func(context.Context) error
+
 	// Open state is a function pointer for returning a state pool indirection.
 	OpenState func() (Pool, error)
 
@@ -94,6 +100,9 @@ func (cfg Config) Validate() error {
 	if cfg.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if cfg.UpgradeSchema == nil {
+		return errors.NotValidf("nil UpgradeSchema function")
+	}
 	if cfg.OpenState == nil {
 		return errors.NotValidf("nil OpenState function")
 	}
@@ -126,6 +135,7 @@ type upgradeDB struct {
 	agent          agent.Agent
 	logger         Logger
 	pool           Pool
+	upgradeSchema  func(context.Context) error
 	performUpgrade func(version.Number, []upgrades.Target, func() upgrades.Context) error
 	upgradeInfo    UpgradeInfo
 	retryStrategy  retry.CallArgs
@@ -149,6 +159,7 @@ func NewWorker(cfg Config) (worker.Worker, error) {
 		tag:             cfg.Tag,
 		agent:           cfg.Agent,
 		logger:          cfg.Logger,
+		upgradeSchema:   cfg.UpgradeSchema,
 		performUpgrade:  cfg.PerformUpgrade,
 		retryStrategy:   cfg.RetryStrategy,
 		clock:           cfg.Clock,
@@ -168,6 +179,14 @@ func (w *upgradeDB) run() error {
 		}
 	}()
 
+	// The controller schema patches are applied on every start, regardless
+	// of whether there are upgrade steps to run. Each controller may attempt
+	// this; patches already applied by another controller are skipped.
+	// If the schema is newer than we know about, we must not continue.
+	if err := w.upgradeSchema(w.tomb.Context(context.Background())); err != nil {
+		return errors.Annotate(err, "upgrading controller database schema")
+	}
+
 	if w.upgradeDone() {
 		return nil
 	}
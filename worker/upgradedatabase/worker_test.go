@@ -4,6 +4,7 @@
 package upgradedatabase_test
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/database"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
@@ -51,11 +53,13 @@ type baseSuite struct {
 func (s *baseSuite) ignoreLogging(c *gc.C) {
 	debugIt := func(message string, args ...interface{}) { logIt(c, loggo.DEBUG, message, args) }
 	infoIt := func(message string, args ...interface{}) { logIt(c, loggo.INFO, message, args) }
+	warningIt := func(message string, args ...interface{}) { logIt(c, loggo.WARNING, message, args) }
 	errorIt := func(message string, args ...interface{}) { logIt(c, loggo.ERROR, message, args) }
 
 	e := s.logger.EXPECT()
 	e.Debugf(gomock.Any(), gomock.Any()).AnyTimes().Do(debugIt)
 	e.Infof(gomock.Any(), gomock.Any()).AnyTimes().Do(infoIt)
+	e.Warningf(gomock.Any(), gomock.Any()).AnyTimes().Do(warningIt)
 	e.Errorf(gomock.Any(), gomock.Any()).AnyTimes().Do(errorIt)
 }
 
@@ -109,6 +113,10 @@ func (s *workerSuite) TestValidateConfig(c *gc.C) {
 	cfg.Logger = nil
 	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
 
+	cfg = s.getConfig()
+	cfg.UpgradeSchema = nil
+	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
+
 	cfg = s.getConfig()
 	cfg.OpenState = nil
 	c.Check(cfg.Validate(), jc.Satisfies, errors.IsNotValid)
@@ -169,6 +177,49 @@ func (s *workerSuite) TestAlreadyUpgradedNoWork(c *gc.C) {
 	workertest.CleanKill(c, w)
 }
 
+func (s *workerSuite) TestUpgradeSchemaAlwaysRuns(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.ignoreLogging(c)
+
+	s.lock.EXPECT().IsUnlocked().Return(true)
+
+	called := make(chan struct{})
+	cfg := s.getConfig()
+	cfg.UpgradeSchema = func(context.Context) error {
+		close(called)
+		return nil
+	}
+
+	w, err := upgradedatabase.NewWorker(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-called:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for schema upgrade")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestUpgradeSchemaNewerRefusesToStart(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.ignoreLogging(c)
+
+	// Nothing else is done if the schema can't be upgraded;
+	// in particular the upgrade complete lock is not unlocked.
+	cfg := s.getConfig()
+	cfg.UpgradeSchema = func(context.Context) error {
+		return errors.Annotate(database.ErrSchemaNewer, "database at version 9, latest known version 4")
+	}
+
+	w, err := upgradedatabase.NewWorker(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, jc.ErrorIs, database.ErrSchemaNewer)
+	c.Assert(err, gc.ErrorMatches, "upgrading controller database schema: database at version 9, .*")
+}
+
 func (s *workerSuite) TestNotPrimaryWatchForCompletionSuccess(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.ignoreLogging(c)
@@ -587,6 +638,7 @@ func (s *workerSuite) getConfig() upgradedatabase.Config {
 		Tag:             names.NewMachineTag("0"),
 		Agent:           s.agent,
 		Logger:          s.logger,
+		UpgradeSchema:   func(context.Context) error { return nil },
 		OpenState:       func() (upgradedatabase.Pool, error) { return s.pool, nil },
 		PerformUpgrade:  func(version.Number, []upgrades.Target, func() upgrades.Context) error { return nil },
 		RetryStrategy:   retry.CallArgs{Clock: clock.WallClock, Delay: time.Millisecond, Attempts: 3},